@@ -1,9 +1,13 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/holydocs/holydocs/internal/adapters"
 	"github.com/holydocs/holydocs/internal/adapters/primary/cli"
@@ -11,12 +15,15 @@ import (
 	"github.com/holydocs/holydocs/internal/core"
 	do "github.com/samber/do/v2"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 const (
 	appName        = "holydocs"
 	appDescription = "generate system-architecture documentation"
 	appLongDesc    = `HolyDOCs is a tool for generating docs from AsyncAPI, ServiceFile, etc.`
+
+	defaultConfigFile = "holydocs.yaml"
 )
 
 var (
@@ -24,13 +31,6 @@ var (
 )
 
 func main() {
-	if err := run(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
-	}
-}
-
-func run() error {
 	injector := do.New(
 		core.Package,
 		adapters.PrimaryPackage,
@@ -38,20 +38,32 @@ func run() error {
 		config.Package,
 	)
 
-	rootCmd := buildRootCommand(injector)
-
-	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, _ []string) error {
-		configFile, err := cmd.Flags().GetString("config")
-		if err != nil {
-			return fmt.Errorf("getting config file flag: %w", err)
-		}
+	if err := run(injector); err != nil {
+		do.MustInvoke[*cli.Reporter](injector).Error(err)
+		os.Exit(1)
+	}
+}
 
-		do.ProvideValue(injector, config.ConfigFilePath(configFile))
+func run(injector do.Injector) error {
+	// Commands load the configuration when they run or complete flag values. Completion doesn't run the
+	// persistent pre-run of the root command, so the path is read from the arguments up front.
+	do.ProvideValue(injector, config.ConfigFilePath(configFileFromArgs(os.Args[1:])))
 
-		return nil
+	// Errors parsing the command line are reported before the root command configures the reporter.
+	if outputFormatFromArgs(os.Args[1:]) == string(cli.OutputFormatJSON) {
+		_ = do.MustInvoke[*cli.Reporter](injector).Configure(cli.OutputFormatJSON, false)
 	}
 
-	if err := rootCmd.Execute(); err != nil {
+	rootCmd := buildRootCommand(injector)
+
+	// Interrupts and CI timeouts cancel the context, so commands stop promptly and clean up after themselves.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// A second interrupt terminates right away.
+	context.AfterFunc(ctx, stop)
+
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
 		return fmt.Errorf("%w: %w", ErrCommandExecution, err)
 	}
 
@@ -59,16 +71,102 @@ func run() error {
 }
 
 func buildRootCommand(injector do.Injector) *cobra.Command {
+	reporter := do.MustInvoke[*cli.Reporter](injector)
+
 	rootCmd := &cobra.Command{
 		Use:   appName,
 		Short: appDescription,
 		Long:  appLongDesc,
+		// Errors are reported by main in the output format, the usage would break JSON lines on stderr.
+		SilenceErrors: true,
+		SilenceUsage:  reporter.JSON(),
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			format, _ := cmd.Flags().GetString("output-format")
+			quiet, _ := cmd.Flags().GetBool("quiet")
+
+			return reporter.Configure(cli.OutputFormat(format), quiet)
+		},
 	}
 
-	rootCmd.PersistentFlags().StringP("config", "c", "holydocs.yaml", "Path to YAML configuration file")
+	rootCmd.PersistentFlags().StringP("config", "c", defaultConfigFile, "Path to YAML configuration file")
+	rootCmd.PersistentFlags().String("output-format", string(cli.OutputFormatText),
+		"Format of errors, warnings and findings: text or json (JSON objects on stderr, one per line)")
+	rootCmd.PersistentFlags().BoolP("quiet", "q", false, "Leave out progress, print warnings, errors and summaries only")
 
 	cliCommand := do.MustInvoke[*cli.Command](injector)
 	rootCmd.AddCommand(cliCommand.GetCommand())
 
+	diagramCommand := do.MustInvoke[*cli.DiagramCommand](injector)
+	rootCmd.AddCommand(diagramCommand.GetCommand())
+
+	diffCommand := do.MustInvoke[*cli.DiffCommand](injector)
+	rootCmd.AddCommand(diffCommand.GetCommand())
+
+	schemaCommand := do.MustInvoke[*cli.SchemaCommand](injector)
+	rootCmd.AddCommand(schemaCommand.GetCommand())
+
+	changelogCommand := do.MustInvoke[*cli.ChangelogCommand](injector)
+	rootCmd.AddCommand(changelogCommand.GetCommand())
+
+	releaseCommand := do.MustInvoke[*cli.ReleaseCommand](injector)
+	rootCmd.AddCommand(releaseCommand.GetCommand())
+
+	ingestCommand := do.MustInvoke[*cli.IngestCommand](injector)
+	rootCmd.AddCommand(ingestCommand.GetCommand())
+
+	exportCommand := do.MustInvoke[*cli.ExportCommand](injector)
+	rootCmd.AddCommand(exportCommand.GetCommand())
+
+	examplesCommand := do.MustInvoke[*cli.ExamplesCommand](injector)
+	rootCmd.AddCommand(examplesCommand.GetCommand())
+
+	cacheCommand := do.MustInvoke[*cli.CacheCommand](injector)
+	rootCmd.AddCommand(cacheCommand.GetCommand())
+
+	publishCommand := do.MustInvoke[*cli.PublishCommand](injector)
+	rootCmd.AddCommand(publishCommand.GetCommand())
+
+	previewCommand := do.MustInvoke[*cli.PreviewCommand](injector)
+	rootCmd.AddCommand(previewCommand.GetCommand())
+
+	validateCommand := do.MustInvoke[*cli.ValidateCommand](injector)
+	rootCmd.AddCommand(validateCommand.GetCommand())
+
+	probeCommand := do.MustInvoke[*cli.ProbeCommand](injector)
+	rootCmd.AddCommand(probeCommand.GetCommand())
+
+	snippetCommand := do.MustInvoke[*cli.SnippetCommand](injector)
+	rootCmd.AddCommand(snippetCommand.GetCommand())
+
+	mergeCommand := do.MustInvoke[*cli.MergeCommand](injector)
+	rootCmd.AddCommand(mergeCommand.GetCommand())
+
+	gateCommand := do.MustInvoke[*cli.GateCommand](injector)
+	rootCmd.AddCommand(gateCommand.GetCommand())
+
 	return rootCmd
 }
+
+// configFileFromArgs returns the value of the config flag, ignoring all other flags and arguments.
+func configFileFromArgs(args []string) string {
+	flags := pflag.NewFlagSet(appName, pflag.ContinueOnError)
+	flags.ParseErrorsWhitelist.UnknownFlags = true
+	flags.SetOutput(io.Discard)
+
+	configFile := flags.StringP("config", "c", defaultConfigFile, "")
+	_ = flags.Parse(args)
+
+	return *configFile
+}
+
+// outputFormatFromArgs returns the value of the output-format flag, ignoring all other flags and arguments.
+func outputFormatFromArgs(args []string) string {
+	flags := pflag.NewFlagSet(appName, pflag.ContinueOnError)
+	flags.ParseErrorsWhitelist.UnknownFlags = true
+	flags.SetOutput(io.Discard)
+
+	outputFormat := flags.String("output-format", string(cli.OutputFormatText), "")
+	_ = flags.Parse(args)
+
+	return *outputFormat
+}
@@ -0,0 +1,73 @@
+// Package examples publishes end-to-end holydocs.yaml samples for common setups.
+// The sample files are embedded into the binary and printed by the examples command.
+package examples
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Example names.
+const (
+	Minimal      = "minimal"
+	Monorepo     = "monorepo"
+	Ingest       = "ingest"
+	EventCatalog = "event-catalog"
+)
+
+// Errors.
+var (
+	ErrUnknownExample = errors.New("unknown example")
+)
+
+//go:embed *.holydocs.yaml
+var files embed.FS
+
+// Example describes a published configuration sample.
+type Example struct {
+	Name        string
+	Description string
+}
+
+// List returns all published examples in the order they are presented.
+func List() []Example {
+	return []Example{
+		{Name: Minimal, Description: "All specifications in one directory, single page documentation"},
+		{Name: Monorepo, Description: "Specifications next to the services, multi-page documentation with custom markdown"},
+		{Name: Ingest, Description: "Specifications pushed by deploy pipelines of many repositories"},
+		{Name: EventCatalog, Description: "AsyncAPI-driven event catalog with example payloads and AsyncAPI export"},
+	}
+}
+
+// Names returns the names of all published examples.
+func Names() []string {
+	list := List()
+
+	names := make([]string, 0, len(list))
+	for _, example := range list {
+		names = append(names, example.Name)
+	}
+
+	return names
+}
+
+// FileName returns the name of the embedded file holding the example.
+func FileName(name string) string {
+	return name + ".holydocs.yaml"
+}
+
+// Get returns the embedded configuration sample by name.
+func Get(name string) ([]byte, error) {
+	if !slices.Contains(Names(), name) {
+		return nil, fmt.Errorf("%w: %s (expected one of %v)", ErrUnknownExample, name, Names())
+	}
+
+	data, err := files.ReadFile(FileName(name))
+	if err != nil {
+		return nil, fmt.Errorf("reading example %s: %w", name, err)
+	}
+
+	return data, nil
+}
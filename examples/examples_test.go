@@ -0,0 +1,39 @@
+package examples
+
+import (
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/config"
+	do "github.com/samber/do/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestExamplesLoad ensures every published example is a valid configuration.
+func TestExamplesLoad(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range Names() {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := Get(name)
+			require.NoError(t, err)
+			assert.NotEmpty(t, data)
+
+			injector := do.New()
+			do.ProvideValue(injector, config.ConfigFilePath(FileName(name)))
+
+			cfg, err := config.LoadConfig(injector)
+			require.NoError(t, err)
+			assert.NotEmpty(t, cfg.Output.Title)
+		})
+	}
+}
+
+func TestGet_UnknownExample(t *testing.T) {
+	t.Parallel()
+
+	_, err := Get("kubernetes")
+	require.ErrorIs(t, err, ErrUnknownExample)
+}
@@ -2,20 +2,68 @@ package adapters
 
 import (
 	"github.com/holydocs/holydocs/internal/adapters/primary/cli"
+	"github.com/holydocs/holydocs/internal/adapters/secondary/asyncapi"
+	"github.com/holydocs/holydocs/internal/adapters/secondary/cache"
+	"github.com/holydocs/holydocs/internal/adapters/secondary/dependencies"
 	docsgen "github.com/holydocs/holydocs/internal/adapters/secondary/docs"
+	"github.com/holydocs/holydocs/internal/adapters/secondary/lineage"
+	"github.com/holydocs/holydocs/internal/adapters/secondary/notify"
+	"github.com/holydocs/holydocs/internal/adapters/secondary/oncall"
+	"github.com/holydocs/holydocs/internal/adapters/secondary/plugin"
+	"github.com/holydocs/holydocs/internal/adapters/secondary/preview"
+	"github.com/holydocs/holydocs/internal/adapters/secondary/probe"
+	"github.com/holydocs/holydocs/internal/adapters/secondary/prometheus"
+	"github.com/holydocs/holydocs/internal/adapters/secondary/remote"
 	"github.com/holydocs/holydocs/internal/adapters/secondary/schema"
+	"github.com/holydocs/holydocs/internal/adapters/secondary/sources"
+	"github.com/holydocs/holydocs/internal/adapters/secondary/statuspage"
 	"github.com/holydocs/holydocs/internal/adapters/secondary/target"
+	"github.com/holydocs/holydocs/internal/adapters/secondary/wasm"
+	"github.com/holydocs/holydocs/internal/adapters/secondary/wiki"
 	do "github.com/samber/do/v2"
 )
 
 //nolint:gochecknoglobals // Package variables are required for dependency injection setup
 var PrimaryPackage = do.Package(
+	do.Lazy[*cli.Reporter](cli.NewReporter),
 	do.Lazy[*cli.Command](cli.NewCommand),
+	do.Lazy[*cli.DiagramCommand](cli.NewDiagramCommand),
+	do.Lazy[*cli.DiffCommand](cli.NewDiffCommand),
+	do.Lazy[*cli.SchemaCommand](cli.NewSchemaCommand),
+	do.Lazy[*cli.ChangelogCommand](cli.NewChangelogCommand),
+	do.Lazy[*cli.ReleaseCommand](cli.NewReleaseCommand),
+	do.Lazy[*cli.IngestCommand](cli.NewIngestCommand),
+	do.Lazy[*cli.ExportCommand](cli.NewExportCommand),
+	do.Lazy[*cli.ExamplesCommand](cli.NewExamplesCommand),
+	do.Lazy[*cli.CacheCommand](cli.NewCacheCommand),
+	do.Lazy[*cli.PublishCommand](cli.NewPublishCommand),
+	do.Lazy[*cli.PreviewCommand](cli.NewPreviewCommand),
+	do.Lazy[*cli.ValidateCommand](cli.NewValidateCommand),
+	do.Lazy[*cli.ProbeCommand](cli.NewProbeCommand),
+	do.Lazy[*cli.SnippetCommand](cli.NewSnippetCommand),
+	do.Lazy[*cli.MergeCommand](cli.NewMergeCommand),
+	do.Lazy[*cli.GateCommand](cli.NewGateCommand),
 )
 
 //nolint:gochecknoglobals // Package variables are required for dependency injection setup
 var SecondaryPackage = do.Package(
 	do.Lazy[*schema.Loader](schema.NewLoader),
 	do.Lazy[*docsgen.Generator](docsgen.NewGenerator),
+	do.Lazy(docsgen.NewOutputFS),
 	do.Lazy(target.NewTargetProvider),
+	do.Lazy[*sources.Store](sources.NewStore),
+	do.Lazy[*asyncapi.Exporter](asyncapi.NewExporter),
+	do.Lazy[*dependencies.Exporter](dependencies.NewExporter),
+	do.Lazy[*lineage.Exporter](lineage.NewExporter),
+	do.Lazy[*remote.Fetcher](remote.NewFetcher),
+	do.Lazy[*cache.Store](cache.NewStore),
+	do.Lazy[*wiki.Publisher](wiki.NewPublisher),
+	do.Lazy[*preview.Uploader](preview.NewUploader),
+	do.Lazy[*plugin.Runner](plugin.NewRunner),
+	do.Lazy[*wasm.Rules](wasm.NewRules),
+	do.Lazy[*oncall.Directory](oncall.NewDirectory),
+	do.Lazy[*statuspage.Checker](statuspage.NewChecker),
+	do.Lazy[*probe.Prober](probe.NewProber),
+	do.Lazy[*prometheus.Client](prometheus.NewClient),
+	do.Lazy[*notify.Notifier](notify.NewNotifier),
 )
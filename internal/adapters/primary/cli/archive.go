@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/holydocs/holydocs/pkg/outputfs"
+)
+
+// checkArchive checks that the archive file has a supported format and isn't written into the output
+// directory, where it would be packed into itself.
+func checkArchive(file, outputDir string) error {
+	if _, err := outputfs.ArchiveFormatOf(file); err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrUnsupportedValue, err)
+	}
+
+	absFile, err := filepath.Abs(file)
+	if err != nil {
+		return fmt.Errorf("resolving archive path: %w", err)
+	}
+
+	absDir, err := filepath.Abs(outputDir)
+	if err != nil {
+		return fmt.Errorf("resolving output directory: %w", err)
+	}
+
+	if rel, err := filepath.Rel(absDir, absFile); err == nil && filepath.IsLocal(rel) {
+		return fmt.Errorf("%w: archive %s is inside the output directory %s", domain.ErrUnsupportedValue, file,
+			outputDir)
+	}
+
+	return nil
+}
+
+// writeArchive packs the documentation in outputDir into the archive file, its format follows from the
+// extension. A partially written archive is removed.
+func writeArchive(outputDir, file string) (err error) {
+	format, err := outputfs.ArchiveFormatOf(file)
+	if err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrUnsupportedValue, err)
+	}
+
+	if dir := filepath.Dir(file); dir != "." {
+		if err := os.MkdirAll(dir, dirPerm); err != nil {
+			return fmt.Errorf("creating archive directory %s: %w", dir, err)
+		}
+	}
+
+	archive, err := os.Create(file)
+	if err != nil {
+		return fmt.Errorf("creating archive %s: %w", file, err)
+	}
+
+	defer func() {
+		err = errors.Join(err, archive.Close())
+		if err != nil {
+			_ = os.Remove(file)
+		}
+	}()
+
+	return outputfs.WriteArchive(archive, outputfs.OS(), outputDir, format)
+}
@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckArchive(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, checkArchive("docs.zip", "docs"))
+	require.NoError(t, checkArchive(filepath.Join("dist", "docs.tar.gz"), "docs"))
+	require.ErrorIs(t, checkArchive("docs.rar", "docs"), domain.ErrUnsupportedValue)
+	require.ErrorIs(t, checkArchive(filepath.Join("docs", "docs.zip"), "docs"), domain.ErrUnsupportedValue)
+}
+
+func TestWriteArchive(t *testing.T) {
+	t.Parallel()
+
+	outputDir := filepath.Join(t.TempDir(), "docs")
+	require.NoError(t, os.MkdirAll(filepath.Join(outputDir, "diagrams"), dirPerm))
+	require.NoError(t, os.WriteFile(filepath.Join(outputDir, "README.md"), []byte("# Docs"), filePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(outputDir, "diagrams", "overview.svg"), []byte("<svg/>"), filePerm))
+
+	file := filepath.Join(t.TempDir(), "dist", "docs.zip")
+	require.NoError(t, writeArchive(outputDir, file))
+
+	archive, err := zip.OpenReader(file)
+	require.NoError(t, err)
+	defer archive.Close()
+
+	var names []string
+	for _, f := range archive.File {
+		names = append(names, f.Name)
+	}
+
+	assert.Equal(t, []string{"README.md", "diagrams/", "diagrams/overview.svg"}, names)
+
+	require.Error(t, writeArchive(filepath.Join(t.TempDir(), "missing"), file))
+	assert.NoFileExists(t, file, "a failed archive is removed")
+}
@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"fmt"
+
+	do "github.com/samber/do/v2"
+	"github.com/spf13/cobra"
+)
+
+// CacheCommand represents the cache command.
+type CacheCommand struct {
+	cmd *cobra.Command
+	commandDeps
+}
+
+func NewCacheCommand(i do.Injector) (*CacheCommand, error) {
+	c := &CacheCommand{
+		commandDeps: newCommandDeps(i),
+	}
+
+	c.cmd = &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the cache of parsed specifications",
+		Long: `Schemas parsed from AsyncAPI files are cached in cache.dir, keyed by the hash of the file content,
+so unchanged files aren't parsed again by later runs. Files referencing other documents are never cached.`,
+	}
+
+	c.cmd.AddCommand(&cobra.Command{
+		Use:   "clear",
+		Short: "Remove all cached schemas",
+		Args:  cobra.NoArgs,
+		RunE:  c.resolved(c.clear),
+	})
+
+	return c, nil
+}
+
+// GetCommand returns the cobra command.
+func (c *CacheCommand) GetCommand() *cobra.Command {
+	return c.cmd
+}
+
+func (c *CacheCommand) clear(cmd *cobra.Command, _ []string) error {
+	removed, err := c.app.ClearCache(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to clear cache: %w", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Removed %d cached schemas from %s\n", removed, c.config.Cache.Dir)
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	do "github.com/samber/do/v2"
+	"github.com/spf13/cobra"
+)
+
+// Errors.
+var (
+	ErrSquashRangeRequired = errors.New("provide exactly one of --before or --all")
+)
+
+// ChangelogCommand represents the changelog command.
+type ChangelogCommand struct {
+	cmd *cobra.Command
+	commandDeps
+
+	before string
+	all    bool
+}
+
+func NewChangelogCommand(i do.Injector) (*ChangelogCommand, error) {
+	c := &ChangelogCommand{
+		commandDeps: newCommandDeps(i),
+	}
+
+	c.cmd = &cobra.Command{
+		Use:   "changelog",
+		Short: "Maintain the changelog of generated documentation",
+	}
+
+	squashCmd := &cobra.Command{
+		Use:   "squash",
+		Short: "Collapse old changelog entries into a baseline entry",
+		Long: `Collapse old changelog entries stored in domain.json of the output directory into a single
+baseline entry, keeping the file size and the changelog section manageable for long-lived repositories.
+
+Run gen-docs afterwards to update the generated documentation.
+
+Examples:
+  # Squash everything recorded before 2024
+  holydocs changelog squash --before 2024-01-01
+
+  # Squash the whole history
+  holydocs changelog squash --all`,
+		RunE: c.resolved(c.squash),
+	}
+	squashCmd.Flags().StringVar(&c.before, "before", "", "Squash entries older than this date (YYYY-MM-DD)")
+	squashCmd.Flags().BoolVar(&c.all, "all", false, "Squash all entries")
+	c.cmd.AddCommand(squashCmd)
+
+	return c, nil
+}
+
+// GetCommand returns the cobra command.
+func (c *ChangelogCommand) GetCommand() *cobra.Command {
+	return c.cmd
+}
+
+func (c *ChangelogCommand) squash(cmd *cobra.Command, _ []string) error {
+	if (c.before == "") == !c.all {
+		return ErrSquashRangeRequired
+	}
+
+	var before time.Time
+	if c.before != "" {
+		parsed, err := time.Parse(time.DateOnly, c.before)
+		if err != nil {
+			return fmt.Errorf("invalid --before date %q, expected YYYY-MM-DD: %w", c.before, err)
+		}
+		before = parsed
+	}
+
+	reply, err := c.app.SquashChangelog(cmd.Context(), domain.SquashChangelogRequest{
+		OutputDir: c.config.Output.Dir,
+		Before:    before,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to squash changelog: %w", err)
+	}
+
+	if reply.Squashed == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "Nothing to squash")
+
+		return nil
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Squashed %d changelog entries into a baseline, %d entries remain\n",
+		reply.Squashed, reply.Remaining)
+
+	return nil
+}
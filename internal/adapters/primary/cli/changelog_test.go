@@ -0,0 +1,30 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangelogCommand_SquashRequiresRange(t *testing.T) {
+	t.Parallel()
+
+	for _, args := range [][]string{
+		{"squash"},
+		{"squash", "--all", "--before", "2024-01-01"},
+	} {
+		cmd, err := NewChangelogCommand(setupTestInjector())
+		require.NoError(t, err)
+
+		cobraCmd := cmd.GetCommand()
+		cobraCmd.SetArgs(args)
+		require.ErrorIs(t, cobraCmd.Execute(), ErrSquashRangeRequired)
+	}
+
+	cmd, err := NewChangelogCommand(setupTestInjector())
+	require.NoError(t, err)
+
+	cobraCmd := cmd.GetCommand()
+	cobraCmd.SetArgs([]string{"squash", "--before", "January"})
+	require.ErrorContains(t, cobraCmd.Execute(), "invalid --before date")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"sort"
@@ -31,18 +32,27 @@ var (
 
 // Command represents the gen-docs command.
 type Command struct {
-	cmd    *cobra.Command
-	app    *app.App
-	config *config.Config
+	cmd *cobra.Command
+	commandDeps
+	reporter *Reporter
+
+	strict      bool
+	keepGoing   bool
+	profile     string
+	profileFile string
+
+	highlight             []string
+	highlightTechnologies []string
+	namespaces            []string
+	noMetadata            bool
+	archive               string
+	attribution           string
 }
 
 func NewCommand(i do.Injector) (*Command, error) {
-	appInstance := do.MustInvoke[*app.App](i)
-	cfg := do.MustInvoke[*config.Config](i)
-
 	c := &Command{
-		app:    appInstance,
-		config: cfg,
+		commandDeps: newCommandDeps(i),
+		reporter:    do.MustInvoke[*Reporter](i),
 	}
 
 	c.cmd = &cobra.Command{
@@ -62,12 +72,91 @@ Output:
   - README.md with system overview
   - JSON metadata
 
+Strict mode:
+  With --strict the command fails when warnings are reported, e.g. for relationships with unknown
+  participants, documentation configured for unknown services or systems, unreadable markdown files,
+  message flow diagrams that failed to render or were left out although services declare operations,
+  repositories on hosts without link templates or generated files edited by hand since the last run.
+  Documentation is still written.
+
+Keep going:
+  With --keep-going a malformed specification file or a diagram that fails to render doesn't abort
+  the run. The failing files are left out, failed diagrams are replaced with placeholders and the
+  documentation starts with a list of the missing parts. The command still exits with an error.
+  While specification files are left out, the schema isn't stored and no changelog is recorded.
+
+Highlighting:
+  With --highlight and --highlight-technology the overview and system diagrams emphasize the given
+  services, systems or external participants and the relationships using the given technologies,
+  e.g. to track a migration. The flags replace diagram.highlight of the configuration.
+
+Namespaces:
+  With --namespace only the services of the given namespaces and the services without a namespace
+  are documented. The flag replaces input.namespaces of the configuration.
+
+Archive:
+  With --archive the documentation in the output directory, pages, diagrams and domain.json, is
+  also packed into a single .zip, .tar.gz or .tgz file, e.g. to attach it to a release or upload it
+  as a CI artifact. Paths in the archive are relative to the output directory.
+
+Attribution:
+  With --attribution git the changes recorded in the changelog mention the author and subject of
+  the last commit changing the specifications of their service, so the changelog tells why an
+  architecture changed, not only what changed.
+
+Profiling:
+  With --profile cpu or --profile mem the command writes a pprof profile of the run to
+  holydocs-<kind>.pprof or to the file given with --profile-file. Inspect it with go tool pprof.
+
 Examples:
   # Use configuration file
-  holydocs gen-docs --config ./holydocs.yaml`,
-		RunE: c.run,
+  holydocs gen-docs --config ./holydocs.yaml
+
+  # Fail the CI job on documentation warnings
+  holydocs gen-docs --strict
+
+  # Build a pull request preview without touching the changelog history
+  holydocs gen-docs --no-metadata
+
+  # Publish what can be generated when some specifications are broken
+  holydocs gen-docs --keep-going
+
+  # Show everything still talking to the old message broker
+  holydocs gen-docs --highlight-technology kafka
+
+  # Document the services of one business unit
+  holydocs gen-docs --namespace payments
+
+  # Pack the documentation into a single file for a release
+  holydocs gen-docs --archive docs.zip
+
+  # Find out where a slow run spends its time
+  holydocs gen-docs --profile cpu && go tool pprof -top holydocs-cpu.pprof`,
+		RunE: c.resolved(c.run),
 	}
 
+	c.cmd.Flags().BoolVar(&c.strict, "strict", false, "Fail when warnings are reported")
+	c.cmd.Flags().BoolVar(&c.keepGoing, "keep-going", false,
+		"Leave out failing specification files and diagrams instead of aborting")
+	c.cmd.Flags().StringVar(&c.profile, "profile", "", "Write a pprof profile of the run: cpu or mem")
+	c.cmd.Flags().StringVar(&c.profileFile, "profile-file", "",
+		"Profile output file (defaults to holydocs-<kind>.pprof)")
+	c.cmd.Flags().StringSliceVar(&c.highlight, "highlight", nil,
+		"Services, systems or external participants to highlight in diagrams")
+	c.cmd.Flags().StringSliceVar(&c.highlightTechnologies, "highlight-technology", nil,
+		"Relationship technologies to highlight in diagrams, e.g. kafka")
+	c.cmd.Flags().BoolVar(&c.noMetadata, "no-metadata", false,
+		"Don't record the schema and its changes in domain.json, e.g. for preview builds")
+	c.cmd.Flags().StringSliceVar(&c.namespaces, "namespace", nil,
+		"Namespaces of the services to document, services without a namespace are always documented")
+	c.cmd.Flags().StringVar(&c.archive, "archive", "",
+		"Also pack the documentation into an archive file: .zip, .tar.gz or .tgz")
+	c.cmd.Flags().StringVar(&c.attribution, "attribution", "",
+		"Add the last commit of the specifications of a service to its recorded changes: git")
+	_ = c.cmd.RegisterFlagCompletionFunc("highlight", focusNameCompletion(&c.commandDeps))
+	_ = c.cmd.RegisterFlagCompletionFunc("profile", profileCompletion)
+	_ = c.cmd.RegisterFlagCompletionFunc("attribution", attributionCompletion)
+
 	return c, nil
 }
 
@@ -76,19 +165,63 @@ func (c *Command) GetCommand() *cobra.Command {
 	return c.cmd
 }
 
-func (c *Command) run(_ *cobra.Command, _ []string) error {
+func (c *Command) run(cmd *cobra.Command, _ []string) (err error) {
+	stopProfile, err := startProfile(c.profile, c.profileFile)
+	if err != nil {
+		return fmt.Errorf("failed to start profiling: %w", err)
+	}
+
+	defer func() {
+		if stopErr := stopProfile(); stopErr != nil && err == nil {
+			err = fmt.Errorf("failed to write profile: %w", stopErr)
+		}
+	}()
+
+	if cmd.Flags().Changed("highlight") || cmd.Flags().Changed("highlight-technology") {
+		c.config.Diagram.Highlight = config.Highlight{
+			Services:     c.highlight,
+			Technologies: c.highlightTechnologies,
+		}
+	}
+
+	if c.noMetadata {
+		c.config.Output.Metadata = false
+	}
+
+	if cmd.Flags().Changed("namespace") {
+		c.config.Input.Namespaces = c.namespaces
+	}
+
+	if c.archive != "" {
+		if err := checkArchive(c.archive, c.config.Output.Dir); err != nil {
+			return err
+		}
+	}
+
+	if err := checkAttribution(c.attribution); err != nil {
+		return err
+	}
+
 	if err := c.prepareOutputDirectory(c.config.Output.Dir); err != nil {
 		return fmt.Errorf("failed to prepare output directory: %w", err)
 	}
 
-	ctx := context.Background()
+	ctx := cmd.Context()
 
-	if err := c.generateDocumentation(ctx, c.config); err != nil {
+	if err := c.generateDocumentation(ctx, c.config, false); err != nil {
 		return fmt.Errorf("failed to generate documentation: %w", err)
 	}
 
 	fmt.Printf("Documentation generated successfully in: %s\n", c.config.Output.Dir)
 
+	if c.archive != "" {
+		if err := writeArchive(c.config.Output.Dir, c.archive); err != nil {
+			return fmt.Errorf("failed to archive documentation: %w", err)
+		}
+
+		fmt.Printf("Documentation archived to: %s\n", c.archive)
+	}
+
 	return nil
 }
 
@@ -100,8 +233,19 @@ func (c *Command) prepareOutputDirectory(outputDir string) error {
 	return nil
 }
 
-func (c *Command) generateDocumentation(ctx context.Context, cfg *config.Config) error {
-	serviceFilesPaths, asyncAPIFilesPaths, err := c.getSpecFilesPaths(cfg)
+// generateDocumentation generates the documentation configured in cfg. A preview leaves out everything
+// written or sent outside of the output directory.
+func (c *Command) generateDocumentation(ctx context.Context, cfg *config.Config, preview bool) error {
+	// ingest and preview generate documentation without running gen-docs.
+	if err := c.resolve(); err != nil {
+		return err
+	}
+
+	if err := fetchSources(ctx, c.app, cfg, c.reporter, os.Stdout); err != nil {
+		return err
+	}
+
+	serviceFilesPaths, asyncAPIFilesPaths, err := specFilesWithSources(ctx, c.app, cfg, c.reporter, os.Stdout)
 	if err != nil {
 		return fmt.Errorf("getting spec files paths: %w", err)
 	}
@@ -110,40 +254,139 @@ func (c *Command) generateDocumentation(ctx context.Context, cfg *config.Config)
 		ServiceFilesPaths:  serviceFilesPaths,
 		AsyncAPIFilesPaths: asyncAPIFilesPaths,
 		OutputDir:          cfg.Output.Dir,
+		Strict:             c.strict,
+		KeepGoing:          c.keepGoing,
+		Attribution:        domain.ChangeAttribution(c.attribution),
+		Preview:            preview,
 	}
 
 	reply, err := c.app.GenerateDocumentation(ctx, req)
-	if err != nil {
+	if err != nil && !errors.Is(err, domain.ErrPartialGeneration) {
 		return fmt.Errorf("generating documentation: %w", err)
 	}
 
+	progress := c.reporter.Progress(os.Stdout)
+
 	if reply.Changelog != nil && len(reply.Changelog.Changes) > 0 {
-		fmt.Printf("\nNew Changes Detected:\n")
+		fmt.Fprintf(progress, "\nNew Changes Detected:\n")
 		for _, change := range reply.Changelog.Changes {
-			fmt.Printf("• %s %s: %s\n", change.Type, change.Category, change.Details)
+			fmt.Fprintf(progress, "• %s %s: %s\n", change.Type, change.Category, change.Details)
 			if change.Diff != "" {
-				fmt.Println(change.Diff)
+				fmt.Fprintln(progress, change.Diff)
 			}
 		}
 	}
 
+	c.reporter.Warnings(os.Stdout, codeDocumentationWarning, reply.Warnings)
+
+	if err != nil {
+		return fmt.Errorf("generating documentation: %w", err)
+	}
+
 	return nil
 }
 
 func (c *Command) getSpecFilesPaths(cfg *config.Config) ([]string, []string, error) {
+	return specFilesPaths(cfg, os.Stdout)
+}
+
+// specFilesPaths resolves spec files from the config, progress messages are written to out.
+func specFilesPaths(cfg *config.Config, out io.Writer) ([]string, []string, error) {
 	if len(cfg.Input.ServiceFiles) != 0 || len(cfg.Input.AsyncAPIFiles) != 0 {
 		return cfg.Input.ServiceFiles, cfg.Input.AsyncAPIFiles, nil
 	}
 
 	if cfg.Input.Dir != "" {
-		return specFilesFromDir(cfg.Input.Dir)
+		return specFilesFromDir(cfg.Input.Dir, out)
 	}
 
 	return nil, nil, ErrNoSpecFilesProvided
 }
 
-func specFilesFromDir(dir string) ([]string, []string, error) {
-	fmt.Println("Scanning directory for spec files:", dir)
+// fetchSources stores the specifications of the configured remote sources and source plugins as sources.
+// Only commands generating documentation fetch them, the other commands use the copies of the last run, so
+// they work offline. Progress and warnings are reported to out.
+func fetchSources(ctx context.Context, application *app.App, cfg *config.Config, reporter *Reporter,
+	out io.Writer) error {
+	progress := reporter.Progress(out)
+
+	if len(cfg.Input.Remote) > 0 {
+		fmt.Fprintln(progress, "Fetching remote sources:", len(cfg.Input.Remote))
+	}
+
+	warnings, err := application.FetchRemoteSources(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching remote sources: %w", err)
+	}
+
+	for _, warning := range warnings {
+		reporter.Warning(out, codeSourceWarning, warning)
+	}
+
+	warnings, err = application.FetchPluginSources(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching plugin sources: %w", err)
+	}
+
+	for _, warning := range warnings {
+		reporter.Warning(out, codeSourceWarning, warning)
+	}
+
+	return nil
+}
+
+// specFilesWithSources resolves spec files from the config and adds sources received by the ingest command or
+// fetched by the last documentation run. Progress and warnings are reported to out.
+func specFilesWithSources(ctx context.Context, application *app.App, cfg *config.Config, reporter *Reporter,
+	out io.Writer) ([]string, []string, error) {
+	progress := reporter.Progress(out)
+
+	// Expired sources are removed first, so an input directory containing them doesn't document them.
+	if _, err := application.PruneSources(ctx); err != nil {
+		return nil, nil, fmt.Errorf("pruning ingested sources: %w", err)
+	}
+
+	sources, err := application.ListSources(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("listing ingested sources: %w", err)
+	}
+
+	serviceFiles, asyncAPIFiles, err := specFilesPaths(cfg, progress)
+	if err != nil && (len(sources) == 0 || !errors.Is(err, ErrNoSpecFilesFound)) {
+		return nil, nil, err
+	}
+
+	if len(sources) == 0 {
+		return serviceFiles, asyncAPIFiles, nil
+	}
+
+	fmt.Fprintln(progress, "Found ingested sources:", len(sources))
+
+	for _, source := range sources {
+		switch source.Kind {
+		case domain.SourceKindServiceFile:
+			serviceFiles = appendPath(serviceFiles, source.Path)
+		case domain.SourceKindAsyncAPI:
+			asyncAPIFiles = appendPath(asyncAPIFiles, source.Path)
+		}
+	}
+
+	return serviceFiles, asyncAPIFiles, nil
+}
+
+// appendPath appends path unless it is already present, e.g. found by scanning the input directory.
+func appendPath(paths []string, path string) []string {
+	for _, p := range paths {
+		if filepath.Clean(p) == filepath.Clean(path) {
+			return paths
+		}
+	}
+
+	return append(paths, path)
+}
+
+func specFilesFromDir(dir string, out io.Writer) ([]string, []string, error) {
+	fmt.Fprintln(out, "Scanning directory for spec files:", dir)
 
 	asyncMap := make(map[string]struct{})
 	serviceMap := make(map[string]struct{})
@@ -194,8 +437,8 @@ func specFilesFromDir(dir string) ([]string, []string, error) {
 		return nil, nil, fmt.Errorf("%w in directory %s", ErrNoSpecFilesFound, dir)
 	}
 
-	fmt.Println("Found AsyncAPI files:", asyncAPIFiles)
-	fmt.Println("Found ServiceFile files:", serviceFiles)
+	fmt.Fprintln(out, "Found AsyncAPI files:", asyncAPIFiles)
+	fmt.Fprintln(out, "Found ServiceFile files:", serviceFiles)
 
 	return serviceFiles, asyncAPIFiles, nil
 }
@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"io"
 	"os"
 	"path/filepath"
 	"testing"
@@ -17,12 +18,14 @@ import (
 func setupTestInjector() do.Injector {
 	injector := do.New()
 	do.Provide(injector, func(i do.Injector) (*app.App, error) {
-		return app.NewApp(nil, nil, nil, nil), nil
+		return app.NewApp(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil), nil
 	})
 	do.Provide(injector, schema.NewLoader)
 	do.Provide(injector, docsgen.NewGenerator)
+	do.Provide(injector, docsgen.NewOutputFS)
 	do.ProvideValue(injector, config.ConfigFilePath(""))
 	do.Provide(injector, config.LoadConfig)
+	do.Provide(injector, NewReporter)
 
 	return injector
 }
@@ -36,6 +39,30 @@ func TestNewCommand(t *testing.T) {
 	require.NotNil(t, cmd)
 	assert.NotNil(t, cmd.cmd)
 	assert.Equal(t, "gen-docs", cmd.cmd.Use)
+	assert.Equal(t, "false", cmd.cmd.Flag("no-metadata").DefValue)
+	require.NoError(t, cmd.resolve())
+	assert.True(t, cmd.config.Output.Metadata)
+}
+
+func TestNewCommand_MalformedConfig(t *testing.T) {
+	t.Parallel()
+
+	configFile := filepath.Join(t.TempDir(), "holydocs.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte("output: [\n"), 0o600))
+
+	injector := setupTestInjector()
+	do.OverrideValue(injector, config.ConfigFilePath(configFile))
+
+	cmd, err := NewCommand(injector)
+	require.NoError(t, err)
+	assert.Nil(t, cmd.config)
+
+	cobraCmd := cmd.GetCommand()
+	cobraCmd.SetArgs([]string{})
+	cobraCmd.SetOut(io.Discard)
+
+	err = cobraCmd.Execute()
+	require.ErrorContains(t, err, "loading configuration")
 }
 
 func TestCommand_GetCommand(t *testing.T) {
@@ -150,7 +177,7 @@ func TestSpecFilesFromDir_EmptyDirectory(t *testing.T) {
 
 	tempDir := t.TempDir()
 
-	serviceFiles, asyncFiles, err := specFilesFromDir(tempDir)
+	serviceFiles, asyncFiles, err := specFilesFromDir(tempDir, io.Discard)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), ErrNoSpecFilesFound.Error())
 	assert.Nil(t, serviceFiles)
@@ -169,7 +196,7 @@ info:
 `), 0o644)
 	require.NoError(t, err)
 
-	serviceFiles, asyncFiles, err := specFilesFromDir(tempDir)
+	serviceFiles, asyncFiles, err := specFilesFromDir(tempDir, io.Discard)
 	require.NoError(t, err)
 	assert.Empty(t, serviceFiles)
 	assert.Contains(t, asyncFiles, asyncAPIFile)
@@ -187,7 +214,7 @@ info:
 `), 0o644)
 	require.NoError(t, err)
 
-	serviceFiles, asyncFiles, err := specFilesFromDir(tempDir)
+	serviceFiles, asyncFiles, err := specFilesFromDir(tempDir, io.Discard)
 	require.NoError(t, err)
 	assert.Contains(t, serviceFiles, serviceFile)
 	assert.Empty(t, asyncFiles)
@@ -212,7 +239,7 @@ info:
 `), 0o644)
 	require.NoError(t, err)
 
-	serviceFiles, asyncFiles, err := specFilesFromDir(tempDir)
+	serviceFiles, asyncFiles, err := specFilesFromDir(tempDir, io.Discard)
 	require.NoError(t, err)
 	assert.Contains(t, serviceFiles, serviceFile)
 	assert.Contains(t, asyncFiles, asyncAPIFile)
@@ -229,7 +256,7 @@ func TestSpecFilesFromDir_IgnoresNonYAMLFiles(t *testing.T) {
 	require.NoError(t, err)
 
 	// Non-YAML files are ignored, so we should get an error for no spec files found
-	serviceFiles, asyncFiles, err := specFilesFromDir(tempDir)
+	serviceFiles, asyncFiles, err := specFilesFromDir(tempDir, io.Discard)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), ErrNoSpecFilesFound.Error())
 	assert.Nil(t, serviceFiles)
@@ -247,7 +274,7 @@ func TestSpecFilesFromDir_IgnoresInvalidYAML(t *testing.T) {
 	require.NoError(t, err)
 
 	// Invalid YAML files are silently ignored, so we should get an error for no spec files found
-	serviceFiles, asyncFiles, err := specFilesFromDir(tempDir)
+	serviceFiles, asyncFiles, err := specFilesFromDir(tempDir, io.Discard)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), ErrNoSpecFilesFound.Error())
 	assert.Nil(t, serviceFiles)
@@ -269,7 +296,7 @@ info:
 `), 0o644)
 	require.NoError(t, err)
 
-	_, asyncFiles, err := specFilesFromDir(tempDir)
+	_, asyncFiles, err := specFilesFromDir(tempDir, io.Discard)
 	require.NoError(t, err)
 	assert.Contains(t, asyncFiles, asyncAPIFile)
 }
@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+)
+
+// serviceNameCompletion completes names of the services recorded in domain.json of the last generated
+// documentation, described by their system. Nothing is completed before documentation was generated or
+// when the configuration doesn't load.
+func serviceNameCompletion(deps *commandDeps) cobra.CompletionFunc {
+	return func(_ *cobra.Command, _ []string, _ string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if err := deps.resolve(); err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		schema, err := deps.app.DocumentedSchema(context.Background(), deps.config.Output.Dir)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		completions := make([]cobra.Completion, 0, len(schema.Services))
+		for _, service := range schema.Services {
+			system := service.Info.System
+			if system == "" {
+				system = deps.config.Output.GlobalName
+			}
+
+			completions = append(completions, cobra.CompletionWithDesc(service.Info.Name, system))
+		}
+
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// focusNameCompletion completes names of the services and systems recorded in domain.json of the last
+// generated documentation.
+func focusNameCompletion(deps *commandDeps) cobra.CompletionFunc {
+	completeServices := serviceNameCompletion(deps)
+
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		completions, directive := completeServices(cmd, args, toComplete)
+		if deps.app == nil {
+			return completions, directive
+		}
+
+		schema, err := deps.app.DocumentedSchema(context.Background(), deps.config.Output.Dir)
+		if err != nil {
+			return completions, directive
+		}
+
+		systems := make(map[string]struct{})
+		for _, service := range schema.Services {
+			if _, ok := systems[service.Info.System]; ok || service.Info.System == "" {
+				continue
+			}
+
+			systems[service.Info.System] = struct{}{}
+			completions = append(completions, cobra.CompletionWithDesc(service.Info.System, "system"))
+		}
+
+		return completions, directive
+	}
+}
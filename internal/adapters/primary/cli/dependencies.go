@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/spf13/cobra"
+)
+
+func (c *ExportCommand) exportDependencies(cmd *cobra.Command, _ []string) error {
+	format := domain.DependencyExportFormat(c.dependenciesFormat)
+	if !slices.Contains(domain.DependencyExportFormats(), format) {
+		return fmt.Errorf("%w: format %q, expected one of %v",
+			domain.ErrUnsupportedValue, format, domain.DependencyExportFormats())
+	}
+
+	// Progress messages go to stderr so the table can be piped from stdout.
+	ctx := cmd.Context()
+
+	serviceFilesPaths, asyncAPIFilesPaths, err := specFilesWithSources(ctx, c.app, c.config, c.reporter, cmd.ErrOrStderr())
+	if err != nil {
+		return fmt.Errorf("getting spec files paths: %w", err)
+	}
+
+	export, err := c.app.ExportDependencies(ctx, domain.ExportDependenciesRequest{
+		ServiceFilesPaths:  serviceFilesPaths,
+		AsyncAPIFilesPaths: asyncAPIFilesPaths,
+		Format:             format,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export dependencies: %w", err)
+	}
+
+	if c.dependenciesOutput == stdoutOutput {
+		if _, err := cmd.OutOrStdout().Write(export.Content); err != nil {
+			return fmt.Errorf("writing dependencies: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := os.WriteFile(c.dependenciesOutput, export.Content, filePerm); err != nil {
+		return fmt.Errorf("writing dependencies %s: %w", c.dependenciesOutput, err)
+	}
+
+	fmt.Fprintf(cmd.ErrOrStderr(), "Exported %d dependencies: %s\n", export.Dependencies, c.dependenciesOutput)
+
+	return nil
+}
+
+func dependencyFormatCompletion(_ *cobra.Command, _ []string, _ string) ([]cobra.Completion,
+	cobra.ShellCompDirective) {
+	formats := domain.DependencyExportFormats()
+
+	completions := make([]cobra.Completion, 0, len(formats))
+	for _, format := range formats {
+		completions = append(completions, string(format))
+	}
+
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
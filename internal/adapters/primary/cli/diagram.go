@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	do "github.com/samber/do/v2"
+	"github.com/spf13/cobra"
+)
+
+// DiagramCommand represents the diagram command.
+type DiagramCommand struct {
+	cmd *cobra.Command
+	commandDeps
+	reporter *Reporter
+
+	service     string
+	focus       string
+	depth       int
+	diagramType string
+	format      string
+	output      string
+}
+
+func NewDiagramCommand(i do.Injector) (*DiagramCommand, error) {
+	c := &DiagramCommand{
+		commandDeps: newCommandDeps(i),
+		reporter:    do.MustInvoke[*Reporter](i),
+	}
+
+	c.cmd = &cobra.Command{
+		Use:   "diagram",
+		Short: "Render a single diagram for one service or the neighborhood of a service",
+		Long: `Render one diagram for a single service without running the whole documentation pipeline.
+
+With --focus, the overview diagram is limited to the services and participants within --depth
+hops of a service or of the services of a system, following relationships and message flow.
+
+Input files are taken from the configuration the same way as for gen-docs.
+The diagram is written to stdout unless an output file is given.
+
+Diagram types:
+  - relationships: service relationships diagram
+  - flow: message flow between the service and other services (requires AsyncAPI files)
+
+Examples:
+  # Render relationships of a service as SVG into a file
+  holydocs diagram --service "User Service" --output user-service.svg
+
+  # Print message flow of a service as D2 script
+  holydocs diagram --service "User Service" --type flow --format d2
+
+  # Render everything up to two hops away from a service
+  holydocs diagram --focus "Orders Service" --depth 2 --output orders-neighborhood.svg`,
+		RunE: c.resolved(c.run),
+	}
+
+	c.cmd.Flags().StringVarP(&c.service, "service", "s", "", "Name of the service to render the diagram for")
+	c.cmd.Flags().StringVar(&c.focus, "focus", "",
+		"Name of the service or system to render the neighborhood of, instead of a diagram of --service")
+	c.cmd.Flags().IntVar(&c.depth, "depth", 1, "Number of hops around the --focus service or system")
+	c.cmd.Flags().StringVarP(&c.diagramType, "type", "t", string(domain.ServiceDiagramRelationships),
+		"Diagram type: relationships or flow")
+	c.cmd.Flags().StringVarP(&c.format, "format", "f", string(domain.DiagramFormatSVG), "Output format: svg or d2")
+	c.cmd.Flags().StringVarP(&c.output, "output", "o", "", "Output file (defaults to stdout)")
+	c.cmd.MarkFlagsOneRequired("service", "focus")
+	c.cmd.MarkFlagsMutuallyExclusive("service", "focus")
+	c.cmd.MarkFlagsMutuallyExclusive("focus", "type")
+	_ = c.cmd.RegisterFlagCompletionFunc("service", serviceNameCompletion(&c.commandDeps))
+	_ = c.cmd.RegisterFlagCompletionFunc("focus", focusNameCompletion(&c.commandDeps))
+	_ = c.cmd.RegisterFlagCompletionFunc("type", cobra.FixedCompletions([]cobra.Completion{
+		string(domain.ServiceDiagramRelationships), string(domain.ServiceDiagramFlow),
+	}, cobra.ShellCompDirectiveNoFileComp))
+	_ = c.cmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions([]cobra.Completion{
+		string(domain.DiagramFormatSVG), string(domain.DiagramFormatD2),
+	}, cobra.ShellCompDirectiveNoFileComp))
+
+	return c, nil
+}
+
+// GetCommand returns the cobra command.
+func (c *DiagramCommand) GetCommand() *cobra.Command {
+	return c.cmd
+}
+
+func (c *DiagramCommand) run(cmd *cobra.Command, _ []string) error {
+	// Progress messages go to stderr so the diagram can be piped from stdout.
+	ctx := cmd.Context()
+
+	serviceFilesPaths, asyncAPIFilesPaths, err := specFilesWithSources(ctx, c.app, c.config, c.reporter, cmd.ErrOrStderr())
+	if err != nil {
+		return fmt.Errorf("getting spec files paths: %w", err)
+	}
+
+	req := domain.GenerateServiceDiagramRequest{
+		ServiceFilesPaths:  serviceFilesPaths,
+		AsyncAPIFilesPaths: asyncAPIFilesPaths,
+		Service:            c.service,
+		Type:               domain.ServiceDiagramType(c.diagramType),
+		Format:             domain.DiagramFormat(c.format),
+	}
+
+	if c.focus != "" {
+		req.Service = c.focus
+		req.Type = domain.ServiceDiagramFocus
+		req.Depth = c.depth
+	}
+
+	diagram, err := c.app.GenerateServiceDiagram(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to generate diagram: %w", err)
+	}
+
+	if c.output == "" {
+		if _, err := cmd.OutOrStdout().Write(diagram); err != nil {
+			return fmt.Errorf("writing diagram: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := os.WriteFile(c.output, diagram, filePerm); err != nil {
+		return fmt.Errorf("writing diagram to %s: %w", c.output, err)
+	}
+
+	return nil
+}
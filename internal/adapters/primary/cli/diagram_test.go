@@ -0,0 +1,25 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDiagramCommand(t *testing.T) {
+	t.Parallel()
+
+	injector := setupTestInjector()
+	cmd, err := NewDiagramCommand(injector)
+	require.NoError(t, err)
+	require.NotNil(t, cmd)
+
+	cobraCmd := cmd.GetCommand()
+	assert.Equal(t, "diagram", cobraCmd.Use)
+	assert.Equal(t, "relationships", cobraCmd.Flag("type").DefValue)
+	assert.Equal(t, "svg", cobraCmd.Flag("format").DefValue)
+	assert.NotNil(t, cobraCmd.Flag("service"))
+	assert.NotNil(t, cobraCmd.Flag("focus"))
+	assert.Equal(t, "1", cobraCmd.Flag("depth").DefValue)
+}
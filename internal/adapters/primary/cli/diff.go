@@ -0,0 +1,208 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	do "github.com/samber/do/v2"
+	"github.com/spf13/cobra"
+)
+
+// DiffCommand represents the diff command.
+type DiffCommand struct {
+	cmd *cobra.Command
+	commandDeps
+	reporter *Reporter
+
+	visual      bool
+	format      string
+	output      string
+	attribution string
+}
+
+func NewDiffCommand(i do.Injector) (*DiffCommand, error) {
+	c := &DiffCommand{
+		commandDeps: newCommandDeps(i),
+		reporter:    do.MustInvoke[*Reporter](i),
+	}
+
+	c.cmd = &cobra.Command{
+		Use:   "diff <before> <after>",
+		Short: "Compare two schema snapshots",
+		Long: `Compare two schema snapshots, the domain.json files recorded with generated documentation or
+directories of generated documentation holding one, and list the services, relationships and
+operations added, removed and changed between them. When both are directories, the Markdown
+pages are compared as well: pages and sections added, removed and changed are listed together
+with the services whose documentation changed, rather than a diff of the regenerated text.
+
+With --visual, the overview diagram of both snapshots is rendered instead, with added services
+and relationships in green, removed ones ghosted in red and changed ones in amber, for
+architecture reviews. The list of changes goes to stderr then.
+
+With --attribution git, changes mention the author and subject of the last commit changing the
+specifications of their service, taken from the input files of the configuration.
+
+Examples:
+  # List the changes since the documentation of the last release
+  holydocs diff release/domain.json docs/domain.json
+
+  # Render the changes as an SVG diagram
+  holydocs diff release/domain.json docs --visual --output changes.svg
+
+  # Tell who changed what since the last release
+  holydocs diff release/domain.json docs --attribution git`,
+		Args: cobra.ExactArgs(2),
+		RunE: c.resolved(c.run),
+	}
+
+	c.cmd.Flags().BoolVar(&c.visual, "visual", false, "Render the overview diagram of the changes")
+	c.cmd.Flags().StringVarP(&c.format, "format", "f", string(domain.DiagramFormatSVG),
+		"Format of the --visual diagram: svg or d2")
+	c.cmd.Flags().StringVarP(&c.output, "output", "o", "", "Output file of the --visual diagram (defaults to stdout)")
+	c.cmd.Flags().StringVar(&c.attribution, "attribution", "",
+		"Add the last commit of the specifications of a service to its changes: git")
+	_ = c.cmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions([]cobra.Completion{
+		string(domain.DiagramFormatSVG), string(domain.DiagramFormatD2),
+	}, cobra.ShellCompDirectiveNoFileComp))
+	_ = c.cmd.RegisterFlagCompletionFunc("attribution", attributionCompletion)
+
+	return c, nil
+}
+
+// GetCommand returns the cobra command.
+func (c *DiffCommand) GetCommand() *cobra.Command {
+	return c.cmd
+}
+
+func (c *DiffCommand) run(cmd *cobra.Command, args []string) error {
+	if err := checkAttribution(c.attribution); err != nil {
+		return err
+	}
+
+	req := domain.DiffSchemasRequest{
+		Before:      args[0],
+		After:       args[1],
+		Visual:      c.visual,
+		Format:      domain.DiagramFormat(c.format),
+		Attribution: domain.ChangeAttribution(c.attribution),
+	}
+
+	if c.attribution != "" {
+		var err error
+
+		// Progress messages go to stderr, the output may be piped from stdout.
+		req.ServiceFilesPaths, req.AsyncAPIFilesPaths, err = specFilesWithSources(cmd.Context(), c.app, c.config,
+			c.reporter, cmd.ErrOrStderr())
+		if err != nil {
+			return fmt.Errorf("getting spec files paths: %w", err)
+		}
+	}
+
+	reply, err := c.app.DiffSchemas(cmd.Context(), req)
+	if err != nil {
+		return fmt.Errorf("failed to compare schemas: %w", err)
+	}
+
+	if !c.visual {
+		printChanges(cmd.OutOrStdout(), reply.Changelog.Changes)
+		printDocsDiff(cmd.OutOrStdout(), reply.Docs)
+
+		return nil
+	}
+
+	// The diagram may be piped from stdout.
+	printChanges(cmd.ErrOrStderr(), reply.Changelog.Changes)
+	printDocsDiff(cmd.ErrOrStderr(), reply.Docs)
+
+	if c.output == "" {
+		if _, err := cmd.OutOrStdout().Write(reply.Diagram); err != nil {
+			return fmt.Errorf("writing diagram: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := os.WriteFile(c.output, reply.Diagram, filePerm); err != nil {
+		return fmt.Errorf("writing diagram to %s: %w", c.output, err)
+	}
+
+	return nil
+}
+
+// checkAttribution checks the --attribution flag, empty for no attribution.
+func checkAttribution(attribution string) error {
+	if attribution != "" && !slices.Contains(domain.ChangeAttributions(), domain.ChangeAttribution(attribution)) {
+		return fmt.Errorf("%w: attribution %q, expected one of %v", domain.ErrUnsupportedValue, attribution,
+			domain.ChangeAttributions())
+	}
+
+	return nil
+}
+
+func attributionCompletion(_ *cobra.Command, _ []string, _ string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	return []cobra.Completion{string(domain.ChangeAttributionGit)}, cobra.ShellCompDirectiveNoFileComp
+}
+
+func printChanges(w io.Writer, changes []domain.Change) {
+	if len(changes) == 0 {
+		fmt.Fprintln(w, "No changes")
+
+		return
+	}
+
+	for _, change := range changes {
+		fmt.Fprintf(w, "• %s %s: %s\n", change.Type, change.Category, change.Details)
+		if change.Diff != "" {
+			fmt.Fprintln(w, change.Diff)
+		}
+	}
+}
+
+// printDocsDiff prints the pages and sections of the documentation that changed, rather than a diff of their
+// text, which regenerated documentation makes long.
+func printDocsDiff(w io.Writer, diff domain.DocsDiff) {
+	if len(diff.Pages) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w, "\nDocumentation:")
+
+	for _, page := range diff.Pages {
+		if page.Type != domain.ChangeTypeChanged {
+			fmt.Fprintf(w, "• %s %s\n", page.Type, page.Path)
+
+			continue
+		}
+
+		fmt.Fprintf(w, "• %s %s: %s added, %s removed, %s changed\n", page.Type, page.Path,
+			sections(len(page.SectionsAdded)), sections(len(page.SectionsRemoved)), sections(len(page.SectionsChanged)))
+
+		for _, section := range page.SectionsAdded {
+			fmt.Fprintf(w, "  + %s\n", section)
+		}
+
+		for _, section := range page.SectionsRemoved {
+			fmt.Fprintf(w, "  - %s\n", section)
+		}
+
+		for _, section := range page.SectionsChanged {
+			fmt.Fprintf(w, "  ~ %s\n", section)
+		}
+	}
+
+	if len(diff.Services) > 0 {
+		fmt.Fprintf(w, "\nServices with changed documentation: %s\n", strings.Join(diff.Services, ", "))
+	}
+}
+
+func sections(count int) string {
+	if count == 1 {
+		return "1 section"
+	}
+
+	return fmt.Sprintf("%d sections", count)
+}
@@ -0,0 +1,33 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDiffCommand(t *testing.T) {
+	t.Parallel()
+
+	injector := setupTestInjector()
+	cmd, err := NewDiffCommand(injector)
+	require.NoError(t, err)
+	require.NotNil(t, cmd)
+
+	cobraCmd := cmd.GetCommand()
+	assert.Equal(t, "diff <before> <after>", cobraCmd.Use)
+	assert.Equal(t, "false", cobraCmd.Flag("visual").DefValue)
+	assert.Equal(t, "svg", cobraCmd.Flag("format").DefValue)
+	assert.Empty(t, cobraCmd.Flag("output").DefValue)
+	assert.Empty(t, cobraCmd.Flag("attribution").DefValue)
+}
+
+func TestCheckAttribution(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, checkAttribution(""))
+	require.NoError(t, checkAttribution("git"))
+	require.ErrorIs(t, checkAttribution("svn"), domain.ErrUnsupportedValue)
+}
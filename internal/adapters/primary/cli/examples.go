@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/holydocs/holydocs/examples"
+	do "github.com/samber/do/v2"
+	"github.com/spf13/cobra"
+)
+
+// examplesColumnPadding separates example names from their descriptions.
+const examplesColumnPadding = 2
+
+// ExamplesCommand represents the examples command.
+type ExamplesCommand struct {
+	cmd *cobra.Command
+}
+
+func NewExamplesCommand(_ do.Injector) (*ExamplesCommand, error) {
+	c := &ExamplesCommand{}
+
+	c.cmd = &cobra.Command{
+		Use:   "examples [" + strings.Join(examples.Names(), "|") + "]",
+		Short: "Print end-to-end configuration samples",
+		Long: `Print holydocs.yaml samples for common setups, with the commands to run them.
+Without arguments the available samples are listed.
+
+Examples:
+  # List the samples
+  holydocs examples
+
+  # Start a new configuration from a sample
+  holydocs examples monorepo > holydocs.yaml`,
+		Args:      cobra.MaximumNArgs(1),
+		ValidArgs: examples.Names(),
+		RunE:      c.run,
+	}
+
+	return c, nil
+}
+
+// GetCommand returns the cobra command.
+func (c *ExamplesCommand) GetCommand() *cobra.Command {
+	return c.cmd
+}
+
+func (c *ExamplesCommand) run(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, examplesColumnPadding, ' ', 0)
+		for _, example := range examples.List() {
+			fmt.Fprintf(w, "%s\t%s\n", example.Name, example.Description)
+		}
+
+		if err := w.Flush(); err != nil {
+			return fmt.Errorf("writing examples: %w", err)
+		}
+
+		return nil
+	}
+
+	data, err := examples.Get(args[0])
+	if err != nil {
+		return fmt.Errorf("getting example: %w", err)
+	}
+
+	if _, err := cmd.OutOrStdout().Write(data); err != nil {
+		return fmt.Errorf("writing example: %w", err)
+	}
+
+	return nil
+}
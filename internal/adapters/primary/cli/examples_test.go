@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/holydocs/holydocs/examples"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExamplesCommand(t *testing.T) {
+	t.Parallel()
+
+	cmd, err := NewExamplesCommand(setupTestInjector())
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	cobraCmd := cmd.GetCommand()
+	cobraCmd.SetOut(&out)
+	cobraCmd.SetArgs([]string{})
+	require.NoError(t, cobraCmd.Execute())
+
+	for _, name := range examples.Names() {
+		assert.Contains(t, out.String(), name)
+	}
+
+	out.Reset()
+	cobraCmd.SetArgs([]string{examples.Minimal})
+	require.NoError(t, cobraCmd.Execute())
+	assert.Contains(t, out.String(), "input:")
+
+	cobraCmd.SetArgs([]string{"unknown"})
+	require.ErrorIs(t, cobraCmd.Execute(), examples.ErrUnknownExample)
+}
@@ -0,0 +1,244 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/holydocs/holydocs/internal/slug"
+	do "github.com/samber/do/v2"
+	"github.com/spf13/cobra"
+)
+
+// stdoutOutput selects stdout instead of an output directory.
+const stdoutOutput = "-"
+
+// ExportCommand represents the export command.
+type ExportCommand struct {
+	cmd *cobra.Command
+	commandDeps
+	reporter *Reporter
+
+	scope   string
+	output  string
+	version string
+
+	dependenciesFormat string
+	dependenciesOutput string
+
+	radarFormat string
+	radarOutput string
+
+	lineageFormat    string
+	lineageOutput    string
+	lineageNamespace string
+}
+
+func NewExportCommand(i do.Injector) (*ExportCommand, error) {
+	c := &ExportCommand{
+		commandDeps: newCommandDeps(i),
+		reporter:    do.MustInvoke[*Reporter](i),
+	}
+
+	c.cmd = &cobra.Command{
+		Use:   "export",
+		Short: "Export the merged schema for downstream tooling",
+	}
+
+	asyncAPICmd := &cobra.Command{
+		Use:   "asyncapi",
+		Short: "Export merged async operations as AsyncAPI 3.0 documents",
+		Long: `Re-emit the async operations of all input specifications as consolidated AsyncAPI 3.0 documents,
+so code generators and linters can consume the organization-wide event surface.
+
+Input files are taken from the configuration the same way as for gen-docs. Documents are written
+to <output>/<name>.asyncapi.yaml, one for all services or one per system. Services without a
+system are exported under the global name. Every operation keeps the service that declared it
+in the x-service field.
+
+Examples:
+  # Export one document with all async operations into the default directory
+  holydocs export asyncapi
+
+  # Export one document per system into a custom directory
+  holydocs export asyncapi --scope system --output ./asyncapi
+
+  # Print the global document to stdout
+  holydocs export asyncapi --output -`,
+		RunE: c.resolved(c.exportAsyncAPI),
+	}
+	asyncAPICmd.Flags().StringVar(&c.scope, "scope", string(domain.AsyncAPIExportScopeGlobal),
+		"Document scope: global or system")
+	asyncAPICmd.Flags().StringVarP(&c.output, "output", "o", "",
+		"Output directory, - for stdout (defaults to the asyncapi directory in the documentation output)")
+	asyncAPICmd.Flags().StringVar(&c.version, "version", "1.0.0", "info.version of the exported documents")
+	_ = asyncAPICmd.RegisterFlagCompletionFunc("scope", scopeCompletion)
+	c.cmd.AddCommand(asyncAPICmd)
+
+	dependenciesCmd := &cobra.Command{
+		Use:   "dependencies",
+		Short: "Export the relationships of all services as a table",
+		Long: `Export one row per relationship of the input specifications with its source, target, action,
+technology, protocol and the system and owner of the source service, for spreadsheet-driven
+reviews and audits. The dot format writes the same relationships as a Graphviz graph with a
+cluster per system, for Graphviz toolchains and custom graph analyses.
+
+Examples:
+  # Print the dependencies as CSV
+  holydocs export dependencies --format csv
+
+  # Write an Excel workbook
+  holydocs export dependencies --format xlsx --output dependencies.xlsx
+
+  # Render the dependency graph with Graphviz
+  holydocs export dependencies --format dot | dot -Tsvg -o dependencies.svg`,
+		RunE: c.resolved(c.exportDependencies),
+	}
+	dependenciesCmd.Flags().StringVar(&c.dependenciesFormat, "format", string(domain.DependencyExportFormatCSV),
+		"Table format: csv, xlsx or dot")
+	dependenciesCmd.Flags().StringVarP(&c.dependenciesOutput, "output", "o", stdoutOutput,
+		"Output file, - for stdout")
+	_ = dependenciesCmd.RegisterFlagCompletionFunc("format", dependencyFormatCompletion)
+	c.cmd.AddCommand(dependenciesCmd)
+
+	radarCmd := &cobra.Command{
+		Use:   "radar",
+		Short: "Export the technologies of all services as a technology radar",
+		Long: `Export every technology of the relationships of the input specifications with the services using
+it, placed in the rings and quadrants configured under export.radar. The fields follow the
+"Build your own Radar" format (name, ring, quadrant, isNew, description), which radar
+visualizers read as CSV or JSON.
+
+Examples:
+  # Print the radar as JSON
+  holydocs export radar
+
+  # Write a CSV sheet
+  holydocs export radar --format csv --output radar.csv`,
+		RunE: c.resolved(c.exportRadar),
+	}
+	radarCmd.Flags().StringVar(&c.radarFormat, "format", string(domain.RadarExportFormatJSON), "Radar format: json or csv")
+	radarCmd.Flags().StringVarP(&c.radarOutput, "output", "o", stdoutOutput, "Output file, - for stdout")
+	_ = radarCmd.RegisterFlagCompletionFunc("format", radarFormatCompletion)
+	c.cmd.AddCommand(radarCmd)
+
+	lineageCmd := &cobra.Command{
+		Use:   "lineage",
+		Short: "Export the datastores and channels services read and write as lineage",
+		Long: `Export every service reading or writing datasets as a lineage job, so data governance platforms
+can ingest the architecture lineage. Datasets are the participants of relationships with an
+access mode, which are datastores, and the channels of async operations.
+
+The openlineage format writes one OpenLineage job event per line, to be posted to an OpenLineage
+endpoint such as Marquez or the OpenMetadata and DataHub integrations. The datahub format writes
+metadata change proposals read by the DataHub file source.
+
+Examples:
+  # Print OpenLineage job events
+  holydocs export lineage
+
+  # Write proposals for "datahub ingest" with the file source
+  holydocs export lineage --format datahub --output lineage.json`,
+		RunE: c.resolved(c.exportLineage),
+	}
+	lineageCmd.Flags().StringVar(&c.lineageFormat, "format", string(domain.LineageExportFormatOpenLineage),
+		"Lineage format: openlineage or datahub")
+	lineageCmd.Flags().StringVarP(&c.lineageOutput, "output", "o", stdoutOutput, "Output file, - for stdout")
+	lineageCmd.Flags().StringVar(&c.lineageNamespace, "namespace", "holydocs",
+		"Namespace of the jobs and channels, the DataHub orchestrator and platform of channels")
+	_ = lineageCmd.RegisterFlagCompletionFunc("format", lineageFormatCompletion)
+	c.cmd.AddCommand(lineageCmd)
+
+	return c, nil
+}
+
+// GetCommand returns the cobra command.
+func (c *ExportCommand) GetCommand() *cobra.Command {
+	return c.cmd
+}
+
+func (c *ExportCommand) exportAsyncAPI(cmd *cobra.Command, _ []string) error {
+	// Progress messages go to stderr so documents can be piped from stdout.
+	ctx := cmd.Context()
+
+	serviceFilesPaths, asyncAPIFilesPaths, err := specFilesWithSources(ctx, c.app, c.config, c.reporter, cmd.ErrOrStderr())
+	if err != nil {
+		return fmt.Errorf("getting spec files paths: %w", err)
+	}
+
+	documents, err := c.app.ExportAsyncAPI(ctx, domain.ExportAsyncAPIRequest{
+		ServiceFilesPaths:  serviceFilesPaths,
+		AsyncAPIFilesPaths: asyncAPIFilesPaths,
+		Scope:              domain.AsyncAPIExportScope(c.scope),
+		Title:              c.config.Output.Title,
+		GlobalName:         c.config.Output.GlobalName,
+		Version:            c.version,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export AsyncAPI documents: %w", err)
+	}
+
+	if c.output == stdoutOutput {
+		for i, document := range documents {
+			if i > 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "---")
+			}
+
+			if _, err := cmd.OutOrStdout().Write(document.Content); err != nil {
+				return fmt.Errorf("writing AsyncAPI document %s: %w", document.Name, err)
+			}
+		}
+
+		return nil
+	}
+
+	outputDir := c.output
+	if outputDir == "" {
+		outputDir = filepath.Join(c.config.Output.Dir, "asyncapi")
+	}
+
+	if err := os.MkdirAll(outputDir, dirPerm); err != nil {
+		return fmt.Errorf("creating output directory %s: %w", outputDir, err)
+	}
+
+	names := make([]string, 0, len(documents))
+	for _, document := range documents {
+		names = append(names, document.Name)
+	}
+
+	// Document names differing only in case or punctuation would share a file.
+	baseNames := domain.UniqueKeys(names, documentBaseName)
+
+	for _, document := range documents {
+		path := filepath.Join(outputDir, baseNames[document.Name]+".asyncapi.yaml")
+		if err := os.WriteFile(path, document.Content, filePerm); err != nil {
+			return fmt.Errorf("writing AsyncAPI document %s: %w", path, err)
+		}
+
+		fmt.Fprintln(cmd.ErrOrStderr(), "Exported:", path)
+	}
+
+	return nil
+}
+
+func scopeCompletion(_ *cobra.Command, _ []string, _ string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	scopes := domain.AsyncAPIExportScopes()
+
+	completions := make([]cobra.Completion, 0, len(scopes))
+	for _, scope := range scopes {
+		completions = append(completions, string(scope))
+	}
+
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// documentBaseName returns the base of the file name of an exported document, e.g. "notification-system"
+// for "notification-system.asyncapi.yaml".
+func documentBaseName(name string) string {
+	if base := slug.Filename(name); base != "" {
+		return base
+	}
+
+	return "asyncapi"
+}
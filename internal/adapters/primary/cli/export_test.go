@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentBaseName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "notification-system", documentBaseName("Notification System"))
+	assert.Equal(t, "asyncapi", documentBaseName(" "))
+	assert.Regexp(t, `^aux-[0-9a-f]{8}$`, documentBaseName("AUX"))
+}
+
+func TestEncodeRadar(t *testing.T) {
+	t.Parallel()
+
+	entries := []domain.RadarEntry{
+		{Name: "gRPC", Ring: "adopt", Quadrant: "platforms", Services: []string{"Billing Service", "Order Service"}},
+		{Name: "Redis", Ring: "hold", Quadrant: "databases", Services: []string{"Order Service"}},
+	}
+
+	var csvOut bytes.Buffer
+	require.NoError(t, encodeRadar(&csvOut, domain.RadarExportFormatCSV, entries))
+	assert.Equal(t, "name,ring,quadrant,isNew,description\n"+
+		"gRPC,adopt,platforms,FALSE,\"Used by Billing Service, Order Service\"\n"+
+		"Redis,hold,databases,FALSE,Used by Order Service\n", csvOut.String())
+
+	var jsonOut bytes.Buffer
+	require.NoError(t, encodeRadar(&jsonOut, domain.RadarExportFormatJSON, entries[1:]))
+	assert.JSONEq(t, `[{"name": "Redis", "ring": "hold", "quadrant": "databases", "isNew": "FALSE",
+		"description": "Used by Order Service"}]`, jsonOut.String())
+}
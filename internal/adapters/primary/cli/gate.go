@@ -0,0 +1,148 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	do "github.com/samber/do/v2"
+	"github.com/spf13/cobra"
+)
+
+// maxCoverage is the highest coverage threshold, in percent.
+const maxCoverage = 100
+
+// GateCommand represents the gate command.
+type GateCommand struct {
+	cmd *cobra.Command
+	commandDeps
+	reporter *Reporter
+
+	minOwnerCoverage       float64
+	minDescriptionCoverage float64
+}
+
+func NewGateCommand(i do.Injector) (*GateCommand, error) {
+	c := &GateCommand{
+		commandDeps: newCommandDeps(i),
+		reporter:    do.MustInvoke[*Reporter](i),
+	}
+
+	c.cmd = &cobra.Command{
+		Use:   "gate",
+		Short: "Fail when the documentation coverage of the services drops below thresholds",
+		Long: `Measure how completely the services are documented and exit with an error when a coverage drops
+below its threshold, so documentation quality can be ratcheted in CI like test coverage.
+
+Coverages are the percentages of services with an owner and with a description, measured on the
+merged services the same way as for gen-docs. Services missing a field are listed under a coverage
+below its threshold. Thresholds of 0 aren't checked.
+
+Input files are taken from the configuration the same way as for gen-docs.
+
+Examples:
+  # Require owners of 90% and descriptions of 80% of the services
+  holydocs gate --min-owner-coverage 90 --min-description-coverage 80`,
+		Args: cobra.NoArgs,
+		RunE: c.resolved(c.run),
+		// Coverage below thresholds is reported as an error, the usage doesn't help raising it.
+		SilenceUsage: true,
+	}
+	c.cmd.Flags().Float64Var(&c.minOwnerCoverage, "min-owner-coverage", 0,
+		"Minimum percentage of services with an owner")
+	c.cmd.Flags().Float64Var(&c.minDescriptionCoverage, "min-description-coverage", 0,
+		"Minimum percentage of services with a description")
+
+	return c, nil
+}
+
+// GetCommand returns the cobra command.
+func (c *GateCommand) GetCommand() *cobra.Command {
+	return c.cmd
+}
+
+func (c *GateCommand) run(cmd *cobra.Command, _ []string) error {
+	for _, flag := range []string{"min-owner-coverage", "min-description-coverage"} {
+		threshold, err := cmd.Flags().GetFloat64(flag)
+		if err != nil {
+			return fmt.Errorf("reading --%s: %w", flag, err)
+		}
+
+		if threshold < 0 || threshold > maxCoverage {
+			return fmt.Errorf("%w: --%s must be between 0 and %d", domain.ErrUnsupportedValue, flag, maxCoverage)
+		}
+	}
+
+	ctx := cmd.Context()
+
+	serviceFilesPaths, asyncAPIFilesPaths, err := specFilesWithSources(ctx, c.app, c.config, c.reporter,
+		cmd.ErrOrStderr())
+	if err != nil {
+		return fmt.Errorf("getting spec files paths: %w", err)
+	}
+
+	coverage, err := c.app.Coverage(ctx, domain.CoverageRequest{
+		ServiceFilesPaths:  serviceFilesPaths,
+		AsyncAPIFilesPaths: asyncAPIFilesPaths,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to measure coverage: %w", err)
+	}
+
+	return writeCoverage(cmd.OutOrStdout(), coverage, c.minOwnerCoverage, c.minDescriptionCoverage)
+}
+
+// writeCoverage prints the coverages with the services missing from the ones below their thresholds, and
+// returns an error naming the coverages below their thresholds.
+func writeCoverage(w io.Writer, coverage domain.Coverage, minOwnerCoverage, minDescriptionCoverage float64) error {
+	var (
+		b     strings.Builder
+		below []string
+	)
+
+	for _, metric := range []struct {
+		name      string
+		value     float64
+		threshold float64
+		missing   []string
+	}{
+		{"Owner", coverage.OwnerCoverage(), minOwnerCoverage, coverage.MissingOwners},
+		{"Description", coverage.DescriptionCoverage(), minDescriptionCoverage, coverage.MissingDescriptions},
+	} {
+		fmt.Fprintf(&b, "%s coverage: %s (%d of %d services)", metric.name, formatPercent(metric.value),
+			coverage.Services-len(metric.missing), coverage.Services)
+
+		if metric.value >= metric.threshold {
+			b.WriteString("\n")
+
+			continue
+		}
+
+		fmt.Fprintf(&b, ", below %s\n", formatPercent(metric.threshold))
+		fmt.Fprintf(&b, "  missing: %s\n", strings.Join(metric.missing, ", "))
+
+		below = append(below, fmt.Sprintf("%s coverage %s below %s", strings.ToLower(metric.name),
+			formatPercent(metric.value), formatPercent(metric.threshold)))
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("writing coverage: %w", err)
+	}
+
+	if len(below) > 0 {
+		return fmt.Errorf("%w: %s", domain.ErrCoverageTooLow, strings.Join(below, ", "))
+	}
+
+	return nil
+}
+
+// formatPercent formats a percentage with at most one decimal, rounded down so a coverage below its threshold
+// is never shown as equal to it, e.g. 87.5%.
+func formatPercent(percent float64) string {
+	const tenths = 10
+
+	return strconv.FormatFloat(math.Floor(percent*tenths)/tenths, 'f', -1, 64) + "%"
+}
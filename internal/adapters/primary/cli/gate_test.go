@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGateCommand(t *testing.T) {
+	t.Parallel()
+
+	cmd, err := NewGateCommand(setupTestInjector())
+	require.NoError(t, err)
+
+	cobraCmd := cmd.GetCommand()
+	assert.Equal(t, "gate", cobraCmd.Use)
+	assert.Equal(t, "0", cobraCmd.Flag("min-owner-coverage").DefValue)
+	assert.Equal(t, "0", cobraCmd.Flag("min-description-coverage").DefValue)
+}
+
+func TestWriteCoverage(t *testing.T) {
+	t.Parallel()
+
+	coverage := domain.Coverage{
+		Services:            8,
+		MissingOwners:       []string{"Billing"},
+		MissingDescriptions: []string{"Audit", "Billing", "Search"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, writeCoverage(&buf, coverage, 80, 50))
+	assert.Equal(t, `Owner coverage: 87.5% (7 of 8 services)
+Description coverage: 62.5% (5 of 8 services)
+`, buf.String())
+
+	buf.Reset()
+	err := writeCoverage(&buf, coverage, 90, 50)
+	require.ErrorIs(t, err, domain.ErrCoverageTooLow)
+	assert.EqualError(t, err, "coverage below threshold: owner coverage 87.5% below 90%")
+	assert.Equal(t, `Owner coverage: 87.5% (7 of 8 services), below 90%
+  missing: Billing
+Description coverage: 62.5% (5 of 8 services)
+`, buf.String())
+}
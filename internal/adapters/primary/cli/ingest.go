@@ -0,0 +1,212 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/holydocs/holydocs/internal/adapters/primary/ingest"
+	do "github.com/samber/do/v2"
+	"github.com/spf13/cobra"
+)
+
+// Server timeouts.
+const (
+	readHeaderTimeout = 10 * time.Second
+	shutdownTimeout   = 10 * time.Second
+)
+
+// IngestCommand represents the ingest command.
+type IngestCommand struct {
+	cmd *cobra.Command
+	commandDeps
+	genDocs *Command
+
+	listen   string
+	generate bool
+}
+
+func NewIngestCommand(i do.Injector) (*IngestCommand, error) {
+	c := &IngestCommand{
+		commandDeps: newCommandDeps(i),
+		genDocs:     do.MustInvoke[*Command](i),
+	}
+
+	c.cmd = &cobra.Command{
+		Use:   "ingest",
+		Short: "Accept specifications pushed by deploy pipelines",
+		Long: `Start an HTTP server accepting ServiceFile and AsyncAPI specifications, so documentation can be
+driven by deployment events instead of static files.
+
+Received specifications are persisted as sources in the ingest directory and expire after the
+configured TTL unless they are pushed again. gen-docs and diagram include active sources in
+addition to the configured input.
+
+API:
+  POST   /sources/{name}[?ttl=24h]  store the request body as a source
+  GET    /sources                   list active sources
+  DELETE /sources/{name}            remove a source
+
+When an ingest token is configured, requests must send it as "Authorization: Bearer <token>".
+Without a token the server only listens on the local host.
+
+With --generate documentation is regenerated in the background after every change, the requests
+are answered with 202 Accepted. Changes received while documentation is generated are covered by
+one more run.
+
+Examples:
+  # Accept specifications and regenerate documentation on every change
+  holydocs ingest --generate
+
+  # Accept specifications from other hosts, which requires a token
+  HOLYDOCS_INGEST_TOKEN=secret holydocs ingest --listen :8080
+
+  # Push a ServiceFile from a deploy pipeline
+  curl -X POST --data-binary @user.servicefile.yaml http://localhost:8080/sources/user-service`,
+		RunE: c.resolved(c.run),
+	}
+
+	c.cmd.Flags().StringVarP(&c.listen, "listen", "l", "localhost:8080",
+		"Address to listen on, addresses beyond the local host require ingest.token")
+	c.cmd.Flags().BoolVar(&c.generate, "generate", false, "Regenerate documentation after every received change")
+
+	return c, nil
+}
+
+// GetCommand returns the cobra command.
+func (c *IngestCommand) GetCommand() *cobra.Command {
+	return c.cmd
+}
+
+func (c *IngestCommand) run(cmd *cobra.Command, _ []string) error {
+	if err := checkListenAddress(c.listen, c.config.Ingest.Token); err != nil {
+		return err
+	}
+
+	// Interrupts cancel the context of the command, which shuts the server down gracefully.
+	ctx, cancel := context.WithCancel(cmd.Context())
+
+	var (
+		onChange    func()
+		regenerated <-chan struct{}
+	)
+
+	if c.generate {
+		onChange, regenerated = c.startRegeneration(ctx, cmd.OutOrStdout())
+	}
+
+	// Regeneration stops before the command returns.
+	defer func() {
+		cancel()
+
+		if regenerated != nil {
+			<-regenerated
+		}
+	}()
+
+	server := &http.Server{
+		Addr:              c.listen,
+		Handler:           ingest.NewHandler(c.app, c.config.Ingest.Token, onChange),
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.ListenAndServe()
+	}()
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Accepting sources on %s, storing them in %s\n", c.listen, c.config.Ingest.Dir)
+
+	select {
+	case err := <-serveErr:
+		if !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("serving ingest API: %w", err)
+		}
+
+		return nil
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancelShutdown()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("shutting down ingest API: %w", err)
+	}
+
+	return nil
+}
+
+// startRegeneration starts generating documentation in the background until ctx is done. It returns the
+// function requesting a regeneration after a change, which doesn't block, and a channel closed once it stopped.
+// Progress is printed to out.
+func (c *IngestCommand) startRegeneration(ctx context.Context, out io.Writer) (func(), <-chan struct{}) {
+	// A single pending regeneration covers all changes received until it starts.
+	pending := make(chan struct{}, 1)
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+		c.regenerate(ctx, out, pending)
+	}()
+
+	return func() {
+		select {
+		case pending <- struct{}{}:
+		default:
+		}
+	}, stopped
+}
+
+// regenerate generates the documentation for every pending change until ctx is done. Failures are reported
+// and the server keeps accepting sources.
+func (c *IngestCommand) regenerate(ctx context.Context, out io.Writer, pending <-chan struct{}) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-pending:
+			if err := c.generateDocumentation(ctx, out); err != nil {
+				c.genDocs.reporter.Error(fmt.Errorf("regenerating documentation: %w", err))
+			}
+		}
+	}
+}
+
+func (c *IngestCommand) generateDocumentation(ctx context.Context, out io.Writer) error {
+	if err := c.genDocs.prepareOutputDirectory(c.config.Output.Dir); err != nil {
+		return fmt.Errorf("failed to prepare output directory: %w", err)
+	}
+
+	if err := c.genDocs.generateDocumentation(ctx, c.config, false); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.genDocs.reporter.Progress(out), "Documentation regenerated in: %s\n", c.config.Output.Dir)
+
+	return nil
+}
+
+// checkListenAddress refuses to serve the ingest API beyond the local host without a token, anyone reaching it
+// could replace the documented specifications.
+func checkListenAddress(addr, token string) error {
+	if token != "" {
+		return nil
+	}
+
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return fmt.Errorf("invalid listen address %q: %w", addr, err)
+	}
+
+	if ip := net.ParseIP(host); host == "localhost" || (ip != nil && ip.IsLoopback()) {
+		return nil
+	}
+
+	return fmt.Errorf("listening on %s exposes the ingest API to the network, set ingest.token or listen on "+
+		"localhost", addr)
+}
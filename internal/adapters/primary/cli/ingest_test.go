@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"testing"
+
+	do "github.com/samber/do/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewIngestCommand(t *testing.T) {
+	t.Parallel()
+
+	injector := setupTestInjector()
+	do.Provide(injector, NewCommand)
+
+	cmd, err := NewIngestCommand(injector)
+	require.NoError(t, err)
+	require.NotNil(t, cmd)
+
+	cobraCmd := cmd.GetCommand()
+	assert.Equal(t, "ingest", cobraCmd.Use)
+	assert.Equal(t, "localhost:8080", cobraCmd.Flag("listen").DefValue)
+	assert.Equal(t, "false", cobraCmd.Flag("generate").DefValue)
+}
+
+func TestCheckListenAddress(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, checkListenAddress("localhost:8080", ""))
+	require.NoError(t, checkListenAddress("127.0.0.1:8080", ""))
+	require.NoError(t, checkListenAddress("[::1]:8080", ""))
+	require.NoError(t, checkListenAddress(":8080", "secret"))
+	require.ErrorContains(t, checkListenAddress(":8080", ""), "set ingest.token")
+	require.ErrorContains(t, checkListenAddress("0.0.0.0:8080", ""), "set ingest.token")
+	require.ErrorContains(t, checkListenAddress("8080", ""), "invalid listen address")
+}
+
+func TestAppendPath(t *testing.T) {
+	t.Parallel()
+
+	paths := []string{"specs/a.yaml", ".holydocs/sources/b.servicefile.yaml"}
+
+	assert.Equal(t, paths, appendPath(paths, "./.holydocs/sources/b.servicefile.yaml"))
+	assert.Equal(t, append(paths, "c.yaml"), appendPath(paths, "c.yaml"))
+}
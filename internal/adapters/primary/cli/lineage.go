@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/spf13/cobra"
+)
+
+func (c *ExportCommand) exportLineage(cmd *cobra.Command, _ []string) error {
+	format := domain.LineageExportFormat(c.lineageFormat)
+	if !slices.Contains(domain.LineageExportFormats(), format) {
+		return fmt.Errorf("%w: format %q, expected one of %v",
+			domain.ErrUnsupportedValue, format, domain.LineageExportFormats())
+	}
+
+	// Progress messages go to stderr so the lineage can be piped from stdout.
+	ctx := cmd.Context()
+
+	serviceFilesPaths, asyncAPIFilesPaths, err := specFilesWithSources(ctx, c.app, c.config, c.reporter, cmd.ErrOrStderr())
+	if err != nil {
+		return fmt.Errorf("getting spec files paths: %w", err)
+	}
+
+	export, err := c.app.ExportLineage(ctx, domain.ExportLineageRequest{
+		ServiceFilesPaths:  serviceFilesPaths,
+		AsyncAPIFilesPaths: asyncAPIFilesPaths,
+		Format:             format,
+		Namespace:          c.lineageNamespace,
+		GlobalName:         c.config.Output.GlobalName,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export lineage: %w", err)
+	}
+
+	if c.lineageOutput == stdoutOutput {
+		if _, err := cmd.OutOrStdout().Write(export.Content); err != nil {
+			return fmt.Errorf("writing lineage: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := os.WriteFile(c.lineageOutput, export.Content, filePerm); err != nil {
+		return fmt.Errorf("writing lineage %s: %w", c.lineageOutput, err)
+	}
+
+	fmt.Fprintf(cmd.ErrOrStderr(), "Exported lineage of %d services: %s\n", export.Jobs, c.lineageOutput)
+
+	return nil
+}
+
+func lineageFormatCompletion(_ *cobra.Command, _ []string, _ string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	formats := domain.LineageExportFormats()
+
+	completions := make([]cobra.Completion, 0, len(formats))
+	for _, format := range formats {
+		completions = append(completions, string(format))
+	}
+
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,151 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	do "github.com/samber/do/v2"
+	"github.com/spf13/cobra"
+)
+
+// MergeCommand represents the merge command.
+type MergeCommand struct {
+	cmd *cobra.Command
+	commandDeps
+	reporter *Reporter
+
+	services []string
+	explain  bool
+}
+
+func NewMergeCommand(i do.Injector) (*MergeCommand, error) {
+	c := &MergeCommand{
+		commandDeps: newCommandDeps(i),
+		reporter:    do.MustInvoke[*Reporter](i),
+	}
+
+	c.cmd = &cobra.Command{
+		Use:   "merge",
+		Short: "Merge the specifications without generating documentation",
+		Long: `Merge the ServiceFiles, AsyncAPI files and relationship rules the same way as for gen-docs and
+print the merged services as JSON, without writing any documentation.
+
+With --explain, the merge is explained instead: for every service, the files declaring it, which
+file each field was taken from and which values of other files were overridden, and the files
+declaring its tags, relationships and operations. This helps finding out why a description or an
+owner in the documentation isn't the one a team expects.
+
+Input files are taken from the configuration the same way as for gen-docs.
+
+Examples:
+  # Explain where the fields of a service come from
+  holydocs merge --explain --service "Order Service"
+
+  # Print the merged schema
+  holydocs merge > merged.json`,
+		Args: cobra.NoArgs,
+		RunE: c.resolved(c.run),
+	}
+	c.cmd.Flags().StringSliceVarP(&c.services, "service", "s", nil,
+		"Services to print, repeatable (all when not set)")
+	c.cmd.Flags().BoolVar(&c.explain, "explain", false,
+		"Explain which files contributed the fields of the services instead of printing the merged schema")
+	_ = c.cmd.RegisterFlagCompletionFunc("service", serviceNameCompletion(&c.commandDeps))
+
+	return c, nil
+}
+
+// GetCommand returns the cobra command.
+func (c *MergeCommand) GetCommand() *cobra.Command {
+	return c.cmd
+}
+
+func (c *MergeCommand) run(cmd *cobra.Command, _ []string) error {
+	// Progress messages go to stderr so the output can be piped from stdout.
+	ctx := cmd.Context()
+
+	serviceFilesPaths, asyncAPIFilesPaths, err := specFilesWithSources(ctx, c.app, c.config, c.reporter,
+		cmd.ErrOrStderr())
+	if err != nil {
+		return fmt.Errorf("getting spec files paths: %w", err)
+	}
+
+	req := domain.MergeRequest{
+		ServiceFilesPaths:  serviceFilesPaths,
+		AsyncAPIFilesPaths: asyncAPIFilesPaths,
+		Services:           c.services,
+	}
+
+	if c.explain {
+		provenance, err := c.app.ExplainMerge(ctx, req)
+		if err != nil {
+			return fmt.Errorf("failed to explain merge: %w", err)
+		}
+
+		return writeProvenance(cmd.OutOrStdout(), provenance)
+	}
+
+	schema, err := c.app.MergeSchemas(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to merge specifications: %w", err)
+	}
+
+	encoder := json.NewEncoder(cmd.OutOrStdout())
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(schema); err != nil {
+		return fmt.Errorf("encoding merged schema: %w", err)
+	}
+
+	return nil
+}
+
+// writeProvenance prints the provenance of every service as an indented outline.
+func writeProvenance(w io.Writer, provenance []domain.ServiceProvenance) error {
+	var b strings.Builder
+
+	for i, service := range provenance {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+
+		fmt.Fprintf(&b, "%s\n", service.Service)
+		fmt.Fprintf(&b, "  sources: %s\n", strings.Join(service.Sources, ", "))
+		writeFieldProvenance(&b, "  ", service.Fields)
+		writeItemProvenance(&b, "tags", service.Tags)
+		writeItemProvenance(&b, "relationships", service.Relationships)
+		writeItemProvenance(&b, "operations", service.Operations)
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("writing merge explanation: %w", err)
+	}
+
+	return nil
+}
+
+func writeFieldProvenance(b *strings.Builder, indent string, fields []domain.FieldProvenance) {
+	for _, field := range fields {
+		fmt.Fprintf(b, "%s%s: %q from %s\n", indent, field.Field, field.Value, field.Source)
+
+		for _, overridden := range field.Overridden {
+			fmt.Fprintf(b, "%s  overrides %q from %s\n", indent, overridden.Value, overridden.Source)
+		}
+	}
+}
+
+func writeItemProvenance(b *strings.Builder, name string, items []domain.ItemProvenance) {
+	if len(items) == 0 {
+		return
+	}
+
+	fmt.Fprintf(b, "  %s:\n", name)
+
+	for _, item := range items {
+		fmt.Fprintf(b, "    %s from %s\n", item.Item, strings.Join(item.Sources, ", "))
+		writeFieldProvenance(b, "      ", item.Fields)
+	}
+}
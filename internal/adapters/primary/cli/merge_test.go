@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMergeCommand(t *testing.T) {
+	t.Parallel()
+
+	cmd, err := NewMergeCommand(setupTestInjector())
+	require.NoError(t, err)
+
+	cobraCmd := cmd.GetCommand()
+	assert.Equal(t, "merge", cobraCmd.Use)
+	assert.Equal(t, "false", cobraCmd.Flag("explain").DefValue)
+	assert.NotNil(t, cobraCmd.Flag("service"))
+}
+
+func TestWriteProvenance(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, writeProvenance(&buf, []domain.ServiceProvenance{
+		{
+			Service: "Orders",
+			Sources: []string{"orders.servicefile.yaml", "orders.asyncapi.yaml"},
+			Fields: []domain.FieldProvenance{{
+				Field:      "description",
+				Value:      "Handles orders",
+				Source:     "orders.servicefile.yaml",
+				Overridden: []domain.SourcedValue{{Value: "Orders", Source: "orders.asyncapi.yaml"}},
+			}},
+			Relationships: []domain.ItemProvenance{{
+				Item:    "uses PostgreSQL via SQL",
+				Sources: []string{"orders.servicefile.yaml", "input.relationships"},
+				Fields: []domain.FieldProvenance{
+					{Field: "description", Value: "Database", Source: "input.relationships"},
+				},
+			}},
+		},
+		{Service: "Payments", Sources: []string{"payments.servicefile.yaml"}},
+	}))
+
+	assert.Equal(t, `Orders
+  sources: orders.servicefile.yaml, orders.asyncapi.yaml
+  description: "Handles orders" from orders.servicefile.yaml
+    overrides "Orders" from orders.asyncapi.yaml
+  relationships:
+    uses PostgreSQL via SQL from orders.servicefile.yaml, input.relationships
+      description: "Database" from input.relationships
+
+Payments
+  sources: payments.servicefile.yaml
+`, buf.String())
+}
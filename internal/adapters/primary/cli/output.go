@@ -0,0 +1,201 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sync"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	do "github.com/samber/do/v2"
+)
+
+// OutputFormat is the format errors and warnings of commands are reported in.
+type OutputFormat string
+
+// Output formats.
+const (
+	OutputFormatText OutputFormat = "text"
+	OutputFormatJSON OutputFormat = "json"
+)
+
+// OutputFormats returns the supported output formats.
+func OutputFormats() []OutputFormat {
+	return []OutputFormat{OutputFormatText, OutputFormatJSON}
+}
+
+// Levels of reported messages.
+const (
+	levelError   = "error"
+	levelWarning = "warning"
+)
+
+// Codes of warnings, telling where they were reported.
+const (
+	codeSourceWarning        = "source_warning"
+	codeDocumentationWarning = "documentation_warning"
+	codePublishWarning       = "publish_warning"
+)
+
+// errorCodes maps errors to the codes they are reported with, the first matching error wins.
+//
+//nolint:gochecknoglobals // Lookup table of errors, which are package variables themselves.
+var errorCodes = []struct {
+	err  error
+	code string
+}{
+	{domain.ErrValidationFailed, "validation_failed"},
+	{domain.ErrCoverageTooLow, "coverage_too_low"},
+	{domain.ErrStrictWarnings, "strict_warnings"},
+	{domain.ErrPartialGeneration, "partial_generation"},
+	{domain.ErrFetchFailed, "fetch_failed"},
+	{domain.ErrPublishFailed, "publish_failed"},
+	{domain.ErrPluginFailed, "plugin_failed"},
+	{domain.ErrPluginNotFound, "plugin_not_found"},
+	{domain.ErrServiceNotFound, "service_not_found"},
+	{domain.ErrSourceNotFound, "source_not_found"},
+	{domain.ErrReleaseExists, "release_exists"},
+	{domain.ErrInvalidSource, "invalid_source"},
+	{domain.ErrUnsupportedValue, "unsupported_value"},
+	{domain.ErrNoDiagramData, "no_diagram_data"},
+	{domain.ErrNoAsyncOperations, "no_async_operations"},
+	{ErrNoSpecFilesProvided, "no_spec_files"},
+	{ErrNoSpecFilesFound, "no_spec_files"},
+}
+
+// errorCode returns the code of an error, "error" for errors without a code.
+func errorCode(err error) string {
+	for _, c := range errorCodes {
+		if errors.Is(err, c.err) {
+			return c.code
+		}
+	}
+
+	return levelError
+}
+
+// message is an error, warning or finding reported in the JSON output format, one per line.
+type message struct {
+	Level   string `json:"level"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	// Subject is the service or channel of a finding.
+	Subject string `json:"subject,omitempty"`
+}
+
+// Reporter reports errors, warnings and progress of commands in the output format of the command line.
+//
+// In the JSON format, errors, warnings and findings are written to the standard error as JSON objects, one
+// per line, with a code programs can rely on. Progress isn't reported in the JSON format and with --quiet,
+// so only the summary of a command is printed.
+type Reporter struct {
+	mu     sync.Mutex
+	format OutputFormat
+	quiet  bool
+	stderr io.Writer
+}
+
+func NewReporter(_ do.Injector) (*Reporter, error) {
+	return &Reporter{format: OutputFormatText, stderr: os.Stderr}, nil
+}
+
+// Configure sets the output format and whether progress is left out.
+func (r *Reporter) Configure(format OutputFormat, quiet bool) error {
+	if !slices.Contains(OutputFormats(), format) {
+		return fmt.Errorf("%w: output format %s, expected one of %v", domain.ErrUnsupportedValue, format,
+			OutputFormats())
+	}
+
+	r.format = format
+	r.quiet = quiet
+
+	return nil
+}
+
+// JSON tells whether messages are reported in the JSON format.
+func (r *Reporter) JSON() bool {
+	return r.format == OutputFormatJSON
+}
+
+// Progress returns out, or a writer discarding progress in the JSON format and with --quiet.
+func (r *Reporter) Progress(out io.Writer) io.Writer {
+	if r.quiet || r.JSON() {
+		return io.Discard
+	}
+
+	return out
+}
+
+// Warning reports a warning, printed to out in the text format.
+func (r *Reporter) Warning(out io.Writer, code, warning string) {
+	if r.JSON() {
+		r.write(message{Level: levelWarning, Code: code, Message: warning})
+
+		return
+	}
+
+	fmt.Fprintln(out, "Warning:", warning)
+}
+
+// Warnings reports warnings, printed to out as a list in the text format.
+func (r *Reporter) Warnings(out io.Writer, code string, warnings []string) {
+	if r.JSON() {
+		for _, warning := range warnings {
+			r.write(message{Level: levelWarning, Code: code, Message: warning})
+		}
+
+		return
+	}
+
+	if len(warnings) == 0 {
+		return
+	}
+
+	fmt.Fprintf(out, "\nWarnings:\n")
+
+	for _, warning := range warnings {
+		fmt.Fprintf(out, "• %s\n", warning)
+	}
+}
+
+// Finding reports a finding of the validate command, its code is the rule reporting it.
+func (r *Reporter) Finding(out io.Writer, finding domain.Finding) {
+	if r.JSON() {
+		r.write(message{
+			Level:   levelError,
+			Code:    string(finding.Rule),
+			Message: finding.Message,
+			Subject: finding.Subject,
+		})
+
+		return
+	}
+
+	fmt.Fprintln(out, "•", finding)
+}
+
+// Error reports the error a command failed with.
+func (r *Reporter) Error(err error) {
+	if r.JSON() {
+		r.write(message{Level: levelError, Code: errorCode(err), Message: err.Error()})
+
+		return
+	}
+
+	fmt.Fprintf(r.stderr, "Error: %v\n", err)
+}
+
+func (r *Reporter) write(m message) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	line, err := json.Marshal(m)
+	if err != nil {
+		return
+	}
+
+	fmt.Fprintf(r.stderr, "%s\n", line)
+}
@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReporter_Text(t *testing.T) {
+	t.Parallel()
+
+	var out, stderr bytes.Buffer
+
+	reporter := &Reporter{format: OutputFormatText, stderr: &stderr}
+
+	assert.Equal(t, &out, reporter.Progress(&out))
+
+	reporter.Warning(&out, codeSourceWarning, "catalog unreachable")
+	reporter.Warnings(&out, codeDocumentationWarning, []string{"unknown participant Billing"})
+	reporter.Finding(&out, domain.Finding{Rule: domain.FindingRuleMissingDLQ, Subject: "orders", Message: "no dlq"})
+	reporter.Error(fmt.Errorf("generating documentation: %w", domain.ErrStrictWarnings))
+
+	assert.Equal(t, "Warning: catalog unreachable\n\nWarnings:\n• unknown participant Billing\n"+
+		"• orders: no dlq (missing-dlq)\n", out.String())
+	assert.Equal(t, "Error: generating documentation: warnings reported in strict mode\n", stderr.String())
+
+	require.NoError(t, reporter.Configure(OutputFormatText, true))
+	assert.Equal(t, io.Discard, reporter.Progress(&out))
+}
+
+func TestReporter_JSON(t *testing.T) {
+	t.Parallel()
+
+	var out, stderr bytes.Buffer
+
+	reporter := &Reporter{stderr: &stderr}
+	require.NoError(t, reporter.Configure(OutputFormatJSON, false))
+	require.ErrorIs(t, reporter.Configure("xml", false), domain.ErrUnsupportedValue)
+
+	assert.Equal(t, io.Discard, reporter.Progress(os.Stdout))
+
+	reporter.Warning(&out, codeSourceWarning, "catalog unreachable")
+	reporter.Finding(&out, domain.Finding{Rule: domain.FindingRuleMissingDLQ, Subject: "orders", Message: "no dlq"})
+	reporter.Error(fmt.Errorf("validating: %w: 1 findings", domain.ErrValidationFailed))
+
+	assert.Empty(t, out.String())
+	assert.Equal(t, `{"level":"warning","code":"source_warning","message":"catalog unreachable"}
+{"level":"error","code":"missing-dlq","message":"no dlq","subject":"orders"}
+{"level":"error","code":"validation_failed","message":"validating: validation failed: 1 findings"}
+`, stderr.String())
+}
+
+func TestErrorCode(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "no_spec_files", errorCode(fmt.Errorf("getting spec files paths: %w", ErrNoSpecFilesFound)))
+	assert.Equal(t, "plugin_failed", errorCode(fmt.Errorf("%w: exit status 1", domain.ErrPluginFailed)))
+	assert.Equal(t, "error", errorCode(os.ErrPermission))
+}
@@ -0,0 +1,222 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	do "github.com/samber/do/v2"
+	"github.com/spf13/cobra"
+)
+
+// PreviewCommand represents the preview command.
+type PreviewCommand struct {
+	cmd *cobra.Command
+	commandDeps
+	genDocs *Command
+
+	slug     string
+	pr       int
+	uploader string
+	baseURL  string
+}
+
+func NewPreviewCommand(i do.Injector) (*PreviewCommand, error) {
+	c := &PreviewCommand{
+		commandDeps: newCommandDeps(i),
+		genDocs:     do.MustInvoke[*Command](i),
+	}
+
+	c.cmd = &cobra.Command{
+		Use:   "preview",
+		Short: "Build and upload a documentation preview of a pull request",
+		Long: `Generate the documentation of the current branch into its own directory below publish.preview.dir,
+upload it to static hosting and print the URL of the preview as the last line, ready for a pull
+request comment.
+
+Every preview has a slug, the path it is served below: the --slug flag, pr-<number> with --pr or
+the name of the current git branch. Uploading a preview again replaces the earlier upload.
+
+The documentation on the main branch is left alone. The schema recorded in its domain.json is copied
+to the preview, so the changelog of the preview lists the changes of the pull request.
+
+Uploaders:
+  command  Run publish.preview.upload_command, {dir} is replaced by the generated directory and
+           {slug} by the slug, e.g. "aws s3 sync {dir} s3://previews/{slug}"
+  dir      Copy the preview below publish.preview.target_dir, e.g. a checkout of a gh-pages branch
+
+The URL is the slug below publish.preview.base_url. Without a base URL, the last line printed by the
+upload command is the URL, as hosting CLIs deploying to generated URLs print it.
+
+Examples:
+  # Preview a pull request and keep the URL for a comment
+  url=$(holydocs preview --pr 42 | tail -n 1)
+
+  # Copy the preview of the current branch into a GitHub Pages checkout
+  holydocs preview --uploader dir --base-url https://org.github.io/architecture`,
+		Args: cobra.NoArgs,
+		RunE: c.resolved(c.run),
+	}
+
+	c.cmd.Flags().StringVar(&c.slug, "slug", "", "Slug of the preview (defaults to pr-<number> or the git branch)")
+	c.cmd.Flags().IntVar(&c.pr, "pr", 0, "Number of the pull request, the slug is pr-<number>")
+	c.cmd.Flags().StringVar(&c.uploader, "uploader", "",
+		"Uploader of the preview: command or dir (overrides publish.preview.uploader)")
+	c.cmd.Flags().StringVar(&c.baseURL, "base-url", "",
+		"URL previews are served from (overrides publish.preview.base_url)")
+	_ = c.cmd.RegisterFlagCompletionFunc("uploader", previewUploaderCompletion)
+
+	return c, nil
+}
+
+// GetCommand returns the cobra command.
+func (c *PreviewCommand) GetCommand() *cobra.Command {
+	return c.cmd
+}
+
+func (c *PreviewCommand) run(cmd *cobra.Command, _ []string) error {
+	slug, err := c.previewSlug()
+	if err != nil {
+		return err
+	}
+
+	req := c.uploadRequest(slug)
+
+	switch {
+	case !slices.Contains(domain.PreviewUploaders(), req.Uploader):
+		return fmt.Errorf("invalid uploader: %s (must be command or dir)", req.Uploader)
+	case req.Uploader == domain.PreviewUploaderCommand && req.Command == "":
+		return errors.New("upload command is required, set publish.preview.upload_command")
+	case req.Uploader == domain.PreviewUploaderDir && req.TargetDir == "":
+		return errors.New("target directory is required, set publish.preview.target_dir")
+	}
+
+	ctx := cmd.Context()
+
+	// Pages of earlier pushes to the pull request must not linger in the preview.
+	if err := os.RemoveAll(req.Dir); err != nil {
+		return fmt.Errorf("failed to remove previous preview: %w", err)
+	}
+
+	if err := c.genDocs.prepareOutputDirectory(req.Dir); err != nil {
+		return fmt.Errorf("failed to prepare output directory: %w", err)
+	}
+
+	if err := c.app.PreparePreview(ctx, c.config.Output.Dir, req.Dir); err != nil {
+		return fmt.Errorf("failed to prepare preview: %w", err)
+	}
+
+	c.config.Output.Dir = req.Dir
+
+	if err := c.genDocs.generateDocumentation(ctx, c.config, true); err != nil {
+		return fmt.Errorf("failed to generate documentation: %w", err)
+	}
+
+	reply, err := c.app.UploadPreview(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to upload preview: %w", err)
+	}
+
+	out := cmd.OutOrStdout()
+
+	if reply.URL == "" {
+		fmt.Fprintf(out, "Preview %s uploaded, set publish.preview.base_url to print its URL\n", slug)
+
+		return nil
+	}
+
+	fmt.Fprintf(out, "Preview %s uploaded:\n", slug)
+	fmt.Fprintln(out, reply.URL)
+
+	return nil
+}
+
+func (c *PreviewCommand) uploadRequest(slug string) domain.UploadPreviewRequest {
+	preview := c.config.Publish.Preview
+
+	req := domain.UploadPreviewRequest{
+		Uploader:  domain.PreviewUploader(preview.Uploader),
+		Dir:       filepath.Join(preview.Dir, slug),
+		Slug:      slug,
+		Command:   preview.UploadCommand,
+		TargetDir: preview.TargetDir,
+		BaseURL:   preview.BaseURL,
+	}
+
+	if c.uploader != "" {
+		req.Uploader = domain.PreviewUploader(c.uploader)
+	}
+
+	if c.baseURL != "" {
+		req.BaseURL = c.baseURL
+	}
+
+	return req
+}
+
+// previewSlug returns the slug of the preview from the flags, or from the current git branch.
+func (c *PreviewCommand) previewSlug() (string, error) {
+	value := c.slug
+
+	switch {
+	case value != "":
+	case c.pr > 0:
+		value = "pr-" + strconv.Itoa(c.pr)
+	default:
+		output, err := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD").Output()
+		if err != nil {
+			return "", fmt.Errorf("finding the git branch, pass --slug or --pr: %w", err)
+		}
+
+		value = strings.TrimSpace(string(output))
+	}
+
+	slug := slugify(value)
+	if slug == "" || slug == "head" {
+		return "", fmt.Errorf("no preview slug can be made of %q, pass --slug or --pr", value)
+	}
+
+	return slug, nil
+}
+
+// slugify lowercases a name and replaces every run of characters other than letters and digits with a dash,
+// e.g. feature/Order-Events becomes feature-order-events.
+func slugify(name string) string {
+	var b strings.Builder
+
+	dash := false
+
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+
+			b.WriteRune(r)
+			dash = false
+
+			continue
+		}
+
+		dash = true
+	}
+
+	return b.String()
+}
+
+func previewUploaderCompletion(_ *cobra.Command, _ []string, _ string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	uploaders := domain.PreviewUploaders()
+
+	completions := make([]cobra.Completion, 0, len(uploaders))
+	for _, uploader := range uploaders {
+		completions = append(completions, string(uploader))
+	}
+
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
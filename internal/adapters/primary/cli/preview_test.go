@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	do "github.com/samber/do/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPreviewCommand(t *testing.T) {
+	t.Parallel()
+
+	injector := setupTestInjector()
+	do.Provide(injector, NewCommand)
+
+	cmd, err := NewPreviewCommand(injector)
+	require.NoError(t, err)
+	require.NotNil(t, cmd)
+
+	cobraCmd := cmd.GetCommand()
+	assert.Equal(t, "preview", cobraCmd.Use)
+	assert.Equal(t, "0", cobraCmd.Flag("pr").DefValue)
+	assert.Empty(t, cobraCmd.Flag("slug").DefValue)
+	require.NoError(t, cmd.resolve())
+	assert.Equal(t, "command", cmd.config.Publish.Preview.Uploader)
+}
+
+func TestPreviewCommand_uploadRequest(t *testing.T) {
+	t.Parallel()
+
+	injector := setupTestInjector()
+	do.Provide(injector, NewCommand)
+
+	cmd, err := NewPreviewCommand(injector)
+	require.NoError(t, err)
+	require.NoError(t, cmd.resolve())
+
+	cmd.pr = 42
+	slug, err := cmd.previewSlug()
+	require.NoError(t, err)
+	assert.Equal(t, "pr-42", slug)
+
+	cmd.slug = "Feature/Order Events"
+	slug, err = cmd.previewSlug()
+	require.NoError(t, err)
+	assert.Equal(t, "feature-order-events", slug)
+
+	cmd.slug = "//"
+	_, err = cmd.previewSlug()
+	require.Error(t, err)
+
+	cmd.uploader = "dir"
+	cmd.baseURL = "https://previews.example.com"
+	req := cmd.uploadRequest("pr-42")
+	assert.Equal(t, domain.PreviewUploaderDir, req.Uploader)
+	assert.Equal(t, "https://previews.example.com", req.BaseURL)
+	assert.Equal(t, ".holydocs/previews/pr-42", req.Dir)
+}
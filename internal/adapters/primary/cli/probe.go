@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	do "github.com/samber/do/v2"
+	"github.com/spf13/cobra"
+)
+
+const probeColumnPadding = 2
+
+// ProbeCommand represents the probe command.
+type ProbeCommand struct {
+	cmd *cobra.Command
+	commandDeps
+	reporter *Reporter
+
+	environments []string
+	timeout      time.Duration
+}
+
+func NewProbeCommand(i do.Injector) (*ProbeCommand, error) {
+	c := &ProbeCommand{
+		commandDeps: newCommandDeps(i),
+		reporter:    do.MustInvoke[*Reporter](i),
+	}
+
+	c.cmd = &cobra.Command{
+		Use:   "probe",
+		Short: "Check the reachability of the health endpoints of deployments",
+		Long: `Request the health and readiness endpoints of the deployments declared by services (info.deployments
+in ServiceFiles) once and record the results as probes.json in the output directory. The next gen-docs
+run shows them in the Deployments sections of the services, marked as a point-in-time check.
+
+Input files are taken from the configuration the same way as for gen-docs. Endpoints answering with
+a 2xx status are healthy, redirects are not followed. The results of a run replace earlier ones.
+
+Examples:
+  # Probe all deployments, then generate the documentation showing the results
+  holydocs probe && holydocs gen-docs
+
+  # Probe the production deployments for a handover document
+  holydocs probe --environment production --timeout 10s`,
+		Args: cobra.NoArgs,
+		RunE: c.resolved(c.run),
+	}
+	c.cmd.Flags().StringSliceVar(&c.environments, "environment", nil,
+		"Environments whose deployments are probed, repeatable (all when not set)")
+	c.cmd.Flags().DurationVar(&c.timeout, "timeout", 5*time.Second, "Timeout of probing a single endpoint")
+
+	return c, nil
+}
+
+// GetCommand returns the cobra command.
+func (c *ProbeCommand) GetCommand() *cobra.Command {
+	return c.cmd
+}
+
+func (c *ProbeCommand) run(cmd *cobra.Command, _ []string) error {
+	if c.timeout <= 0 {
+		return fmt.Errorf("invalid --timeout %s, expected a positive duration", c.timeout)
+	}
+
+	ctx := cmd.Context()
+
+	serviceFilesPaths, asyncAPIFilesPaths, err := specFilesWithSources(ctx, c.app, c.config, c.reporter,
+		cmd.ErrOrStderr())
+	if err != nil {
+		return fmt.Errorf("getting spec files paths: %w", err)
+	}
+
+	reply, err := c.app.ProbeDeployments(ctx, domain.ProbeDeploymentsRequest{
+		ServiceFilesPaths:  serviceFilesPaths,
+		AsyncAPIFilesPaths: asyncAPIFilesPaths,
+		OutputDir:          c.config.Output.Dir,
+		Environments:       c.environments,
+		Timeout:            c.timeout,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to probe deployments: %w", err)
+	}
+
+	if len(reply.Report.Results) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "No deployments with health endpoints to probe")
+
+		return nil
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, probeColumnPadding, ' ', 0)
+
+	for _, result := range reply.Report.Results {
+		status := "unreachable: " + result.Error
+		if result.Reachable {
+			status = fmt.Sprintf("%d in %d ms", result.StatusCode, result.LatencyMS)
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", result.Service, result.Environment, result.Kind, status)
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("writing probe results: %w", err)
+	}
+
+	return nil
+}
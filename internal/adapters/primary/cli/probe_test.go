@@ -0,0 +1,25 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProbeCommand_Flags(t *testing.T) {
+	t.Parallel()
+
+	cmd, err := NewProbeCommand(setupTestInjector())
+	require.NoError(t, err)
+
+	cobraCmd := cmd.GetCommand()
+	cobraCmd.SetArgs([]string{"production"})
+	require.ErrorContains(t, cobraCmd.Execute(), "unknown command")
+
+	cmd, err = NewProbeCommand(setupTestInjector())
+	require.NoError(t, err)
+
+	cobraCmd = cmd.GetCommand()
+	cobraCmd.SetArgs([]string{"--timeout", "0s"})
+	require.ErrorContains(t, cobraCmd.Execute(), "invalid --timeout")
+}
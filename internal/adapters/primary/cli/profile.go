@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/spf13/cobra"
+)
+
+// Supported profile kinds.
+const (
+	profileCPU = "cpu"
+	profileMem = "mem"
+)
+
+// startProfile starts collecting a pprof profile of the given kind and returns the function writing it to the
+// path, which defaults to holydocs-<kind>.pprof. CPU profiles cover the time between the two calls, memory
+// profiles capture the heap when the returned function is called. An empty kind disables profiling.
+func startProfile(kind, path string) (func() error, error) {
+	if kind == "" {
+		return func() error { return nil }, nil
+	}
+
+	if kind != profileCPU && kind != profileMem {
+		return nil, fmt.Errorf("%w: profile %q, expected %s or %s",
+			domain.ErrUnsupportedValue, kind, profileCPU, profileMem)
+	}
+
+	if path == "" {
+		path = "holydocs-" + kind + ".pprof"
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("creating profile file %s: %w", path, err)
+	}
+
+	if kind == profileCPU {
+		if err := pprof.StartCPUProfile(file); err != nil {
+			file.Close()
+
+			return nil, fmt.Errorf("starting CPU profile: %w", err)
+		}
+
+		return func() error {
+			pprof.StopCPUProfile()
+
+			return closeProfile(file)
+		}, nil
+	}
+
+	return func() error {
+		// Collect garbage first so the profile shows live memory rather than unswept allocations.
+		runtime.GC()
+
+		if err := pprof.WriteHeapProfile(file); err != nil {
+			file.Close()
+
+			return fmt.Errorf("writing memory profile: %w", err)
+		}
+
+		return closeProfile(file)
+	}, nil
+}
+
+func closeProfile(file *os.File) error {
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("closing profile file %s: %w", file.Name(), err)
+	}
+
+	fmt.Fprintln(os.Stderr, "Profile written to:", file.Name())
+
+	return nil
+}
+
+func profileCompletion(_ *cobra.Command, _ []string, _ string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	return []cobra.Completion{profileCPU, profileMem}, cobra.ShellCompDirectiveNoFileComp
+}
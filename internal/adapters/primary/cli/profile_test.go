@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartProfile(t *testing.T) {
+	t.Parallel()
+
+	for _, kind := range []string{profileCPU, profileMem} {
+		t.Run(kind, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), kind+".pprof")
+
+			stop, err := startProfile(kind, path)
+			require.NoError(t, err)
+			require.NoError(t, stop())
+
+			info, err := os.Stat(path)
+			require.NoError(t, err)
+			assert.Positive(t, info.Size())
+		})
+	}
+}
+
+func TestStartProfile_Disabled(t *testing.T) {
+	t.Parallel()
+
+	stop, err := startProfile("", "")
+	require.NoError(t, err)
+	require.NoError(t, stop())
+}
+
+func TestStartProfile_UnsupportedKind(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "block.pprof")
+
+	_, err := startProfile("block", path)
+	require.ErrorIs(t, err, domain.ErrUnsupportedValue)
+	assert.NoFileExists(t, path)
+}
@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/spf13/cobra"
+)
+
+// radarColumns are the fields of "Build your own Radar" sheets.
+//
+//nolint:gochecknoglobals // Fixed header of the radar sheets.
+var radarColumns = []string{"name", "ring", "quadrant", "isNew", "description"}
+
+// radarIsNew is the isNew field of every entry, the radar is aggregated without history.
+const radarIsNew = "FALSE"
+
+// radarBlip is a technology in the JSON format of "Build your own Radar".
+type radarBlip struct {
+	Name        string `json:"name"`
+	Ring        string `json:"ring"`
+	Quadrant    string `json:"quadrant"`
+	IsNew       string `json:"isNew"`
+	Description string `json:"description"`
+}
+
+func (c *ExportCommand) exportRadar(cmd *cobra.Command, _ []string) error {
+	format := domain.RadarExportFormat(c.radarFormat)
+	if !slices.Contains(domain.RadarExportFormats(), format) {
+		return fmt.Errorf("%w: format %q, expected one of %v",
+			domain.ErrUnsupportedValue, format, domain.RadarExportFormats())
+	}
+
+	// Progress messages go to stderr so the radar can be piped from stdout.
+	ctx := cmd.Context()
+
+	serviceFilesPaths, asyncAPIFilesPaths, err := specFilesWithSources(ctx, c.app, c.config, c.reporter, cmd.ErrOrStderr())
+	if err != nil {
+		return fmt.Errorf("getting spec files paths: %w", err)
+	}
+
+	entries, err := c.app.ExportRadar(ctx, domain.ExportRadarRequest{
+		ServiceFilesPaths:  serviceFilesPaths,
+		AsyncAPIFilesPaths: asyncAPIFilesPaths,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export radar: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := encodeRadar(&buf, format, entries); err != nil {
+		return err
+	}
+
+	if c.radarOutput == stdoutOutput {
+		if _, err := cmd.OutOrStdout().Write(buf.Bytes()); err != nil {
+			return fmt.Errorf("writing radar: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := os.WriteFile(c.radarOutput, buf.Bytes(), filePerm); err != nil {
+		return fmt.Errorf("writing radar %s: %w", c.radarOutput, err)
+	}
+
+	fmt.Fprintf(cmd.ErrOrStderr(), "Exported %d technologies: %s\n", len(entries), c.radarOutput)
+
+	return nil
+}
+
+func radarBlips(entries []domain.RadarEntry) []radarBlip {
+	blips := make([]radarBlip, 0, len(entries))
+
+	for _, entry := range entries {
+		blips = append(blips, radarBlip{
+			Name:        entry.Name,
+			Ring:        entry.Ring,
+			Quadrant:    entry.Quadrant,
+			IsNew:       radarIsNew,
+			Description: "Used by " + strings.Join(entry.Services, ", "),
+		})
+	}
+
+	return blips
+}
+
+func encodeRadar(w io.Writer, format domain.RadarExportFormat, entries []domain.RadarEntry) error {
+	blips := radarBlips(entries)
+
+	if format == domain.RadarExportFormatJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+
+		if err := encoder.Encode(blips); err != nil {
+			return fmt.Errorf("encoding radar as JSON: %w", err)
+		}
+
+		return nil
+	}
+
+	rows := make([][]string, 0, len(blips)+1)
+	rows = append(rows, radarColumns)
+
+	for _, blip := range blips {
+		rows = append(rows, []string{blip.Name, blip.Ring, blip.Quadrant, blip.IsNew, blip.Description})
+	}
+
+	if err := csv.NewWriter(w).WriteAll(rows); err != nil {
+		return fmt.Errorf("encoding radar as CSV: %w", err)
+	}
+
+	return nil
+}
+
+func radarFormatCompletion(_ *cobra.Command, _ []string, _ string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	formats := domain.RadarExportFormats()
+
+	completions := make([]cobra.Completion, 0, len(formats))
+	for _, format := range formats {
+		completions = append(completions, string(format))
+	}
+
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
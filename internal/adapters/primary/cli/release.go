@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	do "github.com/samber/do/v2"
+	"github.com/spf13/cobra"
+)
+
+// ReleaseCommand represents the release command.
+type ReleaseCommand struct {
+	cmd *cobra.Command
+	commandDeps
+
+	date  string
+	force bool
+}
+
+func NewReleaseCommand(i do.Injector) (*ReleaseCommand, error) {
+	c := &ReleaseCommand{
+		commandDeps: newCommandDeps(i),
+	}
+
+	c.cmd = &cobra.Command{
+		Use:   "release <version>",
+		Short: "Snapshot the generated documentation for a product release",
+		Long: `Copy the documentation generated into the output directory, domain.json included, to
+releases/<version>/ and update the releases index at releases/README.md, so the architecture can be
+referenced as it was at every product release.
+
+Run gen-docs first, the snapshot holds the documentation as it is in the output directory.
+
+Examples:
+  # Snapshot the documentation for v1.4.0
+  holydocs release v1.4.0
+
+  # Record the date of a past release
+  holydocs release v1.3.0 --date 2024-05-02
+
+  # Replace an existing snapshot
+  holydocs release v1.4.0 --force`,
+		Args: cobra.ExactArgs(1),
+		RunE: c.resolved(c.run),
+	}
+	c.cmd.Flags().StringVar(&c.date, "date", "", "Release date (YYYY-MM-DD), today when not set")
+	c.cmd.Flags().BoolVar(&c.force, "force", false, "Replace an existing snapshot of the version")
+
+	return c, nil
+}
+
+// GetCommand returns the cobra command.
+func (c *ReleaseCommand) GetCommand() *cobra.Command {
+	return c.cmd
+}
+
+func (c *ReleaseCommand) run(cmd *cobra.Command, args []string) error {
+	var date time.Time
+	if c.date != "" {
+		parsed, err := time.Parse(time.DateOnly, c.date)
+		if err != nil {
+			return fmt.Errorf("invalid --date %q, expected YYYY-MM-DD: %w", c.date, err)
+		}
+		date = parsed
+	}
+
+	reply, err := c.app.ReleaseDocumentation(cmd.Context(), domain.ReleaseDocumentationRequest{
+		OutputDir: c.config.Output.Dir,
+		Version:   args[0],
+		Date:      date,
+		Force:     c.force,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to release documentation: %w", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Documentation of %s snapshotted to: %s (%d releases)\n", args[0], reply.Dir,
+		len(reply.Releases))
+
+	return nil
+}
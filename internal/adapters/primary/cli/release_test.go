@@ -0,0 +1,25 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReleaseCommand_Args(t *testing.T) {
+	t.Parallel()
+
+	cmd, err := NewReleaseCommand(setupTestInjector())
+	require.NoError(t, err)
+
+	cobraCmd := cmd.GetCommand()
+	cobraCmd.SetArgs([]string{})
+	require.ErrorContains(t, cobraCmd.Execute(), "accepts 1 arg")
+
+	cmd, err = NewReleaseCommand(setupTestInjector())
+	require.NoError(t, err)
+
+	cobraCmd = cmd.GetCommand()
+	cobraCmd.SetArgs([]string{"v1.4.0", "--date", "May"})
+	require.ErrorContains(t, cobraCmd.Execute(), "invalid --date")
+}
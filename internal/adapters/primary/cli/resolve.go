@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/app"
+	do "github.com/samber/do/v2"
+	"github.com/spf13/cobra"
+)
+
+// commandDeps holds the application and configuration of a command. They are resolved when the command runs
+// or completes rather than when it is built, so a malformed configuration file fails the command with an
+// error instead of --help, completion and every other command.
+type commandDeps struct {
+	injector do.Injector
+	app      *app.App
+	config   *config.Config
+}
+
+func newCommandDeps(i do.Injector) commandDeps {
+	return commandDeps{injector: i}
+}
+
+// resolve loads the configuration and the application, once.
+func (d *commandDeps) resolve() error {
+	if d.config != nil && d.app != nil {
+		return nil
+	}
+
+	cfg, err := do.Invoke[*config.Config](d.injector)
+	if err != nil {
+		return err //nolint:wrapcheck // wrapped by config.LoadConfig
+	}
+
+	application, err := do.Invoke[*app.App](d.injector)
+	if err != nil {
+		return fmt.Errorf("creating application: %w", err)
+	}
+
+	d.config, d.app = cfg, application
+
+	return nil
+}
+
+// resolved runs a command once its dependencies are resolved.
+func (d *commandDeps) resolved(run func(*cobra.Command, []string) error) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		if err := d.resolve(); err != nil {
+			return err
+		}
+
+		return run(cmd, args)
+	}
+}
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/holydocs/holydocs/schemas"
+	do "github.com/samber/do/v2"
+	"github.com/spf13/cobra"
+)
+
+// SchemaCommand represents the schema command.
+type SchemaCommand struct {
+	cmd *cobra.Command
+}
+
+func NewSchemaCommand(_ do.Injector) (*SchemaCommand, error) {
+	c := &SchemaCommand{}
+
+	c.cmd = &cobra.Command{
+		Use:   "schema",
+		Short: "Work with JSON Schemas of HolyDOCs files",
+	}
+
+	c.cmd.AddCommand(&cobra.Command{
+		Use:   "print <" + strings.Join(schemas.Names(), "|") + ">",
+		Short: "Print a JSON Schema",
+		Long: `Print a JSON Schema for one of the HolyDOCs files, for editor completion and validation.
+
+Schemas:
+  - config: holydocs.yaml configuration file
+  - domain: domain model of services and their relationships
+  - metadata: domain.json metadata written next to the generated documentation
+  - run-report: run-report.json summary of the last documentation run
+
+Examples:
+  # Save the configuration schema for the editor
+  holydocs schema print config > holydocs.schema.json`,
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: schemas.Names(),
+		RunE:      c.print,
+	})
+
+	return c, nil
+}
+
+// GetCommand returns the cobra command.
+func (c *SchemaCommand) GetCommand() *cobra.Command {
+	return c.cmd
+}
+
+func (c *SchemaCommand) print(cmd *cobra.Command, args []string) error {
+	data, err := schemas.Get(args[0])
+	if err != nil {
+		return fmt.Errorf("getting schema: %w", err)
+	}
+
+	if _, err := cmd.OutOrStdout().Write(data); err != nil {
+		return fmt.Errorf("writing schema: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/holydocs/holydocs/schemas"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaCommand_Print(t *testing.T) {
+	t.Parallel()
+
+	cmd, err := NewSchemaCommand(setupTestInjector())
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	cobraCmd := cmd.GetCommand()
+	cobraCmd.SetOut(&out)
+	cobraCmd.SetArgs([]string{"print", schemas.Config})
+	require.NoError(t, cobraCmd.Execute())
+
+	assert.True(t, json.Valid(out.Bytes()))
+	assert.Contains(t, out.String(), `"$id"`)
+
+	cobraCmd.SetArgs([]string{"print", "unknown"})
+	require.ErrorIs(t, cobraCmd.Execute(), schemas.ErrUnknownSchema)
+}
@@ -0,0 +1,163 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	do "github.com/samber/do/v2"
+	"github.com/spf13/cobra"
+)
+
+const defaultSnippetDiagram = "architecture.svg"
+
+// SnippetCommand represents the snippet command.
+type SnippetCommand struct {
+	cmd *cobra.Command
+	commandDeps
+	reporter *Reporter
+
+	service string
+	output  string
+	diagram string
+	docsURL string
+}
+
+func NewSnippetCommand(i do.Injector) (*SnippetCommand, error) {
+	c := &SnippetCommand{
+		commandDeps: newCommandDeps(i),
+		reporter:    do.MustInvoke[*Reporter](i),
+	}
+
+	c.cmd = &cobra.Command{
+		Use:   "snippet",
+		Short: "Render a Markdown snippet of a service for the README of its repository",
+		Long: `Render a small Markdown fragment describing one service, to be committed into the README of the
+repository of the service: a thumbnail of its relationships diagram, the services and dependencies it
+depends on, the services depending on it and a link to its full documentation.
+
+The snippet is enclosed in holydocs:snippet markers. When the output file already holds a snippet, only
+the part between the markers is replaced, otherwise the snippet is appended, so rerunning the command
+keeps the README consistent with the central model. The relationships diagram is written to --diagram
+and referenced relative to the output file.
+
+Input files are taken from the configuration the same way as for gen-docs. The full documentation is
+linked at --docs-url, which defaults to documentation.badges.base_url.
+
+Examples:
+  # Update the snippet in the README of the service repository
+  holydocs snippet --service "Orders Service" --output ../orders/README.md --diagram ../orders/docs/architecture.svg
+
+  # Print the snippet without a diagram
+  holydocs snippet --service "Orders Service" --diagram ""`,
+		Args: cobra.NoArgs,
+		RunE: c.resolved(c.run),
+	}
+	c.cmd.Flags().StringVarP(&c.service, "service", "s", "", "Name of the service to render the snippet for")
+	c.cmd.Flags().StringVarP(&c.output, "output", "o", "",
+		"File receiving the snippet, e.g. the README of the service (defaults to stdout)")
+	c.cmd.Flags().StringVar(&c.diagram, "diagram", defaultSnippetDiagram,
+		"File the relationships diagram is written to, no diagram when empty")
+	c.cmd.Flags().StringVar(&c.docsURL, "docs-url", "",
+		"URL the documentation is published at (defaults to documentation.badges.base_url)")
+	_ = c.cmd.MarkFlagRequired("service")
+	_ = c.cmd.RegisterFlagCompletionFunc("service", serviceNameCompletion(&c.commandDeps))
+
+	return c, nil
+}
+
+// GetCommand returns the cobra command.
+func (c *SnippetCommand) GetCommand() *cobra.Command {
+	return c.cmd
+}
+
+func (c *SnippetCommand) run(cmd *cobra.Command, _ []string) error {
+	ctx := cmd.Context()
+
+	serviceFilesPaths, asyncAPIFilesPaths, err := specFilesWithSources(ctx, c.app, c.config, c.reporter,
+		cmd.ErrOrStderr())
+	if err != nil {
+		return fmt.Errorf("getting spec files paths: %w", err)
+	}
+
+	docsURL := c.docsURL
+	if docsURL == "" {
+		docsURL = c.config.Documentation.Badges.BaseURL
+	}
+
+	diagramPath, err := snippetDiagramPath(c.diagram, c.output)
+	if err != nil {
+		return err
+	}
+
+	var existing []byte
+	if c.output != "" {
+		existing, err = os.ReadFile(c.output)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("reading %s: %w", c.output, err)
+		}
+	}
+
+	reply, err := c.app.GenerateSnippet(ctx, domain.GenerateSnippetRequest{
+		ServiceFilesPaths:  serviceFilesPaths,
+		AsyncAPIFilesPaths: asyncAPIFilesPaths,
+		Service:            c.service,
+		DocsURL:            docsURL,
+		DiagramPath:        diagramPath,
+		Existing:           existing,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to generate snippet: %w", err)
+	}
+
+	if c.diagram != "" {
+		if err := os.MkdirAll(filepath.Dir(c.diagram), dirPerm); err != nil {
+			return fmt.Errorf("creating directory of %s: %w", c.diagram, err)
+		}
+
+		if err := os.WriteFile(c.diagram, reply.Diagram, filePerm); err != nil {
+			return fmt.Errorf("writing diagram to %s: %w", c.diagram, err)
+		}
+	}
+
+	if c.output == "" {
+		if _, err := cmd.OutOrStdout().Write(reply.Content); err != nil {
+			return fmt.Errorf("writing snippet: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := os.WriteFile(c.output, reply.Content, filePerm); err != nil {
+		return fmt.Errorf("writing snippet to %s: %w", c.output, err)
+	}
+
+	return nil
+}
+
+// snippetDiagramPath returns the path the snippet references the diagram by, relative to the output file.
+func snippetDiagramPath(diagram, output string) (string, error) {
+	if diagram == "" || output == "" {
+		return filepath.ToSlash(diagram), nil
+	}
+
+	outputDir, err := filepath.Abs(filepath.Dir(output))
+	if err != nil {
+		return "", fmt.Errorf("resolving directory of %s: %w", output, err)
+	}
+
+	diagramPath, err := filepath.Abs(diagram)
+	if err != nil {
+		return "", fmt.Errorf("resolving %s: %w", diagram, err)
+	}
+
+	rel, err := filepath.Rel(outputDir, diagramPath)
+	if err != nil {
+		return "", fmt.Errorf("resolving %s relative to %s: %w", diagram, output, err)
+	}
+
+	return filepath.ToSlash(rel), nil
+}
@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSnippetCommand(t *testing.T) {
+	t.Parallel()
+
+	cmd, err := NewSnippetCommand(setupTestInjector())
+	require.NoError(t, err)
+
+	cobraCmd := cmd.GetCommand()
+	assert.Equal(t, "snippet", cobraCmd.Use)
+	assert.Equal(t, "architecture.svg", cobraCmd.Flag("diagram").DefValue)
+
+	cobraCmd.SetArgs([]string{})
+	require.ErrorContains(t, cobraCmd.Execute(), `required flag(s) "service" not set`)
+}
+
+func TestSnippetDiagramPath(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		diagram, output, want string
+	}{
+		{diagram: "architecture.svg", want: "architecture.svg"},
+		{diagram: "", output: "README.md", want: ""},
+		{diagram: "../orders/docs/architecture.svg", output: "../orders/README.md", want: "docs/architecture.svg"},
+		{diagram: "architecture.svg", output: "docs/README.md", want: "../architecture.svg"},
+	} {
+		got, err := snippetDiagramPath(tt.diagram, tt.output)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, got, tt)
+	}
+}
@@ -0,0 +1,314 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	do "github.com/samber/do/v2"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// ValidateCommand represents the validate command.
+type ValidateCommand struct {
+	cmd *cobra.Command
+	commandDeps
+	reporter *Reporter
+
+	prose           bool
+	requireApproval bool
+	baseline        string
+	updateBaseline  bool
+}
+
+func NewValidateCommand(i do.Injector) (*ValidateCommand, error) {
+	c := &ValidateCommand{
+		commandDeps: newCommandDeps(i),
+		reporter:    do.MustInvoke[*Reporter](i),
+	}
+
+	c.cmd = &cobra.Command{
+		Use:   "validate",
+		Short: "Check the specifications for inconsistencies",
+		Long: `Load the specifications and report inconsistencies between them without generating documentation.
+
+Input files are taken from the configuration the same way as for gen-docs. The command exits with
+an error when findings are reported, so it can guard CI pipelines.
+
+Rules:
+  missing-dlq  A channel declares a dead letter queue (x-dlq or documentation.channels.<name>.dlq)
+               that is not a channel of any operation.
+  duplicate-description
+               Services share identical or nearly identical descriptions, usually scaffolded from a
+               template and never filled in.
+
+Prose rules, checked with --prose over service and relationship descriptions (validate.prose):
+  spelling         Words missing from the dictionary, the words of names in the specifications
+                   and validate.prose.words. Only checked with a dictionary.
+  sentence-length  Sentences longer than validate.prose.max_sentence_length words.
+  banned-word      Words and phrases of validate.prose.banned_words.
+
+Custom rules of validate.rules are WebAssembly modules, WASI commands reading the schema as JSON
+from their standard input and writing their findings as JSON to their standard output. They are
+checked on every run, their findings are reported under the names of the rules.
+
+Approvals, checked with --require-approval or validate.approval.required:
+  unapproved-relationship
+               A relationship added since the documentation in the output directory was generated
+               connects services of different systems, or a service of a system with an undocumented
+               participant, without approval. A relationship is approved by its approval field in the
+               ServiceFile extensions or by an entry of the validate.approval.file list.
+
+A baseline file of accepted findings (--baseline or validate.baseline) lets existing findings pass, so
+only new findings fail the command. --update-baseline writes the current findings to the file.
+
+Examples:
+  # Check the specifications of the configuration
+  holydocs validate --config ./holydocs.yaml
+
+  # Also lint the descriptions
+  holydocs validate --prose
+
+  # Require approvals of new relationships between systems
+  holydocs validate --require-approval
+
+  # Accept the current findings, later runs fail on new ones only
+  holydocs validate --baseline holydocs-baseline.json --update-baseline`,
+		Args: cobra.NoArgs,
+		RunE: c.resolved(c.run),
+		// Findings are reported as an error, the usage doesn't help fixing them.
+		SilenceUsage: true,
+	}
+
+	c.cmd.Flags().BoolVar(&c.prose, "prose", false, "Lint service and relationship descriptions")
+	c.cmd.Flags().BoolVar(&c.requireApproval, "require-approval", false,
+		"Require approvals of new relationships between systems (overrides validate.approval.required)")
+	c.cmd.Flags().StringVar(&c.baseline, "baseline", "",
+		"Baseline file of accepted findings (overrides validate.baseline)")
+	c.cmd.Flags().BoolVar(&c.updateBaseline, "update-baseline", false,
+		"Write the current findings to the baseline file instead of failing on them")
+
+	return c, nil
+}
+
+// GetCommand returns the cobra command.
+func (c *ValidateCommand) GetCommand() *cobra.Command {
+	return c.cmd
+}
+
+func (c *ValidateCommand) run(cmd *cobra.Command, _ []string) error {
+	ctx := cmd.Context()
+
+	serviceFilesPaths, asyncAPIFilesPaths, err := specFilesWithSources(ctx, c.app, c.config, c.reporter, cmd.ErrOrStderr())
+	if err != nil {
+		return fmt.Errorf("getting spec files paths: %w", err)
+	}
+
+	req := domain.ValidateRequest{
+		ServiceFilesPaths:  serviceFilesPaths,
+		AsyncAPIFilesPaths: asyncAPIFilesPaths,
+		Rules:              customRules(c.config.Validate.Rules),
+	}
+
+	if c.prose {
+		req.Prose, err = proseRules(c.config.Validate.Prose)
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.requireApproval || c.config.Validate.Approval.Required {
+		req.Approval, err = approvalPolicy(c.config.Validate.Approval, c.config.Output.Dir)
+		if err != nil {
+			return err
+		}
+	}
+
+	baseline := c.config.Validate.Baseline
+	if c.baseline != "" {
+		baseline = c.baseline
+	}
+
+	switch {
+	case c.updateBaseline && baseline == "":
+		return errors.New("baseline file is required, pass --baseline or set validate.baseline")
+	case baseline != "" && !c.updateBaseline:
+		req.Baseline, err = readBaseline(baseline)
+		if err != nil {
+			return err
+		}
+	}
+
+	reply, err := c.app.Validate(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to validate specifications: %w", err)
+	}
+
+	out := cmd.OutOrStdout()
+
+	if c.updateBaseline {
+		if err := writeBaseline(baseline, reply.Findings); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(out, "Baseline %s written with %d findings\n", baseline, len(reply.Findings))
+
+		return nil
+	}
+
+	if reply.Accepted > 0 {
+		fmt.Fprintf(out, "%d findings accepted by the baseline\n", reply.Accepted)
+	}
+
+	if len(reply.Fixed) > 0 {
+		fmt.Fprintf(out, "%d findings of the baseline were fixed, update it with --update-baseline\n", len(reply.Fixed))
+	}
+
+	if len(reply.Findings) == 0 {
+		fmt.Fprintln(out, "No findings")
+
+		return nil
+	}
+
+	for _, finding := range reply.Findings {
+		c.reporter.Finding(out, finding)
+	}
+
+	return fmt.Errorf("%w: %d findings", domain.ErrValidationFailed, len(reply.Findings))
+}
+
+func customRules(rules []config.CustomRule) []domain.CustomRule {
+	custom := make([]domain.CustomRule, 0, len(rules))
+	for _, rule := range rules {
+		custom = append(custom, domain.CustomRule{
+			Name:   domain.FindingRule(rule.Name),
+			Module: rule.Module,
+			Config: rule.Config,
+		})
+	}
+
+	return custom
+}
+
+// baselineFile is the content of a baseline file.
+type baselineFile struct {
+	Findings []domain.Finding `json:"findings"`
+}
+
+// readBaseline reads the accepted findings of a baseline file. A missing file accepts no findings, so the
+// baseline can be configured before it is written.
+func readBaseline(path string) ([]domain.Finding, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("reading baseline: %w", err)
+	}
+
+	var baseline baselineFile
+	if err := json.Unmarshal(content, &baseline); err != nil {
+		return nil, fmt.Errorf("parsing baseline %s: %w", path, err)
+	}
+
+	return baseline.Findings, nil
+}
+
+func writeBaseline(path string, findings []domain.Finding) error {
+	if findings == nil {
+		findings = []domain.Finding{}
+	}
+
+	content, err := json.MarshalIndent(baselineFile{Findings: findings}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling baseline: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(content, '\n'), filePerm); err != nil {
+		return fmt.Errorf("writing baseline: %w", err)
+	}
+
+	return nil
+}
+
+// approvalEntry is an entry of the approval file.
+type approvalEntry struct {
+	Service     string `yaml:"service"`
+	Participant string `yaml:"participant"`
+	Reference   string `yaml:"reference"`
+}
+
+// approvalPolicy reads the approval file of the approval configuration.
+func approvalPolicy(approval config.Approval, outputDir string) (*domain.ApprovalPolicy, error) {
+	policy := &domain.ApprovalPolicy{OutputDir: outputDir}
+
+	if approval.File == "" {
+		return policy, nil
+	}
+
+	content, err := os.ReadFile(approval.File)
+	if err != nil {
+		return nil, fmt.Errorf("reading approval file: %w", err)
+	}
+
+	var entries []approvalEntry
+	if err := yaml.Unmarshal(content, &entries); err != nil {
+		return nil, fmt.Errorf("parsing approval file %s: %w", approval.File, err)
+	}
+
+	for _, entry := range entries {
+		if entry.Service == "" || entry.Participant == "" {
+			return nil, fmt.Errorf("approval file %s: service and participant are required", approval.File)
+		}
+
+		policy.Approvals = append(policy.Approvals, domain.RelationshipApproval{
+			Service:     entry.Service,
+			Participant: entry.Participant,
+			Reference:   entry.Reference,
+		})
+	}
+
+	return policy, nil
+}
+
+// proseRules reads the dictionary of the prose configuration, extended by its words.
+func proseRules(prose config.Prose) (*domain.ProseRules, error) {
+	rules := &domain.ProseRules{
+		MaxSentenceLength: prose.MaxSentenceLength,
+		BannedWords:       prose.BannedWords,
+	}
+
+	if prose.Dictionary == "" {
+		return rules, nil
+	}
+
+	content, err := os.ReadFile(prose.Dictionary)
+	if err != nil {
+		return nil, fmt.Errorf("reading dictionary: %w", err)
+	}
+
+	rules.Dictionary = make(map[string]struct{})
+
+	for _, line := range strings.Split(string(content), "\n") {
+		// Hunspell dictionaries start with the number of words and append affix flags to them.
+		word, _, _ := strings.Cut(strings.TrimSpace(line), "/")
+		if word == "" || strings.TrimFunc(word, unicode.IsDigit) == "" {
+			continue
+		}
+
+		rules.Dictionary[strings.ToLower(word)] = struct{}{}
+	}
+
+	for _, word := range prose.Words {
+		rules.Dictionary[strings.ToLower(strings.TrimSpace(word))] = struct{}{}
+	}
+
+	return rules, nil
+}
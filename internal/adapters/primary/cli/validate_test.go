@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProseRules(t *testing.T) {
+	t.Parallel()
+
+	dictionary := filepath.Join(t.TempDir(), "en_US.dic")
+	require.NoError(t, os.WriteFile(dictionary, []byte("3\nOrder/SM\nship/DSG\n\nrefund\n"), 0o644))
+
+	rules, err := proseRules(config.Prose{
+		Dictionary:        dictionary,
+		Words:             []string{"Kafka"},
+		MaxSentenceLength: 30,
+		BannedWords:       []string{"simply"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]struct{}{"order": {}, "ship": {}, "refund": {}, "kafka": {}}, rules.Dictionary)
+	assert.Equal(t, 30, rules.MaxSentenceLength)
+	assert.Equal(t, []string{"simply"}, rules.BannedWords)
+
+	rules, err = proseRules(config.Prose{MaxSentenceLength: 20})
+	require.NoError(t, err)
+	assert.Empty(t, rules.Dictionary)
+
+	_, err = proseRules(config.Prose{Dictionary: filepath.Join(t.TempDir(), "missing.dic")})
+	require.ErrorContains(t, err, "reading dictionary")
+}
+
+func TestApprovalPolicy(t *testing.T) {
+	t.Parallel()
+
+	file := filepath.Join(t.TempDir(), "approvals.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(`- service: Orders
+  participant: Billing
+  reference: docs/adr/0007-billing-events.md
+`), 0o644))
+
+	policy, err := approvalPolicy(config.Approval{File: file}, "docs")
+	require.NoError(t, err)
+	assert.Equal(t, &domain.ApprovalPolicy{
+		OutputDir: "docs",
+		Approvals: []domain.RelationshipApproval{
+			{Service: "Orders", Participant: "Billing", Reference: "docs/adr/0007-billing-events.md"},
+		},
+	}, policy)
+
+	policy, err = approvalPolicy(config.Approval{Required: true}, "docs")
+	require.NoError(t, err)
+	assert.Empty(t, policy.Approvals)
+
+	require.NoError(t, os.WriteFile(file, []byte("- service: Orders\n"), 0o644))
+	_, err = approvalPolicy(config.Approval{File: file}, "docs")
+	require.ErrorContains(t, err, "service and participant are required")
+}
+
+func TestBaseline(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "holydocs-baseline.json")
+
+	findings, err := readBaseline(path)
+	require.NoError(t, err)
+	assert.Empty(t, findings)
+
+	require.NoError(t, writeBaseline(path, nil))
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"findings": []}`, string(content))
+
+	accepted := []domain.Finding{{Rule: domain.FindingRuleMissingDLQ, Subject: "orders", Message: "dead letter queue"}}
+	require.NoError(t, writeBaseline(path, accepted))
+
+	findings, err = readBaseline(path)
+	require.NoError(t, err)
+	assert.Equal(t, accepted, findings)
+
+	require.NoError(t, os.WriteFile(path, []byte("findings: []"), 0o644))
+	_, err = readBaseline(path)
+	require.ErrorContains(t, err, "parsing baseline")
+}
@@ -0,0 +1,170 @@
+// Package ingest provides the HTTP API accepting ServiceFile and AsyncAPI specifications
+// pushed by deploy pipelines.
+package ingest
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+)
+
+// maxPayloadSize limits the size of a pushed specification.
+const maxPayloadSize = 10 << 20
+
+// Application defines the use cases served by the handler.
+type Application interface {
+	IngestSource(ctx context.Context, req domain.IngestSourceRequest) (domain.Source, error)
+	ListSources(ctx context.Context) ([]domain.Source, error)
+	DeleteSource(ctx context.Context, name string) error
+}
+
+// Handler serves the ingest API:
+//
+//	POST   /sources/{name}[?ttl=24h]  store the request body as a source
+//	GET    /sources                   list active sources
+//	DELETE /sources/{name}            remove a source
+type Handler struct {
+	app      Application
+	token    string
+	onChange func()
+	mux      *http.ServeMux
+}
+
+// NewHandler creates a handler. Requests must carry the token as a bearer token unless it is empty.
+// onChange, when not nil, is called after every stored or removed source and must not block: documentation
+// is regenerated outside of the request, which is answered with 202 Accepted then.
+func NewHandler(app Application, token string, onChange func()) *Handler {
+	h := &Handler{
+		app:      app,
+		token:    token,
+		onChange: onChange,
+		mux:      http.NewServeMux(),
+	}
+
+	h.mux.HandleFunc("POST /sources/{name}", h.ingest)
+	h.mux.HandleFunc("GET /sources", h.list)
+	h.mux.HandleFunc("DELETE /sources/{name}", h.delete)
+
+	return h
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !h.authorized(r) {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		writeError(w, http.StatusUnauthorized, errors.New("missing or invalid bearer token"))
+
+		return
+	}
+
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *Handler) authorized(r *http.Request) bool {
+	if h.token == "" {
+		return true
+	}
+
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+	return ok && subtle.ConstantTimeCompare([]byte(token), []byte(h.token)) == 1
+}
+
+func (h *Handler) ingest(w http.ResponseWriter, r *http.Request) {
+	var ttl time.Duration
+	if value := r.URL.Query().Get("ttl"); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed <= 0 {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid ttl %q", value))
+
+			return
+		}
+		ttl = parsed
+	}
+
+	content, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPayloadSize))
+	if err != nil {
+		writeError(w, http.StatusRequestEntityTooLarge, fmt.Errorf("reading payload: %w", err))
+
+		return
+	}
+
+	source, err := h.app.IngestSource(r.Context(), domain.IngestSourceRequest{
+		Name:    r.PathValue("name"),
+		Content: content,
+		TTL:     ttl,
+	})
+	if err != nil {
+		writeError(w, statusCode(err), err)
+
+		return
+	}
+
+	writeJSON(w, h.changed(http.StatusOK), source)
+}
+
+func (h *Handler) list(w http.ResponseWriter, r *http.Request) {
+	sources, err := h.app.ListSources(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+
+		return
+	}
+
+	if sources == nil {
+		sources = []domain.Source{}
+	}
+
+	writeJSON(w, http.StatusOK, sources)
+}
+
+func (h *Handler) delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.app.DeleteSource(r.Context(), r.PathValue("name")); err != nil {
+		writeError(w, statusCode(err), err)
+
+		return
+	}
+
+	w.WriteHeader(h.changed(http.StatusNoContent))
+}
+
+// changed reports a change of the sources and returns the status of the response, 202 Accepted when
+// documentation is regenerated.
+func (h *Handler) changed(status int) int {
+	if h.onChange == nil {
+		return status
+	}
+
+	h.onChange()
+
+	return http.StatusAccepted
+}
+
+func statusCode(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidSource):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrSourceNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
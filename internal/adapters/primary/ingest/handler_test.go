@@ -0,0 +1,135 @@
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeApp struct {
+	requests []domain.IngestSourceRequest
+	sources  []domain.Source
+	deleted  []string
+}
+
+func (f *fakeApp) IngestSource(_ context.Context, req domain.IngestSourceRequest) (domain.Source, error) {
+	if !strings.Contains(string(req.Content), "servicefile") {
+		return domain.Source{}, fmt.Errorf("saving source: %w", domain.ErrInvalidSource)
+	}
+
+	f.requests = append(f.requests, req)
+
+	return domain.Source{Name: req.Name, Kind: domain.SourceKindServiceFile}, nil
+}
+
+func (f *fakeApp) ListSources(_ context.Context) ([]domain.Source, error) {
+	return f.sources, nil
+}
+
+func (f *fakeApp) DeleteSource(_ context.Context, name string) error {
+	if name != "user" {
+		return fmt.Errorf("deleting source: %w", domain.ErrSourceNotFound)
+	}
+
+	f.deleted = append(f.deleted, name)
+
+	return nil
+}
+
+func serve(h http.Handler, method, target, body string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestHandler_Ingest(t *testing.T) {
+	t.Parallel()
+
+	app := &fakeApp{}
+	changes := 0
+	h := NewHandler(app, "", func() {
+		changes++
+	})
+
+	rec := serve(h, http.MethodPost, "/sources/user?ttl=24h", "servicefile: 0.1.0", nil)
+	require.Equal(t, http.StatusAccepted, rec.Code)
+
+	var source domain.Source
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &source))
+	assert.Equal(t, "user", source.Name)
+
+	require.Len(t, app.requests, 1)
+	assert.Equal(t, "servicefile: 0.1.0", string(app.requests[0].Content))
+	assert.Equal(t, 24*time.Hour, app.requests[0].TTL)
+	assert.Equal(t, 1, changes)
+
+	rec = serve(h, http.MethodPost, "/sources/user", "openapi: 3.0.0", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = serve(h, http.MethodPost, "/sources/user?ttl=soon", "servicefile: 0.1.0", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, 1, changes)
+}
+
+func TestHandler_ListAndDelete(t *testing.T) {
+	t.Parallel()
+
+	app := &fakeApp{}
+	h := NewHandler(app, "", nil)
+
+	rec := serve(h, http.MethodGet, "/sources", "", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, "[]", rec.Body.String())
+
+	rec = serve(h, http.MethodDelete, "/sources/user", "", nil)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, []string{"user"}, app.deleted)
+
+	rec = serve(h, http.MethodDelete, "/sources/other", "", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = serve(h, http.MethodPut, "/sources/user", "", nil)
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestHandler_Token(t *testing.T) {
+	t.Parallel()
+
+	h := NewHandler(&fakeApp{}, "secret", nil)
+
+	rec := serve(h, http.MethodGet, "/sources", "", nil)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = serve(h, http.MethodGet, "/sources", "", http.Header{"Authorization": {"Bearer wrong"}})
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = serve(h, http.MethodGet, "/sources", "", http.Header{"Authorization": {"Bearer secret"}})
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestHandler_WithoutRegeneration(t *testing.T) {
+	t.Parallel()
+
+	h := NewHandler(&fakeApp{}, "", nil)
+
+	rec := serve(h, http.MethodPost, "/sources/user", "servicefile: 0.1.0", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = serve(h, http.MethodDelete, "/sources/user", "", nil)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
@@ -0,0 +1,402 @@
+// Package asyncapi re-emits the merged async operations known to holydocs as AsyncAPI 3.0 documents.
+package asyncapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	do "github.com/samber/do/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// asyncAPIVersion is the AsyncAPI specification version of exported documents.
+const asyncAPIVersion = "3.0.0"
+
+// messageNameSuffix is appended by the AsyncAPI parser to the component key of every message, it is removed
+// from component keys so documents exported from loaded specifications keep their original message names.
+const messageNameSuffix = "Message"
+
+// yamlIndent is the indentation of exported documents.
+const yamlIndent = 2
+
+// Exporter builds AsyncAPI documents from the merged schema.
+type Exporter struct{}
+
+func NewExporter(_ do.Injector) (*Exporter, error) {
+	return &Exporter{}, nil
+}
+
+type document struct {
+	AsyncAPI   string               `yaml:"asyncapi"`
+	Info       info                 `yaml:"info"`
+	Channels   map[string]channel   `yaml:"channels"`
+	Operations map[string]operation `yaml:"operations"`
+	Components components           `yaml:"components"`
+
+	// messageNames are the names of all messages of the document, used to keep message keys unique.
+	messageNames map[string]struct{}
+}
+
+type info struct {
+	Title       string `yaml:"title"`
+	Version     string `yaml:"version"`
+	Description string `yaml:"description,omitempty"`
+}
+
+type channel struct {
+	Address    string               `yaml:"address"`
+	Parameters map[string]parameter `yaml:"parameters,omitempty"`
+	Messages   map[string]ref       `yaml:"messages"`
+}
+
+type parameter struct {
+	Description string `yaml:"description,omitempty"`
+}
+
+type ref struct {
+	Ref string `yaml:"$ref"`
+}
+
+type operation struct {
+	Action   domain.OperationAction `yaml:"action"`
+	Channel  ref                    `yaml:"channel"`
+	Messages []ref                  `yaml:"messages"`
+	Reply    *reply                 `yaml:"reply,omitempty"`
+	// Service keeps the service the operation was declared by, as merging loses the document boundaries.
+	Service string `yaml:"x-service"`
+}
+
+type reply struct {
+	Channel  ref   `yaml:"channel"`
+	Messages []ref `yaml:"messages"`
+}
+
+type components struct {
+	Messages map[string]message `yaml:"messages"`
+}
+
+type message struct {
+	Name     string    `yaml:"name"`
+	Payload  any       `yaml:"payload,omitempty"`
+	Examples []example `yaml:"examples,omitempty"`
+}
+
+type example struct {
+	Payload any `yaml:"payload"`
+}
+
+type serviceGroup struct {
+	name     string
+	services []domain.Service
+}
+
+// Export returns one document for all services or, with the system scope, one document per system.
+// Services without a system are exported under the global name. Payload schemas are rebuilt from the
+// payload shapes, so types, formats and enums are kept while descriptions and constraints are not.
+func (e *Exporter) Export(
+	schema domain.Schema,
+	req domain.ExportAsyncAPIRequest,
+) ([]domain.AsyncAPIDocument, error) {
+	var groups []serviceGroup
+
+	switch req.Scope {
+	case domain.AsyncAPIExportScopeGlobal, "":
+		groups = []serviceGroup{{name: req.Title, services: schema.Services}}
+	case domain.AsyncAPIExportScopeSystem:
+		groups = groupBySystem(schema.Services, req.GlobalName)
+	default:
+		return nil, fmt.Errorf("%w: export scope %q, expected one of %v",
+			domain.ErrUnsupportedValue, req.Scope, domain.AsyncAPIExportScopes())
+	}
+
+	var documents []domain.AsyncAPIDocument
+
+	for _, group := range groups {
+		doc := buildDocument(group, req.Version)
+		if len(doc.Operations) == 0 {
+			continue
+		}
+
+		content, err := marshal(doc)
+		if err != nil {
+			return nil, fmt.Errorf("encoding AsyncAPI document %s: %w", group.name, err)
+		}
+
+		documents = append(documents, domain.AsyncAPIDocument{Name: group.name, Content: content})
+	}
+
+	if len(documents) == 0 {
+		return nil, domain.ErrNoAsyncOperations
+	}
+
+	return documents, nil
+}
+
+func groupBySystem(services []domain.Service, globalName string) []serviceGroup {
+	bySystem := make(map[string][]domain.Service)
+
+	for _, service := range services {
+		name := service.Info.System
+		if name == "" {
+			name = globalName
+		}
+		bySystem[name] = append(bySystem[name], service)
+	}
+
+	groups := make([]serviceGroup, 0, len(bySystem))
+	for name, services := range bySystem {
+		groups = append(groups, serviceGroup{name: name, services: services})
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].name < groups[j].name
+	})
+
+	return groups
+}
+
+func buildDocument(group serviceGroup, version string) document {
+	doc := document{
+		AsyncAPI:     asyncAPIVersion,
+		Info:         info{Title: group.name, Version: version},
+		Channels:     make(map[string]channel),
+		Operations:   make(map[string]operation),
+		Components:   components{Messages: make(map[string]message)},
+		messageNames: messageNames(group.services),
+	}
+
+	var serviceNames []string
+
+	for _, service := range group.services {
+		if len(service.Operation) == 0 {
+			continue
+		}
+		serviceNames = append(serviceNames, service.Info.Name)
+
+		for _, op := range service.Operation {
+			exported := operation{
+				Action:   op.Action,
+				Channel:  doc.addChannel(op.Channel.Name),
+				Messages: doc.addMessage(op.Channel.Name, op.Channel.Message),
+				Service:  service.Info.Name,
+			}
+
+			if op.Reply != nil {
+				exported.Reply = &reply{
+					Channel:  doc.addChannel(op.Reply.Name),
+					Messages: doc.addMessage(op.Reply.Name, op.Reply.Message),
+				}
+			}
+
+			doc.Operations[operationID(doc.Operations, service.Info.Name, op)] = exported
+		}
+	}
+
+	sort.Strings(serviceNames)
+	if len(serviceNames) > 0 {
+		doc.Info.Description = "Merged async operations of " + strings.Join(serviceNames, ", ") + "."
+	}
+
+	return doc
+}
+
+func (d *document) addChannel(address string) ref {
+	if _, ok := d.Channels[address]; !ok {
+		ch := channel{Address: address, Messages: make(map[string]ref)}
+
+		for _, name := range addressParameters(address) {
+			if ch.Parameters == nil {
+				ch.Parameters = make(map[string]parameter)
+			}
+			ch.Parameters[name] = parameter{}
+		}
+
+		d.Channels[address] = ch
+	}
+
+	return ref{Ref: "#/channels/" + escapePointer(address)}
+}
+
+// addMessage registers the message in components and on the channel. The first declaration of a message
+// wins, producers and consumers normally share the payload.
+func (d *document) addMessage(address string, msg domain.Message) []ref {
+	key := d.messageKey(msg.Name)
+	if key == "" {
+		return []ref{}
+	}
+
+	if _, ok := d.Components.Messages[key]; !ok {
+		exported := message{Name: key, Payload: payloadSchema(msg.Payload)}
+
+		for _, value := range msg.Examples {
+			var payload any
+			if err := json.Unmarshal([]byte(value), &payload); err == nil {
+				exported.Examples = append(exported.Examples, example{Payload: payload})
+			}
+		}
+
+		d.Components.Messages[key] = exported
+	}
+
+	d.Channels[address].Messages[key] = ref{Ref: "#/components/messages/" + escapePointer(key)}
+
+	return []ref{{Ref: "#/channels/" + escapePointer(address) + "/messages/" + escapePointer(key)}}
+}
+
+// messageKey is the component key of a message. The suffix is kept when removing it would collide with another
+// message, e.g. OrderCreatedMessage next to OrderCreated.
+func (d *document) messageKey(name string) string {
+	key := strings.TrimSuffix(name, messageNameSuffix)
+	if key == "" || key == name {
+		return name
+	}
+
+	if _, exists := d.messageNames[key]; exists {
+		return name
+	}
+
+	return key
+}
+
+func messageNames(services []domain.Service) map[string]struct{} {
+	names := make(map[string]struct{})
+
+	for _, service := range services {
+		for _, op := range service.Operation {
+			names[op.Channel.Message.Name] = struct{}{}
+			if op.Reply != nil {
+				names[op.Reply.Message.Name] = struct{}{}
+			}
+		}
+	}
+
+	return names
+}
+
+// operationID derives a unique operation identifier from the service, the action and the channel.
+func operationID(operations map[string]operation, service string, op domain.Operation) string {
+	base := identifier(service) + "_" + string(op.Action) + "_" + identifier(op.Channel.Name)
+
+	id := base
+	for i := 2; ; i++ {
+		if _, exists := operations[id]; !exists {
+			return id
+		}
+		id = fmt.Sprintf("%s_%d", base, i)
+	}
+}
+
+func identifier(name string) string {
+	var b strings.Builder
+
+	underscore := false
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			underscore = false
+
+			continue
+		}
+
+		if !underscore && b.Len() > 0 {
+			b.WriteByte('_')
+			underscore = true
+		}
+	}
+
+	return strings.TrimSuffix(b.String(), "_")
+}
+
+// addressParameters returns the names of the {parameter} placeholders of a channel address.
+func addressParameters(address string) []string {
+	var names []string
+
+	for {
+		start := strings.IndexByte(address, '{')
+		if start < 0 {
+			return names
+		}
+
+		end := strings.IndexByte(address[start:], '}')
+		if end < 0 {
+			return names
+		}
+
+		names = append(names, address[start+1:start+end])
+		address = address[start+end+1:]
+	}
+}
+
+// escapePointer escapes a key for use in a JSON pointer reference.
+func escapePointer(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
+
+// payloadSchema rebuilds a JSON Schema from a payload shape, where leaves are type strings such as
+// "string[uuid]" or "string[enum:a,b]", objects are maps and arrays hold the shape of their items.
+func payloadSchema(payload string) any {
+	if strings.TrimSpace(payload) == "" {
+		return nil
+	}
+
+	var shape any
+	if err := json.Unmarshal([]byte(payload), &shape); err != nil {
+		return nil
+	}
+
+	return shapeSchema(shape)
+}
+
+func shapeSchema(shape any) map[string]any {
+	switch shape := shape.(type) {
+	case map[string]any:
+		properties := make(map[string]any, len(shape))
+		for name, value := range shape {
+			properties[name] = shapeSchema(value)
+		}
+
+		return map[string]any{"type": "object", "properties": properties}
+	case []any:
+		schema := map[string]any{"type": "array"}
+		if len(shape) > 0 {
+			schema["items"] = shapeSchema(shape[0])
+		}
+
+		return schema
+	case string:
+		kind, format, _ := strings.Cut(strings.TrimSuffix(shape, "]"), "[")
+		schema := map[string]any{"type": kind}
+
+		if values, ok := strings.CutPrefix(format, "enum:"); ok {
+			schema["enum"] = strings.Split(values, ",")
+		} else if format != "" {
+			schema["format"] = format
+		}
+
+		return schema
+	default:
+		return map[string]any{}
+	}
+}
+
+func marshal(doc document) ([]byte, error) {
+	var buf bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(yamlIndent)
+
+	if err := encoder.Encode(doc); err != nil {
+		return nil, fmt.Errorf("encoding YAML: %w", err)
+	}
+
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("encoding YAML: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
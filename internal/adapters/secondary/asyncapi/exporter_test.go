@@ -0,0 +1,157 @@
+package asyncapi
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/adapters/secondary/schema"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	do "github.com/samber/do/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadTestSchema(t *testing.T) domain.Schema {
+	t.Helper()
+
+	loader, err := schema.NewLoader(do.New())
+	require.NoError(t, err)
+
+	// The sample services only, the loader test cases keep specifications of single extensions next to them.
+	var specs, serviceFiles []string
+
+	for _, name := range []string{"analytics", "campaign", "mailer", "notification", "reports", "user"} {
+		specs = append(specs, filepath.Join("../schema/testdata", name+".asyncapi.yaml"))
+
+		matches, err := filepath.Glob(filepath.Join("../schema/testdata", name+".servicefile.y*ml"))
+		require.NoError(t, err)
+		serviceFiles = append(serviceFiles, matches...)
+	}
+
+	s, err := loader.Load(context.Background(), serviceFiles, specs)
+	require.NoError(t, err)
+	s.Sort()
+
+	return s
+}
+
+func TestExport_GlobalRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	original := loadTestSchema(t)
+
+	exporter, err := NewExporter(do.New())
+	require.NoError(t, err)
+
+	documents, err := exporter.Export(original, domain.ExportAsyncAPIRequest{
+		Scope:   domain.AsyncAPIExportScopeGlobal,
+		Title:   "Example Organization",
+		Version: "2.0.0",
+	})
+	require.NoError(t, err)
+	require.Len(t, documents, 1)
+	assert.Equal(t, "Example Organization", documents[0].Name)
+	assert.Contains(t, string(documents[0].Content), "x-service: Notification Service")
+
+	path := filepath.Join(t.TempDir(), "global.asyncapi.yaml")
+	require.NoError(t, os.WriteFile(path, documents[0].Content, 0o600))
+
+	loader, err := schema.NewLoader(do.New())
+	require.NoError(t, err)
+
+	exported, err := loader.Load(context.Background(), nil, []string{path})
+	require.NoError(t, err)
+	require.Len(t, exported.Services, 1)
+
+	var want int
+	for _, service := range original.Services {
+		want += len(service.Operation)
+	}
+	assert.Len(t, exported.Services[0].Operation, want)
+
+	messages := make(map[string]string)
+	for _, op := range exported.Services[0].Operation {
+		messages[op.Channel.Message.Name] = op.Channel.Message.Payload
+	}
+	assert.Contains(t, messages, "AnalyticsEventMessage")
+	assert.Contains(t, messages["AnalyticsEventMessage"], "string[uuid]")
+}
+
+func TestExport_SystemScope(t *testing.T) {
+	t.Parallel()
+
+	exporter, err := NewExporter(do.New())
+	require.NoError(t, err)
+
+	documents, err := exporter.Export(loadTestSchema(t), domain.ExportAsyncAPIRequest{
+		Scope:      domain.AsyncAPIExportScopeSystem,
+		Title:      "Example Organization",
+		GlobalName: "Internal Services",
+		Version:    "1.0.0",
+	})
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(documents))
+	for _, document := range documents {
+		names = append(names, document.Name)
+	}
+	assert.Equal(t, []string{"Analytics System", "Internal Services", "Notification System"}, names)
+}
+
+func TestExport_MessageKeys(t *testing.T) {
+	t.Parallel()
+
+	exporter, err := NewExporter(do.New())
+	require.NoError(t, err)
+
+	documents, err := exporter.Export(domain.Schema{Services: []domain.Service{{
+		Info: domain.ServiceInfo{Name: "Orders"},
+		Operation: []domain.Operation{
+			{Action: domain.ActionSend, Channel: domain.Channel{Name: "orders.created",
+				Message: domain.Message{Name: "OrderCreatedMessage", Payload: `{"id": "string"}`}}},
+			{Action: domain.ActionSend, Channel: domain.Channel{Name: "orders.created.v2",
+				Message: domain.Message{Name: "OrderCreated", Payload: `{"order_id": "string"}`}}},
+			{Action: domain.ActionSend, Channel: domain.Channel{Name: "orders.cancelled",
+				Message: domain.Message{Name: "OrderCancelledMessage"}}},
+		},
+	}}}, domain.ExportAsyncAPIRequest{Title: "Orders"})
+	require.NoError(t, err)
+	require.Len(t, documents, 1)
+
+	content := string(documents[0].Content)
+	assert.Contains(t, content, "$ref: '#/components/messages/OrderCreatedMessage'")
+	assert.Contains(t, content, "$ref: '#/components/messages/OrderCreated'")
+	assert.Contains(t, content, "$ref: '#/components/messages/OrderCancelled'")
+	assert.Contains(t, content, "order_id")
+}
+
+func TestExport_Errors(t *testing.T) {
+	t.Parallel()
+
+	exporter, err := NewExporter(do.New())
+	require.NoError(t, err)
+
+	_, err = exporter.Export(domain.Schema{}, domain.ExportAsyncAPIRequest{Scope: "team"})
+	require.ErrorIs(t, err, domain.ErrUnsupportedValue)
+
+	_, err = exporter.Export(domain.Schema{
+		Services: []domain.Service{{Info: domain.ServiceInfo{Name: "Gateway"}}},
+	}, domain.ExportAsyncAPIRequest{})
+	require.ErrorIs(t, err, domain.ErrNoAsyncOperations)
+}
+
+func TestShapeSchema(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"id":     map[string]any{"type": "string", "format": "uuid"},
+			"status": map[string]any{"type": "string", "enum": []string{"sent", "opened"}},
+			"tags":   map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+		},
+	}, payloadSchema(`{"id": "string[uuid]", "status": "string[enum:sent,opened]", "tags": ["string"]}`))
+	assert.Nil(t, payloadSchema(""))
+}
@@ -0,0 +1,134 @@
+// Package cache keeps results of expensive computations, such as parsed specifications, between runs.
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/holydocs/holydocs/internal/config"
+	do "github.com/samber/do/v2"
+)
+
+// File permissions.
+const (
+	dirPerm  = 0o755
+	filePerm = 0o644
+)
+
+// entrySuffix is the suffix of cache entry files, only files with it are removed when clearing the cache.
+const entrySuffix = ".cache.json"
+
+// Store keeps JSON encoded values in a directory, one file per key.
+type Store struct {
+	dir     string
+	enabled bool
+}
+
+func NewStore(i do.Injector) (*Store, error) {
+	cfg := do.MustInvoke[*config.Config](i)
+
+	return &Store{dir: cfg.Cache.Dir, enabled: cfg.Cache.Enabled}, nil
+}
+
+// Get decodes the value stored under key into value and reports whether it was found.
+// Unreadable entries are reported as missing, so they're computed and stored again.
+func (s *Store) Get(key string, value any) bool {
+	if !s.enabled {
+		return false
+	}
+
+	data, err := os.ReadFile(s.path(key))
+	if err != nil {
+		return false
+	}
+
+	return json.Unmarshal(data, value) == nil
+}
+
+// Put stores value under key.
+func (s *Store) Put(key string, value any) error {
+	if !s.enabled {
+		return nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("encoding cache entry %s: %w", key, err)
+	}
+
+	if err := os.MkdirAll(s.dir, dirPerm); err != nil {
+		return fmt.Errorf("creating cache directory %s: %w", s.dir, err)
+	}
+
+	// Written to a temporary file first, so concurrent runs never read a partial entry.
+	tmp, err := os.CreateTemp(s.dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("creating cache entry %s: %w", key, err)
+	}
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+
+		return fmt.Errorf("writing cache entry %s: %w", key, err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+
+		return fmt.Errorf("writing cache entry %s: %w", key, err)
+	}
+
+	if err := os.Chmod(tmp.Name(), filePerm); err != nil {
+		os.Remove(tmp.Name())
+
+		return fmt.Errorf("setting permissions of cache entry %s: %w", key, err)
+	}
+
+	if err := os.Rename(tmp.Name(), s.path(key)); err != nil {
+		os.Remove(tmp.Name())
+
+		return fmt.Errorf("replacing cache entry %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// Clear removes all entries and returns how many were removed. Other files in the directory are kept.
+func (s *Store) Clear() (int, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+
+		return 0, fmt.Errorf("reading cache directory %s: %w", s.dir, err)
+	}
+
+	removed := 0
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), entrySuffix) {
+			continue
+		}
+
+		if err := os.Remove(filepath.Join(s.dir, entry.Name())); err != nil && !os.IsNotExist(err) {
+			return removed, fmt.Errorf("removing cache entry %s: %w", entry.Name(), err)
+		}
+		removed++
+	}
+
+	return removed, nil
+}
+
+// Dir returns the cache directory.
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+func (s *Store) path(key string) string {
+	return filepath.Join(s.dir, key+entrySuffix)
+}
@@ -0,0 +1,65 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type entry struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+func TestStore_PutGetClear(t *testing.T) {
+	t.Parallel()
+
+	store := &Store{dir: filepath.Join(t.TempDir(), "cache"), enabled: true}
+
+	var missing entry
+	assert.False(t, store.Get("missing", &missing))
+
+	require.NoError(t, store.Put("first", entry{Name: "first", Count: 1}))
+	require.NoError(t, store.Put("second", entry{Name: "second", Count: 2}))
+
+	var got entry
+	require.True(t, store.Get("first", &got))
+	assert.Equal(t, entry{Name: "first", Count: 1}, got)
+
+	unrelated := filepath.Join(store.dir, "notes.txt")
+	require.NoError(t, os.WriteFile(unrelated, []byte("keep"), filePerm))
+
+	removed, err := store.Clear()
+	require.NoError(t, err)
+	assert.Equal(t, 2, removed)
+	assert.False(t, store.Get("first", &got))
+	assert.FileExists(t, unrelated)
+}
+
+func TestStore_CorruptedEntryIsMissing(t *testing.T) {
+	t.Parallel()
+
+	store := &Store{dir: t.TempDir(), enabled: true}
+	require.NoError(t, os.WriteFile(store.path("broken"), []byte("{"), filePerm))
+
+	var got entry
+	assert.False(t, store.Get("broken", &got))
+}
+
+func TestStore_Disabled(t *testing.T) {
+	t.Parallel()
+
+	store := &Store{dir: filepath.Join(t.TempDir(), "cache")}
+	require.NoError(t, store.Put("first", entry{Name: "first"}))
+	assert.NoDirExists(t, store.dir)
+
+	var got entry
+	assert.False(t, store.Get("first", &got))
+
+	removed, err := store.Clear()
+	require.NoError(t, err)
+	assert.Zero(t, removed)
+}
@@ -0,0 +1,123 @@
+package dependencies
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+)
+
+// graphNode is a service or participant of the dependency graph, services carry their system and owner.
+type graphNode struct {
+	name, system, owner string
+	service             bool
+}
+
+// writeDOT writes the dependencies as a Graphviz digraph without layout hints beyond the node shapes, so it
+// can be fed to dot, gvpr or graph libraries. Services are boxes grouped into a cluster per system, other
+// participants are ellipses. Nodes and edges carry the columns of the table as attributes.
+func writeDOT(w io.Writer, dependencies []domain.Dependency) error {
+	clusters, ungrouped := graphNodes(dependencies)
+
+	var b strings.Builder
+	b.WriteString("digraph dependencies {\n")
+
+	for i, system := range slices.Sorted(maps.Keys(clusters)) {
+		fmt.Fprintf(&b, "  subgraph cluster_%d {\n    label=%s;\n", i, dotID(system))
+
+		for _, n := range clusters[system] {
+			b.WriteString("    " + dotNode(n.name, n.service, n.system, n.owner) + "\n")
+		}
+
+		b.WriteString("  }\n")
+	}
+
+	for _, n := range ungrouped {
+		b.WriteString("  " + dotNode(n.name, n.service, n.system, n.owner) + "\n")
+	}
+
+	for _, dep := range dependencies {
+		fmt.Fprintf(&b, "  %s -> %s [%s];\n", dotID(dep.Source), dotID(dep.Target), dotAttributes(
+			"label", string(dep.Action), "action", string(dep.Action),
+			"technology", dep.Technology, "proto", dep.Proto))
+	}
+
+	b.WriteString("}\n")
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("encoding dependencies as DOT: %w", err)
+	}
+
+	return nil
+}
+
+// graphNodes returns the nodes of the dependencies grouped by system, in order of appearance. Nodes without a
+// system are returned apart.
+func graphNodes(dependencies []domain.Dependency) (map[string][]*graphNode, []*graphNode) {
+	var order []string
+
+	nodes := make(map[string]*graphNode)
+	add := func(name string) *graphNode {
+		if n, ok := nodes[name]; ok {
+			return n
+		}
+
+		nodes[name] = &graphNode{name: name}
+		order = append(order, name)
+
+		return nodes[name]
+	}
+
+	for _, dep := range dependencies {
+		source := add(dep.Source)
+		source.service, source.system, source.owner = true, dep.System, dep.Owner
+		add(dep.Target)
+	}
+
+	clusters := make(map[string][]*graphNode)
+
+	var ungrouped []*graphNode
+
+	for _, name := range order {
+		n := nodes[name]
+		if n.system == "" {
+			ungrouped = append(ungrouped, n)
+
+			continue
+		}
+
+		clusters[n.system] = append(clusters[n.system], n)
+	}
+
+	return clusters, ungrouped
+}
+
+func dotNode(name string, service bool, system, owner string) string {
+	shape := "ellipse"
+	if service {
+		shape = "box"
+	}
+
+	return fmt.Sprintf("%s [%s];", dotID(name), dotAttributes("shape", shape, "system", system, "owner", owner))
+}
+
+// dotAttributes formats key-value pairs as a DOT attribute list, leaving out empty values.
+func dotAttributes(pairs ...string) string {
+	attributes := make([]string, 0, len(pairs)/2)
+
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if pairs[i+1] != "" {
+			attributes = append(attributes, pairs[i]+"="+dotID(pairs[i+1]))
+		}
+	}
+
+	return strings.Join(attributes, ", ")
+}
+
+// dotID quotes a DOT identifier, escaping the quotes and backslashes in it.
+func dotID(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value) + `"`
+}
@@ -0,0 +1,64 @@
+// Package dependencies encodes the relationships of the services as a dependency table or graph.
+package dependencies
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	do "github.com/samber/do/v2"
+)
+
+// columns are the header of exported dependency tables.
+//
+//nolint:gochecknoglobals // Fixed header of the CSV and XLSX tables.
+var columns = []string{"source", "target", "action", "technology", "proto", "system", "owner"}
+
+// sheetName is the name of the worksheet of exported workbooks.
+const sheetName = "Dependencies"
+
+// Exporter encodes dependencies as CSV, XLSX workbooks or Graphviz DOT graphs.
+type Exporter struct{}
+
+func NewExporter(_ do.Injector) (*Exporter, error) {
+	return &Exporter{}, nil
+}
+
+// Export encodes the dependencies in the format.
+func (e *Exporter) Export(dependencies []domain.Dependency, format domain.DependencyExportFormat) ([]byte, error) {
+	var buf bytes.Buffer
+
+	switch format {
+	case domain.DependencyExportFormatCSV:
+		if err := csv.NewWriter(&buf).WriteAll(rows(dependencies)); err != nil {
+			return nil, fmt.Errorf("encoding dependencies as CSV: %w", err)
+		}
+	case domain.DependencyExportFormatXLSX:
+		if err := writeXLSX(&buf, sheetName, rows(dependencies)); err != nil {
+			return nil, err
+		}
+	case domain.DependencyExportFormatDOT:
+		if err := writeDOT(&buf, dependencies); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%w: format %q, expected one of %v",
+			domain.ErrUnsupportedValue, format, domain.DependencyExportFormats())
+	}
+
+	return buf.Bytes(), nil
+}
+
+func rows(dependencies []domain.Dependency) [][]string {
+	rows := make([][]string, 0, len(dependencies)+1)
+	rows = append(rows, columns)
+
+	for _, dep := range dependencies {
+		rows = append(rows, []string{
+			dep.Source, dep.Target, string(dep.Action), dep.Technology, dep.Proto, dep.System, dep.Owner,
+		})
+	}
+
+	return rows
+}
@@ -0,0 +1,108 @@
+package dependencies
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	do "github.com/samber/do/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExporter_Export(t *testing.T) {
+	t.Parallel()
+
+	dependencies := []domain.Dependency{
+		{Source: "Order Service", Target: "Billing Service", Action: domain.RelationshipActionRequests,
+			Technology: "gRPC", Proto: "grpc", System: "Ordering", Owner: "team-orders"},
+		{Source: "Order Service", Target: "postgres", Action: domain.RelationshipActionUses,
+			Technology: "PostgreSQL, primary", System: "Ordering"},
+	}
+
+	exporter, err := NewExporter(do.New())
+	require.NoError(t, err)
+
+	csvOut, err := exporter.Export(dependencies, domain.DependencyExportFormatCSV)
+	require.NoError(t, err)
+	assert.Equal(t, "source,target,action,technology,proto,system,owner\n"+
+		"Order Service,Billing Service,requests,gRPC,grpc,Ordering,team-orders\n"+
+		"Order Service,postgres,uses,\"PostgreSQL, primary\",,Ordering,\n", string(csvOut))
+
+	dependencies[1].Technology = "<PostgreSQL & co>"
+	xlsxOut, err := exporter.Export(dependencies, domain.DependencyExportFormatXLSX)
+	require.NoError(t, err)
+
+	archive, err := zip.NewReader(bytes.NewReader(xlsxOut), int64(len(xlsxOut)))
+	require.NoError(t, err)
+
+	parts := make(map[string]string)
+	for _, file := range archive.File {
+		part, err := file.Open()
+		require.NoError(t, err)
+
+		content, err := io.ReadAll(part)
+		require.NoError(t, err)
+		require.NoError(t, part.Close())
+
+		parts[file.Name] = string(content)
+	}
+
+	assert.Contains(t, parts, "[Content_Types].xml")
+	assert.Contains(t, parts["xl/workbook.xml"], `<sheet name="Dependencies"`)
+	assert.Contains(t, parts["xl/worksheets/sheet1.xml"],
+		`<c r="G2" t="inlineStr"><is><t xml:space="preserve">team-orders</t></is></c>`)
+	assert.Contains(t, parts["xl/worksheets/sheet1.xml"], "&lt;PostgreSQL &amp; co&gt;")
+}
+
+func TestExporter_ExportDOT(t *testing.T) {
+	t.Parallel()
+
+	dependencies := []domain.Dependency{
+		{Source: "Order Service", Target: "Billing Service", Action: domain.RelationshipActionRequests,
+			Technology: "gRPC", Proto: "grpc", System: "Ordering", Owner: "team-orders"},
+		{Source: "Order Service", Target: `orders "main"`, Action: domain.RelationshipActionUses,
+			System: "Ordering", Owner: "team-orders"},
+		{Source: "Billing Service", Target: "stripe", Action: domain.RelationshipActionRequests},
+	}
+
+	exporter, err := NewExporter(do.New())
+	require.NoError(t, err)
+
+	out, err := exporter.Export(dependencies, domain.DependencyExportFormatDOT)
+	require.NoError(t, err)
+	assert.Equal(t, `digraph dependencies {
+  subgraph cluster_0 {
+    label="Ordering";
+    "Order Service" [shape="box", system="Ordering", owner="team-orders"];
+  }
+  "Billing Service" [shape="box"];
+  "orders \"main\"" [shape="ellipse"];
+  "stripe" [shape="ellipse"];
+  "Order Service" -> "Billing Service" [label="requests", action="requests", technology="gRPC", proto="grpc"];
+  "Order Service" -> "orders \"main\"" [label="uses", action="uses"];
+  "Billing Service" -> "stripe" [label="requests", action="requests"];
+}
+`, string(out))
+}
+
+func TestXLSXColumn(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "A", xlsxColumn(0))
+	assert.Equal(t, "Z", xlsxColumn(25))
+	assert.Equal(t, "AA", xlsxColumn(26))
+	assert.Equal(t, "BA", xlsxColumn(52))
+}
+
+func TestExporter_ExportUnsupportedFormat(t *testing.T) {
+	t.Parallel()
+
+	exporter, err := NewExporter(do.New())
+	require.NoError(t, err)
+
+	_, err = exporter.Export(nil, "ods")
+	require.ErrorIs(t, err, domain.ErrUnsupportedValue)
+}
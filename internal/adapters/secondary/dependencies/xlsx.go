@@ -0,0 +1,108 @@
+package dependencies
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// xlsxParts are the static parts of a workbook with a single worksheet.
+//
+//nolint:gochecknoglobals // Fixed parts of every workbook.
+var xlsxParts = map[string]string{
+	"[Content_Types].xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ` +
+		`ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ` +
+		`ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`,
+	"_rels/.rels": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" ` +
+		`Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" ` +
+		`Target="xl/workbook.xml"/></Relationships>`,
+	"xl/_rels/workbook.xml.rels": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" ` +
+		`Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" ` +
+		`Target="worksheets/sheet1.xml"/></Relationships>`,
+}
+
+// writeXLSX writes rows as the only worksheet of an Office Open XML workbook. Cells hold inline strings,
+// which keeps the workbook free of a shared strings table.
+func writeXLSX(w io.Writer, sheetName string, rows [][]string) error {
+	var sheet strings.Builder
+	sheet.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+
+	for i, row := range rows {
+		fmt.Fprintf(&sheet, `<row r="%d">`, i+1)
+
+		for j, value := range row {
+			fmt.Fprintf(&sheet, `<c r="%s%d" t="inlineStr"><is><t xml:space="preserve">`, xlsxColumn(j), i+1)
+			if err := xml.EscapeText(&sheet, []byte(value)); err != nil {
+				return fmt.Errorf("encoding cell: %w", err)
+			}
+			sheet.WriteString(`</t></is></c>`)
+		}
+
+		sheet.WriteString(`</row>`)
+	}
+
+	sheet.WriteString(`</sheetData></worksheet>`)
+
+	var name strings.Builder
+	if err := xml.EscapeText(&name, []byte(sheetName)); err != nil {
+		return fmt.Errorf("encoding sheet name: %w", err)
+	}
+
+	sheetParts := map[string]string{
+		"xl/workbook.xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+			`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="` + name.String() + `" sheetId="1" r:id="rId1"/></sheets></workbook>`,
+		"xl/worksheets/sheet1.xml": sheet.String(),
+	}
+
+	archive := zip.NewWriter(w)
+
+	for _, parts := range []map[string]string{xlsxParts, sheetParts} {
+		for _, path := range slices.Sorted(maps.Keys(parts)) {
+			part, err := archive.Create(path)
+			if err != nil {
+				return fmt.Errorf("creating %s: %w", path, err)
+			}
+
+			if _, err := io.WriteString(part, parts[path]); err != nil {
+				return fmt.Errorf("writing %s: %w", path, err)
+			}
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("closing workbook: %w", err)
+	}
+
+	return nil
+}
+
+// xlsxColumnLetters is the number of letters naming worksheet columns.
+const xlsxColumnLetters = 26
+
+// xlsxColumn returns the letters of a zero-based column index, e.g. "A" for 0 and "AA" for 26.
+func xlsxColumn(index int) string {
+	var letters []byte
+	for index >= 0 {
+		letters = append([]byte{byte('A' + index%xlsxColumnLetters)}, letters...)
+		index = index/xlsxColumnLetters - 1
+	}
+
+	return string(letters)
+}
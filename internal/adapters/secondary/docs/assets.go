@@ -0,0 +1,151 @@
+package docs
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/pkg/outputfs"
+)
+
+// gitAttributesFileName marks the diagrams for Git LFS.
+const gitAttributesFileName = ".gitattributes"
+
+// assetHashLength is the number of hex digits of the content hash in object paths of uploaded diagrams.
+const assetHashLength = 12
+
+// diagramFilePatterns match the diagram files kept in the configured storage.
+//
+//nolint:gochecknoglobals // Patterns also written into .gitattributes of the diagrams directory.
+var diagramFilePatterns = []string{"*.svg", "*.png"}
+
+// storeDiagrams moves the diagrams below baseDir to the configured storage. It returns the URLs of the
+// uploaded diagrams by their slash-separated path relative to baseDir.
+func storeDiagrams(
+	ctx context.Context,
+	fsys outputfs.FS,
+	baseDir string,
+	assets config.Assets,
+) (map[string]string, error) {
+	switch assets.Storage {
+	case config.AssetStorageLFS:
+		return nil, trackDiagramsWithLFS(fsys, filepath.Join(baseDir, diagramsDirName))
+	case config.AssetStorageBucket:
+		return uploadDiagrams(ctx, fsys, baseDir, assets)
+	default:
+		return nil, nil
+	}
+}
+
+// trackDiagramsWithLFS writes a .gitattributes into the diagrams directory, so committing the documentation
+// stores the diagrams with Git LFS.
+func trackDiagramsWithLFS(fsys outputfs.FS, diagramsDir string) error {
+	var attributes strings.Builder
+
+	attributes.WriteString("# " + generatedFileNotice + "\n")
+	for _, pattern := range diagramFilePatterns {
+		attributes.WriteString(pattern + " filter=lfs diff=lfs merge=lfs -text\n")
+	}
+
+	attributesPath := filepath.Join(diagramsDir, gitAttributesFileName)
+	if err := fsys.WriteFile(attributesPath, []byte(attributes.String()), filePerm); err != nil {
+		return fmt.Errorf("writing %s: %w", attributesPath, err)
+	}
+
+	return nil
+}
+
+// uploadDiagrams uploads the diagrams below baseDir with the upload command and removes them from the output
+// directory, D2 scripts are kept. Object paths carry a hash of the content, so documentation committed
+// earlier keeps showing the diagrams it was generated with. The upload command reads the diagrams, which
+// takes documentation written to the local filesystem.
+func uploadDiagrams(
+	ctx context.Context,
+	fsys outputfs.FS,
+	baseDir string,
+	assets config.Assets,
+) (map[string]string, error) {
+	urls := make(map[string]string)
+
+	err := outputfs.WalkDir(fsys, filepath.Join(baseDir, diagramsDirName),
+		func(file string, entry fs.DirEntry, err error) error {
+			if err != nil || entry.IsDir() || !isDiagramFile(file) {
+				return err
+			}
+
+			rel, err := filepath.Rel(baseDir, file)
+			if err != nil {
+				return err
+			}
+
+			objectPath, err := assetObjectPath(fsys, file, filepath.ToSlash(rel))
+			if err != nil {
+				return err
+			}
+
+			if err := runUploadCommand(ctx, assets.UploadCommand, file, objectPath); err != nil {
+				return fmt.Errorf("uploading %s: %w", rel, err)
+			}
+
+			urls[filepath.ToSlash(rel)] = strings.TrimSuffix(assets.BaseURL, "/") + "/" + objectPath
+
+			return fsys.Remove(file)
+		})
+	if err != nil {
+		return nil, fmt.Errorf("uploading diagrams: %w", err)
+	}
+
+	return urls, nil
+}
+
+func isDiagramFile(file string) bool {
+	for _, pattern := range diagramFilePatterns {
+		if matched, _ := filepath.Match(pattern, filepath.Base(file)); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// assetObjectPath returns the object path of a diagram, its path with a hash of its content before the
+// extension.
+func assetObjectPath(fsys outputfs.FS, file, rel string) (string, error) {
+	content, err := fsys.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(content)
+	ext := path.Ext(rel)
+
+	return strings.TrimSuffix(rel, ext) + "." + hex.EncodeToString(sum[:])[:assetHashLength] + ext, nil
+}
+
+// runUploadCommand runs the upload command with the placeholders replaced. The command is split into
+// arguments at spaces and not run by a shell.
+func runUploadCommand(ctx context.Context, command, file, objectPath string) error {
+	args := strings.Fields(command)
+	if len(args) == 0 {
+		return errors.New("upload command is empty")
+	}
+
+	for i, arg := range args {
+		args[i] = strings.NewReplacer("{file}", file, "{path}", objectPath).Replace(arg)
+	}
+
+	output, err := exec.CommandContext(ctx, args[0], args[1:]...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s: %w: %s", args[0], err, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package docs
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/pkg/outputfs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreDiagrams_LFS(t *testing.T) {
+	t.Parallel()
+
+	dir, fsys := "docs", outputfs.NewMemory()
+	require.NoError(t, fsys.MkdirAll(filepath.Join(dir, diagramsDirName), dirPerm))
+
+	urls, err := storeDiagrams(context.Background(), fsys, dir, config.Assets{Storage: config.AssetStorageLFS})
+	require.NoError(t, err)
+	assert.Empty(t, urls)
+
+	attributes, err := fsys.ReadFile(filepath.Join(dir, diagramsDirName, gitAttributesFileName))
+	require.NoError(t, err)
+	assert.Contains(t, string(attributes), "*.svg filter=lfs diff=lfs merge=lfs -text\n")
+}
+
+func TestStoreDiagrams_Bucket(t *testing.T) {
+	t.Parallel()
+
+	if _, err := exec.LookPath("cp"); err != nil {
+		t.Skip("cp is not available")
+	}
+
+	dir, bucket := t.TempDir(), t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, diagramsDirName), dirPerm))
+	require.NoError(t, os.MkdirAll(filepath.Join(bucket, diagramsDirName), dirPerm))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, diagramsDirName, "overview.svg"), []byte("<svg/>"), filePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, diagramsDirName, "overview.d2"), []byte("a -> b"), filePerm))
+
+	urls, err := storeDiagrams(context.Background(), outputfs.OS(), dir, config.Assets{
+		Storage:       config.AssetStorageBucket,
+		UploadCommand: "cp {file} " + bucket + "/{path}",
+		BaseURL:       "https://assets.example.com/docs/",
+	})
+	require.NoError(t, err)
+
+	objectPath := "diagrams/overview.d4dc56669143.svg"
+	assert.Equal(t, map[string]string{"diagrams/overview.svg": "https://assets.example.com/docs/" + objectPath}, urls)
+
+	uploaded, err := os.ReadFile(filepath.Join(bucket, filepath.FromSlash(objectPath)))
+	require.NoError(t, err)
+	assert.Equal(t, "<svg/>", string(uploaded))
+
+	assert.NoFileExists(t, filepath.Join(dir, diagramsDirName, "overview.svg"), "uploaded diagrams are removed")
+	assert.FileExists(t, filepath.Join(dir, diagramsDirName, "overview.d2"), "D2 scripts are kept")
+
+	_, err = storeDiagrams(context.Background(), outputfs.OS(), dir, config.Assets{
+		Storage:       config.AssetStorageBucket,
+		UploadCommand: "false {file}",
+	})
+	require.NoError(t, err, "nothing left to upload")
+}
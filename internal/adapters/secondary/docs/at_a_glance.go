@@ -0,0 +1,201 @@
+package docs
+
+import (
+	"cmp"
+	"fmt"
+	"html"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/holydocs/holydocs/pkg/outputfs"
+)
+
+// statsDirName holds the charts of the At a Glance section inside the diagrams directory.
+const statsDirName = "stats"
+
+// Dimensions of the bar charts, text metrics follow the badges.
+const (
+	chartRowHeight   = 22
+	chartBarHeight   = 14
+	chartMaxBarWidth = 240
+	chartPadding     = 10
+	chartBarColor    = "#4c78a8"
+)
+
+type atAGlanceView struct {
+	Totals       []glanceCount
+	Systems      []glanceCount
+	Technologies []glanceCount
+	// SystemsChart and TechnologiesChart are the paths of the bar charts of Systems and Technologies.
+	SystemsChart      string
+	TechnologiesChart string
+}
+
+// glanceCount is a labeled number of the At a Glance section.
+type glanceCount struct {
+	Label string
+	Count int
+}
+
+// HasData reports whether there is anything to summarize.
+func (v atAGlanceView) HasData() bool {
+	return len(v.Totals) > 0
+}
+
+// buildAtAGlance counts the services, systems, external dependencies, channels and endpoints of the schema,
+// the services of every system, with the ones without a system under standaloneName, and the technologies
+// by the number of services using them, limited to the top ones unless top is 0.
+func buildAtAGlance(schema domain.Schema, top int, standaloneName string) atAGlanceView {
+	if len(schema.Services) == 0 {
+		return atAGlanceView{}
+	}
+
+	systems := make(map[string]int)
+	externals := make(map[string]struct{})
+	technologies := make(map[string]int)
+	endpoints := 0
+
+	for _, service := range schema.Services {
+		system := service.Info.System
+		if system == "" {
+			system = standaloneName
+		}
+
+		systems[system]++
+		endpoints += len(service.Endpoints)
+
+		used := make(map[string]struct{})
+
+		for _, rel := range service.Relationships {
+			if rel.External && !rel.Person {
+				externals[rel.Participant] = struct{}{}
+			}
+
+			if rel.Technology != "" {
+				used[rel.Technology] = struct{}{}
+			}
+		}
+
+		for technology := range used {
+			technologies[technology]++
+		}
+	}
+
+	systemCount := len(systems)
+	if _, ok := systems[standaloneName]; ok {
+		systemCount--
+	}
+
+	view := atAGlanceView{
+		Totals: []glanceCount{
+			{Label: "Services", Count: len(schema.Services)},
+			{Label: "Systems", Count: systemCount},
+			{Label: "External Dependencies", Count: len(externals)},
+			{Label: "Channels", Count: len(schema.ChannelNames())},
+			{Label: "Endpoints", Count: endpoints},
+		},
+		Systems:      rankCounts(systems),
+		Technologies: rankCounts(technologies),
+	}
+
+	if top > 0 && len(view.Technologies) > top {
+		view.Technologies = view.Technologies[:top]
+	}
+
+	return view
+}
+
+// rankCounts orders counts from the highest, equal ones by label.
+func rankCounts(counts map[string]int) []glanceCount {
+	ranked := make([]glanceCount, 0, len(counts))
+	for label, count := range counts {
+		ranked = append(ranked, glanceCount{Label: label, Count: count})
+	}
+
+	slices.SortFunc(ranked, func(a, b glanceCount) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+
+		return cmp.Compare(strings.ToLower(a.Label), strings.ToLower(b.Label))
+	})
+
+	return ranked
+}
+
+// barChartSVG renders counts as a horizontal bar chart with the labels on the left and the counts after the
+// bars, scaled to the highest count.
+func barChartSVG(title string, counts []glanceCount) []byte {
+	labelWidth, highest := 0, 0
+	for _, count := range counts {
+		labelWidth = max(labelWidth, utf8.RuneCountInString(count.Label)*badgeCharWidth)
+		highest = max(highest, count.Count)
+	}
+
+	barX := labelWidth + 2*chartPadding
+	width := barX + chartMaxBarWidth + 4*badgeCharWidth + chartPadding
+	height := len(counts)*chartRowHeight + chartPadding
+
+	var svg strings.Builder
+
+	fmt.Fprintf(&svg, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" role="img" aria-label="%s">`,
+		width, height, html.EscapeString(title))
+	fmt.Fprintf(&svg, `<title>%s</title>`, html.EscapeString(title))
+	svg.WriteString(`<g font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11" fill="#333">`)
+
+	for i, count := range counts {
+		y := chartPadding + i*chartRowHeight
+		barWidth := max(1, count.Count*chartMaxBarWidth/max(highest, 1))
+
+		fmt.Fprintf(&svg, `<text x="%d" y="%d" text-anchor="end">%s</text>`,
+			labelWidth+chartPadding, y+chartBarHeight-3, html.EscapeString(count.Label))
+		fmt.Fprintf(&svg, `<rect x="%d" y="%d" width="%d" height="%d" rx="2" fill="%s"/>`,
+			barX, y, barWidth, chartBarHeight, chartBarColor)
+		fmt.Fprintf(&svg, `<text x="%d" y="%d">%d</text>`, barX+barWidth+badgeCharWidth, y+chartBarHeight-3, count.Count)
+	}
+
+	svg.WriteString("</g></svg>\n")
+
+	return []byte(svg.String())
+}
+
+// writeAtAGlanceCharts renders the bar charts of the services by system and the technologies and attaches
+// their paths to the view.
+func writeAtAGlanceCharts(fsys outputfs.FS, view atAGlanceView, diagramsDir string) (atAGlanceView, error) {
+	if !view.HasData() {
+		return view, nil
+	}
+
+	statsDir := filepath.Join(diagramsDir, statsDirName)
+	if err := fsys.MkdirAll(statsDir, dirPerm); err != nil {
+		return view, fmt.Errorf("create stats directory: %w", err)
+	}
+
+	charts := []struct {
+		name   string
+		title  string
+		counts []glanceCount
+		path   *string
+	}{
+		{name: "systems", title: "Services by System", counts: view.Systems, path: &view.SystemsChart},
+		{name: "technologies", title: "Top Technologies", counts: view.Technologies, path: &view.TechnologiesChart},
+	}
+
+	for _, chart := range charts {
+		if len(chart.counts) == 0 {
+			continue
+		}
+
+		if err := fsys.WriteFile(filepath.Join(statsDir, chart.name+".svg"), barChartSVG(chart.title, chart.counts),
+			filePerm); err != nil {
+			return view, fmt.Errorf("write %s chart: %w", chart.name, err)
+		}
+
+		*chart.path = filepath.ToSlash(filepath.Join(diagramsDirName, statsDirName, chart.name+".svg"))
+	}
+
+	return view, nil
+}
@@ -0,0 +1,74 @@
+package docs
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/holydocs/holydocs/pkg/outputfs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildAtAGlance(t *testing.T) {
+	t.Parallel()
+
+	schema := domain.Schema{Services: []domain.Service{
+		{
+			Info: domain.ServiceInfo{Name: "Order Service", System: "Ordering"},
+			Relationships: []domain.Relationship{
+				{Action: domain.RelationshipActionUses, Participant: "orders-db", Technology: "PostgreSQL"},
+				{Action: domain.RelationshipActionUses, Participant: "cache", Technology: "Redis"},
+				{Action: domain.RelationshipActionRequests, Participant: "Stripe", Technology: "HTTP", External: true},
+			},
+			Operation: []domain.Operation{{Action: domain.ActionSend, Channel: domain.Channel{Name: "orders.created"}}},
+			Endpoints: []domain.Endpoint{{Method: "GET", Path: "/orders"}, {Method: "POST", Path: "/orders"}},
+		},
+		{
+			Info: domain.ServiceInfo{Name: "Billing Service", System: "Ordering"},
+			Relationships: []domain.Relationship{
+				{Action: domain.RelationshipActionUses, Participant: "billing-db", Technology: "PostgreSQL"},
+				{Action: domain.RelationshipActionUses, Participant: "ledger-db", Technology: "PostgreSQL"},
+				{Action: domain.RelationshipActionRequests, Participant: "Customer", Technology: "HTTP", External: true,
+					Person: true},
+			},
+			Operation: []domain.Operation{{Action: domain.ActionReceive, Channel: domain.Channel{Name: "orders.created"}}},
+		},
+		{Info: domain.ServiceInfo{Name: "Audit Service"}},
+	}}
+
+	glance := buildAtAGlance(schema, 2, "Shared Services")
+
+	assert.Equal(t, []glanceCount{
+		{Label: "Services", Count: 3},
+		{Label: "Systems", Count: 1},
+		{Label: "External Dependencies", Count: 1},
+		{Label: "Channels", Count: 1},
+		{Label: "Endpoints", Count: 2},
+	}, glance.Totals)
+	assert.Equal(t, []glanceCount{{Label: "Ordering", Count: 2}, {Label: "Shared Services", Count: 1}}, glance.Systems)
+	assert.Equal(t, []glanceCount{{Label: "HTTP", Count: 2}, {Label: "PostgreSQL", Count: 2}}, glance.Technologies)
+
+	assert.False(t, buildAtAGlance(domain.Schema{}, 0, standaloneServicesName).HasData())
+}
+
+func TestWriteAtAGlanceCharts(t *testing.T) {
+	t.Parallel()
+
+	dir, fsys := "diagrams", outputfs.NewMemory()
+
+	glance, err := writeAtAGlanceCharts(fsys, atAGlanceView{
+		Totals:  []glanceCount{{Label: "Services", Count: 3}},
+		Systems: []glanceCount{{Label: "Ordering & Billing", Count: 2}, {Label: "Audit", Count: 1}},
+	}, dir)
+	require.NoError(t, err)
+
+	assert.Equal(t, "diagrams/stats/systems.svg", glance.SystemsChart)
+	assert.Empty(t, glance.TechnologiesChart)
+
+	chart, err := fsys.ReadFile(filepath.Join(dir, statsDirName, "systems.svg"))
+	require.NoError(t, err)
+	assert.Contains(t, string(chart), "Ordering &amp; Billing")
+	assert.Contains(t, string(chart), `width="240"`)
+	assert.Contains(t, string(chart), `width="120"`)
+}
@@ -0,0 +1,101 @@
+package docs
+
+import (
+	"fmt"
+	"html"
+	"path/filepath"
+	"time"
+	"unicode/utf8"
+
+	"github.com/holydocs/holydocs/pkg/outputfs"
+)
+
+// badgesDirName holds the last updated badges of services inside the diagrams directory.
+const badgesDirName = "badges"
+
+const lastUpdatedBadgeLabel = "last updated"
+
+// freshnessColors color badges by the age of the specifications in months, older ones are red.
+//
+//nolint:gochecknoglobals // Read-only lookup table
+var freshnessColors = []struct {
+	months int
+	color  string
+}{
+	{months: 3, color: "#4c1"},
+	{months: 6, color: "#dfb317"},
+	{months: 12, color: "#fe7d37"},
+}
+
+const staleBadgeColor = "#e05d44"
+
+// Approximate metrics of the 11px Verdana of the badges, shields.io measures the text instead.
+const (
+	badgeCharWidth = 7
+	badgePadding   = 10
+)
+
+// freshnessColor returns the color of a badge for specifications last updated at the given time.
+func freshnessColor(updated, now time.Time) string {
+	for _, level := range freshnessColors {
+		if updated.After(now.AddDate(0, -level.months, 0)) {
+			return level.color
+		}
+	}
+
+	return staleBadgeColor
+}
+
+// badgeSVG renders a badge in the flat style of shields.io.
+func badgeSVG(label, message, color string) []byte {
+	labelWidth := utf8.RuneCountInString(label)*badgeCharWidth + badgePadding
+	messageWidth := utf8.RuneCountInString(message)*badgeCharWidth + badgePadding
+	width := labelWidth + messageWidth
+	label, message = html.EscapeString(label), html.EscapeString(message)
+
+	return fmt.Appendf(nil, `<svg xmlns="http://www.w3.org/2000/svg" width="%[1]d" height="20" role="img" aria-label="%[4]s: %[5]s">`+
+		`<title>%[4]s: %[5]s</title>`+
+		`<linearGradient id="s" x2="0" y2="100%%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>`+
+		`<clipPath id="r"><rect width="%[1]d" height="20" rx="3" fill="#fff"/></clipPath>`+
+		`<g clip-path="url(#r)"><rect width="%[2]d" height="20" fill="#555"/><rect x="%[2]d" width="%[3]d" height="20" fill="%[6]s"/><rect width="%[1]d" height="20" fill="url(#s)"/></g>`+
+		`<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">`+
+		`<text x="%[7]d" y="14">%[4]s</text><text x="%[8]d" y="14">%[5]s</text></g></svg>`+"\n",
+		width, labelWidth, messageWidth, label, message, color, labelWidth/2, labelWidth+messageWidth/2)
+}
+
+// writeFreshnessBadges renders a last updated badge for every service with known specification dates
+// and attaches it to the service.
+func writeFreshnessBadges(fsys outputfs.FS, data templateData, diagramsDir string, lastUpdated map[string]time.Time,
+	now time.Time) (templateData, error) {
+	if len(lastUpdated) == 0 {
+		return data, nil
+	}
+
+	badgesDir := filepath.Join(diagramsDir, badgesDirName)
+	if err := fsys.MkdirAll(badgesDir, dirPerm); err != nil {
+		return data, fmt.Errorf("create badges directory: %w", err)
+	}
+
+	for i := range data.Systems {
+		for j := range data.Systems[i].Services {
+			service := &data.Systems[i].Services[j]
+
+			updated, ok := lastUpdated[service.Name]
+			if !ok {
+				continue
+			}
+
+			service.LastUpdated = updated.Format(sourceDateLayout)
+			badge := badgeSVG(lastUpdatedBadgeLabel, service.LastUpdated, freshnessColor(updated, now))
+
+			if err := fsys.WriteFile(filepath.Join(badgesDir, service.FileName+".svg"), badge, filePerm); err != nil {
+				return data, fmt.Errorf("write badge of %s: %w", service.Name, err)
+			}
+
+			service.LastUpdatedBadge = filepath.ToSlash(filepath.Join(diagramsDirName, badgesDirName,
+				service.FileName+".svg"))
+		}
+	}
+
+	return data, nil
+}
@@ -0,0 +1,48 @@
+package docs
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/holydocs/holydocs/pkg/outputfs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFreshnessColor(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "#4c1", freshnessColor(now.AddDate(0, -1, 0), now))
+	assert.Equal(t, "#dfb317", freshnessColor(now.AddDate(0, -4, 0), now))
+	assert.Equal(t, "#fe7d37", freshnessColor(now.AddDate(0, -9, 0), now))
+	assert.Equal(t, staleBadgeColor, freshnessColor(now.AddDate(-2, 0, 0), now))
+}
+
+func TestWriteFreshnessBadges(t *testing.T) {
+	t.Parallel()
+
+	dir, fsys := "diagrams", outputfs.NewMemory()
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	data := templateData{Systems: []systemView{{Services: []serviceView{
+		{Name: "Order Service", FileName: "order-service"},
+		{Name: "User Service", FileName: "user-service"},
+	}}}}
+
+	data, err := writeFreshnessBadges(fsys, data, dir, map[string]time.Time{
+		"Order Service": time.Date(2025, 5, 20, 8, 0, 0, 0, time.UTC),
+	}, now)
+	require.NoError(t, err)
+
+	order := data.Systems[0].Services[0]
+	assert.Equal(t, "2025-05-20", order.LastUpdated)
+	assert.Equal(t, "diagrams/badges/order-service.svg", order.LastUpdatedBadge)
+	assert.Empty(t, data.Systems[0].Services[1].LastUpdatedBadge)
+
+	badge, err := fsys.ReadFile(filepath.Join(dir, badgesDirName, "order-service.svg"))
+	require.NoError(t, err)
+	assert.Contains(t, string(badge), `aria-label="last updated: 2025-05-20"`)
+	assert.Contains(t, string(badge), `fill="#4c1"`)
+}
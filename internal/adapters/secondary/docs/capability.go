@@ -0,0 +1,237 @@
+package docs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+
+	d2target "github.com/holydocs/holydocs/internal/adapters/secondary/target/d2"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/holydocs/holydocs/pkg/outputfs"
+)
+
+// capabilitiesFileName is the business capabilities page in multi-page documentation.
+const capabilitiesFileName = "capabilities.md"
+
+// capabilityAnchorPrefix keeps capability anchors apart from system anchors, capabilities are often named
+// like systems.
+const capabilityAnchorPrefix = "capability-"
+
+// capabilityDiagramsDirName holds the capability diagrams inside the diagrams directory.
+const capabilityDiagramsDirName = "capabilities"
+
+// capabilityMapDiagramName is the overview diagram grouping the services by business capability.
+const capabilityMapDiagramName = "capability-map"
+
+type capabilityMapView struct {
+	Diagram      string
+	D2           string
+	Capabilities []capabilityView
+}
+
+// HasData reports whether any service declares a business capability.
+func (v capabilityMapView) HasData() bool {
+	return len(v.Capabilities) > 0
+}
+
+type capabilityView struct {
+	Name     string
+	Anchor   string
+	Diagram  string
+	D2       string
+	Services []capabilityServiceView
+}
+
+type capabilityServiceView struct {
+	Service eventLink
+	System  string
+	Owner   string
+}
+
+// Systems lists the systems the services of the capability are built in, e.g. "Storefront, Finance", which
+// shows where capabilities and systems don't align.
+func (v capabilityView) Systems() string {
+	var systems []string
+
+	for _, service := range v.Services {
+		if service.System != "" && !slices.Contains(systems, service.System) {
+			systems = append(systems, service.System)
+		}
+	}
+
+	return strings.Join(systems, ", ")
+}
+
+// generateCapabilities renders the services grouped by business capability instead of system: an overview
+// of the capabilities and a diagram per capability. Nothing is rendered when no service declares one.
+func generateCapabilities(ctx context.Context, fsys outputfs.FS, schema domain.Schema, asyncEdges []asyncEdge,
+	target domain.Target, globalName, diagramsDir string, recorder *diagramRecorder) (capabilityMapView, error) {
+	capabilities := schema.BusinessCapabilities()
+	if len(capabilities) == 0 {
+		return capabilityMapView{}, nil
+	}
+
+	d2Target, ok := target.(*d2target.Target)
+	if !ok {
+		return capabilityMapView{}, errors.New("target is not a D2 target")
+	}
+
+	capabilitiesDir := filepath.Join(diagramsDir, capabilityDiagramsDirName)
+	if err := fsys.MkdirAll(capabilitiesDir, dirPerm); err != nil {
+		return capabilityMapView{}, fmt.Errorf("create capability diagrams directory: %w", err)
+	}
+
+	capabilitySchema := schema.ByBusinessCapability()
+	edges := convertAsyncEdges(asyncEdges)
+
+	script, err := d2Target.GenerateOverviewDiagramScript(capabilitySchema, edges, globalName)
+	if err != nil {
+		return capabilityMapView{}, fmt.Errorf("generate capability map D2 script: %w", err)
+	}
+
+	err = renderD2Diagram(ctx, fsys, d2Target, script, diagramsDir, capabilityMapDiagramName)
+	if err == nil {
+		recorder.rendered()
+	} else if err := recorder.tolerate("capability map", filepath.Join(diagramsDir,
+		capabilityMapDiagramName+".svg"), err); err != nil {
+		return capabilityMapView{}, err
+	}
+
+	view := capabilityMapView{
+		Diagram: filepath.ToSlash(filepath.Join(diagramsDirName, capabilityMapDiagramName+".svg")),
+		D2:      filepath.ToSlash(filepath.Join(diagramsDirName, capabilityMapDiagramName+".d2")),
+	}
+
+	for _, capability := range capabilities {
+		fileBase := sanitizeFilename(capability)
+
+		script, err := d2Target.GenerateSystemDiagramScript(capabilitySchema, capability, edges)
+		if err != nil {
+			return capabilityMapView{}, fmt.Errorf("generate capability D2 script for %s: %w", capability, err)
+		}
+
+		err = renderD2Diagram(ctx, fsys, d2Target, script, capabilitiesDir, fileBase)
+		if err == nil {
+			recorder.rendered()
+		} else if err := recorder.tolerate("capability diagram of "+capability,
+			filepath.Join(capabilitiesDir, fileBase+".svg"), err); err != nil {
+			return capabilityMapView{}, err
+		}
+
+		capabilityView := buildCapabilityView(schema, capability)
+		capabilityView.Diagram = filepath.ToSlash(filepath.Join(diagramsDirName, capabilityDiagramsDirName,
+			fileBase+".svg"))
+		capabilityView.D2 = filepath.ToSlash(filepath.Join(diagramsDirName, capabilityDiagramsDirName,
+			fileBase+".d2"))
+
+		view.Capabilities = append(view.Capabilities, capabilityView)
+	}
+
+	return view, nil
+}
+
+// renderD2Diagram writes a D2 script and the diagram rendered from it to fileBase.d2 and fileBase.svg in dir.
+func renderD2Diagram(ctx context.Context, fsys outputfs.FS, d2Target *d2target.Target, script []byte,
+	dir, fileBase string) error {
+	if err := fsys.WriteFile(filepath.Join(dir, fileBase+".d2"), script, filePerm); err != nil {
+		return fmt.Errorf("write D2 script: %w", err)
+	}
+
+	diagram, err := d2Target.RenderSchema(ctx, domain.FormattedSchema{Type: "d2", Data: script})
+	if err != nil {
+		return fmt.Errorf("render diagram: %w", err)
+	}
+
+	if err := fsys.WriteFile(filepath.Join(dir, fileBase+".svg"), diagram, filePerm); err != nil {
+		return fmt.Errorf("write diagram: %w", err)
+	}
+
+	return nil
+}
+
+// buildCapabilityView lists the services supporting the capability, by name.
+func buildCapabilityView(schema domain.Schema, capability string) capabilityView {
+	view := capabilityView{Name: capability, Anchor: capabilityAnchorPrefix + sanitizeAnchor(capability)}
+
+	for _, service := range schema.Services {
+		if strings.TrimSpace(service.Info.BusinessCapability) != capability {
+			continue
+		}
+
+		view.Services = append(view.Services, capabilityServiceView{
+			Service: eventLink{Name: service.Info.Name},
+			System:  strings.TrimSpace(service.Info.System),
+			Owner:   service.Info.Owner,
+		})
+	}
+
+	slices.SortFunc(view.Services, func(a, b capabilityServiceView) int {
+		return strings.Compare(a.Service.Name, b.Service.Name)
+	})
+
+	return view
+}
+
+// linkCapabilities links the services of business capabilities to their sections. In multi-page mode the
+// capabilities are written to their own page.
+func linkCapabilities(data templateData, multiPage bool) templateData {
+	if !data.Capabilities.HasData() {
+		return data
+	}
+
+	if multiPage {
+		data.CapabilitiesPath = capabilitiesFileName
+	}
+
+	serviceLinks := make(map[string]string)
+	for _, system := range data.Systems {
+		for _, service := range system.Services {
+			serviceLinks[service.Name] = sectionLink(service.Anchor, service.FilePath, multiPage)
+		}
+	}
+
+	capabilities := make([]capabilityView, len(data.Capabilities.Capabilities))
+	for i, capability := range data.Capabilities.Capabilities {
+		services := make([]capabilityServiceView, len(capability.Services))
+		for j, service := range capability.Services {
+			service.Service.Link = serviceLinks[service.Service.Name]
+			services[j] = service
+		}
+
+		capability.Services = services
+		capabilities[i] = capability
+	}
+
+	data.Capabilities.Capabilities = capabilities
+
+	return data
+}
+
+type capabilitiesPageData struct {
+	Capabilities capabilityMapView
+}
+
+// writeCapabilitiesPage generates the business capabilities page for multi-page mode.
+func writeCapabilitiesPage(pages site, outputDir string, data templateData) error {
+	tmpl, err := template.New("capabilities.tmpl").Funcs(pages.templateFuncs()).
+		ParseFS(multiPageTemplateFS, "templates/md_multi_page/capabilities.tmpl")
+	if err != nil {
+		return fmt.Errorf("parse capabilities template: %w", err)
+	}
+
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, capabilitiesPageData{Capabilities: data.Capabilities}); err != nil {
+		return fmt.Errorf("execute capabilities template: %w", err)
+	}
+
+	if err := pages.writePage(filepath.Join(outputDir, capabilitiesFileName),
+		pageMeta{Title: "Business Capabilities"}, buf.String()); err != nil {
+		return fmt.Errorf("write capabilities page: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package docs
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	d2target "github.com/holydocs/holydocs/internal/adapters/secondary/target/d2"
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/holydocs/holydocs/pkg/outputfs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func capabilitySchema() domain.Schema {
+	return domain.Schema{Services: []domain.Service{
+		{
+			Info: domain.ServiceInfo{Name: "Checkout Service", System: "Storefront", BusinessCapability: "Payments",
+				Owner: "team-checkout"},
+			Relationships: []domain.Relationship{
+				{Action: domain.RelationshipActionRequests, Participant: "Ledger Service", Technology: "gRPC"},
+			},
+		},
+		{Info: domain.ServiceInfo{Name: "Ledger Service", System: "Finance", BusinessCapability: "Payments"}},
+		{Info: domain.ServiceInfo{Name: "Login Service", System: "Storefront", BusinessCapability: "Identity"}},
+		{Info: domain.ServiceInfo{Name: "Mailer Service"}},
+	}}
+}
+
+func TestBuildCapabilityView(t *testing.T) {
+	t.Parallel()
+
+	view := buildCapabilityView(capabilitySchema(), "Payments")
+
+	assert.Equal(t, "capability-payments", view.Anchor)
+	assert.Equal(t, []capabilityServiceView{
+		{Service: eventLink{Name: "Checkout Service"}, System: "Storefront", Owner: "team-checkout"},
+		{Service: eventLink{Name: "Ledger Service"}, System: "Finance"},
+	}, view.Services)
+	assert.Equal(t, "Storefront, Finance", view.Systems())
+
+	data := linkCapabilities(templateData{
+		Systems: []systemView{{Name: "Finance", Services: []serviceView{
+			{Name: "Ledger Service", Anchor: "ledger-service", FilePath: "services/ledger-service.md"},
+		}}},
+		Capabilities: capabilityMapView{Capabilities: []capabilityView{view}},
+	}, true)
+
+	assert.Equal(t, capabilitiesFileName, data.CapabilitiesPath)
+	assert.Equal(t, "services/ledger-service.md", data.Capabilities.Capabilities[0].Services[1].Service.Link)
+	assert.Empty(t, view.Services[1].Service.Link)
+}
+
+func TestGenerateCapabilities(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	target, err := d2target.NewTarget(config.D2Config{Pad: 64, Font: "SourceSansPro", Layout: "elk"})
+	require.NoError(t, err)
+
+	fsys := outputfs.NewMemory()
+	recorder := newDiagramRecorder(fsys, false)
+
+	view, err := generateCapabilities(ctx, fsys, domain.Schema{Services: []domain.Service{
+		{Info: domain.ServiceInfo{Name: "Mailer Service", System: "Messaging"}},
+	}}, nil, target, "Overview", "diagrams", recorder)
+	require.NoError(t, err)
+	assert.False(t, view.HasData())
+	assert.Empty(t, fsys.Files())
+
+	view, err = generateCapabilities(ctx, fsys, capabilitySchema(), nil, target, "Overview", "diagrams", recorder)
+	require.NoError(t, err)
+
+	assert.Equal(t, "diagrams/capability-map.svg", view.Diagram)
+	require.Len(t, view.Capabilities, 2)
+	assert.Equal(t, "Identity", view.Capabilities[0].Name)
+	assert.Equal(t, "diagrams/capabilities/payments.svg", view.Capabilities[1].Diagram)
+	assert.Equal(t, 3, recorder.stats.Rendered)
+
+	capabilityMap, err := fsys.ReadFile(filepath.Join("diagrams", "capability-map.d2"))
+	require.NoError(t, err)
+	assert.Contains(t, string(capabilityMap), "Payments")
+	assert.NotContains(t, string(capabilityMap), "Storefront")
+
+	_, err = fsys.ReadFile(filepath.Join("diagrams", "capabilities", "identity.svg"))
+	require.NoError(t, err)
+}
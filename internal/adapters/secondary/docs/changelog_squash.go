@@ -0,0 +1,109 @@
+package docs
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+)
+
+// SquashChangelog collapses changelog entries older than req.Before (all entries when zero)
+// in the domain.json of req.OutputDir into a single baseline entry.
+func (g *Generator) SquashChangelog(req domain.SquashChangelogRequest) (domain.SquashChangelogReply, error) {
+	metadata, err := readMetadata(g.fs, req.OutputDir)
+	if err != nil {
+		return domain.SquashChangelogReply{}, fmt.Errorf("error reading existing holydocs data: %w", err)
+	}
+
+	if metadata == nil {
+		return domain.SquashChangelogReply{}, fmt.Errorf("%w in %s", domain.ErrMetadataNotFound,
+			filepath.Clean(req.OutputDir))
+	}
+
+	changelogs, squashed := squashChangelogs(metadata.Changelogs, req.Before)
+	if squashed == 0 {
+		return domain.SquashChangelogReply{Remaining: len(changelogs)}, nil
+	}
+
+	metadata.Changelogs = changelogs
+	if err := writeMetadata(g.fs, req.OutputDir, *metadata); err != nil {
+		return domain.SquashChangelogReply{}, fmt.Errorf("error writing holydocs data: %w", err)
+	}
+
+	return domain.SquashChangelogReply{Squashed: squashed, Remaining: len(changelogs)}, nil
+}
+
+// squashChangelogs replaces entries dated before the given time with one baseline entry
+// dated as the newest squashed entry. Changelogs are expected newest first.
+// A single entry that already is a baseline is left untouched, the counts of baselines squashed
+// again are added to the new one.
+func squashChangelogs(changelogs []domain.Changelog, before time.Time) ([]domain.Changelog, int) {
+	var kept, squash []domain.Changelog
+
+	for _, changelog := range changelogs {
+		if before.IsZero() || changelog.Date.Before(before) {
+			squash = append(squash, changelog)
+
+			continue
+		}
+
+		kept = append(kept, changelog)
+	}
+
+	if len(squash) == 0 || (len(squash) == 1 && isBaseline(squash[0])) {
+		return changelogs, 0
+	}
+
+	summary := domain.ChangelogBaseline{Since: squash[0].Date}
+	newest := squash[0].Date
+
+	for _, changelog := range squash {
+		if changelog.Date.After(newest) {
+			newest = changelog.Date
+		}
+
+		entries, changes, since := squashedCounts(changelog)
+		summary.Entries += entries
+		summary.Changes += changes
+		if since.Before(summary.Since) {
+			summary.Since = since
+		}
+	}
+
+	baseline := domain.Changelog{
+		Date: newest,
+		Changes: []domain.Change{{
+			Type:     domain.ChangeTypeBaseline,
+			Category: "changelog",
+			Name:     "baseline",
+			Details: fmt.Sprintf("%d changelog entries with %d changes from %s to %s were squashed",
+				summary.Entries, summary.Changes, summary.Since.Format(time.DateOnly), newest.Format(time.DateOnly)),
+			Timestamp: newest,
+		}},
+		Baseline: &summary,
+	}
+
+	return append(kept, baseline), len(squash)
+}
+
+// squashedCounts returns the entries and changes an entry stands for and the date of the oldest of them.
+// Baselines squashed before their counts were recorded count as a single entry without changes.
+func squashedCounts(changelog domain.Changelog) (int, int, time.Time) {
+	if changelog.Baseline != nil {
+		return changelog.Baseline.Entries, changelog.Baseline.Changes, changelog.Baseline.Since
+	}
+
+	changes := 0
+	for _, change := range changelog.Changes {
+		if change.Type != domain.ChangeTypeBaseline {
+			changes++
+		}
+	}
+
+	return 1, changes, changelog.Date
+}
+
+func isBaseline(changelog domain.Changelog) bool {
+	return len(changelog.Changes) == 1 && changelog.Changes[0].Type == domain.ChangeTypeBaseline
+}
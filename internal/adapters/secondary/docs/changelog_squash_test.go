@@ -0,0 +1,116 @@
+package docs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/holydocs/holydocs/pkg/outputfs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testChangelog(date string, changes int) domain.Changelog {
+	parsed, _ := time.Parse(time.DateOnly, date)
+	changelog := domain.Changelog{Date: parsed}
+	for range changes {
+		changelog.Changes = append(changelog.Changes, domain.Change{Type: domain.ChangeTypeAdded, Timestamp: parsed})
+	}
+
+	return changelog
+}
+
+func TestSquashChangelogs(t *testing.T) {
+	t.Parallel()
+
+	changelogs := []domain.Changelog{
+		testChangelog("2024-03-01", 1),
+		testChangelog("2023-12-01", 2),
+		testChangelog("2023-06-01", 3),
+	}
+
+	before, _ := time.Parse(time.DateOnly, "2024-01-01")
+	squashed, count := squashChangelogs(changelogs, before)
+	assert.Equal(t, 2, count)
+	require.Len(t, squashed, 2)
+	assert.Equal(t, changelogs[0], squashed[0])
+	assert.Equal(t, changelogs[1].Date, squashed[1].Date)
+	require.Len(t, squashed[1].Changes, 1)
+	assert.Equal(t, domain.ChangeTypeBaseline, squashed[1].Changes[0].Type)
+	assert.Equal(t, "2 changelog entries with 5 changes from 2023-06-01 to 2023-12-01 were squashed",
+		squashed[1].Changes[0].Details)
+
+	// Squashing again finds only the baseline and leaves it as is.
+	again, count := squashChangelogs(squashed, before)
+	assert.Zero(t, count)
+	assert.Equal(t, squashed, again)
+
+	all, count := squashChangelogs(squashed, time.Time{})
+	assert.Equal(t, 2, count)
+	require.Len(t, all, 1)
+	assert.Equal(t, changelogs[0].Date, all[0].Date)
+}
+
+func TestSquashChangelogs_Twice(t *testing.T) {
+	t.Parallel()
+
+	squashed, count := squashChangelogs([]domain.Changelog{
+		testChangelog("2023-06-01", 5),
+		testChangelog("2023-03-01", 3),
+		testChangelog("2023-01-01", 2),
+	}, time.Time{})
+	assert.Equal(t, 3, count)
+	require.Len(t, squashed, 1)
+	assert.Equal(t, "3 changelog entries with 10 changes from 2023-01-01 to 2023-06-01 were squashed",
+		squashed[0].Changes[0].Details)
+
+	again, count := squashChangelogs(append([]domain.Changelog{testChangelog("2023-09-01", 2)}, squashed...),
+		time.Time{})
+	assert.Equal(t, 2, count)
+	require.Len(t, again, 1)
+	assert.Equal(t, "4 changelog entries with 12 changes from 2023-01-01 to 2023-09-01 were squashed",
+		again[0].Changes[0].Details)
+
+	since, _ := time.Parse(time.DateOnly, "2023-01-01")
+	assert.Equal(t, &domain.ChangelogBaseline{Entries: 4, Changes: 12, Since: since}, again[0].Baseline)
+}
+
+func TestGenerator_SquashChangelog(t *testing.T) {
+	t.Parallel()
+
+	outputDir, fsys := "docs", outputfs.NewMemory()
+	g := &Generator{fs: fsys}
+
+	_, err := g.SquashChangelog(domain.SquashChangelogRequest{OutputDir: outputDir})
+	require.ErrorIs(t, err, domain.ErrMetadataNotFound)
+
+	require.NoError(t, writeMetadata(fsys, outputDir, Metadata{
+		Changelogs: []domain.Changelog{testChangelog("2024-03-01", 1), testChangelog("2023-06-01", 1)},
+	}))
+
+	reply, err := g.SquashChangelog(domain.SquashChangelogRequest{OutputDir: outputDir})
+	require.NoError(t, err)
+	assert.Equal(t, domain.SquashChangelogReply{Squashed: 2, Remaining: 1}, reply)
+
+	metadata, err := readMetadata(fsys, outputDir)
+	require.NoError(t, err)
+	require.Len(t, metadata.Changelogs, 1)
+	assert.Equal(t, domain.ChangeTypeBaseline, metadata.Changelogs[0].Changes[0].Type)
+}
+
+func TestGenerator_DocumentedSchema(t *testing.T) {
+	t.Parallel()
+
+	outputDir, fsys := "docs", outputfs.NewMemory()
+	g := &Generator{fs: fsys}
+
+	_, err := g.DocumentedSchema(outputDir)
+	require.ErrorIs(t, err, domain.ErrMetadataNotFound)
+
+	schema := domain.Schema{Services: []domain.Service{{Info: domain.ServiceInfo{Name: "User Service"}}}}
+	require.NoError(t, writeMetadata(fsys, outputDir, Metadata{Schema: schema}))
+
+	documented, err := g.DocumentedSchema(outputDir)
+	require.NoError(t, err)
+	assert.Equal(t, "User Service", documented.Services[0].Info.Name)
+}
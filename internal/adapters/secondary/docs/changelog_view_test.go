@@ -0,0 +1,75 @@
+package docs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/holydocs/holydocs/pkg/outputfs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitChangelogs(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	changelogs := []domain.Changelog{
+		{Date: now.AddDate(0, 0, -1)},
+		{Date: now.AddDate(0, 0, -10)},
+		{Date: now.AddDate(0, 0, -100)},
+	}
+
+	recent, older := splitChangelogs(changelogs, config.ChangelogDocumentation{}, now)
+	assert.Len(t, recent, 3)
+	assert.Empty(t, older)
+
+	recent, older = splitChangelogs(changelogs, config.ChangelogDocumentation{MaxEntries: 1}, now)
+	assert.Len(t, recent, 1)
+	assert.Len(t, older, 2)
+
+	recent, older = splitChangelogs(changelogs, config.ChangelogDocumentation{CollapseOlderThan: "30d"}, now)
+	assert.Len(t, recent, 2)
+	assert.Len(t, older, 1)
+
+	recent, older = splitChangelogs(changelogs,
+		config.ChangelogDocumentation{MaxEntries: 5, CollapseOlderThan: "48h"}, now)
+	assert.Len(t, recent, 1)
+	assert.Len(t, older, 2)
+}
+
+func TestWriteReadme_CollapsedChangelog(t *testing.T) {
+	t.Parallel()
+
+	change := func(details string) []domain.Change {
+		return []domain.Change{{Type: domain.ChangeTypeAdded, Category: "service", Details: details}}
+	}
+	changelogs := []domain.Changelog{
+		{Date: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC), Changes: change("'A' was added")},
+		{Date: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC), Changes: change("'B' was added")},
+	}
+
+	outputDir := t.TempDir()
+	require.NoError(t, writeReadme(newSite(outputfs.OS(), config.Output{Dir: outputDir}), templateData{
+		Title:            "Test",
+		Changelogs:       changelogs,
+		RecentChangelogs: changelogs[:1],
+		OlderChangelogs:  changelogs[1:],
+	}))
+
+	readme, err := os.ReadFile(filepath.Join(outputDir, "README.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(readme), `## Changelog
+### 2024-05-01 10:00
+- **added** service: 'A' was added
+
+<details><summary>Older changes (1)</summary>
+
+### 2024-01-01 10:00
+- **added** service: 'B' was added
+
+</details>`)
+}
@@ -0,0 +1,58 @@
+package docs
+
+import (
+	"strings"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+)
+
+// annotateChannelViews sets the service level annotations of the channels and locates the channels of their
+// dead letter queues, so the sections can link them.
+func annotateChannelViews(views []channelView, slas map[string]domain.ChannelSLA) []channelView {
+	if len(slas) == 0 {
+		return views
+	}
+
+	byName := make(map[string]channelView, len(views))
+	for _, view := range views {
+		byName[view.Name] = view
+	}
+
+	annotated := make([]channelView, len(views))
+	for i, view := range views {
+		view.SLA = slas[view.Name]
+
+		if dlq, ok := byName[view.SLA.DLQ]; ok {
+			view.DLQAnchor = dlq.Anchor
+			view.DLQFileName = dlq.FileName
+		}
+
+		annotated[i] = view
+	}
+
+	return annotated
+}
+
+// HasSLA reports whether the channel has any service level annotation.
+func (v channelView) HasSLA() bool {
+	return v.SLA != domain.ChannelSLA{}
+}
+
+// SLASummary returns the service level annotations of the channel on a single line.
+func (v channelView) SLASummary() string {
+	var parts []string
+
+	if v.SLA.Throughput != "" {
+		parts = append(parts, v.SLA.Throughput)
+	}
+
+	if v.SLA.MaxLatency != "" {
+		parts = append(parts, "max latency "+v.SLA.MaxLatency)
+	}
+
+	if v.SLA.DLQ != "" {
+		parts = append(parts, "DLQ "+v.SLA.DLQ)
+	}
+
+	return strings.Join(parts, ", ")
+}
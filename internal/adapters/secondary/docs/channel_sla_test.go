@@ -0,0 +1,28 @@
+package docs
+
+import (
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnnotateChannelViews(t *testing.T) {
+	t.Parallel()
+
+	views := annotateChannelViews([]channelView{
+		{Name: "orders", Anchor: "orders", FileName: "orders"},
+		{Name: "orders.dlq", Anchor: "ordersdlq", FileName: "orders-dlq"},
+		{Name: "payments", Anchor: "payments", FileName: "payments"},
+	}, map[string]domain.ChannelSLA{
+		"orders":   {Throughput: "500 msg/s", MaxLatency: "2s", DLQ: "orders.dlq"},
+		"payments": {DLQ: "payments.dlq"},
+	})
+
+	assert.True(t, views[0].HasSLA())
+	assert.Equal(t, "500 msg/s, max latency 2s, DLQ orders.dlq", views[0].SLASummary())
+	assert.Equal(t, "ordersdlq", views[0].DLQAnchor)
+	assert.Equal(t, "orders-dlq", views[0].DLQFileName)
+	assert.False(t, views[1].HasSLA())
+	assert.Empty(t, views[2].DLQAnchor)
+}
@@ -0,0 +1,82 @@
+package docs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	d2target "github.com/holydocs/holydocs/internal/adapters/secondary/target/d2"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/holydocs/holydocs/pkg/outputfs"
+)
+
+const contextMapDiagramName = "context-map"
+
+type contextMapView struct {
+	Diagram       string
+	D2            string
+	Relationships []contextMapRelationshipView
+}
+
+type contextMapRelationshipView struct {
+	Upstream   string
+	Downstream string
+	Symmetric  bool
+	Patterns   string
+}
+
+// HasData reports whether any system is marked as a bounded context.
+func (v contextMapView) HasData() bool {
+	return v.Diagram != ""
+}
+
+// generateContextMap renders the DDD context map when at least one system is marked as a bounded context.
+func generateContextMap(ctx context.Context, fsys outputfs.FS, schema domain.Schema, target domain.Target,
+	diagramsDir string, recorder *diagramRecorder) (contextMapView, error) {
+	d2Target, ok := target.(*d2target.Target)
+	if !ok {
+		return contextMapView{}, errors.New("target is not a D2 target")
+	}
+
+	script, err := d2Target.GenerateContextMapDiagramScript(schema)
+	if err != nil {
+		return contextMapView{}, fmt.Errorf("generate context map D2 script: %w", err)
+	}
+
+	if len(script) == 0 {
+		return contextMapView{}, nil
+	}
+
+	d2Path := filepath.Join(diagramsDir, contextMapDiagramName+".d2")
+	if err := fsys.WriteFile(d2Path, script, filePerm); err != nil {
+		return contextMapView{}, fmt.Errorf("write context map D2 script: %w", err)
+	}
+
+	diagram, err := d2Target.RenderSchema(ctx, domain.FormattedSchema{Type: "d2", Data: script})
+	if err != nil {
+		return contextMapView{}, fmt.Errorf("render context map diagram: %w", err)
+	}
+
+	svgPath := filepath.Join(diagramsDir, contextMapDiagramName+".svg")
+	if err := fsys.WriteFile(svgPath, diagram, filePerm); err != nil {
+		return contextMapView{}, fmt.Errorf("write context map diagram: %w", err)
+	}
+	recorder.rendered()
+
+	view := contextMapView{
+		Diagram: filepath.ToSlash(filepath.Join(diagramsDirName, contextMapDiagramName+".svg")),
+		D2:      filepath.ToSlash(filepath.Join(diagramsDirName, contextMapDiagramName+".d2")),
+	}
+
+	for _, edge := range d2target.BuildContextMap(schema).Edges {
+		view.Relationships = append(view.Relationships, contextMapRelationshipView{
+			Upstream:   edge.Upstream,
+			Downstream: edge.Downstream,
+			Symmetric:  edge.Symmetric,
+			Patterns:   edge.Label,
+		})
+	}
+
+	return view, nil
+}
@@ -0,0 +1,305 @@
+package docs
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"gopkg.in/yaml.v3"
+)
+
+// datastoreSchemasFileName is the datastore schemas appendix page in multi-page documentation.
+const datastoreSchemasFileName = "datastores.md"
+
+// datastoreAnchorPrefix keeps datastore anchors apart from service and event anchors.
+const datastoreAnchorPrefix = "datastore-"
+
+type datastoreSchemaView struct {
+	Name   string
+	Anchor string
+	Tables []datastoreTable
+	// Users are the services having a relationship with the datastore.
+	Users []eventLink
+}
+
+type datastoreTable struct {
+	Name    string            `yaml:"name"`
+	Note    string            `yaml:"note"`
+	Columns []datastoreColumn `yaml:"columns"`
+}
+
+type datastoreColumn struct {
+	Name string `yaml:"name"`
+	Type string `yaml:"type"`
+	Note string `yaml:"note"`
+}
+
+// datastoreInventory is the YAML inventory of a datastore, in the spirit of dbdocs: tables of relational
+// databases and collections of document stores, both with their columns (fields).
+type datastoreInventory struct {
+	Tables      []datastoreTable `yaml:"tables"`
+	Collections []datastoreTable `yaml:"collections"`
+}
+
+// buildDatastoreSchemas loads the configured table inventories of datastores. Inventories that can't be read
+// are left out and reported as warnings.
+func buildDatastoreSchemas(schema domain.Schema,
+	datastores map[string]config.DatastoreDocumentation) ([]datastoreSchemaView, []string) {
+	var (
+		views    []datastoreSchemaView
+		warnings []string
+	)
+
+	for _, name := range slices.Sorted(maps.Keys(datastores)) {
+		tables, err := loadDatastoreSchema(datastores[name].Schema)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("datastore %s schema left out: %v", name, err))
+			continue
+		}
+
+		view := datastoreSchemaView{Name: name, Anchor: datastoreAnchorPrefix + sanitizeAnchor(name), Tables: tables}
+
+		for _, service := range schema.Services {
+			for _, rel := range service.Relationships {
+				if rel.Participant == name {
+					view.Users = appendEventLink(view.Users, service.Info.Name)
+				}
+			}
+		}
+
+		sortEventLinks(view.Users)
+
+		if len(view.Users) == 0 {
+			warnings = append(warnings, fmt.Sprintf("schema configured for datastore %s no service uses", name))
+		}
+
+		views = append(views, view)
+	}
+
+	return views, warnings
+}
+
+func loadDatastoreSchema(path string) ([]datastoreTable, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read schema: %w", err)
+	}
+
+	if strings.EqualFold(filepath.Ext(path), ".sql") {
+		return parseDDL(string(data)), nil
+	}
+
+	var inventory datastoreInventory
+	if err := yaml.Unmarshal(data, &inventory); err != nil {
+		return nil, fmt.Errorf("parse schema %s: %w", path, err)
+	}
+
+	return append(inventory.Tables, inventory.Collections...), nil
+}
+
+var (
+	sqlLineComment  = regexp.MustCompile(`--[^\n]*`)
+	sqlBlockComment = regexp.MustCompile(`(?s)/\*.*?\*/`)
+	sqlCreateTable  = regexp.MustCompile(
+		`(?i)\bCREATE\s+(?:(?:GLOBAL|LOCAL)\s+)?(?:(?:TEMP|TEMPORARY|UNLOGGED)\s+)?TABLE\s+` +
+			`(?:IF\s+NOT\s+EXISTS\s+)?([^\s(]+)\s*\(`)
+)
+
+// sqlConstraintKeywords start table constraints, which are not columns.
+//
+//nolint:gochecknoglobals // Fixed vocabulary of the SQL parser.
+var sqlConstraintKeywords = []string{"CONSTRAINT", "PRIMARY", "FOREIGN", "UNIQUE", "CHECK", "EXCLUDE", "INDEX", "KEY"}
+
+// sqlColumnKeywords end the type of a column definition, the rest of the definition is kept as its note.
+//
+//nolint:gochecknoglobals // Fixed vocabulary of the SQL parser.
+var sqlColumnKeywords = []string{
+	"NOT", "NULL", "DEFAULT", "PRIMARY", "REFERENCES", "UNIQUE", "CHECK", "CONSTRAINT", "COLLATE", "GENERATED",
+	"AUTO_INCREMENT", "AUTOINCREMENT", "COMMENT", "IDENTITY",
+}
+
+// parseDDL extracts the tables and their columns from CREATE TABLE statements. Other statements are ignored.
+func parseDDL(ddl string) []datastoreTable {
+	ddl = sqlBlockComment.ReplaceAllString(ddl, "")
+	ddl = sqlLineComment.ReplaceAllString(ddl, "")
+
+	var tables []datastoreTable
+
+	for _, match := range sqlCreateTable.FindAllStringSubmatchIndex(ddl, -1) {
+		body, ok := parenthesized(ddl[match[1]:])
+		if !ok {
+			continue
+		}
+
+		table := datastoreTable{Name: unquoteIdentifier(ddl[match[2]:match[3]])}
+
+		for _, definition := range splitTopLevel(body) {
+			if column, ok := parseColumn(definition); ok {
+				table.Columns = append(table.Columns, column)
+			}
+		}
+
+		tables = append(tables, table)
+	}
+
+	return tables
+}
+
+// parenthesized returns the text up to the parenthesis closing the one opened right before s.
+func parenthesized(s string) (string, bool) {
+	depth := 1
+
+	for i, r := range s {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return s[:i], true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// splitTopLevel splits the definitions of a table on commas outside of parentheses and quotes.
+func splitTopLevel(body string) []string {
+	var (
+		parts []string
+		depth int
+		quote rune
+		start int
+	)
+
+	for i, r := range body {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"' || r == '`':
+			quote = r
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+		case r == ',' && depth == 0:
+			parts = append(parts, body[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(parts, body[start:])
+}
+
+func parseColumn(definition string) (datastoreColumn, bool) {
+	fields := strings.Fields(definition)
+	if len(fields) == 0 || slices.Contains(sqlConstraintKeywords, strings.ToUpper(fields[0])) {
+		return datastoreColumn{}, false
+	}
+
+	column := datastoreColumn{Name: unquoteIdentifier(fields[0])}
+
+	typeEnd := len(fields)
+	for i := 1; i < len(fields); i++ {
+		if slices.Contains(sqlColumnKeywords, strings.ToUpper(fields[i])) {
+			typeEnd = i
+			break
+		}
+	}
+
+	column.Type = strings.Join(fields[1:typeEnd], " ")
+	column.Note = strings.Join(fields[typeEnd:], " ")
+
+	return column, true
+}
+
+func unquoteIdentifier(identifier string) string {
+	parts := strings.Split(identifier, ".")
+	for i, part := range parts {
+		parts[i] = strings.Trim(part, "\"`[]")
+	}
+
+	return strings.Join(parts, ".")
+}
+
+// linkDatastoreSchemas links the relationships of services and the datastores table to the schemas of the
+// datastores. In multi-page mode links point to the appendix page.
+func linkDatastoreSchemas(data templateData, multiPage bool) templateData {
+	if len(data.DatastoreSchemas) == 0 {
+		return data
+	}
+
+	var fromOverview, fromService string
+	if multiPage {
+		data.DatastoreSchemasPath = datastoreSchemasFileName
+		fromOverview = datastoreSchemasFileName
+		fromService = "../" + datastoreSchemasFileName
+	}
+
+	anchors := make(map[string]string, len(data.DatastoreSchemas))
+	for _, datastore := range data.DatastoreSchemas {
+		anchors[datastore.Name] = datastore.Anchor
+	}
+
+	serviceLinks := make(map[string]string)
+	for i := range data.Systems {
+		for j := range data.Systems[i].Services {
+			service := &data.Systems[i].Services[j]
+			serviceLinks[service.Name] = sectionLink(service.Anchor, service.FilePath, multiPage)
+
+			for k := range service.RelationshipSummaries {
+				summary := &service.RelationshipSummaries[k]
+				if anchor, ok := anchors[summary.Participant]; ok {
+					summary.SchemaLink = fromService + "#" + anchor
+				}
+			}
+		}
+	}
+
+	for i := range data.Datastores {
+		if anchor, ok := anchors[data.Datastores[i].Name]; ok {
+			data.Datastores[i].SchemaLink = fromOverview + "#" + anchor
+		}
+	}
+
+	for i := range data.DatastoreSchemas {
+		data.DatastoreSchemas[i].Users = resolveEventLinks(data.DatastoreSchemas[i].Users, serviceLinks)
+	}
+
+	return data
+}
+
+type datastoreSchemasPageData struct {
+	DatastoreSchemas []datastoreSchemaView
+}
+
+// writeDatastoreSchemasPage generates the datastore schemas appendix page for multi-page mode.
+func writeDatastoreSchemasPage(pages site, outputDir string, data templateData) error {
+	tmpl, err := template.New("datastores.tmpl").Funcs(pages.templateFuncs()).
+		ParseFS(multiPageTemplateFS, "templates/md_multi_page/datastores.tmpl")
+	if err != nil {
+		return fmt.Errorf("parse datastore schemas template: %w", err)
+	}
+
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, datastoreSchemasPageData{DatastoreSchemas: data.DatastoreSchemas}); err != nil {
+		return fmt.Errorf("execute datastore schemas template: %w", err)
+	}
+
+	schemasPath := filepath.Join(outputDir, datastoreSchemasFileName)
+	if err := pages.writePage(schemasPath, pageMeta{Title: "Datastore Schemas"}, buf.String()); err != nil {
+		return fmt.Errorf("write datastore schemas page: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package docs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDDL(t *testing.T) {
+	t.Parallel()
+
+	tables := parseDDL(`
+-- Orders placed by customers
+CREATE TABLE IF NOT EXISTS "public"."orders" (
+    id UUID PRIMARY KEY,
+    customer_id UUID NOT NULL REFERENCES customers (id),
+    total NUMERIC(10, 2) NOT NULL DEFAULT 0,
+    status VARCHAR(32), /* pending, paid, shipped */
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    CONSTRAINT orders_total_check CHECK (total >= 0)
+);
+
+CREATE INDEX orders_customer_idx ON orders (customer_id);
+
+create table order_items (
+    order_id uuid,
+    sku text,
+    primary key (order_id, sku)
+);
+`)
+
+	assert.Equal(t, []datastoreTable{
+		{Name: "public.orders", Columns: []datastoreColumn{
+			{Name: "id", Type: "UUID", Note: "PRIMARY KEY"},
+			{Name: "customer_id", Type: "UUID", Note: "NOT NULL REFERENCES customers (id)"},
+			{Name: "total", Type: "NUMERIC(10, 2)", Note: "NOT NULL DEFAULT 0"},
+			{Name: "status", Type: "VARCHAR(32)"},
+			{Name: "created_at", Type: "TIMESTAMP WITH TIME ZONE", Note: "NOT NULL"},
+		}},
+		{Name: "order_items", Columns: []datastoreColumn{
+			{Name: "order_id", Type: "uuid"},
+			{Name: "sku", Type: "text"},
+		}},
+	}, tables)
+}
+
+func TestBuildDatastoreSchemas(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	inventory := filepath.Join(dir, "events.yaml")
+	require.NoError(t, os.WriteFile(inventory, []byte(`tables:
+  - name: outbox
+    note: Events waiting to be published
+    columns:
+      - name: id
+        type: bigint
+collections:
+  - name: snapshots
+    columns:
+      - name: payload
+        type: object
+        note: Latest state of the aggregate
+`), 0o600))
+
+	schema := domain.Schema{Services: []domain.Service{{
+		Info: domain.ServiceInfo{Name: "Orders"},
+		Relationships: []domain.Relationship{
+			{Action: domain.RelationshipActionUses, Participant: "events-db", Access: domain.AccessWrite},
+		},
+	}}}
+
+	views, warnings := buildDatastoreSchemas(schema, map[string]config.DatastoreDocumentation{
+		"events-db": {Schema: inventory},
+		"legacy-db": {Schema: filepath.Join(dir, "missing.sql")},
+	})
+
+	require.Len(t, views, 1)
+	assert.Equal(t, datastoreSchemaView{
+		Name:   "events-db",
+		Anchor: "datastore-events-db",
+		Tables: []datastoreTable{
+			{Name: "outbox", Note: "Events waiting to be published", Columns: []datastoreColumn{
+				{Name: "id", Type: "bigint"},
+			}},
+			{Name: "snapshots", Columns: []datastoreColumn{
+				{Name: "payload", Type: "object", Note: "Latest state of the aggregate"},
+			}},
+		},
+		Users: []eventLink{{Name: "Orders"}},
+	}, views[0])
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "legacy-db")
+
+	data := templateData{
+		Systems: []systemView{{Services: []serviceView{{
+			Name: "Orders", Anchor: "orders", FilePath: "services/orders.md",
+			RelationshipSummaries: []relationshipSummary{{Participant: "events-db"}, {Participant: "Redis"}},
+		}}}},
+		Datastores:       []datastoreView{{Name: "events-db"}},
+		DatastoreSchemas: views,
+	}
+
+	linked := linkDatastoreSchemas(data, true)
+	assert.Equal(t, datastoreSchemasFileName, linked.DatastoreSchemasPath)
+	assert.Equal(t, "../datastores.md#datastore-events-db",
+		linked.Systems[0].Services[0].RelationshipSummaries[0].SchemaLink)
+	assert.Empty(t, linked.Systems[0].Services[0].RelationshipSummaries[1].SchemaLink)
+	assert.Equal(t, "datastores.md#datastore-events-db", linked.Datastores[0].SchemaLink)
+	assert.Equal(t, []eventLink{{Name: "Orders", Link: "services/orders.md"}}, linked.DatastoreSchemas[0].Users)
+
+	linked = linkDatastoreSchemas(data, false)
+	assert.Equal(t, "#datastore-events-db", linked.Datastores[0].SchemaLink)
+	assert.Equal(t, []eventLink{{Name: "Orders", Link: "#orders"}}, linked.DatastoreSchemas[0].Users)
+}
@@ -0,0 +1,73 @@
+package docs
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+)
+
+type datastoreView struct {
+	Name       string
+	Technology string
+	Writers    []string
+	Readers    []string
+	// SchemaLink points to the schema of the datastore, when one is configured.
+	SchemaLink string
+}
+
+// WritersList returns the services writing to the datastore, comma-separated.
+func (v datastoreView) WritersList() string {
+	return strings.Join(v.Writers, ", ")
+}
+
+// ReadersList returns the services reading from the datastore, comma-separated.
+func (v datastoreView) ReadersList() string {
+	return strings.Join(v.Readers, ", ")
+}
+
+// buildDatastores lists the participants relationships declare an access mode for, with the services writing
+// to and reading from them.
+func buildDatastores(schema domain.Schema) []datastoreView {
+	views := make(map[string]*datastoreView)
+
+	for _, service := range schema.Services {
+		for _, rel := range service.Relationships {
+			if !rel.Access.Valid() {
+				continue
+			}
+
+			view, exists := views[rel.Participant]
+			if !exists {
+				view = &datastoreView{Name: rel.Participant}
+				views[rel.Participant] = view
+			}
+
+			if view.Technology == "" {
+				view.Technology = rel.Technology
+			}
+
+			if rel.Access.Writes() && !slices.Contains(view.Writers, service.Info.Name) {
+				view.Writers = append(view.Writers, service.Info.Name)
+			}
+
+			if rel.Access.Reads() && !slices.Contains(view.Readers, service.Info.Name) {
+				view.Readers = append(view.Readers, service.Info.Name)
+			}
+		}
+	}
+
+	datastores := make([]datastoreView, 0, len(views))
+	for _, view := range views {
+		sort.Strings(view.Writers)
+		sort.Strings(view.Readers)
+		datastores = append(datastores, *view)
+	}
+
+	sort.Slice(datastores, func(i, j int) bool {
+		return strings.ToLower(datastores[i].Name) < strings.ToLower(datastores[j].Name)
+	})
+
+	return datastores
+}
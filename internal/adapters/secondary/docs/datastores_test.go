@@ -0,0 +1,36 @@
+package docs
+
+import (
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildDatastores(t *testing.T) {
+	t.Parallel()
+
+	schema := domain.Schema{Services: []domain.Service{
+		{
+			Info: domain.ServiceInfo{Name: "Orders"},
+			Relationships: []domain.Relationship{
+				{Action: domain.RelationshipActionUses, Participant: "orders-db", Technology: "PostgreSQL",
+					Access: domain.AccessReadWrite},
+				{Action: domain.RelationshipActionUses, Participant: "Redis"},
+			},
+		},
+		{
+			Info: domain.ServiceInfo{Name: "Reporting"},
+			Relationships: []domain.Relationship{
+				{Action: domain.RelationshipActionUses, Participant: "orders-db", Access: domain.AccessRead},
+				{Action: domain.RelationshipActionUses, Participant: "Analytics", Access: domain.AccessWrite},
+			},
+		},
+	}}
+
+	assert.Equal(t, []datastoreView{
+		{Name: "Analytics", Writers: []string{"Reporting"}},
+		{Name: "orders-db", Technology: "PostgreSQL", Writers: []string{"Orders"}, Readers: []string{"Orders", "Reporting"}},
+	}, buildDatastores(schema))
+	assert.Empty(t, buildDatastores(domain.Schema{}))
+}
@@ -0,0 +1,160 @@
+package docs
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+)
+
+type decommissionView struct {
+	Service    string
+	Owner      string
+	SunsetDate string
+	Overdue    bool
+	Blockers   []decommissionBlocker
+}
+
+type decommissionBlocker struct {
+	Service string
+	Owner   string
+	Via     string
+}
+
+// buildDecommissioning lists deprecated services together with the dependencies that still
+// block their removal. A service is overdue when its sunset date has passed and blockers remain.
+func buildDecommissioning(schema domain.Schema, asyncEdges []asyncEdge, now time.Time) []decommissionView {
+	owners := make(map[string]string, len(schema.Services))
+	for _, service := range schema.Services {
+		owners[service.Info.Name] = service.Info.Owner
+	}
+
+	var views []decommissionView
+
+	for _, service := range schema.Services {
+		if !service.Info.Deprecated {
+			continue
+		}
+
+		blockers := collectDecommissionBlockers(service, schema.Services, asyncEdges, owners)
+
+		views = append(views, decommissionView{
+			Service:    service.Info.Name,
+			Owner:      service.Info.Owner,
+			SunsetDate: service.Info.SunsetDate,
+			Overdue:    len(blockers) > 0 && sunsetPassed(service.Info.SunsetDate, now),
+			Blockers:   blockers,
+		})
+	}
+
+	sort.SliceStable(views, func(i, j int) bool {
+		if views[i].SunsetDate != views[j].SunsetDate {
+			return views[i].SunsetDate < views[j].SunsetDate
+		}
+
+		return views[i].Service < views[j].Service
+	})
+
+	return views
+}
+
+func collectDecommissionBlockers(deprecated domain.Service, services []domain.Service,
+	asyncEdges []asyncEdge, owners map[string]string) []decommissionBlocker {
+	name := deprecated.Info.Name
+	seen := make(map[string]struct{})
+	var blockers []decommissionBlocker
+
+	add := func(service, via string) {
+		key := service + "|" + via
+		if _, exists := seen[key]; exists {
+			return
+		}
+		seen[key] = struct{}{}
+		blockers = append(blockers, decommissionBlocker{Service: service, Owner: owners[service], Via: via})
+	}
+
+	for _, service := range services {
+		if service.Info.Name == name {
+			continue
+		}
+
+		for _, rel := range service.Relationships {
+			// A service replying to the deprecated one is a dependency of it, not a dependent.
+			if rel.Participant == name && rel.Action != domain.RelationshipActionReplies {
+				add(service.Info.Name, string(rel.Action))
+			}
+		}
+	}
+
+	for _, rel := range deprecated.Relationships {
+		if rel.Action == domain.RelationshipActionReplies && rel.Participant != name {
+			add(rel.Participant, string(domain.RelationshipActionRequests))
+		}
+	}
+
+	// Send edges on request/reply channels are covered by the reply edge in the right direction.
+	replyChannels := make(map[string]struct{})
+	for _, edge := range asyncEdges {
+		if edge.Kind == "reply" {
+			replyChannels[edge.Channel] = struct{}{}
+		}
+	}
+
+	for _, edge := range asyncEdges {
+		_, isReplyChannel := replyChannels[edge.Channel]
+
+		switch {
+		case edge.Source == name && edge.Target != name && edge.Kind == "reply":
+			add(edge.Target, "requests "+edge.Channel)
+		case isReplyChannel:
+			continue
+		case edge.Source == name && edge.Target != name:
+			add(edge.Target, "consumes "+edge.Channel)
+		case edge.Target == name && edge.Source != name:
+			add(edge.Source, "publishes to "+edge.Channel)
+		}
+	}
+
+	sort.SliceStable(blockers, func(i, j int) bool {
+		if blockers[i].Owner != blockers[j].Owner {
+			// Blockers without an owner go last.
+			if blockers[i].Owner == "" || blockers[j].Owner == "" {
+				return blockers[j].Owner == ""
+			}
+
+			return blockers[i].Owner < blockers[j].Owner
+		}
+		if blockers[i].Service != blockers[j].Service {
+			return blockers[i].Service < blockers[j].Service
+		}
+
+		return blockers[i].Via < blockers[j].Via
+	})
+
+	return blockers
+}
+
+func sunsetPassed(sunsetDate string, now time.Time) bool {
+	sunset, err := time.Parse(time.DateOnly, strings.TrimSpace(sunsetDate))
+	if err != nil {
+		return false
+	}
+
+	return now.UTC().Format(time.DateOnly) > sunset.Format(time.DateOnly)
+}
+
+// decommissionWarnings returns a human readable warning for every overdue decommission.
+func decommissionWarnings(views []decommissionView) []string {
+	var warnings []string
+
+	for _, view := range views {
+		if view.Overdue {
+			warnings = append(warnings, fmt.Sprintf("%s passed its sunset date %s with %d dependencies remaining",
+				view.Service, view.SunsetDate, len(view.Blockers)))
+		}
+	}
+
+	return warnings
+}
@@ -0,0 +1,65 @@
+package docs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildDecommissioning(t *testing.T) {
+	t.Parallel()
+
+	schema := domain.Schema{
+		Services: []domain.Service{
+			{
+				Info: domain.ServiceInfo{Name: "Legacy", Owner: "team-core", Deprecated: true, SunsetDate: "2025-01-31"},
+				Relationships: []domain.Relationship{
+					{Action: domain.RelationshipActionReplies, Participant: "Support Agent", Person: true},
+				},
+			},
+			{
+				Info: domain.ServiceInfo{Name: "Orders", Owner: "team-orders"},
+				Relationships: []domain.Relationship{
+					{Action: domain.RelationshipActionRequests, Participant: "Legacy"},
+				},
+			},
+			{
+				Info: domain.ServiceInfo{Name: "Billing", Owner: "team-billing"},
+			},
+			{
+				Info: domain.ServiceInfo{Name: "Unused", Deprecated: true},
+			},
+		},
+	}
+	edges := []asyncEdge{
+		{Source: "Legacy", Target: "Billing", Channel: "legacy.events", Kind: "send"},
+		{Source: "Legacy", Target: "Billing", Channel: "billing.request", Kind: "send"},
+		{Source: "Billing", Target: "Legacy", Channel: "billing.request", Kind: "reply"},
+	}
+
+	views := buildDecommissioning(schema, edges, time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC))
+	require.Len(t, views, 2)
+
+	assert.Equal(t, "Unused", views[0].Service)
+	assert.Empty(t, views[0].Blockers)
+	assert.False(t, views[0].Overdue)
+
+	legacy := views[1]
+	assert.Equal(t, "Legacy", legacy.Service)
+	assert.True(t, legacy.Overdue)
+	assert.Equal(t, []decommissionBlocker{
+		{Service: "Billing", Owner: "team-billing", Via: "consumes legacy.events"},
+		{Service: "Orders", Owner: "team-orders", Via: "requests"},
+		{Service: "Support Agent", Via: "requests"},
+	}, legacy.Blockers)
+
+	warnings := decommissionWarnings(views)
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "Legacy passed its sunset date 2025-01-31")
+
+	onSunsetDay := buildDecommissioning(schema, edges, time.Date(2025, 1, 31, 23, 0, 0, 0, time.UTC))
+	assert.False(t, onSunsetDay[1].Overdue)
+}
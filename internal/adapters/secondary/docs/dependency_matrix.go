@@ -0,0 +1,192 @@
+package docs
+
+import (
+	"encoding/csv"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/holydocs/holydocs/pkg/outputfs"
+)
+
+// dependencyMatrixFileName is the CSV export of the dependency matrix, written next to the pages.
+const dependencyMatrixFileName = "dependency-matrix.csv"
+
+// Kinds of async edges in the dependency matrix, relationships are counted by their action.
+const (
+	asyncDependencyKind      = "async"
+	asyncReplyDependencyKind = "async reply"
+)
+
+// dependencyMatrixView counts the edges between services of systems, from the system of the row to the one
+// of the column.
+type dependencyMatrixView struct {
+	Systems []string
+	Rows    []dependencyMatrixRow
+	// CSVPath links the CSV export, it is set once the export is written.
+	CSVPath string
+}
+
+type dependencyMatrixRow struct {
+	System string
+	Cells  []dependencyMatrixCell
+}
+
+type dependencyMatrixCell struct {
+	Count int
+	Kinds map[string]int
+}
+
+// HasData reports whether the estate has enough systems for a matrix.
+func (v dependencyMatrixView) HasData() bool {
+	return len(v.Systems) > 1
+}
+
+// Text returns the number of edges with their kinds, e.g. "3 (2 requests, 1 async)" or "2 sends" for a
+// single kind, or "—" without edges.
+func (c dependencyMatrixCell) Text() string {
+	if c.Count == 0 {
+		return "—"
+	}
+
+	if len(c.Kinds) == 1 {
+		for kind, count := range c.Kinds {
+			return fmt.Sprintf("%d %s", count, kind)
+		}
+	}
+
+	kinds := make([]string, 0, len(c.Kinds))
+	for _, kind := range slices.Sorted(maps.Keys(c.Kinds)) {
+		kinds = append(kinds, fmt.Sprintf("%d %s", c.Kinds[kind], kind))
+	}
+
+	return fmt.Sprintf("%d (%s)", c.Count, strings.Join(kinds, ", "))
+}
+
+type dependencyEdgeKey struct {
+	from, to, kind string
+}
+
+// buildDependencyMatrix aggregates relationships between documented services and async edges into a matrix
+// of systems. Edges are counted once per pair of services and kind, however many sides declare them.
+// Services without a system are grouped as standalone services.
+func buildDependencyMatrix(schema domain.Schema, asyncEdges []asyncEdge) dependencyMatrixView {
+	systemOf := make(map[string]string, len(schema.Services))
+	for _, service := range schema.Services {
+		system := service.Info.System
+		if system == "" {
+			system = standaloneServicesName
+		}
+
+		systemOf[service.Info.Name] = system
+	}
+
+	edges := make(map[dependencyEdgeKey]struct{})
+
+	for _, service := range schema.Services {
+		for _, rel := range service.Relationships {
+			if _, ok := systemOf[rel.Participant]; !ok || rel.Participant == service.Info.Name {
+				continue
+			}
+
+			edges[dependencyEdge(service.Info.Name, rel)] = struct{}{}
+		}
+	}
+
+	for _, edge := range asyncEdges {
+		_, sourceKnown := systemOf[edge.Source]
+		_, targetKnown := systemOf[edge.Target]
+		if !sourceKnown || !targetKnown {
+			continue
+		}
+
+		kind := asyncDependencyKind
+		if edge.Kind == "reply" {
+			kind = asyncReplyDependencyKind
+		}
+
+		edges[dependencyEdgeKey{from: edge.Source, to: edge.Target, kind: kind}] = struct{}{}
+	}
+
+	systems := slices.Sorted(maps.Values(systemOf))
+	systems = slices.Compact(systems)
+
+	// Standalone services come last, as in the services chapter.
+	if i := slices.Index(systems, standaloneServicesName); i >= 0 {
+		systems = append(slices.Delete(systems, i, i+1), standaloneServicesName)
+	}
+
+	index := make(map[string]int, len(systems))
+	view := dependencyMatrixView{Systems: systems, Rows: make([]dependencyMatrixRow, len(systems))}
+
+	for i, system := range systems {
+		index[system] = i
+		view.Rows[i] = dependencyMatrixRow{System: system, Cells: make([]dependencyMatrixCell, len(systems))}
+	}
+
+	for edge := range edges {
+		cell := &view.Rows[index[systemOf[edge.from]]].Cells[index[systemOf[edge.to]]]
+		if cell.Kinds == nil {
+			cell.Kinds = make(map[string]int)
+		}
+
+		cell.Count++
+		cell.Kinds[edge.kind]++
+	}
+
+	return view
+}
+
+// dependencyEdge orients a relationship like the diagrams do, replies are drawn as requests of the participant
+// and receives as sends of the participant.
+func dependencyEdge(service string, rel domain.Relationship) dependencyEdgeKey {
+	switch rel.Action {
+	case domain.RelationshipActionReplies:
+		return dependencyEdgeKey{from: rel.Participant, to: service, kind: string(domain.RelationshipActionRequests)}
+	case domain.RelationshipActionReceives:
+		return dependencyEdgeKey{from: rel.Participant, to: service, kind: string(domain.RelationshipActionSends)}
+	default:
+		return dependencyEdgeKey{from: service, to: rel.Participant, kind: string(rel.Action)}
+	}
+}
+
+// writeDependencyMatrixCSV writes the dependency matrix as CSV, with the systems as the header row and
+// the first column.
+func writeDependencyMatrixCSV(fsys outputfs.FS, dir string, matrix dependencyMatrixView) error {
+	if err := fsys.MkdirAll(dir, dirPerm); err != nil {
+		return fmt.Errorf("create dependency matrix directory: %w", err)
+	}
+
+	var buf strings.Builder
+	w := csv.NewWriter(&buf)
+
+	records := make([][]string, 0, len(matrix.Rows)+1)
+	records = append(records, append([]string{"system"}, matrix.Systems...))
+
+	for _, row := range matrix.Rows {
+		record := []string{row.System}
+		for _, cell := range row.Cells {
+			text := ""
+			if cell.Count > 0 {
+				text = cell.Text()
+			}
+
+			record = append(record, text)
+		}
+
+		records = append(records, record)
+	}
+
+	if err := w.WriteAll(records); err != nil {
+		return fmt.Errorf("encode dependency matrix: %w", err)
+	}
+
+	if err := fsys.WriteFile(filepath.Join(dir, dependencyMatrixFileName), []byte(buf.String()), filePerm); err != nil {
+		return fmt.Errorf("write dependency matrix: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package docs
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/holydocs/holydocs/pkg/outputfs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildDependencyMatrix(t *testing.T) {
+	t.Parallel()
+
+	schema := domain.Schema{Services: []domain.Service{
+		{
+			Info: domain.ServiceInfo{Name: "Order Service", System: "Ordering"},
+			Relationships: []domain.Relationship{
+				{Action: domain.RelationshipActionRequests, Participant: "Billing Service"},
+				{Action: domain.RelationshipActionRequests, Participant: "Stripe", External: true},
+				{Action: domain.RelationshipActionUses, Participant: "postgres"},
+			},
+		},
+		{
+			Info: domain.ServiceInfo{Name: "Billing Service", System: "Billing"},
+			Relationships: []domain.Relationship{
+				{Action: domain.RelationshipActionReplies, Participant: "Order Service"},
+			},
+		},
+		{Info: domain.ServiceInfo{Name: "Audit Service"}},
+	}}
+
+	matrix := buildDependencyMatrix(schema, []asyncEdge{
+		{Source: "Order Service", Target: "Billing Service", Channel: "orders", Kind: "send"},
+		{Source: "Billing Service", Target: "Audit Service", Channel: "invoices", Kind: "send"},
+		{Source: "Billing Service", Target: "Order Service", Channel: "orders", Kind: "reply"},
+	})
+
+	require.True(t, matrix.HasData())
+	assert.Equal(t, []string{"Billing", "Ordering", standaloneServicesName}, matrix.Systems)
+
+	billing, ordering := matrix.Rows[0], matrix.Rows[1]
+	assert.Equal(t, "—", billing.Cells[0].Text())
+	assert.Equal(t, "1 async reply", billing.Cells[1].Text())
+	assert.Equal(t, "1 async", billing.Cells[2].Text())
+	assert.Equal(t, "2 (1 async, 1 requests)", ordering.Cells[0].Text())
+
+	dir, fsys := "docs", outputfs.NewMemory()
+	require.NoError(t, writeDependencyMatrixCSV(fsys, dir, matrix))
+
+	content, err := fsys.ReadFile(filepath.Join(dir, dependencyMatrixFileName))
+	require.NoError(t, err)
+	assert.Equal(t, "system,Billing,Ordering,Standalone Services\n"+
+		"Billing,,1 async reply,1 async\n"+
+		"Ordering,\"2 (1 async, 1 requests)\",,\n"+
+		"Standalone Services,,,\n", string(content))
+}
@@ -0,0 +1,109 @@
+package docs
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+)
+
+// thirdPartyDependencyView is an entry of the register of third-party dependencies, with the vendor and
+// licensing recorded for it.
+type thirdPartyDependencyView struct {
+	Name       string
+	Technology string
+	Vendor     string
+	License    string
+	Compliance string
+	// StatusPage links the status page of the vendor, Status is the status it reported on generation.
+	StatusPage string
+	Status     string
+	Users      []string
+}
+
+// UsersList returns the services depending on the dependency, comma-separated.
+func (v thirdPartyDependencyView) UsersList() string {
+	return strings.Join(v.Users, ", ")
+}
+
+// buildThirdPartyDependencies lists the external participants and the participants configured under
+// documentation.dependencies with the services depending on them. The register is only built when
+// dependencies are configured, configured dependencies no service has a relationship with are reported.
+// Statuses fetched from the status pages of dependencies are shown with their links.
+func buildThirdPartyDependencies(schema domain.Schema, dependencies map[string]config.DependencyDocumentation,
+	statuses map[string]domain.DependencyStatus) ([]thirdPartyDependencyView, []string) {
+	if len(dependencies) == 0 {
+		return nil, nil
+	}
+
+	views := make(map[string]*thirdPartyDependencyView)
+
+	for _, service := range schema.Services {
+		for _, rel := range service.Relationships {
+			dependency, configured := dependencies[rel.Participant]
+			if !rel.External && !configured {
+				continue
+			}
+
+			view, exists := views[rel.Participant]
+			if !exists {
+				view = &thirdPartyDependencyView{
+					Name:       rel.Participant,
+					Vendor:     dependency.Vendor,
+					License:    dependency.License,
+					Compliance: dependency.Compliance,
+					StatusPage: dependency.StatusPage,
+					Status:     statusText(statuses[rel.Participant]),
+				}
+				views[rel.Participant] = view
+			}
+
+			if view.Technology == "" {
+				view.Technology = rel.Technology
+			}
+
+			if !slices.Contains(view.Users, service.Info.Name) {
+				view.Users = append(view.Users, service.Info.Name)
+			}
+		}
+	}
+
+	var warnings []string
+
+	for name := range dependencies {
+		if _, ok := views[name]; !ok {
+			warnings = append(warnings, fmt.Sprintf("dependency configured for %s no service uses", name))
+		}
+	}
+
+	sort.Strings(warnings)
+
+	register := make([]thirdPartyDependencyView, 0, len(views))
+	for _, view := range views {
+		sort.Strings(view.Users)
+		register = append(register, *view)
+	}
+
+	sort.Slice(register, func(i, j int) bool {
+		return strings.ToLower(register[i].Name) < strings.ToLower(register[j].Name)
+	})
+
+	return register, warnings
+}
+
+// statusText describes the status reported by a status page, empty when it wasn't fetched.
+func statusText(status domain.DependencyStatus) string {
+	if status.Description != "" {
+		return status.Description
+	}
+
+	return status.Indicator
+}
+
+// hasStatusPages reports whether any dependency of the register links a status page.
+func hasStatusPages(register []thirdPartyDependencyView) bool {
+	return slices.ContainsFunc(register, func(v thirdPartyDependencyView) bool { return v.StatusPage != "" })
+}
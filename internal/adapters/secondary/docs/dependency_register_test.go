@@ -0,0 +1,103 @@
+package docs
+
+import (
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/holydocs/holydocs/pkg/outputfs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildThirdPartyDependencies(t *testing.T) {
+	t.Parallel()
+
+	schema := domain.Schema{Services: []domain.Service{
+		{
+			Info: domain.ServiceInfo{Name: "Payments"},
+			Relationships: []domain.Relationship{
+				{Action: domain.RelationshipActionRequests, Participant: "Stripe", Technology: "HTTP", External: true},
+				{Action: domain.RelationshipActionUses, Participant: "payments-db", Technology: "MongoDB"},
+				{Action: domain.RelationshipActionRequests, Participant: "Orders", Technology: "gRPC"},
+			},
+		},
+		{
+			Info: domain.ServiceInfo{Name: "Notifications"},
+			Relationships: []domain.Relationship{
+				{Action: domain.RelationshipActionRequests, Participant: "Twilio", External: true},
+				{Action: domain.RelationshipActionRequests, Participant: "Stripe", External: true},
+			},
+		},
+	}}
+
+	register, warnings := buildThirdPartyDependencies(schema, nil, nil)
+	assert.Empty(t, register, "the register is opt-in")
+	assert.Empty(t, warnings)
+
+	register, warnings = buildThirdPartyDependencies(schema, map[string]config.DependencyDocumentation{
+		"Stripe":      {Vendor: "Stripe, Inc.", Compliance: "DPA signed"},
+		"payments-db": {Vendor: "MongoDB Inc.", License: "SSPL"},
+		"Segment":     {License: "Commercial"},
+		"Twilio":      {StatusPage: "https://status.twilio.com"},
+	}, map[string]domain.DependencyStatus{
+		"Twilio": {Indicator: "minor", Description: "Partially Degraded Service"},
+	})
+	assert.Equal(t, []thirdPartyDependencyView{
+		{Name: "payments-db", Technology: "MongoDB", Vendor: "MongoDB Inc.", License: "SSPL", Users: []string{"Payments"}},
+		{Name: "Stripe", Technology: "HTTP", Vendor: "Stripe, Inc.", Compliance: "DPA signed",
+			Users: []string{"Notifications", "Payments"}},
+		{Name: "Twilio", StatusPage: "https://status.twilio.com", Status: "Partially Degraded Service",
+			Users: []string{"Notifications"}},
+	}, register)
+	assert.True(t, hasStatusPages(register))
+	assert.Equal(t, []string{"dependency configured for Segment no service uses"}, warnings)
+}
+
+func TestWriteReadme_ThirdPartyDependencies(t *testing.T) {
+	t.Parallel()
+
+	fsys := outputfs.NewMemory()
+	require.NoError(t, fsys.MkdirAll("docs", dirPerm))
+	require.NoError(t, writeReadme(newSite(fsys, config.Output{Dir: "docs"}), templateData{
+		Title: "Test",
+		ThirdPartyDependencies: []thirdPartyDependencyView{
+			{Name: "Stripe", Technology: "HTTP", Vendor: "Stripe, Inc.", Compliance: "DPA signed",
+				Users: []string{"Notifications", "Payments"}},
+			{Name: "Twilio", Users: []string{"Notifications"}},
+		},
+	}))
+
+	readme, err := fsys.ReadFile("docs/README.md")
+	require.NoError(t, err)
+	assert.Contains(t, string(readme), "- [Third-Party Dependencies](#third-party-dependencies)")
+	assert.Contains(t, string(readme), "| Stripe | HTTP | Stripe, Inc. | — | DPA signed | Notifications, Payments |")
+	assert.Contains(t, string(readme), "| Twilio | — | — | — | — | Notifications |")
+}
+
+func TestWriteReadme_ThirdPartyDependencyStatus(t *testing.T) {
+	t.Parallel()
+
+	fsys := outputfs.NewMemory()
+	require.NoError(t, fsys.MkdirAll("docs", dirPerm))
+	require.NoError(t, writeReadme(newSite(fsys, config.Output{Dir: "docs"}), templateData{
+		Title: "Test",
+		ThirdPartyDependencies: []thirdPartyDependencyView{
+			{Name: "Stripe", StatusPage: "https://status.stripe.com", Users: []string{"Payments"}},
+			{Name: "Twilio", StatusPage: "https://status.twilio.com", Status: "All Systems Operational",
+				Users: []string{"Notifications"}},
+			{Name: "Segment", Users: []string{"Analytics"}},
+		},
+		ThirdPartyStatusPages: true,
+	}))
+
+	readme, err := fsys.ReadFile("docs/README.md")
+	require.NoError(t, err)
+	assert.Contains(t, string(readme), "| Dependency | Technology | Vendor | License | Compliance | Status | Used by |\n"+
+		"|------------|------------|--------|---------|------------|--------|---------|\n")
+	assert.Contains(t, string(readme),
+		"| Stripe | — | — | — | — | [Status page](https://status.stripe.com) | Payments |")
+	assert.Contains(t, string(readme),
+		"| Twilio | — | — | — | — | [All Systems Operational](https://status.twilio.com) | Notifications |")
+	assert.Contains(t, string(readme), "| Segment | — | — | — | — | — | Analytics |")
+}
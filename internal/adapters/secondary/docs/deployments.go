@@ -0,0 +1,144 @@
+package docs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/holydocs/holydocs/pkg/outputfs"
+)
+
+// probesFileName holds the results of the last holydocs probe next to the generated documentation.
+const probesFileName = "probes.json"
+
+// probedAtLayout formats the time deployments were probed at, always in UTC.
+const probedAtLayout = "2006-01-02 15:04 UTC"
+
+// deploymentView is a row of the Deployments table of a service.
+type deploymentView struct {
+	Environment string
+	Health      deploymentEndpointView
+	Readiness   deploymentEndpointView
+}
+
+// deploymentEndpointView is a health endpoint of a deployment with the result of its last probe, if any.
+type deploymentEndpointView struct {
+	URL    string
+	Result string
+}
+
+// buildDeploymentViews lists the environments the service is deployed to.
+func buildDeploymentViews(service domain.Service) []deploymentView {
+	if len(service.Info.Deployments) == 0 {
+		return nil
+	}
+
+	views := make([]deploymentView, 0, len(service.Info.Deployments))
+	for _, deployment := range service.Info.Deployments {
+		views = append(views, deploymentView{
+			Environment: deployment.Environment,
+			Health:      deploymentEndpointView{URL: deployment.Health},
+			Readiness:   deploymentEndpointView{URL: deployment.Readiness},
+		})
+	}
+
+	return views
+}
+
+// probeResultText describes the result of a probe, e.g. "✅ 200 (45 ms)".
+func probeResultText(result domain.ProbeResult) string {
+	switch {
+	case result.OK():
+		return fmt.Sprintf("✅ %d (%d ms)", result.StatusCode, result.LatencyMS)
+	case result.Reachable:
+		return fmt.Sprintf("⚠️ %d (%d ms)", result.StatusCode, result.LatencyMS)
+	default:
+		return "❌ unreachable"
+	}
+}
+
+// applyProbeReport attaches the results of the last probe to the endpoints of deployments. Results of
+// endpoints declared differently since are left out, they don't tell anything about the current ones.
+func applyProbeReport(data templateData, report *domain.ProbeReport) templateData {
+	if report == nil || len(report.Results) == 0 {
+		return data
+	}
+
+	type endpointKey struct {
+		service, environment string
+		kind                 domain.ProbeKind
+		url                  string
+	}
+
+	results := make(map[endpointKey]domain.ProbeResult, len(report.Results))
+	for _, result := range report.Results {
+		results[endpointKey{result.Service, result.Environment, result.Kind, result.URL}] = result
+	}
+
+	probedAt := report.ProbedAt.UTC().Format(probedAtLayout)
+
+	for i := range data.Systems {
+		for j := range data.Systems[i].Services {
+			service := &data.Systems[i].Services[j]
+
+			for k := range service.Deployments {
+				deployment := &service.Deployments[k]
+
+				for kind, endpoint := range map[domain.ProbeKind]*deploymentEndpointView{
+					domain.ProbeKindHealth:    &deployment.Health,
+					domain.ProbeKindReadiness: &deployment.Readiness,
+				} {
+					result, ok := results[endpointKey{service.Name, deployment.Environment, kind, endpoint.URL}]
+					if !ok || endpoint.URL == "" {
+						continue
+					}
+
+					endpoint.Result = probeResultText(result)
+					service.ProbedAt = probedAt
+				}
+			}
+		}
+	}
+
+	return data
+}
+
+// WriteProbeReport writes the results of probing deployments into the output directory, the next
+// documentation run shows them.
+func (g *Generator) WriteProbeReport(outputDir string, report domain.ProbeReport) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling probe report: %w", err)
+	}
+
+	if err := g.fs.MkdirAll(outputDir, dirPerm); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	if err := g.fs.WriteFile(filepath.Join(outputDir, probesFileName), data, filePerm); err != nil {
+		return fmt.Errorf("error writing probe report: %w", err)
+	}
+
+	return nil
+}
+
+// readProbeReport reads the results of the last probe, nil when deployments weren't probed.
+func readProbeReport(fsys outputfs.FS, outputDir string) (*domain.ProbeReport, error) {
+	data, err := fsys.ReadFile(filepath.Join(outputDir, probesFileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading probe report: %w", err)
+	}
+
+	var report domain.ProbeReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("error parsing probe report: %w", err)
+	}
+
+	return &report, nil
+}
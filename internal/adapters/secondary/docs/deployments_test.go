@@ -0,0 +1,104 @@
+package docs
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/holydocs/holydocs/pkg/outputfs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyProbeReport(t *testing.T) {
+	t.Parallel()
+
+	service := domain.Service{Info: domain.ServiceInfo{Name: "Orders", Deployments: []domain.Deployment{
+		{Environment: "staging", Health: "https://orders.staging.example.com/healthz"},
+		{Environment: "production", Health: "https://orders.example.com/healthz",
+			Readiness: "https://orders.example.com/readyz"},
+	}}}
+	data := templateData{Systems: []systemView{{Services: []serviceView{
+		{Name: "Orders", Deployments: buildDeploymentViews(service)},
+		{Name: "Payments"},
+	}}}}
+
+	report := &domain.ProbeReport{
+		ProbedAt: time.Date(2024, 5, 2, 10, 30, 0, 0, time.FixedZone("CEST", 2*60*60)),
+		Results: []domain.ProbeResult{
+			{Service: "Orders", Environment: "production", Kind: domain.ProbeKindHealth,
+				URL: "https://orders.example.com/healthz", Reachable: true, StatusCode: http.StatusOK, LatencyMS: 45},
+			{Service: "Orders", Environment: "production", Kind: domain.ProbeKindReadiness,
+				URL: "https://orders.example.com/readyz", Reachable: true, StatusCode: http.StatusServiceUnavailable,
+				LatencyMS: 12},
+			{Service: "Orders", Environment: "staging", Kind: domain.ProbeKindHealth,
+				URL: "https://orders.old.example.com/healthz", Error: "no such host"},
+		},
+	}
+
+	data = applyProbeReport(data, report)
+	orders := data.Systems[0].Services[0]
+	assert.Equal(t, "2024-05-02 08:30 UTC", orders.ProbedAt)
+	assert.Equal(t, []deploymentView{
+		{
+			Environment: "staging",
+			Health:      deploymentEndpointView{URL: "https://orders.staging.example.com/healthz"},
+		},
+		{
+			Environment: "production",
+			Health:      deploymentEndpointView{URL: "https://orders.example.com/healthz", Result: "✅ 200 (45 ms)"},
+			Readiness:   deploymentEndpointView{URL: "https://orders.example.com/readyz", Result: "⚠️ 503 (12 ms)"},
+		},
+	}, orders.Deployments, "results of endpoints declared differently since are left out")
+	assert.Empty(t, data.Systems[0].Services[1].ProbedAt)
+
+	assert.Equal(t, "❌ unreachable", probeResultText(domain.ProbeResult{Error: "no such host"}))
+}
+
+func TestGenerator_WriteProbeReport(t *testing.T) {
+	t.Parallel()
+
+	fsys := outputfs.NewMemory()
+	g := &Generator{fs: fsys}
+
+	report, err := readProbeReport(fsys, "docs")
+	require.NoError(t, err)
+	assert.Nil(t, report)
+
+	probed := domain.ProbeReport{
+		ProbedAt: time.Date(2024, 5, 2, 8, 30, 0, 0, time.UTC),
+		Results: []domain.ProbeResult{{Service: "Orders", Environment: "production", Kind: domain.ProbeKindHealth,
+			URL: "https://orders.example.com/healthz", Reachable: true, StatusCode: http.StatusOK}},
+	}
+	require.NoError(t, g.WriteProbeReport("docs", probed))
+
+	report, err = readProbeReport(fsys, "docs")
+	require.NoError(t, err)
+	assert.Equal(t, &probed, report)
+}
+
+func TestWriteServicePage_Deployments(t *testing.T) {
+	t.Parallel()
+
+	fsys := outputfs.NewMemory()
+	require.NoError(t, fsys.MkdirAll("docs/services", dirPerm))
+	require.NoError(t, writeServicePage(newSite(fsys, config.Output{Dir: "docs"}), "docs/services", serviceView{
+		Name:     "Orders",
+		FileName: "orders",
+		Deployments: []deploymentView{{
+			Environment: "production",
+			Health:      deploymentEndpointView{URL: "https://orders.example.com/healthz", Result: "✅ 200 (45 ms)"},
+		}},
+		ProbedAt: "2024-05-02 08:30 UTC",
+	}, nil))
+
+	page, err := fsys.ReadFile("docs/services/orders.md")
+	require.NoError(t, err)
+	assert.Contains(t, string(page), "## Deployments\n\n| Environment | Health | Readiness |\n"+
+		"|-------------|--------|-----------|\n"+
+		"| production | [https://orders.example.com/healthz](https://orders.example.com/healthz) ✅ 200 (45 ms) | — |\n\n"+
+		"_Reachability as probed on 2024-05-02 08:30 UTC by `holydocs probe`, a point-in-time check rather than "+
+		"monitoring._\n")
+}
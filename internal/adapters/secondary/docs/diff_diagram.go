@@ -0,0 +1,72 @@
+package docs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	d2target "github.com/holydocs/holydocs/internal/adapters/secondary/target/d2"
+	"github.com/holydocs/holydocs/internal/core/domain"
+)
+
+// SchemaSnapshot returns the schema recorded in a domain.json file, or in the domain.json of a directory
+// of generated documentation.
+func (g *Generator) SchemaSnapshot(path string) (domain.Schema, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return domain.Schema{}, fmt.Errorf("reading schema snapshot: %w", err)
+	}
+
+	if info.IsDir() {
+		return g.DocumentedSchema(path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return domain.Schema{}, fmt.Errorf("reading schema snapshot: %w", err)
+	}
+
+	metadata, err := decodeMetadata(data)
+	if err != nil {
+		return domain.Schema{}, fmt.Errorf("decoding schema snapshot %s: %w", path, err)
+	}
+
+	return metadata.Schema, nil
+}
+
+// GenerateDiffDiagram renders the overview diagram of two schemas with the services and relationships added,
+// removed and changed between them colored.
+func (g *Generator) GenerateDiffDiagram(
+	ctx context.Context,
+	before, after domain.Schema,
+	format domain.DiagramFormat,
+) ([]byte, error) {
+	if format != domain.DiagramFormatSVG && format != domain.DiagramFormatD2 {
+		return nil, fmt.Errorf("%w: diagram format %q", domain.ErrUnsupportedValue, format)
+	}
+
+	d2Target, ok := g.target.(*d2target.Target)
+	if !ok {
+		return nil, errors.New("target is not a D2 target")
+	}
+
+	before.Sort()
+	after.Sort()
+
+	script, err := d2Target.GenerateOverviewDiffScript(before, after, g.config.Vocabulary.Term(g.config.Output.GlobalName))
+	if err != nil {
+		return nil, fmt.Errorf("generate overview diff D2 script: %w", err)
+	}
+
+	if format == domain.DiagramFormatD2 {
+		return script, nil
+	}
+
+	diagram, err := d2Target.RenderSchema(ctx, domain.FormattedSchema{Type: "d2", Data: script})
+	if err != nil {
+		return nil, fmt.Errorf("render overview diff diagram: %w", err)
+	}
+
+	return diagram, nil
+}
@@ -0,0 +1,121 @@
+package docs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/holydocs/holydocs/pkg/outputfs"
+)
+
+// hashingFS records the content hashes of the files written below dir, domain.json aside, so the next run
+// can tell generated files edited by hand since.
+type hashingFS struct {
+	outputfs.FS
+
+	dir    string
+	hashes map[string]string
+}
+
+func newHashingFS(fsys outputfs.FS, dir string) *hashingFS {
+	return &hashingFS{FS: fsys, dir: dir, hashes: make(map[string]string)}
+}
+
+func (h *hashingFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	if err := h.FS.WriteFile(name, data, perm); err != nil {
+		return err
+	}
+
+	if rel, ok := h.generated(name); ok {
+		h.hashes[rel] = contentHash(data)
+	}
+
+	return nil
+}
+
+// generated returns the slash-separated path of a generated file relative to the output directory, false for
+// domain.json and files outside of the output directory.
+func (h *hashingFS) generated(name string) (string, bool) {
+	rel, err := filepath.Rel(h.dir, name)
+	if err != nil || rel == "domain.json" || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+
+	return filepath.ToSlash(rel), true
+}
+
+// contentHash returns the SHA-256 hash of a generated file, leaving out its kept blocks, which are meant to
+// be edited by hand.
+func contentHash(data []byte) string {
+	content := string(data)
+	if strings.Contains(content, keepStartPrefix) {
+		content = withoutKept(content)
+	}
+
+	sum := sha256.Sum256([]byte(content))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// withoutKept removes the kept blocks of a page, along with the blank lines inserted in front of them. A block
+// missing its end marker runs to the end of the page.
+func withoutKept(content string) string {
+	var (
+		lines []string
+		kept  bool
+	)
+
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case !kept && strings.HasPrefix(trimmed, keepStartPrefix):
+			kept = true
+
+			for len(lines) > 0 && lines[len(lines)-1] == "" {
+				lines = lines[:len(lines)-1]
+			}
+		case kept && trimmed == keepEnd:
+			kept = false
+		case !kept:
+			lines = append(lines, line)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// editedFileWarnings reports the files of the previous run in dir whose content no longer has the hash
+// recorded for them, as their edits are overwritten by the run. Files removed since aren't reported.
+func editedFileWarnings(fsys outputfs.FS, dir string, previous *Metadata) ([]string, error) {
+	if previous == nil {
+		return nil, nil
+	}
+
+	var warnings []string
+
+	for _, name := range slices.Sorted(maps.Keys(previous.Files)) {
+		data, err := fsys.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("error reading generated file %s: %w", name, err)
+		}
+
+		if contentHash(data) != previous.Files[name] {
+			warnings = append(warnings, fmt.Sprintf("generated file %s was edited by hand since the last run "+
+				"and is overwritten, move the edits between %s and %s markers to keep them",
+				name, keepStartPrefix+" -->", keepEnd))
+		}
+	}
+
+	return warnings, nil
+}
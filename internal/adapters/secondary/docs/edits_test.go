@@ -0,0 +1,131 @@
+package docs
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	d2target "github.com/holydocs/holydocs/internal/adapters/secondary/target/d2"
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/holydocs/holydocs/pkg/outputfs"
+	mf "github.com/holydocs/messageflow/pkg/messageflow"
+	do "github.com/samber/do/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashingFS(t *testing.T) {
+	t.Parallel()
+
+	fsys := newHashingFS(outputfs.NewMemory(), "docs")
+
+	for _, name := range []string{
+		filepath.Join("docs", "README.md"),
+		filepath.Join("docs", "diagrams", "overview.svg"),
+		filepath.Join("docs", "domain.json"),
+		filepath.Join("site", "README.md"),
+	} {
+		require.NoError(t, fsys.MkdirAll(filepath.Dir(name), dirPerm))
+		require.NoError(t, fsys.WriteFile(name, []byte("# Docs\n"), filePerm))
+	}
+
+	assert.Equal(t, map[string]string{
+		"README.md":             contentHash([]byte("# Docs\n")),
+		"diagrams/overview.svg": contentHash([]byte("# Docs\n")),
+	}, fsys.hashes)
+}
+
+func TestContentHash_IgnoresKeptBlocks(t *testing.T) {
+	t.Parallel()
+
+	generated := "# Docs\n\n## Orders Service\n\nTakes orders.\n\n## Billing Service\n\nBills.\n"
+	kept := insertKept(generated, keptBlocks("# Docs\n\n## Orders Service\n\n<!-- holydocs:keep:start -->\n"+
+		"See the wiki.\n<!-- holydocs:keep:end -->\n\n## Billing Service\n\n<!-- holydocs:keep:start -->\n"+
+		"Being replaced.\n<!-- holydocs:keep:end -->\n"))
+	edited := "# Docs\n\n## Orders Service\n\nTakes and tracks orders.\n\n## Billing Service\n\nBills.\n"
+
+	require.Contains(t, kept, "Being replaced.")
+	assert.Equal(t, contentHash([]byte(generated)), contentHash([]byte(kept)))
+	assert.NotEqual(t, contentHash([]byte(generated)), contentHash([]byte(edited)))
+}
+
+func TestEditedFileWarnings(t *testing.T) {
+	t.Parallel()
+
+	fsys := outputfs.NewMemory()
+	require.NoError(t, fsys.MkdirAll("docs", dirPerm))
+	require.NoError(t, fsys.WriteFile(filepath.Join("docs", "README.md"), []byte("# Docs\nEdited.\n"), filePerm))
+	require.NoError(t, fsys.WriteFile(filepath.Join("docs", "orders.md"), []byte("# Orders\n"), filePerm))
+
+	warnings, err := editedFileWarnings(fsys, "docs", nil)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+
+	warnings, err = editedFileWarnings(fsys, "docs", &Metadata{Files: map[string]string{
+		"README.md":  contentHash([]byte("# Docs\n")),
+		"orders.md":  contentHash([]byte("# Orders\n")),
+		"removed.md": contentHash([]byte("# Removed\n")),
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"generated file README.md was edited by hand since the last run and is overwritten, move the edits " +
+			"between <!-- holydocs:keep:start --> and <!-- holydocs:keep:end --> markers to keep them",
+	}, warnings)
+}
+
+// newMemoryGenerator returns a generator writing the documentation of the test configuration to docs in memory.
+func newMemoryGenerator(t *testing.T) (*Generator, *outputfs.Memory) {
+	t.Helper()
+
+	configInjector := do.New()
+	do.ProvideValue(configInjector, config.ConfigFilePath(filepath.Join("testdata", "holydocs.test.yaml")))
+	cfg, err := config.LoadConfig(configInjector)
+	require.NoError(t, err)
+
+	cfg.Output.Dir = "docs"
+
+	target, err := d2target.NewTarget(config.D2Config{Pad: 64, Font: "SourceSansPro", Layout: "elk"})
+	require.NoError(t, err)
+
+	fsys := outputfs.NewMemory()
+
+	injector := do.New()
+	do.ProvideValue[domain.Target](injector, target)
+	do.ProvideValue(injector, cfg)
+	do.ProvideValue[outputfs.FS](injector, fsys)
+	generator, err := NewGenerator(injector)
+	require.NoError(t, err)
+
+	return generator, fsys
+}
+
+func TestGenerate_UnrecordedRunKeepsFileHashes(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	generator, _ := newMemoryGenerator(t)
+
+	orders := domain.Schema{Services: []domain.Service{{Info: domain.ServiceInfo{Name: "Orders Service"}}}}
+	billing := domain.Schema{Services: []domain.Service{
+		{Info: domain.ServiceInfo{Name: "Orders Service"}},
+		{Info: domain.ServiceInfo{Name: "Billing Service"}},
+	}}
+
+	for _, run := range []struct {
+		schema domain.Schema
+		opts   domain.GenerateOptions
+	}{
+		{schema: orders},
+		// Source errors keep the schema from being recorded while the pages are rewritten.
+		{schema: billing, opts: domain.GenerateOptions{SourceErrors: []string{"billing.yaml: invalid"}}},
+		{schema: billing},
+	} {
+		reply, err := generator.Generate(ctx, run.schema, mf.Schema{}, nil, run.opts)
+		require.NoError(t, err)
+
+		for _, warning := range reply.Warnings {
+			assert.NotContains(t, warning, "edited by hand")
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package docs
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+)
+
+type endpointView struct {
+	Method  string
+	Path    string
+	Summary string
+	Auth    []string
+	// Anchor is prefixed with the anchor of the service, paths repeat across services.
+	Anchor string
+}
+
+func buildEndpointViews(serviceAnchor string, endpoints []domain.Endpoint) []endpointView {
+	if len(endpoints) == 0 {
+		return nil
+	}
+
+	views := make([]endpointView, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		views = append(views, endpointView{
+			Method:  endpoint.Method,
+			Path:    endpoint.Path,
+			Summary: endpoint.Summary,
+			Auth:    endpoint.Auth,
+			Anchor:  serviceAnchor + "-" + endpointAnchor(endpoint),
+		})
+	}
+
+	return views
+}
+
+// endpointAnchor slugs the method and the segments of the path, slugging the path as a whole would drop
+// the separators and give /orders/{id} and /ordersid the same anchor.
+func endpointAnchor(endpoint domain.Endpoint) string {
+	words := strings.FieldsFunc(endpoint.Path, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	return sanitizeAnchor(endpoint.Method + " " + strings.Join(words, " "))
+}
+
+// AuthList returns the security schemes protecting the endpoint, or "public" when there are none.
+func (v endpointView) AuthList() string {
+	if len(v.Auth) == 0 {
+		return "public"
+	}
+
+	return strings.Join(v.Auth, ", ")
+}
+
+// EndpointCount returns the number of endpoints of the service for its summary.
+func (v serviceView) EndpointCount() string {
+	return countNoun(len(v.Endpoints), "endpoint", "endpoints")
+}
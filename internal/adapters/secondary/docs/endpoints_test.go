@@ -0,0 +1,27 @@
+package docs
+
+import (
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildEndpointViews(t *testing.T) {
+	t.Parallel()
+
+	views := buildEndpointViews("campaign-service", []domain.Endpoint{
+		{Method: "GET", Path: "/campaigns", Summary: "List campaigns"},
+		{Method: "DELETE", Path: "/campaigns/{id}", Auth: []string{"bearerAuth", "oauth2 (campaigns:write)"}},
+	})
+
+	require.Len(t, views, 2)
+	assert.Equal(t, "campaign-service-get-campaigns", views[0].Anchor)
+	assert.Equal(t, "public", views[0].AuthList())
+	assert.Equal(t, "campaign-service-delete-campaigns-id", views[1].Anchor)
+	assert.Equal(t, "bearerAuth, oauth2 (campaigns:write)", views[1].AuthList())
+
+	assert.Equal(t, "2 endpoints", serviceView{Endpoints: views}.EndpointCount())
+	assert.Equal(t, "1 endpoint", serviceView{Endpoints: views[:1]}.EndpointCount())
+}
@@ -0,0 +1,240 @@
+package docs
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+)
+
+// eventCatalogFileName is the event catalog page in multi-page documentation.
+const eventCatalogFileName = "events.md"
+
+// eventAnchorPrefix keeps event anchors apart from service and channel anchors.
+const eventAnchorPrefix = "event-"
+
+type eventCatalogView struct {
+	Events []eventView
+}
+
+// HasData reports whether any message type is documented.
+func (v eventCatalogView) HasData() bool {
+	return len(v.Events) > 0
+}
+
+type eventView struct {
+	Name      string
+	Anchor    string
+	Payload   string
+	Producers []eventLink
+	Consumers []eventLink
+	Channels  []eventLink
+	Examples  []string
+	// SynthesizedExample is generated from the payload when no example is declared.
+	SynthesizedExample string
+
+	payloadFromProducer bool
+}
+
+// eventLink is a named reference rendered as a markdown link when Link is set.
+type eventLink struct {
+	Name string
+	Link string
+}
+
+// buildEventCatalog collects every message type with the services producing and consuming it and the channels
+// carrying it. A request is produced by its sender, a reply by the service receiving the request. The payload
+// declared by a producer takes precedence over the one declared by a consumer.
+func buildEventCatalog(schema domain.Schema) eventCatalogView {
+	events := make(map[string]*eventView)
+
+	add := func(service string, channel string, msg domain.Message, producer bool) {
+		if msg.Name == "" {
+			return
+		}
+
+		event, ok := events[msg.Name]
+		if !ok {
+			event = &eventView{Name: msg.Name, Anchor: eventAnchorPrefix + sanitizeAnchor(msg.Name)}
+			events[msg.Name] = event
+		}
+
+		if producer {
+			event.Producers = appendEventLink(event.Producers, service)
+		} else {
+			event.Consumers = appendEventLink(event.Consumers, service)
+		}
+
+		event.Channels = appendEventLink(event.Channels, channel)
+
+		payload := strings.TrimSpace(msg.Payload)
+		if payload != "" && (event.Payload == "" || producer && !event.payloadFromProducer) {
+			event.Payload = payload
+			event.payloadFromProducer = producer
+		}
+
+		for _, example := range msg.Examples {
+			if !slices.Contains(event.Examples, example) {
+				event.Examples = append(event.Examples, example)
+			}
+		}
+	}
+
+	for _, service := range schema.Services {
+		for _, op := range service.Operation {
+			add(service.Info.Name, op.Channel.Name, op.Channel.Message, op.Action == domain.ActionSend)
+
+			if op.Reply != nil {
+				add(service.Info.Name, op.Reply.Name, op.Reply.Message, op.Action == domain.ActionReceive)
+			}
+		}
+	}
+
+	view := eventCatalogView{}
+
+	for _, event := range events {
+		sortEventLinks(event.Producers)
+		sortEventLinks(event.Consumers)
+		sortEventLinks(event.Channels)
+		view.Events = append(view.Events, *event)
+	}
+
+	sort.Slice(view.Events, func(i, j int) bool {
+		return view.Events[i].Name < view.Events[j].Name
+	})
+
+	return view
+}
+
+func appendEventLink(links []eventLink, name string) []eventLink {
+	for _, link := range links {
+		if link.Name == name {
+			return links
+		}
+	}
+
+	return append(links, eventLink{Name: name})
+}
+
+func sortEventLinks(links []eventLink) {
+	sort.Slice(links, func(i, j int) bool {
+		return links[i].Name < links[j].Name
+	})
+}
+
+// linkEventCatalog cross-links the event catalog with the service and channel sections. In multi-page mode
+// links point to the pages, so it expects the file paths set by enrichTemplateDataForMultiPage.
+func linkEventCatalog(data templateData, multiPage bool) templateData {
+	if !data.EventCatalog.HasData() {
+		return data
+	}
+
+	serviceLinks := make(map[string]string)
+	for _, system := range data.Systems {
+		for _, service := range system.Services {
+			serviceLinks[service.Name] = sectionLink(service.Anchor, service.FilePath, multiPage)
+		}
+	}
+
+	channelLinks := make(map[string]string)
+	if data.MessageFlow.HasData {
+		for _, channel := range data.MessageFlow.Channels {
+			channelLinks[channel.Name] = sectionLink(channel.Anchor, channel.FilePath, multiPage)
+		}
+	}
+
+	// Event links from channel and service sections, relative to their pages in multi-page mode.
+	var fromChannel, fromService string
+	if multiPage {
+		data.EventCatalogPath = eventCatalogFileName
+		fromChannel = "../../" + eventCatalogFileName
+		fromService = "../" + eventCatalogFileName
+	}
+
+	eventAnchors := make(map[string]string)
+	produced := make(map[string][]eventLink)
+	consumed := make(map[string][]eventLink)
+
+	for i := range data.EventCatalog.Events {
+		event := &data.EventCatalog.Events[i]
+		eventAnchors[event.Name] = event.Anchor
+
+		event.Producers = resolveEventLinks(event.Producers, serviceLinks)
+		event.Consumers = resolveEventLinks(event.Consumers, serviceLinks)
+		event.Channels = resolveEventLinks(event.Channels, channelLinks)
+
+		link := eventLink{Name: event.Name, Link: fromService + "#" + event.Anchor}
+		for _, producer := range event.Producers {
+			produced[producer.Name] = append(produced[producer.Name], link)
+		}
+		for _, consumer := range event.Consumers {
+			consumed[consumer.Name] = append(consumed[consumer.Name], link)
+		}
+	}
+
+	for i := range data.Systems {
+		for j := range data.Systems[i].Services {
+			service := &data.Systems[i].Services[j]
+			service.ProducedEvents = produced[service.Name]
+			service.ConsumedEvents = consumed[service.Name]
+		}
+	}
+
+	for i := range data.MessageFlow.Channels {
+		messages := data.MessageFlow.Channels[i].Messages
+		for j := range messages {
+			if anchor, ok := eventAnchors[messages[j].Name]; ok {
+				messages[j].EventLink = fromChannel + "#" + anchor
+			}
+		}
+	}
+
+	return data
+}
+
+func sectionLink(anchor, filePath string, multiPage bool) string {
+	if multiPage {
+		return filePath
+	}
+
+	return "#" + anchor
+}
+
+func resolveEventLinks(links []eventLink, targets map[string]string) []eventLink {
+	resolved := make([]eventLink, len(links))
+	for i, link := range links {
+		resolved[i] = eventLink{Name: link.Name, Link: targets[link.Name]}
+	}
+
+	return resolved
+}
+
+// eventCatalogPageData represents data for the event catalog page.
+type eventCatalogPageData struct {
+	EventCatalog eventCatalogView
+}
+
+// writeEventCatalogPage generates the event catalog page.
+func writeEventCatalogPage(pages site, outputDir string, data templateData) error {
+	tmpl, err := template.New("events.tmpl").Funcs(pages.templateFuncs()).
+		ParseFS(multiPageTemplateFS, "templates/md_multi_page/events.tmpl")
+	if err != nil {
+		return fmt.Errorf("parse event catalog template: %w", err)
+	}
+
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, eventCatalogPageData{EventCatalog: data.EventCatalog}); err != nil {
+		return fmt.Errorf("execute event catalog template: %w", err)
+	}
+
+	eventsPath := filepath.Join(outputDir, eventCatalogFileName)
+	if err := pages.writePage(eventsPath, pageMeta{Title: "Event Catalog"}, buf.String()); err != nil {
+		return fmt.Errorf("write event catalog page: %w", err)
+	}
+
+	return nil
+}
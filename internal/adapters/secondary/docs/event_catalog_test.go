@@ -0,0 +1,143 @@
+package docs
+
+import (
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func eventCatalogSchema() domain.Schema {
+	return domain.Schema{
+		Services: []domain.Service{
+			{
+				Info: domain.ServiceInfo{Name: "Order Service"},
+				Operation: []domain.Operation{
+					{
+						Action: domain.ActionSend,
+						Channel: domain.Channel{
+							Name: "orders.created",
+							Message: domain.Message{
+								Name:     "OrderCreated",
+								Payload:  `{"id": "string[uuid]"}`,
+								Examples: []string{`{"id": "1"}`},
+							},
+						},
+					},
+					{
+						Action: domain.ActionSend,
+						Channel: domain.Channel{
+							Name:    "stock.reserve",
+							Message: domain.Message{Name: "ReserveStock"},
+						},
+						Reply: &domain.Channel{
+							Name:    "stock.reserve",
+							Message: domain.Message{Name: "StockReserved"},
+						},
+					},
+				},
+			},
+			{
+				Info: domain.ServiceInfo{Name: "Billing Service"},
+				Operation: []domain.Operation{
+					{
+						Action: domain.ActionReceive,
+						Channel: domain.Channel{
+							Name:    "orders.created",
+							Message: domain.Message{Name: "OrderCreated", Payload: `{"id": "string"}`},
+						},
+					},
+				},
+			},
+			{
+				Info: domain.ServiceInfo{Name: "Stock Service"},
+				Operation: []domain.Operation{
+					{
+						Action: domain.ActionReceive,
+						Channel: domain.Channel{
+							Name:    "stock.reserve",
+							Message: domain.Message{Name: "ReserveStock"},
+						},
+						Reply: &domain.Channel{
+							Name:    "stock.reserve",
+							Message: domain.Message{Name: "StockReserved"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestBuildEventCatalog(t *testing.T) {
+	t.Parallel()
+
+	catalog := buildEventCatalog(eventCatalogSchema())
+	require.True(t, catalog.HasData())
+	require.Len(t, catalog.Events, 3)
+
+	orderCreated := catalog.Events[0]
+	assert.Equal(t, "OrderCreated", orderCreated.Name)
+	assert.Equal(t, "event-ordercreated", orderCreated.Anchor)
+	assert.Equal(t, `{"id": "string[uuid]"}`, orderCreated.Payload, "producer payload takes precedence")
+	assert.Equal(t, []eventLink{{Name: "Order Service"}}, orderCreated.Producers)
+	assert.Equal(t, []eventLink{{Name: "Billing Service"}}, orderCreated.Consumers)
+	assert.Equal(t, []eventLink{{Name: "orders.created"}}, orderCreated.Channels)
+	assert.Equal(t, []string{`{"id": "1"}`}, orderCreated.Examples)
+
+	reserveStock := catalog.Events[1]
+	assert.Equal(t, "ReserveStock", reserveStock.Name)
+	assert.Equal(t, []eventLink{{Name: "Order Service"}}, reserveStock.Producers)
+	assert.Equal(t, []eventLink{{Name: "Stock Service"}}, reserveStock.Consumers)
+
+	stockReserved := catalog.Events[2]
+	assert.Equal(t, "StockReserved", stockReserved.Name)
+	assert.Equal(t, []eventLink{{Name: "Stock Service"}}, stockReserved.Producers)
+	assert.Equal(t, []eventLink{{Name: "Order Service"}}, stockReserved.Consumers)
+
+	assert.False(t, buildEventCatalog(domain.Schema{}).HasData())
+}
+
+func TestLinkEventCatalog(t *testing.T) {
+	t.Parallel()
+
+	newData := func() templateData {
+		return templateData{
+			Systems: []systemView{{
+				Name: "Shop",
+				Services: []serviceView{
+					{Name: "Order Service", Anchor: "order-service", FilePath: "services/order-service.md"},
+					{Name: "Billing Service", Anchor: "billing-service", FilePath: "services/billing-service.md"},
+				},
+			}},
+			MessageFlow: messageFlowView{
+				HasData: true,
+				Channels: []channelView{{
+					Name:     "orders.created",
+					Anchor:   "orderscreated",
+					FilePath: "messageflow/channels/orderscreated.md",
+					Messages: []channelMessage{{Name: "OrderCreated", Direction: "receive"}},
+				}},
+			},
+			EventCatalog: buildEventCatalog(eventCatalogSchema()),
+		}
+	}
+
+	single := linkEventCatalog(newData(), false)
+	assert.Empty(t, single.EventCatalogPath)
+	assert.Equal(t, []eventLink{{Name: "Order Service", Link: "#order-service"}}, single.EventCatalog.Events[0].Producers)
+	assert.Equal(t, []eventLink{{Name: "orders.created", Link: "#orderscreated"}}, single.EventCatalog.Events[0].Channels)
+	assert.Equal(t, []eventLink{{Name: "stock.reserve"}}, single.EventCatalog.Events[1].Channels,
+		"channels without a section are not linked")
+	assert.Equal(t, "#event-ordercreated", single.MessageFlow.Channels[0].Messages[0].EventLink)
+	assert.Equal(t, []eventLink{{Name: "OrderCreated", Link: "#event-ordercreated"}},
+		single.Systems[0].Services[1].ConsumedEvents)
+	assert.Len(t, single.Systems[0].Services[0].ProducedEvents, 2)
+
+	multi := linkEventCatalog(newData(), true)
+	assert.Equal(t, eventCatalogFileName, multi.EventCatalogPath)
+	assert.Equal(t, "services/order-service.md", multi.EventCatalog.Events[0].Producers[0].Link)
+	assert.Equal(t, "../../events.md#event-ordercreated", multi.MessageFlow.Channels[0].Messages[0].EventLink)
+	assert.Equal(t, "../events.md#event-ordercreated", multi.Systems[0].Services[1].ConsumedEvents[0].Link)
+}
@@ -0,0 +1,226 @@
+package docs
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/holydocs/holydocs/internal/config"
+)
+
+// Bounds of synthesized values.
+const (
+	maxExampleInteger   = 1000
+	maxExampleCents     = 100000
+	maxExampleArrayLen  = 2
+	maxExampleTimeShift = 365 * 24 * time.Hour
+	maxExampleHost      = 254
+	centsPerUnit        = 100
+)
+
+// UUID version 4 and RFC 4122 variant bits.
+const (
+	uuidVersionMask = 0x0f
+	uuidVersion4    = 0x40
+	uuidVariantMask = 0x3f
+	uuidVariant     = 0x80
+)
+
+//nolint:gochecknoglobals // Reference time of synthesized dates, fixed so examples are reproducible.
+var exampleEpoch = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+//nolint:gochecknoglobals // Words used for strings without a recognizable field name.
+var exampleWords = []string{"alpha", "bravo", "charlie", "delta", "echo", "foxtrot", "golf", "hotel"}
+
+//nolint:gochecknoglobals // Values for strings whose field name hints at their content, matched in order.
+var exampleFieldHints = []struct {
+	substring string
+	values    []string
+}{
+	{"email", []string{"jane.doe@example.com", "john.smith@example.com"}},
+	{"phone", []string{"+14155550123", "+442071838750"}},
+	{"url", []string{"https://example.com/resource"}},
+	{"country", []string{"US", "DE", "JP"}},
+	{"currency", []string{"USD", "EUR"}},
+	{"language", []string{"en", "de"}},
+	{"locale", []string{"en-US", "de-DE"}},
+	{"first_name", []string{"Jane", "John"}},
+	{"last_name", []string{"Doe", "Smith"}},
+	{"name", []string{"Jane Doe", "John Smith"}},
+	{"title", []string{"Weekly summary", "Welcome aboard"}},
+	{"description", []string{"Short description of the item"}},
+	{"message", []string{"Hello from the example generator"}},
+}
+
+// synthesizeExample generates an example payload from the payload shape of a message, as produced from its
+// JSON Schema: leaves are type strings such as "string[uuid]", "string[enum:a,b]" or "number[float]",
+// objects are nested maps and arrays hold the shape of their items. Values are drawn from a generator seeded
+// with the seed and the message name, so the same message always gets the same example.
+// It returns an empty string when the payload has no fields to fill.
+func synthesizeExample(payload, messageName string, seed int64) string {
+	var shape map[string]any
+	if err := json.Unmarshal([]byte(payload), &shape); err != nil || len(shape) == 0 {
+		return ""
+	}
+
+	hash := fnv.New64a()
+	hash.Write([]byte(messageName))
+
+	gen := exampleGenerator{rnd: rand.New(rand.NewPCG(uint64(seed), hash.Sum64()))}
+
+	data, err := json.MarshalIndent(gen.value("", shape), "", "  ")
+	if err != nil {
+		return ""
+	}
+
+	return string(data)
+}
+
+type exampleGenerator struct {
+	rnd *rand.Rand
+}
+
+func (g exampleGenerator) value(field string, shape any) any {
+	switch shape := shape.(type) {
+	case map[string]any:
+		object := make(map[string]any, len(shape))
+		// Fields are visited in a fixed order so the generator produces the same values on every run.
+		for _, key := range slices.Sorted(maps.Keys(shape)) {
+			object[key] = g.value(key, shape[key])
+		}
+
+		return object
+	case []any:
+		items := []any{}
+		if len(shape) == 0 {
+			return items
+		}
+
+		for range 1 + g.rnd.IntN(maxExampleArrayLen) {
+			items = append(items, g.value(field, shape[0]))
+		}
+
+		return items
+	case string:
+		return g.scalar(field, shape)
+	default:
+		return shape
+	}
+}
+
+// scalar generates a value for a type string, "type" or "type[format]".
+func (g exampleGenerator) scalar(field, typeString string) any {
+	kind, format, _ := strings.Cut(strings.TrimSuffix(typeString, "]"), "[")
+
+	if values, ok := strings.CutPrefix(format, "enum:"); ok {
+		return g.pick(strings.Split(values, ","))
+	}
+
+	switch kind {
+	case "integer":
+		return g.rnd.IntN(maxExampleInteger)
+	case "number":
+		return float64(g.rnd.IntN(maxExampleCents)) / centsPerUnit
+	case "boolean":
+		return g.rnd.IntN(2) == 1
+	case "object":
+		return map[string]any{}
+	case "null":
+		return nil
+	default:
+		return g.formattedString(field, format)
+	}
+}
+
+func (g exampleGenerator) formattedString(field, format string) string {
+	switch format {
+	case "uuid":
+		return g.uuid()
+	case "date-time":
+		return g.time().Format(time.RFC3339)
+	case "date":
+		return g.time().Format(time.DateOnly)
+	case "time":
+		return g.time().Format(time.TimeOnly)
+	case "email":
+		return g.pick(exampleFieldHints[0].values)
+	case "uri", "url":
+		return "https://example.com/" + g.pick(exampleWords)
+	case "hostname":
+		return g.pick(exampleWords) + ".example.com"
+	case "ipv4":
+		return fmt.Sprintf("192.0.2.%d", 1+g.rnd.IntN(maxExampleHost))
+	case "ipv6":
+		return fmt.Sprintf("2001:db8::%x", 1+g.rnd.IntN(maxExampleHost))
+	}
+
+	name := strings.ToLower(field)
+	for _, hint := range exampleFieldHints {
+		if strings.Contains(name, hint.substring) {
+			return g.pick(hint.values)
+		}
+	}
+
+	if name == "id" || strings.HasSuffix(name, "_id") {
+		return g.uuid()
+	}
+
+	return g.pick(exampleWords)
+}
+
+func (g exampleGenerator) uuid() string {
+	var b [16]byte
+	for i := range b {
+		b[i] = byte(g.rnd.Uint32())
+	}
+
+	b[6] = b[6]&uuidVersionMask | uuidVersion4
+	b[8] = b[8]&uuidVariantMask | uuidVariant
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+func (g exampleGenerator) time() time.Time {
+	return exampleEpoch.Add(time.Duration(g.rnd.Int64N(int64(maxExampleTimeShift/time.Second))) * time.Second)
+}
+
+func (g exampleGenerator) pick(values []string) string {
+	return values[g.rnd.IntN(len(values))]
+}
+
+// applySynthesizedExamples adds synthesized examples to the channel messages and catalog events
+// without declared examples, when enabled in the configuration.
+func applySynthesizedExamples(data templateData, cfg config.ExamplesDocumentation) templateData {
+	if !cfg.Synthesize {
+		return data
+	}
+
+	declared := make(map[string]bool)
+
+	for i := range data.EventCatalog.Events {
+		event := &data.EventCatalog.Events[i]
+		if len(event.Examples) > 0 {
+			declared[event.Name] = true
+
+			continue
+		}
+
+		event.SynthesizedExample = synthesizeExample(event.Payload, event.Name, cfg.Seed)
+	}
+
+	for i := range data.MessageFlow.Channels {
+		messages := data.MessageFlow.Channels[i].Messages
+		for j := range messages {
+			if !declared[messages[j].Name] {
+				messages[j].Example = synthesizeExample(messages[j].Payload, messages[j].Name, cfg.Seed)
+			}
+		}
+	}
+
+	return data
+}
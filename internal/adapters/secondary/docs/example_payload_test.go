@@ -0,0 +1,102 @@
+package docs
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const examplePayloadShape = `{
+  "event_id": "string[uuid]",
+  "event_type": "string[enum:sent,opened]",
+  "occurred_at": "string[date-time]",
+  "email": "string[email]",
+  "count": "integer",
+  "score": "number[float]",
+  "active": "boolean",
+  "tags": ["string"],
+  "context": {"user_id": "string", "extra": "object"}
+}`
+
+func TestSynthesizeExample(t *testing.T) {
+	t.Parallel()
+
+	example := synthesizeExample(examplePayloadShape, "EventMessage", 1)
+	require.NotEmpty(t, example)
+
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal([]byte(example), &payload))
+
+	assert.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, payload["event_id"])
+	assert.Contains(t, []any{"sent", "opened"}, payload["event_type"])
+	assert.Contains(t, payload["email"], "@example.com")
+	assert.IsType(t, float64(0), payload["count"])
+	assert.IsType(t, float64(0), payload["score"])
+	assert.IsType(t, true, payload["active"])
+	assert.NotEmpty(t, payload["tags"])
+
+	occurredAt, ok := payload["occurred_at"].(string)
+	require.True(t, ok)
+	_, err := time.Parse(time.RFC3339, occurredAt)
+	require.NoError(t, err)
+
+	nested, ok := payload["context"].(map[string]any)
+	require.True(t, ok)
+	assert.Regexp(t, `^[0-9a-f-]{36}$`, nested["user_id"])
+	assert.Equal(t, map[string]any{}, nested["extra"])
+}
+
+func TestSynthesizeExample_Deterministic(t *testing.T) {
+	t.Parallel()
+
+	first := synthesizeExample(examplePayloadShape, "EventMessage", 7)
+	assert.Equal(t, first, synthesizeExample(examplePayloadShape, "EventMessage", 7))
+	assert.NotEqual(t, first, synthesizeExample(examplePayloadShape, "EventMessage", 8))
+	assert.NotEqual(t, first, synthesizeExample(examplePayloadShape, "OtherMessage", 7))
+}
+
+func TestSynthesizeExample_NoFields(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, synthesizeExample("", "EventMessage", 1))
+	assert.Empty(t, synthesizeExample("{}", "EventMessage", 1))
+	assert.Empty(t, synthesizeExample("not json", "EventMessage", 1))
+}
+
+func TestApplySynthesizedExamples(t *testing.T) {
+	t.Parallel()
+
+	newData := func() templateData {
+		return templateData{
+			MessageFlow: messageFlowView{
+				HasData: true,
+				Channels: []channelView{{
+					Name: "orders",
+					Messages: []channelMessage{
+						{Name: "OrderCreated", Payload: `{"id": "string[uuid]"}`},
+						{Name: "OrderShipped", Payload: `{"id": "string[uuid]"}`},
+					},
+				}},
+			},
+			EventCatalog: eventCatalogView{Events: []eventView{
+				{Name: "OrderCreated", Payload: `{"id": "string[uuid]"}`, Examples: []string{`{"id": "1"}`}},
+				{Name: "OrderShipped", Payload: `{"id": "string[uuid]"}`},
+			}},
+		}
+	}
+
+	disabled := applySynthesizedExamples(newData(), config.ExamplesDocumentation{})
+	assert.Empty(t, disabled.MessageFlow.Channels[0].Messages[1].Example)
+	assert.Empty(t, disabled.EventCatalog.Events[1].SynthesizedExample)
+
+	data := applySynthesizedExamples(newData(), config.ExamplesDocumentation{Synthesize: true, Seed: 1})
+	messages := data.MessageFlow.Channels[0].Messages
+	assert.Empty(t, messages[0].Example, "declared examples are not replaced")
+	assert.NotEmpty(t, messages[1].Example)
+	assert.Empty(t, data.EventCatalog.Events[0].SynthesizedExample)
+	assert.Equal(t, messages[1].Example, data.EventCatalog.Events[1].SynthesizedExample)
+}
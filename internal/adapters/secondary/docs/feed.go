@@ -0,0 +1,233 @@
+package docs
+
+import (
+	"encoding/xml"
+	"fmt"
+	"html"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/holydocs/holydocs/pkg/outputfs"
+)
+
+// Feeds of the changelog written next to the pages.
+const (
+	changelogFeedFileName     = "changelog.atom"
+	changelogCalendarFileName = "changelog.ics"
+)
+
+// changelogEntryLayout formats the dates of changelog entries like the changelog section does.
+const changelogEntryLayout = "2006-01-02 15:04"
+
+// feedIDPrefix identifies the feed and its entries when the documentation has no published URL.
+const feedIDPrefix = "urn:holydocs:changelog"
+
+// icsLineLength is the longest line of an iCalendar in octets, longer lines are folded.
+const icsLineLength = 75
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Link    *atomLink   `xml:"link,omitempty"`
+	Author  atomAuthor  `xml:"author"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomEntry struct {
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Link    *atomLink   `xml:"link,omitempty"`
+	Content atomContent `xml:"content"`
+}
+
+type atomContent struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+// writeChangelogFeeds writes the changelog entries, newest first, as an Atom feed and the notable changes as an
+// iCalendar into the directory of the pages, when enabled, so stakeholders can follow the architecture in their
+// feed readers and calendars. Links point to baseURL, the URL the documentation is published at, if set.
+func writeChangelogFeeds(fsys outputfs.FS, dir, title, baseURL string, cfg config.ChangelogDocumentation,
+	changelogs []domain.Changelog) error {
+	if len(changelogs) == 0 || (!cfg.Feed && !cfg.Calendar) {
+		return nil
+	}
+
+	if err := fsys.MkdirAll(dir, dirPerm); err != nil {
+		return fmt.Errorf("create changelog feed directory: %w", err)
+	}
+
+	if cfg.Feed {
+		feed, err := changelogFeed(title, baseURL, changelogs)
+		if err != nil {
+			return err
+		}
+
+		if err := fsys.WriteFile(filepath.Join(dir, changelogFeedFileName), feed, filePerm); err != nil {
+			return fmt.Errorf("write changelog feed: %w", err)
+		}
+	}
+
+	if cfg.Calendar {
+		calendar := changelogCalendar(title, baseURL, changelogs)
+		if err := fsys.WriteFile(filepath.Join(dir, changelogCalendarFileName), calendar, filePerm); err != nil {
+			return fmt.Errorf("write changelog calendar: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// changelogFeed renders the changelog entries as an Atom feed, one feed entry per changelog entry.
+func changelogFeed(title, baseURL string, changelogs []domain.Changelog) ([]byte, error) {
+	feed := atomFeed{
+		Title:   title + " Changelog",
+		ID:      feedIDPrefix,
+		Updated: changelogs[0].Date.UTC().Format(time.RFC3339),
+		Author:  atomAuthor{Name: "HolyDOCs"},
+	}
+
+	var link *atomLink
+	if baseURL != "" {
+		link = &atomLink{Href: baseURL}
+		feed.ID, feed.Link = baseURL, link
+	}
+
+	for _, changelog := range changelogs {
+		var content strings.Builder
+
+		content.WriteString("<ul>")
+
+		for _, change := range changelog.Changes {
+			fmt.Fprintf(&content, "<li><strong>%s</strong> %s: %s</li>", html.EscapeString(string(change.Type)),
+				html.EscapeString(change.Category), html.EscapeString(change.Details))
+		}
+
+		content.WriteString("</ul>")
+
+		feed.Entries = append(feed.Entries, atomEntry{
+			Title:   changelogEntryTitle(len(changelog.Changes), "change", changelog.Date),
+			ID:      changelogEntryID(changelog.Date),
+			Updated: changelog.Date.UTC().Format(time.RFC3339),
+			Link:    link,
+			Content: atomContent{Type: "html", Body: content.String()},
+		})
+	}
+
+	data, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal changelog feed: %w", err)
+	}
+
+	return append([]byte(xml.Header), append(data, '\n')...), nil
+}
+
+// changelogCalendar renders the notable changes as an iCalendar, one all-day event per changelog entry with
+// notable changes.
+func changelogCalendar(title, baseURL string, changelogs []domain.Changelog) []byte {
+	var b strings.Builder
+
+	writeICSLine(&b, "BEGIN:VCALENDAR")
+	writeICSLine(&b, "VERSION:2.0")
+	writeICSLine(&b, "PRODID:-//HolyDOCs//Changelog//EN")
+	writeICSLine(&b, "X-WR-CALNAME:"+escapeICSText(title+" Changelog"))
+
+	for _, changelog := range changelogs {
+		var lines []string
+
+		for _, change := range changelog.Changes {
+			if notableChange(change) {
+				lines = append(lines, fmt.Sprintf("%s %s: %s", change.Type, change.Category, change.Details))
+			}
+		}
+
+		if len(lines) == 0 {
+			continue
+		}
+
+		date := changelog.Date.UTC()
+
+		writeICSLine(&b, "BEGIN:VEVENT")
+		writeICSLine(&b, "UID:"+changelogEntryID(changelog.Date))
+		writeICSLine(&b, "DTSTAMP:"+date.Format("20060102T150405Z"))
+		writeICSLine(&b, "DTSTART;VALUE=DATE:"+date.Format("20060102"))
+		writeICSLine(&b, "SUMMARY:"+escapeICSText(changelogEntryTitle(len(lines), "notable change", changelog.Date)))
+		writeICSLine(&b, "DESCRIPTION:"+escapeICSText(strings.Join(lines, "\n")))
+
+		if baseURL != "" {
+			writeICSLine(&b, "URL:"+baseURL)
+		}
+
+		writeICSLine(&b, "END:VEVENT")
+	}
+
+	writeICSLine(&b, "END:VCALENDAR")
+
+	return []byte(b.String())
+}
+
+// notableChange reports whether a change adds or removes a service or a relationship, which changes the
+// architecture drawn in the diagrams, unlike changed descriptions and operations.
+func notableChange(change domain.Change) bool {
+	if change.Type != domain.ChangeTypeAdded && change.Type != domain.ChangeTypeRemoved {
+		return false
+	}
+
+	return change.Category == "service" || change.Category == "relationship"
+}
+
+// changelogEntryTitle titles a changelog entry by its number of changes and its date, e.g.
+// "2 changes on 2025-01-15 10:00".
+func changelogEntryTitle(count int, noun string, date time.Time) string {
+	if count != 1 {
+		noun += "s"
+	}
+
+	return fmt.Sprintf("%d %s on %s", count, noun, date.Format(changelogEntryLayout))
+}
+
+// changelogEntryID identifies a changelog entry by its date, which stays the same across runs.
+func changelogEntryID(date time.Time) string {
+	return feedIDPrefix + ":" + date.UTC().Format(time.RFC3339Nano)
+}
+
+// escapeICSText escapes the special characters of iCalendar text values.
+func escapeICSText(text string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(text)
+}
+
+// writeICSLine writes a content line of an iCalendar, folded at icsLineLength octets without splitting
+// characters and ended by CRLF.
+func writeICSLine(b *strings.Builder, line string) {
+	limit := icsLineLength
+
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+
+		b.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		// Continuation lines start with a space, which counts towards their length.
+		limit = icsLineLength - 1
+	}
+
+	b.WriteString(line + "\r\n")
+}
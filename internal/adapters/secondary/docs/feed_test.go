@@ -0,0 +1,109 @@
+package docs
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/holydocs/holydocs/pkg/outputfs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteChangelogFeeds(t *testing.T) {
+	t.Parallel()
+
+	changelogs := []domain.Changelog{
+		{
+			Date: time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC),
+			Changes: []domain.Change{
+				{Type: domain.ChangeTypeAdded, Category: "service", Details: "'Search' was added"},
+				{Type: domain.ChangeTypeChanged, Category: "relationship",
+					Details: "Relationship description changed for 'requests' to 'Payments, Billing'"},
+			},
+		},
+		{
+			Date: time.Date(2025, 1, 10, 9, 30, 0, 0, time.UTC),
+			Changes: []domain.Change{
+				{Type: domain.ChangeTypeChanged, Category: "operation", Details: "'send' operation changed"},
+			},
+		},
+	}
+
+	fsys := outputfs.NewMemory()
+	require.NoError(t, writeChangelogFeeds(fsys, "docs", "Shop", "", config.ChangelogDocumentation{}, changelogs))
+	_, err := fsys.Stat("docs/" + changelogFeedFileName)
+	require.Error(t, err, "feeds are only written when enabled")
+
+	cfg := config.ChangelogDocumentation{Feed: true, Calendar: true}
+	require.NoError(t, writeChangelogFeeds(fsys, "docs", "Shop", "https://docs.example.com", cfg, changelogs))
+
+	feed, err := fsys.ReadFile("docs/" + changelogFeedFileName)
+	require.NoError(t, err)
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Shop Changelog</title>
+  <id>https://docs.example.com</id>
+  <updated>2025-01-15T10:00:00Z</updated>
+  <link href="https://docs.example.com"></link>
+  <author>
+    <name>HolyDOCs</name>
+  </author>
+  <entry>
+    <title>2 changes on 2025-01-15 10:00</title>
+    <id>urn:holydocs:changelog:2025-01-15T10:00:00Z</id>
+    <updated>2025-01-15T10:00:00Z</updated>
+    <link href="https://docs.example.com"></link>
+    <content type="html">&lt;ul&gt;&lt;li&gt;&lt;strong&gt;added&lt;/strong&gt; service: `+
+		`&amp;#39;Search&amp;#39; was added&lt;/li&gt;`+
+		`&lt;li&gt;&lt;strong&gt;changed&lt;/strong&gt; relationship: Relationship description changed for `+
+		`&amp;#39;requests&amp;#39; to &amp;#39;Payments, Billing&amp;#39;&lt;/li&gt;&lt;/ul&gt;</content>
+  </entry>
+  <entry>
+    <title>1 change on 2025-01-10 09:30</title>
+    <id>urn:holydocs:changelog:2025-01-10T09:30:00Z</id>
+    <updated>2025-01-10T09:30:00Z</updated>
+    <link href="https://docs.example.com"></link>
+    <content type="html">&lt;ul&gt;&lt;li&gt;&lt;strong&gt;changed&lt;/strong&gt; operation: &amp;#39;send&amp;#39; `+
+		`operation changed&lt;/li&gt;&lt;/ul&gt;</content>
+  </entry>
+</feed>
+`, string(feed))
+
+	calendar, err := fsys.ReadFile("docs/" + changelogCalendarFileName)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//HolyDOCs//Changelog//EN",
+		"X-WR-CALNAME:Shop Changelog",
+		"BEGIN:VEVENT",
+		"UID:urn:holydocs:changelog:2025-01-15T10:00:00Z",
+		"DTSTAMP:20250115T100000Z",
+		"DTSTART;VALUE=DATE:20250115",
+		"SUMMARY:1 notable change on 2025-01-15 10:00",
+		"DESCRIPTION:added service: 'Search' was added",
+		"URL:https://docs.example.com",
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n"), string(calendar), "entries without added or removed services and relationships are left out")
+}
+
+func TestWriteICSLine(t *testing.T) {
+	t.Parallel()
+
+	var b strings.Builder
+	writeICSLine(&b, "DESCRIPTION:"+strings.Repeat("é", 70))
+
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, "DESCRIPTION:"+strings.Repeat("é", 70), strings.ReplaceAll(
+		strings.Join(lines, "\r\n"), "\r\n ", ""))
+
+	for _, line := range lines {
+		assert.LessOrEqual(t, len(line), icsLineLength)
+	}
+}
@@ -0,0 +1,97 @@
+package docs
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	mf "github.com/holydocs/messageflow/pkg/messageflow"
+)
+
+// standaloneServicesName is the name of the group of services without a system.
+const standaloneServicesName = "Standalone Services"
+
+// fileNames assigns the file names of system, service and channel pages and diagrams. Names that sanitize
+// to the same file name, e.g. "Service_A" and "Service-A", would overwrite each other's files, so they are
+// told apart by a suffix, see domain.UniqueKeys. Names missing from the maps get the sanitized name.
+type fileNames struct {
+	systems  map[string]string
+	services map[string]string
+	channels map[string]string
+}
+
+func newFileNames(schema domain.Schema, messageflowSchema mf.Schema) fileNames {
+	systems := make([]string, 0, len(schema.Services))
+	services := make([]string, 0, len(schema.Services))
+
+	for _, service := range schema.Services {
+		services = append(services, service.Info.Name)
+
+		system := strings.TrimSpace(service.Info.System)
+		if system == "" {
+			system = standaloneServicesName
+		}
+		systems = append(systems, system)
+	}
+
+	return fileNames{
+		systems:  domain.UniqueKeys(systems, sanitizeFilename),
+		services: domain.UniqueKeys(services, sanitizeFilename),
+		channels: domain.UniqueKeys(extractUniqueChannels(messageflowSchema), sanitizeFilename),
+	}
+}
+
+func (n fileNames) system(name string) string {
+	return fileName(n.systems, strings.TrimSpace(name))
+}
+
+func (n fileNames) service(name string) string {
+	return fileName(n.services, name)
+}
+
+func (n fileNames) channel(name string) string {
+	return fileName(n.channels, name)
+}
+
+func fileName(names map[string]string, name string) string {
+	if key, ok := names[name]; ok {
+		return key
+	}
+
+	return sanitizeFilename(name)
+}
+
+// collisionWarnings reports the names that got a suffixed file name because another name of the same kind
+// sanitizes to the same file name. Links to their pages and diagrams change when the other name goes away.
+func (n fileNames) collisionWarnings() []string {
+	var warnings []string
+
+	warnings = appendCollisionWarnings(warnings, "system", n.systems)
+	warnings = appendCollisionWarnings(warnings, "service", n.services)
+	warnings = appendCollisionWarnings(warnings, "channel", n.channels)
+
+	return warnings
+}
+
+func appendCollisionWarnings(warnings []string, kind string, names map[string]string) []string {
+	owners := make(map[string]string, len(names))
+	for name, key := range names {
+		if key == sanitizeFilename(name) {
+			owners[key] = name
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(names)) {
+		base := sanitizeFilename(name)
+		if names[name] == base {
+			continue
+		}
+
+		warnings = append(warnings, fmt.Sprintf("%s %s has the same file name %s as %s, its files are named %s, "+
+			"rename one of them to keep links stable", kind, name, base, owners[base], names[name]))
+	}
+
+	return warnings
+}
@@ -0,0 +1,53 @@
+package docs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	mf "github.com/holydocs/messageflow/pkg/messageflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileNames(t *testing.T) {
+	t.Parallel()
+
+	schema := domain.Schema{Services: []domain.Service{
+		{Info: domain.ServiceInfo{Name: "Service-A", System: "Shop"}},
+		{Info: domain.ServiceInfo{Name: "Service_A", System: "shop"}},
+		{Info: domain.ServiceInfo{Name: "Billing"}},
+	}}
+	messageflowSchema := mf.Schema{Services: []mf.Service{{
+		Name: "Service-A",
+		Operation: []mf.Operation{
+			{Action: mf.ActionSend, Channel: mf.Channel{Name: "orders-created"}},
+			{Action: mf.ActionSend, Channel: mf.Channel{Name: "orders_created"}},
+		},
+	}}}
+
+	names := newFileNames(schema, messageflowSchema)
+
+	assert.Equal(t, "service-a", names.service("Service-A"))
+	assert.Regexp(t, `^service-a-[0-9a-f]{8}$`, names.service("Service_A"))
+	assert.Equal(t, "billing", names.service("Billing"))
+	assert.Equal(t, "shop", names.system("Shop"))
+	assert.Regexp(t, `^shop-[0-9a-f]{8}$`, names.system("shop"))
+	assert.Equal(t, "standalone-services", names.system(standaloneServicesName))
+	assert.Equal(t, "unknown", names.service("Unknown"), "unknown names get the sanitized name")
+
+	warnings := names.collisionWarnings()
+	require.Len(t, warnings, 3)
+	assert.Contains(t, warnings[0], "system shop has the same file name shop as Shop")
+	assert.Contains(t, warnings[1], "service Service_A has the same file name service-a as Service-A")
+	assert.Contains(t, warnings[2], "channel orders_created has the same file name orders-created as orders-created")
+}
+
+func TestSanitizeFilename(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "order-service", sanitizeFilename("Order Service"))
+	assert.Equal(t, "item", sanitizeFilename(""))
+	assert.Regexp(t, `^con-[0-9a-f]{8}$`, sanitizeFilename("CON"), "names reserved on Windows")
+	assert.LessOrEqual(t, len(sanitizeFilename(strings.Repeat("Long Service Name ", 10))), 64)
+}
@@ -0,0 +1,159 @@
+package docs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	d2target "github.com/holydocs/holydocs/internal/adapters/secondary/target/d2"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	mf "github.com/holydocs/messageflow/pkg/messageflow"
+)
+
+// focusDiagram renders the overview diagram of the neighborhood of a service or system.
+func (g *Generator) focusDiagram(
+	ctx context.Context,
+	schema domain.Schema,
+	messageflowSchema mf.Schema,
+	req domain.GenerateServiceDiagramRequest,
+) ([]byte, error) {
+	d2Target, ok := g.highlightedTarget().(*d2target.Target)
+	if !ok {
+		return nil, errors.New("target is not a D2 target")
+	}
+
+	if req.Depth < 0 {
+		return nil, fmt.Errorf("%w: depth %d", domain.ErrUnsupportedValue, req.Depth)
+	}
+
+	neighborhood, edges, err := focusNeighborhood(schema, buildAsyncEdges(messageflowSchema), req.Service, req.Depth)
+	if err != nil {
+		return nil, err
+	}
+
+	script, err := generateOverviewDiagramWithSystemContent(d2Target,
+		modifySchemaWithServiceSummaries(neighborhood, &g.config.Documentation),
+		convertAsyncEdges(edges), g.config.Vocabulary.Term(g.config.Output.GlobalName), &g.config.Documentation)
+	if err != nil {
+		return nil, fmt.Errorf("generate focus D2 script: %w", err)
+	}
+
+	if len(script) == 0 {
+		return nil, fmt.Errorf("%w: nothing to show around %s", domain.ErrNoDiagramData, req.Service)
+	}
+
+	if req.Format == domain.DiagramFormatD2 {
+		return script, nil
+	}
+
+	diagram, err := d2Target.RenderSchema(ctx, domain.FormattedSchema{Type: "d2", Data: script})
+	if err != nil {
+		return nil, fmt.Errorf("render focus diagram: %w", err)
+	}
+
+	return diagram, nil
+}
+
+// focusNeighborhood returns the part of the schema within depth hops of the focused service, or of the
+// services of the focused system, following relationships and async edges in both directions. Services
+// outside the neighborhood are left out together with their relationships, as are relationships and async
+// edges leading out of it.
+func focusNeighborhood(
+	schema domain.Schema,
+	asyncEdges []asyncEdge,
+	focus string,
+	depth int,
+) (domain.Schema, []asyncEdge, error) {
+	var focused []string
+
+	for _, service := range schema.Services {
+		if service.Info.Name == focus || strings.TrimSpace(service.Info.System) == focus {
+			focused = append(focused, service.Info.Name)
+		}
+	}
+
+	if len(focused) == 0 {
+		return domain.Schema{}, nil, fmt.Errorf("%w: no service or system named %s", domain.ErrServiceNotFound, focus)
+	}
+
+	reached := reachWithin(schemaNeighbors(schema, asyncEdges), focused, depth)
+
+	var neighborhood domain.Schema
+
+	for _, service := range schema.Services {
+		if _, ok := reached[service.Info.Name]; !ok {
+			continue
+		}
+
+		relationships := make([]domain.Relationship, 0, len(service.Relationships))
+		for _, rel := range service.Relationships {
+			if _, ok := reached[rel.Participant]; ok {
+				relationships = append(relationships, rel)
+			}
+		}
+
+		service.Relationships = relationships
+		neighborhood.Services = append(neighborhood.Services, service)
+	}
+
+	var edges []asyncEdge
+
+	for _, edge := range asyncEdges {
+		_, sourceReached := reached[edge.Source]
+		_, targetReached := reached[edge.Target]
+
+		if sourceReached && targetReached {
+			edges = append(edges, edge)
+		}
+	}
+
+	return neighborhood, edges, nil
+}
+
+// schemaNeighbors lists the services and participants linked to each other by a relationship or an async edge,
+// in both directions.
+func schemaNeighbors(schema domain.Schema, asyncEdges []asyncEdge) map[string][]string {
+	neighbors := make(map[string][]string)
+	link := func(a, b string) {
+		neighbors[a] = append(neighbors[a], b)
+		neighbors[b] = append(neighbors[b], a)
+	}
+
+	for _, service := range schema.Services {
+		for _, rel := range service.Relationships {
+			link(service.Info.Name, rel.Participant)
+		}
+	}
+
+	for _, edge := range asyncEdges {
+		link(edge.Source, edge.Target)
+	}
+
+	return neighbors
+}
+
+// reachWithin returns the names reached from the frontier within depth hops, the frontier included.
+func reachWithin(neighbors map[string][]string, frontier []string, depth int) map[string]struct{} {
+	reached := make(map[string]struct{}, len(frontier))
+	for _, name := range frontier {
+		reached[name] = struct{}{}
+	}
+
+	for hop := 0; hop < depth && len(frontier) > 0; hop++ {
+		var next []string
+
+		for _, name := range frontier {
+			for _, neighbor := range neighbors[name] {
+				if _, ok := reached[neighbor]; !ok {
+					reached[neighbor] = struct{}{}
+					next = append(next, neighbor)
+				}
+			}
+		}
+
+		frontier = next
+	}
+
+	return reached
+}
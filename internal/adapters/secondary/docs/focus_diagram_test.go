@@ -0,0 +1,67 @@
+package docs
+
+import (
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFocusNeighborhood(t *testing.T) {
+	t.Parallel()
+
+	schema := domain.Schema{Services: []domain.Service{
+		{
+			Info: domain.ServiceInfo{Name: "Orders", System: "Shop"},
+			Relationships: []domain.Relationship{
+				{Action: domain.RelationshipActionUses, Participant: "Payments"},
+				{Action: domain.RelationshipActionUses, Participant: "PostgreSQL", External: true},
+			},
+		},
+		{
+			Info: domain.ServiceInfo{Name: "Payments", System: "Billing"},
+			Relationships: []domain.Relationship{
+				{Action: domain.RelationshipActionUses, Participant: "Stripe", External: true},
+			},
+		},
+		{Info: domain.ServiceInfo{Name: "Shipping", System: "Shop"}},
+		{Info: domain.ServiceInfo{Name: "Mailer"}},
+	}}
+	edges := []asyncEdge{
+		{Source: "Shipping", Target: "Mailer", Channel: "shipped", Kind: "send"},
+	}
+
+	neighborhood, focusEdges, err := focusNeighborhood(schema, edges, "Orders", 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Orders", "Payments"}, serviceNames(neighborhood))
+	assert.Len(t, neighborhood.Services[0].Relationships, 2)
+	assert.Empty(t, neighborhood.Services[1].Relationships, "Stripe is two hops away")
+	assert.Empty(t, focusEdges)
+
+	neighborhood, _, err = focusNeighborhood(schema, edges, "Orders", 2)
+	require.NoError(t, err)
+	assert.Len(t, neighborhood.Services[1].Relationships, 1)
+
+	neighborhood, _, err = focusNeighborhood(schema, edges, "Orders", 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Orders"}, serviceNames(neighborhood))
+	assert.Empty(t, neighborhood.Services[0].Relationships)
+
+	neighborhood, focusEdges, err = focusNeighborhood(schema, edges, "Shop", 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Orders", "Payments", "Shipping", "Mailer"}, serviceNames(neighborhood))
+	assert.Equal(t, edges, focusEdges)
+
+	_, _, err = focusNeighborhood(schema, edges, "Inventory", 1)
+	require.ErrorIs(t, err, domain.ErrServiceNotFound)
+}
+
+func serviceNames(schema domain.Schema) []string {
+	names := make([]string, 0, len(schema.Services))
+	for _, service := range schema.Services {
+		names = append(names, service.Info.Name)
+	}
+
+	return names
+}
@@ -0,0 +1,345 @@
+package docs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	mf "github.com/holydocs/messageflow/pkg/messageflow"
+)
+
+// generation holds the state of a single documentation run, shared by the steps rendering and writing it.
+type generation struct {
+	config *config.Config
+	opts   domain.GenerateOptions
+	output config.Output
+
+	schema domain.Schema
+	// diagramSchema is the schema drawn in the diagrams, in strict mode without questionable relationships.
+	diagramSchema domain.Schema
+	asyncEdges    []asyncEdge
+	names         fileNames
+
+	fsys     *hashingFS
+	pages    site
+	dirs     *outputDirectories
+	target   domain.Target
+	recorder *diagramRecorder
+
+	data     templateData
+	warnings []string
+	// editWarnings report the files of the previous run edited by hand since.
+	editWarnings []string
+}
+
+// generationStep adds a part of the documentation to a run, usually the diagrams or pages of a feature.
+type generationStep func(ctx context.Context, run *generation) error
+
+// postRenderSteps run after the diagrams of the overview, the systems and the services were rendered, in order.
+// Diagrams of optional sections come first, the diagrams are optimized once all are rendered.
+func postRenderSteps() []generationStep {
+	return []generationStep{
+		renderContextMapStep,
+		renderRuntimeOverlayStep,
+		renderPersonasStep,
+		renderCapabilitiesStep,
+		writeFreshnessBadgesStep,
+		writeServiceBadgesStep,
+		writeAtAGlanceStep,
+		optimizeDiagramsStep,
+	}
+}
+
+// sectionSteps fill the sections of the pages built from the schema rather than from diagrams, in order.
+func sectionSteps() []generationStep {
+	return []generationStep{
+		buildCatalogSectionsStep,
+		buildReviewSectionsStep,
+		applyProbeReportStep,
+		buildDependencySectionsStep,
+	}
+}
+
+// newGeneration prepares the output directories of a run, the files written are hashed for the next run.
+// previous is the metadata of the previous run, nil when there is none.
+func (g *Generator) newGeneration(schema domain.Schema, messageflowSchema mf.Schema, output config.Output,
+	opts domain.GenerateOptions, previous *Metadata) (*generation, error) {
+	// Files edited by hand since the previous run are overwritten, their edits belong in kept blocks.
+	editWarnings, err := editedFileWarnings(g.fs, output.Dir, previous)
+	if err != nil {
+		return nil, err
+	}
+
+	// Aliases only change how external participants are drawn and listed, the metadata keeps the declared names.
+	schema = schema.AliasExternalParticipants(g.config.Documentation.ExternalAliases)
+
+	fsys := newHashingFS(g.fs, output.Dir)
+	pages := newSite(fsys, output)
+	pages.interactive = g.config.Diagram.Interactive
+
+	dirs, err := setupOutputDirectories(fsys, pages.diagramsBaseDir())
+	if err != nil {
+		return nil, err
+	}
+
+	run := &generation{
+		config:        g.config,
+		opts:          opts,
+		output:        output,
+		schema:        schema,
+		diagramSchema: schema,
+		asyncEdges:    buildAsyncEdges(messageflowSchema),
+		names:         newFileNames(schema, messageflowSchema),
+		fsys:          fsys,
+		pages:         pages,
+		dirs:          dirs,
+		target:        g.highlightedTarget(),
+		recorder:      newDiagramRecorder(fsys, opts.KeepGoing),
+		editWarnings:  editWarnings,
+	}
+
+	return run, nil
+}
+
+// renderDiagrams renders the diagrams of the overview, the systems, the services and the message flow and
+// builds the template data around them.
+func (run *generation) renderDiagrams(ctx context.Context, messageflowSchema mf.Schema,
+	messageflowTarget mf.Target, changelogs []domain.Changelog) error {
+	// In strict mode, diagrams and the services drawn next to them only show relationships passing validation.
+	var questionable []questionableEdgeView
+	if run.config.Diagram.Strict {
+		run.diagramSchema, questionable = strictSchema(run.schema)
+	}
+
+	results, err := generateAllDiagrams(ctx, run.fsys, run.diagramSchema, run.asyncEdges, run.target,
+		messageflowSchema, messageflowTarget, run.config, run.dirs, run.names, run.recorder)
+	if err != nil {
+		return err
+	}
+
+	run.data = buildTemplateData(run.config, results, changelogs, run.names)
+	run.data.QuestionableEdges = questionable
+
+	return nil
+}
+
+// build renders the diagrams and fills the template data of the run.
+func (run *generation) build(ctx context.Context, messageflowSchema mf.Schema, messageflowTarget mf.Target,
+	changelogs []domain.Changelog) error {
+	diagramsStart := time.Now()
+
+	if err := run.renderDiagrams(ctx, messageflowSchema, messageflowTarget, changelogs); err != nil {
+		return err
+	}
+
+	if err := run.runSteps(ctx, postRenderSteps()); err != nil {
+		return err
+	}
+
+	run.recorder.stats.DurationMS = time.Since(diagramsStart).Milliseconds()
+
+	return run.runSteps(ctx, sectionSteps())
+}
+
+// runSteps runs the steps in order and stops at the first failing one.
+func (run *generation) runSteps(ctx context.Context, steps []generationStep) error {
+	for _, step := range steps {
+		if err := step(ctx, run); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func renderContextMapStep(ctx context.Context, run *generation) error {
+	var err error
+
+	run.data.ContextMap, err = generateContextMap(ctx, run.fsys, run.diagramSchema, run.target,
+		run.dirs.DiagramsDir, run.recorder)
+	if err := run.recorder.tolerate("context map", "", err); err != nil {
+		return fmt.Errorf("failed to generate context map: %w", err)
+	}
+
+	return nil
+}
+
+func renderRuntimeOverlayStep(ctx context.Context, run *generation) error {
+	var err error
+
+	run.data.RuntimeOverlay, err = generateRuntimeOverlay(ctx, run.fsys, run.diagramSchema, run.opts.RuntimeMetrics,
+		run.target, run.dirs.DiagramsDir, run.recorder)
+	if err := run.recorder.tolerate("runtime overlay", "", err); err != nil {
+		return fmt.Errorf("failed to generate runtime overlay: %w", err)
+	}
+
+	return nil
+}
+
+func renderPersonasStep(ctx context.Context, run *generation) error {
+	var err error
+
+	run.data.Personas, err = generatePersonas(ctx, run.fsys, run.diagramSchema, run.target, run.dirs.DiagramsDir,
+		run.recorder)
+	if err := run.recorder.tolerate("persona diagrams", "", err); err != nil {
+		return fmt.Errorf("failed to generate persona diagrams: %w", err)
+	}
+
+	return nil
+}
+
+func renderCapabilitiesStep(ctx context.Context, run *generation) error {
+	var err error
+
+	run.data.Capabilities, err = generateCapabilities(ctx, run.fsys, run.diagramSchema, run.asyncEdges, run.target,
+		run.config.Vocabulary.Term(run.config.Output.GlobalName), run.dirs.DiagramsDir, run.recorder)
+	if err := run.recorder.tolerate("capability diagrams", "", err); err != nil {
+		return fmt.Errorf("failed to generate capability diagrams: %w", err)
+	}
+
+	return nil
+}
+
+func writeFreshnessBadgesStep(_ context.Context, run *generation) error {
+	var err error
+
+	run.data, err = writeFreshnessBadges(run.fsys, run.data, run.dirs.DiagramsDir, run.opts.LastUpdated, time.Now())
+
+	return err
+}
+
+func writeServiceBadgesStep(_ context.Context, run *generation) error {
+	var err error
+
+	run.data, err = writeServiceBadges(run.fsys, run.data, run.output.Dir, run.output.Format,
+		run.config.Documentation.Badges)
+
+	return err
+}
+
+func writeAtAGlanceStep(_ context.Context, run *generation) error {
+	glance := run.config.Documentation.AtAGlance
+	if !glance.Enabled {
+		return nil
+	}
+
+	var err error
+
+	run.data.AtAGlance, err = writeAtAGlanceCharts(run.fsys, buildAtAGlance(run.schema, glance.TopTechnologies,
+		run.config.Vocabulary.Term(standaloneServicesName)), run.dirs.DiagramsDir)
+
+	return err
+}
+
+func optimizeDiagramsStep(_ context.Context, run *generation) error {
+	if !run.config.Diagram.OptimizeSVG {
+		return nil
+	}
+
+	return optimizeDiagrams(run.fsys, run.dirs.DiagramsDir)
+}
+
+func storeDiagramsStep(ctx context.Context, run *generation) error {
+	var err error
+
+	run.pages.assetURLs, err = storeDiagrams(ctx, run.fsys, run.pages.diagramsBaseDir(), run.output.Assets)
+
+	return err
+}
+
+func buildCatalogSectionsStep(_ context.Context, run *generation) error {
+	run.data.MessageFlow.Channels = annotateChannelViews(run.data.MessageFlow.Channels, run.schema.ChannelSLAs())
+	run.data.EventCatalog = buildEventCatalog(run.schema)
+	run.data = applySynthesizedExamples(run.data, run.config.Documentation.Examples)
+	run.data.PlannedChanges = buildPlannedChanges(run.schema)
+	run.data.Datastores = buildDatastores(run.schema)
+	run.data.Risks = buildRisks(run.schema)
+
+	return nil
+}
+
+func buildReviewSectionsStep(_ context.Context, run *generation) error {
+	run.data.DependencyMatrix = buildDependencyMatrix(run.schema, run.asyncEdges)
+	run.data.Decommissioning = buildDecommissioning(run.schema, run.asyncEdges, time.Now())
+	run.data.NeedsReview = buildNeedsReview(run.opts.NeedsReview)
+	run.data.PendingReview = run.opts.PendingReview
+	run.data = applyOnCall(run.data, run.opts.OnCall)
+
+	return nil
+}
+
+func applyProbeReportStep(_ context.Context, run *generation) error {
+	probes, err := readProbeReport(run.fsys, run.output.Dir)
+	if err != nil {
+		return err
+	}
+
+	run.data = applyVocabulary(applyProbeReport(run.data, probes), run.config.Vocabulary)
+
+	return nil
+}
+
+func buildDependencySectionsStep(_ context.Context, run *generation) error {
+	var schemaWarnings, dependencyWarnings []string
+
+	run.data.DatastoreSchemas, schemaWarnings = buildDatastoreSchemas(run.schema, run.config.Documentation.Datastores)
+	run.data.ThirdPartyDependencies, dependencyWarnings = buildThirdPartyDependencies(run.schema,
+		run.config.Documentation.Dependencies, run.opts.DependencyStatus)
+	run.data.ThirdPartyStatusPages = hasStatusPages(run.data.ThirdPartyDependencies)
+
+	run.warnings = append(run.warnings, schemaWarnings...)
+	run.warnings = append(run.warnings, dependencyWarnings...)
+
+	return nil
+}
+
+// reply collects the warnings and errors of the run. Warnings about files edited by hand come last.
+func (run *generation) reply(changelog *domain.Changelog) domain.GenerateDocumentationReply {
+	warnings := ghostParticipantWarnings(run.schema)
+	warnings = append(warnings, configReferenceWarnings(run.schema, run.config.Documentation)...)
+	warnings = append(warnings, repositoryTemplateWarnings(run.schema, run.config.Documentation.Repositories)...)
+	warnings = append(warnings, run.warnings...)
+	warnings = append(warnings, run.names.collisionWarnings()...)
+	warnings = append(warnings, run.recorder.warnings...)
+	warnings = append(warnings, decommissionWarnings(run.data.Decommissioning)...)
+	warnings = append(warnings, run.editWarnings...)
+
+	run.data.Errors = append(append([]string(nil), run.opts.SourceErrors...), run.recorder.errors...)
+
+	return domain.GenerateDocumentationReply{
+		Changelog: changelog,
+		Warnings:  warnings,
+		Errors:    run.data.Errors,
+		Diagrams:  run.recorder.stats,
+	}
+}
+
+// write stores the diagrams and writes the pages and changelog feeds to the output directory, then the output
+// targets and the outputs of systems. Interrupted runs don't get here, so they upload no diagrams.
+func (run *generation) write(ctx context.Context, systems map[string]config.SystemDocumentation,
+	changelogs []domain.Changelog) error {
+	if err := storeDiagramsStep(ctx, run); err != nil {
+		return err
+	}
+
+	if err := writeDocs(run.pages, run.output.Format, run.data); err != nil {
+		return err
+	}
+
+	if err := writeChangelogFeeds(run.fsys, run.pages.contentDir, run.output.Title,
+		strings.TrimSuffix(run.config.Documentation.Badges.BaseURL, "/"), run.config.Documentation.Changelog,
+		changelogs); err != nil {
+		return err
+	}
+
+	for _, target := range run.output.Targets {
+		if err := writeTarget(run.pages, target.Output(run.output), run.data); err != nil {
+			return fmt.Errorf("output target %s: %w", target.Dir, err)
+		}
+	}
+
+	return writeSystemOutputs(run.pages, run.output, systems, run.data)
+}
@@ -6,16 +6,19 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
 	"text/template"
-	"unicode"
+	"time"
 
 	d2target "github.com/holydocs/holydocs/internal/adapters/secondary/target/d2"
 	"github.com/holydocs/holydocs/internal/config"
 	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/holydocs/holydocs/internal/slug"
+	"github.com/holydocs/holydocs/pkg/outputfs"
 	mf "github.com/holydocs/messageflow/pkg/messageflow"
 	do "github.com/samber/do/v2"
 )
@@ -35,6 +38,10 @@ var readmeTemplateFS embed.FS
 //go:embed templates/md_multi_page/messageflow-context.tmpl
 //go:embed templates/md_multi_page/channel.tmpl
 //go:embed templates/md_multi_page/changelog.tmpl
+//go:embed templates/md_multi_page/events.tmpl
+//go:embed templates/md_multi_page/datastores.tmpl
+//go:embed templates/md_multi_page/personas.tmpl
+//go:embed templates/md_multi_page/capabilities.tmpl
 var multiPageTemplateFS embed.FS
 
 // DocumentationConfig is an alias for config.Documentation to avoid circular imports.
@@ -42,8 +49,12 @@ type DocumentationConfig = config.Documentation
 
 // Metadata represents the metadata for the documentation.
 type Metadata struct {
+	// Version is the format version, files written before versioning have none and are read as version 1.
+	Version    int                `json:"version,omitempty"`
 	Schema     domain.Schema      `json:"schema"`
 	Changelogs []domain.Changelog `json:"changelogs"`
+	// Files are the content hashes of the generated files, by their path relative to the output directory.
+	Files map[string]string `json:"files,omitempty"`
 }
 
 // File permissions.
@@ -71,13 +82,40 @@ type templateData struct {
 	SystemSummaries        map[string]string
 	MessageFlow            messageFlowView
 	Changelogs             []domain.Changelog
+	RecentChangelogs       []domain.Changelog
+	OlderChangelogs        []domain.Changelog
+	ContextMap             contextMapView
+	RuntimeOverlay         runtimeOverlayView
+	DependencyMatrix       dependencyMatrixView
+	EventCatalog           eventCatalogView
+	PlannedChanges         plannedChangesView
+	Datastores             []datastoreView
+	DatastoreSchemas       []datastoreSchemaView
+	ThirdPartyDependencies []thirdPartyDependencyView
+	// ThirdPartyStatusPages adds the status column to the register when dependencies link status pages.
+	ThirdPartyStatusPages  bool
+	Risks                  []riskView
+	Personas               []personaView
+	Capabilities           capabilityMapView
+	Decommissioning        []decommissionView
+	NeedsReview            []needsReviewView
+	PendingReview          []domain.PendingService
+	QuestionableEdges      []questionableEdgeView
+	AtAGlance              atAGlanceView
 	MessageFlowContextPath string
+	EventCatalogPath       string
+	DatastoreSchemasPath   string
+	PersonasPath           string
+	CapabilitiesPath       string
 	ChangelogPath          string
+	// Errors lists the parts left out by generation failures tolerated with --keep-going.
+	Errors []string
 }
 
 type systemView struct {
 	Name     string
 	Anchor   string
+	FileName string
 	Services []serviceView
 	FilePath string
 }
@@ -89,20 +127,44 @@ type systemDiagramView struct {
 }
 
 type serviceView struct {
-	Name                  string
-	Anchor                string
-	System                string
-	Description           string
-	Owner                 string
-	Repository            string
+	Name               string
+	Anchor             string
+	System             string
+	BusinessCapability string
+	Description        string
+	Owner              string
+	Repository         string
+	// RepositoryURL links the repository, or the directory of the service in a monorepo, at Subpath.
+	RepositoryURL string
+	Subpath       string
+	// RepositoryLinks are deep links into the repository, OwnerURL links the page of the owning team.
+	RepositoryLinks       []repositoryLink
+	OwnerURL              string
+	OnCall                *onCallView
 	Tags                  []string
+	Planned               bool
+	Deprecated            bool
+	SunsetDate            string
 	RelationshipsDiagram  string
 	RelationshipsD2       string
 	RelationshipSummaries []relationshipSummary
 	InterServiceLinks     []serviceConnection
 	AsyncSummaries        []asyncSummary
 	ServiceFlowDiagram    string
-	FilePath              string
+	ProducedEvents        []eventLink
+	ConsumedEvents        []eventLink
+	Endpoints             []endpointView
+	Limits                []limitView
+	// Deployments are the environments of the service, ProbedAt is when their endpoints were last probed.
+	Deployments []deploymentView
+	ProbedAt    string
+	// LastUpdated is the date of the last change of the specifications, shown as LastUpdatedBadge.
+	LastUpdated      string
+	LastUpdatedBadge string
+	// BadgesSnippet embeds the badges of the service, set when the documentation has a base URL.
+	BadgesSnippet string
+	FileName      string
+	FilePath      string
 }
 
 type relationshipSummary struct {
@@ -110,9 +172,32 @@ type relationshipSummary struct {
 	Action      domain.RelationshipAction
 	Technology  string
 	Description string
+	Notes       string
 	Proto       string
 	External    bool
 	Person      bool
+	Planned     bool
+	Criticality domain.Criticality
+	Access      domain.Access
+	// SchemaLink points to the schema of the participant, when it's a documented datastore.
+	SchemaLink string
+}
+
+type plannedChangesView struct {
+	Services      []string
+	Relationships []plannedRelationshipView
+}
+
+// HasData reports whether the schema contains any planned services or relationships.
+func (v plannedChangesView) HasData() bool {
+	return len(v.Services) > 0 || len(v.Relationships) > 0
+}
+
+type plannedRelationshipView struct {
+	Service     string
+	Action      domain.RelationshipAction
+	Participant string
+	Description string
 }
 
 type serviceConnection struct {
@@ -139,13 +224,20 @@ type channelView struct {
 	Anchor      string
 	DiagramPath string
 	Messages    []channelMessage
+	FileName    string
 	FilePath    string
+	SLA         domain.ChannelSLA
+	// DLQAnchor and DLQFileName locate the section of the dead letter queue, when it's a documented channel.
+	DLQAnchor   string
+	DLQFileName string
 }
 
 type channelMessage struct {
 	Name      string
 	Direction string
 	Payload   string
+	Example   string
+	EventLink string
 }
 
 type asyncEdge struct {
@@ -177,73 +269,200 @@ func convertAsyncEdges(edges []asyncEdge) []domain.AsyncEdge {
 type Generator struct {
 	target domain.Target
 	config *config.Config
+	// fs is where the documentation is written, the local filesystem unless overridden.
+	fs outputfs.FS
 }
 
 func NewGenerator(i do.Injector) (*Generator, error) {
 	target := do.MustInvoke[domain.Target](i)
 	cfg := do.MustInvoke[*config.Config](i)
+	fsys := do.MustInvoke[outputfs.FS](i)
 
 	return &Generator{
 		target: target,
 		config: cfg,
+		fs:     fsys,
 	}, nil
 }
 
+// NewOutputFS provides the local filesystem to write documentation to. Library consumers override it with
+// do.OverrideValue to generate documentation elsewhere.
+func NewOutputFS(_ do.Injector) (outputfs.FS, error) {
+	return outputfs.OS(), nil
+}
+
+// highlightedTarget returns the target with the configured highlight applied to its diagrams.
+func (g *Generator) highlightedTarget() domain.Target {
+	if d2Target, ok := g.target.(*d2target.Target); ok {
+		return d2Target.WithHighlight(g.config.Diagram.Highlight)
+	}
+
+	return g.target
+}
+
 // Generate produces the documentation bundle (markdown + diagrams) for the provided schemas.
 func (g *Generator) Generate(
 	ctx context.Context,
 	schema domain.Schema,
 	messageflowSchema mf.Schema,
 	messageflowTarget mf.Target,
-) (*domain.Changelog, error) {
+	opts domain.GenerateOptions,
+) (reply domain.GenerateDocumentationReply, err error) {
 	if g.target == nil {
-		return nil, ErrHolydocsTargetRequired
+		return domain.GenerateDocumentationReply{}, ErrHolydocsTargetRequired
 	}
 
 	// Sort schemas before processing to ensure consistent ordering
 	schema.Sort()
 	messageflowSchema.Sort()
 
-	metadata, newChangelog, err := g.processMetadata(schema, g.config.Output.Dir)
+	output, systems := g.outputs(opts)
+
+	previous, err := readMetadata(g.fs, output.Dir)
 	if err != nil {
-		return nil, fmt.Errorf("error processing metadata: %w", err)
+		return domain.GenerateDocumentationReply{}, fmt.Errorf("error reading existing holydocs data: %w", err)
 	}
 
-	outputDirs, err := setupOutputDirectories(g.config.Output.Dir)
+	// A failed or interrupted run must not record changes its pages don't show.
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, restoreMetadata(g.fs, output.Dir, previous))
+		}
+	}()
+
+	// A partial schema must not become the baseline of the next changelog, neither must previews.
+	record := len(opts.SourceErrors) == 0 && output.Metadata
+
+	metadata, newChangelog, err := g.processMetadata(schema, output.Dir, record, opts)
 	if err != nil {
-		return nil, err
+		return domain.GenerateDocumentationReply{}, fmt.Errorf("error processing metadata: %w", err)
 	}
 
-	asyncEdges := buildAsyncEdges(messageflowSchema)
-
-	diagramResults, err := generateAllDiagrams(
-		ctx, schema, asyncEdges, g.target, messageflowSchema, messageflowTarget, g.config, outputDirs)
+	run, err := g.newGeneration(schema, messageflowSchema, output, opts, previous)
 	if err != nil {
-		return nil, err
+		return domain.GenerateDocumentationReply{}, err
+	}
+
+	if err := run.build(ctx, messageflowSchema, messageflowTarget, metadata.Changelogs); err != nil {
+		return domain.GenerateDocumentationReply{}, err
+	}
+
+	reply = run.reply(newChangelog)
+
+	// Rendering stops on cancellation, diagrams are only stored and pages written by runs that weren't
+	// interrupted.
+	if err := ctx.Err(); err != nil {
+		return reply, err
+	}
+
+	if err := run.write(ctx, systems, metadata.Changelogs); err != nil {
+		return reply, err
+	}
+
+	if err := g.recordFiles(output.Dir, record, metadata, previous, run.fsys.hashes); err != nil {
+		return reply, err
+	}
+
+	return reply, nil
+}
+
+// recordFiles stores the hashes of the files written to outputDir with the metadata of a recorded run. Runs not
+// recording their schema still rewrite the pages of the previous run, so its metadata gets their hashes, or the
+// next run reports every rewritten page as edited by hand.
+func (g *Generator) recordFiles(outputDir string, record bool, metadata, previous *Metadata,
+	hashes map[string]string) error {
+	if !record {
+		if previous == nil {
+			return nil
+		}
+
+		// A copy, the previous metadata is restored when writing fails.
+		kept := *previous
+		metadata = &kept
+	}
+
+	metadata.Files = hashes
+	if err := writeMetadata(g.fs, outputDir, *metadata); err != nil {
+		return fmt.Errorf("error writing holydocs data: %w", err)
+	}
+
+	return nil
+}
+
+// outputs returns the output and the systems with their own output directory the documentation is written to.
+func (g *Generator) outputs(opts domain.GenerateOptions) (config.Output, map[string]config.SystemDocumentation) {
+	output, systems := g.config.Output, g.config.Documentation.Systems
+	if opts.OutputDir != "" {
+		// Variants such as the redacted documentation and previews are only written to their own directory.
+		output.Dir, output.Targets, systems = opts.OutputDir, nil, nil
+	}
+
+	return output, systems
+}
+
+// writeDocs writes the pages of the documentation in the format, along with the dependency matrix.
+func writeDocs(pages site, format string, data templateData) error {
+	if data.DependencyMatrix.HasData() {
+		if err := writeDependencyMatrixCSV(pages.fs, pages.contentDir, data.DependencyMatrix); err != nil {
+			return err
+		}
+
+		data.DependencyMatrix.CSVPath = dependencyMatrixFileName
 	}
 
-	data := buildTemplateData(g.config, diagramResults, metadata.Changelogs)
+	// Writing pages links them in place, every output starts from its own copy.
+	data = cloneTemplateData(data)
+
+	if pages.viewers() {
+		if err := writeViewers(pages, format, data); err != nil {
+			return err
+		}
+	}
 
-	if g.config.Output.Format == "md_multi_page" {
-		return newChangelog, writeMultiPageDocs(g.config.Output.Dir, data)
+	if format == "md_multi_page" {
+		return writeMultiPageDocs(pages, data)
 	}
 
-	return newChangelog, writeReadme(g.config.Output.Dir, data)
+	data = linkPersonas(linkDatastoreSchemas(linkEventCatalog(data, false), false), false)
+
+	return writeReadme(pages, linkCapabilities(data, false))
 }
 
-func (g *Generator) processMetadata(schema domain.Schema, outputDir string) (*Metadata, *domain.Changelog, error) {
-	existingMetadata, err := readMetadata(outputDir)
+// processMetadata compares the schema with the one of the previous run and, when record is set, stores it
+// together with the new changelog entry, whose changes are attributed to the commits of their services. The
+// previous schema of the options takes precedence over the one recorded in domain.json.
+func (g *Generator) processMetadata(
+	schema domain.Schema,
+	outputDir string,
+	record bool,
+	opts domain.GenerateOptions,
+) (*Metadata, *domain.Changelog, error) {
+	existingMetadata, err := readMetadata(g.fs, outputDir)
 	if err != nil {
 		return nil, nil, fmt.Errorf("error reading existing holydocs data: %w", err)
 	}
 
+	if !record {
+		metadata := Metadata{Schema: schema}
+		if existingMetadata != nil {
+			metadata.Changelogs = existingMetadata.Changelogs
+		}
+
+		return &metadata, nil, nil
+	}
+
 	var (
 		newChangelog       *domain.Changelog
 		existingChangelogs []domain.Changelog
 	)
 
 	if existingMetadata != nil {
-		changelog := existingMetadata.Schema.Compare(schema)
+		previous := existingMetadata.Schema
+		if opts.Previous != nil {
+			previous = *opts.Previous
+		}
+
+		changelog := previous.Compare(schema).Attribute(opts.Attribution)
 		if len(changelog.Changes) > 0 {
 			newChangelog = &changelog
 		}
@@ -264,7 +483,7 @@ func (g *Generator) processMetadata(schema domain.Schema, outputDir string) (*Me
 		return metadata.Changelogs[i].Date.After(metadata.Changelogs[j].Date)
 	})
 
-	if err := writeMetadata(outputDir, metadata); err != nil {
+	if err := writeMetadata(g.fs, outputDir, metadata); err != nil {
 		return nil, nil, fmt.Errorf("error writing holydocs data: %w", err)
 	}
 
@@ -284,27 +503,27 @@ type diagramResults struct {
 	MessageFlowView     messageFlowView
 }
 
-func setupOutputDirectories(outputDir string) (*outputDirectories, error) {
-	if err := os.MkdirAll(outputDir, dirPerm); err != nil {
+func setupOutputDirectories(fsys outputfs.FS, outputDir string) (*outputDirectories, error) {
+	if err := fsys.MkdirAll(outputDir, dirPerm); err != nil {
 		return nil, fmt.Errorf("%w: %w", ErrDirectoryCreationFailed, err)
 	}
 
 	diagramsDir := filepath.Join(outputDir, diagramsDirName)
-	if err := os.RemoveAll(diagramsDir); err != nil {
+	if err := fsys.RemoveAll(diagramsDir); err != nil {
 		return nil, fmt.Errorf("failed to clean diagrams directory: %w", err)
 	}
 
-	if err := os.MkdirAll(diagramsDir, dirPerm); err != nil {
+	if err := fsys.MkdirAll(diagramsDir, dirPerm); err != nil {
 		return nil, fmt.Errorf("%w diagrams directory: %w", ErrDirectoryCreationFailed, err)
 	}
 
 	serviceDiagramDir := filepath.Join(diagramsDir, servicesDiagramDirName)
-	if err := os.MkdirAll(serviceDiagramDir, dirPerm); err != nil {
+	if err := fsys.MkdirAll(serviceDiagramDir, dirPerm); err != nil {
 		return nil, fmt.Errorf("%w service diagrams directory: %w", ErrDirectoryCreationFailed, err)
 	}
 
 	messageflowDiagramDir := filepath.Join(diagramsDir, messageflowDiagramDirName)
-	if err := os.MkdirAll(messageflowDiagramDir, dirPerm); err != nil {
+	if err := fsys.MkdirAll(messageflowDiagramDir, dirPerm); err != nil {
 		return nil, fmt.Errorf("%w message flow diagrams directory: %w", ErrDirectoryCreationFailed, err)
 	}
 
@@ -317,6 +536,7 @@ func setupOutputDirectories(outputDir string) (*outputDirectories, error) {
 
 func generateAllDiagrams(
 	ctx context.Context,
+	fsys outputfs.FS,
 	schema domain.Schema,
 	asyncEdges []asyncEdge,
 	holydocsTarget domain.Target,
@@ -324,25 +544,31 @@ func generateAllDiagrams(
 	messageflowTarget mf.Target,
 	cfg *config.Config,
 	outputDirs *outputDirectories,
+	names fileNames,
+	recorder *diagramRecorder,
 ) (*diagramResults, error) {
 	overviewDiagramPath := filepath.Join(outputDirs.DiagramsDir, "overview.svg")
-	if err := generateOverviewDiagram(ctx, schema, asyncEdges, holydocsTarget, cfg.Output.GlobalName,
-		overviewDiagramPath, &cfg.Documentation); err != nil {
+	overviewSchema, overviewEdges := reduceOverview(schema, asyncEdges, cfg.Diagram)
+	err := generateOverviewDiagram(ctx, fsys, overviewSchema, overviewEdges, holydocsTarget,
+		cfg.Vocabulary.Term(cfg.Output.GlobalName), overviewDiagramPath, &cfg.Documentation, cfg.Diagram.Drawio, recorder)
+	if err := recorder.tolerate("overview diagram", overviewDiagramPath, err); err != nil {
 		return nil, fmt.Errorf("failed to generate overview diagram: %w", err)
 	}
 
-	serviceViews, err := buildServiceViews(ctx, schema, asyncEdges, holydocsTarget,
-		messageflowSchema, messageflowTarget, outputDirs.ServiceDiagramDir, &cfg.Documentation)
+	serviceViews, err := buildServiceViews(ctx, fsys, schema, asyncEdges, holydocsTarget,
+		messageflowSchema, messageflowTarget, outputDirs.ServiceDiagramDir, &cfg.Documentation, names, recorder)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build service views: %w", err)
 	}
 
-	systemDiagrams, err := generateSystemDiagrams(ctx, schema, asyncEdges, holydocsTarget, outputDirs.DiagramsDir)
+	systemDiagrams, err := generateSystemDiagrams(ctx, fsys, schema, asyncEdges, holydocsTarget,
+		outputDirs.DiagramsDir, names, cfg.Diagram.Drawio, recorder)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate system diagrams: %w", err)
 	}
 
-	mfv, err := generateMessageFlowSection(ctx, messageflowSchema, messageflowTarget, outputDirs.MessageflowDiagramDir)
+	mfv, err := generateMessageFlowSection(ctx, fsys, messageflowSchema, messageflowTarget,
+		outputDirs.MessageflowDiagramDir, names, recorder)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate message flow diagrams: %w", err)
 	}
@@ -359,6 +585,7 @@ func buildTemplateData(
 	cfg *config.Config,
 	diagramResults *diagramResults,
 	changelogs []domain.Changelog,
+	names fileNames,
 ) templateData {
 	overviewMarkdown := processMarkdown(cfg.Documentation.Overview.Description)
 
@@ -374,20 +601,42 @@ func buildTemplateData(
 		systemMarkdowns[systemName] = processMarkdown(systemDoc.Description)
 	}
 
+	recentChangelogs, olderChangelogs := splitChangelogs(changelogs, cfg.Documentation.Changelog, time.Now())
+
 	return templateData{
 		Title:           cfg.Output.Title,
 		OverviewDiagram: filepath.ToSlash(filepath.Join(diagramsDirName, filepath.Base(diagramResults.OverviewDiagramPath))),
 		OverviewD2: filepath.ToSlash(filepath.Join(diagramsDirName,
 			strings.TrimSuffix(filepath.Base(diagramResults.OverviewDiagramPath), ".svg")+".d2")),
 		OverviewMarkdown: overviewMarkdown,
-		Systems:          groupServicesBySystem(diagramResults.ServiceViews),
+		Systems:          groupServicesBySystem(diagramResults.ServiceViews, names),
 		SystemDiagrams:   diagramResults.SystemDiagrams,
 		SystemMarkdowns:  systemMarkdowns,
 		ServiceSummaries: serviceSummaries,
 		SystemSummaries:  systemSummaries,
 		MessageFlow:      diagramResults.MessageFlowView,
 		Changelogs:       changelogs,
+		RecentChangelogs: recentChangelogs,
+		OlderChangelogs:  olderChangelogs,
+	}
+}
+
+// splitChangelogs separates changelog entries (newest first) shown expanded from the ones
+// collapsed according to the max_entries and collapse_older_than settings.
+func splitChangelogs(changelogs []domain.Changelog, cfg config.ChangelogDocumentation,
+	now time.Time) ([]domain.Changelog, []domain.Changelog) {
+	age, err := cfg.CollapseAge()
+	if err != nil {
+		age = 0 // Validated when loading configuration
+	}
+
+	for i, changelog := range changelogs {
+		if (cfg.MaxEntries > 0 && i >= cfg.MaxEntries) || (age > 0 && changelog.Date.Before(now.Add(-age))) {
+			return changelogs[:i], changelogs[i:]
+		}
 	}
+
+	return changelogs, nil
 }
 
 func processMarkdown(markdown config.Markdown) string {
@@ -406,10 +655,14 @@ func processMarkdown(markdown config.Markdown) string {
 
 func generateSystemDiagrams(
 	ctx context.Context,
+	fsys outputfs.FS,
 	schema domain.Schema,
 	asyncEdges []asyncEdge,
 	target domain.Target,
 	diagramsDir string,
+	names fileNames,
+	drawio bool,
+	recorder *diagramRecorder,
 ) (map[string]systemDiagramView, error) {
 	d2Target, ok := target.(*d2target.Target)
 	if !ok {
@@ -432,29 +685,44 @@ func generateSystemDiagrams(
 		}
 
 		if len(script) == 0 {
+			recorder.skipped("system diagram of "+systemName, "no services to show")
+
 			continue
 		}
 
-		d2Filename := fmt.Sprintf("system-%s.d2", sanitizeFilename(systemName))
+		d2Filename := fmt.Sprintf("system-%s.d2", names.system(systemName))
 		d2Path := filepath.Join(diagramsDir, d2Filename)
-		if err := os.WriteFile(d2Path, script, filePerm); err != nil {
+		if err := fsys.WriteFile(d2Path, script, filePerm); err != nil {
 			return nil, fmt.Errorf("write system D2 script for %s: %w", systemName, err)
 		}
 
+		if drawio {
+			drawioPath := filepath.Join(diagramsDir, fmt.Sprintf("system-%s.drawio", names.system(systemName)))
+			err := writeDrawio(ctx, fsys, d2Target, script, systemName, drawioPath)
+			if err := recorder.tolerate("draw.io system diagram of "+systemName, "", err); err != nil {
+				return nil, err
+			}
+		}
+
+		svgFilename := fmt.Sprintf("system-%s.svg", names.system(systemName))
+		svgPath := filepath.Join(diagramsDir, svgFilename)
+
 		diagram, err := d2Target.GenerateSystemDiagram(ctx, schema, systemName, convertAsyncEdges(asyncEdges))
 		if err != nil {
-			return nil, fmt.Errorf("render system diagram for %s: %w", systemName, err)
+			err = fmt.Errorf("render system diagram for %s: %w", systemName, err)
+		} else if err = fsys.WriteFile(svgPath, diagram, filePerm); err != nil {
+			err = fmt.Errorf("write system diagram for %s: %w", systemName, err)
+		} else {
+			recorder.rendered()
 		}
 
-		svgFilename := fmt.Sprintf("system-%s.svg", sanitizeFilename(systemName))
-		svgPath := filepath.Join(diagramsDir, svgFilename)
-		if err := os.WriteFile(svgPath, diagram, filePerm); err != nil {
-			return nil, fmt.Errorf("write system diagram for %s: %w", systemName, err)
+		if err := recorder.tolerate("system diagram of "+systemName, svgPath, err); err != nil {
+			return nil, err
 		}
 
 		displayName := systemName
 		if displayName == "" {
-			displayName = "Standalone Services"
+			displayName = standaloneServicesName
 		}
 		systemDiagrams[displayName] = systemDiagramView{
 			SystemDiagram: filepath.ToSlash(filepath.Join(diagramsDirName, svgFilename)),
@@ -468,6 +736,7 @@ func generateSystemDiagrams(
 
 func buildServiceViews(
 	ctx context.Context,
+	fsys outputfs.FS,
 	schema domain.Schema,
 	asyncEdges []asyncEdge,
 	holydocsTarget domain.Target,
@@ -475,14 +744,17 @@ func buildServiceViews(
 	messageflowTarget mf.Target,
 	outputDir string,
 	documentation *DocumentationConfig,
+	names fileNames,
+	recorder *diagramRecorder,
 ) ([]serviceView, error) {
 	serviceNameSet := buildServiceNameSet(schema.Services)
 	edgesByService := buildEdgesByServiceMap(asyncEdges)
 
 	views := make([]serviceView, 0, len(schema.Services))
 	for _, service := range schema.Services {
-		view, err := buildServiceView(ctx, service, schema.Services, edgesByService,
-			holydocsTarget, messageflowSchema, messageflowTarget, serviceNameSet, outputDir, documentation)
+		view, err := buildServiceView(ctx, fsys, service, schema.Services, edgesByService,
+			holydocsTarget, messageflowSchema, messageflowTarget, serviceNameSet, outputDir, documentation,
+			names.service(service.Info.Name), recorder)
 		if err != nil {
 			return nil, err
 		}
@@ -521,6 +793,7 @@ func buildEdgesByServiceMap(asyncEdges []asyncEdge) map[string][]asyncEdge {
 
 func buildServiceView(
 	ctx context.Context,
+	fsys outputfs.FS,
 	service domain.Service,
 	allServices []domain.Service,
 	edgesByService map[string][]asyncEdge,
@@ -530,18 +803,19 @@ func buildServiceView(
 	serviceNameSet map[string]struct{},
 	outputDir string,
 	documentation *DocumentationConfig,
+	filenameBase string,
+	recorder *diagramRecorder,
 ) (serviceView, error) {
-	filenameBase := sanitizeFilename(service.Info.Name)
-
 	relationshipDiagram := filepath.Join(outputDir, filenameBase+"-relationships.svg")
-	if err := generateServiceRelationshipsDiagram(ctx, service, allServices,
-		edgesByService[service.Info.Name], holydocsTarget, relationshipDiagram); err != nil {
+	err := generateServiceRelationshipsDiagram(ctx, fsys, service, allServices,
+		edgesByService[service.Info.Name], holydocsTarget, relationshipDiagram, recorder)
+	if err := recorder.tolerate("relationships diagram of "+service.Info.Name, relationshipDiagram, err); err != nil {
 		return serviceView{}, err
 	}
 
 	asyncSummaries := buildAsyncSummaries(service.Info.Name, edgesByService, holydocsTarget, serviceNameSet)
-	serviceFlowDiagram := buildServiceFlowDiagram(ctx, service, messageflowSchema,
-		messageflowTarget, outputDir, filenameBase)
+	serviceFlowDiagram := buildServiceFlowDiagram(ctx, fsys, service, messageflowSchema,
+		messageflowTarget, outputDir, filenameBase, recorder)
 
 	tags := append([]string(nil), service.Info.Tags...)
 	sort.Strings(tags)
@@ -560,14 +834,37 @@ func buildServiceView(
 		}
 	}
 
+	var hosts map[string]config.RepositoryLinks
+	if documentation != nil {
+		hosts = documentation.Repositories
+	}
+
+	repoPages, err := repositoryLinks(service.Info, hosts)
+	if err != nil {
+		return serviceView{}, err
+	}
+
+	repositoryURL := service.Info.Repository
+	if repoPages.TreeURL != "" {
+		repositoryURL = repoPages.TreeURL
+	}
+
 	return serviceView{
-		Name:        service.Info.Name,
-		Anchor:      sanitizeAnchor(service.Info.Name),
-		System:      service.Info.System,
-		Description: d2target.FormatDescription(strings.TrimSpace(description)),
-		Owner:       service.Info.Owner,
-		Repository:  service.Info.Repository,
-		Tags:        tags,
+		Name:               service.Info.Name,
+		Anchor:             sanitizeAnchor(service.Info.Name),
+		System:             service.Info.System,
+		BusinessCapability: strings.TrimSpace(service.Info.BusinessCapability),
+		Description:        d2target.FormatDescription(strings.TrimSpace(description)),
+		Owner:              service.Info.Owner,
+		Repository:         service.Info.Repository,
+		RepositoryURL:      repositoryURL,
+		Subpath:            service.Info.Subpath,
+		RepositoryLinks:    repoPages.Links,
+		OwnerURL:           repoPages.OwnerURL,
+		Tags:               tags,
+		Planned:            service.Info.Planned,
+		Deprecated:         service.Info.Deprecated,
+		SunsetDate:         service.Info.SunsetDate,
 		RelationshipsDiagram: filepath.ToSlash(filepath.Join(diagramsDirName,
 			servicesDiagramDirName, filepath.Base(relationshipDiagram))),
 		RelationshipsD2: filepath.ToSlash(filepath.Join(diagramsDirName,
@@ -576,6 +873,10 @@ func buildServiceView(
 		InterServiceLinks:     buildServiceConnections(service.Info.Name, edgesByService[service.Info.Name]),
 		AsyncSummaries:        asyncSummaries,
 		ServiceFlowDiagram:    serviceFlowDiagram,
+		Endpoints:             buildEndpointViews(sanitizeAnchor(service.Info.Name), service.Endpoints),
+		Limits:                buildLimitViews(service),
+		Deployments:           buildDeploymentViews(service),
+		FileName:              filenameBase,
 	}, nil
 }
 
@@ -618,30 +919,47 @@ func buildAsyncSummaries(serviceName string, edgesByService map[string][]asyncEd
 
 func buildServiceFlowDiagram(
 	ctx context.Context,
+	fsys outputfs.FS,
 	service domain.Service,
 	messageflowSchema mf.Schema,
 	messageflowTarget mf.Target,
 	outputDir,
 	filenameBase string,
+	recorder *diagramRecorder,
 ) string {
 	if messageflowTarget == nil || len(messageflowSchema.Services) == 0 {
 		return ""
 	}
 
+	diagram := "message flow diagram of " + service.Info.Name
+
 	servicesDiagramPath := filepath.Join(outputDir, filenameBase+"-service-services.svg")
-	err := generateMessageFlowDiagram(ctx, messageflowSchema, messageflowTarget, mf.FormatOptions{
+	err := generateMessageFlowDiagram(ctx, fsys, messageflowSchema, messageflowTarget, mf.FormatOptions{
 		Mode:    mf.FormatModeServiceServices,
 		Service: service.Info.Name,
 	}, servicesDiagramPath)
 	if err == nil {
+		recorder.rendered()
+
 		return filepath.ToSlash(filepath.Join(diagramsDirName,
 			servicesDiagramDirName, filepath.Base(servicesDiagramPath)))
 	}
 	if !errors.Is(err, errNoDiagramData) {
-		// Log error but don't fail the entire process
+		// Don't fail the entire process, the diagram is left out and reported as a warning
+		recorder.failed(diagram, err)
+
+		return ""
+	}
+
+	// Services without operations have no message flow, a service declaring some should have one.
+	if len(service.Operation) > 0 {
+		recorder.missing(diagram, "no message flow")
+
 		return ""
 	}
 
+	recorder.skipped(diagram, "no async operations")
+
 	return ""
 }
 
@@ -657,13 +975,22 @@ func buildRelationshipSummaries(rels []domain.Relationship) []relationshipSummar
 			Action:      rel.Action,
 			Technology:  rel.Technology,
 			Description: rel.Description,
+			Notes:       strings.TrimSpace(rel.Notes),
 			Proto:       rel.Proto,
 			External:    rel.External,
 			Person:      rel.Person,
+			Planned:     rel.Planned,
+			Criticality: rel.Criticality,
+			Access:      rel.Access,
 		})
 	}
 
+	// The dependencies that matter most come first.
 	sort.SliceStable(summaries, func(i, j int) bool {
+		if weightI, weightJ := summaries[i].Criticality.Weight(), summaries[j].Criticality.Weight(); weightI != weightJ {
+			return weightI > weightJ
+		}
+
 		if summaries[i].Action != summaries[j].Action {
 			return summaries[i].Action < summaries[j].Action
 		}
@@ -674,6 +1001,44 @@ func buildRelationshipSummaries(rels []domain.Relationship) []relationshipSummar
 	return summaries
 }
 
+// buildPlannedChanges collects services and relationships that are planned but not built yet.
+// Relationships of planned services are not listed separately, they are implied by the service.
+func buildPlannedChanges(schema domain.Schema) plannedChangesView {
+	var view plannedChangesView
+
+	for _, service := range schema.Services {
+		if service.Info.Planned {
+			view.Services = append(view.Services, service.Info.Name)
+
+			continue
+		}
+
+		for _, rel := range service.Relationships {
+			if !rel.Planned {
+				continue
+			}
+
+			view.Relationships = append(view.Relationships, plannedRelationshipView{
+				Service:     service.Info.Name,
+				Action:      rel.Action,
+				Participant: rel.Participant,
+				Description: strings.TrimSpace(rel.Description),
+			})
+		}
+	}
+
+	sort.Strings(view.Services)
+	sort.SliceStable(view.Relationships, func(i, j int) bool {
+		if view.Relationships[i].Service != view.Relationships[j].Service {
+			return view.Relationships[i].Service < view.Relationships[j].Service
+		}
+
+		return view.Relationships[i].Participant < view.Relationships[j].Participant
+	})
+
+	return view
+}
+
 func buildServiceConnections(serviceName string, edges []asyncEdge) []serviceConnection {
 	if len(edges) == 0 {
 		return nil
@@ -842,11 +1207,14 @@ func buildSystemDescription(systemName string, _ []domain.Service, documentation
 
 func generateOverviewDiagram(
 	ctx context.Context,
+	fsys outputfs.FS,
 	schema domain.Schema,
 	asyncEdges []asyncEdge,
 	target domain.Target,
 	globalName, outputPath string,
 	documentation *DocumentationConfig,
+	drawio bool,
+	recorder *diagramRecorder,
 ) error {
 	d2Target, ok := target.(*d2target.Target)
 	if !ok {
@@ -862,15 +1230,25 @@ func generateOverviewDiagram(
 	}
 
 	if len(script) == 0 {
+		recorder.skipped("overview diagram", "no services to show")
+
 		return nil
 	}
 
 	// Save raw D2 script
 	d2Path := strings.TrimSuffix(outputPath, ".svg") + ".d2"
-	if err := os.WriteFile(d2Path, script, filePerm); err != nil {
+	if err := fsys.WriteFile(d2Path, script, filePerm); err != nil {
 		return fmt.Errorf("write overview D2 script: %w", err)
 	}
 
+	if drawio {
+		drawioPath := strings.TrimSuffix(outputPath, ".svg") + ".drawio"
+		err := writeDrawio(ctx, fsys, d2Target, script, globalName, drawioPath)
+		if err := recorder.tolerate("draw.io overview diagram", "", err); err != nil {
+			return err
+		}
+	}
+
 	formatted := domain.FormattedSchema{
 		Type: "d2",
 		Data: script,
@@ -880,20 +1258,43 @@ func generateOverviewDiagram(
 		return fmt.Errorf("render overview diagram: %w", err)
 	}
 
-	if err := os.WriteFile(outputPath, diagram, filePerm); err != nil {
+	if err := fsys.WriteFile(outputPath, diagram, filePerm); err != nil {
 		return fmt.Errorf("write overview diagram: %w", err)
 	}
+	recorder.rendered()
+
+	return nil
+}
+
+// writeDrawio writes the editable draw.io counterpart of a D2 diagram.
+func writeDrawio(
+	ctx context.Context,
+	fsys outputfs.FS,
+	target *d2target.Target,
+	script []byte,
+	name, path string,
+) error {
+	diagram, err := target.RenderDrawio(ctx, script, name)
+	if err != nil {
+		return fmt.Errorf("render draw.io diagram %s: %w", name, err)
+	}
+
+	if err := fsys.WriteFile(path, diagram, filePerm); err != nil {
+		return fmt.Errorf("write draw.io diagram %s: %w", name, err)
+	}
 
 	return nil
 }
 
 func generateServiceRelationshipsDiagram(
 	ctx context.Context,
+	fsys outputfs.FS,
 	service domain.Service,
 	allServices []domain.Service,
 	serviceEdges []asyncEdge,
 	target domain.Target,
 	outputPath string,
+	recorder *diagramRecorder,
 ) error {
 	d2Target, ok := target.(*d2target.Target)
 	if !ok {
@@ -907,11 +1308,13 @@ func generateServiceRelationshipsDiagram(
 	}
 
 	if len(script) == 0 {
+		recorder.skipped("relationships diagram of "+service.Info.Name, "no relationships")
+
 		return nil
 	}
 
 	d2Path := strings.TrimSuffix(outputPath, ".svg") + ".d2"
-	if err := os.WriteFile(d2Path, script, filePerm); err != nil {
+	if err := fsys.WriteFile(d2Path, script, filePerm); err != nil {
 		return fmt.Errorf("write service relationships D2 script: %w", err)
 	}
 
@@ -921,9 +1324,10 @@ func generateServiceRelationshipsDiagram(
 		return fmt.Errorf("render service relationships diagram: %w", err)
 	}
 
-	if err := os.WriteFile(outputPath, diagram, filePerm); err != nil {
+	if err := fsys.WriteFile(outputPath, diagram, filePerm); err != nil {
 		return fmt.Errorf("write service relationships diagram: %w", err)
 	}
+	recorder.rendered()
 
 	return nil
 }
@@ -932,6 +1336,7 @@ var errNoDiagramData = errors.New("no diagram data")
 
 func generateMessageFlowDiagram(
 	ctx context.Context,
+	fsys outputfs.FS,
 	schema mf.Schema,
 	target mf.Target,
 	opts mf.FormatOptions,
@@ -941,6 +1346,10 @@ func generateMessageFlowDiagram(
 		return errNoDiagramData
 	}
 
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	formatted, err := target.FormatSchema(ctx, schema, opts)
 	if err != nil {
 		return fmt.Errorf("format schema: %w", err)
@@ -959,7 +1368,7 @@ func generateMessageFlowDiagram(
 		return errNoDiagramData
 	}
 
-	if err := os.WriteFile(outputPath, diagram, filePerm); err != nil {
+	if err := fsys.WriteFile(outputPath, diagram, filePerm); err != nil {
 		return fmt.Errorf("write diagram: %w", err)
 	}
 
@@ -968,9 +1377,12 @@ func generateMessageFlowDiagram(
 
 func generateMessageFlowSection(
 	ctx context.Context,
+	fsys outputfs.FS,
 	schema mf.Schema,
 	target mf.Target,
 	outputDir string,
+	names fileNames,
+	recorder *diagramRecorder,
 ) (messageFlowView, error) {
 	result := messageFlowView{}
 
@@ -979,16 +1391,26 @@ func generateMessageFlowSection(
 	}
 
 	contextDiagram := filepath.Join(outputDir, "context.svg")
-	if err := generateMessageFlowDiagram(ctx, schema, target,
-		mf.FormatOptions{Mode: mf.FormatModeContextServices}, contextDiagram); err != nil {
-		if errors.Is(err, errNoDiagramData) {
-			return result, nil
-		}
+	err := generateMessageFlowDiagram(ctx, fsys, schema, target,
+		mf.FormatOptions{Mode: mf.FormatModeContextServices}, contextDiagram)
+	switch {
+	case err == nil:
+		recorder.rendered()
+	case errors.Is(err, errNoDiagramData) && len(extractUniqueChannels(schema)) > 0:
+		recorder.missing("message flow context diagram", "no message flow")
 
-		return result, err
+		return result, nil
+	case errors.Is(err, errNoDiagramData):
+		recorder.skipped("message flow context diagram", "no async operations")
+
+		return result, nil
+	default:
+		if err := recorder.tolerate("message flow context diagram", contextDiagram, err); err != nil {
+			return result, err
+		}
 	}
 
-	channelViews, err := generateChannelViews(ctx, schema, target, outputDir)
+	channelViews, err := generateChannelViews(ctx, fsys, schema, target, outputDir, names, recorder)
 	if err != nil {
 		return result, err
 	}
@@ -1011,28 +1433,36 @@ func generateMessageFlowSection(
 
 func generateChannelViews(
 	ctx context.Context,
+	fsys outputfs.FS,
 	schema mf.Schema,
 	target mf.Target,
 	outputDir string,
+	names fileNames,
+	recorder *diagramRecorder,
 ) ([]channelView, error) {
 	channels := extractUniqueChannels(schema)
 	channelInfo := extractChannelInfo(schema)
 	channelViews := make([]channelView, 0, len(channels))
 
 	for _, channel := range channels {
-		filename := fmt.Sprintf("channel-%s.svg", sanitizeFilename(channel))
+		filename := fmt.Sprintf("channel-%s.svg", names.channel(channel))
 		path := filepath.Join(outputDir, filename)
-		err := generateMessageFlowDiagram(ctx, schema, target, mf.FormatOptions{
+		err := generateMessageFlowDiagram(ctx, fsys, schema, target, mf.FormatOptions{
 			Mode:         mf.FormatModeChannelServices,
 			Channel:      channel,
 			OmitPayloads: true,
 		}, path)
-		if err != nil {
-			if errors.Is(err, errNoDiagramData) {
-				continue
-			}
+		switch {
+		case err == nil:
+			recorder.rendered()
+		case errors.Is(err, errNoDiagramData):
+			recorder.missing("channel diagram of "+channel, "no message flow")
 
-			return nil, fmt.Errorf("channel diagram for %s: %w", channel, err)
+			continue
+		default:
+			if err := recorder.tolerate("channel diagram of "+channel, path, err); err != nil {
+				return nil, fmt.Errorf("channel diagram for %s: %w", channel, err)
+			}
 		}
 
 		channelViews = append(channelViews, channelView{
@@ -1040,6 +1470,7 @@ func generateChannelViews(
 			Anchor:      sanitizeAnchor(channel),
 			DiagramPath: filepath.ToSlash(filepath.Join(diagramsDirName, messageflowDiagramDirName, filename)),
 			Messages:    channelInfo[channel],
+			FileName:    names.channel(channel),
 		})
 	}
 
@@ -1180,7 +1611,7 @@ func sortAsyncEdges(edges []asyncEdge) {
 	})
 }
 
-func groupServicesBySystem(services []serviceView) []systemView {
+func groupServicesBySystem(services []serviceView, names fileNames) []systemView {
 	systems := make(map[string][]serviceView)
 	order := make([]string, 0)
 
@@ -1213,12 +1644,13 @@ func groupServicesBySystem(services []serviceView) []systemView {
 
 		displayName := system
 		if displayName == "" {
-			displayName = "Standalone Services"
+			displayName = standaloneServicesName
 		}
 
 		result = append(result, systemView{
 			Name:     displayName,
 			Anchor:   sanitizeAnchor(displayName),
+			FileName: names.system(displayName),
 			Services: servicesInSystem,
 		})
 	}
@@ -1227,39 +1659,20 @@ func groupServicesBySystem(services []serviceView) []systemView {
 }
 
 func sanitizeAnchor(name string) string {
-	anchor := strings.ToLower(strings.TrimSpace(name))
-	anchor = strings.ReplaceAll(anchor, " ", "-")
-	anchor = strings.ReplaceAll(anchor, "_", "-")
-
-	var builder strings.Builder
-	for _, r := range anchor {
-		if unicode.IsLetter(r) || unicode.IsNumber(r) || r == '-' {
-			builder.WriteRune(r)
-		}
-	}
-
-	result := builder.String()
-	result = strings.ReplaceAll(result, "--", "-")
-	result = strings.Trim(result, "-")
-
-	return result
+	return slug.Make(name)
 }
 
 func sanitizeFilename(name string) string {
-	anchor := sanitizeAnchor(name)
-	if anchor == "" {
-		return "item"
+	if filename := slug.Filename(name); filename != "" {
+		return filename
 	}
 
-	return anchor
+	return "item"
 }
 
-func writeReadme(outputDir string, data templateData) error {
-	tmpl, err := template.New("readme.tmpl").Funcs(template.FuncMap{
-		"Anchor": sanitizeAnchor,
-		"Join":   strings.Join,
-		"lower":  strings.ToLower,
-	}).ParseFS(readmeTemplateFS, "templates/md_single_page/readme.tmpl")
+func writeReadme(pages site, data templateData) error {
+	tmpl, err := template.New("readme.tmpl").Funcs(pages.templateFuncs()).
+		ParseFS(readmeTemplateFS, "templates/md_single_page/readme.tmpl")
 	if err != nil {
 		return fmt.Errorf("parse template: %w", err)
 	}
@@ -1269,8 +1682,8 @@ func writeReadme(outputDir string, data templateData) error {
 		return fmt.Errorf("execute template: %w", err)
 	}
 
-	readmePath := filepath.Join(outputDir, "README.md")
-	if err := os.WriteFile(readmePath, []byte(buf.String()), filePerm); err != nil {
+	readmePath := filepath.Join(pages.contentDir, overviewFileName)
+	if err := pages.writePage(readmePath, pageMeta{Title: data.Title}, buf.String()); err != nil {
 		return fmt.Errorf("write README: %w", err)
 	}
 
@@ -1278,74 +1691,109 @@ func writeReadme(outputDir string, data templateData) error {
 }
 
 // writeMultiPageDocs generates multi-page documentation structure.
-func writeMultiPageDocs(outputDir string, data templateData) error {
+func writeMultiPageDocs(pages site, data templateData) error {
+	outputDir := pages.contentDir
+
 	// Create directory structure
 	systemsDir := filepath.Join(outputDir, "systems")
-	if err := os.MkdirAll(systemsDir, dirPerm); err != nil {
+	if err := pages.fs.MkdirAll(systemsDir, dirPerm); err != nil {
 		return fmt.Errorf("create systems directory: %w", err)
 	}
 
 	servicesDir := filepath.Join(outputDir, "services")
-	if err := os.MkdirAll(servicesDir, dirPerm); err != nil {
+	if err := pages.fs.MkdirAll(servicesDir, dirPerm); err != nil {
 		return fmt.Errorf("create services directory: %w", err)
 	}
 
 	messageflowDir := filepath.Join(outputDir, "messageflow")
-	if err := os.MkdirAll(messageflowDir, dirPerm); err != nil {
+	if err := pages.fs.MkdirAll(messageflowDir, dirPerm); err != nil {
 		return fmt.Errorf("create messageflow directory: %w", err)
 	}
 
 	channelsDir := filepath.Join(messageflowDir, "channels")
-	if err := os.MkdirAll(channelsDir, dirPerm); err != nil {
+	if err := pages.fs.MkdirAll(channelsDir, dirPerm); err != nil {
 		return fmt.Errorf("create channels directory: %w", err)
 	}
 
 	// Update data with file paths for navigation
 	data = enrichTemplateDataForMultiPage(data, outputDir)
+	pages.weights = navWeights(pages.nav(data))
 
 	// Write overview page (README.md)
-	if err := writeOverviewPage(outputDir, data); err != nil {
+	if err := writeOverviewPage(pages, data); err != nil {
 		return fmt.Errorf("write overview page: %w", err)
 	}
 
 	// Write system pages
 	for _, system := range data.Systems {
-		if err := writeSystemPage(systemsDir, system, data); err != nil {
+		if err := writeSystemPage(pages, systemsDir, system, data); err != nil {
 			return fmt.Errorf("write system page for %s: %w", system.Name, err)
 		}
 	}
 
 	// Write service pages
-	if err := writeServicePages(servicesDir, data); err != nil {
+	if err := writeServicePages(pages, servicesDir, data); err != nil {
 		return fmt.Errorf("write service pages: %w", err)
 	}
 
 	// Write messageflow context page
 	if data.MessageFlow.HasData {
-		if err := writeMessageFlowContextPage(messageflowDir, data); err != nil {
+		if err := writeMessageFlowContextPage(pages, messageflowDir, data); err != nil {
 			return fmt.Errorf("write messageflow context page: %w", err)
 		}
 
 		// Write channel pages
 		for _, channel := range data.MessageFlow.Channels {
-			if err := writeChannelPage(channelsDir, channel); err != nil {
+			if err := writeChannelPage(pages, channelsDir, channel); err != nil {
 				return fmt.Errorf("write channel page for %s: %w", channel.Name, err)
 			}
 		}
 	}
 
+	// Write event catalog page
+	if data.EventCatalog.HasData() {
+		if err := writeEventCatalogPage(pages, outputDir, data); err != nil {
+			return fmt.Errorf("write event catalog page: %w", err)
+		}
+	}
+
+	// Write datastore schemas page
+	if len(data.DatastoreSchemas) > 0 {
+		if err := writeDatastoreSchemasPage(pages, outputDir, data); err != nil {
+			return fmt.Errorf("write datastore schemas page: %w", err)
+		}
+	}
+
+	// Write personas page
+	if len(data.Personas) > 0 {
+		if err := writePersonasPage(pages, outputDir, data); err != nil {
+			return fmt.Errorf("write personas page: %w", err)
+		}
+	}
+
+	// Write business capabilities page
+	if data.Capabilities.HasData() {
+		if err := writeCapabilitiesPage(pages, outputDir, data); err != nil {
+			return fmt.Errorf("write capabilities page: %w", err)
+		}
+	}
+
 	// Write changelog page
 	if len(data.Changelogs) > 0 {
-		if err := writeChangelogPage(outputDir, data); err != nil {
+		if err := writeChangelogPage(pages, outputDir, data); err != nil {
 			return fmt.Errorf("write changelog page: %w", err)
 		}
 	}
 
+	if err := pages.writeConfig(data); err != nil {
+		return fmt.Errorf("write site configuration: %w", err)
+	}
+
 	return nil
 }
 
 // writeServicePages generates all service pages.
-func writeServicePages(servicesDir string, data templateData) error {
+func writeServicePages(pages site, servicesDir string, data templateData) error {
 	channels := []channelView{}
 	if data.MessageFlow.HasData {
 		channels = data.MessageFlow.Channels
@@ -1353,7 +1801,7 @@ func writeServicePages(servicesDir string, data templateData) error {
 
 	for _, system := range data.Systems {
 		for _, service := range system.Services {
-			if err := writeServicePage(servicesDir, service, channels); err != nil {
+			if err := writeServicePage(pages, servicesDir, service, channels); err != nil {
 				return fmt.Errorf("write service page for %s: %w", service.Name, err)
 			}
 		}
@@ -1366,7 +1814,7 @@ func writeServicePages(servicesDir string, data templateData) error {
 func enrichTemplateDataForMultiPage(data templateData, _ string) templateData {
 	// Add file paths to systems
 	for i := range data.Systems {
-		systemFilename := sanitizeFilename(data.Systems[i].Name) + ".md"
+		systemFilename := data.Systems[i].FileName + ".md"
 		data.Systems[i].FilePath = filepath.ToSlash(
 			filepath.Join("systems", systemFilename))
 	}
@@ -1374,13 +1822,17 @@ func enrichTemplateDataForMultiPage(data templateData, _ string) templateData {
 	// Add file paths to services and adjust diagram paths
 	for i := range data.Systems {
 		for j := range data.Systems[i].Services {
-			serviceFilename := sanitizeFilename(data.Systems[i].Services[j].Name) + ".md"
+			serviceFilename := data.Systems[i].Services[j].FileName + ".md"
 			data.Systems[i].Services[j].FilePath = filepath.ToSlash(
 				filepath.Join("services", serviceFilename))
 			// Update diagram paths to be relative from service file location (services/ -> ../)
 			relDiagram := data.Systems[i].Services[j].RelationshipsDiagram
 			data.Systems[i].Services[j].RelationshipsDiagram = filepath.ToSlash(
 				filepath.Join("..", relDiagram))
+			if data.Systems[i].Services[j].LastUpdatedBadge != "" {
+				badge := data.Systems[i].Services[j].LastUpdatedBadge
+				data.Systems[i].Services[j].LastUpdatedBadge = filepath.ToSlash(filepath.Join("..", badge))
+			}
 			if data.Systems[i].Services[j].ServiceFlowDiagram != "" {
 				flowDiagram := data.Systems[i].Services[j].ServiceFlowDiagram
 				data.Systems[i].Services[j].ServiceFlowDiagram = filepath.ToSlash(
@@ -1396,7 +1848,7 @@ func enrichTemplateDataForMultiPage(data templateData, _ string) templateData {
 		data.MessageFlow.ContextDiagram = filepath.ToSlash(
 			filepath.Join("..", data.MessageFlow.ContextDiagram))
 		for i := range data.MessageFlow.Channels {
-			channelFilename := sanitizeFilename(data.MessageFlow.Channels[i].Name) + ".md"
+			channelFilename := data.MessageFlow.Channels[i].FileName + ".md"
 			// Set path relative to overview page (messageflow/channels/{filename}.md)
 			data.MessageFlow.Channels[i].FilePath = filepath.ToSlash(
 				filepath.Join("messageflow", "channels", channelFilename))
@@ -1413,16 +1865,13 @@ func enrichTemplateDataForMultiPage(data templateData, _ string) templateData {
 		data.ChangelogPath = "changelog.md"
 	}
 
-	return data
+	return linkCapabilities(linkPersonas(linkDatastoreSchemas(linkEventCatalog(data, true), true), true), true)
 }
 
 // writeOverviewPage generates the main overview page (README.md) for multi-page mode.
-func writeOverviewPage(outputDir string, data templateData) error {
-	tmpl, err := template.New("overview.tmpl").Funcs(template.FuncMap{
-		"Anchor": sanitizeAnchor,
-		"Join":   strings.Join,
-		"lower":  strings.ToLower,
-	}).ParseFS(multiPageTemplateFS, "templates/md_multi_page/overview.tmpl")
+func writeOverviewPage(pages site, data templateData) error {
+	tmpl, err := template.New("overview.tmpl").Funcs(pages.templateFuncs()).
+		ParseFS(multiPageTemplateFS, "templates/md_multi_page/overview.tmpl")
 	if err != nil {
 		return fmt.Errorf("parse overview template: %w", err)
 	}
@@ -1432,8 +1881,8 @@ func writeOverviewPage(outputDir string, data templateData) error {
 		return fmt.Errorf("execute overview template: %w", err)
 	}
 
-	readmePath := filepath.Join(outputDir, "README.md")
-	if err := os.WriteFile(readmePath, []byte(buf.String()), filePerm); err != nil {
+	readmePath := filepath.Join(pages.contentDir, pages.overviewFile())
+	if err := pages.writePage(readmePath, pageMeta{Title: data.Title}, buf.String()); err != nil {
 		return fmt.Errorf("write overview page: %w", err)
 	}
 
@@ -1453,12 +1902,9 @@ type servicePageData struct {
 }
 
 // writeSystemPage generates an individual system page.
-func writeSystemPage(systemsDir string, system systemView, data templateData) error {
-	tmpl, err := template.New("system.tmpl").Funcs(template.FuncMap{
-		"Anchor": sanitizeAnchor,
-		"Join":   strings.Join,
-		"lower":  strings.ToLower,
-	}).ParseFS(multiPageTemplateFS, "templates/md_multi_page/system.tmpl")
+func writeSystemPage(pages site, systemsDir string, system systemView, data templateData) error {
+	tmpl, err := template.New("system.tmpl").Funcs(pages.templateFuncs()).
+		ParseFS(multiPageTemplateFS, "templates/md_multi_page/system.tmpl")
 	if err != nil {
 		return fmt.Errorf("parse system template: %w", err)
 	}
@@ -1489,9 +1935,10 @@ func writeSystemPage(systemsDir string, system systemView, data templateData) er
 		return fmt.Errorf("execute system template: %w", err)
 	}
 
-	systemFilename := sanitizeFilename(system.Name) + ".md"
+	systemFilename := system.FileName + ".md"
 	systemPath := filepath.Join(systemsDir, systemFilename)
-	if err := os.WriteFile(systemPath, []byte(buf.String()), filePerm); err != nil {
+	meta := pageMeta{Title: system.Name, Tags: systemTags(system), System: system.Name}
+	if err := pages.writePage(systemPath, meta, buf.String()); err != nil {
 		return fmt.Errorf("write system page: %w", err)
 	}
 
@@ -1506,12 +1953,9 @@ type systemPageData struct {
 }
 
 // writeServicePage generates an individual service page.
-func writeServicePage(servicesDir string, service serviceView, messageFlowChannels []channelView) error {
-	tmpl, err := template.New("service.tmpl").Funcs(template.FuncMap{
-		"Anchor": sanitizeAnchor,
-		"Join":   strings.Join,
-		"lower":  strings.ToLower,
-	}).ParseFS(multiPageTemplateFS, "templates/md_multi_page/service.tmpl")
+func writeServicePage(pages site, servicesDir string, service serviceView, messageFlowChannels []channelView) error {
+	tmpl, err := template.New("service.tmpl").Funcs(pages.templateFuncs()).
+		ParseFS(multiPageTemplateFS, "templates/md_multi_page/service.tmpl")
 	if err != nil {
 		return fmt.Errorf("parse service template: %w", err)
 	}
@@ -1536,7 +1980,7 @@ func writeServicePage(servicesDir string, service serviceView, messageFlowChanne
 			// Path relative to service file location (services/ -> ../messageflow/channels/{filename}.md)
 			channelLinks = append(channelLinks, channelLink{
 				Name: channelName,
-				Path: filepath.ToSlash(filepath.Join("..", "messageflow", "channels", sanitizeFilename(ch.Name)+".md")),
+				Path: filepath.ToSlash(filepath.Join("..", "messageflow", "channels", ch.FileName+".md")),
 			})
 		}
 	}
@@ -1556,9 +2000,10 @@ func writeServicePage(servicesDir string, service serviceView, messageFlowChanne
 		return fmt.Errorf("execute service template: %w", err)
 	}
 
-	serviceFilename := sanitizeFilename(service.Name) + ".md"
+	serviceFilename := service.FileName + ".md"
 	servicePath := filepath.Join(servicesDir, serviceFilename)
-	if err := os.WriteFile(servicePath, []byte(buf.String()), filePerm); err != nil {
+	meta := pageMeta{Title: service.Name, Tags: service.Tags, Owner: service.Owner, System: service.System}
+	if err := pages.writePage(servicePath, meta, buf.String()); err != nil {
 		return fmt.Errorf("write service page: %w", err)
 	}
 
@@ -1572,12 +2017,9 @@ type messageFlowContextPageData struct {
 }
 
 // writeMessageFlowContextPage generates the messageflow context page.
-func writeMessageFlowContextPage(messageflowDir string, data templateData) error {
-	tmpl, err := template.New("messageflow-context.tmpl").Funcs(template.FuncMap{
-		"Anchor": sanitizeAnchor,
-		"Join":   strings.Join,
-		"lower":  strings.ToLower,
-	}).ParseFS(multiPageTemplateFS, "templates/md_multi_page/messageflow-context.tmpl")
+func writeMessageFlowContextPage(pages site, messageflowDir string, data templateData) error {
+	tmpl, err := template.New("messageflow-context.tmpl").Funcs(pages.templateFuncs()).
+		ParseFS(multiPageTemplateFS, "templates/md_multi_page/messageflow-context.tmpl")
 	if err != nil {
 		return fmt.Errorf("parse messageflow context template: %w", err)
 	}
@@ -1604,7 +2046,7 @@ func writeMessageFlowContextPage(messageflowDir string, data templateData) error
 	}
 
 	contextPath := filepath.Join(messageflowDir, "context.md")
-	if err := os.WriteFile(contextPath, []byte(buf.String()), filePerm); err != nil {
+	if err := pages.writePage(contextPath, pageMeta{Title: "Message Flow"}, buf.String()); err != nil {
 		return fmt.Errorf("write messageflow context page: %w", err)
 	}
 
@@ -1617,12 +2059,9 @@ type channelPageData struct {
 }
 
 // writeChannelPage generates an individual channel page.
-func writeChannelPage(channelsDir string, channel channelView) error {
-	tmpl, err := template.New("channel.tmpl").Funcs(template.FuncMap{
-		"Anchor": sanitizeAnchor,
-		"Join":   strings.Join,
-		"lower":  strings.ToLower,
-	}).ParseFS(multiPageTemplateFS, "templates/md_multi_page/channel.tmpl")
+func writeChannelPage(pages site, channelsDir string, channel channelView) error {
+	tmpl, err := template.New("channel.tmpl").Funcs(pages.templateFuncs()).
+		ParseFS(multiPageTemplateFS, "templates/md_multi_page/channel.tmpl")
 	if err != nil {
 		return fmt.Errorf("parse channel template: %w", err)
 	}
@@ -1636,9 +2075,9 @@ func writeChannelPage(channelsDir string, channel channelView) error {
 		return fmt.Errorf("execute channel template: %w", err)
 	}
 
-	channelFilename := sanitizeFilename(channel.Name) + ".md"
+	channelFilename := channel.FileName + ".md"
 	channelPath := filepath.Join(channelsDir, channelFilename)
-	if err := os.WriteFile(channelPath, []byte(buf.String()), filePerm); err != nil {
+	if err := pages.writePage(channelPath, pageMeta{Title: channel.Name}, buf.String()); err != nil {
 		return fmt.Errorf("write channel page: %w", err)
 	}
 
@@ -1647,22 +2086,21 @@ func writeChannelPage(channelsDir string, channel channelView) error {
 
 // changelogPageData represents data for the changelog page.
 type changelogPageData struct {
-	Changelogs []domain.Changelog
+	RecentChangelogs []domain.Changelog
+	OlderChangelogs  []domain.Changelog
 }
 
 // writeChangelogPage generates the changelog page.
-func writeChangelogPage(outputDir string, data templateData) error {
-	tmpl, err := template.New("changelog.tmpl").Funcs(template.FuncMap{
-		"Anchor": sanitizeAnchor,
-		"Join":   strings.Join,
-		"lower":  strings.ToLower,
-	}).ParseFS(multiPageTemplateFS, "templates/md_multi_page/changelog.tmpl")
+func writeChangelogPage(pages site, outputDir string, data templateData) error {
+	tmpl, err := template.New("changelog.tmpl").Funcs(pages.templateFuncs()).
+		ParseFS(multiPageTemplateFS, "templates/md_multi_page/changelog.tmpl")
 	if err != nil {
 		return fmt.Errorf("parse changelog template: %w", err)
 	}
 
 	pageData := changelogPageData{
-		Changelogs: data.Changelogs,
+		RecentChangelogs: data.RecentChangelogs,
+		OlderChangelogs:  data.OlderChangelogs,
 	}
 
 	var buf strings.Builder
@@ -1671,7 +2109,7 @@ func writeChangelogPage(outputDir string, data templateData) error {
 	}
 
 	changelogPath := filepath.Join(outputDir, "changelog.md")
-	if err := os.WriteFile(changelogPath, []byte(buf.String()), filePerm); err != nil {
+	if err := pages.writePage(changelogPath, pageMeta{Title: "Changelog"}, buf.String()); err != nil {
 		return fmt.Errorf("write changelog page: %w", err)
 	}
 
@@ -1804,39 +2242,82 @@ func extractSendMessages(operations []opEntry, appendMessage func(string, mf.Mes
 	}
 }
 
-func readMetadata(outputDir string) (*Metadata, error) {
+// DocumentedSchema returns the schema recorded in domain.json of previously generated documentation.
+func (g *Generator) DocumentedSchema(outputDir string) (domain.Schema, error) {
+	metadata, err := readMetadata(g.fs, outputDir)
+	if err != nil {
+		return domain.Schema{}, fmt.Errorf("error reading existing holydocs data: %w", err)
+	}
+
+	if metadata == nil {
+		return domain.Schema{}, fmt.Errorf("%w in %s", domain.ErrMetadataNotFound, filepath.Clean(outputDir))
+	}
+
+	return metadata.Schema, nil
+}
+
+// CopyMetadata copies the schema and changelog recorded in the domain.json of fromDir to toDir, so
+// documentation generated into toDir records its changes against them. Nothing is copied without metadata.
+func (g *Generator) CopyMetadata(fromDir, toDir string) error {
+	metadata, err := readMetadata(g.fs, fromDir)
+	if err != nil || metadata == nil {
+		return err
+	}
+
+	// The hashes are the ones of the files in fromDir, which toDir doesn't have.
+	metadata.Files = nil
+
+	return writeMetadata(g.fs, toDir, *metadata)
+}
+
+func readMetadata(fsys outputfs.FS, outputDir string) (*Metadata, error) {
 	metadataPath := filepath.Join(outputDir, "domain.json")
 
-	if _, err := os.Stat(metadataPath); os.IsNotExist(err) {
+	if _, err := fsys.Stat(metadataPath); errors.Is(err, fs.ErrNotExist) {
 		return nil, nil // No existing metadata
 	}
 
-	data, err := os.ReadFile(metadataPath)
+	data, err := fsys.ReadFile(metadataPath)
 	if err != nil {
 		return nil, fmt.Errorf("error reading metadata file: %w", err)
 	}
 
-	var metadata Metadata
-	if err := json.Unmarshal(data, &metadata); err != nil {
-		return nil, fmt.Errorf("error unmarshaling metadata: %w", err)
+	metadata, err := decodeMetadata(data)
+	if err != nil {
+		return nil, err
 	}
 
 	return &metadata, nil
 }
 
-func writeMetadata(outputDir string, data Metadata) error {
-	if err := os.MkdirAll(outputDir, dirPerm); err != nil {
+// restoreMetadata restores the metadata read before a run, removing the metadata written by the run when
+// there was none before.
+func restoreMetadata(fsys outputfs.FS, outputDir string, previous *Metadata) error {
+	if previous != nil {
+		return writeMetadata(fsys, outputDir, *previous)
+	}
+
+	if err := fsys.Remove(filepath.Join(outputDir, "domain.json")); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("error removing metadata file: %w", err)
+	}
+
+	return nil
+}
+
+func writeMetadata(fsys outputfs.FS, outputDir string, data Metadata) error {
+	if err := fsys.MkdirAll(outputDir, dirPerm); err != nil {
 		return fmt.Errorf("error creating output directory: %w", err)
 	}
 
 	metadataPath := filepath.Join(outputDir, "domain.json")
+	data.Version = MetadataVersion
 
 	jsonData, err := json.MarshalIndent(data, "", "  ")
 	if err != nil {
 		return fmt.Errorf("error marshaling metadata: %w", err)
 	}
 
-	if err := os.WriteFile(metadataPath, jsonData, filePerm); err != nil {
+	if err := fsys.WriteFile(metadataPath, jsonData, filePerm); err != nil {
 		return fmt.Errorf("error writing metadata file: %w", err)
 	}
 
@@ -17,6 +17,7 @@ import (
 	d2target "github.com/holydocs/holydocs/internal/adapters/secondary/target/d2"
 	"github.com/holydocs/holydocs/internal/config"
 	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/holydocs/holydocs/pkg/outputfs"
 	mf "github.com/holydocs/messageflow/pkg/messageflow"
 	mfschema "github.com/holydocs/messageflow/pkg/schema"
 	mfd2 "github.com/holydocs/messageflow/pkg/schema/target/d2"
@@ -29,6 +30,7 @@ func setupTestGenerator(t *testing.T, target domain.Target, cfg *config.Config)
 	injector := do.New()
 	do.ProvideValue(injector, target)
 	do.ProvideValue(injector, cfg)
+	do.Provide(injector, NewOutputFS)
 	generator, err := NewGenerator(injector)
 	require.NoError(t, err)
 
@@ -63,7 +65,7 @@ func TestGenerateDocs(t *testing.T) {
 	cfg.Output.Dir = outputDir
 
 	generator := setupTestGenerator(t, holydocsTarget, cfg)
-	_, err = generator.Generate(ctx, holydocsSchema, mfSchema, mfTarget)
+	_, err = generator.Generate(ctx, holydocsSchema, mfSchema, mfTarget, domain.GenerateOptions{})
 	if err != nil {
 		t.Fatalf("generate docs: %v", err)
 	}
@@ -149,7 +151,7 @@ func TestProcessMetadata_FirstRun(t *testing.T) {
 	target, err := d2target.NewTarget(config.D2Config{})
 	require.NoError(t, err)
 	generator := setupTestGenerator(t, target, cfg)
-	metadata, newChangelog, err := generator.processMetadata(schema, tempDir)
+	metadata, newChangelog, err := generator.processMetadata(schema, tempDir, true, domain.GenerateOptions{})
 
 	require.NoError(t, err)
 	assert.NotNil(t, metadata)
@@ -177,11 +179,11 @@ func TestProcessMetadata_SecondRunNoChanges(t *testing.T) {
 	generator := setupTestGenerator(t, target, cfg)
 
 	// First run
-	_, _, err = generator.processMetadata(schema, tempDir)
+	_, _, err = generator.processMetadata(schema, tempDir, true, domain.GenerateOptions{})
 	require.NoError(t, err)
 
 	// Second run with same schema
-	metadata, newChangelog, err := generator.processMetadata(schema, tempDir)
+	metadata, newChangelog, err := generator.processMetadata(schema, tempDir, true, domain.GenerateOptions{})
 
 	require.NoError(t, err)
 	assert.NotNil(t, metadata)
@@ -223,11 +225,11 @@ func TestProcessMetadata_SecondRunWithChanges(t *testing.T) {
 	generator := setupTestGenerator(t, target, cfg)
 
 	// First run
-	_, _, err = generator.processMetadata(oldSchema, tempDir)
+	_, _, err = generator.processMetadata(oldSchema, tempDir, true, domain.GenerateOptions{})
 	require.NoError(t, err)
 
 	// Second run with changes
-	metadata, newChangelog, err := generator.processMetadata(newSchema, tempDir)
+	metadata, newChangelog, err := generator.processMetadata(newSchema, tempDir, true, domain.GenerateOptions{})
 
 	require.NoError(t, err)
 	assert.NotNil(t, metadata)
@@ -237,17 +239,152 @@ func TestProcessMetadata_SecondRunWithChanges(t *testing.T) {
 	assert.Len(t, metadata.Changelogs, 1, "Should have one changelog entry")
 }
 
-func TestReadMetadata_FileNotExists(t *testing.T) {
+func TestProcessMetadata_PartialSchemaNotRecorded(t *testing.T) {
 	tempDir := t.TempDir()
 
-	metadata, err := readMetadata(tempDir)
+	oldSchema := domain.Schema{
+		Services: []domain.Service{
+			{Info: domain.ServiceInfo{Name: "Test Service"}},
+			{Info: domain.ServiceInfo{Name: "Other Service"}},
+		},
+	}
+	partialSchema := domain.Schema{Services: oldSchema.Services[:1]}
+
+	cfg := &config.Config{Output: config.Output{Dir: tempDir}}
+	target, err := d2target.NewTarget(config.D2Config{})
+	require.NoError(t, err)
+	generator := setupTestGenerator(t, target, cfg)
+
+	_, _, err = generator.processMetadata(oldSchema, tempDir, true, domain.GenerateOptions{})
+	require.NoError(t, err)
+
+	metadata, newChangelog, err := generator.processMetadata(partialSchema, tempDir, false, domain.GenerateOptions{})
+	require.NoError(t, err)
+	assert.Nil(t, newChangelog, "Should not record removals of services left out")
+	assert.Equal(t, partialSchema, metadata.Schema)
+
+	stored, err := readMetadata(outputfs.OS(), tempDir)
+	require.NoError(t, err)
+	assert.Equal(t, oldSchema, stored.Schema, "Should keep the previous schema")
+}
+
+func TestProcessMetadata_PreviousSchema(t *testing.T) {
+	tempDir := t.TempDir()
+
+	schemaUsing := func(technology string) domain.Schema {
+		return domain.Schema{Services: []domain.Service{{
+			Info: domain.ServiceInfo{Name: "Test Service"},
+			Relationships: []domain.Relationship{
+				{Action: domain.RelationshipActionUses, Participant: "orders-db", Technology: technology},
+			},
+		}}}
+	}
+
+	cfg := &config.Config{Output: config.Output{Dir: tempDir}}
+	target, err := d2target.NewTarget(config.D2Config{})
+	require.NoError(t, err)
+	generator := setupTestGenerator(t, target, cfg)
+
+	_, _, err = generator.processMetadata(schemaUsing("Postgres"), tempDir, true, domain.GenerateOptions{})
+	require.NoError(t, err)
+
+	previous := schemaUsing("PostgreSQL")
+	opts := domain.GenerateOptions{Previous: &previous}
+
+	_, newChangelog, err := generator.processMetadata(schemaUsing("PostgreSQL"), tempDir, true, opts)
+	require.NoError(t, err)
+	assert.Nil(t, newChangelog, "Should compare with the previous schema of the options")
+}
+
+func TestProcessMetadata_Attribution(t *testing.T) {
+	tempDir := t.TempDir()
+
+	cfg := &config.Config{Output: config.Output{Dir: tempDir}}
+	target, err := d2target.NewTarget(config.D2Config{})
+	require.NoError(t, err)
+	generator := setupTestGenerator(t, target, cfg)
+
+	_, _, err = generator.processMetadata(domain.Schema{}, tempDir, true, domain.GenerateOptions{})
+	require.NoError(t, err)
+
+	schema := domain.Schema{Services: []domain.Service{{Info: domain.ServiceInfo{Name: "Test Service"}}}}
+	commits := map[string]domain.Commit{"Test Service": {Author: "Jane Doe", Subject: "Add test service"}}
+
+	metadata, newChangelog, err := generator.processMetadata(schema, tempDir, true,
+		domain.GenerateOptions{Attribution: commits})
+	require.NoError(t, err)
+	require.NotNil(t, newChangelog)
+	assert.Equal(t, `'Test Service' was added (last commit by Jane Doe: "Add test service")`,
+		newChangelog.Changes[0].Details)
+	assert.Equal(t, newChangelog.Changes, metadata.Changelogs[0].Changes, "Should record attributed changes")
+}
+
+func TestGenerate_Interrupted(t *testing.T) {
+	t.Parallel()
+
+	generator, fsys := newMemoryGenerator(t)
+
+	orders := domain.Schema{Services: []domain.Service{{Info: domain.ServiceInfo{Name: "Orders Service"}}}}
+	_, err := generator.Generate(context.Background(), orders, mf.Schema{}, nil, domain.GenerateOptions{})
+	require.NoError(t, err)
+
+	pages := func() map[string][]byte {
+		files := fsys.Files()
+		for name := range files {
+			if strings.Contains(name, "/"+diagramsDirName+"/") {
+				delete(files, name)
+			}
+		}
+
+		return files
+	}
+	before := pages()
+	require.Contains(t, before, "docs/domain.json")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	billing := domain.Schema{Services: []domain.Service{
+		{Info: domain.ServiceInfo{Name: "Orders Service"}},
+		{Info: domain.ServiceInfo{Name: "Billing Service"}},
+	}}
+	_, err = generator.Generate(ctx, billing, mf.Schema{}, nil, domain.GenerateOptions{})
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, before, pages(), "Should neither write pages nor record the schema")
+}
+
+func TestRestoreMetadata(t *testing.T) {
+	tempDir, fsys := "docs", outputfs.NewMemory()
+
+	previous := Metadata{Schema: domain.Schema{Services: []domain.Service{
+		{Info: domain.ServiceInfo{Name: "Test Service"}},
+	}}}
+	require.NoError(t, writeMetadata(fsys, tempDir, Metadata{}))
+	require.NoError(t, restoreMetadata(fsys, tempDir, &previous))
+
+	stored, err := readMetadata(fsys, tempDir)
+	require.NoError(t, err)
+	assert.Equal(t, previous.Schema, stored.Schema, "Should restore the previous schema")
+
+	require.NoError(t, restoreMetadata(fsys, tempDir, nil))
+	stored, err = readMetadata(fsys, tempDir)
+	require.NoError(t, err)
+	assert.Nil(t, stored, "Should remove metadata written by the first run")
+
+	require.NoError(t, restoreMetadata(fsys, tempDir, nil), "Should ignore missing metadata")
+}
+
+func TestReadMetadata_FileNotExists(t *testing.T) {
+	tempDir, fsys := "docs", outputfs.NewMemory()
+
+	metadata, err := readMetadata(fsys, tempDir)
 
 	require.NoError(t, err)
 	assert.Nil(t, metadata, "Should return nil when file doesn't exist")
 }
 
 func TestReadMetadata_FileExists(t *testing.T) {
-	tempDir := t.TempDir()
+	tempDir, fsys := "docs", outputfs.NewMemory()
 
 	expectedMetadata := Metadata{
 		Schema: domain.Schema{
@@ -274,10 +411,10 @@ func TestReadMetadata_FileExists(t *testing.T) {
 		},
 	}
 
-	err := writeMetadata(tempDir, expectedMetadata)
+	err := writeMetadata(fsys, tempDir, expectedMetadata)
 	require.NoError(t, err)
 
-	metadata, err := readMetadata(tempDir)
+	metadata, err := readMetadata(fsys, tempDir)
 
 	require.NoError(t, err)
 	assert.NotNil(t, metadata)
@@ -302,7 +439,7 @@ func TestWriteMetadata(t *testing.T) {
 		Changelogs: []domain.Changelog{},
 	}
 
-	err := writeMetadata(tempDir, metadata)
+	err := writeMetadata(outputfs.OS(), tempDir, metadata)
 
 	require.NoError(t, err)
 
@@ -312,6 +449,28 @@ func TestWriteMetadata(t *testing.T) {
 	require.NoError(t, err, "Metadata file should be created")
 }
 
+func TestGenerator_CopyMetadata(t *testing.T) {
+	from, to, fsys := "docs", "preview", outputfs.NewMemory()
+	generator := &Generator{fs: fsys}
+
+	require.NoError(t, generator.CopyMetadata(from, to))
+	assert.Empty(t, fsys.Files())
+
+	metadata := Metadata{
+		Schema:     domain.Schema{Services: []domain.Service{{Info: domain.ServiceInfo{Name: "Test Service"}}}},
+		Changelogs: []domain.Changelog{{Changes: []domain.Change{{Type: domain.ChangeTypeAdded}}}},
+	}
+	require.NoError(t, writeMetadata(fsys, from, metadata))
+
+	require.NoError(t, generator.CopyMetadata(from, to))
+
+	copied, err := readMetadata(fsys, to)
+	require.NoError(t, err)
+	require.NotNil(t, copied)
+	assert.Equal(t, metadata.Schema, copied.Schema)
+	assert.Len(t, copied.Changelogs, 1)
+}
+
 func validateGeneratedFiles(t *testing.T, outputDir, expectedDir string) {
 	generatedFiles := collectFiles(t, outputDir)
 	expectedFiles := collectFiles(t, expectedDir)
@@ -414,7 +573,7 @@ func TestGenerateDocs_MultiPageFormat(t *testing.T) {
 	cfg.Output.Format = "md_multi_page"
 
 	generator := setupTestGenerator(t, holydocsTarget, cfg)
-	_, err = generator.Generate(ctx, holydocsSchema, mfSchema, mfTarget)
+	_, err = generator.Generate(ctx, holydocsSchema, mfSchema, mfTarget, domain.GenerateOptions{})
 	if err != nil {
 		t.Fatalf("generate docs: %v", err)
 	}
@@ -426,3 +585,124 @@ func TestGenerateDocs_MultiPageFormat(t *testing.T) {
 	expectedDir := filepath.Join("testdata", "expected_md_multi_page")
 	validateGeneratedFiles(t, outputDir, expectedDir)
 }
+
+func TestGenerateDocs_OutputTargets(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	asyncFiles, serviceFiles := getTestDataFiles()
+	holydocsSchema, holydocsTarget, mfSchema, mfTarget := setupTestSchemasAndTargets(t, ctx, asyncFiles, serviceFiles)
+
+	configInjector := do.New()
+	do.ProvideValue(configInjector, config.ConfigFilePath(filepath.Join("testdata", "holydocs.test.yaml")))
+	cfg, err := config.LoadConfig(configInjector)
+	require.NoError(t, err)
+
+	outputDir := filepath.Join(t.TempDir(), "docs")
+	targetDir := filepath.Join(t.TempDir(), "site")
+	cfg.Output.Dir = outputDir
+	cfg.Output.Targets = []config.OutputTarget{{Dir: targetDir, Format: "md_multi_page"}}
+
+	generator := setupTestGenerator(t, holydocsTarget, cfg)
+	_, err = generator.Generate(ctx, holydocsSchema, mfSchema, mfTarget, domain.GenerateOptions{})
+	require.NoError(t, err)
+
+	validateGeneratedFiles(t, outputDir, filepath.Join("testdata", "expected_md_single_page"))
+
+	// The metadata is only kept in the output directory.
+	expected := collectFiles(t, filepath.Join("testdata", "expected_md_multi_page"))
+	delete(expected, "domain.json")
+	generated := collectFiles(t, targetDir)
+	require.Equal(t, sortedKeys(expected), sortedKeys(generated))
+
+	for path, content := range expected {
+		if !bytes.Equal(content, generated[path]) {
+			validateFileContent(t, path, content, generated[path])
+		}
+	}
+}
+
+func TestGenerateDocs_SystemOutputs(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	asyncFiles, serviceFiles := getTestDataFiles()
+	holydocsSchema, holydocsTarget, mfSchema, mfTarget := setupTestSchemasAndTargets(t, ctx, asyncFiles, serviceFiles)
+
+	configInjector := do.New()
+	do.ProvideValue(configInjector, config.ConfigFilePath(filepath.Join("testdata", "holydocs.test.yaml")))
+	cfg, err := config.LoadConfig(configInjector)
+	require.NoError(t, err)
+
+	outputDir := filepath.Join(t.TempDir(), "docs")
+	systemDir := filepath.Join(t.TempDir(), "analytics")
+	cfg.Output.Dir = outputDir
+	cfg.Output.Format = "md_multi_page"
+
+	analytics := cfg.Documentation.Systems["Analytics System"]
+	analytics.Output = systemDir
+	cfg.Documentation.Systems["Analytics System"] = analytics
+
+	generator := setupTestGenerator(t, holydocsTarget, cfg)
+	_, err = generator.Generate(ctx, holydocsSchema, mfSchema, mfTarget, domain.GenerateOptions{})
+	require.NoError(t, err)
+
+	validateGeneratedFiles(t, outputDir, filepath.Join("testdata", "expected_md_multi_page"))
+
+	assert.FileExists(t, filepath.Join(systemDir, "systems", "analytics-system.md"))
+	assert.NoFileExists(t, filepath.Join(systemDir, "systems", "notification-system.md"))
+	assert.FileExists(t, filepath.Join(systemDir, "services", "analytics-service.md"))
+	assert.NoFileExists(t, filepath.Join(systemDir, "services", "mailer-service.md"))
+	assert.FileExists(t, filepath.Join(systemDir, "diagrams", "overview.svg"))
+
+	readme, err := os.ReadFile(filepath.Join(systemDir, "README.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(readme), "Analytics System")
+	assert.NotContains(t, string(readme), "(systems/notification-system.md)")
+}
+
+func TestGenerateDocs_Drawio(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	asyncFiles, serviceFiles := getTestDataFiles()
+	holydocsSchema, holydocsTarget, mfSchema, mfTarget := setupTestSchemasAndTargets(t, ctx, asyncFiles, serviceFiles)
+
+	configInjector := do.New()
+	do.ProvideValue(configInjector, config.ConfigFilePath(filepath.Join("testdata", "holydocs.test.yaml")))
+	cfg, err := config.LoadConfig(configInjector)
+	require.NoError(t, err)
+
+	outputDir := filepath.Join(t.TempDir(), "docs")
+	cfg.Output.Dir = outputDir
+	cfg.Diagram.Drawio = true
+
+	generator := setupTestGenerator(t, holydocsTarget, cfg)
+	_, err = generator.Generate(ctx, holydocsSchema, mfSchema, mfTarget, domain.GenerateOptions{})
+	require.NoError(t, err)
+
+	for _, name := range []string{"overview.drawio", "system-analytics-system.drawio"} {
+		diagram, err := os.ReadFile(filepath.Join(outputDir, "diagrams", name))
+		require.NoError(t, err)
+		assert.Contains(t, string(diagram), "<mxfile")
+		assert.Contains(t, string(diagram), "container=1")
+	}
+}
+
+func TestBuildRelationshipSummaries_CriticalFirst(t *testing.T) {
+	t.Parallel()
+
+	summaries := buildRelationshipSummaries([]domain.Relationship{
+		{Action: domain.RelationshipActionUses, Participant: "Redis"},
+		{Action: domain.RelationshipActionUses, Participant: "Postgres", Criticality: domain.CriticalityHigh},
+		{Action: domain.RelationshipActionRequests, Participant: "Billing", Criticality: domain.CriticalityCritical},
+		{Action: domain.RelationshipActionRequests, Participant: "Audit"},
+	})
+
+	participants := make([]string, 0, len(summaries))
+	for _, summary := range summaries {
+		participants = append(participants, summary.Participant)
+	}
+
+	assert.Equal(t, []string{"Billing", "Postgres", "Audit", "Redis"}, participants)
+}
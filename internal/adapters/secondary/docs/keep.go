@@ -0,0 +1,144 @@
+package docs
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/holydocs/holydocs/pkg/outputfs"
+)
+
+// Kept blocks are parts of generated pages written by hand between these markers, preserved when the pages
+// are generated again. The start marker may name the block, e.g. <!-- holydocs:keep:start owners -->.
+const (
+	keepStartPrefix = "<!-- holydocs:keep:start"
+	keepEnd         = "<!-- holydocs:keep:end -->"
+)
+
+// keptBlock is a kept block of a page, with the section it was written in.
+type keptBlock struct {
+	section string
+	lines   []string
+}
+
+// preserveKept carries the kept blocks of the page previously written to pagePath over to its new content.
+func preserveKept(fsys outputfs.FS, pagePath, content string) string {
+	previous, err := fsys.ReadFile(pagePath)
+	if err != nil {
+		return content
+	}
+
+	blocks := keptBlocks(string(previous))
+	if len(blocks) == 0 {
+		return content
+	}
+
+	return insertKept(content, blocks)
+}
+
+// keptBlocks returns the kept blocks of a page. A block missing its end marker runs to the end of the page.
+func keptBlocks(content string) []keptBlock {
+	var (
+		blocks []keptBlock
+		block  *keptBlock
+	)
+
+	for _, line := range sectionLines(strings.Split(content, "\n")) {
+		trimmed := strings.TrimSpace(line.text)
+
+		if block == nil {
+			if strings.HasPrefix(trimmed, keepStartPrefix) {
+				block = &keptBlock{section: line.section, lines: []string{line.text}}
+			}
+
+			continue
+		}
+
+		block.lines = append(block.lines, line.text)
+
+		if trimmed == keepEnd {
+			blocks = append(blocks, *block)
+			block = nil
+		}
+	}
+
+	if block != nil {
+		block.lines = append(block.lines, keepEnd)
+		blocks = append(blocks, *block)
+	}
+
+	return blocks
+}
+
+// insertKept adds kept blocks at the end of the sections they were written in, or at the end of the page when
+// their section is gone. Blocks whose start marker the content already has, e.g. from configured Markdown,
+// are left out.
+func insertKept(content string, blocks []keptBlock) string {
+	lines := sectionLines(strings.Split(strings.TrimRight(content, "\n"), "\n"))
+
+	bySection := make(map[string][]keptBlock)
+	var orphaned []keptBlock
+
+	for _, block := range blocks {
+		if slices.ContainsFunc(lines, func(line sectionLine) bool { return line.text == block.lines[0] }) {
+			continue
+		}
+
+		if block.section == "" ||
+			slices.ContainsFunc(lines, func(line sectionLine) bool { return line.section == block.section }) {
+			bySection[block.section] = append(bySection[block.section], block)
+		} else {
+			orphaned = append(orphaned, block)
+		}
+	}
+
+	output := make([]string, 0, len(lines))
+	flush := func(section string) {
+		for _, block := range bySection[section] {
+			// The blank lines and anchor closing the section stay in front of the next heading.
+			tail := len(output)
+			for tail > 0 && (output[tail-1] == "" || anchorPattern.MatchString(output[tail-1])) {
+				tail--
+			}
+
+			closing := slices.Clone(output[tail:])
+
+			output = output[:tail]
+			if tail > 0 {
+				output = append(output, "")
+			}
+
+			output = append(output, block.lines...)
+			if len(closing) == 0 || closing[0] != "" {
+				output = append(output, "")
+			}
+
+			output = append(output, closing...)
+		}
+
+		delete(bySection, section)
+	}
+
+	for i, line := range lines {
+		if line.heading {
+			var previous string
+			if i > 0 {
+				previous = lines[i-1].section
+			}
+
+			flush(previous)
+		}
+
+		output = append(output, line.text)
+	}
+
+	if len(lines) > 0 {
+		flush(lines[len(lines)-1].section)
+	}
+
+	for _, block := range orphaned {
+		output = append(output, "")
+		output = append(output, block.lines...)
+	}
+
+	return strings.TrimRight(strings.Join(output, "\n"), "\n") + "\n"
+}
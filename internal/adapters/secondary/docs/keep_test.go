@@ -0,0 +1,100 @@
+package docs
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/pkg/outputfs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertKept(t *testing.T) {
+	t.Parallel()
+
+	previous := `# Docs
+
+<!-- holydocs:keep:start intro -->
+Maintained by the platform team.
+<!-- holydocs:keep:end -->
+
+## Orders Service
+
+Takes orders.
+
+<!-- holydocs:keep:start -->
+### Runbook
+
+See the wiki.
+<!-- holydocs:keep:end -->
+
+## Fax Service
+
+<!-- holydocs:keep:start fax -->
+Being replaced.
+<!-- holydocs:keep:end -->
+`
+
+	content := `# Docs
+
+## Orders Service
+
+Takes and tracks orders.
+
+<a id="billing-service"></a>
+## Billing Service
+
+Bills.
+`
+
+	assert.Equal(t, `# Docs
+
+<!-- holydocs:keep:start intro -->
+Maintained by the platform team.
+<!-- holydocs:keep:end -->
+
+## Orders Service
+
+Takes and tracks orders.
+
+<!-- holydocs:keep:start -->
+### Runbook
+
+See the wiki.
+<!-- holydocs:keep:end -->
+
+<a id="billing-service"></a>
+## Billing Service
+
+Bills.
+
+<!-- holydocs:keep:start fax -->
+Being replaced.
+<!-- holydocs:keep:end -->
+`, insertKept(content, keptBlocks(previous)))
+
+	// Blocks already part of the content aren't repeated.
+	assert.Equal(t, previous, insertKept(previous, keptBlocks(previous)))
+}
+
+func TestWritePage_PreservesKeptBlocks(t *testing.T) {
+	t.Parallel()
+
+	pagePath, fsys := filepath.Join("docs", "README.md"), outputfs.NewMemory()
+	require.NoError(t, fsys.MkdirAll("docs", dirPerm))
+	pages := newSite(fsys, config.Output{Dir: filepath.Dir(pagePath)})
+
+	require.NoError(t, pages.writePage(pagePath, pageMeta{}, "# Docs\n\n## Orders\n\nTakes orders.\n"))
+
+	edited := "# Docs\n\n## Orders\n\nTakes orders.\n\n<!-- holydocs:keep:start -->\nAsk #orders.\n<!-- holydocs:keep:end -->\n"
+	require.NoError(t, fsys.WriteFile(pagePath, []byte(edited), filePerm))
+
+	require.NoError(t, pages.writePage(pagePath, pageMeta{}, "# Docs\n\n## Orders\n\nTakes all orders.\n"))
+
+	content, err := fsys.ReadFile(pagePath)
+	require.NoError(t, err)
+	assert.Equal(t,
+		"# Docs\n\n## Orders\n\nTakes all orders.\n\n<!-- holydocs:keep:start -->\nAsk #orders.\n<!-- holydocs:keep:end -->\n",
+		string(content))
+}
@@ -0,0 +1,47 @@
+package docs
+
+import (
+	"fmt"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+)
+
+// limitView is a row of the Limits table of a service, Participant is empty for limits of the service itself.
+type limitView struct {
+	Limit       string
+	Participant string
+	Value       string
+}
+
+// buildLimitViews lists the limits of the service followed by the limits of its relationships.
+func buildLimitViews(service domain.Service) []limitView {
+	var views []limitView
+
+	if limits := service.Info.Limits; limits != nil {
+		if limits.MaxRPS > 0 {
+			views = append(views, limitView{Limit: "Max requests", Value: fmt.Sprintf("%d/s", limits.MaxRPS)})
+		}
+
+		if limits.MaxConnections > 0 {
+			views = append(views, limitView{Limit: "Max connections", Value: fmt.Sprint(limits.MaxConnections)})
+		}
+	}
+
+	for _, rel := range service.Relationships {
+		if rel.Limits == nil {
+			continue
+		}
+
+		if rel.Limits.RateLimit > 0 {
+			views = append(views, limitView{Limit: "Rate limit", Participant: rel.Participant,
+				Value: fmt.Sprintf("%d/s", rel.Limits.RateLimit)})
+		}
+
+		if rel.Limits.ConnectionPool > 0 {
+			views = append(views, limitView{Limit: "Connection pool", Participant: rel.Participant,
+				Value: fmt.Sprint(rel.Limits.ConnectionPool)})
+		}
+	}
+
+	return views
+}
@@ -0,0 +1,51 @@
+package docs
+
+import (
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/holydocs/holydocs/pkg/outputfs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildLimitViews(t *testing.T) {
+	t.Parallel()
+
+	service := domain.Service{
+		Info: domain.ServiceInfo{Name: "Order Service", Limits: &domain.ServiceLimits{MaxRPS: 500}},
+		Relationships: []domain.Relationship{
+			{Participant: "Stripe", Limits: &domain.RelationshipLimits{RateLimit: 100}},
+			{Participant: "Billing Service"},
+			{Participant: "orders-db", Limits: &domain.RelationshipLimits{ConnectionPool: 20}},
+		},
+	}
+
+	assert.Equal(t, []limitView{
+		{Limit: "Max requests", Value: "500/s"},
+		{Limit: "Rate limit", Participant: "Stripe", Value: "100/s"},
+		{Limit: "Connection pool", Participant: "orders-db", Value: "20"},
+	}, buildLimitViews(service))
+	assert.Empty(t, buildLimitViews(domain.Service{}))
+}
+
+func TestWriteServicePage_Limits(t *testing.T) {
+	t.Parallel()
+
+	fsys := outputfs.NewMemory()
+	require.NoError(t, fsys.MkdirAll("docs/services", dirPerm))
+	require.NoError(t, writeServicePage(newSite(fsys, config.Output{Dir: "docs"}), "docs/services", serviceView{
+		Name:     "Order Service",
+		FileName: "order-service",
+		Limits: []limitView{
+			{Limit: "Max requests", Value: "500/s"},
+			{Limit: "Rate limit", Participant: "Stripe", Value: "100/s"},
+		},
+	}, nil))
+
+	page, err := fsys.ReadFile("docs/services/order-service.md")
+	require.NoError(t, err)
+	assert.Contains(t, string(page), "## Limits\n\n| Limit | Participant | Value |\n|-------|-------------|-------|\n"+
+		"| Max requests | — | 500/s |\n| Rate limit | Stripe | 100/s |\n")
+}
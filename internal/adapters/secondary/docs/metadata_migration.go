@@ -0,0 +1,87 @@
+package docs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// MetadataVersion is the current format version of the persisted domain.json metadata.
+// Bump it together with a new entry in metadataMigrations whenever the persisted domain
+// model changes in a way older files can't be decoded or compared as is.
+const MetadataVersion = 1
+
+// Errors.
+var (
+	ErrMetadataVersionUnsupported = errors.New("unsupported metadata version")
+)
+
+// unversionedMetadataVersion is the version of files written before versioning was introduced, their format
+// is the one of version 1.
+const unversionedMetadataVersion = 1
+
+// metadataMigration upgrades a decoded domain.json document by exactly one version.
+type metadataMigration func(doc map[string]any) error
+
+// metadataMigrations are ordered by the version they migrate from, starting with version 1.
+// No format change has needed one yet.
+//
+//nolint:gochecknoglobals // Ordered list of migrations.
+var metadataMigrations []metadataMigration
+
+// decodeMetadata decodes domain.json, migrating older formats to the current version.
+func decodeMetadata(data []byte) (Metadata, error) {
+	return decodeMetadataWith(data, metadataMigrations)
+}
+
+func decodeMetadataWith(data []byte, migrations []metadataMigration) (Metadata, error) {
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return Metadata{}, fmt.Errorf("error unmarshaling metadata: %w", err)
+	}
+
+	version, err := metadataVersion(doc)
+	if err != nil {
+		return Metadata{}, err
+	}
+
+	current := unversionedMetadataVersion + len(migrations)
+	if version > current {
+		return Metadata{}, fmt.Errorf("%w: %d, this holydocs version supports up to %d",
+			ErrMetadataVersionUnsupported, version, current)
+	}
+
+	for v := version; v < current; v++ {
+		if err := migrations[v-unversionedMetadataVersion](doc); err != nil {
+			return Metadata{}, fmt.Errorf("error migrating metadata from version %d: %w", v, err)
+		}
+	}
+
+	doc["version"] = current
+
+	migrated, err := json.Marshal(doc)
+	if err != nil {
+		return Metadata{}, fmt.Errorf("error marshaling migrated metadata: %w", err)
+	}
+
+	var metadata Metadata
+	if err := json.Unmarshal(migrated, &metadata); err != nil {
+		return Metadata{}, fmt.Errorf("error unmarshaling metadata: %w", err)
+	}
+
+	return metadata, nil
+}
+
+func metadataVersion(doc map[string]any) (int, error) {
+	raw, ok := doc["version"]
+	if !ok {
+		return unversionedMetadataVersion, nil
+	}
+
+	number, ok := raw.(float64)
+	if !ok || number < unversionedMetadataVersion || number != float64(int(number)) {
+		return 0, fmt.Errorf("%w: %v", ErrMetadataVersionUnsupported, raw)
+	}
+
+	return int(number), nil
+}
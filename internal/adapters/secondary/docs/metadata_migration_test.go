@@ -0,0 +1,71 @@
+package docs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeMetadata_Unversioned(t *testing.T) {
+	t.Parallel()
+
+	metadata, err := decodeMetadata([]byte(`{
+		"schema": {"services": [{"info": {"name": "User Service", "description": ""}}]},
+		"changelogs": []
+	}`))
+	require.NoError(t, err)
+	assert.Equal(t, MetadataVersion, metadata.Version)
+	require.Len(t, metadata.Schema.Services, 1)
+	assert.Equal(t, "User Service", metadata.Schema.Services[0].Info.Name)
+}
+
+func TestDecodeMetadata_NewerVersion(t *testing.T) {
+	t.Parallel()
+
+	_, err := decodeMetadata([]byte(`{"version": 99, "schema": {"services": []}, "changelogs": []}`))
+	require.ErrorIs(t, err, ErrMetadataVersionUnsupported)
+
+	_, err = decodeMetadata([]byte(`{"version": "1"}`))
+	require.ErrorIs(t, err, ErrMetadataVersionUnsupported)
+
+	_, err = decodeMetadata([]byte(`{"version": 0}`))
+	require.ErrorIs(t, err, ErrMetadataVersionUnsupported)
+}
+
+func TestMetadataVersion_MatchesMigrations(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, MetadataVersion, unversionedMetadataVersion+len(metadataMigrations))
+}
+
+func TestDecodeMetadataWith_AppliesMigrationsInOrder(t *testing.T) {
+	t.Parallel()
+
+	migrations := []metadataMigration{
+		func(doc map[string]any) error {
+			doc["schema"] = doc["domain"]
+			delete(doc, "domain")
+
+			return nil
+		},
+		func(doc map[string]any) error {
+			if _, ok := doc["changelogs"]; !ok {
+				doc["changelogs"] = []any{}
+			}
+
+			return nil
+		},
+	}
+
+	metadata, err := decodeMetadataWith([]byte(`{"domain": {"services": [{"info": {"name": "A"}}]}}`), migrations)
+	require.NoError(t, err)
+	assert.Equal(t, 3, metadata.Version)
+	require.Len(t, metadata.Schema.Services, 1)
+	assert.NotNil(t, metadata.Changelogs)
+
+	metadata, err = decodeMetadataWith([]byte(`{"version": 3, "schema": {"services": []}}`), migrations)
+	require.NoError(t, err)
+	assert.Equal(t, 3, metadata.Version)
+	assert.Nil(t, metadata.Changelogs)
+}
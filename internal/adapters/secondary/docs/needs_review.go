@@ -0,0 +1,43 @@
+package docs
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+)
+
+// sourceDateLayout formats modification dates of specifications, times of day don't matter at their age.
+const sourceDateLayout = "2006-01-02"
+
+type needsReviewView struct {
+	Service                string
+	Paths                  []string
+	ModifiedAt             string
+	ChangedDependencies    []string
+	DependenciesModifiedAt string
+}
+
+// buildNeedsReview lists the likely stale ServiceFiles, oldest first.
+func buildNeedsReview(stale []domain.StaleService) []needsReviewView {
+	if len(stale) == 0 {
+		return nil
+	}
+
+	views := make([]needsReviewView, 0, len(stale))
+	for _, service := range stale {
+		views = append(views, needsReviewView{
+			Service:                service.Name,
+			Paths:                  service.Paths,
+			ModifiedAt:             service.ModifiedAt.Format(sourceDateLayout),
+			ChangedDependencies:    service.ChangedDependencies,
+			DependenciesModifiedAt: service.DependenciesModifiedAt.Format(sourceDateLayout),
+		})
+	}
+
+	slices.SortStableFunc(views, func(a, b needsReviewView) int {
+		return strings.Compare(a.ModifiedAt, b.ModifiedAt)
+	})
+
+	return views
+}
@@ -0,0 +1,71 @@
+package docs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/holydocs/holydocs/pkg/outputfs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildNeedsReview(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, buildNeedsReview(nil))
+
+	views := buildNeedsReview([]domain.StaleService{
+		{
+			Name:                   "Order Service",
+			Paths:                  []string{"order.servicefile.yaml"},
+			ModifiedAt:             time.Date(2024, 11, 2, 10, 0, 0, 0, time.UTC),
+			ChangedDependencies:    []string{"User Service"},
+			DependenciesModifiedAt: time.Date(2025, 5, 20, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			Name:                   "Billing Service",
+			Paths:                  []string{"billing.servicefile.yaml"},
+			ModifiedAt:             time.Date(2024, 3, 14, 16, 0, 0, 0, time.UTC),
+			ChangedDependencies:    []string{"Shipping Service"},
+			DependenciesModifiedAt: time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC),
+		},
+	})
+
+	assert.Equal(t, []needsReviewView{
+		{
+			Service:                "Billing Service",
+			Paths:                  []string{"billing.servicefile.yaml"},
+			ModifiedAt:             "2024-03-14",
+			ChangedDependencies:    []string{"Shipping Service"},
+			DependenciesModifiedAt: "2025-04-01",
+		},
+		{
+			Service:                "Order Service",
+			Paths:                  []string{"order.servicefile.yaml"},
+			ModifiedAt:             "2024-11-02",
+			ChangedDependencies:    []string{"User Service"},
+			DependenciesModifiedAt: "2025-05-20",
+		},
+	}, views)
+}
+
+func TestWriteReadme_PendingReview(t *testing.T) {
+	t.Parallel()
+
+	outputDir := t.TempDir()
+	require.NoError(t, writeReadme(newSite(outputfs.OS(), config.Output{Dir: outputDir}), templateData{
+		Title: "Test",
+		PendingReview: []domain.PendingService{
+			{Name: "Load Test Service", Description: "Generates load.", Sources: []string{"load-test", "perf"}},
+		},
+	}))
+
+	readme, err := os.ReadFile(filepath.Join(outputDir, "README.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(readme), "- [Pending Review](#pending-review)")
+	assert.Contains(t, string(readme), "| Load Test Service | Generates load. | load-test, perf |")
+}
@@ -0,0 +1,38 @@
+package docs
+
+import "github.com/holydocs/holydocs/internal/core/domain"
+
+// onCallView is the escalation policy of a service shown next to its owner, with links to the provider.
+type onCallView struct {
+	Provider            string
+	EscalationPolicy    string
+	EscalationPolicyURL string
+	URL                 string
+}
+
+// applyOnCall attaches the escalation policies looked up for this generation to their services.
+func applyOnCall(data templateData, onCall map[string]domain.OnCall) templateData {
+	if len(onCall) == 0 {
+		return data
+	}
+
+	for i := range data.Systems {
+		for j := range data.Systems[i].Services {
+			service := &data.Systems[i].Services[j]
+
+			policy, ok := onCall[service.Name]
+			if !ok {
+				continue
+			}
+
+			service.OnCall = &onCallView{
+				Provider:            policy.Provider.Title(),
+				EscalationPolicy:    policy.EscalationPolicy,
+				EscalationPolicyURL: policy.EscalationPolicyURL,
+				URL:                 policy.URL,
+			}
+		}
+	}
+
+	return data
+}
@@ -0,0 +1,57 @@
+package docs
+
+import (
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/holydocs/holydocs/pkg/outputfs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyOnCall(t *testing.T) {
+	t.Parallel()
+
+	data := applyOnCall(templateData{Systems: []systemView{{Services: []serviceView{
+		{Name: "Payments"},
+		{Name: "Orders"},
+	}}}}, map[string]domain.OnCall{
+		"Payments": {
+			Provider:            domain.OnCallProviderPagerDuty,
+			EscalationPolicy:    "Payments Primary",
+			EscalationPolicyURL: "https://acme.pagerduty.com/escalation_policies/PE1",
+			URL:                 "https://acme.pagerduty.com/service-directory/PX7Q2M1",
+		},
+	})
+
+	assert.Equal(t, &onCallView{
+		Provider:            "PagerDuty",
+		EscalationPolicy:    "Payments Primary",
+		EscalationPolicyURL: "https://acme.pagerduty.com/escalation_policies/PE1",
+		URL:                 "https://acme.pagerduty.com/service-directory/PX7Q2M1",
+	}, data.Systems[0].Services[0].OnCall)
+	assert.Nil(t, data.Systems[0].Services[1].OnCall)
+}
+
+func TestWriteServicePage_OnCall(t *testing.T) {
+	t.Parallel()
+
+	fsys := outputfs.NewMemory()
+	require.NoError(t, fsys.MkdirAll("docs/services", dirPerm))
+	require.NoError(t, writeServicePage(newSite(fsys, config.Output{Dir: "docs"}), "docs/services", serviceView{
+		Name:     "Payments",
+		FileName: "payments",
+		Owner:    "Payments Team",
+		OnCall: &onCallView{
+			Provider:         "Opsgenie",
+			EscalationPolicy: "Payments Primary",
+			URL:              "https://acme.app.opsgenie.com/teams/dashboard/T1/main",
+		},
+	}, nil))
+
+	page, err := fsys.ReadFile("docs/services/payments.md")
+	require.NoError(t, err)
+	assert.Contains(t, string(page), "- Owner: Payments Team\n- On-call: Payments Primary · "+
+		"[Opsgenie](https://acme.app.opsgenie.com/teams/dashboard/T1/main)\n")
+}
@@ -0,0 +1,184 @@
+package docs
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+)
+
+// overviewReducer leaves the services and participants matching diagram.hide out of the overview and draws
+// the ones matching diagram.collapse as a single node. Entries match names and tags case-insensitively.
+type overviewReducer struct {
+	hide          map[string]struct{}
+	collapse      map[string]struct{}
+	collapseLabel string
+	services      map[string]domain.Service
+	// collapsed holds the names of the services and participants drawn as the collapsed node.
+	collapsed map[string]struct{}
+}
+
+// reduceOverview returns the schema and async edges drawn in the overview diagram. Service pages and tables
+// keep documenting the hidden and collapsed services and dependencies.
+func reduceOverview(schema domain.Schema, asyncEdges []asyncEdge, cfg config.Diagram) (domain.Schema, []asyncEdge) {
+	if len(cfg.Hide) == 0 && len(cfg.Collapse) == 0 {
+		return schema, asyncEdges
+	}
+
+	r := overviewReducer{
+		hide:          lowerSet(cfg.Hide),
+		collapse:      lowerSet(cfg.Collapse),
+		collapseLabel: cfg.CollapseLabel,
+		services:      make(map[string]domain.Service, len(schema.Services)),
+		collapsed:     make(map[string]struct{}),
+	}
+
+	for _, service := range schema.Services {
+		r.services[service.Info.Name] = service
+	}
+
+	reduced := r.reduceServices(schema.Services)
+	edges := r.reduceAsyncEdges(reduced, asyncEdges)
+
+	description := collapsedDescription(r.collapsed)
+	for i := range reduced.Services {
+		for j, rel := range reduced.Services[i].Relationships {
+			if rel.Participant == r.collapseLabel && rel.External {
+				reduced.Services[i].Relationships[j].Description = description
+			}
+		}
+	}
+
+	return reduced, edges
+}
+
+// reduceServices leaves the hidden services out and points the relationships to collapsed participants to the
+// collapsed node.
+func (r overviewReducer) reduceServices(services []domain.Service) domain.Schema {
+	var reduced domain.Schema
+
+	for _, service := range services {
+		if r.matchesService(r.hide, service.Info.Name) {
+			continue
+		}
+
+		if r.matchesService(r.collapse, service.Info.Name) {
+			r.collapsed[service.Info.Name] = struct{}{}
+
+			continue
+		}
+
+		relationships := make([]domain.Relationship, 0, len(service.Relationships))
+
+		for _, rel := range service.Relationships {
+			switch {
+			case r.matchesParticipant(r.hide, rel):
+				continue
+			case r.matchesParticipant(r.collapse, rel):
+				r.collapsed[rel.Participant] = struct{}{}
+				rel = r.collapsedRelationship(rel.Action, rel.Planned)
+			}
+
+			relationships = append(relationships, rel)
+		}
+
+		service.Relationships = relationships
+		reduced.Services = append(reduced.Services, service)
+	}
+
+	return reduced
+}
+
+// reduceAsyncEdges keeps the async edges between the services left in the overview. Edges to and from collapsed
+// services become relationships of the kept service to the collapsed node.
+func (r overviewReducer) reduceAsyncEdges(reduced domain.Schema, asyncEdges []asyncEdge) []asyncEdge {
+	services := make(map[string]*domain.Service, len(reduced.Services))
+	for i := range reduced.Services {
+		services[reduced.Services[i].Info.Name] = &reduced.Services[i]
+	}
+
+	edges := make([]asyncEdge, 0, len(asyncEdges))
+
+	for _, edge := range asyncEdges {
+		source, sourceKept := services[edge.Source]
+		target, targetKept := services[edge.Target]
+
+		_, sourceCollapsed := r.collapsed[edge.Source]
+		_, targetCollapsed := r.collapsed[edge.Target]
+
+		switch {
+		case sourceKept && targetKept:
+			edges = append(edges, edge)
+		case sourceKept && targetCollapsed:
+			source.Relationships = append(source.Relationships,
+				r.collapsedRelationship(domain.RelationshipActionSends, false))
+		case targetKept && sourceCollapsed:
+			target.Relationships = append(target.Relationships,
+				r.collapsedRelationship(domain.RelationshipActionReceives, false))
+		}
+	}
+
+	return edges
+}
+
+func (r overviewReducer) collapsedRelationship(action domain.RelationshipAction, planned bool) domain.Relationship {
+	return domain.Relationship{
+		Action:      action,
+		Participant: r.collapseLabel,
+		External:    true,
+		Planned:     planned,
+	}
+}
+
+// matchesService reports whether a service of the schema matches an entry by name or by one of its tags.
+func (r overviewReducer) matchesService(entries map[string]struct{}, name string) bool {
+	service, ok := r.services[name]
+	if !ok {
+		return false
+	}
+
+	return matchesEntry(entries, name, service.Info.Tags)
+}
+
+// matchesParticipant reports whether the participant of a relationship matches an entry by its name, by a
+// tag of the relationship or, for services of the schema, by a tag of the service.
+func (r overviewReducer) matchesParticipant(entries map[string]struct{}, rel domain.Relationship) bool {
+	return matchesEntry(entries, rel.Participant, rel.Tags) || r.matchesService(entries, rel.Participant)
+}
+
+func matchesEntry(entries map[string]struct{}, name string, tags []string) bool {
+	if _, ok := entries[strings.ToLower(name)]; ok {
+		return true
+	}
+
+	return slices.ContainsFunc(tags, func(tag string) bool {
+		_, ok := entries[strings.ToLower(tag)]
+
+		return ok
+	})
+}
+
+func lowerSet(values []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		if value = strings.ToLower(strings.TrimSpace(value)); value != "" {
+			set[value] = struct{}{}
+		}
+	}
+
+	return set
+}
+
+// collapsedDescription lists the names drawn as the collapsed node.
+func collapsedDescription(collapsed map[string]struct{}) string {
+	names := make([]string, 0, len(collapsed))
+	for name := range collapsed {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return strings.Join(names, ", ")
+}
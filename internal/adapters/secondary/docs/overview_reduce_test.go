@@ -0,0 +1,66 @@
+package docs
+
+import (
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReduceOverview(t *testing.T) {
+	t.Parallel()
+
+	schema := domain.Schema{Services: []domain.Service{
+		{
+			Info: domain.ServiceInfo{Name: "Orders"},
+			Relationships: []domain.Relationship{
+				{Action: domain.RelationshipActionRequests, Participant: "Billing"},
+				{Action: domain.RelationshipActionRequests, Participant: "Flagsmith", External: true},
+				{Action: domain.RelationshipActionSends, Participant: "Loki", External: true, Tags: []string{"logging"}},
+				{Action: domain.RelationshipActionRequests, Participant: "Prometheus", External: true},
+			},
+		},
+		{Info: domain.ServiceInfo{Name: "Billing"}},
+		{Info: domain.ServiceInfo{Name: "Audit", Tags: []string{"Logging"}}},
+	}}
+	edges := []asyncEdge{
+		{Source: "Orders", Target: "Billing", Channel: "orders.created", Kind: "pub"},
+		{Source: "Orders", Target: "Audit", Channel: "orders.created", Kind: "pub"},
+	}
+
+	unchanged, unchangedEdges := reduceOverview(schema, edges, config.Diagram{})
+	assert.Equal(t, schema, unchanged)
+	assert.Equal(t, edges, unchangedEdges)
+
+	reduced, reducedEdges := reduceOverview(schema, edges, config.Diagram{
+		Hide:          []string{"prometheus"},
+		Collapse:      []string{"logging", "Flagsmith"},
+		CollapseLabel: "Shared Dependencies",
+	})
+
+	require.Len(t, reduced.Services, 2, "the collapsed Audit service is no service of the overview")
+	assert.Equal(t, "Orders", reduced.Services[0].Info.Name)
+	assert.Equal(t, "Billing", reduced.Services[1].Info.Name)
+	assert.Equal(t, []asyncEdge{edges[0]}, reducedEdges)
+
+	shared := domain.Relationship{
+		Participant: "Shared Dependencies",
+		Description: "Audit, Flagsmith, Loki",
+		External:    true,
+	}
+
+	var participants []string
+	for _, rel := range reduced.Services[0].Relationships {
+		participants = append(participants, rel.Participant)
+		if rel.Participant == shared.Participant {
+			assert.Equal(t, shared.Description, rel.Description)
+			assert.True(t, rel.External)
+		}
+	}
+
+	assert.Equal(t, []string{"Billing", "Shared Dependencies", "Shared Dependencies", "Shared Dependencies"},
+		participants, "Flagsmith, Loki and the async edge to Audit lead to the collapsed node")
+	assert.Len(t, schema.Services[0].Relationships, 4, "the schema itself is left alone")
+}
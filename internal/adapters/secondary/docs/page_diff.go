@@ -0,0 +1,297 @@
+package docs
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+)
+
+const sectionSeparator = " / "
+
+var (
+	sectionPattern = regexp.MustCompile(`^(#{1,6})\s+(.+?)\s*$`)
+	// backLinkPattern matches the link back to the overview in the titles of multi-page documentation.
+	backLinkPattern = regexp.MustCompile(`^\[←\]\([^)]*\)\s*\|\s*`)
+)
+
+// pageSections holds the text of a page below every heading up to the next one, by heading path.
+type pageSections struct {
+	paths   []string
+	content map[string]string
+}
+
+// DiffDocs summarizes the changes of the Markdown pages between two directories of generated documentation:
+// the pages and sections added, removed and changed, and which of the services their documentation is about.
+// Nothing is summarized unless both paths are directories.
+func (g *Generator) DiffDocs(before, after string, services []string) (domain.DocsDiff, error) {
+	for _, dir := range []string{before, after} {
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			return domain.DocsDiff{}, nil
+		}
+	}
+
+	beforePages, err := readPages(before)
+	if err != nil {
+		return domain.DocsDiff{}, fmt.Errorf("reading pages of %s: %w", before, err)
+	}
+
+	afterPages, err := readPages(after)
+	if err != nil {
+		return domain.DocsDiff{}, fmt.Errorf("reading pages of %s: %w", after, err)
+	}
+
+	paths := make([]string, 0, len(beforePages)+len(afterPages))
+	for path := range beforePages {
+		paths = append(paths, path)
+	}
+
+	for path := range afterPages {
+		if _, exists := beforePages[path]; !exists {
+			paths = append(paths, path)
+		}
+	}
+
+	slices.Sort(paths)
+
+	known := make(map[string]bool, len(services))
+	for _, service := range services {
+		known[service] = true
+	}
+
+	var (
+		diff    domain.DocsDiff
+		changed = make(map[string]bool)
+	)
+
+	for _, path := range paths {
+		oldContent, existed := beforePages[path]
+		newContent, exists := afterPages[path]
+
+		if existed && exists && oldContent == newContent {
+			continue
+		}
+
+		page, touched := diffPage(path, parseSections(oldContent), parseSections(newContent))
+
+		switch {
+		case !existed:
+			page.Type = domain.ChangeTypeAdded
+		case !exists:
+			page.Type = domain.ChangeTypeRemoved
+		default:
+			page.Type = domain.ChangeTypeChanged
+		}
+
+		diff.Pages = append(diff.Pages, page)
+
+		for _, section := range touched {
+			for _, heading := range strings.Split(section, sectionSeparator) {
+				if known[heading] {
+					changed[heading] = true
+				}
+			}
+		}
+	}
+
+	for service := range changed {
+		diff.Services = append(diff.Services, service)
+	}
+
+	slices.Sort(diff.Services)
+
+	return diff, nil
+}
+
+// readPages returns the content of the Markdown pages below a directory by their slash separated paths.
+func readPages(dir string) (map[string]string, error) {
+	pages := make(map[string]string)
+
+	err := filepath.WalkDir(dir, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() || filepath.Ext(file) != ".md" {
+			return nil
+		}
+
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, file)
+		if err != nil {
+			return err
+		}
+
+		pages[filepath.ToSlash(rel)] = string(content)
+
+		return nil
+	})
+
+	return pages, err
+}
+
+// diffPage compares the sections of two versions of a page. Only the outermost of nested sections added or
+// removed together are listed. Every section added, removed or changed is returned as touched.
+func diffPage(path string, before, after pageSections) (domain.PageDiff, []string) {
+	page := domain.PageDiff{Path: path}
+
+	var touched []string
+
+	for _, section := range after.paths {
+		oldContent, existed := before.content[section]
+
+		switch {
+		case !existed:
+			touched = append(touched, section)
+			if !nestedIn(section, page.SectionsAdded) {
+				page.SectionsAdded = append(page.SectionsAdded, section)
+			}
+		case oldContent != after.content[section] && !isTOCSection(section):
+			touched = append(touched, section)
+			page.SectionsChanged = append(page.SectionsChanged, section)
+		}
+	}
+
+	for _, section := range before.paths {
+		if _, exists := after.content[section]; !exists {
+			touched = append(touched, section)
+			if !nestedIn(section, page.SectionsRemoved) {
+				page.SectionsRemoved = append(page.SectionsRemoved, section)
+			}
+		}
+	}
+
+	return page, touched
+}
+
+// isTOCSection reports whether a section is a table of contents, which changes with the headings.
+func isTOCSection(section string) bool {
+	label := strings.TrimPrefix(tocHeading, "## ")
+
+	return section == label || strings.HasSuffix(section, sectionSeparator+label)
+}
+
+func nestedIn(section string, parents []string) bool {
+	return slices.ContainsFunc(parents, func(parent string) bool {
+		return strings.HasPrefix(section, parent+sectionSeparator)
+	})
+}
+
+// parseSections splits a page into sections by its headings. The text above the first heading is left out.
+func parseSections(content string) pageSections {
+	sections := pageSections{content: make(map[string]string)}
+
+	var (
+		current string
+		text    strings.Builder
+	)
+
+	flush := func() {
+		if current != "" {
+			sections.content[current] = text.String()
+		}
+
+		text.Reset()
+	}
+
+	for _, line := range sectionLines(strings.Split(content, "\n")) {
+		if !line.heading {
+			// Anchors belong to the heading following them.
+			if !anchorPattern.MatchString(line.text) {
+				text.WriteString(line.text)
+				text.WriteByte('\n')
+			}
+
+			continue
+		}
+
+		flush()
+
+		current = line.section
+		sections.paths = append(sections.paths, current)
+	}
+
+	flush()
+
+	return sections
+}
+
+// sectionLine is a line of a page with the heading path of the section it belongs to.
+type sectionLine struct {
+	text    string
+	section string
+	heading bool
+}
+
+// sectionLines assigns the lines of a page to the sections of its headings, the lines above the first heading
+// to none. Headings repeated under the same parent are numbered. Headings in code blocks and kept blocks
+// don't start sections.
+func sectionLines(lines []string) []sectionLine {
+	type heading struct {
+		level int
+		label string
+	}
+
+	var (
+		result  = make([]sectionLine, 0, len(lines))
+		stack   []heading
+		current string
+		fenced  bool
+		kept    bool
+		seen    = make(map[string]int)
+	)
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~"):
+			fenced = !fenced
+		case strings.HasPrefix(trimmed, keepStartPrefix):
+			kept = true
+		case trimmed == keepEnd:
+			kept = false
+		}
+
+		match := sectionPattern.FindStringSubmatch(line)
+		if fenced || kept || match == nil {
+			result = append(result, sectionLine{text: line, section: current})
+
+			continue
+		}
+
+		level := len(match[1])
+		for len(stack) > 0 && stack[len(stack)-1].level >= level {
+			stack = stack[:len(stack)-1]
+		}
+
+		label := linkPattern.ReplaceAllString(backLinkPattern.ReplaceAllString(match[2], ""), "$1")
+		stack = append(stack, heading{level: level, label: label})
+
+		labels := make([]string, 0, len(stack))
+		for _, h := range stack {
+			labels = append(labels, h.label)
+		}
+
+		current = strings.Join(labels, sectionSeparator)
+
+		seen[current]++
+		if count := seen[current]; count > 1 {
+			current += " (" + strconv.Itoa(count) + ")"
+		}
+
+		result = append(result, sectionLine{text: line, section: current, heading: true})
+	}
+
+	return result
+}
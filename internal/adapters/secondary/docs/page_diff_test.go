@@ -0,0 +1,119 @@
+package docs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffDocs(t *testing.T) {
+	t.Parallel()
+
+	before, after := t.TempDir(), t.TempDir()
+
+	writePages := func(dir string, pages map[string]string) {
+		for name, content := range pages {
+			path := filepath.Join(dir, filepath.FromSlash(name))
+			require.NoError(t, os.MkdirAll(filepath.Dir(path), dirPerm))
+			require.NoError(t, os.WriteFile(path, []byte(content), filePerm))
+		}
+	}
+
+	writePages(before, map[string]string{
+		"README.md": `# Docs
+
+## Table of Contents
+
+- [Services](#services)
+
+## Services
+
+### Orders Service
+
+Takes orders.
+
+#### Relationships
+
+- Billing
+
+### Fax Service
+
+Sends faxes.
+
+#### Relationships
+
+- Printer
+`,
+		"services/fax-service.md":    "# [←](../README.md) | Fax Service\n",
+		"services/orders-service.md": "# [←](../README.md) | Orders Service\n\nTakes orders.\n",
+	})
+	writePages(after, map[string]string{
+		"README.md": `# Docs
+
+## Table of Contents
+
+- [Services](#services)
+- [Changelog](#changelog)
+
+## Services
+
+### Orders Service
+
+Takes and tracks orders.
+
+#### Relationships
+
+- Billing
+
+<a id="billing-service"></a>
+### Billing Service
+
+Bills.
+
+## Changelog
+
+` + "```\n# not a heading\n```\n",
+		"services/billing-service.md": "# [←](../README.md) | Billing Service\n",
+		"services/orders-service.md":  "# [←](../README.md) | Orders Service\n\nTakes and tracks orders.\n",
+	})
+
+	generator := setupTestGenerator(t, nil, &config.Config{})
+
+	diff, err := generator.DiffDocs(before, after, []string{"Orders Service", "Fax Service", "Billing Service"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []domain.PageDiff{
+		{
+			Path:            "README.md",
+			Type:            domain.ChangeTypeChanged,
+			SectionsAdded:   []string{"Docs / Services / Billing Service", "Docs / Changelog"},
+			SectionsRemoved: []string{"Docs / Services / Fax Service"},
+			SectionsChanged: []string{"Docs / Services / Orders Service"},
+		},
+		{
+			Path:          "services/billing-service.md",
+			Type:          domain.ChangeTypeAdded,
+			SectionsAdded: []string{"Billing Service"},
+		},
+		{
+			Path:            "services/fax-service.md",
+			Type:            domain.ChangeTypeRemoved,
+			SectionsRemoved: []string{"Fax Service"},
+		},
+		{
+			Path:            "services/orders-service.md",
+			Type:            domain.ChangeTypeChanged,
+			SectionsChanged: []string{"Orders Service"},
+		},
+	}, diff.Pages)
+	assert.Equal(t, []string{"Billing Service", "Fax Service", "Orders Service"}, diff.Services)
+
+	diff, err = generator.DiffDocs(filepath.Join(before, "README.md"), after, nil)
+	require.NoError(t, err)
+	assert.Empty(t, diff.Pages)
+}
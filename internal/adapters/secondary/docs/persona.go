@@ -0,0 +1,233 @@
+package docs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	d2target "github.com/holydocs/holydocs/internal/adapters/secondary/target/d2"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/holydocs/holydocs/pkg/outputfs"
+)
+
+// personasFileName is the personas page in multi-page documentation.
+const personasFileName = "personas.md"
+
+// personaAnchorPrefix keeps persona anchors apart from service anchors, personas are often named like teams.
+const personaAnchorPrefix = "persona-"
+
+// personaDiagramsDirName holds the persona diagrams inside the diagrams directory.
+const personaDiagramsDirName = "personas"
+
+type personaView struct {
+	Name    string
+	Anchor  string
+	Diagram string
+	D2      string
+	Systems []personaSystemView
+}
+
+type personaSystemView struct {
+	Name string
+	// Standalone groups the services without a system.
+	Standalone   bool
+	Interactions []personaInteractionView
+}
+
+type personaInteractionView struct {
+	Service     eventLink
+	Label       string
+	Technology  string
+	Description string
+	Planned     bool
+}
+
+// Reach summarizes how many services and systems the persona reaches, e.g. "3 services across 2 systems".
+func (v personaView) Reach() string {
+	services := make(map[string]struct{})
+	systems := 0
+
+	for _, system := range v.Systems {
+		if !system.Standalone {
+			systems++
+		}
+
+		for _, interaction := range system.Interactions {
+			services[interaction.Service.Name] = struct{}{}
+		}
+	}
+
+	reach := countNoun(len(services), "service", "services")
+	if systems > 0 {
+		reach += " across " + countNoun(systems, "system", "systems")
+	}
+
+	return reach
+}
+
+func countNoun(count int, singular, plural string) string {
+	if count == 1 {
+		return "1 " + singular
+	}
+
+	return fmt.Sprintf("%d %s", count, plural)
+}
+
+// generatePersonas renders a diagram per persona, aggregating the services the persona interacts with.
+func generatePersonas(ctx context.Context, fsys outputfs.FS, schema domain.Schema, target domain.Target,
+	diagramsDir string, recorder *diagramRecorder) ([]personaView, error) {
+	journeys := d2target.BuildPersonaJourneys(schema)
+	if len(journeys) == 0 {
+		return nil, nil
+	}
+
+	d2Target, ok := target.(*d2target.Target)
+	if !ok {
+		return nil, errors.New("target is not a D2 target")
+	}
+
+	personasDir := filepath.Join(diagramsDir, personaDiagramsDirName)
+	if err := fsys.MkdirAll(personasDir, dirPerm); err != nil {
+		return nil, fmt.Errorf("create persona diagrams directory: %w", err)
+	}
+
+	views := make([]personaView, 0, len(journeys))
+
+	for _, journey := range journeys {
+		view := buildPersonaView(journey)
+		fileBase := sanitizeFilename(journey.Persona)
+		svgPath := filepath.Join(personasDir, fileBase+".svg")
+
+		err := renderPersonaDiagram(ctx, fsys, d2Target, schema, journey, personasDir, fileBase)
+		if err == nil {
+			recorder.rendered()
+		} else if err := recorder.tolerate("persona diagram of "+journey.Persona, svgPath, err); err != nil {
+			return nil, err
+		}
+
+		view.Diagram = filepath.ToSlash(filepath.Join(diagramsDirName, personaDiagramsDirName, fileBase+".svg"))
+		view.D2 = filepath.ToSlash(filepath.Join(diagramsDirName, personaDiagramsDirName, fileBase+".d2"))
+
+		views = append(views, view)
+	}
+
+	return views, nil
+}
+
+func renderPersonaDiagram(ctx context.Context, fsys outputfs.FS, d2Target *d2target.Target, schema domain.Schema,
+	journey d2target.PersonaJourney, personasDir, fileBase string) error {
+	script, err := d2Target.GeneratePersonaDiagramScript(schema, journey)
+	if err != nil {
+		return fmt.Errorf("generate persona D2 script: %w", err)
+	}
+
+	if err := fsys.WriteFile(filepath.Join(personasDir, fileBase+".d2"), script, filePerm); err != nil {
+		return fmt.Errorf("write persona D2 script: %w", err)
+	}
+
+	diagram, err := d2Target.RenderSchema(ctx, domain.FormattedSchema{Type: "d2", Data: script})
+	if err != nil {
+		return fmt.Errorf("render persona diagram: %w", err)
+	}
+
+	if err := fsys.WriteFile(filepath.Join(personasDir, fileBase+".svg"), diagram, filePerm); err != nil {
+		return fmt.Errorf("write persona diagram: %w", err)
+	}
+
+	return nil
+}
+
+func buildPersonaView(journey d2target.PersonaJourney) personaView {
+	view := personaView{Name: journey.Persona, Anchor: personaAnchorPrefix + sanitizeAnchor(journey.Persona)}
+
+	for _, interaction := range journey.Interactions {
+		name := interaction.System
+		if name == "" {
+			name = standaloneServicesName
+		}
+
+		if len(view.Systems) == 0 || view.Systems[len(view.Systems)-1].Name != name {
+			view.Systems = append(view.Systems, personaSystemView{Name: name, Standalone: interaction.System == ""})
+		}
+
+		system := &view.Systems[len(view.Systems)-1]
+		system.Interactions = append(system.Interactions, personaInteractionView{
+			Service:     eventLink{Name: interaction.Service},
+			Label:       interaction.Label,
+			Technology:  interaction.Technology,
+			Description: strings.TrimSpace(interaction.Description),
+			Planned:     interaction.Planned,
+		})
+	}
+
+	return view
+}
+
+// linkPersonas links the services of persona journeys to their sections. In multi-page mode the personas
+// are written to their own page.
+func linkPersonas(data templateData, multiPage bool) templateData {
+	if len(data.Personas) == 0 {
+		return data
+	}
+
+	if multiPage {
+		data.PersonasPath = personasFileName
+	}
+
+	serviceLinks := make(map[string]string)
+	for _, system := range data.Systems {
+		for _, service := range system.Services {
+			serviceLinks[service.Name] = sectionLink(service.Anchor, service.FilePath, multiPage)
+		}
+	}
+
+	personas := make([]personaView, len(data.Personas))
+	for i, persona := range data.Personas {
+		systems := make([]personaSystemView, len(persona.Systems))
+		for j, system := range persona.Systems {
+			interactions := make([]personaInteractionView, len(system.Interactions))
+			for k, interaction := range system.Interactions {
+				interaction.Service.Link = serviceLinks[interaction.Service.Name]
+				interactions[k] = interaction
+			}
+
+			system.Interactions = interactions
+			systems[j] = system
+		}
+
+		persona.Systems = systems
+		personas[i] = persona
+	}
+
+	data.Personas = personas
+
+	return data
+}
+
+type personasPageData struct {
+	Personas []personaView
+}
+
+// writePersonasPage generates the personas page for multi-page mode.
+func writePersonasPage(pages site, outputDir string, data templateData) error {
+	tmpl, err := template.New("personas.tmpl").Funcs(pages.templateFuncs()).
+		ParseFS(multiPageTemplateFS, "templates/md_multi_page/personas.tmpl")
+	if err != nil {
+		return fmt.Errorf("parse personas template: %w", err)
+	}
+
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, personasPageData{Personas: data.Personas}); err != nil {
+		return fmt.Errorf("execute personas template: %w", err)
+	}
+
+	if err := pages.writePage(filepath.Join(outputDir, personasFileName), pageMeta{Title: "Personas"},
+		buf.String()); err != nil {
+		return fmt.Errorf("write personas page: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package docs
+
+import (
+	"testing"
+
+	d2target "github.com/holydocs/holydocs/internal/adapters/secondary/target/d2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildPersonaView(t *testing.T) {
+	t.Parallel()
+
+	view := buildPersonaView(d2target.PersonaJourney{
+		Persona: "Data Analyst",
+		Interactions: []d2target.PersonaInteraction{
+			{Service: "Dashboard Service", System: "Analytics", Label: "requests"},
+			{Service: "Reports Service", System: "Analytics", Label: "requests"},
+			{Service: "Reports Service", System: "Analytics", Label: "sends", Planned: true},
+			{Service: "Export Service", Label: "requests"},
+		},
+	})
+
+	assert.Equal(t, "persona-data-analyst", view.Anchor)
+	require.Len(t, view.Systems, 2)
+	assert.Equal(t, "Analytics", view.Systems[0].Name)
+	assert.Len(t, view.Systems[0].Interactions, 3)
+	assert.Equal(t, standaloneServicesName, view.Systems[1].Name)
+	assert.True(t, view.Systems[1].Standalone)
+	assert.Equal(t, "3 services across 1 system", view.Reach())
+
+	data := linkPersonas(templateData{
+		Systems: []systemView{{Name: "Analytics", Services: []serviceView{
+			{Name: "Reports Service", Anchor: "reports-service", FilePath: "services/reports-service.md"},
+		}}},
+		Personas: []personaView{view},
+	}, true)
+
+	assert.Equal(t, personasFileName, data.PersonasPath)
+	assert.Equal(t, "services/reports-service.md", data.Personas[0].Systems[0].Interactions[1].Service.Link)
+	assert.Empty(t, data.Personas[0].Systems[0].Interactions[0].Service.Link)
+	assert.Empty(t, view.Systems[0].Interactions[1].Service.Link, "linking must not modify the built view")
+
+	standalone := buildPersonaView(d2target.PersonaJourney{
+		Persona:      "Support Agent",
+		Interactions: []d2target.PersonaInteraction{{Service: "Export Service", Label: "requests"}},
+	})
+	assert.Equal(t, "1 service", standalone.Reach())
+}
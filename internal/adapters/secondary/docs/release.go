@@ -0,0 +1,289 @@
+package docs
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/holydocs/holydocs/pkg/outputfs"
+	"gopkg.in/yaml.v3"
+)
+
+// Release snapshots and their index.
+const (
+	releasesDirName       = "releases"
+	releaseFileName       = "release.json"
+	releasesIndexFileName = "README.md"
+	versionsFileName      = "versions.json"
+)
+
+// latestReleaseAlias names the newest release in versions.json.
+const latestReleaseAlias = "latest"
+
+// releaseVersionPattern matches versions usable as a directory name, such as v1.4.0 or 2024.10-rc.1.
+var releaseVersionPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._+-]*$`)
+
+// Release copies the documentation generated into req.OutputDir, domain.json included, to
+// releases/<version>/ and rewrites the releases index listing all snapshots.
+func (g *Generator) Release(req domain.ReleaseDocumentationRequest) (domain.ReleaseDocumentationReply, error) {
+	if !releaseVersionPattern.MatchString(req.Version) {
+		return domain.ReleaseDocumentationReply{}, fmt.Errorf("%w: release version %q, expected a name like v1.4.0",
+			domain.ErrUnsupportedValue, req.Version)
+	}
+
+	metadata, err := readMetadata(g.fs, req.OutputDir)
+	if err != nil {
+		return domain.ReleaseDocumentationReply{}, fmt.Errorf("error reading existing holydocs data: %w", err)
+	}
+
+	if metadata == nil {
+		return domain.ReleaseDocumentationReply{}, fmt.Errorf("%w in %s", domain.ErrMetadataNotFound,
+			filepath.Clean(req.OutputDir))
+	}
+
+	releasesDir := filepath.Join(req.OutputDir, releasesDirName)
+	releaseDir := filepath.Join(releasesDir, req.Version)
+
+	if _, err := g.fs.Stat(releaseDir); err == nil {
+		if !req.Force {
+			return domain.ReleaseDocumentationReply{}, fmt.Errorf("%w: %s", domain.ErrReleaseExists, releaseDir)
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return domain.ReleaseDocumentationReply{}, fmt.Errorf("checking release %s: %w", releaseDir, err)
+	}
+
+	if err := copyBundle(g.fs, req.OutputDir, releaseDir); err != nil {
+		return domain.ReleaseDocumentationReply{}, err
+	}
+
+	date := req.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	release := domain.Release{
+		Version:    req.Version,
+		ReleasedAt: date.UTC().Truncate(time.Second),
+		Services:   len(metadata.Schema.Services),
+	}
+
+	content, err := json.MarshalIndent(release, "", "  ")
+	if err != nil {
+		return domain.ReleaseDocumentationReply{}, fmt.Errorf("error marshaling release: %w", err)
+	}
+
+	if err := g.fs.WriteFile(filepath.Join(releaseDir, releaseFileName), content, filePerm); err != nil {
+		return domain.ReleaseDocumentationReply{}, fmt.Errorf("error writing release: %w", err)
+	}
+
+	releases, err := readReleases(g.fs, releasesDir)
+	if err != nil {
+		return domain.ReleaseDocumentationReply{}, err
+	}
+
+	output := config.Output{Dir: req.OutputDir}
+	if g.config != nil {
+		output = g.config.Output
+		output.Dir = req.OutputDir
+	}
+
+	if err := writeReleasesIndex(g.fs, releasesDir, releasesOverviewPath(newSite(g.fs, output)), releases); err != nil {
+		return domain.ReleaseDocumentationReply{}, err
+	}
+
+	if err := writeVersions(g.fs, releasesDir, releases); err != nil {
+		return domain.ReleaseDocumentationReply{}, err
+	}
+
+	// The snapshot and the current site select versions from now on, gen-docs keeps it so.
+	for _, dir := range []string{releaseDir, req.OutputDir} {
+		if err := versionMkDocsConfig(g.fs, filepath.Join(dir, mkDocsConfigFileName)); err != nil {
+			return domain.ReleaseDocumentationReply{}, err
+		}
+	}
+
+	return domain.ReleaseDocumentationReply{Dir: releaseDir, Releases: releases}, nil
+}
+
+// copyBundle replaces dst with a copy of the documentation in src, leaving out the release snapshots.
+func copyBundle(fsys outputfs.FS, src, dst string) error {
+	if err := fsys.RemoveAll(dst); err != nil {
+		return fmt.Errorf("failed to clean release directory: %w", err)
+	}
+
+	releasesDir := filepath.Join(src, releasesDirName)
+
+	err := outputfs.WalkDir(fsys, src, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if file == releasesDir {
+			return fs.SkipDir
+		}
+
+		rel, err := filepath.Rel(src, file)
+		if err != nil {
+			return err
+		}
+
+		target := filepath.Join(dst, rel)
+		if entry.IsDir() {
+			return fsys.MkdirAll(target, dirPerm)
+		}
+
+		content, err := fsys.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		return fsys.WriteFile(target, content, filePerm)
+	})
+	if err != nil {
+		return fmt.Errorf("copy documentation to %s: %w", dst, err)
+	}
+
+	return nil
+}
+
+// readReleases returns the releases snapshotted in the releases directory, newest first. Directories
+// without a release.json are not releases and are skipped.
+func readReleases(fsys outputfs.FS, releasesDir string) ([]domain.Release, error) {
+	entries, err := fsys.ReadDir(releasesDir)
+	if err != nil {
+		return nil, fmt.Errorf("reading releases: %w", err)
+	}
+
+	var releases []domain.Release
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		content, err := fsys.ReadFile(filepath.Join(releasesDir, entry.Name(), releaseFileName))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading release %s: %w", entry.Name(), err)
+		}
+
+		var release domain.Release
+		if err := json.Unmarshal(content, &release); err != nil {
+			return nil, fmt.Errorf("parsing release %s: %w", entry.Name(), err)
+		}
+
+		// The directory names the release, also when the file was copied along.
+		release.Version = entry.Name()
+		releases = append(releases, release)
+	}
+
+	slices.SortFunc(releases, func(a, b domain.Release) int {
+		return cmp.Or(b.ReleasedAt.Compare(a.ReleasedAt), strings.Compare(b.Version, a.Version))
+	})
+
+	return releases, nil
+}
+
+// releasesOverviewPath returns the path of the overview page of a snapshot relative to its directory.
+func releasesOverviewPath(s site) string {
+	rel, err := filepath.Rel(s.dir, s.contentDir)
+	if err != nil {
+		rel = "."
+	}
+
+	return filepath.ToSlash(filepath.Join(rel, s.overviewFile()))
+}
+
+// writeReleasesIndex writes the page listing the releases with links to their overview pages.
+func writeReleasesIndex(fsys outputfs.FS, releasesDir, overviewPath string, releases []domain.Release) error {
+	var b strings.Builder
+
+	b.WriteString("# Releases\n\n")
+	fmt.Fprintf(&b, "<!-- %s -->\n\n", generatedFileNotice)
+	b.WriteString("Architecture documentation as it was at each product release, newest first.\n\n")
+	b.WriteString("| Release | Date | Services |\n")
+	b.WriteString("|---------|------|----------|\n")
+
+	for _, release := range releases {
+		fmt.Fprintf(&b, "| [%s](%s/%s) | %s | %d |\n", release.Version, release.Version, overviewPath,
+			release.ReleasedAt.Format(time.DateOnly), release.Services)
+	}
+
+	if err := fsys.WriteFile(filepath.Join(releasesDir, releasesIndexFileName), []byte(b.String()), filePerm); err != nil {
+		return fmt.Errorf("error writing releases index: %w", err)
+	}
+
+	return nil
+}
+
+// siteVersion is an entry of versions.json, the format of mike read by the version selectors of sites.
+type siteVersion struct {
+	Version string   `json:"version"`
+	Title   string   `json:"title"`
+	Aliases []string `json:"aliases"`
+}
+
+// writeVersions writes versions.json listing the releases newest first, the newest aliased as latest.
+func writeVersions(fsys outputfs.FS, releasesDir string, releases []domain.Release) error {
+	versions := make([]siteVersion, 0, len(releases))
+
+	for i, release := range releases {
+		version := siteVersion{Version: release.Version, Title: release.Version, Aliases: []string{}}
+		if i == 0 {
+			version.Aliases = append(version.Aliases, latestReleaseAlias)
+		}
+
+		versions = append(versions, version)
+	}
+
+	content, err := json.MarshalIndent(versions, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling versions: %w", err)
+	}
+
+	if err := fsys.WriteFile(filepath.Join(releasesDir, versionsFileName), content, filePerm); err != nil {
+		return fmt.Errorf("error writing versions: %w", err)
+	}
+
+	return nil
+}
+
+// versionMkDocsConfig adds the version selector to a generated mkdocs.yml, a missing file is left out.
+func versionMkDocsConfig(fsys outputfs.FS, configPath string) error {
+	content, err := fsys.ReadFile(configPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", configPath, err)
+	}
+
+	var file mkDocsFile
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return fmt.Errorf("parsing %s: %w", configPath, err)
+	}
+
+	file.Extra = versionedMkDocsExtra()
+
+	content, err = encodeMkDocsConfig(file)
+	if err != nil {
+		return fmt.Errorf("building %s: %w", configPath, err)
+	}
+
+	if err := fsys.WriteFile(configPath, content, filePerm); err != nil {
+		return fmt.Errorf("writing %s: %w", configPath, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package docs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/holydocs/holydocs/pkg/outputfs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerator_Release(t *testing.T) {
+	t.Parallel()
+
+	outputDir, fsys := "docs", outputfs.NewMemory()
+	g := &Generator{fs: fsys, config: &config.Config{}}
+
+	_, err := g.Release(domain.ReleaseDocumentationRequest{OutputDir: outputDir, Version: "v1.3.0"})
+	require.ErrorIs(t, err, domain.ErrMetadataNotFound)
+
+	schema := domain.Schema{Services: []domain.Service{{Info: domain.ServiceInfo{Name: "User Service"}}}}
+	require.NoError(t, writeMetadata(fsys, outputDir, Metadata{Schema: schema}))
+	require.NoError(t, fsys.MkdirAll("docs/diagrams", dirPerm))
+	require.NoError(t, fsys.WriteFile("docs/README.md", []byte("# Overview"), filePerm))
+	require.NoError(t, fsys.WriteFile("docs/diagrams/overview.svg", []byte("<svg/>"), filePerm))
+
+	for _, version := range []string{"", "../v1", "v1/2", "."} {
+		_, err := g.Release(domain.ReleaseDocumentationRequest{OutputDir: outputDir, Version: version})
+		require.ErrorIs(t, err, domain.ErrUnsupportedValue, version)
+	}
+
+	older := time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)
+	reply, err := g.Release(domain.ReleaseDocumentationRequest{OutputDir: outputDir, Version: "v1.3.0", Date: older})
+	require.NoError(t, err)
+	assert.Equal(t, "docs/releases/v1.3.0", reply.Dir)
+
+	newer := older.AddDate(0, 1, 0)
+	reply, err = g.Release(domain.ReleaseDocumentationRequest{OutputDir: outputDir, Version: "v1.4.0", Date: newer})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Release{
+		{Version: "v1.4.0", ReleasedAt: newer, Services: 1},
+		{Version: "v1.3.0", ReleasedAt: older, Services: 1},
+	}, reply.Releases)
+
+	files := fsys.Files()
+	assert.Equal(t, "# Overview", string(files["docs/releases/v1.4.0/README.md"]))
+	assert.Equal(t, "<svg/>", string(files["docs/releases/v1.4.0/diagrams/overview.svg"]))
+	assert.Contains(t, files, "docs/releases/v1.4.0/domain.json")
+	assert.NotContains(t, files, "docs/releases/v1.4.0/releases/v1.3.0/README.md", "snapshots are not nested")
+
+	index := string(files["docs/releases/README.md"])
+	assert.Contains(t, index, "| [v1.4.0](v1.4.0/README.md) | 2024-06-02 | 1 |\n| [v1.3.0](v1.3.0/README.md) |")
+
+	assert.JSONEq(t, `[
+		{"version": "v1.4.0", "title": "v1.4.0", "aliases": ["latest"]},
+		{"version": "v1.3.0", "title": "v1.3.0", "aliases": []}
+	]`, string(files["docs/releases/versions.json"]))
+
+	_, err = g.Release(domain.ReleaseDocumentationRequest{OutputDir: outputDir, Version: "v1.4.0"})
+	require.ErrorIs(t, err, domain.ErrReleaseExists)
+
+	_, err = g.Release(domain.ReleaseDocumentationRequest{OutputDir: outputDir, Version: "v1.4.0", Force: true})
+	require.NoError(t, err)
+}
+
+func TestReleasesOverviewPath(t *testing.T) {
+	t.Parallel()
+
+	fsys := outputfs.NewMemory()
+
+	assert.Equal(t, "README.md", releasesOverviewPath(newSite(fsys, config.Output{Dir: "docs"})))
+	assert.Equal(t, "docs/README.md",
+		releasesOverviewPath(newSite(fsys, config.Output{Dir: "docs", Flavor: config.FlavorMkDocs})))
+	assert.Equal(t, "content/_index.md",
+		releasesOverviewPath(newSite(fsys, config.Output{Dir: "docs", Flavor: config.FlavorHugo})))
+}
+
+func TestGenerator_ReleaseVersionsMkDocs(t *testing.T) {
+	t.Parallel()
+
+	outputDir, fsys := "docs", outputfs.NewMemory()
+	output := config.Output{Dir: outputDir, Flavor: config.FlavorMkDocs}
+	g := &Generator{fs: fsys, config: &config.Config{Output: output}}
+
+	require.NoError(t, writeMetadata(fsys, outputDir, Metadata{}))
+
+	pages := newSite(fsys, output)
+	require.NoError(t, pages.writeConfig(siteTestData()))
+	assert.False(t, pages.versioned())
+
+	_, err := g.Release(domain.ReleaseDocumentationRequest{OutputDir: outputDir, Version: "v1.4.0"})
+	require.NoError(t, err)
+	assert.True(t, pages.versioned())
+
+	files := fsys.Files()
+	assert.Contains(t, string(files["docs/releases/README.md"]), "[v1.4.0](v1.4.0/docs/README.md)")
+
+	for _, configPath := range []string{"docs/mkdocs.yml", "docs/releases/v1.4.0/mkdocs.yml"} {
+		content := string(files[configPath])
+		assert.Contains(t, content, "  - Event Catalog: events.md\nextra:\n  version:\n"+
+			"    provider: mike\n    default: latest\n", configPath)
+	}
+
+	// Regenerated documentation keeps the version selector.
+	require.NoError(t, pages.writeConfig(siteTestData()))
+	content, err := fsys.ReadFile("docs/mkdocs.yml")
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "provider: mike")
+}
@@ -0,0 +1,155 @@
+package docs
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"text/template"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+)
+
+// repositoryLink is a deep link into the repository of a service.
+type repositoryLink struct {
+	Label string
+	URL   string
+}
+
+// repositoryPages are the pages of the repository of a service rendered from the templates of its SCM host.
+type repositoryPages struct {
+	// Links are the deep links to the README and the ServiceFile.
+	Links []repositoryLink
+	// OwnerURL is the page of the owning team.
+	OwnerURL string
+	// TreeURL is the directory of the service in a monorepo.
+	TreeURL string
+}
+
+// repositoryLinks renders the links configured for the SCM host of the repository of a service.
+func repositoryLinks(info domain.ServiceInfo, hosts map[string]config.RepositoryLinks) (repositoryPages, error) {
+	web, host, path, ok := parseRepository(info.Repository)
+	if !ok {
+		return repositoryPages{}, nil
+	}
+
+	links, found := hostLinks(hosts, host)
+	if !found {
+		return repositoryPages{}, nil
+	}
+
+	dir := ""
+	if info.Subpath != "" {
+		dir = info.Subpath + "/"
+	}
+
+	segments := strings.Split(path, "/")
+	fields := map[string]string{
+		"Repository": web,
+		"Host":       host,
+		"Path":       path,
+		"Org":        segments[0],
+		"Name":       segments[len(segments)-1],
+		"Subpath":    info.Subpath,
+		"Dir":        dir,
+		"Service":    info.Name,
+		"System":     info.System,
+		"Owner":      info.Owner,
+	}
+
+	render := func(label, link string) (string, error) {
+		if link == "" {
+			return "", nil
+		}
+
+		tmpl, err := template.New(label).Option("missingkey=error").Parse(link)
+		if err != nil {
+			return "", fmt.Errorf("parse %s link template of %s: %w", label, host, err)
+		}
+
+		var rendered strings.Builder
+		if err := tmpl.Execute(&rendered, fields); err != nil {
+			return "", fmt.Errorf("render %s link of %s: %w", label, info.Name, err)
+		}
+
+		return strings.TrimSpace(rendered.String()), nil
+	}
+
+	var pages repositoryPages
+
+	for _, link := range []repositoryLink{{Label: "README", URL: links.Readme}, {Label: "ServiceFile", URL: links.Servicefile}} {
+		rendered, err := render(link.Label, link.URL)
+		if err != nil {
+			return repositoryPages{}, err
+		}
+
+		if rendered != "" {
+			pages.Links = append(pages.Links, repositoryLink{Label: link.Label, URL: rendered})
+		}
+	}
+
+	if info.Subpath != "" {
+		treeURL, err := render("tree", links.Tree)
+		if err != nil {
+			return repositoryPages{}, err
+		}
+
+		pages.TreeURL = treeURL
+	}
+
+	if info.Owner != "" {
+		ownerURL, err := render("team", links.Team)
+		if err != nil {
+			return repositoryPages{}, err
+		}
+
+		pages.OwnerURL = ownerURL
+	}
+
+	return pages, nil
+}
+
+// parseRepository returns the web URL, the host and the path without the .git suffix of a repository URL,
+// also of SSH remotes such as git@github.com:org/repo.git, which are browsed over HTTPS.
+// hostLinks looks up the link templates configured for an SCM host, host names are case insensitive.
+func hostLinks(hosts map[string]config.RepositoryLinks, host string) (config.RepositoryLinks, bool) {
+	for name, links := range hosts {
+		if strings.EqualFold(name, host) {
+			return links, true
+		}
+	}
+
+	return config.RepositoryLinks{}, false
+}
+
+func parseRepository(repository string) (string, string, string, bool) {
+	repository = strings.TrimSpace(repository)
+
+	scheme := "https"
+
+	var host, path string
+
+	if user, remote, ok := strings.Cut(repository, "@"); ok && !strings.Contains(user, "/") &&
+		!strings.Contains(repository, "://") {
+		host, path, _ = strings.Cut(remote, ":")
+	} else {
+		parsed, err := url.Parse(repository)
+		if err != nil {
+			return "", "", "", false
+		}
+
+		host, path = parsed.Host, parsed.Path
+		if parsed.Scheme == "http" {
+			scheme = parsed.Scheme
+		}
+	}
+
+	host = strings.ToLower(host)
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+
+	if host == "" || path == "" {
+		return "", "", "", false
+	}
+
+	return scheme + "://" + host + "/" + path, host, path, true
+}
@@ -0,0 +1,69 @@
+package docs
+
+import (
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRepository(t *testing.T) {
+	t.Parallel()
+
+	for repository, want := range map[string][3]string{
+		"https://github.com/acme/orders":           {"https://github.com/acme/orders", "github.com", "acme/orders"},
+		"https://GitLab.com/acme/shop/orders.git/": {"https://gitlab.com/acme/shop/orders", "gitlab.com", "acme/shop/orders"},
+		"http://git.internal/acme/orders":          {"http://git.internal/acme/orders", "git.internal", "acme/orders"},
+		"git@github.com:acme/orders.git":           {"https://github.com/acme/orders", "github.com", "acme/orders"},
+		"ssh://git@bitbucket.org/acme/orders.git":  {"https://bitbucket.org/acme/orders", "bitbucket.org", "acme/orders"},
+	} {
+		web, host, path, ok := parseRepository(repository)
+		require.True(t, ok, repository)
+		assert.Equal(t, want, [3]string{web, host, path}, repository)
+	}
+
+	_, _, _, ok := parseRepository("orders")
+	assert.False(t, ok)
+}
+
+func TestRepositoryLinks(t *testing.T) {
+	t.Parallel()
+
+	hosts := map[string]config.RepositoryLinks{"github.com": {
+		Readme:      "{{ .Repository }}/blob/main/{{ .Dir }}README.md",
+		Servicefile: "{{ .Repository }}/blob/main/{{ .Name }}.servicefile.yaml",
+		Team:        "https://github.com/orgs/{{ .Org }}/teams/{{ .Owner }}",
+		Tree:        "{{ .Repository }}/tree/main/{{ .Subpath }}",
+	}}
+
+	pages, err := repositoryLinks(domain.ServiceInfo{
+		Name:       "Order Service",
+		Owner:      "orders-team",
+		Repository: "git@github.com:acme/orders.git",
+	}, hosts)
+	require.NoError(t, err)
+	assert.Equal(t, repositoryPages{
+		Links: []repositoryLink{
+			{Label: "README", URL: "https://github.com/acme/orders/blob/main/README.md"},
+			{Label: "ServiceFile", URL: "https://github.com/acme/orders/blob/main/orders.servicefile.yaml"},
+		},
+		OwnerURL: "https://github.com/orgs/acme/teams/orders-team",
+	}, pages)
+
+	pages, err = repositoryLinks(domain.ServiceInfo{
+		Name:       "Payment Service",
+		Repository: "https://github.com/acme/platform",
+		Subpath:    "services/payments",
+	}, hosts)
+	require.NoError(t, err)
+	assert.Equal(t, "https://github.com/acme/platform/blob/main/services/payments/README.md", pages.Links[0].URL)
+	assert.Equal(t, "https://github.com/acme/platform/tree/main/services/payments", pages.TreeURL)
+	assert.Empty(t, pages.OwnerURL)
+
+	pages, err = repositoryLinks(domain.ServiceInfo{Name: "Billing Service",
+		Repository: "https://gitlab.com/acme/billing"}, hosts)
+	require.NoError(t, err)
+	assert.Empty(t, pages)
+}
@@ -0,0 +1,71 @@
+package docs
+
+import (
+	"sort"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+)
+
+// riskView is an entry of the risk register, a risk of a service or of one of its relationships.
+type riskView struct {
+	Severity domain.Criticality
+	Type     string
+	Service  string
+	// Participant is the participant of the relationship at risk, empty for risks of the service.
+	Participant string
+	Owner       string
+	Description string
+}
+
+// buildRisks aggregates the risks annotated on services and relationships, the most severe first and then
+// by owner, risks of services without an owner last.
+func buildRisks(schema domain.Schema) []riskView {
+	var risks []riskView
+
+	for _, service := range schema.Services {
+		add := func(risk domain.Risk, participant string) {
+			risks = append(risks, riskView{
+				Severity:    risk.Severity,
+				Type:        risk.Type.Title(),
+				Service:     service.Info.Name,
+				Participant: participant,
+				Owner:       service.Info.Owner,
+				Description: risk.Description,
+			})
+		}
+
+		for _, risk := range service.Info.Risks {
+			add(risk, "")
+		}
+
+		for _, rel := range service.Relationships {
+			for _, risk := range rel.Risks {
+				add(risk, rel.Participant)
+			}
+		}
+	}
+
+	sort.SliceStable(risks, func(i, j int) bool {
+		a, b := risks[i], risks[j]
+
+		if a.Severity.Weight() != b.Severity.Weight() {
+			return a.Severity.Weight() > b.Severity.Weight()
+		}
+
+		if (a.Owner == "") != (b.Owner == "") {
+			return a.Owner != ""
+		}
+
+		if a.Owner != b.Owner {
+			return a.Owner < b.Owner
+		}
+
+		if a.Service != b.Service {
+			return a.Service < b.Service
+		}
+
+		return a.Participant < b.Participant
+	})
+
+	return risks
+}
@@ -0,0 +1,61 @@
+package docs
+
+import (
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/holydocs/holydocs/pkg/outputfs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildRisks(t *testing.T) {
+	t.Parallel()
+
+	schema := domain.Schema{Services: []domain.Service{
+		{
+			Info: domain.ServiceInfo{Name: "Reporting", Risks: []domain.Risk{
+				{Type: domain.RiskTypeEOLRuntime, Severity: domain.CriticalityHigh, Description: "Python 3.7"},
+			}},
+		},
+		{
+			Info: domain.ServiceInfo{Name: "Payments", Owner: "payments-team", Risks: []domain.Risk{
+				{Type: domain.RiskTypeSinglePointOfFailure, Severity: domain.CriticalityLow},
+			}},
+			Relationships: []domain.Relationship{{
+				Action: domain.RelationshipActionRequests, Participant: "Stripe",
+				Risks: []domain.Risk{{Type: domain.RiskTypeVendorLockIn, Severity: domain.CriticalityHigh}},
+			}},
+		},
+	}}
+
+	assert.Equal(t, []riskView{
+		{Severity: domain.CriticalityHigh, Type: "Vendor lock-in", Service: "Payments", Participant: "Stripe",
+			Owner: "payments-team"},
+		{Severity: domain.CriticalityHigh, Type: "End-of-life runtime", Service: "Reporting", Description: "Python 3.7"},
+		{Severity: domain.CriticalityLow, Type: "Single point of failure", Service: "Payments", Owner: "payments-team"},
+	}, buildRisks(schema))
+	assert.Empty(t, buildRisks(domain.Schema{}))
+}
+
+func TestWriteReadme_Risks(t *testing.T) {
+	t.Parallel()
+
+	fsys := outputfs.NewMemory()
+	require.NoError(t, fsys.MkdirAll("docs", dirPerm))
+	require.NoError(t, writeReadme(newSite(fsys, config.Output{Dir: "docs"}), templateData{
+		Title: "Test",
+		Risks: []riskView{
+			{Severity: domain.CriticalityHigh, Type: "Vendor lock-in", Service: "Payments", Participant: "Stripe",
+				Owner: "payments-team"},
+			{Severity: domain.CriticalityLow, Type: "End-of-life runtime", Service: "Reporting", Description: "Python 3.7"},
+		},
+	}))
+
+	readme, err := fsys.ReadFile("docs/README.md")
+	require.NoError(t, err)
+	assert.Contains(t, string(readme), "- [Risks](#risks)")
+	assert.Contains(t, string(readme), "| high | Vendor lock-in | Payments | Stripe | payments-team | — |")
+	assert.Contains(t, string(readme), "| low | End-of-life runtime | Reporting | — | — | Python 3.7 |")
+}
@@ -0,0 +1,98 @@
+package docs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"path/filepath"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/holydocs/holydocs/pkg/outputfs"
+)
+
+// runReportFileName is the run report written next to the generated documentation.
+const runReportFileName = "run-report.json"
+
+// failedDiagramSVG is the placeholder written in place of a diagram that failed with --keep-going.
+const failedDiagramSVG = `<svg xmlns="http://www.w3.org/2000/svg" width="640" height="120" viewBox="0 0 640 120">
+<rect x="1" y="1" width="638" height="118" fill="#fff5f5" stroke="#d73a49" stroke-width="2"/>
+<text x="20" y="50" font-family="sans-serif" font-size="16" fill="#d73a49">Failed to generate %s</text>
+<text x="20" y="80" font-family="sans-serif" font-size="12" fill="#586069">%s</text>
+</svg>
+`
+
+// diagramRecorder collects the diagrams rendered and skipped while generating documentation.
+type diagramRecorder struct {
+	stats     domain.DiagramStats
+	warnings  []string
+	keepGoing bool
+	errors    []string
+	// fs is where placeholders of failed diagrams are written.
+	fs outputfs.FS
+}
+
+func newDiagramRecorder(fsys outputfs.FS, keepGoing bool) *diagramRecorder {
+	return &diagramRecorder{stats: domain.DiagramStats{Skipped: []domain.SkippedDiagram{}}, keepGoing: keepGoing,
+		fs: fsys}
+}
+
+func (r *diagramRecorder) rendered() {
+	r.stats.Rendered++
+}
+
+// skipped records a diagram left out because it has nothing to show.
+func (r *diagramRecorder) skipped(diagram, reason string) {
+	r.stats.Skipped = append(r.stats.Skipped, domain.SkippedDiagram{Diagram: diagram, Reason: reason})
+}
+
+// missing records a diagram left out although its data suggests it should have been drawn, e.g. a channel
+// without message flow, which is also reported as a warning so --strict fails.
+func (r *diagramRecorder) missing(diagram, reason string) {
+	r.skipped(diagram, reason)
+	r.warnings = append(r.warnings, fmt.Sprintf("%s skipped: %s", diagram, reason))
+}
+
+// failed records a diagram left out because rendering it failed, which is also reported as a warning.
+func (r *diagramRecorder) failed(diagram string, err error) {
+	r.skipped(diagram, err.Error())
+	r.warnings = append(r.warnings, fmt.Sprintf("%s skipped: %v", diagram, err))
+}
+
+// tolerate returns err unless generation keeps going after failures, in which case the diagram is recorded
+// as skipped, the error is collected and a placeholder is written to svgPath, when set, so links keep working.
+func (r *diagramRecorder) tolerate(diagram, svgPath string, err error) error {
+	// Interrupted runs stop instead of replacing every remaining diagram with a placeholder.
+	if err == nil || !r.keepGoing || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+
+	r.skipped(diagram, err.Error())
+	r.errors = append(r.errors, fmt.Sprintf("%s: %v", diagram, err))
+
+	if svgPath == "" {
+		return nil
+	}
+
+	placeholder := fmt.Sprintf(failedDiagramSVG, html.EscapeString(diagram), html.EscapeString(err.Error()))
+	if err := r.fs.WriteFile(svgPath, []byte(placeholder), filePerm); err != nil {
+		return fmt.Errorf("writing placeholder of %s: %w", diagram, err)
+	}
+
+	return nil
+}
+
+// WriteRunReport writes the run report into the output directory.
+func (g *Generator) WriteRunReport(outputDir string, report domain.RunReport) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling run report: %w", err)
+	}
+
+	if err := g.fs.WriteFile(filepath.Join(outputDir, runReportFileName), data, filePerm); err != nil {
+		return fmt.Errorf("error writing run report: %w", err)
+	}
+
+	return nil
+}
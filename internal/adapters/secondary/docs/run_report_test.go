@@ -0,0 +1,143 @@
+package docs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/holydocs/holydocs/pkg/outputfs"
+	mf "github.com/holydocs/messageflow/pkg/messageflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiagramRecorder(t *testing.T) {
+	t.Parallel()
+
+	recorder := newDiagramRecorder(outputfs.OS(), false)
+	recorder.rendered()
+	recorder.rendered()
+	recorder.skipped("channel diagram of orders", "no message flow")
+	recorder.failed("message flow diagram of Billing", errors.New("format schema: boom"))
+	recorder.missing("channel diagram of payments", "no message flow")
+
+	assert.Equal(t, 2, recorder.stats.Rendered)
+	assert.Equal(t, []domain.SkippedDiagram{
+		{Diagram: "channel diagram of orders", Reason: "no message flow"},
+		{Diagram: "message flow diagram of Billing", Reason: "format schema: boom"},
+		{Diagram: "channel diagram of payments", Reason: "no message flow"},
+	}, recorder.stats.Skipped)
+	assert.Equal(t, []string{
+		"message flow diagram of Billing skipped: format schema: boom",
+		"channel diagram of payments skipped: no message flow",
+	}, recorder.warnings)
+}
+
+// emptyFlowTarget formats every message flow to nothing, as for a schema the diagrams can't draw.
+type emptyFlowTarget struct{}
+
+func (emptyFlowTarget) FormatSchema(context.Context, mf.Schema, mf.FormatOptions) (mf.FormattedSchema, error) {
+	return mf.FormattedSchema{}, nil
+}
+
+func (emptyFlowTarget) RenderSchema(context.Context, mf.FormattedSchema) ([]byte, error) {
+	return nil, nil
+}
+
+func (emptyFlowTarget) Capabilities() mf.TargetCapabilities {
+	return mf.TargetCapabilities{Format: true, Render: true}
+}
+
+func TestGenerateMessageFlowSection_MissingDiagrams(t *testing.T) {
+	t.Parallel()
+
+	schema := mf.Schema{Services: []mf.Service{
+		{Name: "Billing", Operation: []mf.Operation{{Action: mf.ActionSend, Channel: mf.Channel{Name: "invoices"}}}},
+		{Name: "Mailer"},
+	}}
+	fsys := outputfs.NewMemory()
+	recorder := newDiagramRecorder(fsys, false)
+
+	view, err := generateMessageFlowSection(context.Background(), fsys, schema, emptyFlowTarget{}, "messageflow",
+		newFileNames(domain.Schema{}, schema), recorder)
+	require.NoError(t, err)
+	assert.False(t, view.HasData)
+
+	assert.Empty(t, buildServiceFlowDiagram(context.Background(), fsys, domain.Service{
+		Info:      domain.ServiceInfo{Name: "Billing"},
+		Operation: []domain.Operation{{Action: domain.ActionSend, Channel: domain.Channel{Name: "invoices"}}},
+	}, schema, emptyFlowTarget{}, "messageflow", "billing", recorder))
+	assert.Empty(t, buildServiceFlowDiagram(context.Background(), fsys, domain.Service{
+		Info: domain.ServiceInfo{Name: "Mailer"},
+	}, schema, emptyFlowTarget{}, "messageflow", "mailer", recorder))
+
+	assert.Equal(t, []string{
+		"message flow context diagram skipped: no message flow",
+		"message flow diagram of Billing skipped: no message flow",
+	}, recorder.warnings)
+	assert.Equal(t, domain.SkippedDiagram{Diagram: "message flow diagram of Mailer", Reason: "no async operations"},
+		recorder.stats.Skipped[2])
+}
+
+func TestDiagramRecorder_Tolerate(t *testing.T) {
+	t.Parallel()
+
+	renderErr := errors.New("render <overview>: boom")
+
+	strict := newDiagramRecorder(outputfs.OS(), false)
+	require.ErrorIs(t, strict.tolerate("overview diagram", "", renderErr), renderErr)
+	assert.Empty(t, strict.stats.Skipped)
+	assert.Empty(t, strict.errors)
+
+	svgPath := filepath.Join(t.TempDir(), "overview.svg")
+	recorder := newDiagramRecorder(outputfs.OS(), true)
+	require.NoError(t, recorder.tolerate("overview diagram", svgPath, nil))
+	require.NoError(t, recorder.tolerate("overview diagram", svgPath, renderErr))
+
+	assert.Equal(t, []domain.SkippedDiagram{{Diagram: "overview diagram", Reason: renderErr.Error()}},
+		recorder.stats.Skipped)
+	assert.Equal(t, []string{"overview diagram: render <overview>: boom"}, recorder.errors)
+
+	placeholder, err := os.ReadFile(svgPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(placeholder), "Failed to generate overview diagram")
+	assert.Contains(t, string(placeholder), "render &lt;overview&gt;: boom")
+}
+
+func TestDiagramRecorder_TolerateCanceled(t *testing.T) {
+	t.Parallel()
+
+	recorder := newDiagramRecorder(outputfs.OS(), true)
+	err := recorder.tolerate("overview diagram", "", fmt.Errorf("render overview: %w", context.Canceled))
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, recorder.errors)
+}
+
+func TestGenerator_WriteRunReport(t *testing.T) {
+	t.Parallel()
+
+	outputDir, fsys := "docs", outputfs.NewMemory()
+	require.NoError(t, fsys.MkdirAll(outputDir, dirPerm))
+
+	report := domain.RunReport{
+		Version:  domain.RunReportVersion,
+		Sources:  domain.SourcesReport{ServiceFiles: 2, AsyncAPIFiles: 1, Services: 2},
+		Diagrams: domain.DiagramStats{Rendered: 3, Skipped: []domain.SkippedDiagram{}},
+		Warnings: []string{},
+	}
+
+	require.NoError(t, (&Generator{fs: fsys}).WriteRunReport(outputDir, report))
+
+	data, err := fsys.ReadFile(filepath.Join(outputDir, runReportFileName))
+	require.NoError(t, err)
+
+	var decoded domain.RunReport
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, report.Sources, decoded.Sources)
+	assert.Equal(t, 3, decoded.Diagrams.Rendered)
+}
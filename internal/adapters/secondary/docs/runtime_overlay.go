@@ -0,0 +1,113 @@
+package docs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	d2target "github.com/holydocs/holydocs/internal/adapters/secondary/target/d2"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/holydocs/holydocs/pkg/outputfs"
+)
+
+const runtimeOverlayDiagramName = "runtime-overlay"
+
+type runtimeOverlayView struct {
+	Diagram       string
+	D2            string
+	Relationships []runtimeRelationshipView
+}
+
+type runtimeRelationshipView struct {
+	Service     string
+	Participant string
+	Latency     string
+	ErrorRate   string
+	Objectives  string
+	Breached    bool
+}
+
+// HasData reports whether metrics of any relationship were observed.
+func (v runtimeOverlayView) HasData() bool {
+	return v.Diagram != ""
+}
+
+// generateRuntimeOverlay renders the overlay of the relationships with the metrics queried from Prometheus.
+func generateRuntimeOverlay(ctx context.Context, fsys outputfs.FS, schema domain.Schema,
+	metrics []domain.RelationshipMetrics, target domain.Target, diagramsDir string,
+	recorder *diagramRecorder) (runtimeOverlayView, error) {
+	d2Target, ok := target.(*d2target.Target)
+	if !ok {
+		return runtimeOverlayView{}, errors.New("target is not a D2 target")
+	}
+
+	script, err := d2Target.GenerateRuntimeOverlayScript(schema, metrics)
+	if err != nil {
+		return runtimeOverlayView{}, fmt.Errorf("generate runtime overlay D2 script: %w", err)
+	}
+
+	if len(script) == 0 {
+		return runtimeOverlayView{}, nil
+	}
+
+	d2Path := filepath.Join(diagramsDir, runtimeOverlayDiagramName+".d2")
+	if err := fsys.WriteFile(d2Path, script, filePerm); err != nil {
+		return runtimeOverlayView{}, fmt.Errorf("write runtime overlay D2 script: %w", err)
+	}
+
+	diagram, err := d2Target.RenderSchema(ctx, domain.FormattedSchema{Type: "d2", Data: script})
+	if err != nil {
+		return runtimeOverlayView{}, fmt.Errorf("render runtime overlay diagram: %w", err)
+	}
+
+	svgPath := filepath.Join(diagramsDir, runtimeOverlayDiagramName+".svg")
+	if err := fsys.WriteFile(svgPath, diagram, filePerm); err != nil {
+		return runtimeOverlayView{}, fmt.Errorf("write runtime overlay diagram: %w", err)
+	}
+	recorder.rendered()
+
+	return runtimeOverlayView{
+		Diagram:       filepath.ToSlash(filepath.Join(diagramsDirName, runtimeOverlayDiagramName+".svg")),
+		D2:            filepath.ToSlash(filepath.Join(diagramsDirName, runtimeOverlayDiagramName+".d2")),
+		Relationships: buildRuntimeRelationships(metrics),
+	}, nil
+}
+
+// buildRuntimeRelationships lists the observed metrics of relationships with the objectives they are held
+// against, e.g. "p95 ≤ 500 ms, errors ≤ 1%".
+func buildRuntimeRelationships(metrics []domain.RelationshipMetrics) []runtimeRelationshipView {
+	views := make([]runtimeRelationshipView, 0, len(metrics))
+
+	for _, m := range metrics {
+		view := runtimeRelationshipView{
+			Service:     m.Service,
+			Participant: m.Participant,
+			Breached:    m.Breached(),
+		}
+
+		if m.P95Latency != nil {
+			view.Latency = d2target.FormatLatency(*m.P95Latency)
+		}
+
+		if m.ErrorRate != nil {
+			view.ErrorRate = d2target.FormatErrorRate(*m.ErrorRate)
+		}
+
+		var objectives []string
+
+		if m.LatencySLO > 0 {
+			objectives = append(objectives, "p95 ≤ "+d2target.FormatLatency(m.LatencySLO))
+		}
+
+		if m.ErrorRateSLO > 0 {
+			objectives = append(objectives, "errors ≤ "+d2target.FormatErrorRate(m.ErrorRateSLO))
+		}
+
+		view.Objectives = strings.Join(objectives, ", ")
+		views = append(views, view)
+	}
+
+	return views
+}
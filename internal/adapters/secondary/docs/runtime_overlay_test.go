@@ -0,0 +1,58 @@
+package docs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	d2target "github.com/holydocs/holydocs/internal/adapters/secondary/target/d2"
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/holydocs/holydocs/pkg/outputfs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateRuntimeOverlay(t *testing.T) {
+	t.Parallel()
+
+	target, err := d2target.NewTarget(config.D2Config{Pad: 64, Font: "SourceSansPro", Layout: "elk"})
+	require.NoError(t, err)
+
+	fsys := outputfs.NewMemory()
+	require.NoError(t, fsys.MkdirAll("docs/diagrams", dirPerm))
+	recorder := newDiagramRecorder(fsys, false)
+
+	view, err := generateRuntimeOverlay(context.Background(), fsys, domain.Schema{}, nil, target, "docs/diagrams",
+		recorder)
+	require.NoError(t, err)
+	assert.False(t, view.HasData(), "no overlay without metrics")
+
+	latency, errorRate := 1200*time.Millisecond, 0.004
+	view, err = generateRuntimeOverlay(context.Background(), fsys, domain.Schema{}, []domain.RelationshipMetrics{
+		{Service: "Orders", Participant: "Payments", P95Latency: &latency, ErrorRate: &errorRate,
+			LatencySLO: time.Second, ErrorRateSLO: 0.01},
+		{Service: "Orders", Participant: "Stripe", ErrorRate: &errorRate},
+	}, target, "docs/diagrams", recorder)
+	require.NoError(t, err)
+
+	assert.Equal(t, "diagrams/runtime-overlay.svg", view.Diagram)
+	assert.Equal(t, []runtimeRelationshipView{
+		{Service: "Orders", Participant: "Payments", Latency: "1.2 s", ErrorRate: "0.4%",
+			Objectives: "p95 ≤ 1 s, errors ≤ 1%", Breached: true},
+		{Service: "Orders", Participant: "Stripe", ErrorRate: "0.4%"},
+	}, view.Relationships)
+
+	script, err := fsys.ReadFile("docs/diagrams/runtime-overlay.d2")
+	require.NoError(t, err)
+	assert.Contains(t, string(script), `"p95 1.2 s · 0.4% errors"`)
+	assert.Equal(t, 1, recorder.stats.Rendered)
+
+	require.NoError(t, writeReadme(newSite(fsys, config.Output{Dir: "docs"}), templateData{RuntimeOverlay: view}))
+
+	readme, err := fsys.ReadFile("docs/README.md")
+	require.NoError(t, err)
+	assert.Contains(t, string(readme), "- [Runtime Overlay](#runtime-overlay)")
+	assert.Contains(t, string(readme), "| Orders | Payments | 1.2 s | 0.4% | ❌ p95 ≤ 1 s, errors ≤ 1% |\n"+
+		"| Orders | Stripe | — | 0.4% | — |\n")
+}
@@ -0,0 +1,117 @@
+package docs
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/slug"
+	"github.com/holydocs/holydocs/pkg/outputfs"
+)
+
+// serviceBadgesDirName holds the badges of services in the output directory. Unlike the diagrams, their
+// paths stay the same between runs, so repositories of services can embed them.
+const serviceBadgesDirName = "badges"
+
+// Colors of the badges of services.
+const (
+	documentedBadgeColor = "#4c1"
+	plannedBadgeColor    = "#9f9f9f"
+	deprecatedBadgeColor = "#fe7d37"
+	infoBadgeColor       = "#007ec6"
+)
+
+type serviceBadge struct {
+	file    string
+	label   string
+	message string
+	color   string
+}
+
+// serviceBadges returns the badges of a service: its documentation status, its owner and its tags listed
+// under documentation.badges.tags.
+func serviceBadges(service serviceView, tags []string) []serviceBadge {
+	status := serviceBadge{file: "documented", label: "architecture", message: "documented ✓",
+		color: documentedBadgeColor}
+
+	switch {
+	case service.Deprecated:
+		status.message, status.color = "deprecated", deprecatedBadgeColor
+	case service.Planned:
+		status.message, status.color = "planned", plannedBadgeColor
+	}
+
+	badges := []serviceBadge{status}
+
+	if service.Owner != "" {
+		badges = append(badges, serviceBadge{file: "owner", label: "owner", message: service.Owner,
+			color: infoBadgeColor})
+	}
+
+	for _, tag := range tags {
+		if slices.Contains(service.Tags, tag) {
+			badges = append(badges, serviceBadge{file: "tag-" + slug.Make(tag), label: "tag", message: tag,
+				color: infoBadgeColor})
+		}
+	}
+
+	return badges
+}
+
+// writeServiceBadges renders the badges of every service into badges/<service>/ of the output directory.
+// With a base URL, services get a Markdown snippet embedding their badges, linked to their documentation.
+func writeServiceBadges(fsys outputfs.FS, data templateData, outputDir, format string,
+	cfg config.BadgesDocumentation) (templateData, error) {
+	if !cfg.Enabled {
+		return data, nil
+	}
+
+	badgesDir := filepath.Join(outputDir, serviceBadgesDirName)
+	if err := fsys.RemoveAll(badgesDir); err != nil {
+		return data, fmt.Errorf("clean badges directory: %w", err)
+	}
+
+	baseURL := strings.TrimSuffix(cfg.BaseURL, "/")
+
+	for i := range data.Systems {
+		for j := range data.Systems[i].Services {
+			service := &data.Systems[i].Services[j]
+
+			serviceDir := filepath.Join(badgesDir, service.FileName)
+			if err := fsys.MkdirAll(serviceDir, dirPerm); err != nil {
+				return data, fmt.Errorf("create badges directory of %s: %w", service.Name, err)
+			}
+
+			link := serviceDocsLink(baseURL, format, service.Anchor, service.FileName)
+
+			var snippet []string
+
+			for _, badge := range serviceBadges(*service, cfg.Tags) {
+				svg := badgeSVG(badge.label, badge.message, badge.color)
+				if err := fsys.WriteFile(filepath.Join(serviceDir, badge.file+".svg"), svg, filePerm); err != nil {
+					return data, fmt.Errorf("write %s badge of %s: %w", badge.file, service.Name, err)
+				}
+
+				snippet = append(snippet, fmt.Sprintf("[![%s: %s](%s/%s/%s/%s.svg)](%s)", badge.label, badge.message,
+					baseURL, serviceBadgesDirName, service.FileName, badge.file, link))
+			}
+
+			if baseURL != "" {
+				service.BadgesSnippet = strings.Join(snippet, "\n")
+			}
+		}
+	}
+
+	return data, nil
+}
+
+// serviceDocsLink links the documentation of a service published at baseURL.
+func serviceDocsLink(baseURL, format, anchor, fileName string) string {
+	if format == "md_multi_page" {
+		return baseURL + "/services/" + fileName + ".md"
+	}
+
+	return baseURL + "/#" + anchor
+}
@@ -0,0 +1,76 @@
+package docs
+
+import (
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/pkg/outputfs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteServiceBadges(t *testing.T) {
+	t.Parallel()
+
+	fsys := outputfs.NewMemory()
+	require.NoError(t, fsys.MkdirAll("docs/badges/removed-service", dirPerm))
+
+	data := templateData{Systems: []systemView{{Services: []serviceView{
+		{Name: "Order Service", Anchor: "order-service", FileName: "order-service", Owner: "R&D Payments",
+			Tags: []string{"tier-1", "go"}},
+		{Name: "Legacy Service", Anchor: "legacy-service", FileName: "legacy-service", Deprecated: true},
+	}}}}
+	cfg := config.BadgesDocumentation{Enabled: true, Tags: []string{"tier-1", "pci"}}
+
+	data, err := writeServiceBadges(fsys, data, "docs", "md_single_page", cfg)
+	require.NoError(t, err)
+
+	for _, path := range []string{
+		"docs/badges/order-service/documented.svg",
+		"docs/badges/order-service/owner.svg",
+		"docs/badges/order-service/tag-tier-1.svg",
+		"docs/badges/legacy-service/documented.svg",
+	} {
+		_, err := fsys.Stat(path)
+		require.NoError(t, err, path)
+	}
+
+	_, err = fsys.Stat("docs/badges/removed-service")
+	require.Error(t, err, "badges of removed services are cleaned up")
+
+	owner, err := fsys.ReadFile("docs/badges/order-service/owner.svg")
+	require.NoError(t, err)
+	assert.Contains(t, string(owner), "<title>owner: R&amp;D Payments</title>")
+
+	legacy, err := fsys.ReadFile("docs/badges/legacy-service/documented.svg")
+	require.NoError(t, err)
+	assert.Contains(t, string(legacy), `fill="#fe7d37"`)
+	assert.Empty(t, data.Systems[0].Services[0].BadgesSnippet, "no snippet without a base URL")
+
+	cfg.BaseURL = "https://docs.example.com/"
+	data, err = writeServiceBadges(fsys, data, "docs", "md_multi_page", cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "[![architecture: documented ✓](https://docs.example.com/badges/order-service/documented.svg)]"+
+		"(https://docs.example.com/services/order-service.md)\n"+
+		"[![owner: R&D Payments](https://docs.example.com/badges/order-service/owner.svg)]"+
+		"(https://docs.example.com/services/order-service.md)\n"+
+		"[![tag: tier-1](https://docs.example.com/badges/order-service/tag-tier-1.svg)]"+
+		"(https://docs.example.com/services/order-service.md)", data.Systems[0].Services[0].BadgesSnippet)
+
+	require.NoError(t, fsys.MkdirAll("docs/services", dirPerm))
+	require.NoError(t, writeServicePage(newSite(fsys, config.Output{Dir: "docs"}), "docs/services",
+		data.Systems[0].Services[1], nil))
+
+	page, err := fsys.ReadFile("docs/services/legacy-service.md")
+	require.NoError(t, err)
+	assert.Contains(t, string(page), "## Badges\n\nEmbed the badges of the service into the README of its repository:\n\n"+
+		"```markdown\n[![architecture: deprecated](https://docs.example.com/badges/legacy-service/documented.svg)]"+
+		"(https://docs.example.com/services/legacy-service.md)\n```\n")
+
+	require.NoError(t, writeReadme(newSite(fsys, config.Output{Dir: "docs"}), data))
+
+	readme, err := fsys.ReadFile("docs/README.md")
+	require.NoError(t, err)
+	assert.Contains(t, string(readme), "      - [Badges](#legacy-service-badges)\n")
+	assert.Contains(t, string(readme), "<a id=\"legacy-service-badges\"></a>\n##### Badges\n\n")
+}
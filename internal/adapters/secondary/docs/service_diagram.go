@@ -0,0 +1,125 @@
+package docs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	d2target "github.com/holydocs/holydocs/internal/adapters/secondary/target/d2"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	mf "github.com/holydocs/messageflow/pkg/messageflow"
+)
+
+// GenerateServiceDiagram renders a single diagram for one service, the same diagram
+// that would be embedded into the generated documentation, or the neighborhood of a
+// service or system.
+func (g *Generator) GenerateServiceDiagram(
+	ctx context.Context,
+	schema domain.Schema,
+	messageflowSchema mf.Schema,
+	messageflowTarget mf.Target,
+	req domain.GenerateServiceDiagramRequest,
+) ([]byte, error) {
+	schema = schema.AliasExternalParticipants(g.config.Documentation.ExternalAliases)
+	schema.Sort()
+	messageflowSchema.Sort()
+
+	if req.Format != domain.DiagramFormatSVG && req.Format != domain.DiagramFormatD2 {
+		return nil, fmt.Errorf("%w: diagram format %q", domain.ErrUnsupportedValue, req.Format)
+	}
+
+	if req.Type == domain.ServiceDiagramFocus {
+		return g.focusDiagram(ctx, schema, messageflowSchema, req)
+	}
+
+	service, ok := findService(schema, req.Service)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", domain.ErrServiceNotFound, req.Service)
+	}
+
+	switch req.Type {
+	case domain.ServiceDiagramRelationships:
+		return g.serviceRelationshipsDiagram(ctx, service, schema, messageflowSchema, req.Format)
+	case domain.ServiceDiagramFlow:
+		return serviceFlowDiagram(ctx, service, messageflowSchema, messageflowTarget, req.Format)
+	default:
+		return nil, fmt.Errorf("%w: diagram type %q", domain.ErrUnsupportedValue, req.Type)
+	}
+}
+
+func (g *Generator) serviceRelationshipsDiagram(
+	ctx context.Context,
+	service domain.Service,
+	schema domain.Schema,
+	messageflowSchema mf.Schema,
+	format domain.DiagramFormat,
+) ([]byte, error) {
+	d2Target, ok := g.target.(*d2target.Target)
+	if !ok {
+		return nil, errors.New("target is not a D2 target")
+	}
+
+	edgesByService := buildEdgesByServiceMap(buildAsyncEdges(messageflowSchema))
+	serviceEdges := convertAsyncEdges(edgesByService[service.Info.Name])
+
+	if format == domain.DiagramFormatD2 {
+		script, err := d2Target.GenerateServiceRelationshipsDiagramScript(service, schema.Services, serviceEdges)
+		if err != nil {
+			return nil, fmt.Errorf("generate service relationships D2 script: %w", err)
+		}
+
+		return script, nil
+	}
+
+	diagram, err := d2Target.GenerateServiceRelationshipsDiagram(ctx, service, schema.Services, serviceEdges)
+	if err != nil {
+		return nil, fmt.Errorf("render service relationships diagram: %w", err)
+	}
+
+	return diagram, nil
+}
+
+func serviceFlowDiagram(
+	ctx context.Context,
+	service domain.Service,
+	messageflowSchema mf.Schema,
+	messageflowTarget mf.Target,
+	format domain.DiagramFormat,
+) ([]byte, error) {
+	if messageflowTarget == nil || len(messageflowSchema.Services) == 0 {
+		return nil, fmt.Errorf("%w: no AsyncAPI specifications loaded", domain.ErrNoDiagramData)
+	}
+
+	formatted, err := messageflowTarget.FormatSchema(ctx, messageflowSchema, mf.FormatOptions{
+		Mode:    mf.FormatModeServiceServices,
+		Service: service.Info.Name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("format schema: %w", err)
+	}
+
+	if len(formatted.Data) == 0 {
+		return nil, fmt.Errorf("%w: %s has no message flow", domain.ErrNoDiagramData, service.Info.Name)
+	}
+
+	if format == domain.DiagramFormatD2 {
+		return formatted.Data, nil
+	}
+
+	diagram, err := messageflowTarget.RenderSchema(ctx, formatted)
+	if err != nil {
+		return nil, fmt.Errorf("render schema: %w", err)
+	}
+
+	return diagram, nil
+}
+
+func findService(schema domain.Schema, name string) (domain.Service, bool) {
+	for _, service := range schema.Services {
+		if service.Info.Name == name {
+			return service, true
+		}
+	}
+
+	return domain.Service{}, false
+}
@@ -0,0 +1,580 @@
+package docs
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/pkg/outputfs"
+	"gopkg.in/yaml.v3"
+)
+
+// Directories of flavored documentation. The output directory becomes the root of the site, as in the
+// default layouts of the site generators.
+const (
+	siteContentDirName = "docs"
+	hugoContentDirName = "content"
+	hugoAssetsDirName  = "assets"
+)
+
+// Files of the overview page, Hugo renders the home page from _index.md.
+const (
+	overviewFileName     = "README.md"
+	hugoOverviewFileName = "_index.md"
+)
+
+// Files describing the navigation of a site.
+const (
+	mkDocsConfigFileName      = "mkdocs.yml"
+	docusaurusSidebarFileName = "sidebars.js"
+	docusaurusSidebarName     = "holydocs"
+)
+
+// yamlIndent is the indentation of mkdocs.yml.
+const yamlIndent = 2
+
+// generatedFileNotice heads site files, which are rewritten on every run.
+const generatedFileNotice = "Generated by HolyDOCs, changes are overwritten."
+
+// site describes where documentation pages are written and how they are prepared for the site generator
+// of the flavor.
+type site struct {
+	flavor        string
+	embedDiagrams bool
+	// frontMatter holds the configured fields added to the front matter of every page.
+	frontMatter map[string]any
+	// tocDepth is the number of heading levels listed in the generated tables of contents, none are
+	// generated when it is 0.
+	tocDepth int
+	// dir is the root of the site, contentDir holds the pages.
+	dir        string
+	contentDir string
+	// weights orders pages by their path relative to contentDir.
+	weights map[string]int
+	// interactive enables the pan and zoom viewers of diagrams, see viewers.
+	interactive bool
+	// assetURLs holds the URLs of diagrams uploaded to a bucket by their path relative to the diagrams
+	// base directory.
+	assetURLs map[string]string
+	// fs is where pages are written.
+	fs outputfs.FS
+}
+
+func newSite(fsys outputfs.FS, output config.Output) site {
+	s := site{
+		fs:            fsys,
+		flavor:        output.Flavor,
+		embedDiagrams: output.EmbedDiagrams,
+		frontMatter:   output.FrontMatter,
+		tocDepth:      output.TOC.Depth,
+		dir:           output.Dir,
+		contentDir:    output.Dir,
+	}
+
+	switch output.Flavor {
+	case "":
+	case config.FlavorHugo:
+		s.contentDir = filepath.Join(output.Dir, hugoContentDirName)
+	default:
+		s.contentDir = filepath.Join(output.Dir, siteContentDirName)
+	}
+
+	return s
+}
+
+// diagramsBaseDir returns the directory holding the diagrams directory. Hugo publishes diagrams from its
+// assets, where the figure shortcode finds them by their path.
+func (s site) diagramsBaseDir() string {
+	if s.flavor == config.FlavorHugo {
+		return filepath.Join(s.dir, hugoAssetsDirName)
+	}
+
+	return s.contentDir
+}
+
+// overviewFile returns the file name of the overview page.
+func (s site) overviewFile() string {
+	if s.flavor == config.FlavorHugo {
+		return hugoOverviewFileName
+	}
+
+	return overviewFileName
+}
+
+// templateFuncs returns the functions of the page templates.
+func (s site) templateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"Anchor":       sanitizeAnchor,
+		"Join":         strings.Join,
+		"lower":        strings.ToLower,
+		"Figure":       s.figure,
+		"OverviewPage": s.overviewFile,
+		"GeneratedTOC": s.generatedTOC,
+	}
+}
+
+// generatedTOC reports whether pages get a generated table of contents, replacing the one of the template.
+func (s site) generatedTOC() bool {
+	return s.tocDepth > 0
+}
+
+// figure returns the Markdown showing a diagram, linking the uploaded diagram when it was uploaded to a
+// bucket and with the diagram inlined as a data URI when embedding is enabled. For Hugo it is a figure
+// shortcode whose source is the diagram path from the site root, as relative paths do not survive Hugo's
+// page URLs.
+func (s site) figure(alt, src string) (string, error) {
+	if url, ok := s.assetURLs[diagramPath(src)]; ok {
+		src = url
+	} else if s.embedDiagrams {
+		embedded, err := s.embedDiagram(src)
+		if err != nil {
+			return "", err
+		}
+
+		src = embedded
+	} else if s.flavor == config.FlavorHugo {
+		src = diagramPath(src)
+	}
+
+	if s.flavor == config.FlavorHugo {
+		return "{{< figure src=" + strconv.Quote(src) + " alt=" + strconv.Quote(alt) + " >}}", nil
+	}
+
+	if viewer := s.viewerPath(src); viewer != "" {
+		return "![" + alt + "](" + src + ")\n\n[Open interactive diagram](" + viewer + ")", nil
+	}
+
+	return "![" + alt + "](" + src + ")", nil
+}
+
+// embedDiagram returns the diagram as a data URI, so pages don't depend on the diagram files.
+func (s site) embedDiagram(src string) (string, error) {
+	diagramFile := filepath.Join(s.diagramsBaseDir(), filepath.FromSlash(diagramPath(src)))
+
+	content, err := s.fs.ReadFile(diagramFile)
+	if err != nil {
+		return "", fmt.Errorf("embedding diagram: %w", err)
+	}
+
+	mediaType := mime.TypeByExtension(filepath.Ext(diagramFile))
+	if mediaType == "" {
+		mediaType = http.DetectContentType(content)
+	}
+
+	mediaType, _, _ = strings.Cut(mediaType, ";")
+
+	return "data:" + mediaType + ";base64," + base64.StdEncoding.EncodeToString(content), nil
+}
+
+// diagramPath returns the path of a diagram linked from a page relative to the diagrams base directory.
+func diagramPath(src string) string {
+	src = path.Clean(src)
+	for strings.HasPrefix(src, "../") {
+		src = strings.TrimPrefix(src, "../")
+	}
+
+	return src
+}
+
+// pageFrontMatter holds the front matter of a page of flavored documentation.
+type pageFrontMatter struct {
+	Title  string   `yaml:"title"`
+	Weight int      `yaml:"weight,omitempty"`
+	Tags   []string `yaml:"tags,omitempty"`
+}
+
+// pageMeta describes a page for its front matter.
+type pageMeta struct {
+	Title string
+	Tags  []string
+	// Owner and System are set on the pages of services and systems, configured front matter refers to them.
+	Owner  string
+	System string
+}
+
+// writePage writes a page, preceded by front matter when the documentation has a flavor or front matter is
+// configured, with a generated table of contents when its depth is configured and the kept blocks of the page
+// it replaces. Weights and tags are only written for Hugo, which orders and groups pages by them. Configured
+// fields follow and can't replace the generated ones.
+func (s site) writePage(pagePath string, meta pageMeta, content string) error {
+	if s.generatedTOC() {
+		content = insertTOC(content, s.tocDepth)
+	}
+
+	content = preserveKept(s.fs, pagePath, content)
+
+	var frontMatter strings.Builder
+
+	generated := make(map[string]struct{})
+
+	if s.flavor != "" {
+		flavored := pageFrontMatter{Title: meta.Title}
+
+		if s.flavor == config.FlavorHugo {
+			if rel, err := filepath.Rel(s.contentDir, pagePath); err == nil {
+				flavored.Weight = s.weights[filepath.ToSlash(rel)]
+			}
+			flavored.Tags = meta.Tags
+		}
+
+		encoded, err := yaml.Marshal(flavored)
+		if err != nil {
+			return fmt.Errorf("encoding front matter: %w", err)
+		}
+
+		frontMatter.Write(encoded)
+
+		generated["title"] = struct{}{}
+		if flavored.Weight != 0 {
+			generated["weight"] = struct{}{}
+		}
+		if len(flavored.Tags) > 0 {
+			generated["tags"] = struct{}{}
+		}
+	}
+
+	configured, err := s.configuredFrontMatter(meta, generated)
+	if err != nil {
+		return err
+	}
+
+	if len(configured) > 0 {
+		encoded, err := yaml.Marshal(configured)
+		if err != nil {
+			return fmt.Errorf("encoding front matter: %w", err)
+		}
+
+		frontMatter.Write(encoded)
+	}
+
+	if frontMatter.Len() > 0 {
+		content = "---\n" + frontMatter.String() + "---\n\n" + content
+	}
+
+	if err := s.fs.WriteFile(pagePath, []byte(content), filePerm); err != nil {
+		return fmt.Errorf("writing %s: %w", pagePath, err)
+	}
+
+	return nil
+}
+
+// configuredFrontMatter expands the configured front matter for a page, leaving out the generated fields and
+// fields whose templates render empty, e.g. the owner on pages other than the ones of services.
+func (s site) configuredFrontMatter(meta pageMeta, generated map[string]struct{}) (map[string]any, error) {
+	fields := make(map[string]any, len(s.frontMatter))
+
+	for field, value := range s.frontMatter {
+		if _, ok := generated[field]; ok {
+			continue
+		}
+
+		expanded, err := expandFrontMatter(field, value, meta)
+		if err != nil {
+			return nil, err
+		}
+
+		if !emptyFrontMatter(expanded) {
+			fields[field] = expanded
+		}
+	}
+
+	return fields, nil
+}
+
+// expandFrontMatter executes the string templates of a front matter value with the page.
+func expandFrontMatter(field string, value any, meta pageMeta) (any, error) {
+	switch value := value.(type) {
+	case string:
+		tmpl, err := template.New(field).Option("missingkey=error").Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("parsing front matter field %s: %w", field, err)
+		}
+
+		var buf strings.Builder
+		if err := tmpl.Execute(&buf, meta); err != nil {
+			return nil, fmt.Errorf("executing front matter field %s: %w", field, err)
+		}
+
+		return buf.String(), nil
+	case []any:
+		items := make([]any, 0, len(value))
+		for _, item := range value {
+			expanded, err := expandFrontMatter(field, item, meta)
+			if err != nil {
+				return nil, err
+			}
+
+			if !emptyFrontMatter(expanded) {
+				items = append(items, expanded)
+			}
+		}
+
+		return items, nil
+	case map[string]any:
+		fields := make(map[string]any, len(value))
+		for key, item := range value {
+			expanded, err := expandFrontMatter(field+"."+key, item, meta)
+			if err != nil {
+				return nil, err
+			}
+
+			if !emptyFrontMatter(expanded) {
+				fields[key] = expanded
+			}
+		}
+
+		return fields, nil
+	default:
+		return value, nil
+	}
+}
+
+func emptyFrontMatter(value any) bool {
+	switch value := value.(type) {
+	case string:
+		return value == ""
+	case []any:
+		return len(value) == 0
+	case map[string]any:
+		return len(value) == 0
+	default:
+		return value == nil
+	}
+}
+
+// navWeights numbers the pages of the navigation in order, starting at 1.
+func navWeights(nav []navItem) map[string]int {
+	weights := make(map[string]int)
+
+	var walk func(items []navItem)
+	walk = func(items []navItem) {
+		for _, item := range items {
+			weights[item.Path] = len(weights) + 1
+			walk(item.Children)
+		}
+	}
+	walk(nav)
+
+	return weights
+}
+
+// navItem is an entry of the site navigation, a page optionally followed by nested pages.
+type navItem struct {
+	Title    string
+	Path     string
+	Children []navItem
+}
+
+// nav returns the navigation of the multi-page documentation, paths are relative to the content directory.
+func (s site) nav(data templateData) []navItem {
+	nav := []navItem{{Title: "Overview", Path: s.overviewFile()}}
+
+	for _, system := range data.Systems {
+		item := navItem{Title: system.Name, Path: system.FilePath}
+		for _, service := range system.Services {
+			item.Children = append(item.Children, navItem{Title: service.Name, Path: service.FilePath})
+		}
+
+		nav = append(nav, item)
+	}
+
+	if data.MessageFlowContextPath != "" {
+		item := navItem{Title: "Message Flow", Path: data.MessageFlowContextPath}
+		for _, channel := range data.MessageFlow.Channels {
+			item.Children = append(item.Children, navItem{Title: channel.Name, Path: channel.FilePath})
+		}
+
+		nav = append(nav, item)
+	}
+
+	if data.EventCatalogPath != "" {
+		nav = append(nav, navItem{Title: "Event Catalog", Path: data.EventCatalogPath})
+	}
+
+	if data.DatastoreSchemasPath != "" {
+		nav = append(nav, navItem{Title: "Datastore Schemas", Path: data.DatastoreSchemasPath})
+	}
+
+	if data.PersonasPath != "" {
+		nav = append(nav, navItem{Title: "Personas", Path: data.PersonasPath})
+	}
+
+	if data.CapabilitiesPath != "" {
+		nav = append(nav, navItem{Title: "Business Capabilities", Path: data.CapabilitiesPath})
+	}
+
+	if data.ChangelogPath != "" {
+		nav = append(nav, navItem{Title: "Changelog", Path: data.ChangelogPath})
+	}
+
+	return nav
+}
+
+// writeConfig writes the navigation of the site generator of the flavor into the site directory. Hugo
+// needs none, it builds menus from the page weights.
+func (s site) writeConfig(data templateData) error {
+	var (
+		fileName string
+		content  []byte
+		err      error
+	)
+
+	switch s.flavor {
+	case config.FlavorMkDocs:
+		fileName = mkDocsConfigFileName
+		content, err = mkDocsConfig(data.Title, s.nav(data), s.versioned())
+	case config.FlavorDocusaurus:
+		fileName = docusaurusSidebarFileName
+		content, err = docusaurusSidebar(s.nav(data))
+	default:
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("building %s: %w", fileName, err)
+	}
+
+	configPath := filepath.Join(s.dir, fileName)
+	if err := s.fs.WriteFile(configPath, content, filePerm); err != nil {
+		return fmt.Errorf("writing %s: %w", configPath, err)
+	}
+
+	return nil
+}
+
+type mkDocsFile struct {
+	SiteName string       `yaml:"site_name"`
+	DocsDir  string       `yaml:"docs_dir"`
+	Nav      []any        `yaml:"nav"`
+	Extra    *mkDocsExtra `yaml:"extra,omitempty"`
+}
+
+type mkDocsExtra struct {
+	Version mkDocsVersion `yaml:"version"`
+}
+
+// mkDocsVersion configures the version selector of the Material theme, which lists the versions of
+// versions.json one directory above the site.
+type mkDocsVersion struct {
+	Provider string `yaml:"provider"`
+	Default  string `yaml:"default"`
+}
+
+// mkDocsVersionProvider is the format of versions.json the Material theme reads, the one of mike.
+const mkDocsVersionProvider = "mike"
+
+// versionedMkDocsExtra enables the version selector, opening the latest release by default.
+func versionedMkDocsExtra() *mkDocsExtra {
+	return &mkDocsExtra{Version: mkDocsVersion{Provider: mkDocsVersionProvider, Default: latestReleaseAlias}}
+}
+
+// versioned tells whether releases of the site were snapshotted, the site then gets a version selector.
+func (s site) versioned() bool {
+	_, err := s.fs.Stat(filepath.Join(s.dir, releasesDirName, versionsFileName))
+
+	return err == nil
+}
+
+// mkDocsConfig returns an mkdocs.yml with the navigation. A page with nested pages becomes a section
+// whose first entry is the page itself, titled from its front matter. Versioned sites get a version
+// selector.
+func mkDocsConfig(title string, nav []navItem, versioned bool) ([]byte, error) {
+	file := mkDocsFile{SiteName: title, DocsDir: siteContentDirName, Nav: mkDocsNav(nav)}
+	if versioned {
+		file.Extra = versionedMkDocsExtra()
+	}
+
+	return encodeMkDocsConfig(file)
+}
+
+func encodeMkDocsConfig(file mkDocsFile) ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteString("# " + generatedFileNotice + "\n")
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(yamlIndent)
+
+	if err := encoder.Encode(file); err != nil {
+		return nil, fmt.Errorf("encoding YAML: %w", err)
+	}
+
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("encoding YAML: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func mkDocsNav(items []navItem) []any {
+	nav := make([]any, 0, len(items))
+
+	for _, item := range items {
+		if len(item.Children) == 0 {
+			nav = append(nav, map[string]string{item.Title: item.Path})
+
+			continue
+		}
+
+		section := append([]any{item.Path}, mkDocsNav(item.Children)...)
+		nav = append(nav, map[string][]any{item.Title: section})
+	}
+
+	return nav
+}
+
+// docusaurusSidebar returns a sidebars.js with the navigation as the holydocs sidebar. Document IDs are
+// the page paths without extension, a page with nested pages becomes a category linked to the page.
+func docusaurusSidebar(nav []navItem) ([]byte, error) {
+	sidebar, err := json.MarshalIndent(map[string][]any{docusaurusSidebarName: docusaurusItems(nav)}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encoding sidebar: %w", err)
+	}
+
+	return []byte("// " + generatedFileNotice + "\nmodule.exports = " + string(sidebar) + ";\n"), nil
+}
+
+func docusaurusItems(items []navItem) []any {
+	sidebar := make([]any, 0, len(items))
+
+	for _, item := range items {
+		id := strings.TrimSuffix(item.Path, ".md")
+
+		if len(item.Children) == 0 {
+			sidebar = append(sidebar, map[string]string{"type": "doc", "id": id, "label": item.Title})
+
+			continue
+		}
+
+		sidebar = append(sidebar, map[string]any{
+			"type":  "category",
+			"label": item.Title,
+			"link":  map[string]string{"type": "doc", "id": id},
+			"items": docusaurusItems(item.Children),
+		})
+	}
+
+	return sidebar
+}
+
+// systemTags returns the tags of the services of a system.
+func systemTags(system systemView) []string {
+	var tags []string
+	for _, service := range system.Services {
+		tags = append(tags, service.Tags...)
+	}
+
+	slices.Sort(tags)
+
+	return slices.Compact(tags)
+}
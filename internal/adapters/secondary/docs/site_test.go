@@ -0,0 +1,216 @@
+package docs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/pkg/outputfs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func siteTestData() templateData {
+	return templateData{
+		Title: "Shop: Docs",
+		Systems: []systemView{{
+			Name:     "Shop",
+			FilePath: "systems/shop.md",
+			Services: []serviceView{{Name: "Orders", FilePath: "services/orders.md"}},
+		}},
+		MessageFlowContextPath: "messageflow/context.md",
+		MessageFlow: messageFlowView{
+			HasData:  true,
+			Channels: []channelView{{Name: "orders.created", FilePath: "messageflow/channels/orderscreated.md"}},
+		},
+		EventCatalogPath: eventCatalogFileName,
+	}
+}
+
+func TestNewSite(t *testing.T) {
+	t.Parallel()
+
+	plain := newSite(outputfs.OS(), config.Output{Dir: "out"})
+	assert.Equal(t, "out", plain.contentDir)
+	assert.Equal(t, "out", plain.diagramsBaseDir())
+	assert.Equal(t, "README.md", plain.overviewFile())
+
+	mkdocs := newSite(outputfs.OS(), config.Output{Dir: "out", Flavor: config.FlavorMkDocs})
+	assert.Equal(t, filepath.Join("out", "docs"), mkdocs.contentDir)
+	assert.Equal(t, filepath.Join("out", "docs"), mkdocs.diagramsBaseDir())
+
+	hugo := newSite(outputfs.OS(), config.Output{Dir: "out", Flavor: config.FlavorHugo})
+	assert.Equal(t, filepath.Join("out", "content"), hugo.contentDir)
+	assert.Equal(t, filepath.Join("out", "assets"), hugo.diagramsBaseDir())
+	assert.Equal(t, "_index.md", hugo.overviewFile())
+}
+
+func TestSite_Figure(t *testing.T) {
+	t.Parallel()
+
+	figure, err := newSite(outputfs.OS(), config.Output{}).figure("Orders", "../diagrams/orders.svg")
+	require.NoError(t, err)
+	assert.Equal(t, "![Orders](../diagrams/orders.svg)", figure)
+
+	hugo := newSite(outputfs.OS(), config.Output{Flavor: config.FlavorHugo})
+	figure, err = hugo.figure(`"Orders" flow`, "../../diagrams/messageflow/orders.svg")
+	require.NoError(t, err)
+	assert.Equal(t, `{{< figure src="diagrams/messageflow/orders.svg" alt="\"Orders\" flow" >}}`, figure)
+
+	uploaded := newSite(outputfs.OS(), config.Output{})
+	uploaded.assetURLs = map[string]string{"diagrams/orders.svg": "https://assets.example.com/diagrams/orders.1a2b.svg"}
+	figure, err = uploaded.figure("Orders", "../diagrams/orders.svg")
+	require.NoError(t, err)
+	assert.Equal(t, "![Orders](https://assets.example.com/diagrams/orders.1a2b.svg)", figure)
+}
+
+func TestSite_Figure_EmbedDiagrams(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "diagrams"), dirPerm))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "diagrams", "orders.svg"), []byte("<svg/>"), filePerm))
+
+	embedding := newSite(outputfs.OS(), config.Output{Dir: dir, EmbedDiagrams: true})
+
+	figure, err := embedding.figure("Orders", "../diagrams/orders.svg")
+	require.NoError(t, err)
+	assert.Equal(t, "![Orders](data:image/svg+xml;base64,PHN2Zy8+)", figure)
+
+	_, err = embedding.figure("Missing", "diagrams/missing.svg")
+	require.Error(t, err)
+}
+
+func TestWritePage(t *testing.T) {
+	t.Parallel()
+
+	dir, fsys := "docs", outputfs.NewMemory()
+	require.NoError(t, fsys.MkdirAll(dir, dirPerm))
+
+	plain := filepath.Join(dir, "plain.md")
+	require.NoError(t, newSite(fsys, config.Output{Dir: dir}).writePage(plain,
+		pageMeta{Title: "Orders", Tags: []string{"core"}}, "# Orders\n"))
+	content, err := fsys.ReadFile(plain)
+	require.NoError(t, err)
+	assert.Equal(t, "# Orders\n", string(content))
+
+	flavored := filepath.Join(dir, "flavored.md")
+	docusaurus := newSite(fsys, config.Output{Dir: dir, Flavor: config.FlavorDocusaurus})
+	require.NoError(t, docusaurus.writePage(flavored, pageMeta{Title: "Shop: Orders", Tags: []string{"core"}},
+		"# Orders\n"))
+	content, err = fsys.ReadFile(flavored)
+	require.NoError(t, err)
+	assert.Equal(t, "---\ntitle: 'Shop: Orders'\n---\n\n# Orders\n", string(content))
+}
+
+func TestWritePage_Hugo(t *testing.T) {
+	t.Parallel()
+
+	hugo := newSite(outputfs.OS(), config.Output{Dir: t.TempDir(), Flavor: config.FlavorHugo})
+	hugo.weights = navWeights(hugo.nav(siteTestData()))
+	require.NoError(t, os.MkdirAll(filepath.Join(hugo.contentDir, "services"), dirPerm))
+
+	servicePath := filepath.Join(hugo.contentDir, "services", "orders.md")
+	require.NoError(t, hugo.writePage(servicePath, pageMeta{Title: "Orders", Tags: []string{"core", "orders"}},
+		"# Orders\n"))
+
+	content, err := os.ReadFile(servicePath)
+	require.NoError(t, err)
+	assert.Equal(t, "---\ntitle: Orders\nweight: 3\ntags:\n    - core\n    - orders\n---\n\n# Orders\n", string(content))
+}
+
+func TestWritePage_FrontMatter(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	frontMatter := map[string]any{
+		"owners":      []any{"{{ .Owner }}", "docs-team"},
+		"review_date": "2025-12-01",
+		"labels":      map[string]any{"system": "{{ .System }}"},
+		"title":       "Overridden",
+	}
+
+	servicePath := filepath.Join(dir, "orders.md")
+	plain := newSite(outputfs.OS(), config.Output{Dir: dir, FrontMatter: frontMatter})
+	require.NoError(t, plain.writePage(servicePath, pageMeta{Title: "Orders", Owner: "team-orders", System: "Shop"},
+		"# Orders\n"))
+	content, err := os.ReadFile(servicePath)
+	require.NoError(t, err)
+	assert.Equal(t, "---\nlabels:\n    system: Shop\nowners:\n    - team-orders\n    - docs-team\nreview_date: \"2025-12-01\"\n"+
+		"title: Overridden\n---\n\n# Orders\n", string(content))
+
+	overviewPath := filepath.Join(dir, "overview.md")
+	flavored := newSite(outputfs.OS(), config.Output{Dir: dir, Flavor: config.FlavorMkDocs, FrontMatter: frontMatter})
+	require.NoError(t, flavored.writePage(overviewPath, pageMeta{Title: "Shop"}, "# Shop\n"))
+	content, err = os.ReadFile(overviewPath)
+	require.NoError(t, err)
+	assert.Equal(t, "---\ntitle: Shop\nowners:\n    - docs-team\nreview_date: \"2025-12-01\"\n---\n\n# Shop\n",
+		string(content))
+}
+
+func TestNavWeights(t *testing.T) {
+	t.Parallel()
+
+	weights := navWeights(newSite(outputfs.OS(), config.Output{Flavor: config.FlavorHugo}).nav(siteTestData()))
+	assert.Equal(t, map[string]int{
+		"_index.md":                             1,
+		"systems/shop.md":                       2,
+		"services/orders.md":                    3,
+		"messageflow/context.md":                4,
+		"messageflow/channels/orderscreated.md": 5,
+		"events.md":                             6,
+	}, weights)
+}
+
+func TestWriteSiteConfig_MkDocs(t *testing.T) {
+	t.Parallel()
+
+	dir, fsys := "docs", outputfs.NewMemory()
+	require.NoError(t, fsys.MkdirAll(dir, dirPerm))
+	require.NoError(t, newSite(fsys, config.Output{Dir: dir, Flavor: config.FlavorMkDocs}).writeConfig(siteTestData()))
+
+	content, err := fsys.ReadFile(filepath.Join(dir, mkDocsConfigFileName))
+	require.NoError(t, err)
+	assert.Equal(t, `# Generated by HolyDOCs, changes are overwritten.
+site_name: 'Shop: Docs'
+docs_dir: docs
+nav:
+  - Overview: README.md
+  - Shop:
+      - systems/shop.md
+      - Orders: services/orders.md
+  - Message Flow:
+      - messageflow/context.md
+      - orders.created: messageflow/channels/orderscreated.md
+  - Event Catalog: events.md
+`, string(content))
+}
+
+func TestWriteSiteConfig_Docusaurus(t *testing.T) {
+	t.Parallel()
+
+	dir, fsys := "docs", outputfs.NewMemory()
+	require.NoError(t, fsys.MkdirAll(dir, dirPerm))
+	require.NoError(t, newSite(fsys, config.Output{Dir: dir, Flavor: config.FlavorDocusaurus}).writeConfig(siteTestData()))
+
+	content, err := fsys.ReadFile(filepath.Join(dir, docusaurusSidebarFileName))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "module.exports = {\n  \"holydocs\": [\n")
+	assert.Contains(t, string(content), `"id": "README"`)
+	assert.Contains(t, string(content), `"id": "messageflow/channels/orderscreated"`)
+	assert.Contains(t, string(content), `"type": "category"`)
+}
+
+func TestWriteSiteConfig_NoNavigationFile(t *testing.T) {
+	t.Parallel()
+
+	for _, flavor := range []string{"", config.FlavorHugo} {
+		dir := t.TempDir()
+		require.NoError(t, newSite(outputfs.OS(), config.Output{Dir: dir, Flavor: flavor}).writeConfig(siteTestData()))
+
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Empty(t, entries, flavor)
+	}
+}
@@ -0,0 +1,217 @@
+package docs
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	mf "github.com/holydocs/messageflow/pkg/messageflow"
+)
+
+// Markers enclosing the snippet in the README of a service, so later runs replace it in place.
+const (
+	snippetBeginMarker = "<!-- holydocs:snippet:begin -->"
+	snippetEndMarker   = "<!-- holydocs:snippet:end -->"
+)
+
+// snippetThumbnailWidth is the width the relationships diagram is shown with in the snippet.
+const snippetThumbnailWidth = 480
+
+// GenerateSnippet renders the README snippet of a service: a thumbnail of its relationships diagram, its
+// dependencies and dependents and a link to its full documentation. The snippet replaces the one found in
+// req.Existing or is appended to it.
+func (g *Generator) GenerateSnippet(
+	ctx context.Context,
+	schema domain.Schema,
+	messageflowSchema mf.Schema,
+	req domain.GenerateSnippetRequest,
+) (domain.GenerateSnippetReply, error) {
+	schema = schema.AliasExternalParticipants(g.config.Documentation.ExternalAliases)
+	schema.Sort()
+	messageflowSchema.Sort()
+
+	service, ok := findService(schema, req.Service)
+	if !ok {
+		return domain.GenerateSnippetReply{}, fmt.Errorf("%w: %s", domain.ErrServiceNotFound, req.Service)
+	}
+
+	var reply domain.GenerateSnippetReply
+
+	if req.DiagramPath != "" {
+		diagram, err := g.serviceRelationshipsDiagram(ctx, service, schema, messageflowSchema, domain.DiagramFormatSVG)
+		if err != nil {
+			return domain.GenerateSnippetReply{}, err
+		}
+
+		reply.Diagram = diagram
+	}
+
+	var link string
+	if req.DocsURL != "" {
+		names := newFileNames(schema, messageflowSchema)
+		link = serviceDocsLink(strings.TrimSuffix(req.DocsURL, "/"), g.config.Output.Format,
+			sanitizeAnchor(service.Info.Name), names.service(service.Info.Name))
+	}
+
+	snippet := buildSnippet(service, schema.Services, req.DiagramPath, link)
+	reply.Content = spliceSnippet(req.Existing, snippet)
+
+	return reply, nil
+}
+
+// buildSnippet renders the snippet of a service enclosed in the snippet markers.
+func buildSnippet(service domain.Service, allServices []domain.Service, diagramPath, link string) string {
+	var b strings.Builder
+
+	name := service.Info.Name
+
+	b.WriteString(snippetBeginMarker + "\n")
+	b.WriteString("## Architecture\n\n")
+
+	if diagramPath != "" {
+		img := fmt.Sprintf(`<img src="%s" alt="Relationships of %s" width="%d">`, diagramPath, name,
+			snippetThumbnailWidth)
+		if link != "" {
+			img = fmt.Sprintf(`<a href="%s">%s</a>`, link, img)
+		}
+
+		b.WriteString(img + "\n\n")
+	}
+
+	if summary := snippetSummary(service); summary != "" {
+		b.WriteString(summary + "\n\n")
+	}
+
+	b.WriteString("**Depends on**\n\n")
+
+	summaries := buildRelationshipSummaries(service.Relationships)
+	if len(summaries) == 0 {
+		b.WriteString("_No relationships documented._\n")
+	}
+
+	for _, rel := range summaries {
+		fmt.Fprintf(&b, "- **%s** %s", rel.Action, rel.Participant)
+		writeSnippetQualifiers(&b, rel.Technology, rel.External, rel.Planned)
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n**Used by**\n\n")
+
+	dependents := snippetDependents(name, allServices)
+	if len(dependents) == 0 {
+		b.WriteString("_No services documented to depend on it._\n")
+	}
+
+	for _, dependent := range dependents {
+		b.WriteString(dependent + "\n")
+	}
+
+	if link != "" {
+		fmt.Fprintf(&b, "\nFull documentation: [%s](%s)\n", name, link)
+	}
+
+	b.WriteString("\n<sub>Generated by holydocs from the central architecture model, " +
+		"changes between the markers are overwritten.</sub>\n")
+	b.WriteString(snippetEndMarker + "\n")
+
+	return b.String()
+}
+
+// snippetSummary names the system and the owner of a service, with the state of planned and deprecated ones.
+func snippetSummary(service domain.Service) string {
+	var parts []string
+
+	if service.Info.System != "" {
+		parts = append(parts, fmt.Sprintf("Part of **%s**", service.Info.System))
+	}
+
+	if service.Info.Owner != "" {
+		parts = append(parts, fmt.Sprintf("owned by **%s**", service.Info.Owner))
+	}
+
+	summary := strings.Join(parts, ", ")
+	if summary != "" {
+		summary = strings.ToUpper(summary[:1]) + summary[1:] + "."
+	}
+
+	switch {
+	case service.Info.Deprecated:
+		summary = strings.TrimSpace(summary + " _Deprecated._")
+	case service.Info.Planned:
+		summary = strings.TrimSpace(summary + " _Planned._")
+	}
+
+	return summary
+}
+
+// snippetDependents lists the services with relationships toward the named service.
+func snippetDependents(name string, allServices []domain.Service) []string {
+	var dependents []string
+
+	for _, other := range allServices {
+		if other.Info.Name == name {
+			continue
+		}
+
+		for _, rel := range other.Relationships {
+			if rel.Participant != name || rel.Person {
+				continue
+			}
+
+			var b strings.Builder
+
+			fmt.Fprintf(&b, "- %s **%s** it", other.Info.Name, rel.Action)
+			writeSnippetQualifiers(&b, rel.Technology, false, rel.Planned)
+			dependents = append(dependents, b.String())
+		}
+	}
+
+	sort.Strings(dependents)
+
+	return dependents
+}
+
+func writeSnippetQualifiers(b *strings.Builder, technology string, external, planned bool) {
+	if technology != "" {
+		b.WriteString(" via " + technology)
+	}
+
+	if external {
+		b.WriteString(" _(external)_")
+	}
+
+	if planned {
+		b.WriteString(" _(planned)_")
+	}
+}
+
+// spliceSnippet replaces the snippet enclosed in the markers of existing, or appends the snippet when there
+// is none.
+func spliceSnippet(existing []byte, snippet string) []byte {
+	begin := bytes.Index(existing, []byte(snippetBeginMarker))
+	end := bytes.Index(existing, []byte(snippetEndMarker))
+
+	if begin >= 0 && end > begin {
+		end += len(snippetEndMarker)
+		if end < len(existing) && existing[end] == '\n' {
+			end++
+		}
+
+		spliced := append([]byte(nil), existing[:begin]...)
+		spliced = append(spliced, snippet...)
+
+		return append(spliced, existing[end:]...)
+	}
+
+	if len(existing) == 0 {
+		return []byte(snippet)
+	}
+
+	spliced := bytes.TrimRight(existing, "\n")
+	spliced = append(append([]byte(nil), spliced...), "\n\n"...)
+
+	return append(spliced, snippet...)
+}
@@ -0,0 +1,118 @@
+package docs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	mf "github.com/holydocs/messageflow/pkg/messageflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerator_GenerateSnippet(t *testing.T) {
+	t.Parallel()
+
+	schema := domain.Schema{Services: []domain.Service{
+		{
+			Info: domain.ServiceInfo{Name: "Orders Service", System: "Shop", Owner: "team-orders"},
+			Relationships: []domain.Relationship{
+				{Action: domain.RelationshipActionUses, Participant: "PostgreSQL", Technology: "SQL", External: true},
+				{Action: domain.RelationshipActionRequests, Participant: "Payments", Technology: "HTTP"},
+			},
+		},
+		{
+			Info: domain.ServiceInfo{Name: "Checkout"},
+			Relationships: []domain.Relationship{
+				{Action: domain.RelationshipActionRequests, Participant: "Orders Service", Technology: "gRPC"},
+			},
+		},
+		{Info: domain.ServiceInfo{Name: "Payments"}},
+	}}
+
+	g := &Generator{config: &config.Config{Output: config.Output{Format: "md_multi_page"}}}
+
+	reply, err := g.GenerateSnippet(context.Background(), schema, mf.Schema{}, domain.GenerateSnippetRequest{
+		Service:  "Orders Service",
+		DocsURL:  "https://architecture.example.com/",
+		Existing: []byte("# Orders\n\nOld intro.\n"),
+	})
+	require.NoError(t, err)
+	assert.Nil(t, reply.Diagram)
+	assert.Equal(t, `# Orders
+
+Old intro.
+
+<!-- holydocs:snippet:begin -->
+## Architecture
+
+Part of **Shop**, owned by **team-orders**.
+
+**Depends on**
+
+- **requests** Payments via HTTP
+- **uses** PostgreSQL via SQL _(external)_
+
+**Used by**
+
+- Checkout **requests** it via gRPC
+
+Full documentation: [Orders Service](https://architecture.example.com/services/orders-service.md)
+
+<sub>Generated by holydocs from the central architecture model, changes between the markers are overwritten.</sub>
+<!-- holydocs:snippet:end -->
+`, string(reply.Content))
+
+	_, err = g.GenerateSnippet(context.Background(), schema, mf.Schema{}, domain.GenerateSnippetRequest{
+		Service: "Inventory",
+	})
+	require.ErrorIs(t, err, domain.ErrServiceNotFound)
+}
+
+func TestGenerator_GenerateSnippet_ExternalAliases(t *testing.T) {
+	t.Parallel()
+
+	schema := domain.Schema{Services: []domain.Service{{
+		Info: domain.ServiceInfo{Name: "Checkout"},
+		Relationships: []domain.Relationship{
+			{Action: domain.RelationshipActionRequests, Participant: "Stripe API", Technology: "HTTP", External: true},
+			{Action: domain.RelationshipActionRequests, Participant: "Stripe", Technology: "HTTP", External: true},
+		},
+	}}}
+
+	g := &Generator{config: &config.Config{Documentation: config.Documentation{
+		ExternalAliases: map[string][]string{"Stripe": {"Stripe API"}},
+	}}}
+
+	reply, err := g.GenerateSnippet(context.Background(), schema, mf.Schema{}, domain.GenerateSnippetRequest{
+		Service: "Checkout",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, string(reply.Content), "**Depends on**\n\n- **requests** Stripe via HTTP _(external)_\n\n")
+}
+
+func TestBuildSnippet(t *testing.T) {
+	t.Parallel()
+
+	service := domain.Service{Info: domain.ServiceInfo{Name: "Mailer", Planned: true}}
+
+	snippet := buildSnippet(service, []domain.Service{service}, "docs/architecture.svg", "https://docs/#mailer")
+	assert.Contains(t, snippet, `<a href="https://docs/#mailer"><img src="docs/architecture.svg" `+
+		`alt="Relationships of Mailer" width="480"></a>`)
+	assert.Contains(t, snippet, "_Planned._\n")
+	assert.Contains(t, snippet, "_No relationships documented._\n")
+	assert.Contains(t, snippet, "_No services documented to depend on it._\n")
+}
+
+func TestSpliceSnippet(t *testing.T) {
+	t.Parallel()
+
+	snippet := snippetBeginMarker + "\nnew\n" + snippetEndMarker + "\n"
+
+	assert.Equal(t, snippet, string(spliceSnippet(nil, snippet)))
+	assert.Equal(t, "# Readme\n\n"+snippet, string(spliceSnippet([]byte("# Readme\n\n\n"), snippet)))
+
+	existing := "# Readme\n\n" + snippetBeginMarker + "\nold\n" + snippetEndMarker + "\n\n## Usage\n"
+	assert.Equal(t, "# Readme\n\n"+snippet+"\n## Usage\n", string(spliceSnippet([]byte(existing), snippet)))
+}
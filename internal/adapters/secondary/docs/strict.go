@@ -0,0 +1,87 @@
+package docs
+
+import (
+	"fmt"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+)
+
+// knownActions are the relationship actions diagrams draw.
+//
+//nolint:gochecknoglobals // Fixed vocabulary of relationships.
+var knownActions = map[domain.RelationshipAction]struct{}{
+	domain.RelationshipActionUses:     {},
+	domain.RelationshipActionRequests: {},
+	domain.RelationshipActionReplies:  {},
+	domain.RelationshipActionSends:    {},
+	domain.RelationshipActionReceives: {},
+}
+
+type questionableEdgeView struct {
+	Service     string
+	Action      string
+	Participant string
+	Reason      string
+}
+
+// strictSchema returns the schema with only the relationships passing validation, which are the ones drawn
+// in strict mode, along with the others as questionable edges. A relationship passes validation when its
+// action is known and its participant is a documented service, is marked as external or a person, or is
+// infrastructure the service uses.
+func strictSchema(schema domain.Schema) (domain.Schema, []questionableEdgeView) {
+	services := make(map[string]struct{}, len(schema.Services))
+	for _, service := range schema.Services {
+		services[service.Info.Name] = struct{}{}
+	}
+
+	strict := domain.Schema{Services: make([]domain.Service, 0, len(schema.Services))}
+
+	var questionable []questionableEdgeView
+
+	for _, service := range schema.Services {
+		relationships := make([]domain.Relationship, 0, len(service.Relationships))
+
+		for _, rel := range service.Relationships {
+			reason := questionableReason(service.Info.Name, rel, services)
+			if reason == "" {
+				relationships = append(relationships, rel)
+
+				continue
+			}
+
+			questionable = append(questionable, questionableEdgeView{
+				Service:     service.Info.Name,
+				Action:      string(rel.Action),
+				Participant: rel.Participant,
+				Reason:      reason,
+			})
+		}
+
+		service.Relationships = relationships
+		strict.Services = append(strict.Services, service)
+	}
+
+	return strict, questionable
+}
+
+// questionableReason explains why a relationship fails validation, empty when it passes.
+func questionableReason(serviceName string, rel domain.Relationship, services map[string]struct{}) string {
+	if _, ok := knownActions[rel.Action]; !ok {
+		return fmt.Sprintf("unknown action %q", rel.Action)
+	}
+
+	if rel.Participant == "" {
+		return "no participant"
+	}
+
+	if rel.Participant == serviceName {
+		return "relationship of the service with itself"
+	}
+
+	_, documented := services[rel.Participant]
+	if !documented && !rel.External && !rel.Person && rel.Action != domain.RelationshipActionUses {
+		return "unknown participant, neither a documented service nor marked as external"
+	}
+
+	return ""
+}
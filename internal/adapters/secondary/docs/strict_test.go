@@ -0,0 +1,60 @@
+package docs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/holydocs/holydocs/pkg/outputfs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrictSchema(t *testing.T) {
+	t.Parallel()
+
+	schema := domain.Schema{Services: []domain.Service{
+		{
+			Info: domain.ServiceInfo{Name: "Orders"},
+			Relationships: []domain.Relationship{
+				{Action: domain.RelationshipActionRequests, Participant: "Payments"},
+				{Action: domain.RelationshipActionRequests, Participant: "Stripe", External: true},
+				{Action: domain.RelationshipActionUses, Participant: "orders-db"},
+				{Action: domain.RelationshipActionRequests, Participant: "Paymnets"},
+				{Action: "calls", Participant: "Payments"},
+				{Action: domain.RelationshipActionSends, Participant: "Orders"},
+			},
+		},
+		{Info: domain.ServiceInfo{Name: "Payments"}},
+	}}
+
+	strict, questionable := strictSchema(schema)
+
+	require.Len(t, strict.Services, 2)
+	assert.Equal(t, schema.Services[0].Relationships[:3], strict.Services[0].Relationships)
+	assert.Equal(t, []questionableEdgeView{
+		{Service: "Orders", Action: "requests", Participant: "Paymnets",
+			Reason: "unknown participant, neither a documented service nor marked as external"},
+		{Service: "Orders", Action: "calls", Participant: "Payments", Reason: `unknown action "calls"`},
+		{Service: "Orders", Action: "sends", Participant: "Orders", Reason: "relationship of the service with itself"},
+	}, questionable)
+}
+
+func TestWriteReadme_QuestionableEdges(t *testing.T) {
+	t.Parallel()
+
+	outputDir := t.TempDir()
+	require.NoError(t, writeReadme(newSite(outputfs.OS(), config.Output{Dir: outputDir}), templateData{
+		Title: "Test",
+		QuestionableEdges: []questionableEdgeView{
+			{Service: "Orders", Action: "requests", Reason: "no participant"},
+		},
+	}))
+
+	readme, err := os.ReadFile(filepath.Join(outputDir, "README.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(readme), "- [Questionable Edges](#questionable-edges)")
+	assert.Contains(t, string(readme), "| Orders | requests | — | no participant |")
+}
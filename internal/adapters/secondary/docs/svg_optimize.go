@@ -0,0 +1,166 @@
+package docs
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/holydocs/holydocs/pkg/outputfs"
+)
+
+var (
+	svgCommentPattern  = regexp.MustCompile(`(?s)<!--.*?-->`)
+	svgMetadataPattern = regexp.MustCompile(`(?s)<metadata\b.*?</metadata>`)
+	svgVersionPattern  = regexp.MustCompile(`\s+data-d2-version="[^"]*"`)
+	svgStylePattern    = regexp.MustCompile(`(?s)(<style\b[^>]*>)(.*?)(</style>)`)
+	svgClassPattern    = regexp.MustCompile(`\sclass="([^"]*)"`)
+	svgGeometryPattern = regexp.MustCompile(
+		`(\s(?:x|y|x1|y1|x2|y2|cx|cy|r|rx|ry|width|height|d|points|transform|viewBox|stroke-width)=")([^"]*)"`)
+	svgDecimalPattern   = regexp.MustCompile(`(\d)\.(\d*?)0+\b`)
+	svgDotPattern       = regexp.MustCompile(`(\d)\.(\D|$)`)
+	cssCommentPattern   = regexp.MustCompile(`(?s)/\*.*?\*/`)
+	cssSpacePattern     = regexp.MustCompile(`\s+`)
+	cssPunctPattern     = regexp.MustCompile(`\s*([{};,>])\s*`)
+	cssSelectorsPattern = regexp.MustCompile(`\.([A-Za-z_][\w-]*)`)
+)
+
+// optimizeDiagrams minifies the SVG diagrams below dir in place.
+func optimizeDiagrams(fsys outputfs.FS, dir string) error {
+	err := outputfs.WalkDir(fsys, dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || filepath.Ext(path) != ".svg" {
+			return err
+		}
+
+		content, err := fsys.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		return fsys.WriteFile(path, optimizeSVG(content), filePerm)
+	})
+	if err != nil {
+		return fmt.Errorf("optimizing diagrams: %w", err)
+	}
+
+	return nil
+}
+
+// optimizeSVG strips comments, metadata and the renderer version from an SVG, shortens the numbers of its
+// geometry and minifies its style sheets, leaving out the rules of classes the SVG doesn't use. d2 writes
+// the same theme and Markdown style sheets into every diagram, most of which no element refers to.
+func optimizeSVG(content []byte) []byte {
+	svg := string(content)
+
+	svg = svgCommentPattern.ReplaceAllString(svg, "")
+	svg = svgMetadataPattern.ReplaceAllString(svg, "")
+	svg = svgVersionPattern.ReplaceAllString(svg, "")
+
+	svg = svgGeometryPattern.ReplaceAllStringFunc(svg, func(attr string) string {
+		parts := svgGeometryPattern.FindStringSubmatch(attr)
+		value := svgDecimalPattern.ReplaceAllString(parts[2], "$1.$2")
+
+		return parts[1] + svgDotPattern.ReplaceAllString(value, "$1$2") + `"`
+	})
+
+	used := usedClasses(svgStylePattern.ReplaceAllString(svg, ""))
+
+	return []byte(svgStylePattern.ReplaceAllStringFunc(svg, func(style string) string {
+		parts := svgStylePattern.FindStringSubmatch(style)
+		css, cdata := strings.CutPrefix(strings.TrimSpace(parts[2]), "<![CDATA[")
+		css = strings.TrimSuffix(css, "]]>")
+
+		css = minifyCSS(css, used)
+		if cdata {
+			css = "<![CDATA[" + css + "]]>"
+		}
+
+		return parts[1] + css + parts[3]
+	}))
+}
+
+// usedClasses returns the class names the elements of an SVG refer to.
+func usedClasses(svg string) map[string]struct{} {
+	used := make(map[string]struct{})
+
+	for _, match := range svgClassPattern.FindAllStringSubmatch(svg, -1) {
+		for _, class := range strings.Fields(match[1]) {
+			used[class] = struct{}{}
+		}
+	}
+
+	return used
+}
+
+// minifyCSS removes comments and whitespace from a style sheet and drops the selectors referring to a
+// class not in used. At-rules are kept as they are.
+func minifyCSS(css string, used map[string]struct{}) string {
+	css = cssCommentPattern.ReplaceAllString(css, "")
+	css = cssSpacePattern.ReplaceAllString(css, " ")
+	css = cssPunctPattern.ReplaceAllString(css, "$1")
+	css = strings.ReplaceAll(css, ";}", "}")
+
+	var out strings.Builder
+
+	for css != "" {
+		open := strings.IndexByte(css, '{')
+		if open < 0 {
+			out.WriteString(strings.TrimSpace(css))
+
+			break
+		}
+
+		end := blockEnd(css, open)
+		prelude, block := strings.TrimSpace(css[:open]), css[open:end]
+		css = css[end:]
+
+		if strings.HasPrefix(prelude, "@") {
+			out.WriteString(prelude + block)
+
+			continue
+		}
+
+		var selectors []string
+		for _, selector := range strings.Split(prelude, ",") {
+			if selectorUsed(selector, used) {
+				selectors = append(selectors, selector)
+			}
+		}
+
+		if len(selectors) > 0 {
+			out.WriteString(strings.Join(selectors, ",") + block)
+		}
+	}
+
+	return out.String()
+}
+
+// blockEnd returns the index after the brace closing the block opened at open.
+func blockEnd(css string, open int) int {
+	depth := 0
+
+	for i := open; i < len(css); i++ {
+		switch css[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+
+	return len(css)
+}
+
+func selectorUsed(selector string, used map[string]struct{}) bool {
+	for _, match := range cssSelectorsPattern.FindAllStringSubmatch(selector, -1) {
+		if _, ok := used[match[1]]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,62 @@
+package docs
+
+import (
+	"encoding/xml"
+	"path/filepath"
+	"testing"
+
+	"github.com/holydocs/holydocs/pkg/outputfs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptimizeSVG(t *testing.T) {
+	t.Parallel()
+
+	svg := `<?xml version="1.0" encoding="utf-8"?><!-- rendered by d2 -->` +
+		`<svg xmlns="http://www.w3.org/2000/svg" data-d2-version="v0.7.0" viewBox="0 0 100.000000 50.500000">` +
+		`<metadata><rdf>d2</rdf></metadata>` +
+		`<style type="text/css"><![CDATA[
+.d2-1 .fill-N1 {
+	fill: #0A0F25;
+}
+/* unused theme colors */
+.d2-1 .fill-N2, .d2-1 .fill-N3 { fill: #676C7E; }
+.d2-1 .md a:hover { color: red; }
+@font-face { font-family: d2-1-font; }
+]]></style>` +
+		`<g class="d2-1"><rect x="-53.000000" y="1.050000" class="fill-N1" /><text x="10.000000">v1.10</text></g>` +
+		`<div class="md"><a>Link</a></div></svg>`
+
+	optimized := string(optimizeSVG([]byte(svg)))
+
+	assert.Equal(t, `<?xml version="1.0" encoding="utf-8"?>`+
+		`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 100 50.5">`+
+		`<style type="text/css"><![CDATA[.d2-1 .fill-N1{fill: #0A0F25}.d2-1 .md a:hover{color: red}`+
+		`@font-face{font-family: d2-1-font}]]></style>`+
+		`<g class="d2-1"><rect x="-53" y="1.05" class="fill-N1" /><text x="10">v1.10</text></g>`+
+		`<div class="md"><a>Link</a></div></svg>`, optimized)
+	require.NoError(t, xml.Unmarshal([]byte(optimized), new(struct{})))
+}
+
+func TestOptimizeDiagrams(t *testing.T) {
+	t.Parallel()
+
+	dir, fsys := "diagrams", outputfs.NewMemory()
+	require.NoError(t, fsys.MkdirAll(filepath.Join(dir, servicesDiagramDirName), dirPerm))
+
+	svgPath := filepath.Join(dir, servicesDiagramDirName, "orders.svg")
+	d2Path := filepath.Join(dir, "orders.d2")
+	require.NoError(t, fsys.WriteFile(svgPath, []byte(`<svg><!-- d2 --><rect x="1.500000"/></svg>`), filePerm))
+	require.NoError(t, fsys.WriteFile(d2Path, []byte("# <!-- kept -->"), filePerm))
+
+	require.NoError(t, optimizeDiagrams(fsys, dir))
+
+	svg, err := fsys.ReadFile(svgPath)
+	require.NoError(t, err)
+	assert.Equal(t, `<svg><rect x="1.5"/></svg>`, string(svg))
+
+	script, err := fsys.ReadFile(d2Path)
+	require.NoError(t, err)
+	assert.Equal(t, "# <!-- kept -->", string(script), "only SVG files are optimized")
+}
@@ -0,0 +1,123 @@
+package docs
+
+import (
+	"fmt"
+	"io/fs"
+	"maps"
+	"path/filepath"
+	"slices"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/pkg/outputfs"
+)
+
+// writeTarget writes the documentation of an additional output target. The diagrams rendered for the output
+// directory are copied rather than rendered again, diagrams uploaded to a bucket keep their URLs.
+func writeTarget(pages site, output config.Output, data templateData) error {
+	targetPages := newSite(pages.fs, output)
+	targetPages.assetURLs = pages.assetURLs
+	targetPages.interactive = pages.interactive
+
+	if err := copyDiagrams(pages.fs, filepath.Join(pages.diagramsBaseDir(), diagramsDirName),
+		filepath.Join(targetPages.diagramsBaseDir(), diagramsDirName)); err != nil {
+		return err
+	}
+
+	return writeDocs(targetPages, output.Format, data)
+}
+
+// writeSystemOutputs writes the documentation of every system configured with an output directory to that
+// directory, in the format of the output. It holds the system and its services together with the sections shared
+// by all systems, such as the message flow and the event catalog, so links from the service pages resolve.
+func writeSystemOutputs(pages site, output config.Output, systems map[string]config.SystemDocumentation,
+	data templateData,
+) error {
+	for _, name := range slices.Sorted(maps.Keys(systems)) {
+		dir := systems[name].Output
+		if dir == "" {
+			continue
+		}
+
+		index := slices.IndexFunc(data.Systems, func(system systemView) bool { return system.Name == name })
+		if index < 0 {
+			// Configuration of unknown systems is reported as a warning.
+			continue
+		}
+
+		systemData := data
+		systemData.Title = fmt.Sprintf("%s: %s", data.Title, name)
+		systemData.Systems = data.Systems[index : index+1]
+
+		systemOutput := output
+		systemOutput.Dir, systemOutput.Targets = dir, nil
+
+		if err := writeTarget(pages, systemOutput, systemData); err != nil {
+			return fmt.Errorf("output of system %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// copyDiagrams replaces the diagrams directory dst with a copy of src.
+func copyDiagrams(fsys outputfs.FS, src, dst string) error {
+	if err := fsys.RemoveAll(dst); err != nil {
+		return fmt.Errorf("failed to clean diagrams directory: %w", err)
+	}
+
+	err := outputfs.WalkDir(fsys, src, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, file)
+		if err != nil {
+			return err
+		}
+
+		target := filepath.Join(dst, rel)
+		if entry.IsDir() {
+			return fsys.MkdirAll(target, dirPerm)
+		}
+
+		// Viewers link the pages of an output, every output writes its own.
+		if filepath.Ext(file) == viewerExt {
+			return nil
+		}
+
+		content, err := fsys.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		return fsys.WriteFile(target, content, filePerm)
+	})
+	if err != nil {
+		return fmt.Errorf("copy diagrams to %s: %w", dst, err)
+	}
+
+	return nil
+}
+
+// cloneTemplateData copies the views linking pages modifies in place, so the data can be written again.
+func cloneTemplateData(data templateData) templateData {
+	data.Systems = slices.Clone(data.Systems)
+	for i := range data.Systems {
+		data.Systems[i].Services = slices.Clone(data.Systems[i].Services)
+		for j := range data.Systems[i].Services {
+			service := &data.Systems[i].Services[j]
+			service.RelationshipSummaries = slices.Clone(service.RelationshipSummaries)
+		}
+	}
+
+	data.MessageFlow.Channels = slices.Clone(data.MessageFlow.Channels)
+	for i := range data.MessageFlow.Channels {
+		data.MessageFlow.Channels[i].Messages = slices.Clone(data.MessageFlow.Channels[i].Messages)
+	}
+
+	data.EventCatalog.Events = slices.Clone(data.EventCatalog.Events)
+	data.Datastores = slices.Clone(data.Datastores)
+	data.DatastoreSchemas = slices.Clone(data.DatastoreSchemas)
+
+	return data
+}
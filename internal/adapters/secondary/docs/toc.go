@@ -0,0 +1,143 @@
+package docs
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const tocHeading = "## Table of Contents"
+
+var (
+	headingPattern = regexp.MustCompile(`^(#{2,6})\s+(.+?)\s*$`)
+	anchorPattern  = regexp.MustCompile(`^<a id="([^"]+)"></a>$`)
+	linkPattern    = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+)
+
+// tocEntry is a heading listed in a table of contents.
+type tocEntry struct {
+	level  int
+	label  string
+	anchor string
+}
+
+// insertTOC adds a table of contents of the headings down to depth levels below the page title before the
+// first section of the page. Headings without an anchor get one, so the links don't depend on the anchors
+// the renderer generates. Pages with a table of contents of their own are kept as they are.
+func insertTOC(content string, depth int) string {
+	lines := strings.Split(content, "\n")
+
+	var (
+		output  = make([]string, 0, len(lines))
+		entries []tocEntry
+		used    = make(map[string]int)
+		first   = -1
+		fenced  bool
+	)
+
+	for i, line := range lines {
+		if line == tocHeading {
+			return content
+		}
+
+		if fence := strings.TrimSpace(line); strings.HasPrefix(fence, "```") || strings.HasPrefix(fence, "~~~") {
+			fenced = !fenced
+		}
+
+		match := headingPattern.FindStringSubmatch(line)
+		if fenced || match == nil {
+			output = append(output, line)
+
+			continue
+		}
+
+		if first < 0 {
+			first = len(output)
+		}
+
+		level := len(match[1])
+		label := linkPattern.ReplaceAllString(match[2], "$1")
+		listed := level-1 <= depth
+
+		var anchor string
+		if i > 0 {
+			if explicit := anchorPattern.FindStringSubmatch(lines[i-1]); explicit != nil {
+				anchor = explicit[1]
+				used[anchor]++
+				first = min(first, len(output)-1)
+			}
+		}
+
+		if anchor == "" {
+			anchor = uniqueAnchor(sanitizeAnchor(label), used)
+			if listed && anchor != "" {
+				// Anchors can't interrupt paragraphs and would join the preceding list item or text.
+				if len(output) > 0 && output[len(output)-1] != "" {
+					output = append(output, "")
+				}
+
+				output = append(output, `<a id="`+anchor+`"></a>`)
+			}
+		}
+
+		if listed && anchor != "" {
+			entries = append(entries, tocEntry{level: level, label: label, anchor: anchor})
+		}
+
+		output = append(output, line)
+	}
+
+	if len(entries) == 0 {
+		return content
+	}
+
+	// The blank line separating an added anchor moves above the table of contents.
+	if output[first] == "" {
+		first++
+	}
+
+	head := strings.Join(output[:first], "\n")
+	if head != "" && !strings.HasSuffix(head, "\n") {
+		head += "\n\n"
+	} else if head != "" {
+		head += "\n"
+	}
+
+	return head + tocMarkdown(entries) + strings.Join(output[first:], "\n")
+}
+
+// uniqueAnchor suffixes anchors repeated on a page with their count, as GitHub does.
+func uniqueAnchor(anchor string, used map[string]int) string {
+	if anchor == "" {
+		return ""
+	}
+
+	count := used[anchor]
+	used[anchor]++
+
+	if count == 0 {
+		return anchor
+	}
+
+	return anchor + "-" + strconv.Itoa(count)
+}
+
+// tocMarkdown renders the table of contents, nesting headings below the highest listed level.
+func tocMarkdown(entries []tocEntry) string {
+	top := entries[0].level
+	for _, entry := range entries {
+		top = min(top, entry.level)
+	}
+
+	var toc strings.Builder
+
+	toc.WriteString(tocHeading + "\n\n")
+
+	for _, entry := range entries {
+		toc.WriteString(strings.Repeat("  ", entry.level-top) + "- [" + entry.label + "](#" + entry.anchor + ")\n")
+	}
+
+	toc.WriteString("\n")
+
+	return toc.String()
+}
@@ -0,0 +1,31 @@
+package docs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInsertTOC(t *testing.T) {
+	page := "# Orders\n\nOrder handling.\n\n## API\n\n```md\n## Not a heading\n```\n\n" +
+		"<a id=\"orders-flow\"></a>\n## Message [Flow](flow.md)\n\n### API\n\n#### Payloads\n"
+
+	assert.Equal(t, "# Orders\n\nOrder handling.\n\n"+
+		"## Table of Contents\n\n- [API](#api)\n- [Message Flow](#orders-flow)\n  - [API](#api-1)\n\n"+
+		"<a id=\"api\"></a>\n## API\n\n```md\n## Not a heading\n```\n\n"+
+		"<a id=\"orders-flow\"></a>\n## Message [Flow](flow.md)\n\n<a id=\"api-1\"></a>\n### API\n\n#### Payloads\n",
+		insertTOC(page, 2))
+
+	withTOC := "# Overview\n\n## Table of Contents\n\n- [Services](services.md)\n\n## Overview\n"
+	assert.Equal(t, withTOC, insertTOC(withTOC, 2))
+
+	untitled := "Only text.\n"
+	assert.Equal(t, untitled, insertTOC(untitled, 2))
+}
+
+func TestInsertTOC_SeparatesAnchors(t *testing.T) {
+	page := "# Orders\n- Owner: sales\n## API\n- GET /orders\n## Events\n"
+
+	assert.Equal(t, "# Orders\n- Owner: sales\n\n## Table of Contents\n\n- [API](#api)\n- [Events](#events)\n\n"+
+		"<a id=\"api\"></a>\n## API\n- GET /orders\n\n<a id=\"events\"></a>\n## Events\n", insertTOC(page, 1))
+}
@@ -0,0 +1,239 @@
+package docs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/holydocs/holydocs/pkg/outputfs"
+)
+
+// viewerExt is the extension of the interactive viewers written next to SVG diagrams.
+const viewerExt = ".html"
+
+// viewerTemplate inlines a diagram, so its nodes can be found by their labels and made clickable. Panning and
+// zooming move the view box of the diagram, the viewers load no scripts and work offline.
+//
+//nolint:gochecknoglobals // Parsed once for the viewers of all diagrams.
+var viewerTemplate = template.Must(template.New("viewer").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{ .Title }}</title>
+<style>
+  html, body { margin: 0; height: 100%; overflow: hidden; }
+  #diagram { width: 100vw; height: 100vh; }
+  #diagram > svg { width: 100%; height: 100%; cursor: grab; }
+  #controls { position: fixed; right: 1em; bottom: 1em; display: flex; flex-direction: column; gap: 0.25em; }
+  #controls button { width: 2em; height: 2em; font-size: 1.2em; cursor: pointer; }
+  .holydocs-link { cursor: pointer; }
+</style>
+</head>
+<body>
+<div id="diagram">{{ .SVG }}</div>
+<div id="controls">
+  <button type="button" data-zoom="in" title="Zoom in">+</button>
+  <button type="button" data-zoom="reset" title="Fit">&#8634;</button>
+  <button type="button" data-zoom="out" title="Zoom out">&minus;</button>
+</div>
+<script>
+  const links = {{ .Links }};
+  const svg = document.querySelector("#diagram > svg");
+
+  // The diagram is fitted and centered in the window, zooming is limited to 0.1 to 20 times that.
+  if (!svg.getAttribute("viewBox")) {
+    const box = svg.getBBox();
+    svg.setAttribute("viewBox", [box.x, box.y, box.width, box.height].join(" "));
+  }
+  svg.setAttribute("preserveAspectRatio", "xMidYMid meet");
+
+  const base = svg.viewBox.baseVal;
+  const home = { x: base.x, y: base.y, width: base.width, height: base.height };
+  let view = Object.assign({}, home);
+
+  const show = () => svg.setAttribute("viewBox", [view.x, view.y, view.width, view.height].join(" "));
+  const zoom = (factor, x, y) => {
+    const scale = home.width * factor / view.width;
+    if (scale < 0.1 || scale > 20) return;
+    view = { x: x - (x - view.x) / factor, y: y - (y - view.y) / factor,
+      width: view.width / factor, height: view.height / factor };
+    show();
+  };
+  const point = (e) => {
+    const p = svg.createSVGPoint();
+    p.x = e.clientX;
+    p.y = e.clientY;
+    return p.matrixTransform(svg.getScreenCTM().inverse());
+  };
+
+  svg.addEventListener("wheel", (e) => {
+    e.preventDefault();
+    const p = point(e);
+    zoom(e.deltaY < 0 ? 1.2 : 1 / 1.2, p.x, p.y);
+  }, { passive: false });
+
+  document.querySelector("#controls").addEventListener("click", (e) => {
+    const action = e.target.dataset.zoom;
+    if (action === "reset") {
+      view = Object.assign({}, home);
+      show();
+    } else if (action) {
+      zoom(action === "in" ? 1.2 : 1 / 1.2, view.x + view.width / 2, view.y + view.height / 2);
+    }
+  });
+
+  // Clicks ending a drag pan the diagram rather than following links.
+  let down = null;
+  let last = null;
+  svg.addEventListener("mousedown", (e) => { down = last = [e.clientX, e.clientY]; });
+  window.addEventListener("mouseup", () => { last = null; });
+  window.addEventListener("mousemove", (e) => {
+    if (!last) return;
+    const scale = svg.getScreenCTM();
+    view.x -= (e.clientX - last[0]) / scale.a;
+    view.y -= (e.clientY - last[1]) / scale.d;
+    last = [e.clientX, e.clientY];
+    show();
+  });
+  const dragged = (e) => down && Math.hypot(e.clientX - down[0], e.clientY - down[1]) > 4;
+
+  for (const node of svg.querySelectorAll("g")) {
+    if (!node.querySelector(":scope > g.shape")) continue;
+    const label = node.querySelector(":scope > text") ||
+      node.querySelector(":scope > g > foreignObject h1") ||
+      node.querySelector(":scope > g > foreignObject");
+    const href = label && links[label.textContent.trim()];
+    if (!href) continue;
+    node.classList.add("holydocs-link");
+    node.addEventListener("click", (e) => {
+      if (!dragged(e)) window.location.href = href;
+    });
+  }
+</script>
+</body>
+</html>
+`))
+
+type viewerData struct {
+	Title string
+	SVG   template.HTML
+	Links map[string]string
+}
+
+// viewers reports whether interactive viewers are written for the diagrams. Viewers link the Markdown pages,
+// which site generators publish under other paths, and need the diagram files embedding does without.
+func (s site) viewers() bool {
+	return s.interactive && s.flavor == "" && !s.embedDiagrams
+}
+
+// viewerLinks returns the pages of the systems and services by their names, relative to the content directory.
+func viewerLinks(pages site, format string, data templateData) map[string]string {
+	links := make(map[string]string)
+
+	for _, system := range data.Systems {
+		if format == "md_multi_page" {
+			links[system.Name] = "systems/" + system.FileName + ".md"
+		} else {
+			links[system.Name] = pages.overviewFile() + "#" + sanitizeAnchor(system.Name)
+		}
+
+		for _, service := range system.Services {
+			if format == "md_multi_page" {
+				links[service.Name] = "services/" + service.FileName + ".md"
+			} else {
+				links[service.Name] = pages.overviewFile() + "#" + sanitizeAnchor(service.Name)
+			}
+		}
+	}
+
+	return links
+}
+
+// writeViewers writes an HTML page with pan and zoom next to every SVG diagram, badges and charts aside.
+// Clicking a system or service opens its documentation.
+func writeViewers(pages site, format string, data templateData) error {
+	links := viewerLinks(pages, format, data)
+	diagramsDir := filepath.Join(pages.diagramsBaseDir(), diagramsDirName)
+
+	err := outputfs.WalkDir(pages.fs, diagramsDir, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			if entry.Name() == badgesDirName || entry.Name() == statsDirName {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if filepath.Ext(file) != ".svg" {
+			return nil
+		}
+
+		rel, err := filepath.Rel(pages.diagramsBaseDir(), file)
+		if err != nil {
+			return err
+		}
+
+		return writeViewer(pages.fs, file, strings.Count(filepath.ToSlash(rel), "/"), links)
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to write diagram viewers: %w", err)
+	}
+
+	return nil
+}
+
+// writeViewer writes the viewer of a diagram depth directories below the content directory.
+func writeViewer(fsys outputfs.FS, file string, depth int, links map[string]string) error {
+	svg, err := fsys.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	// The XML declaration isn't allowed inside HTML.
+	if strings.HasPrefix(string(svg), "<?xml") {
+		if _, rest, found := strings.Cut(string(svg), "?>"); found {
+			svg = []byte(rest)
+		}
+	}
+
+	prefix := strings.Repeat("../", depth)
+	relative := make(map[string]string, len(links))
+	for name, link := range links {
+		relative[name] = prefix + link
+	}
+
+	var buf bytes.Buffer
+	if err := viewerTemplate.Execute(&buf, viewerData{
+		Title: strings.TrimSuffix(filepath.Base(file), ".svg"),
+		SVG:   template.HTML(svg), //nolint:gosec // Diagrams are rendered by holydocs.
+		Links: relative,
+	}); err != nil {
+		return fmt.Errorf("execute viewer template for %s: %w", file, err)
+	}
+
+	return fsys.WriteFile(strings.TrimSuffix(file, ".svg")+viewerExt, buf.Bytes(), filePerm)
+}
+
+// viewerPath returns the path of the viewer of a diagram linked from a page, empty when it has none.
+func (s site) viewerPath(src string) string {
+	if !s.viewers() || filepath.Ext(src) != ".svg" {
+		return ""
+	}
+
+	viewer := strings.TrimSuffix(src, ".svg") + viewerExt
+
+	diagramFile := filepath.Join(s.diagramsBaseDir(), filepath.FromSlash(diagramPath(viewer)))
+	if _, err := s.fs.Stat(diagramFile); err != nil {
+		return ""
+	}
+
+	return viewer
+}
@@ -0,0 +1,59 @@
+package docs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/pkg/outputfs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteViewers(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	svg := []byte(`<?xml version="1.0" encoding="utf-8"?><svg><text>Orders</text></svg>`)
+
+	for _, file := range []string{"overview.svg", "services/orders-relationships.svg", "badges/orders.svg"} {
+		path := filepath.Join(dir, diagramsDirName, filepath.FromSlash(file))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), dirPerm))
+		require.NoError(t, os.WriteFile(path, svg, filePerm))
+	}
+
+	pages := newSite(outputfs.OS(), config.Output{Dir: dir})
+	pages.interactive = true
+
+	data := templateData{Systems: []systemView{{
+		Name:     "Shop",
+		FileName: "shop",
+		Services: []serviceView{{Name: "Orders", FileName: "orders"}},
+	}}}
+	require.NoError(t, writeViewers(pages, "md_multi_page", data))
+
+	overview, err := os.ReadFile(filepath.Join(dir, diagramsDirName, "overview.html"))
+	require.NoError(t, err)
+	assert.Contains(t, string(overview), `<div id="diagram"><svg><text>Orders</text></svg></div>`)
+	assert.Contains(t, string(overview), `"Orders":"../services/orders.md"`)
+	assert.Contains(t, string(overview), `"Shop":"../systems/shop.md"`)
+	assert.NotContains(t, string(overview), "<script src=")
+
+	service, err := os.ReadFile(filepath.Join(dir, diagramsDirName, "services", "orders-relationships.html"))
+	require.NoError(t, err)
+	assert.Contains(t, string(service), `"Orders":"../../services/orders.md"`)
+
+	assert.NoFileExists(t, filepath.Join(dir, diagramsDirName, "badges", "orders.html"))
+
+	figure, err := pages.figure("Overview", "diagrams/overview.svg")
+	require.NoError(t, err)
+	assert.Equal(t, "![Overview](diagrams/overview.svg)\n\n[Open interactive diagram](diagrams/overview.html)", figure)
+
+	figure, err = pages.figure("Orders", "../diagrams/badges/orders.svg")
+	require.NoError(t, err)
+	assert.Equal(t, "![Orders](../diagrams/badges/orders.svg)", figure)
+
+	assert.Equal(t, map[string]string{"Shop": "README.md#shop", "Orders": "README.md#orders"},
+		viewerLinks(pages, "md_single_page", data))
+}
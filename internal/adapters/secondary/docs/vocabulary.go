@@ -0,0 +1,136 @@
+package docs
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/holydocs/holydocs/internal/config"
+)
+
+// applyVocabulary replaces generated terms of the documentation with the configured ones: the name of the group
+// of services without a system, directions of async connections and dependencies blocking decommissions.
+func applyVocabulary(data templateData, vocabulary config.Vocabulary) templateData {
+	if len(vocabulary) == 0 {
+		return data
+	}
+
+	standalone := vocabulary.Term(standaloneServicesName)
+
+	data.Systems = vocabularySystems(data.Systems, vocabulary)
+	data.SystemDiagrams = renameKey(data.SystemDiagrams, standaloneServicesName, standalone)
+	data.SystemMarkdowns = renameKey(data.SystemMarkdowns, standaloneServicesName, standalone)
+	data.SystemSummaries = renameKey(data.SystemSummaries, standaloneServicesName, standalone)
+
+	matrix := data.DependencyMatrix
+	matrix.Systems = slices.Clone(matrix.Systems)
+	for i := range matrix.Systems {
+		matrix.Systems[i] = standaloneTerm(matrix.Systems[i], vocabulary)
+	}
+
+	matrix.Rows = slices.Clone(matrix.Rows)
+	for i := range matrix.Rows {
+		matrix.Rows[i].System = standaloneTerm(matrix.Rows[i].System, vocabulary)
+	}
+
+	data.DependencyMatrix = matrix
+
+	personas := make([]personaView, len(data.Personas))
+	for i, persona := range data.Personas {
+		persona.Systems = slices.Clone(persona.Systems)
+		for j := range persona.Systems {
+			persona.Systems[j].Name = standaloneTerm(persona.Systems[j].Name, vocabulary)
+		}
+
+		personas[i] = persona
+	}
+
+	data.Personas = personas
+
+	decommissioning := make([]decommissionView, len(data.Decommissioning))
+	for i, view := range data.Decommissioning {
+		view.Blockers = slices.Clone(view.Blockers)
+		for j := range view.Blockers {
+			view.Blockers[j].Via = vocabularyPhrase(view.Blockers[j].Via, vocabulary)
+		}
+
+		decommissioning[i] = view
+	}
+
+	data.Decommissioning = decommissioning
+
+	return data
+}
+
+// vocabularySystems renames the group of services without a system and the directions of the async
+// connections of the services.
+func vocabularySystems(systems []systemView, vocabulary config.Vocabulary) []systemView {
+	renamed := make([]systemView, len(systems))
+	for i, system := range systems {
+		system.Name = standaloneTerm(system.Name, vocabulary)
+		system.Anchor = sanitizeAnchor(system.Name)
+
+		services := make([]serviceView, len(system.Services))
+		for j, service := range system.Services {
+			service.AsyncSummaries = slices.Clone(service.AsyncSummaries)
+			for k := range service.AsyncSummaries {
+				service.AsyncSummaries[k].Direction = vocabularyPhrase(service.AsyncSummaries[k].Direction, vocabulary)
+			}
+
+			service.InterServiceLinks = slices.Clone(service.InterServiceLinks)
+			for k := range service.InterServiceLinks {
+				service.InterServiceLinks[k].Direction = vocabularyPhrase(service.InterServiceLinks[k].Direction,
+					vocabulary)
+			}
+
+			services[j] = service
+		}
+
+		system.Services = services
+		renamed[i] = system
+	}
+
+	return renamed
+}
+
+// standaloneTerm returns the configured term of the group of services without a system, other names as is.
+func standaloneTerm(name string, vocabulary config.Vocabulary) string {
+	if name == standaloneServicesName {
+		return vocabulary.Term(standaloneServicesName)
+	}
+
+	return name
+}
+
+// vocabularyPhrase replaces the longest term of the vocabulary the text starts with, e.g. "publishes to" of
+// "publishes to orders.created".
+func vocabularyPhrase(text string, vocabulary config.Vocabulary) string {
+	terms := slices.SortedFunc(maps.Keys(vocabulary), func(a, b string) int {
+		return len(b) - len(a)
+	})
+
+	for _, term := range terms {
+		if text == term {
+			return vocabulary.Term(term)
+		}
+
+		if rest, ok := strings.CutPrefix(text, term+" "); ok {
+			return vocabulary.Term(term) + " " + rest
+		}
+	}
+
+	return text
+}
+
+func renameKey[V any](values map[string]V, from, to string) map[string]V {
+	value, ok := values[from]
+	if !ok || from == to {
+		return values
+	}
+
+	renamed := maps.Clone(values)
+	delete(renamed, from)
+	renamed[to] = value
+
+	return renamed
+}
@@ -0,0 +1,52 @@
+package docs
+
+import (
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyVocabulary(t *testing.T) {
+	t.Parallel()
+
+	vocabulary := config.Vocabulary{
+		"Standalone Services": "Shared Services",
+		"publishes to":        "emits to",
+		"sends to":            "produces to",
+	}
+
+	data := applyVocabulary(templateData{
+		Systems: []systemView{{
+			Name:   standaloneServicesName,
+			Anchor: sanitizeAnchor(standaloneServicesName),
+			Services: []serviceView{{
+				Name:              "Audit Service",
+				AsyncSummaries:    []asyncSummary{{Direction: "publishes to", Target: "Billing Service"}},
+				InterServiceLinks: []serviceConnection{{Direction: "sends to", Target: "Billing Service"}},
+			}},
+		}},
+		SystemDiagrams: map[string]systemDiagramView{standaloneServicesName: {SystemDiagram: "standalone.svg"}},
+		Decommissioning: []decommissionView{{
+			Service:  "Audit Service",
+			Blockers: []decommissionBlocker{{Service: "Billing Service", Via: "publishes to audit.events"}},
+		}},
+	}, vocabulary)
+
+	assert.Equal(t, "Shared Services", data.Systems[0].Name)
+	assert.Equal(t, "shared-services", data.Systems[0].Anchor)
+	assert.Equal(t, "emits to", data.Systems[0].Services[0].AsyncSummaries[0].Direction)
+	assert.Equal(t, "produces to", data.Systems[0].Services[0].InterServiceLinks[0].Direction)
+	assert.Contains(t, data.SystemDiagrams, "Shared Services")
+	assert.Equal(t, "emits to audit.events", data.Decommissioning[0].Blockers[0].Via)
+}
+
+func TestVocabularyPhrase(t *testing.T) {
+	t.Parallel()
+
+	vocabulary := config.Vocabulary{"receives from": "reads from", "receives from and replies to": "serves"}
+
+	assert.Equal(t, "serves", vocabularyPhrase("receives from and replies to", vocabulary))
+	assert.Equal(t, "reads from orders", vocabularyPhrase("receives from orders", vocabulary))
+	assert.Equal(t, "requests", vocabularyPhrase("requests", vocabulary))
+}
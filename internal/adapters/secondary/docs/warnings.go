@@ -0,0 +1,113 @@
+package docs
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+)
+
+// ghostParticipantWarnings reports service-to-service relationships with a participant that is neither a
+// documented service nor marked as external or a person. Such participants are drawn as external nodes,
+// which usually hides a typo or a service without a specification. Infrastructure used by a service,
+// such as databases, is expected to be undocumented.
+func ghostParticipantWarnings(schema domain.Schema) []string {
+	services := make(map[string]struct{}, len(schema.Services))
+	for _, service := range schema.Services {
+		services[service.Info.Name] = struct{}{}
+	}
+
+	var warnings []string
+
+	for _, service := range schema.Services {
+		for _, rel := range service.Relationships {
+			if rel.Action == domain.RelationshipActionUses || rel.External || rel.Person || rel.Participant == "" {
+				continue
+			}
+
+			if _, ok := services[rel.Participant]; !ok {
+				warnings = append(warnings, fmt.Sprintf("%s %s unknown participant %s, "+
+					"add its specification or mark it as external", service.Info.Name, rel.Action, rel.Participant))
+			}
+		}
+	}
+
+	return warnings
+}
+
+// configReferenceWarnings reports documentation configured for services and systems missing from the schema
+// and markdown files that can't be read, which are left out of the documentation.
+func configReferenceWarnings(schema domain.Schema, documentation config.Documentation) []string {
+	services := make(map[string]struct{}, len(schema.Services))
+	systems := make(map[string]struct{})
+
+	for _, service := range schema.Services {
+		services[service.Info.Name] = struct{}{}
+		if service.Info.System != "" {
+			systems[service.Info.System] = struct{}{}
+		}
+	}
+
+	warnings := markdownFileWarnings(nil, "overview description", documentation.Overview.Description)
+
+	for _, name := range slices.Sorted(maps.Keys(documentation.Services)) {
+		if _, ok := services[name]; !ok {
+			warnings = append(warnings, fmt.Sprintf("documentation configured for unknown service %s", name))
+		}
+
+		doc := documentation.Services[name]
+		warnings = markdownFileWarnings(warnings, "service "+name+" summary", doc.Summary)
+		warnings = markdownFileWarnings(warnings, "service "+name+" description", doc.Description)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(documentation.Systems)) {
+		if _, ok := systems[name]; !ok {
+			warnings = append(warnings, fmt.Sprintf("documentation configured for unknown system %s", name))
+		}
+
+		doc := documentation.Systems[name]
+		warnings = markdownFileWarnings(warnings, "system "+name+" summary", doc.Summary)
+		warnings = markdownFileWarnings(warnings, "system "+name+" description", doc.Description)
+	}
+
+	return warnings
+}
+
+// repositoryTemplateWarnings reports services whose repository is hosted on an SCM host without link
+// templates while templates are configured for other hosts, the services are left without deep links.
+func repositoryTemplateWarnings(schema domain.Schema, hosts map[string]config.RepositoryLinks) []string {
+	if len(hosts) == 0 {
+		return nil
+	}
+
+	var warnings []string
+
+	for _, service := range schema.Services {
+		_, host, _, ok := parseRepository(service.Info.Repository)
+		if !ok {
+			continue
+		}
+
+		if _, found := hostLinks(hosts, host); !found {
+			warnings = append(warnings, fmt.Sprintf("service %s: no repository link templates configured for %s",
+				service.Info.Name, host))
+		}
+	}
+
+	return warnings
+}
+
+func markdownFileWarnings(warnings []string, context string, markdown config.Markdown) []string {
+	if markdown.Content != "" || markdown.FilePath == "" {
+		return warnings
+	}
+
+	if _, err := os.ReadFile(markdown.FilePath); err != nil {
+		return append(warnings, fmt.Sprintf("%s: can't read markdown file: %v", context, err))
+	}
+
+	return warnings
+}
@@ -0,0 +1,74 @@
+package docs
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func warningsSchema() domain.Schema {
+	return domain.Schema{Services: []domain.Service{
+		{
+			Info: domain.ServiceInfo{Name: "Order Service", System: "Shop"},
+			Relationships: []domain.Relationship{
+				{Action: domain.RelationshipActionUses, Participant: "postgres"},
+				{Action: domain.RelationshipActionRequests, Participant: "Payment Service"},
+				{Action: domain.RelationshipActionRequests, Participant: "Billing Service"},
+				{Action: domain.RelationshipActionRequests, Participant: "Stripe", External: true},
+				{Action: domain.RelationshipActionReplies, Participant: "Customer", Person: true},
+			},
+		},
+		{Info: domain.ServiceInfo{Name: "Payment Service", System: "Shop"}},
+	}}
+}
+
+func TestGhostParticipantWarnings(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{
+		"Order Service requests unknown participant Billing Service, add its specification or mark it as external",
+	}, ghostParticipantWarnings(warningsSchema()))
+}
+
+func TestConfigReferenceWarnings(t *testing.T) {
+	t.Parallel()
+
+	missing := filepath.Join(t.TempDir(), "missing.md")
+
+	warnings := configReferenceWarnings(warningsSchema(), config.Documentation{
+		Services: map[string]config.ServiceDocumentation{
+			"Order Service":    {Summary: config.Markdown{Content: "Orders"}},
+			"Shipping Service": {Summary: config.Markdown{Content: "Shipping"}},
+		},
+		Systems: map[string]config.SystemDocumentation{
+			"Shop":      {Description: config.Markdown{FilePath: missing}},
+			"Warehouse": {},
+		},
+	})
+
+	assert.Len(t, warnings, 3)
+	assert.Equal(t, "documentation configured for unknown service Shipping Service", warnings[0])
+	assert.Contains(t, warnings[1], "system Shop description: can't read markdown file")
+	assert.Equal(t, "documentation configured for unknown system Warehouse", warnings[2])
+
+	assert.Empty(t, configReferenceWarnings(warningsSchema(), config.Documentation{}))
+}
+
+func TestRepositoryTemplateWarnings(t *testing.T) {
+	t.Parallel()
+
+	schema := domain.Schema{Services: []domain.Service{
+		{Info: domain.ServiceInfo{Name: "Order Service", Repository: "https://github.com/acme/orders"}},
+		{Info: domain.ServiceInfo{Name: "Payment Service", Repository: "git@gitlab.acme.io:shop/payments.git"}},
+		{Info: domain.ServiceInfo{Name: "Mailer Service"}},
+	}}
+
+	assert.Equal(t, []string{
+		"service Payment Service: no repository link templates configured for gitlab.acme.io",
+	}, repositoryTemplateWarnings(schema, map[string]config.RepositoryLinks{"GitHub.com": {}}))
+
+	assert.Empty(t, repositoryTemplateWarnings(schema, nil))
+}
@@ -0,0 +1,224 @@
+// Package lineage encodes the datasets services read and write as OpenLineage events or DataHub proposals.
+package lineage
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	do "github.com/samber/do/v2"
+)
+
+// producer identifies holydocs as the producer of OpenLineage events.
+const producer = "https://github.com/holydocs/holydocs"
+
+// OpenLineage schemas of the emitted events and facets.
+const (
+	openLineageJobEventSchema      = "https://openlineage.io/spec/2-0-2/OpenLineage.json#/$defs/JobEvent"
+	openLineageDocumentationSchema = "https://openlineage.io/spec/facets/1-0-1/DocumentationJobFacet.json" +
+		"#/$defs/DocumentationJobFacet"
+	openLineageOwnershipSchema = "https://openlineage.io/spec/facets/1-0-1/OwnershipJobFacet.json" +
+		"#/$defs/OwnershipJobFacet"
+)
+
+// dataHubEnv is the environment (fabric) of the exported DataHub entities.
+const dataHubEnv = "PROD"
+
+type openLineageJobEvent struct {
+	EventTime string               `json:"eventTime"`
+	Producer  string               `json:"producer"`
+	SchemaURL string               `json:"schemaURL"`
+	Job       openLineageJob       `json:"job"`
+	Inputs    []openLineageDataset `json:"inputs"`
+	Outputs   []openLineageDataset `json:"outputs"`
+}
+
+type openLineageJob struct {
+	Namespace string         `json:"namespace"`
+	Name      string         `json:"name"`
+	Facets    map[string]any `json:"facets,omitempty"`
+}
+
+type openLineageDataset struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// dataHubProposal is a metadata change proposal in the JSON file format read by the DataHub file source.
+type dataHubProposal struct {
+	EntityType string        `json:"entityType"`
+	EntityURN  string        `json:"entityUrn"`
+	ChangeType string        `json:"changeType"`
+	AspectName string        `json:"aspectName"`
+	Aspect     dataHubAspect `json:"aspect"`
+}
+
+type dataHubAspect struct {
+	JSON any `json:"json"`
+}
+
+// Exporter encodes lineage jobs as OpenLineage job events or DataHub metadata change proposals.
+type Exporter struct {
+	// now is the event time of OpenLineage events.
+	now func() time.Time
+}
+
+func NewExporter(_ do.Injector) (*Exporter, error) {
+	return &Exporter{now: time.Now}, nil
+}
+
+// Export writes the lineage as OpenLineage job events, one JSON document per line, or as a JSON list of
+// DataHub metadata change proposals. Services are jobs of the namespace, grouped into a DataHub data flow per
+// system, services without a system go under the global name.
+func (e *Exporter) Export(jobs []domain.LineageJob, req domain.ExportLineageRequest) ([]byte, error) {
+	var buf bytes.Buffer
+
+	encoder := json.NewEncoder(&buf)
+
+	switch req.Format {
+	case domain.LineageExportFormatOpenLineage:
+		now := e.now()
+
+		for _, job := range jobs {
+			if err := encoder.Encode(openLineageEvent(job, req.Namespace, now)); err != nil {
+				return nil, fmt.Errorf("encoding lineage of %s as OpenLineage: %w", job.Service, err)
+			}
+		}
+	case domain.LineageExportFormatDataHub:
+		encoder.SetIndent("", "  ")
+
+		if err := encoder.Encode(dataHubProposals(jobs, req.Namespace, req.GlobalName)); err != nil {
+			return nil, fmt.Errorf("encoding lineage as DataHub proposals: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("%w: format %q, expected one of %v",
+			domain.ErrUnsupportedValue, req.Format, domain.LineageExportFormats())
+	}
+
+	return buf.Bytes(), nil
+}
+
+func openLineageEvent(job domain.LineageJob, namespace string, now time.Time) openLineageJobEvent {
+	facets := make(map[string]any)
+
+	if job.Description != "" {
+		facets["documentation"] = map[string]any{
+			"_producer":   producer,
+			"_schemaURL":  openLineageDocumentationSchema,
+			"description": job.Description,
+		}
+	}
+
+	if job.Owner != "" {
+		facets["ownership"] = map[string]any{
+			"_producer":  producer,
+			"_schemaURL": openLineageOwnershipSchema,
+			"owners":     []map[string]string{{"name": job.Owner, "type": "team"}},
+		}
+	}
+
+	event := openLineageJobEvent{
+		EventTime: now.UTC().Format(time.RFC3339),
+		Producer:  producer,
+		SchemaURL: openLineageJobEventSchema,
+		Job:       openLineageJob{Namespace: namespace, Name: job.Service, Facets: facets},
+		Inputs:    make([]openLineageDataset, 0, len(job.Inputs)),
+		Outputs:   make([]openLineageDataset, 0, len(job.Outputs)),
+	}
+
+	for _, dataset := range job.Inputs {
+		event.Inputs = append(event.Inputs, openLineageDataset{platform(dataset, namespace), dataset.Name})
+	}
+
+	for _, dataset := range job.Outputs {
+		event.Outputs = append(event.Outputs, openLineageDataset{platform(dataset, namespace), dataset.Name})
+	}
+
+	return event
+}
+
+func dataHubProposals(jobs []domain.LineageJob, namespace, globalName string) []dataHubProposal {
+	var proposals []dataHubProposal
+
+	flows := make(map[string]struct{})
+
+	for _, job := range jobs {
+		flow := job.System
+		if flow == "" {
+			flow = globalName
+		}
+
+		flowURN := fmt.Sprintf("urn:li:dataFlow:(%s,%s,%s)", urnPart(namespace), urnPart(flow), dataHubEnv)
+		if _, exists := flows[flowURN]; !exists {
+			flows[flowURN] = struct{}{}
+			proposals = append(proposals, dataHubUpsert("dataFlow", flowURN, "dataFlowInfo",
+				map[string]any{"name": flow}))
+		}
+
+		jobURN := fmt.Sprintf("urn:li:dataJob:(%s,%s)", flowURN, urnPart(job.Service))
+
+		info := map[string]any{"name": job.Service, "type": map[string]string{"string": "SERVICE"}}
+		if job.Description != "" {
+			info["description"] = job.Description
+		}
+
+		proposals = append(proposals,
+			dataHubUpsert("dataJob", jobURN, "dataJobInfo", info),
+			dataHubUpsert("dataJob", jobURN, "dataJobInputOutput", map[string]any{
+				"inputDatasets":  dataHubDatasets(job.Inputs, namespace),
+				"outputDatasets": dataHubDatasets(job.Outputs, namespace),
+			}),
+		)
+
+		if job.Owner != "" {
+			proposals = append(proposals, dataHubUpsert("dataJob", jobURN, "ownership", map[string]any{
+				"owners": []map[string]string{{
+					"owner": "urn:li:corpGroup:" + urnPart(job.Owner),
+					"type":  "TECHNICAL_OWNER",
+				}},
+			}))
+		}
+	}
+
+	return proposals
+}
+
+func dataHubUpsert(entityType, urn, aspectName string, aspect any) dataHubProposal {
+	return dataHubProposal{
+		EntityType: entityType,
+		EntityURN:  urn,
+		ChangeType: "UPSERT",
+		AspectName: aspectName,
+		Aspect:     dataHubAspect{JSON: aspect},
+	}
+}
+
+func dataHubDatasets(datasets []domain.LineageDataset, namespace string) []string {
+	urns := make([]string, 0, len(datasets))
+
+	for _, dataset := range datasets {
+		urns = append(urns, fmt.Sprintf("urn:li:dataset:(urn:li:dataPlatform:%s,%s,%s)",
+			urnPart(platform(dataset, namespace)), urnPart(dataset.Name), dataHubEnv))
+	}
+
+	return urns
+}
+
+// platform returns the namespace of a dataset: the technology of datastores, e.g. postgresql,
+// and the namespace of the jobs for channels and datastores without a technology.
+func platform(dataset domain.LineageDataset, namespace string) string {
+	technology := strings.ToLower(strings.TrimSpace(dataset.Technology))
+	if dataset.Kind != domain.LineageDatasetDatastore || technology == "" {
+		return namespace
+	}
+
+	return strings.ReplaceAll(technology, " ", "-")
+}
+
+// urnPart escapes the characters delimiting the parts of DataHub URNs.
+func urnPart(value string) string {
+	return strings.NewReplacer("%", "%25", "(", "%28", ")", "%29", ",", "%2C").Replace(value)
+}
@@ -0,0 +1,69 @@
+package lineage
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	do "github.com/samber/do/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExporter_Export(t *testing.T) {
+	t.Parallel()
+
+	jobs := []domain.LineageJob{{
+		Service:     "Order Service",
+		Description: "Takes orders.",
+		Owner:       "team-orders",
+		Inputs: []domain.LineageDataset{
+			{Name: "orders-db", Kind: domain.LineageDatasetDatastore, Technology: "PostgreSQL"},
+		},
+		Outputs: []domain.LineageDataset{{Name: "orders.created", Kind: domain.LineageDatasetChannel}},
+	}}
+	exporter := &Exporter{now: func() time.Time { return time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC) }}
+
+	openLineage, err := exporter.Export(jobs, domain.ExportLineageRequest{
+		Format: domain.LineageExportFormatOpenLineage, Namespace: "acme", GlobalName: "Internal",
+	})
+	require.NoError(t, err)
+
+	var event map[string]any
+	require.NoError(t, json.Unmarshal(openLineage, &event))
+	assert.Equal(t, "2025-03-01T12:00:00Z", event["eventTime"])
+	job, ok := event["job"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "acme", job["namespace"])
+	assert.Equal(t, "Order Service", job["name"])
+	assert.Equal(t, []any{map[string]any{"namespace": "postgresql", "name": "orders-db"}}, event["inputs"])
+	assert.Equal(t, []any{map[string]any{"namespace": "acme", "name": "orders.created"}}, event["outputs"])
+
+	dataHub, err := exporter.Export(jobs, domain.ExportLineageRequest{
+		Format: domain.LineageExportFormatDataHub, Namespace: "acme", GlobalName: "Internal, Shared",
+	})
+	require.NoError(t, err)
+
+	var proposals []dataHubProposal
+	require.NoError(t, json.Unmarshal(dataHub, &proposals))
+	require.Len(t, proposals, 4)
+	assert.Equal(t, "urn:li:dataFlow:(acme,Internal%2C Shared,PROD)", proposals[0].EntityURN)
+	assert.Equal(t, "urn:li:dataJob:(urn:li:dataFlow:(acme,Internal%2C Shared,PROD),Order Service)",
+		proposals[2].EntityURN)
+	assert.Equal(t, map[string]any{
+		"inputDatasets":  []any{"urn:li:dataset:(urn:li:dataPlatform:postgresql,orders-db,PROD)"},
+		"outputDatasets": []any{"urn:li:dataset:(urn:li:dataPlatform:acme,orders.created,PROD)"},
+	}, proposals[2].Aspect.JSON)
+	assert.Equal(t, "ownership", proposals[3].AspectName)
+}
+
+func TestExporter_ExportUnsupportedFormat(t *testing.T) {
+	t.Parallel()
+
+	exporter, err := NewExporter(do.New())
+	require.NoError(t, err)
+
+	_, err = exporter.Export(nil, domain.ExportLineageRequest{Format: "marquez"})
+	require.ErrorIs(t, err, domain.ErrUnsupportedValue)
+}
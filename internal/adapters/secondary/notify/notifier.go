@@ -0,0 +1,175 @@
+// Package notify posts changes of the documented architecture to the webhooks of subscribers.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	do "github.com/samber/do/v2"
+)
+
+// maxResponseSize limits the size of a webhook response read before closing it.
+const maxResponseSize = 1 << 16
+
+// Notifier posts changes to incoming webhooks of Slack, Microsoft Teams and Mattermost.
+type Notifier struct {
+	client *http.Client
+}
+
+func NewNotifier(_ do.Injector) (*Notifier, error) {
+	return &Notifier{client: &http.Client{}}, nil
+}
+
+// payloads render the message of a notification in the format of its webhook, by notification type.
+//
+//nolint:gochecknoglobals // Fixed set of webhook formats.
+var payloads = map[domain.NotificationType]func(domain.NotifyRequest) any{
+	domain.NotificationTypeSlack:      slackPayload,
+	domain.NotificationTypeTeams:      teamsPayload,
+	domain.NotificationTypeMattermost: mattermostPayload,
+}
+
+// Notify posts the changes to the webhook of the request, in the format of its type.
+func (n *Notifier) Notify(ctx context.Context, req domain.NotifyRequest) error {
+	payload, ok := payloads[req.Type]
+	if !ok {
+		return fmt.Errorf("%w: notification type %q, expected one of %v",
+			domain.ErrUnsupportedValue, req.Type, domain.NotificationTypes())
+	}
+
+	if req.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, req.Timeout)
+		defer cancel()
+	}
+
+	body, err := json.Marshal(payload(req))
+	if err != nil {
+		return fmt.Errorf("encoding message: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.Webhook, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("posting to webhook: %w", redactURL(err))
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseSize))
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("posting to webhook: unexpected status %s", resp.Status)
+	}
+
+	return nil
+}
+
+type textMessage struct {
+	Text string `json:"text"`
+}
+
+// slackPayload lists the changes below a heading in Slack mrkdwn.
+func slackPayload(req domain.NotifyRequest) any {
+	return textMessage{Text: messageText(req, "*%s*\n", "• %s\n")}
+}
+
+// mattermostPayload lists the changes below a heading in Markdown.
+func mattermostPayload(req domain.NotifyRequest) any {
+	return textMessage{Text: messageText(req, "**%s**\n", "- %s\n")}
+}
+
+func messageText(req domain.NotifyRequest, headingFormat, changeFormat string) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, headingFormat, heading(req))
+
+	for _, change := range req.Changes {
+		fmt.Fprintf(&b, changeFormat, changeText(change))
+	}
+
+	return b.String()
+}
+
+type teamsMessage struct {
+	Type        string            `json:"type"`
+	Attachments []teamsAttachment `json:"attachments"`
+}
+
+type teamsAttachment struct {
+	ContentType string       `json:"contentType"`
+	Content     adaptiveCard `json:"content"`
+}
+
+type adaptiveCard struct {
+	Schema  string              `json:"$schema"`
+	Type    string              `json:"type"`
+	Version string              `json:"version"`
+	Body    []adaptiveTextBlock `json:"body"`
+}
+
+type adaptiveTextBlock struct {
+	Type   string `json:"type"`
+	Text   string `json:"text"`
+	Wrap   bool   `json:"wrap"`
+	Weight string `json:"weight,omitempty"`
+	Size   string `json:"size,omitempty"`
+}
+
+// teamsPayload lists the changes below a heading in an Adaptive Card, the format of Teams workflow webhooks.
+func teamsPayload(req domain.NotifyRequest) any {
+	body := []adaptiveTextBlock{{Type: "TextBlock", Text: heading(req), Wrap: true, Weight: "Bolder", Size: "Medium"}}
+	for _, change := range req.Changes {
+		body = append(body, adaptiveTextBlock{Type: "TextBlock", Text: "- " + changeText(change), Wrap: true})
+	}
+
+	return teamsMessage{
+		Type: "message",
+		Attachments: []teamsAttachment{{
+			ContentType: "application/vnd.microsoft.card.adaptive",
+			Content: adaptiveCard{
+				Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+				Type:    "AdaptiveCard",
+				Version: "1.4",
+				Body:    body,
+			},
+		}},
+	}
+}
+
+// heading names the subscriber and the date of the changes.
+func heading(req domain.NotifyRequest) string {
+	return fmt.Sprintf("Architecture changes affecting %s (%s)", req.Subscriber, req.Date.Format("2006-01-02"))
+}
+
+// changeText describes a change by its details, or by its category and name when it has none.
+func changeText(change domain.Change) string {
+	if change.Details != "" {
+		return change.Details
+	}
+
+	return fmt.Sprintf("%s %s was %s", change.Category, change.Name, change.Type)
+}
+
+// redactURL drops the URL from errors of requests, since webhook URLs carry their credentials.
+func redactURL(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err
+	}
+
+	return err
+}
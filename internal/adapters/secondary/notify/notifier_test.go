@@ -0,0 +1,98 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotifier_Notify(t *testing.T) {
+	t.Parallel()
+
+	var received textMessage
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/services/T0/B0/secret" {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	notifier := &Notifier{client: server.Client()}
+	req := domain.NotifyRequest{
+		Type:       domain.NotificationTypeSlack,
+		Webhook:    server.URL + "/services/T0/B0/secret",
+		Subscriber: "team-orders",
+		Date:       time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC),
+		Changes: []domain.Change{
+			{Type: domain.ChangeTypeAdded, Category: "service", Name: "Search", Details: "'Search' was added"},
+			{Type: domain.ChangeTypeChanged, Category: "operation", Name: "Payments:send:payments.captured"},
+		},
+	}
+
+	require.NoError(t, notifier.Notify(context.Background(), req))
+	assert.Equal(t, "*Architecture changes affecting team-orders (2026-10-17)*\n"+
+		"• 'Search' was added\n"+
+		"• operation Payments:send:payments.captured was changed\n", received.Text)
+
+	req.Type = "discord"
+	require.ErrorIs(t, notifier.Notify(context.Background(), req), domain.ErrUnsupportedValue)
+
+	req.Type = domain.NotificationTypeSlack
+	req.Webhook = server.URL + "/services/T0/B0/revoked"
+	require.ErrorContains(t, notifier.Notify(context.Background(), req), "unexpected status 404 Not Found")
+
+	req.Webhook = "http://127.0.0.1:0/services/T0/B0/secret"
+	err := notifier.Notify(context.Background(), req)
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "secret", "webhook URLs are left out of errors")
+}
+
+func TestPayloads(t *testing.T) {
+	t.Parallel()
+
+	req := domain.NotifyRequest{
+		Subscriber: "team-orders",
+		Date:       time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC),
+		Changes: []domain.Change{
+			{Type: domain.ChangeTypeAdded, Category: "service", Name: "Search", Details: "'Search' was added"},
+		},
+	}
+
+	mattermost, err := json.Marshal(mattermostPayload(req))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"text": "**Architecture changes affecting team-orders (2026-10-17)**\n- 'Search' was added\n"}`,
+		string(mattermost))
+
+	teams, err := json.Marshal(teamsPayload(req))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "message",
+		"attachments": [{
+			"contentType": "application/vnd.microsoft.card.adaptive",
+			"content": {
+				"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+				"type": "AdaptiveCard",
+				"version": "1.4",
+				"body": [
+					{"type": "TextBlock", "text": "Architecture changes affecting team-orders (2026-10-17)", "wrap": true,
+						"weight": "Bolder", "size": "Medium"},
+					{"type": "TextBlock", "text": "- 'Search' was added", "wrap": true}
+				]
+			}
+		}]
+	}`, string(teams))
+}
@@ -0,0 +1,218 @@
+// Package oncall looks up the escalation policies of services at on-call providers.
+package oncall
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	do "github.com/samber/do/v2"
+)
+
+// maxResponseSize limits the size of a response of a provider API.
+const maxResponseSize = 8 << 20
+
+// Public APIs of the providers, used unless the request names another one, e.g. the EU instance of Opsgenie.
+const (
+	pagerDutyBaseURL = "https://api.pagerduty.com"
+	opsgenieBaseURL  = "https://api.opsgenie.com"
+)
+
+// provider looks up the escalation policy a service is mapped to.
+type provider interface {
+	lookup(ctx context.Context, client *http.Client, req domain.LookupOnCallRequest) (domain.OnCall, error)
+}
+
+//nolint:gochecknoglobals // Implementations of the supported on-call providers.
+var providers = map[domain.OnCallProvider]provider{
+	domain.OnCallProviderPagerDuty: pagerDuty{},
+	domain.OnCallProviderOpsgenie:  opsgenie{},
+}
+
+// Directory looks up escalation policies with the provider selected by the request.
+type Directory struct {
+	client *http.Client
+}
+
+func NewDirectory(_ do.Injector) (*Directory, error) {
+	return &Directory{client: &http.Client{}}, nil
+}
+
+// Lookup returns the escalation policy currently paging the owners of the service the request identifies.
+func (d *Directory) Lookup(ctx context.Context, req domain.LookupOnCallRequest) (domain.OnCall, error) {
+	p, ok := providers[req.Provider]
+	if !ok {
+		return domain.OnCall{}, fmt.Errorf("%w: on-call provider %q, expected one of %v",
+			domain.ErrUnsupportedValue, req.Provider, domain.OnCallProviders())
+	}
+
+	if req.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, req.Timeout)
+		defer cancel()
+	}
+
+	onCall, err := p.lookup(ctx, d.client, req)
+	if err != nil {
+		return domain.OnCall{}, err
+	}
+
+	onCall.Provider = req.Provider
+
+	return onCall, nil
+}
+
+// pagerDuty reads the escalation policy of a PagerDuty service, the ID of the request is the service ID.
+type pagerDuty struct{}
+
+type pagerDutyServiceResponse struct {
+	Service struct {
+		HTMLURL          string `json:"html_url"`
+		EscalationPolicy struct {
+			Name    string `json:"name"`
+			Summary string `json:"summary"`
+			HTMLURL string `json:"html_url"`
+		} `json:"escalation_policy"`
+	} `json:"service"`
+}
+
+func (pagerDuty) lookup(ctx context.Context, client *http.Client,
+	req domain.LookupOnCallRequest) (domain.OnCall, error) {
+	endpoint := apiURL(req.BaseURL, pagerDutyBaseURL, "services", url.PathEscape(req.ID)) +
+		"?include%5B%5D=escalation_policies"
+
+	var resp pagerDutyServiceResponse
+	if err := getJSON(ctx, client, endpoint, map[string]string{
+		"Authorization": "Token token=" + req.Token,
+		"Accept":        "application/vnd.pagerduty+json;version=2",
+	}, &resp); err != nil {
+		return domain.OnCall{}, err
+	}
+
+	policy := resp.Service.EscalationPolicy
+
+	name := policy.Name
+	if name == "" {
+		name = policy.Summary
+	}
+
+	if name == "" {
+		return domain.OnCall{}, fmt.Errorf("service %s has no escalation policy at PagerDuty", req.ID)
+	}
+
+	return domain.OnCall{
+		EscalationPolicy:    name,
+		EscalationPolicyURL: policy.HTMLURL,
+		URL:                 resp.Service.HTMLURL,
+	}, nil
+}
+
+// opsgenie reads the escalation policies owned by an Opsgenie team, the ID of the request is the team name.
+// Opsgenie has no pages of single escalation policies, the team page is linked instead.
+type opsgenie struct{}
+
+type opsgenieTeamResponse struct {
+	Data struct {
+		Name  string `json:"name"`
+		Links struct {
+			Web string `json:"web"`
+		} `json:"links"`
+	} `json:"data"`
+}
+
+type opsgenieEscalationsResponse struct {
+	Data []struct {
+		Name      string `json:"name"`
+		OwnerTeam struct {
+			Name string `json:"name"`
+		} `json:"ownerTeam"`
+	} `json:"data"`
+}
+
+func (opsgenie) lookup(ctx context.Context, client *http.Client,
+	req domain.LookupOnCallRequest) (domain.OnCall, error) {
+	headers := map[string]string{"Authorization": "GenieKey " + req.Token}
+
+	var team opsgenieTeamResponse
+	if err := getJSON(ctx, client, apiURL(req.BaseURL, opsgenieBaseURL, "v2", "teams", url.PathEscape(req.ID))+
+		"?identifierType=name", headers, &team); err != nil {
+		return domain.OnCall{}, err
+	}
+
+	var escalations opsgenieEscalationsResponse
+	if err := getJSON(ctx, client, apiURL(req.BaseURL, opsgenieBaseURL, "v2", "escalations"), headers,
+		&escalations); err != nil {
+		return domain.OnCall{}, err
+	}
+
+	var names []string
+
+	for _, escalation := range escalations.Data {
+		if escalation.OwnerTeam.Name == team.Data.Name {
+			names = append(names, escalation.Name)
+		}
+	}
+
+	if len(names) == 0 {
+		return domain.OnCall{}, fmt.Errorf("team %s owns no escalation policy at Opsgenie", req.ID)
+	}
+
+	slices.Sort(names)
+
+	return domain.OnCall{
+		EscalationPolicy: strings.Join(names, ", "),
+		URL:              team.Data.Links.Web,
+	}, nil
+}
+
+// apiURL joins the path segments to the base URL of the request, or to the public API of the provider.
+func apiURL(baseURL, fallback string, segments ...string) string {
+	if baseURL == "" {
+		baseURL = fallback
+	}
+
+	return strings.TrimSuffix(baseURL, "/") + "/" + strings.Join(segments, "/")
+}
+
+// getJSON requests the endpoint with the headers and decodes the JSON response into v.
+func getJSON(ctx context.Context, client *http.Client, endpoint string, headers map[string]string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("requesting %s: %w", req.URL.Redacted(), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("requesting %s: unexpected status %s", req.URL.Redacted(), resp.Status)
+	}
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize+1))
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", req.URL.Redacted(), err)
+	}
+
+	if len(content) > maxResponseSize {
+		return fmt.Errorf("%s answered with more than %d bytes", req.URL.Redacted(), maxResponseSize)
+	}
+
+	if err := json.Unmarshal(content, v); err != nil {
+		return fmt.Errorf("parsing %s: %w", req.URL.Redacted(), err)
+	}
+
+	return nil
+}
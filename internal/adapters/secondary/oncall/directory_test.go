@@ -0,0 +1,101 @@
+package oncall
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDirectory_LookupPagerDuty(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/services/PX7Q2M1", r.URL.Path)
+		assert.Equal(t, []string{"escalation_policies"}, r.URL.Query()["include[]"])
+		assert.Equal(t, "Token token=secret", r.Header.Get("Authorization"))
+
+		_, _ = w.Write([]byte(`{"service": {
+			"id": "PX7Q2M1",
+			"html_url": "https://acme.pagerduty.com/service-directory/PX7Q2M1",
+			"escalation_policy": {
+				"id": "PE1",
+				"name": "Payments Primary",
+				"html_url": "https://acme.pagerduty.com/escalation_policies/PE1"
+			}
+		}}`))
+	}))
+	defer server.Close()
+
+	directory := &Directory{client: server.Client()}
+
+	onCall, err := directory.Lookup(context.Background(), domain.LookupOnCallRequest{
+		Provider: domain.OnCallProviderPagerDuty,
+		BaseURL:  server.URL,
+		Token:    "secret",
+		ID:       "PX7Q2M1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, domain.OnCall{
+		Provider:            domain.OnCallProviderPagerDuty,
+		EscalationPolicy:    "Payments Primary",
+		EscalationPolicyURL: "https://acme.pagerduty.com/escalation_policies/PE1",
+		URL:                 "https://acme.pagerduty.com/service-directory/PX7Q2M1",
+	}, onCall)
+}
+
+func TestDirectory_LookupOpsgenie(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GenieKey secret", r.Header.Get("Authorization"))
+
+		switch r.URL.Path {
+		case "/v2/teams/Payments Team":
+			assert.Equal(t, "name", r.URL.Query().Get("identifierType"))
+			_, _ = w.Write([]byte(`{"data": {"name": "Payments Team",
+				"links": {"web": "https://acme.app.opsgenie.com/teams/dashboard/T1/main"}}}`))
+		case "/v2/escalations":
+			_, _ = w.Write([]byte(`{"data": [
+				{"name": "Payments Weekend", "ownerTeam": {"name": "Payments Team"}},
+				{"name": "Orders Primary", "ownerTeam": {"name": "Orders Team"}},
+				{"name": "Payments Primary", "ownerTeam": {"name": "Payments Team"}}
+			]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	directory := &Directory{client: server.Client()}
+	req := domain.LookupOnCallRequest{
+		Provider: domain.OnCallProviderOpsgenie,
+		BaseURL:  server.URL + "/",
+		Token:    "secret",
+		ID:       "Payments Team",
+	}
+
+	onCall, err := directory.Lookup(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, domain.OnCall{
+		Provider:         domain.OnCallProviderOpsgenie,
+		EscalationPolicy: "Payments Primary, Payments Weekend",
+		URL:              "https://acme.app.opsgenie.com/teams/dashboard/T1/main",
+	}, onCall)
+
+	req.ID = "Search Team"
+	_, err = directory.Lookup(context.Background(), req)
+	require.ErrorContains(t, err, "unexpected status 404 Not Found")
+}
+
+func TestDirectory_LookupUnsupportedProvider(t *testing.T) {
+	t.Parallel()
+
+	_, err := (&Directory{client: http.DefaultClient}).Lookup(context.Background(),
+		domain.LookupOnCallRequest{Provider: "victorops"})
+	require.ErrorIs(t, err, domain.ErrUnsupportedValue)
+}
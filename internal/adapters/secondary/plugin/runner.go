@@ -0,0 +1,135 @@
+// Package plugin runs plugins, external programs extending holydocs with sources, targets and publishers.
+//
+// A plugin is started for every request. It reads a single JSON request from its standard input and
+// writes a single JSON response to its standard output, messages for humans go to its standard error.
+// A plugin exiting with a non-zero status or answering with an error fails the run.
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	do "github.com/samber/do/v2"
+)
+
+// ProtocolVersion is the version of the plugin protocol sent with every request. Plugins answering with
+// another version are rejected.
+const ProtocolVersion = 1
+
+// request is the JSON document written to the standard input of a plugin.
+type request struct {
+	ProtocolVersion int               `json:"protocol_version"`
+	Kind            domain.PluginKind `json:"kind"`
+	Name            string            `json:"name"`
+	Config          map[string]string `json:"config,omitempty"`
+	// Schema is the merged schema, sent to targets.
+	Schema *domain.Schema `json:"schema,omitempty"`
+	// OutputDir is the directory of the generated documentation, sent to targets and publishers.
+	OutputDir string `json:"output_dir,omitempty"`
+}
+
+// response is the JSON document a plugin writes to its standard output.
+type response struct {
+	ProtocolVersion int      `json:"protocol_version"`
+	Error           string   `json:"error"`
+	Warnings        []string `json:"warnings"`
+	// Sources are the ServiceFile and AsyncAPI specifications of sources.
+	Sources []source `json:"sources"`
+	// Files are the files targets wrote, relative to the output directory.
+	Files []string `json:"files"`
+	// Changes and URL tell what publishers published where.
+	Changes []string `json:"changes"`
+	URL     string   `json:"url"`
+}
+
+type source struct {
+	Name    string `json:"name"`
+	Content string `json:"content"`
+}
+
+// Runner runs plugins as child processes.
+type Runner struct{}
+
+func NewRunner(_ do.Injector) (*Runner, error) {
+	return &Runner{}, nil
+}
+
+// Run starts the plugin, sends it the request and returns its response.
+func (r *Runner) Run(ctx context.Context, req domain.RunPluginRequest) (domain.RunPluginReply, error) {
+	args := strings.Fields(req.Plugin.Command)
+	if len(args) == 0 {
+		return domain.RunPluginReply{}, fmt.Errorf("%w: command is empty", domain.ErrPluginFailed)
+	}
+
+	input, err := json.Marshal(request{
+		ProtocolVersion: ProtocolVersion,
+		Kind:            req.Plugin.Kind,
+		Name:            req.Plugin.Name,
+		Config:          req.Plugin.Config,
+		Schema:          req.Schema,
+		OutputDir:       req.OutputDir,
+	})
+	if err != nil {
+		return domain.RunPluginReply{}, fmt.Errorf("marshaling plugin request: %w", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return domain.RunPluginReply{}, fmt.Errorf("%w: %s: %w: %s", domain.ErrPluginFailed, args[0], err,
+			strings.TrimSpace(stderr.String()))
+	}
+
+	var resp response
+	if err := json.Unmarshal(stdout.Bytes(), &resp); err != nil {
+		return domain.RunPluginReply{}, fmt.Errorf("%w: invalid response: %w", domain.ErrPluginFailed, err)
+	}
+
+	if resp.ProtocolVersion != 0 && resp.ProtocolVersion != ProtocolVersion {
+		return domain.RunPluginReply{}, fmt.Errorf("%w: protocol version %d, expected %d", domain.ErrPluginFailed,
+			resp.ProtocolVersion, ProtocolVersion)
+	}
+
+	if resp.Error != "" {
+		return domain.RunPluginReply{}, fmt.Errorf("%w: %s", domain.ErrPluginFailed, resp.Error)
+	}
+
+	return reply(resp)
+}
+
+func reply(resp response) (domain.RunPluginReply, error) {
+	result := domain.RunPluginReply{
+		Files:    resp.Files,
+		Changes:  resp.Changes,
+		URL:      resp.URL,
+		Warnings: resp.Warnings,
+	}
+
+	for _, file := range resp.Files {
+		if !filepath.IsLocal(file) {
+			return domain.RunPluginReply{}, fmt.Errorf("%w: file %s is outside of the output directory",
+				domain.ErrPluginFailed, file)
+		}
+	}
+
+	for _, s := range resp.Sources {
+		if s.Name == "" {
+			return domain.RunPluginReply{}, fmt.Errorf("%w: source without name", domain.ErrPluginFailed)
+		}
+
+		result.Sources = append(result.Sources, domain.PluginSource{Name: s.Name, Content: []byte(s.Content)})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,110 @@
+package plugin
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writePlugin writes a shell script plugin and returns the command starting it.
+func writePlugin(t *testing.T, script string) string {
+	t.Helper()
+
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh is not installed")
+	}
+
+	file := filepath.Join(t.TempDir(), "plugin.sh")
+	require.NoError(t, os.WriteFile(file, []byte(script), 0o600))
+
+	return "sh " + file
+}
+
+func TestRunner_Run(t *testing.T) {
+	t.Parallel()
+
+	requestFile := filepath.Join(t.TempDir(), "request.json")
+	command := writePlugin(t, `cat > `+requestFile+`
+echo "reading catalog" >&2
+cat <<'EOF'
+{
+  "protocol_version": 1,
+  "warnings": ["catalog entry without owner"],
+  "sources": [{"name": "billing", "content": "servicefile: \"0.1.0\"\n"}]
+}
+EOF
+`)
+
+	reply, err := (&Runner{}).Run(context.Background(), domain.RunPluginRequest{
+		Plugin: domain.Plugin{
+			Name:    "catalog",
+			Kind:    domain.PluginKindSource,
+			Command: command,
+			Config:  map[string]string{"url": "https://catalog.example.com"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.PluginSource{{Name: "billing", Content: []byte("servicefile: \"0.1.0\"\n")}},
+		reply.Sources)
+	assert.Equal(t, []string{"catalog entry without owner"}, reply.Warnings)
+
+	request, err := os.ReadFile(requestFile)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"protocol_version": 1,
+		"kind": "source",
+		"name": "catalog",
+		"config": {"url": "https://catalog.example.com"}
+	}`, string(request))
+}
+
+func TestRunner_Run_Target(t *testing.T) {
+	t.Parallel()
+
+	command := writePlugin(t, `cat > /dev/null
+echo '{"files": ["structurizr/workspace.dsl"]}'
+`)
+
+	reply, err := (&Runner{}).Run(context.Background(), domain.RunPluginRequest{
+		Plugin:    domain.Plugin{Name: "structurizr", Kind: domain.PluginKindTarget, Command: command},
+		Schema:    &domain.Schema{Services: []domain.Service{{Info: domain.ServiceInfo{Name: "Billing"}}}},
+		OutputDir: "docs",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"structurizr/workspace.dsl"}, reply.Files)
+}
+
+func TestRunner_Run_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		script string
+		errMsg string
+	}{
+		{name: "error response", script: `echo '{"error": "catalog unreachable"}'`, errMsg: "catalog unreachable"},
+		{name: "exit status", script: `echo "no credentials" >&2; exit 3`, errMsg: "no credentials"},
+		{name: "invalid response", script: `echo 'done'`, errMsg: "invalid response"},
+		{name: "protocol version", script: `echo '{"protocol_version": 2}'`, errMsg: "protocol version 2"},
+		{name: "file outside", script: `echo '{"files": ["../outside.md"]}'`, errMsg: "outside of the output"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := (&Runner{}).Run(context.Background(), domain.RunPluginRequest{
+				Plugin: domain.Plugin{Name: "broken", Kind: domain.PluginKindTarget,
+					Command: writePlugin(t, "cat > /dev/null\n"+tt.script+"\n")},
+			})
+			require.ErrorIs(t, err, domain.ErrPluginFailed)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
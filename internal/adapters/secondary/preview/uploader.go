@@ -0,0 +1,113 @@
+// Package preview uploads documentation previews of pull requests to static hosting.
+package preview
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	do "github.com/samber/do/v2"
+)
+
+// uploader uploads a generated preview and returns the URL it is served from, empty when it isn't known.
+type uploader interface {
+	upload(ctx context.Context, req domain.UploadPreviewRequest) (string, error)
+}
+
+//nolint:gochecknoglobals // Implementations of the supported preview uploaders.
+var uploaders = map[domain.PreviewUploader]uploader{
+	domain.PreviewUploaderCommand: commandUploader{},
+	domain.PreviewUploaderDir:     dirUploader{},
+}
+
+// Uploader uploads previews with the uploader selected by the request.
+type Uploader struct{}
+
+func NewUploader(_ do.Injector) (*Uploader, error) {
+	return &Uploader{}, nil
+}
+
+// Upload uploads the preview and returns its URL. With a base URL the preview is served below its slug,
+// otherwise the URL reported by the uploader is returned.
+func (u *Uploader) Upload(ctx context.Context, req domain.UploadPreviewRequest) (domain.UploadPreviewReply, error) {
+	up, ok := uploaders[req.Uploader]
+	if !ok {
+		return domain.UploadPreviewReply{}, fmt.Errorf("%w: preview uploader %q, expected one of %v",
+			domain.ErrUnsupportedValue, req.Uploader, domain.PreviewUploaders())
+	}
+
+	reported, err := up.upload(ctx, req)
+	if err != nil {
+		return domain.UploadPreviewReply{}, err
+	}
+
+	if req.BaseURL == "" {
+		return domain.UploadPreviewReply{URL: reported}, nil
+	}
+
+	return domain.UploadPreviewReply{
+		URL: strings.TrimSuffix(req.BaseURL, "/") + "/" + url.PathEscape(req.Slug) + "/",
+	}, nil
+}
+
+// commandUploader runs the configured command. The command is split into arguments at spaces and not run
+// by a shell. The last line it prints is reported as the URL when it is one, as hosting CLIs deploying to
+// generated URLs do.
+type commandUploader struct{}
+
+func (commandUploader) upload(ctx context.Context, req domain.UploadPreviewRequest) (string, error) {
+	args := strings.Fields(req.Command)
+	if len(args) == 0 {
+		return "", errors.New("upload command is empty")
+	}
+
+	for i, arg := range args {
+		args[i] = strings.NewReplacer("{dir}", req.Dir, "{slug}", req.Slug).Replace(arg)
+	}
+
+	output, err := exec.CommandContext(ctx, args[0], args[1:]...).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("%s: %w: %s", args[0], err, strings.TrimSpace(string(output)))
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	last := strings.TrimSpace(lines[len(lines)-1])
+
+	if strings.HasPrefix(last, "http://") || strings.HasPrefix(last, "https://") {
+		return last, nil
+	}
+
+	return "", nil
+}
+
+// dirUploader copies the preview below its slug into the target directory, replacing an earlier upload of
+// the same preview.
+type dirUploader struct{}
+
+func (dirUploader) upload(_ context.Context, req domain.UploadPreviewRequest) (string, error) {
+	if req.TargetDir == "" {
+		return "", errors.New("target directory is empty")
+	}
+
+	if !filepath.IsLocal(req.Slug) {
+		return "", fmt.Errorf("%w: preview slug %q", domain.ErrUnsupportedValue, req.Slug)
+	}
+
+	target := filepath.Join(req.TargetDir, req.Slug)
+
+	if err := os.RemoveAll(target); err != nil {
+		return "", fmt.Errorf("removing previous preview %s: %w", target, err)
+	}
+
+	if err := os.CopyFS(target, os.DirFS(req.Dir)); err != nil {
+		return "", fmt.Errorf("copying preview to %s: %w", target, err)
+	}
+
+	return "", nil
+}
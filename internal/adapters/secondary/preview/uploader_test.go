@@ -0,0 +1,85 @@
+package preview
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writePreview(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "diagrams"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("# Architecture\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "diagrams", "overview.svg"), []byte("<svg/>"), 0o644))
+
+	return dir
+}
+
+func TestUploader_Upload_Dir(t *testing.T) {
+	t.Parallel()
+
+	target := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(target, "pr-42"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(target, "pr-42", "stale.md"), []byte("# Stale\n"), 0o644))
+
+	reply, err := (&Uploader{}).Upload(context.Background(), domain.UploadPreviewRequest{
+		Uploader:  domain.PreviewUploaderDir,
+		Dir:       writePreview(t),
+		Slug:      "pr-42",
+		TargetDir: target,
+		BaseURL:   "https://previews.example.com/docs/",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "https://previews.example.com/docs/pr-42/", reply.URL)
+
+	assert.FileExists(t, filepath.Join(target, "pr-42", "README.md"))
+	assert.FileExists(t, filepath.Join(target, "pr-42", "diagrams", "overview.svg"))
+	assert.NoFileExists(t, filepath.Join(target, "pr-42", "stale.md"))
+
+	_, err = (&Uploader{}).Upload(context.Background(), domain.UploadPreviewRequest{
+		Uploader:  domain.PreviewUploaderDir,
+		Dir:       writePreview(t),
+		Slug:      "../outside",
+		TargetDir: target,
+	})
+	require.ErrorIs(t, err, domain.ErrUnsupportedValue)
+}
+
+func TestUploader_Upload_Command(t *testing.T) {
+	t.Parallel()
+
+	if _, err := exec.LookPath("echo"); err != nil {
+		t.Skip("echo is not installed")
+	}
+
+	uploader := &Uploader{}
+
+	reply, err := uploader.Upload(context.Background(), domain.UploadPreviewRequest{
+		Uploader: domain.PreviewUploaderCommand,
+		Dir:      writePreview(t),
+		Slug:     "feature-login",
+		Command:  "echo https://{slug}--docs.example.app",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "https://feature-login--docs.example.app", reply.URL)
+
+	reply, err = uploader.Upload(context.Background(), domain.UploadPreviewRequest{
+		Uploader: domain.PreviewUploaderCommand,
+		Dir:      writePreview(t),
+		Slug:     "feature-login",
+		Command:  "echo uploaded {dir}",
+	})
+	require.NoError(t, err)
+	assert.Empty(t, reply.URL)
+
+	_, err = uploader.Upload(context.Background(), domain.UploadPreviewRequest{Uploader: "ftp"})
+	require.ErrorIs(t, err, domain.ErrUnsupportedValue)
+}
@@ -0,0 +1,67 @@
+// Package probe checks the reachability of the health endpoints of deployments.
+package probe
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	do "github.com/samber/do/v2"
+)
+
+// maxDrainSize limits how much of a response body is read before the connection is closed.
+const maxDrainSize = 64 << 10
+
+// Prober requests health endpoints without following redirects, a redirect to a login page is not a healthy
+// deployment.
+type Prober struct {
+	client *http.Client
+}
+
+func NewProber(_ do.Injector) (*Prober, error) {
+	return &Prober{client: &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}}, nil
+}
+
+// Probe requests the endpoint once and reports whether and how it answered. Failures are reported in the
+// result, not as errors, probing continues with the next endpoint.
+func (p *Prober) Probe(ctx context.Context, req domain.ProbeRequest) domain.ProbeResult {
+	result := domain.ProbeResult{URL: req.URL}
+
+	if req.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, req.Timeout)
+		defer cancel()
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, req.URL, nil)
+	if err != nil {
+		result.Error = err.Error()
+
+		return result
+	}
+
+	start := time.Now()
+
+	resp, err := p.client.Do(httpReq)
+	result.LatencyMS = time.Since(start).Milliseconds()
+
+	if err != nil {
+		result.Error = err.Error()
+
+		return result
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainSize))
+
+	result.Reachable = true
+	result.StatusCode = resp.StatusCode
+
+	return result
+}
@@ -0,0 +1,54 @@
+package probe
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	do "github.com/samber/do/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProber_Probe(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/healthz":
+			_, _ = w.Write([]byte("ok"))
+		case "/readyz":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case "/slow":
+			time.Sleep(200 * time.Millisecond)
+		default:
+			http.Redirect(w, r, "/login", http.StatusFound)
+		}
+	}))
+	defer server.Close()
+
+	prober, err := NewProber(do.New())
+	require.NoError(t, err)
+
+	result := prober.Probe(context.Background(), domain.ProbeRequest{URL: server.URL + "/healthz", Timeout: time.Second})
+	assert.True(t, result.OK())
+	assert.Equal(t, http.StatusOK, result.StatusCode)
+	assert.Empty(t, result.Error)
+
+	result = prober.Probe(context.Background(), domain.ProbeRequest{URL: server.URL + "/readyz", Timeout: time.Second})
+	assert.True(t, result.Reachable)
+	assert.False(t, result.OK())
+	assert.Equal(t, http.StatusServiceUnavailable, result.StatusCode)
+
+	result = prober.Probe(context.Background(), domain.ProbeRequest{URL: server.URL + "/status", Timeout: time.Second})
+	assert.Equal(t, http.StatusFound, result.StatusCode, "redirects aren't followed")
+	assert.False(t, result.OK())
+
+	result = prober.Probe(context.Background(),
+		domain.ProbeRequest{URL: server.URL + "/slow", Timeout: 20 * time.Millisecond})
+	assert.False(t, result.Reachable)
+	assert.Contains(t, result.Error, "deadline exceeded")
+}
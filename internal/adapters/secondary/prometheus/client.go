@@ -0,0 +1,142 @@
+// Package prometheus queries metrics of relationships from the Prometheus HTTP API.
+package prometheus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	do "github.com/samber/do/v2"
+)
+
+// maxContentSize limits the size of a query result.
+const maxContentSize = 1 << 20
+
+// queryPath is the instant query endpoint of the Prometheus HTTP API.
+const queryPath = "/api/v1/query"
+
+// Client evaluates instant queries with the Prometheus HTTP API.
+type Client struct {
+	client *http.Client
+}
+
+func NewClient(_ do.Injector) (*Client, error) {
+	return &Client{client: &http.Client{}}, nil
+}
+
+type queryResponse struct {
+	Status    string `json:"status"`
+	ErrorType string `json:"errorType"`
+	Error     string `json:"error"`
+	Data      struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+type vectorSample struct {
+	Value [2]any `json:"value"`
+}
+
+// Query evaluates the query and returns its single value. Queries without data, or resulting in NaN as
+// quantiles of relationships without traffic do, fail with domain.ErrNoMetricData.
+func (c *Client) Query(ctx context.Context, req domain.QueryMetricRequest) (float64, error) {
+	if req.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, req.Timeout)
+		defer cancel()
+	}
+
+	endpoint := strings.TrimSuffix(req.URL, "/") + queryPath + "?" + url.Values{"query": {req.Query}}.Encode()
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return 0, fmt.Errorf("creating request: %w", err)
+	}
+
+	if req.Token != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+req.Token)
+	}
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return 0, fmt.Errorf("querying prometheus: %w", err)
+	}
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, maxContentSize+1))
+	if err != nil {
+		return 0, fmt.Errorf("reading query result: %w", err)
+	}
+
+	if len(content) > maxContentSize {
+		return 0, fmt.Errorf("query result is larger than %d bytes", maxContentSize)
+	}
+
+	var result queryResponse
+	if err := json.Unmarshal(content, &result); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return 0, fmt.Errorf("querying prometheus: unexpected status %s", resp.Status)
+		}
+
+		return 0, fmt.Errorf("parsing query result: %w", err)
+	}
+
+	if result.Status != "success" {
+		return 0, fmt.Errorf("query failed: %s: %s", result.ErrorType, result.Error)
+	}
+
+	return resultValue(result.Data.ResultType, result.Data.Result)
+}
+
+func resultValue(resultType string, result json.RawMessage) (float64, error) {
+	var sample [2]any
+
+	switch resultType {
+	case "scalar":
+		if err := json.Unmarshal(result, &sample); err != nil {
+			return 0, fmt.Errorf("parsing scalar result: %w", err)
+		}
+	case "vector":
+		var samples []vectorSample
+		if err := json.Unmarshal(result, &samples); err != nil {
+			return 0, fmt.Errorf("parsing vector result: %w", err)
+		}
+
+		switch len(samples) {
+		case 0:
+			return 0, domain.ErrNoMetricData
+		case 1:
+			sample = samples[0].Value
+		default:
+			return 0, fmt.Errorf("query results in %d series, expected one, aggregate them e.g. with sum", len(samples))
+		}
+	default:
+		return 0, fmt.Errorf("%w: result type %q, expected a scalar or an instant vector",
+			domain.ErrUnsupportedValue, resultType)
+	}
+
+	text, ok := sample[1].(string)
+	if !ok {
+		return 0, errors.New("sample without value")
+	}
+
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parsing sample value %q: %w", text, err)
+	}
+
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, domain.ErrNoMetricData
+	}
+
+	return value, nil
+}
@@ -0,0 +1,72 @@
+package prometheus
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Query(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/query" || r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		switch r.URL.Query().Get("query") {
+		case "latency":
+			_, _ = w.Write([]byte(`{"status": "success", "data": {"resultType": "vector",
+				"result": [{"metric": {}, "value": [1714638600.123, "0.125"]}]}}`))
+		case "scalar(errors)":
+			_, _ = w.Write([]byte(`{"status": "success", "data": {"resultType": "scalar",
+				"result": [1714638600.123, "0.004"]}}`))
+		case "idle":
+			_, _ = w.Write([]byte(`{"status": "success", "data": {"resultType": "vector",
+				"result": [{"metric": {}, "value": [1714638600.123, "NaN"]}]}}`))
+		case "missing":
+			_, _ = w.Write([]byte(`{"status": "success", "data": {"resultType": "vector", "result": []}}`))
+		case "by_route":
+			_, _ = w.Write([]byte(`{"status": "success", "data": {"resultType": "vector", "result": [
+				{"metric": {"route": "/a"}, "value": [1714638600.123, "1"]},
+				{"metric": {"route": "/b"}, "value": [1714638600.123, "2"]}]}}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"status": "error", "errorType": "bad_data", "error": "parse error"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{client: server.Client()}
+	query := func(q string) (float64, error) {
+		return client.Query(context.Background(), domain.QueryMetricRequest{URL: server.URL + "/", Token: "secret",
+			Query: q})
+	}
+
+	value, err := query("latency")
+	require.NoError(t, err)
+	assert.InDelta(t, 0.125, value, 1e-9)
+
+	value, err = query("scalar(errors)")
+	require.NoError(t, err)
+	assert.InDelta(t, 0.004, value, 1e-9)
+
+	_, err = query("idle")
+	require.ErrorIs(t, err, domain.ErrNoMetricData)
+
+	_, err = query("missing")
+	require.ErrorIs(t, err, domain.ErrNoMetricData)
+
+	_, err = query("by_route")
+	require.ErrorContains(t, err, "results in 2 series")
+
+	_, err = query("rate(")
+	require.ErrorContains(t, err, "query failed: bad_data: parse error")
+}
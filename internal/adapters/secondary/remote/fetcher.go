@@ -0,0 +1,100 @@
+// Package remote fetches specifications of remote sources over HTTP.
+package remote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	do "github.com/samber/do/v2"
+)
+
+// maxContentSize limits the size of a fetched specification.
+const maxContentSize = 32 << 20
+
+// errPermanent marks failures that are not retried, e.g. a missing document.
+var errPermanent = errors.New("permanent failure")
+
+// Fetcher downloads specifications, retrying failed attempts with an exponential backoff.
+type Fetcher struct {
+	client *http.Client
+}
+
+func NewFetcher(_ do.Injector) (*Fetcher, error) {
+	return &Fetcher{client: &http.Client{}}, nil
+}
+
+// Fetch returns the content served at the source URL. Every attempt is limited by the source timeout,
+// failed attempts are retried unless the server rejected the request, e.g. with 404 Not Found.
+func (f *Fetcher) Fetch(ctx context.Context, source domain.RemoteSource) ([]byte, error) {
+	backoff := source.Backoff
+
+	for attempt := 0; ; attempt++ {
+		content, err := f.fetch(ctx, source)
+		if err == nil {
+			return content, nil
+		}
+
+		if errors.Is(err, errPermanent) || attempt >= source.Retries {
+			return nil, fmt.Errorf("%w after %d attempts: %w", domain.ErrFetchFailed, attempt+1, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w: %w", domain.ErrFetchFailed, ctx.Err())
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+	}
+}
+
+func (f *Fetcher) fetch(ctx context.Context, source domain.RemoteSource) ([]byte, error) {
+	if source.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, source.Timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: creating request: %w", errPermanent, err)
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("requesting %s: %w", source.URL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		err := fmt.Errorf("requesting %s: unexpected status %s", source.URL, resp.Status)
+		if retryable(resp.StatusCode) {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("%w: %w", errPermanent, err)
+	}
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, maxContentSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", source.URL, err)
+	}
+
+	if len(content) > maxContentSize {
+		return nil, fmt.Errorf("%w: %s is larger than %d bytes", errPermanent, source.URL, maxContentSize)
+	}
+
+	return content, nil
+}
+
+// retryable reports whether a request answered with the status may succeed when repeated.
+func retryable(status int) bool {
+	return status >= http.StatusInternalServerError ||
+		status == http.StatusRequestTimeout ||
+		status == http.StatusTooManyRequests
+}
@@ -0,0 +1,105 @@
+package remote
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const specification = "asyncapi: 3.0.0\ninfo:\n  title: Billing\n"
+
+func newSource(url string, retries int) domain.RemoteSource {
+	return domain.RemoteSource{
+		Name:    "billing",
+		URL:     url,
+		Timeout: time.Second,
+		Retries: retries,
+		Backoff: time.Millisecond,
+	}
+}
+
+func TestFetcher_RetriesUntilSuccess(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if requests.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+		_, _ = w.Write([]byte(specification))
+	}))
+	defer server.Close()
+
+	fetcher := &Fetcher{client: server.Client()}
+
+	content, err := fetcher.Fetch(context.Background(), newSource(server.URL, 2))
+	require.NoError(t, err)
+	assert.Equal(t, specification, string(content))
+	assert.Equal(t, int32(3), requests.Load())
+}
+
+func TestFetcher_GivesUpAfterRetries(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	fetcher := &Fetcher{client: server.Client()}
+
+	_, err := fetcher.Fetch(context.Background(), newSource(server.URL, 1))
+	require.ErrorIs(t, err, domain.ErrFetchFailed)
+	assert.Contains(t, err.Error(), "after 2 attempts")
+	assert.Equal(t, int32(2), requests.Load())
+}
+
+func TestFetcher_DoesNotRetryRejectedRequests(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	fetcher := &Fetcher{client: server.Client()}
+
+	_, err := fetcher.Fetch(context.Background(), newSource(server.URL, 3))
+	require.ErrorIs(t, err, domain.ErrFetchFailed)
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+func TestFetcher_Timeout(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	fetcher := &Fetcher{client: server.Client()}
+	source := newSource(server.URL, 0)
+	source.Timeout = 50 * time.Millisecond
+
+	_, err := fetcher.Fetch(context.Background(), source)
+	require.ErrorIs(t, err, domain.ErrFetchFailed)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,107 @@
+package schema
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"regexp"
+	"runtime/debug"
+	"strings"
+
+	"github.com/holydocs/messageflow/pkg/messageflow"
+	mfschema "github.com/holydocs/messageflow/pkg/schema"
+)
+
+// cacheFormatVersion changes whenever cached schemas become incompatible with earlier ones.
+const cacheFormatVersion = "1"
+
+// messageflowModule is the module parsing AsyncAPI files, its version is part of cache keys.
+const messageflowModule = "github.com/holydocs/messageflow"
+
+//nolint:gochecknoglobals // Compiled once, used for finding references of AsyncAPI files.
+var refRe = regexp.MustCompile(`\$ref["']?\s*:\s*["']?([^"'\s,}]+)`)
+
+// LoadMessageFlow parses AsyncAPI files into a merged message flow schema. Schemas of unchanged files are
+// taken from the cache, files referencing other documents are always parsed as the cache key only covers
+// the content of the file itself.
+func (l *Loader) LoadMessageFlow(ctx context.Context, asyncapiFilesPaths []string) (messageflow.Schema, error) {
+	schemas := make([]messageflow.Schema, 0, len(asyncapiFilesPaths))
+
+	for _, path := range asyncapiFilesPaths {
+		schema, err := l.loadMessageFlowFile(ctx, strings.TrimSpace(path))
+		if err != nil {
+			return messageflow.Schema{}, err
+		}
+		schemas = append(schemas, schema)
+	}
+
+	merged := messageflow.MergeSchemas(schemas...)
+	merged.Sort()
+
+	return merged, nil
+}
+
+func (l *Loader) loadMessageFlowFile(ctx context.Context, path string) (messageflow.Schema, error) {
+	var key string
+
+	if l.cache != nil {
+		if content, err := os.ReadFile(path); err == nil && !hasExternalRefs(content) {
+			key = cacheKey(content)
+		}
+	}
+
+	var schema messageflow.Schema
+	if key != "" && l.cache.Get(key, &schema) {
+		return schema, nil
+	}
+
+	schema, err := mfschema.Load(ctx, []string{path})
+	if err != nil {
+		return messageflow.Schema{}, fmt.Errorf("parsing AsyncAPI file: %w", err)
+	}
+
+	if key != "" {
+		// The cache only saves time, failing to store an entry doesn't fail loading.
+		_ = l.cache.Put(key, schema)
+	}
+
+	return schema, nil
+}
+
+// cacheKey identifies the schema parsed from content by the current parser.
+func cacheKey(content []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(cacheFormatVersion + "\x00" + parserVersion() + "\x00"))
+	hash.Write(content)
+
+	return "asyncapi-" + hex.EncodeToString(hash.Sum(nil))
+}
+
+// parserVersion returns the version of the module parsing AsyncAPI files, so upgrading it invalidates the cache.
+func parserVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+
+	for _, dep := range info.Deps {
+		if dep.Path == messageflowModule {
+			return dep.Version
+		}
+	}
+
+	return ""
+}
+
+// hasExternalRefs reports whether the document references other documents, local references start with "#".
+func hasExternalRefs(content []byte) bool {
+	for _, match := range refRe.FindAllSubmatch(content, -1) {
+		if !strings.HasPrefix(string(match[1]), "#") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,90 @@
+package schema
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/adapters/secondary/cache"
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/messageflow/pkg/messageflow"
+	mfschema "github.com/holydocs/messageflow/pkg/schema"
+	do "github.com/samber/do/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCachedLoader(t *testing.T, dir string) *Loader {
+	t.Helper()
+
+	injector := do.New()
+	do.ProvideValue(injector, &config.Config{Cache: config.Cache{Enabled: true, Dir: dir}})
+	do.Provide(injector, cache.NewStore)
+
+	loader, err := NewLoader(injector)
+	require.NoError(t, err)
+	require.NotNil(t, loader.cache)
+
+	return loader
+}
+
+func TestLoader_LoadMessageFlow_Cached(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	paths := []string{"testdata/user.asyncapi.yaml", "testdata/notification.asyncapi.yaml"}
+
+	expected, err := mfschema.Load(ctx, paths)
+	require.NoError(t, err)
+
+	cacheDir := t.TempDir()
+	loader := newCachedLoader(t, cacheDir)
+
+	parsed, err := loader.LoadMessageFlow(ctx, paths)
+	require.NoError(t, err)
+	assert.Equal(t, expected, parsed)
+
+	entries, err := os.ReadDir(cacheDir)
+	require.NoError(t, err)
+	assert.Len(t, entries, len(paths))
+
+	// Entries are found by content, so a cached schema is served instead of parsing the file again.
+	content, err := os.ReadFile(paths[0])
+	require.NoError(t, err)
+	marker := messageflow.Schema{Services: []messageflow.Service{{Name: "Cached Service"}}}
+	require.NoError(t, loader.cache.Put(cacheKey(content), marker))
+
+	cached, err := loader.LoadMessageFlow(ctx, paths[:1])
+	require.NoError(t, err)
+	assert.Equal(t, "Cached Service", cached.Services[0].Name)
+}
+
+func TestLoader_LoadMessageFlow_ExternalRefsNotCached(t *testing.T) {
+	t.Parallel()
+
+	content, err := os.ReadFile("testdata/user.asyncapi.yaml")
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "user.asyncapi.yaml")
+	require.NoError(t, os.WriteFile(path, append(content, []byte("\nx-common:\n  $ref: ./common.yaml\n")...), 0o644))
+
+	cacheDir := filepath.Join(dir, "cache")
+	loader := newCachedLoader(t, cacheDir)
+
+	_, err = loader.LoadMessageFlow(context.Background(), []string{path})
+	require.NoError(t, err)
+	assert.NoDirExists(t, cacheDir)
+}
+
+func TestHasExternalRefs(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, hasExternalRefs([]byte(`payload:
+  $ref: '#/components/schemas/User'`)))
+	assert.False(t, hasExternalRefs([]byte(`{"$ref": "#/components/messages/UserCreated"}`)))
+	assert.True(t, hasExternalRefs([]byte(`payload:
+  $ref: ./schemas/user.yaml#/User`)))
+	assert.True(t, hasExternalRefs([]byte(`{"$ref": "https://example.com/common.json"}`)))
+}
@@ -0,0 +1,40 @@
+package schema
+
+import (
+	"context"
+	"strings"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+)
+
+// LoadDeclarations loads the schema declared by every specification file on its own, in the order Load merges
+// them, so the merge can be explained.
+func (l *Loader) LoadDeclarations(ctx context.Context, serviceFilesPaths, asyncapiFilesPaths []string,
+) ([]domain.Declaration, error) {
+	servicefileSchemas, err := l.loadServiceFiles(ctx, serviceFilesPaths)
+	if err != nil {
+		return nil, err
+	}
+
+	declarations := make([]domain.Declaration, 0, len(serviceFilesPaths)+len(asyncapiFilesPaths))
+	for i, schema := range servicefileSchemas {
+		declarations = append(declarations, domain.Declaration{
+			Source: serviceFilesPaths[i],
+			Kind:   domain.SourceKindServiceFile,
+			Schema: schema,
+		})
+	}
+
+	for _, path := range asyncapiFilesPaths {
+		path = strings.TrimSpace(path)
+
+		schema, err := l.loadAsyncAPIFiles(ctx, []string{path})
+		if err != nil {
+			return nil, err
+		}
+
+		declarations = append(declarations, domain.Declaration{Source: path, Kind: domain.SourceKindAsyncAPI, Schema: schema})
+	}
+
+	return declarations, nil
+}
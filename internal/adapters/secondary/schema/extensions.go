@@ -0,0 +1,363 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"gopkg.in/yaml.v3"
+)
+
+// serviceFileExtensions holds holydocs-specific ServiceFile fields that are not (yet)
+// part of the upstream servicefile format. The file is decoded a second time into this
+// structure; relationships are matched to the upstream ones by their position.
+type serviceFileExtensions struct {
+	Info          infoExtensions           `yaml:"info"`
+	Relationships []relationshipExtensions `yaml:"relationships"`
+}
+
+type infoExtensions struct {
+	Planned            bool                     `yaml:"planned,omitempty"`
+	Deprecated         bool                     `yaml:"deprecated,omitempty"`
+	SunsetDate         string                   `yaml:"sunset_date,omitempty"`
+	BoundedContext     bool                     `yaml:"bounded_context,omitempty"`
+	BusinessCapability string                   `yaml:"business_capability,omitempty"`
+	Classification     string                   `yaml:"classification,omitempty"`
+	Namespace          string                   `yaml:"namespace,omitempty"`
+	Risks              []riskExtensions         `yaml:"risks,omitempty"`
+	Limits             *serviceLimitsExtensions `yaml:"limits,omitempty"`
+	Deployments        []deploymentExtensions   `yaml:"deployments,omitempty"`
+}
+
+// deploymentExtensions declares an environment the service is deployed to and its health endpoints there.
+type deploymentExtensions struct {
+	Environment string `yaml:"environment"`
+	Health      string `yaml:"health,omitempty"`
+	Readiness   string `yaml:"readiness,omitempty"`
+}
+
+type relationshipExtensions struct {
+	Notes       string                        `yaml:"notes,omitempty"`
+	Planned     bool                          `yaml:"planned,omitempty"`
+	Criticality domain.Criticality            `yaml:"criticality,omitempty"`
+	Access      domain.Access                 `yaml:"access,omitempty"`
+	DDDPatterns []domain.DDDPattern           `yaml:"ddd_patterns,omitempty"`
+	Approval    string                        `yaml:"approval,omitempty"`
+	Risks       []riskExtensions              `yaml:"risks,omitempty"`
+	Limits      *relationshipLimitsExtensions `yaml:"limits,omitempty"`
+}
+
+// serviceLimitsExtensions declares the capacity limits of a service.
+type serviceLimitsExtensions struct {
+	MaxRPS         int `yaml:"max_rps"`
+	MaxConnections int `yaml:"max_connections"`
+}
+
+// relationshipLimitsExtensions declares the limits of a relationship toward its participant.
+type relationshipLimitsExtensions struct {
+	RateLimit      int `yaml:"rate_limit"`
+	ConnectionPool int `yaml:"connection_pool"`
+}
+
+// riskExtensions annotates a service or relationship with a known risk.
+type riskExtensions struct {
+	Type        domain.RiskType    `yaml:"type"`
+	Severity    domain.Criticality `yaml:"severity"`
+	Description string             `yaml:"description,omitempty"`
+}
+
+func loadServiceFileExtensions(path string) (serviceFileExtensions, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return serviceFileExtensions{}, fmt.Errorf("reading file %s: %w", path, err)
+	}
+
+	var ext serviceFileExtensions
+	if err := yaml.Unmarshal(data, &ext); err != nil {
+		return serviceFileExtensions{}, fmt.Errorf("parsing file %s: %w", path, err)
+	}
+
+	if ext.Info.SunsetDate != "" {
+		if _, err := time.Parse(time.DateOnly, ext.Info.SunsetDate); err != nil {
+			return serviceFileExtensions{}, fmt.Errorf("invalid sunset_date %q in %s, expected YYYY-MM-DD: %w",
+				ext.Info.SunsetDate, path, err)
+		}
+	}
+
+	ext.Info.Namespace = strings.TrimSpace(ext.Info.Namespace)
+	if strings.Contains(ext.Info.Namespace, domain.NamespaceSeparator) {
+		return serviceFileExtensions{}, fmt.Errorf("%w: namespace %q in %s, must not contain %q",
+			domain.ErrUnsupportedValue, ext.Info.Namespace, path, domain.NamespaceSeparator)
+	}
+
+	if err := validateRisks(ext.Info.Risks, "service", path); err != nil {
+		return serviceFileExtensions{}, err
+	}
+
+	if limits := ext.Info.Limits; limits != nil && (limits.MaxRPS < 0 || limits.MaxConnections < 0) {
+		return serviceFileExtensions{}, fmt.Errorf("%w: negative limits of service in %s", domain.ErrUnsupportedValue,
+			path)
+	}
+
+	if err := validateDeployments(ext.Info.Deployments, path); err != nil {
+		return serviceFileExtensions{}, err
+	}
+
+	for i, rel := range ext.Relationships {
+		ext.Relationships[i].Approval = strings.TrimSpace(rel.Approval)
+
+		if err := validateRisks(rel.Risks, fmt.Sprintf("relationship %d", i), path); err != nil {
+			return serviceFileExtensions{}, err
+		}
+
+		if limits := rel.Limits; limits != nil && (limits.RateLimit < 0 || limits.ConnectionPool < 0) {
+			return serviceFileExtensions{}, fmt.Errorf("%w: negative limits of relationship %d in %s",
+				domain.ErrUnsupportedValue, i, path)
+		}
+
+		if rel.Criticality != "" && !rel.Criticality.Valid() {
+			return serviceFileExtensions{}, fmt.Errorf("%w: criticality %q of relationship %d in %s, expected one of %v",
+				domain.ErrUnsupportedValue, rel.Criticality, i, path, domain.Criticalities())
+		}
+
+		if rel.Access != "" && !rel.Access.Valid() {
+			return serviceFileExtensions{}, fmt.Errorf("%w: access %q of relationship %d in %s, expected one of %v",
+				domain.ErrUnsupportedValue, rel.Access, i, path, domain.AccessModes())
+		}
+
+		for _, pattern := range rel.DDDPatterns {
+			if !pattern.Valid() {
+				return serviceFileExtensions{}, fmt.Errorf("%w: ddd pattern %q of relationship %d in %s, expected one of %v",
+					domain.ErrUnsupportedValue, pattern, i, path, domain.DDDPatterns())
+			}
+		}
+	}
+
+	return ext, nil
+}
+
+// validateRisks checks that the risks of the subject, the service or a relationship, have a supported type
+// and severity.
+func validateRisks(risks []riskExtensions, subject, path string) error {
+	for i, risk := range risks {
+		if !risk.Type.Valid() {
+			return fmt.Errorf("%w: type %q of risk %d of %s in %s, expected one of %v",
+				domain.ErrUnsupportedValue, risk.Type, i, subject, path, domain.RiskTypes())
+		}
+
+		if !risk.Severity.Valid() {
+			return fmt.Errorf("%w: severity %q of risk %d of %s in %s, expected one of %v",
+				domain.ErrUnsupportedValue, risk.Severity, i, subject, path, domain.Criticalities())
+		}
+	}
+
+	return nil
+}
+
+// validateDeployments checks that deployments name distinct environments and that their health endpoints
+// are HTTP URLs.
+func validateDeployments(deployments []deploymentExtensions, path string) error {
+	environments := make(map[string]bool, len(deployments))
+
+	for i, deployment := range deployments {
+		environment := strings.TrimSpace(deployment.Environment)
+		if environment == "" {
+			return fmt.Errorf("%w: deployment %d in %s has no environment", domain.ErrUnsupportedValue, i, path)
+		}
+
+		if environments[environment] {
+			return fmt.Errorf("%w: environment %q of deployment %d in %s is declared twice",
+				domain.ErrUnsupportedValue, environment, i, path)
+		}
+		environments[environment] = true
+
+		for _, endpoint := range []string{deployment.Health, deployment.Readiness} {
+			if endpoint != "" && !strings.HasPrefix(endpoint, "http://") && !strings.HasPrefix(endpoint, "https://") {
+				return fmt.Errorf("%w: endpoint %q of deployment %s in %s, expected an http or https URL",
+					domain.ErrUnsupportedValue, endpoint, environment, path)
+			}
+		}
+	}
+
+	return nil
+}
+
+// domainDeployments converts the deployments declared in a ServiceFile.
+func domainDeployments(deployments []deploymentExtensions) []domain.Deployment {
+	if len(deployments) == 0 {
+		return nil
+	}
+
+	converted := make([]domain.Deployment, 0, len(deployments))
+	for _, deployment := range deployments {
+		converted = append(converted, domain.Deployment{
+			Environment: strings.TrimSpace(deployment.Environment),
+			Health:      deployment.Health,
+			Readiness:   deployment.Readiness,
+		})
+	}
+
+	return converted
+}
+
+// domainRisks converts the risks declared in a ServiceFile.
+func domainRisks(risks []riskExtensions) []domain.Risk {
+	if len(risks) == 0 {
+		return nil
+	}
+
+	converted := make([]domain.Risk, 0, len(risks))
+	for _, risk := range risks {
+		converted = append(converted, domain.Risk{
+			Type:        risk.Type,
+			Severity:    risk.Severity,
+			Description: strings.TrimSpace(risk.Description),
+		})
+	}
+
+	return converted
+}
+
+// domainLimits converts the limits declared for a service, none are declared when all are zero.
+func (l *serviceLimitsExtensions) domainLimits() *domain.ServiceLimits {
+	if l == nil || *l == (serviceLimitsExtensions{}) {
+		return nil
+	}
+
+	return &domain.ServiceLimits{MaxRPS: l.MaxRPS, MaxConnections: l.MaxConnections}
+}
+
+// domainLimits converts the limits declared for a relationship, none are declared when all are zero.
+func (l *relationshipLimitsExtensions) domainLimits() *domain.RelationshipLimits {
+	if l == nil || *l == (relationshipLimitsExtensions{}) {
+		return nil
+	}
+
+	return &domain.RelationshipLimits{RateLimit: l.RateLimit, ConnectionPool: l.ConnectionPool}
+}
+
+func (e serviceFileExtensions) relationship(i int) relationshipExtensions {
+	if i < 0 || i >= len(e.Relationships) {
+		return relationshipExtensions{}
+	}
+
+	return e.Relationships[i]
+}
+
+// asyncAPIMessageNameSuffix is appended to the component key by the AsyncAPI parser to name a message.
+const asyncAPIMessageNameSuffix = "Message"
+
+// asyncAPIExtensions holds the parts of an AsyncAPI specification messageflow does not carry over.
+// The file is decoded a second time into this structure; messages are matched by the name
+// the parser derives from their component key, channels by their address.
+type asyncAPIExtensions struct {
+	Channels   map[string]asyncAPIChannel `yaml:"channels"`
+	Components struct {
+		Messages map[string]asyncAPIMessage `yaml:"messages"`
+	} `yaml:"components"`
+}
+
+// asyncAPIChannel holds the service level annotations of a channel, declared as x- extensions.
+type asyncAPIChannel struct {
+	Address    string `yaml:"address"`
+	Throughput string `yaml:"x-throughput"`
+	MaxLatency string `yaml:"x-max-latency"`
+	// DLQ is the address of the dead letter queue or a reference to its channel, e.g. "#/channels/orders.dlq".
+	DLQ string `yaml:"x-dlq"`
+}
+
+// asyncAPIChannelRefPrefix starts references to channels of the same specification.
+const asyncAPIChannelRefPrefix = "#/channels/"
+
+type asyncAPIMessage struct {
+	Examples []struct {
+		Payload any `yaml:"payload"`
+	} `yaml:"examples"`
+}
+
+func loadAsyncAPIExtensions(path string) (asyncAPIExtensions, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return asyncAPIExtensions{}, fmt.Errorf("reading file %s: %w", path, err)
+	}
+
+	var ext asyncAPIExtensions
+	if err := yaml.Unmarshal(data, &ext); err != nil {
+		return asyncAPIExtensions{}, fmt.Errorf("parsing file %s: %w", path, err)
+	}
+
+	for key, channel := range ext.Channels {
+		if channel.MaxLatency == "" {
+			continue
+		}
+
+		if _, err := time.ParseDuration(channel.MaxLatency); err != nil {
+			return asyncAPIExtensions{}, fmt.Errorf("invalid x-max-latency %q of channel %s in %s, expected a duration "+
+				"such as 250ms: %w", channel.MaxLatency, key, path, err)
+		}
+	}
+
+	return ext, nil
+}
+
+// channelSLAs returns the service level annotations of the channels defined in the specification, keyed by
+// channel address. References of dead letter queues to channels are resolved to their addresses.
+func (e asyncAPIExtensions) channelSLAs() map[string]domain.ChannelSLA {
+	slas := make(map[string]domain.ChannelSLA)
+
+	for key, channel := range e.Channels {
+		sla := domain.ChannelSLA{Throughput: channel.Throughput, MaxLatency: channel.MaxLatency, DLQ: channel.DLQ}
+		if sla == (domain.ChannelSLA{}) {
+			continue
+		}
+
+		if ref, ok := strings.CutPrefix(sla.DLQ, asyncAPIChannelRefPrefix); ok {
+			if dlq, exists := e.Channels[ref]; exists {
+				sla.DLQ = e.channelAddress(ref, dlq)
+			}
+		}
+
+		slas[e.channelAddress(key, channel)] = sla
+	}
+
+	return slas
+}
+
+// channelAddress returns the address of a channel, channels without one are named after their key.
+func (e asyncAPIExtensions) channelAddress(key string, channel asyncAPIChannel) string {
+	if channel.Address != "" {
+		return channel.Address
+	}
+
+	return key
+}
+
+// examples returns the example payloads of the messages defined in the specification,
+// keyed by message name and formatted as indented JSON.
+func (e asyncAPIExtensions) examples(path string) (map[string][]string, error) {
+	examples := make(map[string][]string)
+
+	for _, key := range slices.Sorted(maps.Keys(e.Components.Messages)) {
+		name := key + asyncAPIMessageNameSuffix
+
+		for i, example := range e.Components.Messages[key].Examples {
+			if example.Payload == nil {
+				continue
+			}
+
+			payload, err := json.MarshalIndent(example.Payload, "", "  ")
+			if err != nil {
+				return nil, fmt.Errorf("encoding example %d of message %s in %s: %w", i, name, path, err)
+			}
+
+			examples[name] = append(examples[name], string(payload))
+		}
+	}
+
+	return examples, nil
+}
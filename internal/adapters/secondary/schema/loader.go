@@ -5,10 +5,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 
+	"github.com/holydocs/holydocs/internal/adapters/secondary/cache"
 	"github.com/holydocs/holydocs/internal/core/domain"
 	"github.com/holydocs/messageflow/pkg/messageflow"
-	mfschema "github.com/holydocs/messageflow/pkg/schema"
 	"github.com/holydocs/servicefile/pkg/servicefile"
 	do "github.com/samber/do/v2"
 )
@@ -19,17 +20,26 @@ var (
 	ErrAsyncAPILoadFailed    = errors.New("failed to load AsyncAPI files")
 )
 
-type Loader struct{}
+type Loader struct {
+	// cache keeps schemas parsed from AsyncAPI files, nil when parsed schemas aren't cached.
+	cache *cache.Store
+}
+
+func NewLoader(i do.Injector) (*Loader, error) {
+	loader := &Loader{}
+
+	if store, err := do.Invoke[*cache.Store](i); err == nil {
+		loader.cache = store
+	}
 
-func NewLoader(_ do.Injector) (*Loader, error) {
-	return &Loader{}, nil
+	return loader, nil
 }
 
 // Load loads schemas from ServiceFile and AsyncAPI files and merges them.
 func (l *Loader) Load(ctx context.Context, serviceFilesPaths, asyncapiFilesPaths []string) (domain.Schema, error) {
 	var schemas []domain.Schema
 
-	servicefileSchemas, err := l.loadServiceFiles(serviceFilesPaths)
+	servicefileSchemas, err := l.loadServiceFiles(ctx, serviceFilesPaths)
 	if err != nil {
 		return domain.Schema{}, fmt.Errorf("loading service files: %w", err)
 	}
@@ -47,32 +57,57 @@ func (l *Loader) Load(ctx context.Context, serviceFilesPaths, asyncapiFilesPaths
 		return domain.Schema{}, nil
 	}
 
-	return domain.MergeSchemas(schemas...), nil
+	if err := ctx.Err(); err != nil {
+		return domain.Schema{}, err
+	}
+
+	schema := domain.MergeSchemas(schemas...)
+	schema.ResolveNamespaces()
+
+	return schema, nil
 }
 
-func (l *Loader) loadServiceFiles(serviceFilesPaths []string) ([]domain.Schema, error) {
+func (l *Loader) loadServiceFiles(ctx context.Context, serviceFilesPaths []string) ([]domain.Schema, error) {
 	schemas := make([]domain.Schema, 0, len(serviceFilesPaths))
 
 	for _, path := range serviceFilesPaths {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		sf, err := servicefile.Load(path)
 		if err != nil {
 			return nil, fmt.Errorf("%w %s: %w", ErrServiceFileLoadFailed, path, err)
 		}
 
-		schemas = append(schemas, l.convertServiceFileToHolydocs(sf))
+		ext, err := loadServiceFileExtensions(path)
+		if err != nil {
+			return nil, fmt.Errorf("%w %s: %w", ErrServiceFileLoadFailed, path, err)
+		}
+
+		schemas = append(schemas, l.convertServiceFileToHolydocs(sf, ext))
 	}
 
 	return schemas, nil
 }
 
-func (l *Loader) convertServiceFileToHolydocs(sf *servicefile.ServiceFile) domain.Schema {
+func (l *Loader) convertServiceFileToHolydocs(sf *servicefile.ServiceFile, ext serviceFileExtensions) domain.Schema {
 	relationships := make([]domain.Relationship, 0, len(sf.Relationships))
 
-	for _, rel := range sf.Relationships {
+	for i, rel := range sf.Relationships {
+		relExt := ext.relationship(i)
 		relationships = append(relationships, domain.Relationship{
 			Action:      domain.RelationshipAction(rel.Action),
 			Participant: rel.Participant,
 			Description: rel.Description,
+			Notes:       relExt.Notes,
+			Planned:     relExt.Planned,
+			Criticality: relExt.Criticality,
+			Access:      relExt.Access,
+			DDDPatterns: append([]domain.DDDPattern(nil), relExt.DDDPatterns...),
+			Approval:    relExt.Approval,
+			Risks:       domainRisks(relExt.Risks),
+			Limits:      relExt.Limits.domainLimits(),
 			Technology:  rel.Technology,
 			Proto:       rel.Proto,
 			Tags:        append([]string(nil), rel.Tags...),
@@ -83,12 +118,22 @@ func (l *Loader) convertServiceFileToHolydocs(sf *servicefile.ServiceFile) domai
 
 	service := domain.Service{
 		Info: domain.ServiceInfo{
-			Name:        sf.Info.Name,
-			Description: sf.Info.Description,
-			System:      sf.Info.System,
-			Owner:       sf.Info.Owner,
-			Repository:  sf.Info.Repository,
-			Tags:        append([]string(nil), sf.Info.Tags...),
+			Name:               domain.QualifiedName(ext.Info.Namespace, sf.Info.Name),
+			Description:        sf.Info.Description,
+			Namespace:          ext.Info.Namespace,
+			System:             sf.Info.System,
+			BusinessCapability: ext.Info.BusinessCapability,
+			Owner:              sf.Info.Owner,
+			Repository:         sf.Info.Repository,
+			Tags:               append([]string(nil), sf.Info.Tags...),
+			Planned:            ext.Info.Planned,
+			Deprecated:         ext.Info.Deprecated,
+			SunsetDate:         ext.Info.SunsetDate,
+			BoundedContext:     ext.Info.BoundedContext,
+			Classification:     ext.Info.Classification,
+			Risks:              domainRisks(ext.Info.Risks),
+			Limits:             ext.Info.Limits.domainLimits(),
+			Deployments:        domainDeployments(ext.Info.Deployments),
 		},
 		Relationships: relationships,
 	}
@@ -99,19 +144,47 @@ func (l *Loader) convertServiceFileToHolydocs(sf *servicefile.ServiceFile) domai
 }
 
 func (l *Loader) loadAsyncAPIFiles(ctx context.Context, asyncapiFilesPaths []string) (domain.Schema, error) {
-	mfSchema, err := mfschema.Load(ctx, asyncapiFilesPaths)
+	mfSchema, err := l.LoadMessageFlow(ctx, asyncapiFilesPaths)
 	if err != nil {
 		return domain.Schema{}, fmt.Errorf("%w: %w", ErrAsyncAPILoadFailed, err)
 	}
 
-	return l.convertMessageFlowToHolydocs(mfSchema), nil
+	examples := make(map[string][]string)
+	slas := make(map[string]domain.ChannelSLA)
+
+	for _, path := range asyncapiFilesPaths {
+		ext, err := loadAsyncAPIExtensions(path)
+		if err != nil {
+			return domain.Schema{}, fmt.Errorf("%w: %w", ErrAsyncAPILoadFailed, err)
+		}
+
+		for name, sla := range ext.channelSLAs() {
+			slas[name] = slas[name].Merge(sla)
+		}
+
+		fileExamples, err := ext.examples(path)
+		if err != nil {
+			return domain.Schema{}, fmt.Errorf("%w: %w", ErrAsyncAPILoadFailed, err)
+		}
+
+		for name, payloads := range fileExamples {
+			for _, payload := range payloads {
+				if !slices.Contains(examples[name], payload) {
+					examples[name] = append(examples[name], payload)
+				}
+			}
+		}
+	}
+
+	return l.convertMessageFlowToHolydocs(mfSchema, examples, slas), nil
 }
 
-func (l *Loader) convertMessageFlowToHolydocs(mfSchema messageflow.Schema) domain.Schema {
+func (l *Loader) convertMessageFlowToHolydocs(mfSchema messageflow.Schema,
+	examples map[string][]string, slas map[string]domain.ChannelSLA) domain.Schema {
 	holydocsServices := make([]domain.Service, 0, len(mfSchema.Services))
 
 	for _, mfService := range mfSchema.Services {
-		operations := l.convertMessageFlowOperations(mfService.Operation)
+		operations := l.convertMessageFlowOperations(mfService.Operation, examples, slas)
 		service := domain.Service{
 			Info: domain.ServiceInfo{
 				Name:        mfService.Name,
@@ -127,7 +200,8 @@ func (l *Loader) convertMessageFlowToHolydocs(mfSchema messageflow.Schema) domai
 	}
 }
 
-func (l *Loader) convertMessageFlowOperations(mfOperations []messageflow.Operation) []domain.Operation {
+func (l *Loader) convertMessageFlowOperations(mfOperations []messageflow.Operation,
+	examples map[string][]string, slas map[string]domain.ChannelSLA) []domain.Operation {
 	operations := make([]domain.Operation, 0, len(mfOperations))
 	for _, op := range mfOperations {
 		operation := domain.Operation{
@@ -135,18 +209,22 @@ func (l *Loader) convertMessageFlowOperations(mfOperations []messageflow.Operati
 			Channel: domain.Channel{
 				Name: op.Channel.Name,
 				Message: domain.Message{
-					Name:    op.Channel.Message.Name,
-					Payload: op.Channel.Message.Payload,
+					Name:     op.Channel.Message.Name,
+					Payload:  op.Channel.Message.Payload,
+					Examples: examples[op.Channel.Message.Name],
 				},
+				SLA: channelSLA(slas, op.Channel.Name),
 			},
 		}
 		if op.Reply != nil {
 			operation.Reply = &domain.Channel{
 				Name: op.Reply.Name,
 				Message: domain.Message{
-					Name:    op.Reply.Message.Name,
-					Payload: op.Reply.Message.Payload,
+					Name:     op.Reply.Message.Name,
+					Payload:  op.Reply.Message.Payload,
+					Examples: examples[op.Reply.Message.Name],
 				},
+				SLA: channelSLA(slas, op.Reply.Name),
 			}
 		}
 		operations = append(operations, operation)
@@ -154,3 +232,12 @@ func (l *Loader) convertMessageFlowOperations(mfOperations []messageflow.Operati
 
 	return operations
 }
+
+func channelSLA(slas map[string]domain.ChannelSLA, channel string) *domain.ChannelSLA {
+	sla, ok := slas[channel]
+	if !ok {
+		return nil
+	}
+
+	return &sla
+}
@@ -2,6 +2,9 @@ package schema
 
 import (
 	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"testing"
 
 	"github.com/holydocs/holydocs/internal/core/domain"
@@ -26,6 +29,7 @@ type loadTestCase struct {
 	asyncapiFilesPaths  []string
 	expectedServices    int
 	expectedError       bool
+	expectedErrorIs     error
 	expectedErrorString string
 }
 
@@ -75,6 +79,96 @@ func getLoadTestCases() []loadTestCase {
 			expectedError:       true,
 			expectedErrorString: "loading AsyncAPI files",
 		},
+		{
+			name:                "unsupported criticality",
+			serviceFilesPaths:   []string{"testdata/invalid-criticality.servicefile.yaml"},
+			asyncapiFilesPaths:  []string{},
+			expectedError:       true,
+			expectedErrorIs:     domain.ErrUnsupportedValue,
+			expectedErrorString: "urgent",
+		},
+		{
+			name:                "unsupported access",
+			serviceFilesPaths:   []string{"testdata/invalid-access.servicefile.yaml"},
+			asyncapiFilesPaths:  []string{},
+			expectedError:       true,
+			expectedErrorIs:     domain.ErrUnsupportedValue,
+			expectedErrorString: "append",
+		},
+		{
+			name:                "invalid channel max latency",
+			serviceFilesPaths:   []string{},
+			asyncapiFilesPaths:  []string{"testdata/invalid-channel-sla.asyncapi.yaml"},
+			expectedError:       true,
+			expectedErrorString: "x-max-latency",
+		},
+		{
+			name:               "nested namespace",
+			serviceFilesPaths:  []string{"testdata/invalid-namespace.servicefile.yaml"},
+			asyncapiFilesPaths: []string{},
+			expectedError:      true,
+			expectedErrorIs:    domain.ErrUnsupportedValue,
+		},
+		{
+			name:                "unsupported risk type",
+			serviceFilesPaths:   []string{"testdata/invalid-risk-type.servicefile.yaml"},
+			asyncapiFilesPaths:  []string{},
+			expectedError:       true,
+			expectedErrorIs:     domain.ErrUnsupportedValue,
+			expectedErrorString: "data_loss",
+		},
+		{
+			name:                "risk without severity",
+			serviceFilesPaths:   []string{"testdata/invalid-risk-severity.servicefile.yaml"},
+			asyncapiFilesPaths:  []string{},
+			expectedError:       true,
+			expectedErrorIs:     domain.ErrUnsupportedValue,
+			expectedErrorString: `severity ""`,
+		},
+		{
+			name:               "negative limit",
+			serviceFilesPaths:  []string{"testdata/invalid-limits.servicefile.yaml"},
+			asyncapiFilesPaths: []string{},
+			expectedError:      true,
+			expectedErrorIs:    domain.ErrUnsupportedValue,
+		},
+		{
+			name:               "deployment without environment",
+			serviceFilesPaths:  []string{"testdata/invalid-deployment-environment.servicefile.yaml"},
+			asyncapiFilesPaths: []string{},
+			expectedError:      true,
+			expectedErrorIs:    domain.ErrUnsupportedValue,
+		},
+		{
+			name:               "duplicate deployment environment",
+			serviceFilesPaths:  []string{"testdata/duplicate-deployment-environment.servicefile.yaml"},
+			asyncapiFilesPaths: []string{},
+			expectedError:      true,
+			expectedErrorIs:    domain.ErrUnsupportedValue,
+		},
+		{
+			name:               "deployment endpoint not a URL",
+			serviceFilesPaths:  []string{"testdata/invalid-deployment-url.servicefile.yaml"},
+			asyncapiFilesPaths: []string{},
+			expectedError:      true,
+			expectedErrorIs:    domain.ErrUnsupportedValue,
+		},
+		{
+			name:                "invalid sunset date",
+			serviceFilesPaths:   []string{"testdata/invalid-sunset-date.servicefile.yaml"},
+			asyncapiFilesPaths:  []string{},
+			expectedError:       true,
+			expectedErrorIs:     ErrServiceFileLoadFailed,
+			expectedErrorString: "sunset_date",
+		},
+		{
+			name:                "unsupported DDD pattern",
+			serviceFilesPaths:   []string{"testdata/invalid-ddd-pattern.servicefile.yaml"},
+			asyncapiFilesPaths:  []string{},
+			expectedError:       true,
+			expectedErrorIs:     domain.ErrUnsupportedValue,
+			expectedErrorString: "big_ball_of_mud",
+		},
 	}
 }
 
@@ -87,6 +181,9 @@ func runLoadTestCase(t *testing.T, tt loadTestCase) {
 
 	if tt.expectedError {
 		require.Error(t, err)
+		if tt.expectedErrorIs != nil {
+			require.ErrorIs(t, err, tt.expectedErrorIs)
+		}
 		if tt.expectedErrorString != "" {
 			assert.Contains(t, err.Error(), tt.expectedErrorString)
 		}
@@ -156,6 +253,38 @@ func TestLoad_AsyncAPIContent(t *testing.T) {
 	assert.True(t, hasReceive, "Should have receive operations")
 }
 
+func TestLoad_AsyncAPIExamples(t *testing.T) {
+	ctx := context.Background()
+	loader, err := NewLoader(do.New())
+	require.NoError(t, err)
+
+	schema, err := loader.Load(ctx, []string{}, []string{"testdata/notification.asyncapi.yaml"})
+	require.NoError(t, err)
+	require.Len(t, schema.Services, 1)
+
+	examples := make(map[string][]string)
+	for _, op := range schema.Services[0].Operation {
+		examples[op.Channel.Message.Name] = op.Channel.Message.Examples
+	}
+
+	require.Len(t, examples["AnalyticsEventMessage"], 1)
+	assert.Contains(t, examples["AnalyticsEventMessage"][0], `"event_type": "notification_opened"`)
+	assert.Empty(t, examples["PushNotificationMessage"])
+}
+
+func TestLoad_ChannelSLA(t *testing.T) {
+	ctx := context.Background()
+	loader, err := NewLoader(do.New())
+	require.NoError(t, err)
+
+	schema, err := loader.Load(ctx, []string{}, []string{"testdata/channel-sla.asyncapi.yaml"})
+	require.NoError(t, err)
+	require.Len(t, schema.Services, 1)
+	require.Len(t, schema.Services[0].Operation, 1)
+	assert.Equal(t, &domain.ChannelSLA{Throughput: "500 msg/s", MaxLatency: "2s", DLQ: "orders.created.dlq"},
+		schema.Services[0].Operation[0].Channel.SLA)
+}
+
 func TestLoad_MultipleFiles(t *testing.T) {
 	ctx := context.Background()
 	loader, err := NewLoader(do.New())
@@ -208,3 +337,332 @@ func TestLoad_InvalidAsyncAPI(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "loading AsyncAPI files")
 }
+
+func TestLoad_ServiceFileExtensions(t *testing.T) {
+	ctx := context.Background()
+	loader, err := NewLoader(do.New())
+	require.NoError(t, err)
+	schema, err := loader.Load(ctx, []string{"testdata/notification.servicefile.yaml"}, []string{})
+	require.NoError(t, err)
+	require.Len(t, schema.Services, 1)
+	require.Len(t, schema.Services[0].Relationships, 1)
+
+	rel := schema.Services[0].Relationships[0]
+	assert.Equal(t, "Firebase Cloud Messaging", rel.Participant)
+	assert.Contains(t, rel.Notes, "Single provider")
+}
+
+func TestLoad_PlannedExtensions(t *testing.T) {
+	ctx := context.Background()
+	loader, err := NewLoader(do.New())
+	require.NoError(t, err)
+
+	schema, err := loader.Load(ctx, []string{"testdata/user.servicefile.yaml", "testdata/planned.servicefile.yaml"},
+		[]string{})
+	require.NoError(t, err)
+	require.Len(t, schema.Services, 2)
+
+	for _, service := range schema.Services {
+		switch service.Info.Name {
+		case "Billing Service":
+			assert.True(t, service.Info.Planned)
+		case "User Service":
+			assert.False(t, service.Info.Planned)
+			for _, rel := range service.Relationships {
+				assert.Equal(t, rel.Participant == "Keycloak", rel.Planned, rel.Participant)
+			}
+		default:
+			t.Fatalf("unexpected service %s", service.Info.Name)
+		}
+	}
+}
+
+func TestLoad_Namespaces(t *testing.T) {
+	ctx := context.Background()
+	loader, err := NewLoader(do.New())
+	require.NoError(t, err)
+
+	schema, err := loader.Load(ctx, []string{
+		"testdata/retail-gateway.servicefile.yaml",
+		"testdata/payments-ledger.servicefile.yaml",
+		"testdata/payments-gateway.servicefile.yaml",
+	}, []string{})
+	require.NoError(t, err)
+	require.Len(t, schema.Services, 3)
+
+	assert.Equal(t, "Payments/API Gateway", schema.Services[0].Info.Name)
+	assert.Equal(t, "Payments", schema.Services[0].Info.Namespace)
+	assert.Equal(t, "API Gateway", schema.Services[0].Info.LocalName())
+	assert.Equal(t, "Payments/Ledger", schema.Services[0].Relationships[0].Participant)
+	assert.Equal(t, "Retail/API Gateway", schema.Services[2].Info.Name)
+	assert.Equal(t, "Payments/Ledger", schema.Services[2].Relationships[0].Participant)
+}
+
+func TestLoad_BusinessCapability(t *testing.T) {
+	ctx := context.Background()
+	loader, err := NewLoader(do.New())
+	require.NoError(t, err)
+
+	schema, err := loader.Load(ctx, []string{"testdata/business-capability.servicefile.yaml"}, []string{})
+	require.NoError(t, err)
+	require.Len(t, schema.Services, 1)
+
+	assert.Equal(t, "Storefront", schema.Services[0].Info.System)
+	assert.Equal(t, "Payments", schema.Services[0].Info.BusinessCapability)
+}
+
+func TestLoad_DDDExtensions(t *testing.T) {
+	ctx := context.Background()
+	loader, err := NewLoader(do.New())
+	require.NoError(t, err)
+
+	schema, err := loader.Load(ctx, []string{"testdata/notification.servicefile.yaml"}, []string{})
+	require.NoError(t, err)
+	require.Len(t, schema.Services, 1)
+
+	service := schema.Services[0]
+	assert.True(t, service.Info.BoundedContext)
+	require.Len(t, service.Relationships, 1)
+	assert.Equal(t, []domain.DDDPattern{domain.DDDPatternConformist}, service.Relationships[0].DDDPatterns)
+}
+
+func TestLoad_Criticality(t *testing.T) {
+	ctx := context.Background()
+	loader, err := NewLoader(do.New())
+	require.NoError(t, err)
+
+	schema, err := loader.Load(ctx, []string{"testdata/criticality.servicefile.yaml"}, []string{})
+	require.NoError(t, err)
+	require.Len(t, schema.Services, 1)
+	require.Len(t, schema.Services[0].Relationships, 2)
+
+	criticalities := map[string]domain.Criticality{}
+	for _, rel := range schema.Services[0].Relationships {
+		criticalities[rel.Participant] = rel.Criticality
+	}
+	assert.Equal(t, map[string]domain.Criticality{"Billing Service": domain.CriticalityCritical, "Redis": ""},
+		criticalities)
+}
+
+func TestLoad_Risks(t *testing.T) {
+	ctx := context.Background()
+	loader, err := NewLoader(do.New())
+	require.NoError(t, err)
+
+	schema, err := loader.Load(ctx, []string{"testdata/risks.servicefile.yaml"}, []string{})
+	require.NoError(t, err)
+	require.Len(t, schema.Services, 1)
+	assert.Equal(t, []domain.Risk{
+		{Type: domain.RiskTypeEOLRuntime, Severity: domain.CriticalityHigh, Description: "Runs on Node.js 16"},
+	}, schema.Services[0].Info.Risks)
+	require.Len(t, schema.Services[0].Relationships, 1)
+	assert.Equal(t, []domain.Risk{{Type: domain.RiskTypeVendorLockIn, Severity: domain.CriticalityMedium}},
+		schema.Services[0].Relationships[0].Risks)
+}
+
+func TestLoad_Limits(t *testing.T) {
+	ctx := context.Background()
+	loader, err := NewLoader(do.New())
+	require.NoError(t, err)
+
+	schema, err := loader.Load(ctx, []string{"testdata/limits.servicefile.yaml"}, []string{})
+	require.NoError(t, err)
+	require.Len(t, schema.Services, 1)
+	assert.Equal(t, &domain.ServiceLimits{MaxRPS: 500}, schema.Services[0].Info.Limits)
+
+	limits := map[string]*domain.RelationshipLimits{}
+	for _, rel := range schema.Services[0].Relationships {
+		limits[rel.Participant] = rel.Limits
+	}
+	assert.Equal(t, map[string]*domain.RelationshipLimits{"Stripe": {RateLimit: 100}, "orders-db": nil}, limits)
+}
+
+func TestLoad_Deployments(t *testing.T) {
+	ctx := context.Background()
+	loader, err := NewLoader(do.New())
+	require.NoError(t, err)
+
+	schema, err := loader.Load(ctx, []string{"testdata/deployments.servicefile.yaml"}, []string{})
+	require.NoError(t, err)
+	require.Len(t, schema.Services, 1)
+	assert.Equal(t, []domain.Deployment{
+		{Environment: "staging", Health: "https://orders.staging.example.com/healthz"},
+		{Environment: "production", Health: "https://orders.example.com/healthz",
+			Readiness: "https://orders.example.com/readyz"},
+	}, schema.Services[0].Info.Deployments)
+}
+
+func TestLoad_Access(t *testing.T) {
+	ctx := context.Background()
+	loader, err := NewLoader(do.New())
+	require.NoError(t, err)
+
+	schema, err := loader.Load(ctx, []string{"testdata/access.servicefile.yaml"}, []string{})
+	require.NoError(t, err)
+	require.Len(t, schema.Services, 1)
+	require.Len(t, schema.Services[0].Relationships, 1)
+	assert.Equal(t, domain.AccessReadWrite, schema.Services[0].Relationships[0].Access)
+}
+
+func TestLoad_Approval(t *testing.T) {
+	ctx := context.Background()
+	loader, err := NewLoader(do.New())
+	require.NoError(t, err)
+
+	schema, err := loader.Load(ctx, []string{"testdata/approval.servicefile.yaml"}, []string{})
+	require.NoError(t, err)
+	require.Len(t, schema.Services, 1)
+	require.Len(t, schema.Services[0].Relationships, 1)
+	assert.Equal(t, "docs/adr/0007-billing-events.md", schema.Services[0].Relationships[0].Approval)
+}
+
+func TestLoad_Canceled(t *testing.T) {
+	loader, err := NewLoader(do.New())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = loader.Load(ctx, []string{"testdata/notification.servicefile.yaml"}, []string{})
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestLoadEndpoints(t *testing.T) {
+	t.Parallel()
+
+	loader, err := NewLoader(do.New())
+	require.NoError(t, err)
+
+	endpoints, err := loader.LoadEndpoints([]string{"testdata/campaign.openapi.yaml"})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string][]domain.Endpoint{
+		"Campaign Service": {
+			{Method: "GET", Path: "/campaigns", Summary: "List campaigns"},
+			{Method: "POST", Path: "/campaigns", Summary: "Create a campaign", Auth: []string{"bearerAuth"}},
+			{Method: "DELETE", Path: "/campaigns/{id}", Summary: "deleteCampaign",
+				Auth: []string{"oauth2 (campaigns:write)"}},
+		},
+	}, endpoints)
+
+	_, err = loader.LoadEndpoints([]string{"testdata/nonexistent.yaml"})
+	require.ErrorIs(t, err, ErrOpenAPILoadFailed)
+}
+
+func TestLoadSourceFiles(t *testing.T) {
+	t.Parallel()
+
+	loader, err := NewLoader(do.New())
+	require.NoError(t, err)
+
+	files, err := loader.LoadSourceFiles(context.Background(),
+		[]string{"testdata/campaign.servicefile.yaml"}, []string{"testdata/campaign.asyncapi.yaml"})
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+
+	assert.Equal(t, domain.SourceKindServiceFile, files[0].Kind)
+	assert.Equal(t, []string{"Campaign Service"}, files[0].Services)
+	assert.False(t, files[0].ModifiedAt.IsZero())
+
+	assert.Equal(t, domain.SourceKindAsyncAPI, files[1].Kind)
+	assert.Equal(t, []string{"Campaign Service"}, files[1].Services)
+
+	_, err = loader.LoadSourceFiles(context.Background(), []string{"testdata/nonexistent.yaml"}, nil)
+	require.ErrorIs(t, err, ErrServiceFileLoadFailed)
+}
+
+func TestLastCommit(t *testing.T) {
+	t.Parallel()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "orders.servicefile.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("servicefile: 0.1.0\n"), 0o600))
+
+	_, _, ok := lastCommit(context.Background(), path)
+	assert.False(t, ok, "files outside git repositories have no commit")
+
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"add", "--all"},
+		{"-c", "user.name=Jane Doe", "-c", "user.email=jane@localhost", "commit", "--quiet", "--message",
+			"Document orders\n\nWith the body left out."},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+
+	committedAt, commit, ok := lastCommit(context.Background(), path)
+	require.True(t, ok)
+	assert.False(t, committedAt.IsZero())
+	assert.Equal(t, domain.Commit{Author: "Jane Doe", Subject: "Document orders"}, commit)
+}
+
+func TestLoadMonorepo(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	files := map[string]string{
+		"go.mod":                             "module example.com/platform\n",
+		"services/billing/go.mod":            "// Billing\nmodule github.com/acme/platform/services/billing/v2\n\ngo 1.23\n",
+		"services/billing/vendor/x/go.mod":   "module example.com/x\n",
+		"web/storefront/package.json":        `{"name": "@acme/storefront", "version": "1.0.0"}`,
+		"web/node_modules/left/package.json": `{"name": "left"}`,
+		"web/tools/package.json":             `{"private": true}`,
+		"monorepo.yaml":                      "Campaign Service: services/campaigns/\n",
+	}
+
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+
+	loader, err := NewLoader(do.New())
+	require.NoError(t, err)
+
+	monorepo, err := loader.LoadMonorepo(root, filepath.Join(root, "monorepo.yaml"))
+	require.NoError(t, err)
+
+	assert.Equal(t, domain.Monorepo{
+		Mapping: map[string]string{"Campaign Service": "services/campaigns"},
+		Packages: []domain.MonorepoPackage{
+			{Dir: "services/billing", Name: "billing"},
+			{Dir: "web/storefront", Name: "storefront"},
+		},
+	}, monorepo)
+
+	require.NoError(t, os.WriteFile(filepath.Join(root, "monorepo.yaml"), []byte("Campaign Service: ../campaigns\n"),
+		0o644))
+
+	_, err = loader.LoadMonorepo(root, filepath.Join(root, "monorepo.yaml"))
+	require.ErrorIs(t, err, ErrMonorepoLoadFailed)
+}
+
+func TestLoadDeclarations(t *testing.T) {
+	t.Parallel()
+
+	loader, err := NewLoader(do.New())
+	require.NoError(t, err)
+
+	declarations, err := loader.LoadDeclarations(context.Background(),
+		[]string{"testdata/campaign.servicefile.yaml"}, []string{"testdata/campaign.asyncapi.yaml"})
+	require.NoError(t, err)
+	require.Len(t, declarations, 2)
+
+	assert.Equal(t, "testdata/campaign.servicefile.yaml", declarations[0].Source)
+	assert.Equal(t, "Campaign Service", declarations[0].Schema.Services[0].Info.Name)
+	assert.NotEmpty(t, declarations[0].Schema.Services[0].Relationships)
+
+	assert.Equal(t, "testdata/campaign.asyncapi.yaml", declarations[1].Source)
+	assert.Equal(t, "Campaign Service", declarations[1].Schema.Services[0].Info.Name)
+	assert.NotEmpty(t, declarations[1].Schema.Services[0].Operation)
+
+	_, err = loader.LoadDeclarations(context.Background(), nil, []string{"testdata/nonexistent.yaml"})
+	require.ErrorIs(t, err, ErrAsyncAPILoadFailed)
+}
@@ -0,0 +1,155 @@
+package schema
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrMonorepoLoadFailed is returned when the packages or the mapping file of a monorepo can't be read.
+var ErrMonorepoLoadFailed = errors.New("failed to load monorepo")
+
+// majorVersionSuffix is the /vN suffix of Go module paths of major versions above 1.
+var majorVersionSuffix = regexp.MustCompile(`/v[0-9]+$`)
+
+// LoadMonorepo detects the packages below the root of a monorepo by their go.mod and package.json files and
+// reads the mapping file of service names to subdirectories, when given. Dependencies, vendored code and
+// hidden directories are skipped, packages are listed in lexical order of their directories.
+func (l *Loader) LoadMonorepo(root, mappingPath string) (domain.Monorepo, error) {
+	var monorepo domain.Monorepo
+
+	err := filepath.WalkDir(root, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			name := entry.Name()
+			if filePath != root && (name == "node_modules" || name == "vendor" || strings.HasPrefix(name, ".")) {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		dir, err := filepath.Rel(root, filepath.Dir(filePath))
+		if err != nil || dir == "." {
+			return err
+		}
+
+		var name string
+
+		switch entry.Name() {
+		case "go.mod":
+			name, err = goModuleName(filePath)
+		case "package.json":
+			name, err = packageJSONName(filePath)
+		default:
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", filePath, err)
+		}
+
+		if name != "" {
+			monorepo.Packages = append(monorepo.Packages, domain.MonorepoPackage{Dir: filepath.ToSlash(dir), Name: name})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return domain.Monorepo{}, fmt.Errorf("%w: %w", ErrMonorepoLoadFailed, err)
+	}
+
+	if mappingPath == "" {
+		return monorepo, nil
+	}
+
+	monorepo.Mapping, err = loadMonorepoMapping(mappingPath)
+	if err != nil {
+		return domain.Monorepo{}, fmt.Errorf("%w: %w", ErrMonorepoLoadFailed, err)
+	}
+
+	return monorepo, nil
+}
+
+// goModuleName returns the last segment of the module path of a go.mod file, without a major version suffix.
+func goModuleName(filePath string) (string, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("reading go.mod: %w", err)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		module, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "module")
+		if !ok || module == "" || (module[0] != ' ' && module[0] != '\t') {
+			continue
+		}
+
+		module = strings.Trim(strings.TrimSpace(strings.SplitN(module, "//", 2)[0]), `"`)
+
+		return path.Base(majorVersionSuffix.ReplaceAllString(module, "")), nil
+	}
+
+	return "", nil
+}
+
+// packageJSONName returns the name of a package.json file without its scope.
+func packageJSONName(filePath string) (string, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("reading package.json: %w", err)
+	}
+
+	var pkg struct {
+		Name string `json:"name"`
+	}
+
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return "", fmt.Errorf("parsing package.json: %w", err)
+	}
+
+	if pkg.Name == "" {
+		return "", nil
+	}
+
+	return path.Base(pkg.Name), nil
+}
+
+// loadMonorepoMapping reads a YAML file mapping service names to subdirectories of the monorepo.
+func loadMonorepoMapping(mappingPath string) (map[string]string, error) {
+	data, err := os.ReadFile(mappingPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading mapping file: %w", err)
+	}
+
+	var mapping map[string]string
+	if err := yaml.Unmarshal(data, &mapping); err != nil {
+		return nil, fmt.Errorf("parsing mapping file %s: %w", mappingPath, err)
+	}
+
+	for service, dir := range mapping {
+		dir = path.Clean(filepath.ToSlash(strings.TrimSpace(dir)))
+		if !filepath.IsLocal(dir) || dir == "." {
+			return nil, fmt.Errorf("subdirectory %q of %s in mapping file %s must be inside the monorepo",
+				mapping[service], service, mappingPath)
+		}
+
+		mapping[service] = dir
+	}
+
+	return mapping, nil
+}
@@ -0,0 +1,133 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrOpenAPILoadFailed is returned when an OpenAPI specification can't be read.
+var ErrOpenAPILoadFailed = errors.New("failed to load OpenAPI file")
+
+// openAPIMethods are the keys of path items holding operations, in the order of the specification.
+//
+//nolint:gochecknoglobals // Fixed vocabulary of OpenAPI path items.
+var openAPIMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// openAPIDocument is the part of an OpenAPI 3 or Swagger 2.0 specification listing the endpoints.
+type openAPIDocument struct {
+	Info struct {
+		Title   string `yaml:"title"`
+		Service string `yaml:"x-service"`
+	} `yaml:"info"`
+	Security []map[string][]string           `yaml:"security"`
+	Paths    map[string]map[string]yaml.Node `yaml:"paths"`
+}
+
+type openAPIOperation struct {
+	Summary     string `yaml:"summary"`
+	OperationID string `yaml:"operationId"`
+	// Security is nil when the operation inherits the requirements of the document, an empty list makes it public.
+	Security *[]map[string][]string `yaml:"security"`
+}
+
+// LoadEndpoints loads the HTTP endpoints of services from OpenAPI specifications, by service name.
+// A specification belongs to the service named by x-service of its info object, or by its title.
+func (l *Loader) LoadEndpoints(openAPIFilesPaths []string) (map[string][]domain.Endpoint, error) {
+	endpoints := make(map[string][]domain.Endpoint)
+
+	for _, path := range openAPIFilesPaths {
+		service, serviceEndpoints, err := loadOpenAPIFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("%w %s: %w", ErrOpenAPILoadFailed, path, err)
+		}
+
+		endpoints[service] = append(endpoints[service], serviceEndpoints...)
+	}
+
+	return endpoints, nil
+}
+
+func loadOpenAPIFile(path string) (string, []domain.Endpoint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", nil, fmt.Errorf("reading file: %w", err)
+	}
+
+	var doc openAPIDocument
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return "", nil, fmt.Errorf("parsing file: %w", err)
+	}
+
+	service := strings.TrimSpace(doc.Info.Service)
+	if service == "" {
+		service = strings.TrimSpace(doc.Info.Title)
+	}
+
+	if service == "" {
+		return "", nil, errors.New("info.title or info.x-service is required to match the service")
+	}
+
+	var endpoints []domain.Endpoint
+
+	for _, endpointPath := range slices.Sorted(maps.Keys(doc.Paths)) {
+		item := doc.Paths[endpointPath]
+
+		for _, method := range openAPIMethods {
+			node, ok := item[method]
+			if !ok {
+				continue
+			}
+
+			var op openAPIOperation
+			if err := node.Decode(&op); err != nil {
+				return "", nil, fmt.Errorf("parsing %s %s: %w", strings.ToUpper(method), endpointPath, err)
+			}
+
+			security := doc.Security
+			if op.Security != nil {
+				security = *op.Security
+			}
+
+			summary := op.Summary
+			if summary == "" {
+				summary = op.OperationID
+			}
+
+			endpoints = append(endpoints, domain.Endpoint{
+				Method:  strings.ToUpper(method),
+				Path:    endpointPath,
+				Summary: summary,
+				Auth:    securitySchemes(security),
+			})
+		}
+	}
+
+	return service, endpoints, nil
+}
+
+// securitySchemes lists the schemes of security requirements, with their scopes when required.
+func securitySchemes(requirements []map[string][]string) []string {
+	var schemes []string
+
+	for _, requirement := range requirements {
+		for _, name := range slices.Sorted(maps.Keys(requirement)) {
+			scheme := name
+			if scopes := requirement[name]; len(scopes) > 0 {
+				scheme += " (" + strings.Join(scopes, ", ") + ")"
+			}
+
+			if !slices.Contains(schemes, scheme) {
+				schemes = append(schemes, scheme)
+			}
+		}
+	}
+
+	return schemes
+}
@@ -0,0 +1,97 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/holydocs/servicefile/pkg/servicefile"
+)
+
+// LoadSourceFiles lists the specification files with the services they define and their modification time,
+// the date of the last commit of files in git repositories.
+func (l *Loader) LoadSourceFiles(ctx context.Context, serviceFilesPaths, asyncapiFilesPaths []string,
+) ([]domain.SourceFile, error) {
+	files := make([]domain.SourceFile, 0, len(serviceFilesPaths)+len(asyncapiFilesPaths))
+
+	for _, path := range serviceFilesPaths {
+		sf, err := servicefile.Load(path)
+		if err != nil {
+			return nil, fmt.Errorf("%w %s: %w", ErrServiceFileLoadFailed, path, err)
+		}
+
+		file, err := statSourceFile(ctx, path, domain.SourceKindServiceFile)
+		if err != nil {
+			return nil, fmt.Errorf("%w %s: %w", ErrServiceFileLoadFailed, path, err)
+		}
+
+		file.Services = []string{sf.Info.Name}
+		files = append(files, file)
+	}
+
+	for _, path := range asyncapiFilesPaths {
+		path = strings.TrimSpace(path)
+
+		mfSchema, err := l.loadMessageFlowFile(ctx, path)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrAsyncAPILoadFailed, err)
+		}
+
+		file, err := statSourceFile(ctx, path, domain.SourceKindAsyncAPI)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrAsyncAPILoadFailed, err)
+		}
+
+		for _, service := range mfSchema.Services {
+			file.Services = append(file.Services, service.Name)
+		}
+
+		files = append(files, file)
+	}
+
+	return files, nil
+}
+
+func statSourceFile(ctx context.Context, path string, kind domain.SourceKind) (domain.SourceFile, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return domain.SourceFile{}, fmt.Errorf("reading file info: %w", err)
+	}
+
+	file := domain.SourceFile{Path: path, Kind: kind, ModifiedAt: info.ModTime()}
+	if committedAt, commit, ok := lastCommit(ctx, path); ok {
+		file.ModifiedAt, file.LastCommit = committedAt, commit
+	}
+
+	return file, nil
+}
+
+// lastCommit returns the committer date, author and subject of the last commit changing the file. Checkouts set
+// modification times of files to the time of the checkout, commit dates tell when the content changed.
+func lastCommit(ctx context.Context, path string) (time.Time, domain.Commit, bool) {
+	cmd := exec.CommandContext(ctx, "git", "log", "-1", "--format=%cI%x00%an%x00%s", "--", filepath.Base(path))
+	cmd.Dir = filepath.Dir(path)
+
+	output, err := cmd.Output()
+	if err != nil {
+		return time.Time{}, domain.Commit{}, false
+	}
+
+	// Files outside git repositories fail, untracked files have no commits.
+	fields := strings.SplitN(strings.TrimSpace(string(output)), "\x00", 3)
+	if len(fields) != 3 {
+		return time.Time{}, domain.Commit{}, false
+	}
+
+	date, err := time.Parse(time.RFC3339, fields[0])
+	if err != nil {
+		return time.Time{}, domain.Commit{}, false
+	}
+
+	return date, domain.Commit{Author: fields[1], Subject: fields[2]}, true
+}
@@ -0,0 +1,290 @@
+// Package sources persists specifications pushed to holydocs, e.g. by deploy pipelines, on disk.
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	do "github.com/samber/do/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// File permissions.
+const (
+	dirPerm  = 0o755
+	filePerm = 0o644
+)
+
+// sourceFileSuffix is the suffix of files holding the source description next to the specification.
+const sourceFileSuffix = ".source.json"
+
+//nolint:gochecknoglobals // Compiled once, used for validating source names.
+var sourceNameRe = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Store keeps every source as a specification file and a JSON description in a single directory,
+// so the directory can also be scanned as a regular input directory.
+type Store struct {
+	dir string
+	mu  sync.Mutex
+}
+
+func NewStore(i do.Injector) (*Store, error) {
+	cfg := do.MustInvoke[*config.Config](i)
+
+	return &Store{dir: cfg.Ingest.Dir}, nil
+}
+
+// Save stores content as the source, replacing a previous source with the same name.
+// The kind and the path of the returned source are filled from the content.
+func (s *Store) Save(_ context.Context, source domain.Source, content []byte) (domain.Source, error) {
+	name := source.Name
+	if !sourceNameRe.MatchString(name) {
+		return domain.Source{}, fmt.Errorf("%w: name %q must contain only letters, digits, '.', '_' and '-'",
+			domain.ErrInvalidSource, name)
+	}
+
+	kind, err := detectKind(content)
+	if err != nil {
+		return domain.Source{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(s.dir, dirPerm); err != nil {
+		return domain.Source{}, fmt.Errorf("creating sources directory %s: %w", s.dir, err)
+	}
+
+	previous, err := s.read(name)
+	if err != nil && !errors.Is(err, domain.ErrSourceNotFound) {
+		return domain.Source{}, err
+	}
+
+	source.Kind = kind
+	source.Path = filepath.Join(s.dir, name+"."+string(kind)+".yaml")
+
+	if err := writeFileAtomic(source.Path, content); err != nil {
+		return domain.Source{}, err
+	}
+
+	if previous.Path != "" && previous.Path != source.Path {
+		if err := removeIfExists(previous.Path); err != nil {
+			return domain.Source{}, err
+		}
+	}
+
+	data, err := json.MarshalIndent(source, "", "  ")
+	if err != nil {
+		return domain.Source{}, fmt.Errorf("marshaling source %s: %w", name, err)
+	}
+
+	if err := writeFileAtomic(s.sourceFilePath(name), data); err != nil {
+		return domain.Source{}, err
+	}
+
+	return source, nil
+}
+
+// List returns sources that are not expired at now ordered by name. Expired sources are skipped, Prune
+// removes them.
+func (s *Store) List(_ context.Context, now time.Time) ([]domain.Source, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all, err := s.readAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var sources []domain.Source
+
+	for _, source := range all {
+		if !source.Expired(now) {
+			sources = append(sources, source)
+		}
+	}
+
+	return sources, nil
+}
+
+// Prune removes the sources expired at now and returns how many were removed.
+func (s *Store) Prune(_ context.Context, now time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sources, err := s.readAll()
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+
+	for _, source := range sources {
+		if !source.Expired(now) {
+			continue
+		}
+
+		if err := s.remove(source); err != nil {
+			return removed, err
+		}
+
+		removed++
+	}
+
+	return removed, nil
+}
+
+// readAll returns all stored sources ordered by name, expired ones included.
+func (s *Store) readAll() ([]domain.Source, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("reading sources directory %s: %w", s.dir, err)
+	}
+
+	var sources []domain.Source
+
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), sourceFileSuffix)
+		if !ok || entry.IsDir() {
+			continue
+		}
+
+		source, err := s.read(name)
+		if err != nil {
+			return nil, err
+		}
+
+		sources = append(sources, source)
+	}
+
+	sort.Slice(sources, func(i, j int) bool {
+		return sources[i].Name < sources[j].Name
+	})
+
+	return sources, nil
+}
+
+// Delete removes the named source.
+func (s *Store) Delete(_ context.Context, name string) error {
+	if !sourceNameRe.MatchString(name) {
+		return fmt.Errorf("%w: %s", domain.ErrSourceNotFound, name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	source, err := s.read(name)
+	if err != nil {
+		return err
+	}
+
+	return s.remove(source)
+}
+
+func (s *Store) read(name string) (domain.Source, error) {
+	data, err := os.ReadFile(s.sourceFilePath(name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return domain.Source{}, fmt.Errorf("%w: %s", domain.ErrSourceNotFound, name)
+		}
+
+		return domain.Source{}, fmt.Errorf("reading source %s: %w", name, err)
+	}
+
+	var source domain.Source
+	if err := json.Unmarshal(data, &source); err != nil {
+		return domain.Source{}, fmt.Errorf("decoding source %s: %w", name, err)
+	}
+
+	return source, nil
+}
+
+func (s *Store) remove(source domain.Source) error {
+	if err := removeIfExists(source.Path); err != nil {
+		return err
+	}
+
+	return removeIfExists(s.sourceFilePath(source.Name))
+}
+
+func (s *Store) sourceFilePath(name string) string {
+	return filepath.Join(s.dir, name+sourceFileSuffix)
+}
+
+// detectKind recognizes the specification the same way input directories are scanned.
+func detectKind(content []byte) (domain.SourceKind, error) {
+	var doc map[string]any
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return "", fmt.Errorf("%w: payload is not a valid YAML or JSON document: %w", domain.ErrInvalidSource, err)
+	}
+
+	if _, ok := doc["servicefile"]; ok {
+		return domain.SourceKindServiceFile, nil
+	}
+
+	if _, ok := doc["asyncapi"]; ok {
+		return domain.SourceKindAsyncAPI, nil
+	}
+
+	return "", fmt.Errorf("%w: payload is neither a ServiceFile nor an AsyncAPI specification",
+		domain.ErrInvalidSource)
+}
+
+// writeFileAtomic replaces the file in one step, so documentation generated concurrently
+// never reads a partially written specification.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("creating temporary file for %s: %w", path, err)
+	}
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+
+	if err := os.Chmod(tmp.Name(), filePerm); err != nil {
+		os.Remove(tmp.Name())
+
+		return fmt.Errorf("setting permissions of %s: %w", path, err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+
+		return fmt.Errorf("replacing %s: %w", path, err)
+	}
+
+	return nil
+}
+
+func removeIfExists(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("removing %s: %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,149 @@
+package sources
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	serviceFilePayload = "servicefile: \"0.1.0\"\ninfo:\n  name: User Service\n"
+	asyncAPIPayload    = "asyncapi: 3.0.0\ninfo:\n  title: User Service\n"
+)
+
+func TestStore_SaveAndList(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	store := &Store{dir: filepath.Join(t.TempDir(), "sources")}
+
+	saved, err := store.Save(ctx, domain.Source{Name: "user", ReceivedAt: now, ExpiresAt: now.Add(time.Hour)},
+		[]byte(serviceFilePayload))
+	require.NoError(t, err)
+	assert.Equal(t, domain.SourceKindServiceFile, saved.Kind)
+	assert.Equal(t, filepath.Join(store.dir, "user.servicefile.yaml"), saved.Path)
+
+	content, err := os.ReadFile(saved.Path)
+	require.NoError(t, err)
+	assert.Equal(t, serviceFilePayload, string(content))
+
+	_, err = store.Save(ctx, domain.Source{Name: "notification", ReceivedAt: now}, []byte(asyncAPIPayload))
+	require.NoError(t, err)
+
+	sources, err := store.List(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, sources, 2)
+	assert.Equal(t, "notification", sources[0].Name)
+	assert.Equal(t, domain.SourceKindAsyncAPI, sources[0].Kind)
+	assert.True(t, sources[0].ExpiresAt.IsZero())
+	assert.Equal(t, saved, sources[1])
+}
+
+func TestStore_ListSkipsExpired(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	store := &Store{dir: t.TempDir()}
+
+	saved, err := store.Save(ctx, domain.Source{Name: "user", ReceivedAt: now, ExpiresAt: now.Add(time.Hour)},
+		[]byte(serviceFilePayload))
+	require.NoError(t, err)
+
+	sources, err := store.List(ctx, now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, sources)
+	assert.FileExists(t, saved.Path, "listing never removes sources")
+
+	removed, err := store.Prune(ctx, now)
+	require.NoError(t, err)
+	assert.Zero(t, removed)
+	assert.FileExists(t, saved.Path)
+
+	removed, err = store.Prune(ctx, now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	assert.NoFileExists(t, saved.Path)
+	assert.NoFileExists(t, store.sourceFilePath("user"))
+}
+
+func TestStore_SaveReplacesPreviousKind(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := &Store{dir: t.TempDir()}
+
+	first, err := store.Save(ctx, domain.Source{Name: "user"}, []byte(serviceFilePayload))
+	require.NoError(t, err)
+
+	second, err := store.Save(ctx, domain.Source{Name: "user"}, []byte(asyncAPIPayload))
+	require.NoError(t, err)
+
+	assert.NoFileExists(t, first.Path)
+	assert.FileExists(t, second.Path)
+
+	sources, err := store.List(ctx, time.Now())
+	require.NoError(t, err)
+	require.Len(t, sources, 1)
+	assert.Equal(t, domain.SourceKindAsyncAPI, sources[0].Kind)
+}
+
+func TestStore_SaveInvalid(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := &Store{dir: t.TempDir()}
+
+	tests := []struct {
+		name    string
+		source  string
+		payload string
+	}{
+		{name: "path traversal", source: "../user", payload: serviceFilePayload},
+		{name: "hidden name", source: ".user", payload: serviceFilePayload},
+		{name: "invalid yaml", source: "user", payload: "servicefile: [\n"},
+		{name: "unknown specification", source: "user", payload: "openapi: 3.0.0\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := store.Save(ctx, domain.Source{Name: tt.source}, []byte(tt.payload))
+			require.ErrorIs(t, err, domain.ErrInvalidSource)
+		})
+	}
+}
+
+func TestStore_Delete(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := &Store{dir: t.TempDir()}
+
+	saved, err := store.Save(ctx, domain.Source{Name: "user"}, []byte(serviceFilePayload))
+	require.NoError(t, err)
+
+	require.NoError(t, store.Delete(ctx, "user"))
+	assert.NoFileExists(t, saved.Path)
+
+	require.ErrorIs(t, store.Delete(ctx, "user"), domain.ErrSourceNotFound)
+	require.ErrorIs(t, store.Delete(ctx, "../user"), domain.ErrSourceNotFound)
+}
+
+func TestStore_ListMissingDirectory(t *testing.T) {
+	t.Parallel()
+
+	store := &Store{dir: filepath.Join(t.TempDir(), "missing")}
+
+	sources, err := store.List(context.Background(), time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, sources)
+}
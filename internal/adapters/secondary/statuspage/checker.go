@@ -0,0 +1,89 @@
+// Package statuspage reads the current status of third-party dependencies from their status pages.
+package statuspage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	do "github.com/samber/do/v2"
+)
+
+// maxContentSize limits the size of a fetched status.
+const maxContentSize = 1 << 20
+
+// statusPath is the status endpoint of pages hosted by Atlassian Statuspage, which most vendors use.
+const statusPath = "/api/v2/status.json"
+
+// Checker reads status pages in the format of the Statuspage API.
+type Checker struct {
+	client *http.Client
+}
+
+func NewChecker(_ do.Injector) (*Checker, error) {
+	return &Checker{client: &http.Client{}}, nil
+}
+
+type statusResponse struct {
+	Status struct {
+		Indicator   string `json:"indicator"`
+		Description string `json:"description"`
+	} `json:"status"`
+}
+
+// Check returns the current status reported by the status page. URLs of pages are completed with the
+// Statuspage status endpoint, URLs ending with .json are requested as they are.
+func (c *Checker) Check(ctx context.Context, req domain.CheckStatusPageRequest) (domain.DependencyStatus, error) {
+	if req.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, req.Timeout)
+		defer cancel()
+	}
+
+	endpoint := req.URL
+	if !strings.HasSuffix(endpoint, ".json") {
+		endpoint = strings.TrimSuffix(endpoint, "/") + statusPath
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return domain.DependencyStatus{}, fmt.Errorf("creating request: %w", err)
+	}
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return domain.DependencyStatus{}, fmt.Errorf("requesting %s: %w", endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return domain.DependencyStatus{}, fmt.Errorf("requesting %s: unexpected status %s", endpoint, resp.Status)
+	}
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, maxContentSize+1))
+	if err != nil {
+		return domain.DependencyStatus{}, fmt.Errorf("reading %s: %w", endpoint, err)
+	}
+
+	if len(content) > maxContentSize {
+		return domain.DependencyStatus{}, fmt.Errorf("%s is larger than %d bytes", endpoint, maxContentSize)
+	}
+
+	var status statusResponse
+	if err := json.Unmarshal(content, &status); err != nil {
+		return domain.DependencyStatus{}, fmt.Errorf("parsing %s: %w", endpoint, err)
+	}
+
+	if status.Status.Indicator == "" && status.Status.Description == "" {
+		return domain.DependencyStatus{}, fmt.Errorf("%s reports no status, expected a Statuspage status", endpoint)
+	}
+
+	return domain.DependencyStatus{
+		Indicator:   status.Status.Indicator,
+		Description: status.Status.Description,
+	}, nil
+}
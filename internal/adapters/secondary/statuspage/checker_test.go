@@ -0,0 +1,44 @@
+package statuspage
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecker_Check(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/status.json", "/custom/status.json":
+			_, _ = w.Write([]byte(`{"page": {"name": "Stripe"},
+				"status": {"indicator": "minor", "description": "Partially Degraded Service"}}`))
+		case "/blank/api/v2/status.json":
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	checker := &Checker{client: server.Client()}
+	want := domain.DependencyStatus{Indicator: "minor", Description: "Partially Degraded Service"}
+
+	for _, url := range []string{server.URL, server.URL + "/", server.URL + "/custom/status.json"} {
+		status, err := checker.Check(context.Background(), domain.CheckStatusPageRequest{URL: url})
+		require.NoError(t, err, url)
+		assert.Equal(t, want, status, url)
+	}
+
+	_, err := checker.Check(context.Background(), domain.CheckStatusPageRequest{URL: server.URL + "/blank"})
+	require.ErrorContains(t, err, "reports no status")
+
+	_, err = checker.Check(context.Background(), domain.CheckStatusPageRequest{URL: server.URL + "/missing"})
+	require.ErrorContains(t, err, "unexpected status 404 Not Found")
+}
@@ -0,0 +1,334 @@
+package d2
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+)
+
+// Context map arrowhead markers.
+const (
+	upstreamMarker   = "U"
+	downstreamMarker = "D"
+)
+
+// ContextMapNode represents a bounded context, or a participant integrated with a DDD pattern, on the context map.
+type ContextMapNode struct {
+	ID       string
+	Label    string
+	Tooltip  string
+	External bool
+	Planned  bool
+}
+
+// ContextMapEdge represents the relationship between two contexts. Asymmetric edges point from the upstream
+// to the downstream context, symmetric ones (partnership, shared kernel) have no direction.
+type ContextMapEdge struct {
+	From            string
+	To              string
+	Upstream        string
+	Downstream      string
+	Label           string
+	Patterns        []domain.DDDPattern
+	Symmetric       bool
+	Planned         bool
+	UpstreamLabel   string
+	DownstreamLabel string
+}
+
+// ContextMapPayload represents the data structure for the context map template.
+type ContextMapPayload struct {
+	Nodes      []ContextMapNode
+	Edges      []ContextMapEdge
+	HasPlanned bool
+}
+
+// GenerateContextMapDiagramScript generates the D2 script of the context map,
+// nil when no system is marked as a bounded context.
+func (t *Target) GenerateContextMapDiagramScript(schema domain.Schema) ([]byte, error) {
+	payload := BuildContextMap(schema)
+	if len(payload.Nodes) == 0 {
+		return nil, nil
+	}
+
+	var buf bytes.Buffer
+	if err := t.contextMapTemplate.Execute(&buf, payload); err != nil {
+		return nil, fmt.Errorf("execute context map template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// GenerateContextMapDiagram generates the context map diagram, nil when no system is marked as a bounded context.
+func (t *Target) GenerateContextMapDiagram(ctx context.Context, schema domain.Schema) ([]byte, error) {
+	script, err := t.GenerateContextMapDiagramScript(schema)
+	if err != nil || script == nil {
+		return nil, err
+	}
+
+	return t.RenderSchema(ctx, domain.FormattedSchema{Type: targetType, Data: script})
+}
+
+type contextMapEdgeKey struct {
+	from, to  string
+	symmetric bool
+}
+
+// BuildContextMap groups services into the bounded contexts of their systems and aggregates relationships
+// crossing contexts, together with their DDD patterns. Participants outside bounded contexts are only shown
+// when the relationship declares a DDD pattern, e.g. an anticorruption layer in front of an external system.
+func BuildContextMap(schema domain.Schema) ContextMapPayload {
+	contexts := boundedContexts(schema.Services)
+	if len(contexts) == 0 {
+		return ContextMapPayload{}
+	}
+
+	ids := newNodeIDs(schema.Services)
+	serviceContext := make(map[string]string)
+	serviceSystem := make(map[string]string)
+	plannedServices := plannedServiceNames(schema.Services)
+	nodes := make(map[string]*ContextMapNode)
+	contextServices := make(map[string][]string)
+
+	for _, service := range schema.Services {
+		serviceSystem[service.Info.Name] = service.Info.System
+
+		if _, ok := contexts[service.Info.System]; !ok {
+			continue
+		}
+
+		name := service.Info.System
+		serviceContext[service.Info.Name] = name
+		contextServices[name] = append(contextServices[name], service.Info.Name)
+
+		node, ok := nodes[name]
+		if !ok {
+			node = &ContextMapNode{ID: ids.system(name), Label: name, Planned: true}
+			nodes[name] = node
+		}
+		node.Planned = node.Planned && service.Info.Planned
+	}
+
+	for name, services := range contextServices {
+		sort.Strings(services)
+		nodes[name].Tooltip = strings.Join(services, ", ")
+	}
+
+	edges := make(map[contextMapEdgeKey]*ContextMapEdge)
+	var keys []contextMapEdgeKey
+
+	addEdge := func(key contextMapEdgeKey, upstream, downstream string, patterns []domain.DDDPattern, planned bool) {
+		edge, exists := edges[key]
+		if !exists {
+			edge = &ContextMapEdge{
+				From:       nodes[key.from].ID,
+				To:         nodes[key.to].ID,
+				Upstream:   upstream,
+				Downstream: downstream,
+				Symmetric:  key.symmetric,
+				Planned:    planned,
+			}
+			edges[key] = edge
+			keys = append(keys, key)
+		}
+
+		edge.Planned = plannedEdge(planned, exists, edge.Planned)
+		for _, pattern := range patterns {
+			if !slices.Contains(edge.Patterns, pattern) {
+				edge.Patterns = append(edge.Patterns, pattern)
+			}
+		}
+	}
+
+	for _, service := range schema.Services {
+		source, ok := serviceContext[service.Info.Name]
+		if !ok {
+			continue
+		}
+
+		for _, rel := range service.Relationships {
+			target, ok := relationshipContext(rel, serviceContext, serviceSystem)
+			if !ok || target == source {
+				continue
+			}
+
+			if _, isContext := nodes[target]; !isContext {
+				if len(rel.DDDPatterns) == 0 {
+					continue
+				}
+				nodes[target] = &ContextMapNode{ID: externalNodeID(target), Label: target, External: true,
+					Planned: true}
+			}
+
+			planned := isPlannedRelationship(service, rel, plannedServices)
+			if nodes[target].External {
+				nodes[target].Planned = nodes[target].Planned && planned
+			}
+
+			upstream, downstream := source, target
+			if dependsOnParticipant(rel.Action) {
+				upstream, downstream = target, source
+			}
+
+			var symmetric, asymmetric []domain.DDDPattern
+			for _, pattern := range rel.DDDPatterns {
+				if pattern.Symmetric() {
+					symmetric = append(symmetric, pattern)
+				} else {
+					asymmetric = append(asymmetric, pattern)
+				}
+			}
+
+			if len(symmetric) > 0 {
+				from, to := source, target
+				if to < from {
+					from, to = to, from
+				}
+				addEdge(contextMapEdgeKey{from: from, to: to, symmetric: true}, from, to, symmetric, planned)
+			}
+
+			if len(asymmetric) > 0 || len(symmetric) == 0 {
+				addEdge(contextMapEdgeKey{from: upstream, to: downstream}, upstream, downstream, asymmetric, planned)
+			}
+		}
+	}
+
+	return contextMapPayload(nodes, edges, keys)
+}
+
+func contextMapPayload(nodes map[string]*ContextMapNode, edges map[contextMapEdgeKey]*ContextMapEdge,
+	keys []contextMapEdgeKey) ContextMapPayload {
+	payload := ContextMapPayload{}
+
+	for _, node := range nodes {
+		payload.Nodes = append(payload.Nodes, *node)
+		payload.HasPlanned = payload.HasPlanned || node.Planned
+	}
+
+	sort.Slice(payload.Nodes, func(i, j int) bool {
+		return payload.Nodes[i].ID < payload.Nodes[j].ID
+	})
+
+	order := domain.DDDPatterns()
+
+	for _, key := range keys {
+		edge := edges[key]
+		sort.SliceStable(edge.Patterns, func(i, j int) bool {
+			return slices.Index(order, edge.Patterns[i]) < slices.Index(order, edge.Patterns[j])
+		})
+
+		edge.Label = patternNames(edge.Patterns)
+		if !edge.Symmetric {
+			edge.UpstreamLabel, edge.DownstreamLabel = sideLabels(edge.Patterns)
+		}
+
+		payload.Edges = append(payload.Edges, *edge)
+		payload.HasPlanned = payload.HasPlanned || edge.Planned
+	}
+
+	sort.Slice(payload.Edges, func(i, j int) bool {
+		if payload.Edges[i].From != payload.Edges[j].From {
+			return payload.Edges[i].From < payload.Edges[j].From
+		}
+		if payload.Edges[i].To != payload.Edges[j].To {
+			return payload.Edges[i].To < payload.Edges[j].To
+		}
+
+		return !payload.Edges[i].Symmetric && payload.Edges[j].Symmetric
+	})
+
+	return payload
+}
+
+// boundedContexts returns the systems marked as bounded contexts by any of their services.
+func boundedContexts(services []domain.Service) map[string]struct{} {
+	contexts := make(map[string]struct{})
+
+	for _, service := range services {
+		if service.Info.BoundedContext && service.Info.System != "" {
+			contexts[service.Info.System] = struct{}{}
+		}
+	}
+
+	return contexts
+}
+
+// relationshipContext resolves the context of a relationship participant: its bounded context, its system
+// or, for external participants and services without a system, the participant itself.
+func relationshipContext(rel domain.Relationship, serviceContext, serviceSystem map[string]string) (string, bool) {
+	if rel.Person || rel.Participant == "" {
+		return "", false
+	}
+
+	if name, ok := serviceContext[rel.Participant]; ok {
+		return name, true
+	}
+
+	if system := serviceSystem[rel.Participant]; system != "" {
+		return system, true
+	}
+
+	return rel.Participant, true
+}
+
+// dependsOnParticipant reports whether the participant of a relationship is upstream of the declaring service.
+func dependsOnParticipant(action domain.RelationshipAction) bool {
+	switch action {
+	case domain.RelationshipActionSends, domain.RelationshipActionReplies:
+		return false
+	default:
+		return true
+	}
+}
+
+// sideLabels returns the arrowhead labels, upstream patterns (OHS, PL, supplier) on the upstream end and
+// downstream patterns (ACL, conformist, customer) on the downstream end.
+func sideLabels(patterns []domain.DDDPattern) (string, string) {
+	upstream := []string{upstreamMarker}
+	downstream := []string{downstreamMarker}
+
+	for _, pattern := range patterns {
+		switch pattern {
+		case domain.DDDPatternOpenHostService, domain.DDDPatternPublishedLanguage:
+			upstream = append(upstream, pattern.Abbreviation())
+		case domain.DDDPatternCustomerSupplier:
+			upstream = append(upstream, "S")
+			downstream = append(downstream, "C")
+		case domain.DDDPatternConformist, domain.DDDPatternAnticorruptionLayer:
+			downstream = append(downstream, pattern.Abbreviation())
+		default:
+		}
+	}
+
+	return strings.Join(upstream, " "), strings.Join(downstream, " ")
+}
+
+func patternNames(patterns []domain.DDDPattern) string {
+	names := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		names = append(names, PatternName(pattern))
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// PatternName returns the human readable name of a DDD pattern, e.g. "Anticorruption Layer".
+func PatternName(pattern domain.DDDPattern) string {
+	words := strings.Split(string(pattern), "_")
+	for i, word := range words {
+		if word != "" {
+			words[i] = strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+
+	if pattern == domain.DDDPatternCustomerSupplier {
+		return strings.Join(words, "/")
+	}
+
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,121 @@
+package d2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func contextMapSchema() domain.Schema {
+	return domain.Schema{Services: []domain.Service{
+		{
+			Info: domain.ServiceInfo{Name: "Order Service", System: "Ordering", BoundedContext: true},
+			Relationships: []domain.Relationship{
+				{
+					Action:      domain.RelationshipActionRequests,
+					Participant: "Billing Service",
+					DDDPatterns: []domain.DDDPattern{
+						domain.DDDPatternAnticorruptionLayer, domain.DDDPatternOpenHostService,
+					},
+				},
+				{
+					Action:      domain.RelationshipActionUses,
+					Participant: "Catalog Service",
+					DDDPatterns: []domain.DDDPattern{domain.DDDPatternSharedKernel},
+				},
+				{Action: domain.RelationshipActionRequests, Participant: "Stripe", External: true,
+					DDDPatterns: []domain.DDDPattern{domain.DDDPatternConformist}, Planned: true},
+				{Action: domain.RelationshipActionUses, Participant: "postgres"},
+				{Action: domain.RelationshipActionRequests, Participant: "Cart Service"},
+			},
+		},
+		{Info: domain.ServiceInfo{Name: "Cart Service", System: "Ordering"}},
+		{
+			Info: domain.ServiceInfo{Name: "Billing Service", System: "Billing", BoundedContext: true},
+			Relationships: []domain.Relationship{
+				{Action: domain.RelationshipActionSends, Participant: "Order Service"},
+			},
+		},
+		{Info: domain.ServiceInfo{Name: "Catalog Service", System: "Catalog", BoundedContext: true}},
+		{Info: domain.ServiceInfo{Name: "Search Service", System: "Search"}},
+	}}
+}
+
+func TestBuildContextMap(t *testing.T) {
+	t.Parallel()
+
+	payload := BuildContextMap(contextMapSchema())
+
+	labels := make([]string, 0, len(payload.Nodes))
+	for _, node := range payload.Nodes {
+		labels = append(labels, node.Label)
+	}
+	assert.Equal(t, []string{"Stripe", "Billing", "Catalog", "Ordering"}, labels)
+	assert.True(t, payload.Nodes[0].External)
+	assert.True(t, payload.Nodes[0].Planned)
+	assert.Equal(t, "Cart Service, Order Service", payload.Nodes[3].Tooltip)
+	assert.True(t, payload.HasPlanned)
+
+	// Billing also sends to Ordering, which merges into the same upstream/downstream edge.
+	require.Len(t, payload.Edges, 3)
+
+	stripe := payload.Edges[0]
+	assert.Equal(t, "Stripe", stripe.Upstream)
+	assert.Equal(t, "Ordering", stripe.Downstream)
+	assert.Equal(t, "D CF", stripe.DownstreamLabel)
+	assert.True(t, stripe.Planned)
+
+	billing := payload.Edges[1]
+	assert.Equal(t, "Billing", billing.Upstream)
+	assert.Equal(t, "Ordering", billing.Downstream)
+	assert.Equal(t, "Anticorruption Layer, Open Host Service", billing.Label)
+	assert.Equal(t, "U OHS", billing.UpstreamLabel)
+	assert.Equal(t, "D ACL", billing.DownstreamLabel)
+	assert.False(t, billing.Planned)
+
+	catalog := payload.Edges[2]
+	assert.True(t, catalog.Symmetric)
+	assert.Equal(t, "Shared Kernel", catalog.Label)
+}
+
+func TestBuildContextMap_NoBoundedContexts(t *testing.T) {
+	t.Parallel()
+
+	payload := BuildContextMap(domain.Schema{Services: []domain.Service{
+		{Info: domain.ServiceInfo{Name: "Order Service", System: "Ordering"}},
+	}})
+	assert.Empty(t, payload.Nodes)
+}
+
+func TestTarget_ContextMapDiagram(t *testing.T) {
+	t.Parallel()
+
+	target, err := NewTarget(config.D2Config{Pad: 64, Font: "SourceSansPro", Layout: "elk"})
+	require.NoError(t, err)
+
+	script, err := target.GenerateContextMapDiagramScript(contextMapSchema())
+	require.NoError(t, err)
+	assert.Contains(t, string(script), `system_billing -> system_ordering: "Anticorruption Layer, Open Host Service"`)
+	assert.Contains(t, string(script), `source-arrowhead.label: "U OHS"`)
+	assert.Contains(t, string(script), `system_catalog <-> system_ordering: "Shared Kernel"`)
+
+	diagram, err := target.GenerateContextMapDiagram(context.Background(), contextMapSchema())
+	require.NoError(t, err)
+	assert.Contains(t, string(diagram), "<svg")
+
+	empty, err := target.GenerateContextMapDiagram(context.Background(), domain.Schema{})
+	require.NoError(t, err)
+	assert.Nil(t, empty)
+}
+
+func TestPatternName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "Anticorruption Layer", PatternName(domain.DDDPatternAnticorruptionLayer))
+	assert.Equal(t, "Customer/Supplier", PatternName(domain.DDDPatternCustomerSupplier))
+	assert.Equal(t, "Conformist", PatternName(domain.DDDPatternConformist))
+}
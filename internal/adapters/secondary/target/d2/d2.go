@@ -6,18 +6,22 @@ import (
 	"embed"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"sort"
 	"strings"
 	"text/template"
 
 	"github.com/holydocs/holydocs/internal/config"
 	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/holydocs/holydocs/internal/slug"
 	"oss.terrastruct.com/d2/d2graph"
 	"oss.terrastruct.com/d2/d2layouts/d2dagrelayout"
 	"oss.terrastruct.com/d2/d2layouts/d2elklayout"
 	"oss.terrastruct.com/d2/d2lib"
 	"oss.terrastruct.com/d2/d2renderers/d2fonts"
 	"oss.terrastruct.com/d2/d2renderers/d2svg"
+	"oss.terrastruct.com/d2/d2target"
 	"oss.terrastruct.com/d2/lib/log"
 	"oss.terrastruct.com/d2/lib/textmeasure"
 )
@@ -38,6 +42,13 @@ const (
 	asyncOpReply = "reply"
 )
 
+// Datastore access labels.
+const (
+	readsLabel      = "reads"
+	writesLabel     = "writes"
+	readWritesLabel = "reads/writes"
+)
+
 // Async labels.
 const (
 	asyncLabelPub    = "pub"
@@ -68,8 +79,13 @@ type Target struct {
 	overviewTemplate             *template.Template
 	serviceRelationshipsTemplate *template.Template
 	systemTemplate               *template.Template
+	contextMapTemplate           *template.Template
+	personaTemplate              *template.Template
+	runtimeTemplate              *template.Template
 	renderOpts                   *d2svg.RenderOpts
 	config                       config.D2Config
+	highlight                    highlighter
+	labels                       edgeLabeler
 }
 
 // NewTarget creates a new D2 target with the provided configuration.
@@ -89,6 +105,21 @@ func NewTarget(cfg config.D2Config) (*Target, error) {
 		return nil, fmt.Errorf("%w %q: %w", ErrTemplateParsing, "templates/system.tmpl", err)
 	}
 
+	contextMapTemplate, err := template.ParseFS(templatesFS, "templates/context_map.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("%w %q: %w", ErrTemplateParsing, "templates/context_map.tmpl", err)
+	}
+
+	personaTemplate, err := template.ParseFS(templatesFS, "templates/persona.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("%w %q: %w", ErrTemplateParsing, "templates/persona.tmpl", err)
+	}
+
+	runtimeTemplate, err := template.ParseFS(templatesFS, "templates/runtime.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("%w %q: %w", ErrTemplateParsing, "templates/runtime.tmpl", err)
+	}
+
 	renderOpts := &d2svg.RenderOpts{
 		Pad:  &cfg.Pad,
 		Font: cfg.Font,
@@ -106,11 +137,23 @@ func NewTarget(cfg config.D2Config) (*Target, error) {
 		overviewTemplate:             overviewTemplate,
 		serviceRelationshipsTemplate: serviceRelationshipsTemplate,
 		systemTemplate:               systemTemplate,
+		contextMapTemplate:           contextMapTemplate,
+		personaTemplate:              personaTemplate,
+		runtimeTemplate:              runtimeTemplate,
 		renderOpts:                   renderOpts,
 		config:                       cfg,
 	}, nil
 }
 
+// WithHighlight returns a copy of the target that highlights the given services, systems and relationship
+// technologies in overview and system diagrams.
+func (t *Target) WithHighlight(cfg config.Highlight) *Target {
+	highlighted := *t
+	highlighted.highlight = newHighlighter(cfg)
+
+	return &highlighted
+}
+
 // Capabilities returns the capabilities of the D2 target.
 func (t *Target) Capabilities() domain.TargetCapabilities {
 	return domain.TargetCapabilities{
@@ -139,6 +182,26 @@ func (t *Target) RenderSchema(ctx context.Context, fs domain.FormattedSchema) ([
 		return nil, fmt.Errorf("%w: %s, expected: %s", ErrUnsupportedFormatType, fs.Type, targetType)
 	}
 
+	diagram, err := t.compile(ctx, fs.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	svg, err := d2svg.Render(diagram, t.renderOpts)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSVGRendering, err)
+	}
+
+	return svg, nil
+}
+
+// compile lays out a D2 script.
+func (t *Target) compile(ctx context.Context, script []byte) (*d2target.Diagram, error) {
+	// Layouts of large diagrams take a while, interrupted runs don't start new ones.
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	ctx = log.WithDefault(ctx)
 
 	// Create a new Ruler for each call since it's not thread-safe
@@ -157,23 +220,20 @@ func (t *Target) RenderSchema(ctx context.Context, fs domain.FormattedSchema) ([
 		Layout:         &t.config.Layout,
 	}
 
-	diagram, _, err := d2lib.Compile(ctx, string(fs.Data), compileOpts, t.renderOpts)
+	diagram, _, err := d2lib.Compile(ctx, string(script), compileOpts, t.renderOpts)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %w", ErrDiagramCompilation, err)
 	}
 
-	svg, err := d2svg.Render(diagram, t.renderOpts)
-	if err != nil {
-		return nil, fmt.Errorf("%w: %w", ErrSVGRendering, err)
-	}
-
-	return svg, nil
+	return diagram, nil
 }
 
 // ServiceMaps contains service-related maps for efficient lookups.
 type ServiceMaps struct {
-	ServiceNames map[string]struct{}
-	ServiceIDs   map[string]string
+	ServiceNames    map[string]struct{}
+	ServiceIDs      map[string]string
+	PlannedServices map[string]struct{}
+	NodeIDs         nodeIDs
 }
 
 // ServiceRelationshipEdges contains edges and async edges for a service.
@@ -182,20 +242,53 @@ type ServiceRelationshipEdges struct {
 	AsyncEdges []domain.AsyncEdge
 }
 
-func serviceNodeID(name string) string {
-	return "service_" + sanitizeFilename(name)
+// nodeIDs assigns node IDs to services and systems. Names that sanitize to the same ID, e.g. "Service_A" and
+// "Service-A", would be drawn as one node, so they are told apart by a suffix, see domain.UniqueKeys.
+// Names missing from the maps, including all names of a zero value, get the sanitized name as ID.
+type nodeIDs struct {
+	services map[string]string
+	systems  map[string]string
 }
 
-func externalNodeID(name string) string {
-	return "external_" + sanitizeFilename(name)
+func newNodeIDs(services []domain.Service) nodeIDs {
+	serviceNames := make([]string, 0, len(services))
+	systemNames := make([]string, 0)
+
+	for _, service := range services {
+		serviceNames = append(serviceNames, service.Info.Name)
+		if system := strings.TrimSpace(service.Info.System); system != "" {
+			systemNames = append(systemNames, system)
+		}
+	}
+
+	return nodeIDs{
+		services: domain.UniqueKeys(serviceNames, sanitizeFilename),
+		systems:  domain.UniqueKeys(systemNames, sanitizeFilename),
+	}
+}
+
+func (ids nodeIDs) service(name string) string {
+	return "service_" + uniqueKey(ids.services, name)
+}
+
+func (ids nodeIDs) system(name string) string {
+	return "system_" + uniqueKey(ids.systems, name)
+}
+
+func uniqueKey(keys map[string]string, name string) string {
+	if key, ok := keys[name]; ok {
+		return key
+	}
+
+	return sanitizeFilename(name)
 }
 
-func systemNodeID(name string) string {
-	return "system_" + sanitizeFilename(name)
+func externalNodeID(name string) string {
+	return "external_" + sanitizeFilename(name)
 }
 
 func sanitizeFilename(name string) string {
-	return strings.ToLower(strings.ReplaceAll(strings.ReplaceAll(name, " ", "-"), "_", "-"))
+	return slug.Make(name)
 }
 
 func FormatOverviewDescription(description string) string {
@@ -381,20 +474,32 @@ func describeAsyncDirection(label string, isOutgoing bool) string {
 
 // OverviewDocsNode represents a node in the overview diagram for docs generation.
 type OverviewDocsNode struct {
-	ID       string
-	Label    string
-	IsSystem bool
-	External bool
-	Internal bool
-	Person   bool
-	Content  string
+	ID          string
+	Label       string
+	IsSystem    bool
+	External    bool
+	Internal    bool
+	Person      bool
+	Planned     bool
+	Highlighted bool
+	Content     string
+	// Diff is set on the nodes of diff diagrams, see GenerateOverviewDiffScript.
+	Diff domain.ChangeType
 }
 
 // OverviewDocsEdge represents an edge in the overview diagram for docs generation.
 type OverviewDocsEdge struct {
-	From  string
-	To    string
-	Label string
+	From        string
+	To          string
+	Label       string
+	Technology  string
+	Proto       string
+	Criticality domain.Criticality
+	Access      domain.Access
+	Planned     bool
+	Highlighted bool
+	// Diff is set on the edges of diff diagrams, see GenerateOverviewDiffScript.
+	Diff domain.ChangeType
 }
 
 // OverviewDocsPayload represents the data structure for overview docs template.
@@ -402,13 +507,17 @@ type OverviewDocsPayload struct {
 	Nodes               []OverviewDocsNode
 	Edges               []OverviewDocsEdge
 	HasInternalServices bool
+	HasPlanned          bool
+	HasHighlighted      bool
+	HasDiff             bool
 	GlobalName          string
 }
 
 // ServiceRelationshipsDocsNode represents a service node in service relationships diagram.
 type ServiceRelationshipsDocsNode struct {
-	ID    string
-	Label string
+	ID      string
+	Label   string
+	Planned bool
 }
 
 // ServiceRelationshipsDocsExternalNode represents an external node in service relationships diagram.
@@ -419,13 +528,19 @@ type ServiceRelationshipsDocsExternalNode struct {
 	Tooltip  string
 	External bool
 	Person   bool
+	Planned  bool
 }
 
 // ServiceRelationshipsDocsEdge represents an edge in service relationships diagram.
 type ServiceRelationshipsDocsEdge struct {
-	From  string
-	To    string
-	Label string
+	From        string
+	To          string
+	Label       string
+	Technology  string
+	Proto       string
+	Criticality domain.Criticality
+	Access      domain.Access
+	Planned     bool
 }
 
 // ServiceRelationshipsDocsPayload represents the data structure for service relationships docs template.
@@ -433,31 +548,42 @@ type ServiceRelationshipsDocsPayload struct {
 	Services      []ServiceRelationshipsDocsNode
 	ExternalNodes []ServiceRelationshipsDocsExternalNode
 	Edges         []ServiceRelationshipsDocsEdge
+	HasPlanned    bool
 }
 
 // SystemDocsNode represents a service node in system diagram for docs generation.
 type SystemDocsNode struct {
-	ID       string
-	Label    string
-	Content  string
-	External bool
-	Person   bool
+	ID          string
+	Label       string
+	Content     string
+	External    bool
+	Person      bool
+	Planned     bool
+	Highlighted bool
 }
 
 // SystemDocsEdge represents an edge in system diagram for docs generation.
 type SystemDocsEdge struct {
-	From  string
-	To    string
-	Label string
+	From        string
+	To          string
+	Label       string
+	Technology  string
+	Proto       string
+	Criticality domain.Criticality
+	Access      domain.Access
+	Planned     bool
+	Highlighted bool
 }
 
 // SystemDocsPayload represents the data structure for system docs template.
 type SystemDocsPayload struct {
-	SystemName    string
-	SystemID      string
-	SystemNodes   []SystemDocsNode
-	ExternalNodes []SystemDocsNode
-	Edges         []SystemDocsEdge
+	SystemName     string
+	SystemID       string
+	SystemNodes    []SystemDocsNode
+	ExternalNodes  []SystemDocsNode
+	Edges          []SystemDocsEdge
+	HasPlanned     bool
+	HasHighlighted bool
 }
 
 // GenerateOverviewDiagram generates an overview diagram using the docs-specific template.
@@ -560,7 +686,8 @@ func (t *Target) prepareOverviewDocsPayload(schema domain.Schema, asyncEdges []d
 		Edges: []OverviewDocsEdge{},
 	}
 
-	serviceToNode, nodes, idToServiceName := buildOverviewNodes(schema)
+	ids := newNodeIDs(schema.Services)
+	serviceToNode, nodes, idToServiceName := buildOverviewNodes(schema, ids)
 	if len(nodes) == 0 {
 		return payload
 	}
@@ -568,10 +695,16 @@ func (t *Target) prepareOverviewDocsPayload(schema domain.Schema, asyncEdges []d
 	edgeSet := make(map[string]OverviewDocsEdge)
 	edgesByService := buildEdgesByServiceMap(asyncEdges)
 
-	processOverviewRelationships(schema, serviceToNode, nodes, edgeSet)
-	processOverviewAsyncEdges(schema, edgesByService, serviceToNode, idToServiceName, edgeSet, t)
+	plannedServices := plannedServiceNames(schema.Services)
+
+	t.highlight.overviewNodes(nodes, serviceToNode)
+	processOverviewRelationships(schema, serviceToNode, nodes, plannedServices, edgeSet, t.highlight)
+	processOverviewAsyncEdges(schema, edgesByService, serviceToNode, idToServiceName, plannedServices, edgeSet, ids, t)
+	dedupEdges(edgeSet, overviewEdgeEndpoints, mergeOverviewEdges)
 
 	buildOverviewPayload(&payload, nodes, edgeSet, globalName)
+	t.labels.overview(payload.Edges)
+	payload.HasHighlighted = overviewHasHighlighted(payload.Nodes, payload.Edges)
 
 	return payload
 }
@@ -586,47 +719,68 @@ func (t *Target) prepareServiceRelationshipsDocsPayload(service domain.Service,
 
 	serviceMaps := buildServiceMaps(allServices)
 	externalNodes := make(map[string]*externalNodeDocs)
-	serviceEdges := buildServiceRelationshipEdges(service, serviceMaps.ServiceNames, externalNodes, asyncEdges, t)
+	serviceEdges := buildServiceRelationshipEdges(service, serviceMaps, externalNodes, asyncEdges, t)
 
 	definedNodes := make(map[string]struct{})
-	defineServiceNode := createServiceNodeDefiner(&payload, definedNodes)
+	defineServiceNode := createServiceNodeDefiner(&payload, definedNodes, serviceMaps.PlannedServices,
+		serviceMaps.NodeIDs)
 
 	defineAllServiceNodes(service, serviceEdges.AsyncEdges, serviceEdges.Edges,
 		serviceMaps.ServiceNames, serviceMaps.ServiceIDs, defineServiceNode)
 	addExternalNodesToPayload(&payload, externalNodes)
 	sortAndConvertEdges(&payload, serviceEdges.Edges)
+	t.labels.serviceRelationships(payload.Edges)
+	payload.HasPlanned = serviceRelationshipsHavePlanned(payload)
 
 	return payload
 }
 
 func buildServiceMaps(allServices []domain.Service) ServiceMaps {
+	ids := newNodeIDs(allServices)
 	serviceNames := make(map[string]struct{}, len(allServices))
 	serviceIDs := make(map[string]string, len(allServices))
 	for _, svc := range allServices {
 		serviceNames[svc.Info.Name] = struct{}{}
-		serviceIDs[serviceNodeID(svc.Info.Name)] = svc.Info.Name
+		serviceIDs[ids.service(svc.Info.Name)] = svc.Info.Name
 	}
 
 	return ServiceMaps{
-		ServiceNames: serviceNames,
-		ServiceIDs:   serviceIDs,
+		ServiceNames:    serviceNames,
+		ServiceIDs:      serviceIDs,
+		PlannedServices: plannedServiceNames(allServices),
+		NodeIDs:         ids,
 	}
 }
 
-func buildServiceRelationshipEdges(service domain.Service, serviceNames map[string]struct{},
+func buildServiceRelationshipEdges(service domain.Service, serviceMaps ServiceMaps,
 	externalNodes map[string]*externalNodeDocs, asyncEdges []domain.AsyncEdge, t *Target) ServiceRelationshipEdges {
 	filteredServices := []domain.Service{service}
-	edges := buildRelationshipEdgesDocs(filteredServices, serviceNames, externalNodes)
+	edgeSet := make(map[string]diagramEdgeDocs)
+
+	for _, edge := range buildRelationshipEdgesDocs(filteredServices, serviceMaps.ServiceNames,
+		serviceMaps.PlannedServices, externalNodes, serviceMaps.NodeIDs) {
+		edgeSet[fmt.Sprintf("%s|%s|%s|rel", edge.From, edge.To, edge.Label)] = edge
+	}
 
 	serviceOnlyEdges := filterAsyncEdgesForService(service.Info.Name, asyncEdges)
-	diagEdges, _ := t.AggregateAsyncEdgesForService(service.Info.Name, serviceOnlyEdges, serviceNames)
+	diagEdges, _ := t.aggregateAsyncEdges(service.Info.Name, serviceOnlyEdges, serviceMaps.ServiceNames,
+		serviceMaps.NodeIDs)
 
 	for _, de := range diagEdges {
-		edges = append(edges, diagramEdgeDocs(de))
+		_, fromPlanned := serviceMaps.PlannedServices[serviceMaps.ServiceIDs[de.From]]
+		_, toPlanned := serviceMaps.PlannedServices[serviceMaps.ServiceIDs[de.To]]
+		edgeSet[fmt.Sprintf("%s|%s|%s|async", de.From, de.To, de.Label)] = diagramEdgeDocs{
+			From:    de.From,
+			To:      de.To,
+			Label:   de.Label,
+			Planned: fromPlanned || toPlanned,
+		}
 	}
 
+	dedupEdges(edgeSet, diagramEdgeEndpoints, mergeDiagramEdges)
+
 	return ServiceRelationshipEdges{
-		Edges:      edges,
+		Edges:      slices.Collect(maps.Values(edgeSet)),
 		AsyncEdges: serviceOnlyEdges,
 	}
 }
@@ -643,16 +797,19 @@ func filterAsyncEdgesForService(serviceName string, asyncEdges []domain.AsyncEdg
 	return serviceOnlyEdges
 }
 
-func createServiceNodeDefiner(payload *ServiceRelationshipsDocsPayload, definedNodes map[string]struct{}) func(string) {
+func createServiceNodeDefiner(payload *ServiceRelationshipsDocsPayload, definedNodes map[string]struct{},
+	plannedServices map[string]struct{}, ids nodeIDs) func(string) {
 	return func(name string) {
-		id := serviceNodeID(name)
+		id := ids.service(name)
 		if _, exists := definedNodes[id]; exists {
 			return
 		}
 		definedNodes[id] = struct{}{}
+		_, planned := plannedServices[name]
 		payload.Services = append(payload.Services, ServiceRelationshipsDocsNode{
-			ID:    id,
-			Label: name,
+			ID:      id,
+			Label:   name,
+			Planned: planned,
 		})
 	}
 }
@@ -700,6 +857,7 @@ func addExternalNodesToPayload(payload *ServiceRelationshipsDocsPayload, externa
 			Tooltip:  tooltip,
 			External: node.external,
 			Person:   node.person,
+			Planned:  node.planned,
 		})
 	}
 }
@@ -752,9 +910,10 @@ func sortAndConvertEdges(payload *ServiceRelationshipsDocsPayload, edges []diagr
 
 func (t *Target) prepareSystemDocsPayload(schema domain.Schema, systemName string,
 	asyncEdges []domain.AsyncEdge) SystemDocsPayload {
+	ids := newNodeIDs(schema.Services)
 	payload := SystemDocsPayload{
 		SystemName:    systemName,
-		SystemID:      sanitizeFilename(systemName),
+		SystemID:      uniqueKey(ids.systems, systemName),
 		SystemNodes:   []SystemDocsNode{},
 		ExternalNodes: []SystemDocsNode{},
 		Edges:         []SystemDocsEdge{},
@@ -765,7 +924,7 @@ func (t *Target) prepareSystemDocsPayload(schema domain.Schema, systemName strin
 		return payload
 	}
 
-	serviceToNode, nodes, idToServiceName := buildSystemNodes(systemServices)
+	serviceToNode, nodes, idToServiceName := buildSystemNodes(systemServices, ids)
 
 	edgeSet := make(map[string]SystemDocsEdge)
 
@@ -776,17 +935,23 @@ func (t *Target) prepareSystemDocsPayload(schema domain.Schema, systemName strin
 		domain.RelationshipActionReceives: {},
 	}
 
-	processSystemRelationships(schema, systemServices, serviceToNode, nodes, edgeSet)
+	processSystemRelationships(schema, systemServices, serviceToNode, nodes, edgeSet, ids, t.highlight)
 
-	processExternalServiceRelationships(schema, systemServices, serviceToNode, nodes, edgeSet, allowedActions)
+	processExternalServiceRelationships(schema, systemServices, serviceToNode, nodes, edgeSet, allowedActions, ids,
+		t.highlight)
 
-	processPersonRelationships(schema, systemServices, serviceToNode, nodes, edgeSet, allowedActions)
+	processPersonRelationships(schema, systemServices, serviceToNode, nodes, edgeSet, allowedActions, t.highlight)
 
-	edgesByService := processSystemAsyncEdges(systemServices, asyncEdges, serviceToNode, idToServiceName, edgeSet, t)
+	edgesByService := processSystemAsyncEdges(systemServices, asyncEdges, serviceToNode, idToServiceName, edgeSet,
+		ids, t)
 
-	processExternalAsyncEdges(schema, systemServices, serviceToNode, nodes, edgeSet, edgesByService)
+	processExternalAsyncEdges(schema, systemServices, serviceToNode, nodes, edgeSet, edgesByService, ids)
+	dedupEdges(edgeSet, systemEdgeEndpoints, mergeSystemEdges)
 
-	buildSystemPayload(&payload, nodes, edgeSet, systemServices)
+	t.highlight.systemNodes(nodes)
+	buildSystemPayload(&payload, nodes, edgeSet, systemServices, ids)
+	t.labels.system(payload.Edges)
+	payload.HasHighlighted = systemHasHighlighted(payload)
 
 	return payload
 }
@@ -800,23 +965,31 @@ type externalNodeDocs struct {
 	descriptions map[string]struct{}
 	external     bool
 	person       bool
+	planned      bool
 }
 
 type diagramEdgeDocs struct {
-	From  string
-	To    string
-	Label string
+	From        string
+	To          string
+	Label       string
+	Technology  string
+	Proto       string
+	Criticality domain.Criticality
+	Access      domain.Access
+	Planned     bool
 }
 
 func buildRelationshipEdgesDocs(
 	services []domain.Service,
 	serviceNames map[string]struct{},
+	plannedServices map[string]struct{},
 	externalNodes map[string]*externalNodeDocs,
+	ids nodeIDs,
 ) []diagramEdgeDocs {
 	edgeSet := make(map[string]diagramEdgeDocs)
 
 	for _, service := range services {
-		processServiceRelationships(service, serviceNames, externalNodes, edgeSet)
+		processServiceRelationships(service, serviceNames, plannedServices, externalNodes, edgeSet, ids)
 	}
 
 	edges := make([]diagramEdgeDocs, 0, len(edgeSet))
@@ -830,10 +1003,12 @@ func buildRelationshipEdgesDocs(
 func processServiceRelationships(
 	service domain.Service,
 	serviceNames map[string]struct{},
+	plannedServices map[string]struct{},
 	externalNodes map[string]*externalNodeDocs,
 	edgeSet map[string]diagramEdgeDocs,
+	ids nodeIDs,
 ) {
-	serviceID := serviceNodeID(service.Info.Name)
+	serviceID := ids.service(service.Info.Name)
 
 	for _, rel := range service.Relationships {
 		targetName := strings.TrimSpace(rel.Participant)
@@ -841,35 +1016,45 @@ func processServiceRelationships(
 			continue
 		}
 
-		label := string(rel.Action)
-		if rel.Action == domain.RelationshipActionReplies {
-			label = requestsLabel
-		}
+		label := relationshipLabel(rel)
+
+		planned := isPlannedRelationship(service, rel, plannedServices)
 
 		if _, exists := serviceNames[targetName]; exists {
-			processServiceToServiceEdge(serviceID, targetName, label, rel, edgeSet)
+			processServiceToServiceEdge(serviceID, ids.service(targetName), label, rel, planned, edgeSet)
 
 			continue
 		}
 
-		processServiceToExternalEdge(serviceID, targetName, rel, externalNodes, edgeSet, label)
+		processServiceToExternalEdge(serviceID, targetName, rel, planned, externalNodes, edgeSet, label)
 	}
 }
 
 func processServiceToServiceEdge(
-	serviceID, targetName, label string,
+	serviceID, targetID, label string,
 	rel domain.Relationship,
+	planned bool,
 	edgeSet map[string]diagramEdgeDocs,
 ) {
-	targetID := serviceNodeID(targetName)
 	from, to := orientedEdge(serviceID, targetID, rel.Action)
 	key := fmt.Sprintf("%s|%s|%s", from, to, label)
-	edgeSet[key] = diagramEdgeDocs{From: from, To: to, Label: label}
+	existing, exists := edgeSet[key]
+	edgeSet[key] = diagramEdgeDocs{
+		From:        from,
+		To:          to,
+		Label:       label,
+		Technology:  joinedValue(existing.Technology, rel.Technology),
+		Proto:       joinedValue(existing.Proto, rel.Proto),
+		Criticality: domain.MaxCriticality(existing.Criticality, rel.Criticality),
+		Access:      domain.CombineAccess(existing.Access, rel.Access),
+		Planned:     plannedEdge(planned, exists, existing.Planned),
+	}
 }
 
 func processServiceToExternalEdge(
 	serviceID, targetName string,
 	rel domain.Relationship,
+	planned bool,
 	externalNodes map[string]*externalNodeDocs,
 	edgeSet map[string]diagramEdgeDocs,
 	label string,
@@ -882,6 +1067,7 @@ func processServiceToExternalEdge(
 			name:         targetName,
 			technologies: make(map[string]string),
 			descriptions: make(map[string]struct{}),
+			planned:      true,
 		}
 		externalNodes[nodeID] = node
 	}
@@ -890,7 +1076,17 @@ func processServiceToExternalEdge(
 
 	from, to := orientedEdge(serviceID, nodeID, rel.Action)
 	key := fmt.Sprintf("%s|%s|%s", from, to, label)
-	edgeSet[key] = diagramEdgeDocs{From: from, To: to, Label: label}
+	existing, exists := edgeSet[key]
+	edgeSet[key] = diagramEdgeDocs{
+		From:        from,
+		To:          to,
+		Label:       label,
+		Technology:  joinedValue(existing.Technology, rel.Technology),
+		Proto:       joinedValue(existing.Proto, rel.Proto),
+		Criticality: domain.MaxCriticality(existing.Criticality, rel.Criticality),
+		Access:      domain.CombineAccess(existing.Access, rel.Access),
+		Planned:     plannedEdge(planned, exists, existing.Planned),
+	}
 }
 
 func updateExternalNode(node *externalNodeDocs, rel domain.Relationship) {
@@ -907,11 +1103,21 @@ func updateExternalNode(node *externalNodeDocs, rel domain.Relationship) {
 	if rel.Person {
 		node.person = true
 	}
+	if !rel.Planned {
+		node.planned = false
+	}
 }
 
 // AggregateAsyncEdgesForService aggregates async edges for a service and returns diagram edges and summaries.
 func (t *Target) AggregateAsyncEdgesForService(serviceName string, asyncEdges []domain.AsyncEdge,
 	serviceNames map[string]struct{}) ([]DiagramEdge, []AsyncSummary) {
+	ids := nodeIDs{services: domain.UniqueKeys(slices.Collect(maps.Keys(serviceNames)), sanitizeFilename)}
+
+	return t.aggregateAsyncEdges(serviceName, asyncEdges, serviceNames, ids)
+}
+
+func (t *Target) aggregateAsyncEdges(serviceName string, asyncEdges []domain.AsyncEdge,
+	serviceNames map[string]struct{}, ids nodeIDs) ([]DiagramEdge, []AsyncSummary) {
 	summaries := make(map[string]*edgeSummary)
 
 	ensureSummary := func(other string) *edgeSummary {
@@ -933,12 +1139,12 @@ func (t *Target) AggregateAsyncEdgesForService(serviceName string, asyncEdges []
 		processEdgeForService(edge, serviceName, serviceNames, summaries, ensureSummary)
 	}
 
-	mainID := serviceNodeID(serviceName)
+	mainID := ids.service(serviceName)
 	diagramEdges := make([]DiagramEdge, 0, len(summaries)*mapCapacityMultiplier)
 	textSummaries := make([]AsyncSummary, 0, len(summaries)*mapCapacityMultiplier)
 
 	for other, summary := range summaries {
-		otherID := serviceNodeID(other)
+		otherID := ids.service(other)
 
 		if len(summary.outSend) > 0 {
 			if label := deriveAsyncLabel(summary.outSend, summary.outReply); label != "" {
@@ -1014,41 +1220,46 @@ func processIncomingEdge(edge domain.AsyncEdge, serviceName string, serviceNames
 	}
 }
 
-func buildOverviewNodes(schema domain.Schema) (map[string]OverviewDocsNode,
+func buildOverviewNodes(schema domain.Schema, ids nodeIDs) (map[string]OverviewDocsNode,
 	map[string]OverviewDocsNode, map[string]string) {
 	serviceToNode := make(map[string]OverviewDocsNode)
 	nodes := make(map[string]OverviewDocsNode)
 	idToServiceName := make(map[string]string, len(schema.Services))
 
 	for _, service := range schema.Services {
-		idToServiceName[serviceNodeID(service.Info.Name)] = service.Info.Name
+		idToServiceName[ids.service(service.Info.Name)] = service.Info.Name
 	}
 
 	for _, service := range schema.Services {
 		systemName := strings.TrimSpace(service.Info.System)
 		if systemName != "" {
-			nodeID := systemNodeID(systemName)
+			nodeID := ids.system(systemName)
 			node, exists := nodes[nodeID]
 			if !exists {
 				node = OverviewDocsNode{
 					ID:       nodeID,
 					Label:    systemName,
 					Internal: true, // Systems are internal
+					Planned:  true, // Until a service that is not planned joins the system
 				}
-				nodes[nodeID] = node
 			}
+			if !service.Info.Planned {
+				node.Planned = false
+			}
+			nodes[nodeID] = node
 			serviceToNode[service.Info.Name] = node
 
 			continue
 		}
 
-		nodeID := serviceNodeID(service.Info.Name)
+		nodeID := ids.service(service.Info.Name)
 		content := buildOverviewNodeContent(service.Info)
 		node := OverviewDocsNode{
 			ID:       nodeID,
 			Label:    service.Info.Name,
 			Content:  content,
 			Internal: true,
+			Planned:  service.Info.Planned,
 		}
 		nodes[nodeID] = node
 		serviceToNode[service.Info.Name] = node
@@ -1068,7 +1279,8 @@ func buildEdgesByServiceMap(asyncEdges []domain.AsyncEdge) map[string][]domain.A
 }
 
 func processOverviewRelationships(schema domain.Schema, serviceToNode map[string]OverviewDocsNode,
-	nodes map[string]OverviewDocsNode, edgeSet map[string]OverviewDocsEdge) {
+	nodes map[string]OverviewDocsNode, plannedServices map[string]struct{}, edgeSet map[string]OverviewDocsEdge,
+	highlight highlighter) {
 	allowedActions := map[domain.RelationshipAction]struct{}{
 		domain.RelationshipActionRequests: {},
 		domain.RelationshipActionReplies:  {},
@@ -1087,35 +1299,41 @@ func processOverviewRelationships(schema domain.Schema, serviceToNode map[string
 				continue
 			}
 
-			tgtNode := getOrCreateTargetNode(rel, serviceToNode, nodes)
-			processOverviewEdge(srcNode, tgtNode, rel, edgeSet)
+			tgtNode := getOrCreateTargetNode(rel, serviceToNode, nodes, highlight)
+			planned := isPlannedRelationship(service, rel, plannedServices)
+			processOverviewEdge(srcNode, tgtNode, rel, planned, highlight.relationship(rel), edgeSet)
 		}
 	}
 }
 
 func getOrCreateTargetNode(rel domain.Relationship, serviceToNode map[string]OverviewDocsNode,
-	nodes map[string]OverviewDocsNode) OverviewDocsNode {
+	nodes map[string]OverviewDocsNode, highlight highlighter) OverviewDocsNode {
 	tgtNode, ok := serviceToNode[rel.Participant]
 	if !ok {
 		nodeID := externalNodeID(rel.Participant)
 		node, exists := nodes[nodeID]
 		if !exists {
 			node = OverviewDocsNode{
-				ID:       nodeID,
-				Label:    rel.Participant,
-				External: true,       // Both persons and external services are external
-				Person:   rel.Person, // Mark as person if it's a person
-				Content:  FormatOverviewDescription(rel.Description),
+				ID:          nodeID,
+				Label:       rel.Participant,
+				External:    true,       // Both persons and external services are external
+				Person:      rel.Person, // Mark as person if it's a person
+				Planned:     rel.Planned,
+				Highlighted: highlight.service(rel.Participant),
+				Content:     FormatOverviewDescription(rel.Description),
 			}
-			nodes[nodeID] = node
 		}
+		if !rel.Planned {
+			node.Planned = false
+		}
+		nodes[nodeID] = node
 		tgtNode = node
 	}
 
 	return tgtNode
 }
 
-func processOverviewEdge(srcNode, tgtNode OverviewDocsNode, rel domain.Relationship,
+func processOverviewEdge(srcNode, tgtNode OverviewDocsNode, rel domain.Relationship, planned, highlighted bool,
 	edgeSet map[string]OverviewDocsEdge) {
 	from, to := orientedEdge(srcNode.ID, tgtNode.ID, rel.Action)
 	if from == to {
@@ -1144,32 +1362,40 @@ func processOverviewEdge(srcNode, tgtNode OverviewDocsNode, rel domain.Relations
 		toID = "internal." + to
 	}
 
-	label := string(rel.Action)
-	if rel.Action == domain.RelationshipActionReplies {
-		label = requestsLabel
-	}
+	label := relationshipLabel(rel)
 	key := fmt.Sprintf("%s|%s|%s|rel", fromID, toID, label)
-	edgeSet[key] = OverviewDocsEdge{From: fromID, To: toID, Label: label}
+	existing, exists := edgeSet[key]
+	edgeSet[key] = OverviewDocsEdge{
+		From:        fromID,
+		To:          toID,
+		Label:       label,
+		Technology:  joinedValue(existing.Technology, rel.Technology),
+		Proto:       joinedValue(existing.Proto, rel.Proto),
+		Criticality: domain.MaxCriticality(existing.Criticality, rel.Criticality),
+		Access:      domain.CombineAccess(existing.Access, rel.Access),
+		Planned:     plannedEdge(planned, exists, existing.Planned),
+		Highlighted: highlighted || existing.Highlighted,
+	}
 }
 
 func processOverviewAsyncEdges(schema domain.Schema, edgesByService map[string][]domain.AsyncEdge,
 	serviceToNode map[string]OverviewDocsNode, idToServiceName map[string]string,
-	edgeSet map[string]OverviewDocsEdge, t *Target) {
+	plannedServices map[string]struct{}, edgeSet map[string]OverviewDocsEdge, ids nodeIDs, t *Target) {
 	serviceNames := make(map[string]struct{}, len(schema.Services))
 	for _, svc := range schema.Services {
 		serviceNames[svc.Info.Name] = struct{}{}
 	}
 
 	for _, service := range schema.Services {
-		diagEdges, _ := t.AggregateAsyncEdgesForService(service.Info.Name, edgesByService[service.Info.Name], serviceNames)
+		diagEdges, _ := t.aggregateAsyncEdges(service.Info.Name, edgesByService[service.Info.Name], serviceNames, ids)
 		for _, de := range diagEdges {
-			processAsyncEdge(de, serviceToNode, idToServiceName, edgeSet)
+			processAsyncEdge(de, serviceToNode, idToServiceName, plannedServices, edgeSet)
 		}
 	}
 }
 
 func processAsyncEdge(de DiagramEdge, serviceToNode map[string]OverviewDocsNode,
-	idToServiceName map[string]string, edgeSet map[string]OverviewDocsEdge) {
+	idToServiceName map[string]string, plannedServices map[string]struct{}, edgeSet map[string]OverviewDocsEdge) {
 	fromName, ok := idToServiceName[de.From]
 	if !ok {
 		return
@@ -1202,8 +1428,17 @@ func processAsyncEdge(de DiagramEdge, serviceToNode map[string]OverviewDocsNode,
 		toID = "internal." + toNode.ID
 	}
 
+	_, fromPlanned := plannedServices[fromName]
+	_, toPlanned := plannedServices[toName]
+
 	key := fmt.Sprintf("%s|%s|%s|async", fromID, toID, de.Label)
-	edgeSet[key] = OverviewDocsEdge{From: fromID, To: toID, Label: de.Label}
+	existing, exists := edgeSet[key]
+	edgeSet[key] = OverviewDocsEdge{
+		From:    fromID,
+		To:      toID,
+		Label:   de.Label,
+		Planned: plannedEdge(fromPlanned || toPlanned, exists, existing.Planned),
+	}
 }
 
 func findSystemServices(schema domain.Schema, systemName string) []domain.Service {
@@ -1217,23 +1452,24 @@ func findSystemServices(schema domain.Schema, systemName string) []domain.Servic
 	return systemServices
 }
 
-func buildSystemNodes(systemServices []domain.Service) (map[string]SystemDocsNode,
+func buildSystemNodes(systemServices []domain.Service, ids nodeIDs) (map[string]SystemDocsNode,
 	map[string]SystemDocsNode, map[string]string) {
 	serviceToNode := make(map[string]SystemDocsNode)
 	nodes := make(map[string]SystemDocsNode)
 	idToServiceName := make(map[string]string, len(systemServices))
 
 	for _, service := range systemServices {
-		idToServiceName[serviceNodeID(service.Info.Name)] = service.Info.Name
+		idToServiceName[ids.service(service.Info.Name)] = service.Info.Name
 	}
 
 	for _, service := range systemServices {
-		nodeID := serviceNodeID(service.Info.Name)
+		nodeID := ids.service(service.Info.Name)
 		content := buildOverviewNodeContent(service.Info)
 		node := SystemDocsNode{
 			ID:      nodeID,
 			Label:   service.Info.Name,
 			Content: content,
+			Planned: service.Info.Planned,
 		}
 		nodes[nodeID] = node
 		serviceToNode[service.Info.Name] = node
@@ -1243,7 +1479,8 @@ func buildSystemNodes(systemServices []domain.Service) (map[string]SystemDocsNod
 }
 
 func processSystemRelationships(schema domain.Schema, systemServices []domain.Service,
-	serviceToNode map[string]SystemDocsNode, nodes map[string]SystemDocsNode, edgeSet map[string]SystemDocsEdge) {
+	serviceToNode map[string]SystemDocsNode, nodes map[string]SystemDocsNode, edgeSet map[string]SystemDocsEdge,
+	ids nodeIDs, highlight highlighter) {
 	allowedActions := map[domain.RelationshipAction]struct{}{
 		domain.RelationshipActionRequests: {},
 		domain.RelationshipActionReplies:  {},
@@ -1262,19 +1499,19 @@ func processSystemRelationships(schema domain.Schema, systemServices []domain.Se
 				continue
 			}
 
-			tgtNode, found := findSystemTargetNode(rel, systemServices, serviceToNode, schema, nodes)
+			tgtNode, found := findSystemTargetNode(rel, systemServices, serviceToNode, schema, nodes, ids)
 			if !found {
 				continue
 			}
 
-			processSystemEdge(srcNode, tgtNode, rel, edgeSet)
+			processSystemEdge(srcNode, tgtNode, rel, highlight.relationship(rel), edgeSet)
 		}
 	}
 }
 
 func findSystemTargetNode(rel domain.Relationship, systemServices []domain.Service,
 	serviceToNode map[string]SystemDocsNode, schema domain.Schema,
-	nodes map[string]SystemDocsNode) (SystemDocsNode, bool) {
+	nodes map[string]SystemDocsNode, ids nodeIDs) (SystemDocsNode, bool) {
 	// Check if the target service is also in this system
 	for _, targetService := range systemServices {
 		if targetService.Info.Name == rel.Participant {
@@ -1290,7 +1527,7 @@ func findSystemTargetNode(rel domain.Relationship, systemServices []domain.Servi
 	}
 
 	// Check if this is a service from another system
-	return getOrCreateOtherSystemNode(rel, schema, nodes)
+	return getOrCreateOtherSystemNode(rel, schema, nodes, ids)
 }
 
 func getOrCreateExternalNode(rel domain.Relationship, nodes map[string]SystemDocsNode) (SystemDocsNode, bool) {
@@ -1303,18 +1540,22 @@ func getOrCreateExternalNode(rel domain.Relationship, nodes map[string]SystemDoc
 			Content:  FormatOverviewDescription(rel.Description),
 			External: true,
 			Person:   rel.Person,
+			Planned:  rel.Planned,
 		}
-		nodes[nodeID] = node
 	}
+	if !rel.Planned {
+		node.Planned = false
+	}
+	nodes[nodeID] = node
 
 	return node, true
 }
 
 func getOrCreateOtherSystemNode(rel domain.Relationship, schema domain.Schema,
-	nodes map[string]SystemDocsNode) (SystemDocsNode, bool) {
+	nodes map[string]SystemDocsNode, ids nodeIDs) (SystemDocsNode, bool) {
 	for _, otherService := range schema.Services {
 		if otherService.Info.Name == rel.Participant {
-			nodeID := serviceNodeID(rel.Participant)
+			nodeID := ids.service(rel.Participant)
 			node, exists := nodes[nodeID]
 			if !exists {
 				content := buildOverviewNodeContent(otherService.Info)
@@ -1324,6 +1565,7 @@ func getOrCreateOtherSystemNode(rel domain.Relationship, schema domain.Schema,
 					Content:  content,
 					External: false, // This is a service from another system, not external
 					Person:   false,
+					Planned:  otherService.Info.Planned,
 				}
 				nodes[nodeID] = node
 			}
@@ -1335,29 +1577,41 @@ func getOrCreateOtherSystemNode(rel domain.Relationship, schema domain.Schema,
 	return SystemDocsNode{}, false
 }
 
-func processSystemEdge(srcNode, tgtNode SystemDocsNode, rel domain.Relationship, edgeSet map[string]SystemDocsEdge) {
+func processSystemEdge(srcNode, tgtNode SystemDocsNode, rel domain.Relationship, highlighted bool,
+	edgeSet map[string]SystemDocsEdge) {
 	from, to := orientedEdge(srcNode.ID, tgtNode.ID, rel.Action)
 	if from == to {
 		return
 	}
 
-	label := string(rel.Action)
-	if rel.Action == domain.RelationshipActionReplies {
-		label = requestsLabel
-	}
+	label := relationshipLabel(rel)
 	key := fmt.Sprintf("%s|%s|%s|rel", from, to, label)
-	edgeSet[key] = SystemDocsEdge{From: from, To: to, Label: label}
+	existing, exists := edgeSet[key]
+	planned := rel.Planned || srcNode.Planned || tgtNode.Planned
+	edgeSet[key] = SystemDocsEdge{
+		From:        from,
+		To:          to,
+		Label:       label,
+		Technology:  joinedValue(existing.Technology, rel.Technology),
+		Proto:       joinedValue(existing.Proto, rel.Proto),
+		Criticality: domain.MaxCriticality(existing.Criticality, rel.Criticality),
+		Access:      domain.CombineAccess(existing.Access, rel.Access),
+		Planned:     plannedEdge(planned, exists, existing.Planned),
+		Highlighted: highlighted || existing.Highlighted,
+	}
 }
 
 func processExternalServiceRelationships(schema domain.Schema, systemServices []domain.Service,
 	serviceToNode map[string]SystemDocsNode, nodes map[string]SystemDocsNode,
-	edgeSet map[string]SystemDocsEdge, allowedActions map[domain.RelationshipAction]struct{}) {
+	edgeSet map[string]SystemDocsEdge, allowedActions map[domain.RelationshipAction]struct{}, ids nodeIDs,
+	highlight highlighter) {
 	for _, otherService := range schema.Services {
 		if isServiceInSystem(otherService, systemServices) {
 			continue
 		}
 
-		processOtherServiceRelationships(otherService, systemServices, serviceToNode, nodes, edgeSet, allowedActions)
+		processOtherServiceRelationships(otherService, systemServices, serviceToNode, nodes, edgeSet, allowedActions,
+			ids, highlight)
 	}
 }
 
@@ -1373,7 +1627,8 @@ func isServiceInSystem(service domain.Service, systemServices []domain.Service)
 
 func processOtherServiceRelationships(otherService domain.Service, systemServices []domain.Service,
 	serviceToNode map[string]SystemDocsNode, nodes map[string]SystemDocsNode,
-	edgeSet map[string]SystemDocsEdge, allowedActions map[domain.RelationshipAction]struct{}) {
+	edgeSet map[string]SystemDocsEdge, allowedActions map[domain.RelationshipAction]struct{}, ids nodeIDs,
+	highlight highlighter) {
 	for _, rel := range otherService.Relationships {
 		if _, allowed := allowedActions[rel.Action]; !allowed {
 			continue
@@ -1384,8 +1639,8 @@ func processOtherServiceRelationships(otherService domain.Service, systemService
 			continue
 		}
 
-		srcNode := getOrCreateSourceNode(otherService, nodes)
-		processSystemEdge(srcNode, tgtNode, rel, edgeSet)
+		srcNode := getOrCreateSourceNode(otherService, nodes, ids)
+		processSystemEdge(srcNode, tgtNode, rel, highlight.relationship(rel), edgeSet)
 	}
 }
 
@@ -1402,8 +1657,9 @@ func findTargetInSystem(rel domain.Relationship, systemServices []domain.Service
 	return SystemDocsNode{}, false
 }
 
-func getOrCreateSourceNode(otherService domain.Service, nodes map[string]SystemDocsNode) SystemDocsNode {
-	srcNodeID := serviceNodeID(otherService.Info.Name)
+func getOrCreateSourceNode(otherService domain.Service, nodes map[string]SystemDocsNode,
+	ids nodeIDs) SystemDocsNode {
+	srcNodeID := ids.service(otherService.Info.Name)
 	srcNode, exists := nodes[srcNodeID]
 	if !exists {
 		content := buildOverviewNodeContent(otherService.Info)
@@ -1413,6 +1669,7 @@ func getOrCreateSourceNode(otherService domain.Service, nodes map[string]SystemD
 			Content:  content,
 			External: false, // This is a service from another system, not external
 			Person:   false,
+			Planned:  otherService.Info.Planned,
 		}
 		nodes[srcNodeID] = srcNode
 	}
@@ -1422,19 +1679,19 @@ func getOrCreateSourceNode(otherService domain.Service, nodes map[string]SystemD
 
 func processPersonRelationships(schema domain.Schema, systemServices []domain.Service,
 	serviceToNode map[string]SystemDocsNode, nodes map[string]SystemDocsNode,
-	edgeSet map[string]SystemDocsEdge, allowedActions map[domain.RelationshipAction]struct{}) {
+	edgeSet map[string]SystemDocsEdge, allowedActions map[domain.RelationshipAction]struct{}, highlight highlighter) {
 	for _, service := range schema.Services {
 		if !isServiceInSystem(service, systemServices) {
 			continue
 		}
 
-		processServicePersonRelationships(service, serviceToNode, nodes, edgeSet, allowedActions)
+		processServicePersonRelationships(service, serviceToNode, nodes, edgeSet, allowedActions, highlight)
 	}
 }
 
 func processServicePersonRelationships(service domain.Service, serviceToNode map[string]SystemDocsNode,
 	nodes map[string]SystemDocsNode, edgeSet map[string]SystemDocsEdge,
-	allowedActions map[domain.RelationshipAction]struct{}) {
+	allowedActions map[domain.RelationshipAction]struct{}, highlight highlighter) {
 	for _, rel := range service.Relationships {
 		if _, allowed := allowedActions[rel.Action]; !allowed {
 			continue
@@ -1450,7 +1707,7 @@ func processServicePersonRelationships(service domain.Service, serviceToNode map
 			continue
 		}
 
-		processSystemEdge(srcNode, node, rel, edgeSet)
+		processSystemEdge(srcNode, node, rel, highlight.relationship(rel), edgeSet)
 	}
 }
 
@@ -1464,16 +1721,20 @@ func getOrCreatePersonNode(rel domain.Relationship, nodes map[string]SystemDocsN
 			Content:  FormatOverviewDescription(rel.Description),
 			External: true,
 			Person:   true,
+			Planned:  rel.Planned,
 		}
-		nodes[nodeID] = node
 	}
+	if !rel.Planned {
+		node.Planned = false
+	}
+	nodes[nodeID] = node
 
 	return node
 }
 
 func processSystemAsyncEdges(systemServices []domain.Service, asyncEdges []domain.AsyncEdge,
 	serviceToNode map[string]SystemDocsNode, idToServiceName map[string]string,
-	edgeSet map[string]SystemDocsEdge, t *Target) map[string][]domain.AsyncEdge {
+	edgeSet map[string]SystemDocsEdge, ids nodeIDs, t *Target) map[string][]domain.AsyncEdge {
 	serviceNames := make(map[string]struct{}, len(systemServices))
 	for _, svc := range systemServices {
 		serviceNames[svc.Info.Name] = struct{}{}
@@ -1482,7 +1743,7 @@ func processSystemAsyncEdges(systemServices []domain.Service, asyncEdges []domai
 	edgesByService := buildEdgesByServiceMap(asyncEdges)
 
 	for _, service := range systemServices {
-		diagEdges, _ := t.AggregateAsyncEdgesForService(service.Info.Name, edgesByService[service.Info.Name], serviceNames)
+		diagEdges, _ := t.aggregateAsyncEdges(service.Info.Name, edgesByService[service.Info.Name], serviceNames, ids)
 		for _, de := range diagEdges {
 			processSystemAsyncEdge(de, idToServiceName, serviceToNode, edgeSet)
 		}
@@ -1516,37 +1777,50 @@ func processSystemAsyncEdge(de DiagramEdge, idToServiceName map[string]string,
 	}
 
 	key := fmt.Sprintf("%s|%s|%s|async", fromNode.ID, toNode.ID, de.Label)
-	edgeSet[key] = SystemDocsEdge{From: fromNode.ID, To: toNode.ID, Label: de.Label}
+	existing, exists := edgeSet[key]
+	edgeSet[key] = SystemDocsEdge{
+		From:    fromNode.ID,
+		To:      toNode.ID,
+		Label:   de.Label,
+		Planned: plannedEdge(fromNode.Planned || toNode.Planned, exists, existing.Planned),
+	}
 }
 
 func processExternalAsyncEdges(schema domain.Schema, systemServices []domain.Service,
 	serviceToNode map[string]SystemDocsNode, nodes map[string]SystemDocsNode,
-	edgeSet map[string]SystemDocsEdge, edgesByService map[string][]domain.AsyncEdge) {
+	edgeSet map[string]SystemDocsEdge, edgesByService map[string][]domain.AsyncEdge, ids nodeIDs) {
 	for _, otherService := range schema.Services {
 		if isServiceInSystem(otherService, systemServices) {
 			continue
 		}
 
-		processOtherServiceAsyncEdges(otherService, systemServices, serviceToNode, nodes, edgeSet, edgesByService)
+		processOtherServiceAsyncEdges(otherService, systemServices, serviceToNode, nodes, edgeSet, edgesByService,
+			ids)
 	}
 }
 
 func processOtherServiceAsyncEdges(otherService domain.Service, systemServices []domain.Service,
 	serviceToNode map[string]SystemDocsNode, nodes map[string]SystemDocsNode,
-	edgeSet map[string]SystemDocsEdge, edgesByService map[string][]domain.AsyncEdge) {
+	edgeSet map[string]SystemDocsEdge, edgesByService map[string][]domain.AsyncEdge, ids nodeIDs) {
 	for _, edge := range edgesByService[otherService.Info.Name] {
 		tgtNode, found := findTargetInSystemByEdge(edge, systemServices, serviceToNode)
 		if !found {
 			continue
 		}
 
-		srcNode := getOrCreateSourceNode(otherService, nodes)
+		srcNode := getOrCreateSourceNode(otherService, nodes, ids)
 		if srcNode.ID == tgtNode.ID {
 			continue
 		}
 
 		key := fmt.Sprintf("%s|%s|%s|async", srcNode.ID, tgtNode.ID, edge.Kind)
-		edgeSet[key] = SystemDocsEdge{From: srcNode.ID, To: tgtNode.ID, Label: edge.Kind}
+		existing, exists := edgeSet[key]
+		edgeSet[key] = SystemDocsEdge{
+			From:    srcNode.ID,
+			To:      tgtNode.ID,
+			Label:   edge.Kind,
+			Planned: plannedEdge(srcNode.Planned || tgtNode.Planned, exists, existing.Planned),
+		}
 	}
 }
 
@@ -1615,6 +1889,7 @@ func buildOverviewPayload(payload *OverviewDocsPayload, nodes map[string]Overvie
 	payload.Nodes = nodeOrder
 	payload.Edges = edges
 	payload.HasInternalServices = hasInternalServices
+	payload.HasPlanned = overviewHasPlanned(nodeOrder, edges)
 
 	if globalName == "" {
 		payload.GlobalName = "Internal Services"
@@ -1624,7 +1899,7 @@ func buildOverviewPayload(payload *OverviewDocsPayload, nodes map[string]Overvie
 }
 
 func buildSystemPayload(payload *SystemDocsPayload, nodes map[string]SystemDocsNode,
-	edgeSet map[string]SystemDocsEdge, systemServices []domain.Service) {
+	edgeSet map[string]SystemDocsEdge, systemServices []domain.Service, ids nodeIDs) {
 	// Separate system nodes from external nodes
 	systemNodeOrder := make([]SystemDocsNode, 0)
 	externalNodeOrder := make([]SystemDocsNode, 0)
@@ -1633,7 +1908,7 @@ func buildSystemPayload(payload *SystemDocsPayload, nodes map[string]SystemDocsN
 		// Check if this node belongs to the system
 		isSystemNode := false
 		for _, systemService := range systemServices {
-			if serviceNodeID(systemService.Info.Name) == node.ID {
+			if ids.service(systemService.Info.Name) == node.ID {
 				isSystemNode = true
 
 				break
@@ -1676,6 +1951,25 @@ func buildSystemPayload(payload *SystemDocsPayload, nodes map[string]SystemDocsN
 	payload.SystemNodes = systemNodeOrder
 	payload.ExternalNodes = externalNodeOrder
 	payload.Edges = edges
+	payload.HasPlanned = systemHasPlanned(*payload)
+}
+
+// relationshipLabel returns the default label of the edge of a relationship, its datastore access when set.
+func relationshipLabel(rel domain.Relationship) string {
+	switch rel.Access {
+	case domain.AccessRead:
+		return readsLabel
+	case domain.AccessWrite:
+		return writesLabel
+	case domain.AccessReadWrite:
+		return readWritesLabel
+	}
+
+	if rel.Action == domain.RelationshipActionReplies {
+		return requestsLabel
+	}
+
+	return string(rel.Action)
 }
 
 func orientedEdge(sourceID, targetID string, action domain.RelationshipAction) (string, string) {
@@ -0,0 +1,124 @@
+package d2
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/require"
+)
+
+const benchmarkSystems = 20
+
+// benchmarkSchema builds a schema of services spread over systems, each requesting a few neighbours and
+// publishing events consumed by the next service.
+func benchmarkSchema(services, relationships int) (domain.Schema, []domain.AsyncEdge) {
+	schema := domain.Schema{Services: make([]domain.Service, 0, services)}
+	asyncEdges := make([]domain.AsyncEdge, 0, services)
+
+	for i := range services {
+		rels := make([]domain.Relationship, 0, relationships+1)
+		for j := range relationships {
+			rels = append(rels, domain.Relationship{
+				Action:      domain.RelationshipActionRequests,
+				Participant: fmt.Sprintf("Service %05d", (i+j+1)%services),
+				Technology:  "grpc",
+			})
+		}
+		rels = append(rels, domain.Relationship{
+			Action:      domain.RelationshipActionUses,
+			Participant: fmt.Sprintf("Database %02d", i%benchmarkSystems),
+			Technology:  "postgres",
+			External:    true,
+		})
+
+		schema.Services = append(schema.Services, domain.Service{
+			Info: domain.ServiceInfo{
+				Name:   fmt.Sprintf("Service %05d", i),
+				System: fmt.Sprintf("System %02d", i%benchmarkSystems),
+			},
+			Relationships: rels,
+		})
+
+		asyncEdges = append(asyncEdges, domain.AsyncEdge{
+			Source:  fmt.Sprintf("Service %05d", i),
+			Target:  fmt.Sprintf("Service %05d", (i+1)%services),
+			Channel: fmt.Sprintf("service.%05d.events", i),
+			Kind:    asyncOpSend,
+		})
+	}
+
+	return schema, asyncEdges
+}
+
+func newBenchmarkTarget(b *testing.B) *Target {
+	b.Helper()
+
+	target, err := NewTarget(config.D2Config{Pad: 64, Font: "SourceSansPro", Layout: "elk"})
+	require.NoError(b, err)
+
+	return target
+}
+
+func BenchmarkPrepareOverviewDocsPayload(b *testing.B) {
+	target := newBenchmarkTarget(b)
+
+	for _, services := range []int{100, 1000} {
+		schema, asyncEdges := benchmarkSchema(services, 5)
+
+		b.Run(fmt.Sprintf("services=%d", services), func(b *testing.B) {
+			b.ReportAllocs()
+
+			for range b.N {
+				target.prepareOverviewDocsPayload(schema, asyncEdges, "Global")
+			}
+		})
+	}
+}
+
+func BenchmarkPrepareSystemDocsPayload(b *testing.B) {
+	target := newBenchmarkTarget(b)
+
+	for _, services := range []int{100, 1000} {
+		schema, asyncEdges := benchmarkSchema(services, 5)
+
+		b.Run(fmt.Sprintf("services=%d", services), func(b *testing.B) {
+			b.ReportAllocs()
+
+			for range b.N {
+				target.prepareSystemDocsPayload(schema, "System 00", asyncEdges)
+			}
+		})
+	}
+}
+
+func BenchmarkPrepareServiceRelationshipsDocsPayload(b *testing.B) {
+	target := newBenchmarkTarget(b)
+
+	for _, services := range []int{100, 1000} {
+		schema, asyncEdges := benchmarkSchema(services, 5)
+
+		b.Run(fmt.Sprintf("services=%d", services), func(b *testing.B) {
+			b.ReportAllocs()
+
+			for range b.N {
+				for _, service := range schema.Services {
+					target.prepareServiceRelationshipsDocsPayload(service, schema.Services, asyncEdges)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkGenerateOverviewDiagramScript(b *testing.B) {
+	target := newBenchmarkTarget(b)
+	schema, asyncEdges := benchmarkSchema(1000, 5)
+
+	b.ReportAllocs()
+
+	for range b.N {
+		_, err := target.GenerateOverviewDiagramScript(schema, asyncEdges, "Global")
+		require.NoError(b, err)
+	}
+}
@@ -166,6 +166,20 @@ func TestTarget_RenderSchema_WrongType(t *testing.T) {
 	assert.Nil(t, result)
 }
 
+func TestTarget_RenderSchema_Canceled(t *testing.T) {
+	t.Parallel()
+
+	target, err := NewTarget(config.D2Config{Pad: 64, Font: "SourceSansPro", Layout: "elk"})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result, err := target.RenderSchema(ctx, domain.FormattedSchema{Type: domain.TargetType("d2"), Data: []byte("x -> y")})
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, result)
+}
+
 func TestTarget_RenderSchema_ValidD2(t *testing.T) {
 	t.Parallel()
 
@@ -392,3 +406,336 @@ func TestTarget_GenerateSystemDiagram(t *testing.T) {
 	assert.NotEmpty(t, diagram)
 	assert.Contains(t, string(diagram), "<svg")
 }
+
+func TestTarget_PlannedDiagrams(t *testing.T) {
+	t.Parallel()
+
+	target, err := NewTarget(config.D2Config{Pad: 64, Font: "SourceSansPro", Layout: "elk"})
+	require.NoError(t, err)
+
+	current := domain.Service{
+		Info: domain.ServiceInfo{Name: "User Service", System: "Core"},
+		Relationships: []domain.Relationship{
+			{Action: domain.RelationshipActionUses, Participant: "postgres", Technology: "PostgreSQL"},
+			{Action: domain.RelationshipActionRequests, Participant: "Keycloak", External: true, Planned: true},
+		},
+	}
+	planned := domain.Service{
+		Info: domain.ServiceInfo{Name: "Billing Service", System: "Core", Planned: true},
+		Relationships: []domain.Relationship{
+			{Action: domain.RelationshipActionRequests, Participant: "User Service"},
+		},
+	}
+	schema := domain.Schema{Services: []domain.Service{current, planned}}
+
+	payload := target.prepareServiceRelationshipsDocsPayload(current, schema.Services, nil)
+	assert.True(t, payload.HasPlanned)
+	for _, node := range payload.ExternalNodes {
+		assert.Equal(t, node.Label == "Keycloak", node.Planned, node.Label)
+	}
+
+	overview, err := target.GenerateOverviewDiagramScript(schema, nil, "Test")
+	require.NoError(t, err)
+	assert.Contains(t, string(overview), "class: planned")
+
+	system, err := target.GenerateSystemDiagramScript(schema, "Core", nil)
+	require.NoError(t, err)
+	assert.Contains(t, string(system), "{class: planned}")
+
+	diagram, err := target.GenerateSystemDiagram(context.Background(), schema, "Core", nil)
+	require.NoError(t, err)
+	assert.Contains(t, string(diagram), "<svg")
+
+	unplanned, err := target.GenerateOverviewDiagramScript(domain.Schema{Services: []domain.Service{
+		{Info: domain.ServiceInfo{Name: "User Service"}},
+	}}, nil, "Test")
+	require.NoError(t, err)
+	assert.NotContains(t, string(unplanned), "classes")
+}
+
+func TestTarget_HighlightedDiagrams(t *testing.T) {
+	t.Parallel()
+
+	target, err := NewTarget(config.D2Config{Pad: 64, Font: "SourceSansPro", Layout: "elk"})
+	require.NoError(t, err)
+
+	orders := domain.Service{
+		Info: domain.ServiceInfo{Name: "Order Service", System: "Shop"},
+		Relationships: []domain.Relationship{
+			{Action: domain.RelationshipActionRequests, Participant: "Billing Service", Technology: "HTTP"},
+			{Action: domain.RelationshipActionSends, Participant: "Audit Service", Technology: "Kafka"},
+			{Action: domain.RelationshipActionRequests, Participant: "Stripe", External: true, Planned: true},
+		},
+	}
+	billing := domain.Service{Info: domain.ServiceInfo{Name: "Billing Service", System: "Shop"}}
+	audit := domain.Service{Info: domain.ServiceInfo{Name: "Audit Service"}}
+	schema := domain.Schema{Services: []domain.Service{orders, billing, audit}}
+
+	plain, err := target.GenerateOverviewDiagramScript(schema, nil, "Test")
+	require.NoError(t, err)
+	assert.NotContains(t, string(plain), "highlighted")
+
+	highlighted := target.WithHighlight(config.Highlight{
+		Services:     []string{"billing service", "Stripe"},
+		Technologies: []string{"kafka"},
+	})
+
+	overview := highlighted.prepareOverviewDocsPayload(schema, nil, "Test")
+	assert.True(t, overview.HasHighlighted)
+	for _, node := range overview.Nodes {
+		assert.Equal(t, node.Label == "Shop" || node.Label == "Stripe", node.Highlighted, node.Label)
+	}
+	for _, edge := range overview.Edges {
+		assert.Equal(t, edge.Label == "sends", edge.Highlighted, edge.Label)
+	}
+
+	script, err := highlighted.GenerateOverviewDiagramScript(schema, nil, "Test")
+	require.NoError(t, err)
+	assert.Contains(t, string(script), "class: highlighted")
+	assert.Contains(t, string(script), "class: [planned; highlighted]")
+
+	rendered, err := highlighted.GenerateOverviewDiagram(context.Background(), schema, nil, "Test")
+	require.NoError(t, err)
+	assert.Contains(t, string(rendered), "<svg")
+
+	system := highlighted.prepareSystemDocsPayload(schema, "Shop", nil)
+	assert.True(t, system.HasHighlighted)
+	for _, node := range system.SystemNodes {
+		assert.Equal(t, node.Label == "Billing Service", node.Highlighted, node.Label)
+	}
+	for _, edge := range system.Edges {
+		assert.Equal(t, edge.Label == "sends", edge.Highlighted, edge.Label)
+	}
+
+	diagram, err := highlighted.GenerateSystemDiagram(context.Background(), schema, "Shop", nil)
+	require.NoError(t, err)
+	assert.Contains(t, string(diagram), "<svg")
+}
+
+func TestTarget_EdgeLabels(t *testing.T) {
+	t.Parallel()
+
+	target, err := NewTarget(config.D2Config{Pad: 64, Font: "SourceSansPro", Layout: "elk"})
+	require.NoError(t, err)
+
+	_, err = target.WithEdgeLabels(config.EdgeLabels{Template: "{{ .Technologies }}"})
+	require.ErrorIs(t, err, ErrTemplateParsing)
+
+	labeled, err := target.WithEdgeLabels(config.EdgeLabels{
+		Template: `{{ .Label }}{{ with .Proto }} ({{ . }}){{ end }}`,
+		Actions:  map[string]string{"requests": "calls", "Pub": `publishes "events"`},
+	})
+	require.NoError(t, err)
+
+	orders := domain.Service{
+		Info: domain.ServiceInfo{Name: "Order Service", System: "Shop"},
+		Relationships: []domain.Relationship{
+			{Action: domain.RelationshipActionRequests, Participant: "Billing Service", Proto: "gRPC"},
+			{Action: domain.RelationshipActionRequests, Participant: "Billing Service", Proto: "HTTP"},
+			{Action: domain.RelationshipActionUses, Participant: "postgres", Technology: "PostgreSQL"},
+		},
+	}
+	billing := domain.Service{Info: domain.ServiceInfo{Name: "Billing Service", System: "Shop"}}
+	schema := domain.Schema{Services: []domain.Service{orders, billing}}
+	asyncEdges := []domain.AsyncEdge{
+		{Source: "Order Service", Target: "Billing Service", Channel: "orders", Kind: asyncOpSend},
+	}
+
+	system := labeled.prepareSystemDocsPayload(schema, "Shop", asyncEdges)
+	var labels []string
+	for _, edge := range system.Edges {
+		labels = append(labels, edge.Label)
+	}
+	assert.ElementsMatch(t, []string{"calls (gRPC, HTTP)", `publishes \"events\"`}, labels)
+
+	relationships := labeled.prepareServiceRelationshipsDocsPayload(orders, schema.Services, asyncEdges)
+	labels = nil
+	for _, edge := range relationships.Edges {
+		labels = append(labels, edge.Label)
+	}
+	assert.ElementsMatch(t, []string{"calls (gRPC, HTTP)", "uses", `publishes \"events\"`}, labels)
+
+	diagram, err := labeled.GenerateSystemDiagram(context.Background(), schema, "Shop", asyncEdges)
+	require.NoError(t, err)
+	assert.Contains(t, string(diagram), "calls (gRPC, HTTP)")
+}
+
+func TestTarget_CriticalityStyles(t *testing.T) {
+	t.Parallel()
+
+	target, err := NewTarget(config.D2Config{Pad: 64, Font: "SourceSansPro", Layout: "elk"})
+	require.NoError(t, err)
+
+	orders := domain.Service{
+		Info: domain.ServiceInfo{Name: "Order Service", System: "Shop"},
+		Relationships: []domain.Relationship{
+			{Action: domain.RelationshipActionRequests, Participant: "Billing Service", Criticality: domain.CriticalityLow},
+			{Action: domain.RelationshipActionRequests, Participant: "Billing Service", Proto: "gRPC",
+				Criticality: domain.CriticalityCritical},
+			{Action: domain.RelationshipActionRequests, Participant: "Stripe", External: true, Planned: true,
+				Criticality: domain.CriticalityHigh},
+			{Action: domain.RelationshipActionUses, Participant: "postgres"},
+		},
+	}
+	billing := domain.Service{Info: domain.ServiceInfo{Name: "Billing Service", System: "Shop"}}
+	schema := domain.Schema{Services: []domain.Service{orders, billing}}
+
+	system := target.prepareSystemDocsPayload(schema, "Shop", nil)
+	for _, edge := range system.Edges {
+		if edge.To == externalNodeID("Stripe") {
+			assert.Equal(t, ` {class: planned; style: {stroke-width: 4}}`, edge.Attributes())
+		} else {
+			assert.Equal(t, domain.CriticalityCritical, edge.Criticality, "the highest criticality wins")
+		}
+	}
+
+	script, err := target.GenerateSystemDiagramScript(schema, "Shop", nil)
+	require.NoError(t, err)
+	assert.Contains(t, string(script), `{style: {stroke-width: 6; stroke: "#b91c1c"}}`)
+
+	overview, err := target.GenerateOverviewDiagramScript(schema, nil, "Test")
+	require.NoError(t, err)
+	assert.Contains(t, string(overview), "style: {stroke-width: 4}")
+
+	relationships, err := target.GenerateServiceRelationshipsDiagramScript(orders, schema.Services, nil)
+	require.NoError(t, err)
+	assert.Contains(t, string(relationships), `stroke: "#b91c1c"`)
+	assert.Contains(t, string(relationships), "-> "+externalNodeID("postgres")+": \"uses\"\n")
+
+	for _, render := range []func() ([]byte, error){
+		func() ([]byte, error) { return target.GenerateSystemDiagram(context.Background(), schema, "Shop", nil) },
+		func() ([]byte, error) {
+			return target.GenerateOverviewDiagram(context.Background(), schema, nil, "Test")
+		},
+		func() ([]byte, error) {
+			return target.GenerateServiceRelationshipsDiagram(context.Background(), orders, schema.Services, nil)
+		},
+	} {
+		diagram, err := render()
+		require.NoError(t, err)
+		assert.Contains(t, string(diagram), "<svg")
+	}
+}
+
+func TestTarget_DatastoreAccess(t *testing.T) {
+	t.Parallel()
+
+	target, err := NewTarget(config.D2Config{Pad: 64, Font: "SourceSansPro", Layout: "elk"})
+	require.NoError(t, err)
+
+	orders := domain.Service{
+		Info: domain.ServiceInfo{Name: "Order Service"},
+		Relationships: []domain.Relationship{
+			{Action: domain.RelationshipActionUses, Participant: "orders-db", Technology: "PostgreSQL",
+				Access: domain.AccessReadWrite},
+			{Action: domain.RelationshipActionUses, Participant: "catalog-replica", Technology: "PostgreSQL",
+				Access: domain.AccessRead, Criticality: domain.CriticalityCritical},
+			{Action: domain.RelationshipActionUses, Participant: "redis", Technology: "Redis"},
+		},
+	}
+
+	payload := target.prepareServiceRelationshipsDocsPayload(orders, []domain.Service{orders}, nil)
+	attributes := make(map[string]string, len(payload.Edges))
+	for _, edge := range payload.Edges {
+		attributes[edge.Label+" "+edge.To] = edge.Attributes()
+	}
+
+	assert.Equal(t, map[string]string{
+		"reads/writes " + externalNodeID("orders-db"): ` {style: {stroke: "#7c3aed"}}`,
+		"reads " + externalNodeID("catalog-replica"):  ` {style: {stroke-width: 6; stroke: "#b91c1c"}}`,
+		"uses " + externalNodeID("redis"):             "",
+	}, attributes)
+
+	diagram, err := target.GenerateServiceRelationshipsDiagram(context.Background(), orders,
+		[]domain.Service{orders}, nil)
+	require.NoError(t, err)
+	assert.Contains(t, string(diagram), "reads/writes")
+}
+
+func TestTarget_EdgeDedup(t *testing.T) {
+	t.Parallel()
+
+	target, err := NewTarget(config.D2Config{Pad: 64, Font: "SourceSansPro", Layout: "elk"})
+	require.NoError(t, err)
+
+	orders := domain.Service{
+		Info: domain.ServiceInfo{Name: "Order Service", System: "Shop"},
+		Relationships: []domain.Relationship{
+			{Action: domain.RelationshipActionSends, Participant: "Billing Service", Technology: "Kafka", Planned: true},
+			{Action: domain.RelationshipActionRequests, Participant: "Billing Service", Proto: "gRPC"},
+		},
+	}
+	billing := domain.Service{
+		Info: domain.ServiceInfo{Name: "Billing Service", System: "Shop"},
+		Relationships: []domain.Relationship{
+			{Action: domain.RelationshipActionReceives, Participant: "Order Service", Technology: "Apache Kafka",
+				Criticality: domain.CriticalityHigh},
+		},
+	}
+	schema := domain.Schema{Services: []domain.Service{orders, billing}}
+	asyncEdges := []domain.AsyncEdge{
+		{Source: "Order Service", Target: "Billing Service", Channel: "orders", Kind: asyncOpSend},
+	}
+
+	system := target.prepareSystemDocsPayload(schema, "Shop", asyncEdges)
+	require.Len(t, system.Edges, 2, "receives and pub are drawn by the sends edge")
+
+	for _, edge := range system.Edges {
+		if edge.Label != "sends" {
+			assert.Equal(t, "requests", edge.Label)
+
+			continue
+		}
+
+		assert.Equal(t, "Kafka, Apache Kafka", edge.Technology)
+		assert.Equal(t, domain.CriticalityHigh, edge.Criticality)
+		assert.False(t, edge.Planned, "the declared receives relationship exists")
+	}
+
+	relationships := target.prepareServiceRelationshipsDocsPayload(billing, schema.Services, asyncEdges)
+	var labels []string
+	for _, edge := range relationships.Edges {
+		labels = append(labels, edge.Label)
+	}
+	assert.Equal(t, []string{"receives"}, labels, "receives wins over the derived pub edge")
+
+	asyncEdges = append(asyncEdges,
+		domain.AsyncEdge{Source: "Order Service", Target: "Billing Service", Channel: "invoices", Kind: asyncOpSend},
+		domain.AsyncEdge{Source: "Billing Service", Target: "Order Service", Channel: "invoices", Kind: asyncOpReply},
+	)
+	relationships = target.prepareServiceRelationshipsDocsPayload(billing, schema.Services, asyncEdges)
+	labels = nil
+	for _, edge := range relationships.Edges {
+		labels = append(labels, edge.Label)
+	}
+	assert.ElementsMatch(t, []string{"receives", asyncLabelPubReq}, labels,
+		"pub/req is kept as receives doesn't draw the requests")
+}
+
+func TestTarget_CollidingServiceNames(t *testing.T) {
+	t.Parallel()
+
+	target, err := NewTarget(config.D2Config{Pad: 64, Font: "SourceSansPro", Layout: "elk"})
+	require.NoError(t, err)
+
+	hyphen := domain.Service{
+		Info: domain.ServiceInfo{Name: "Service-A", System: "Core"},
+		Relationships: []domain.Relationship{
+			{Action: domain.RelationshipActionRequests, Participant: "Service_A"},
+		},
+	}
+	underscore := domain.Service{Info: domain.ServiceInfo{Name: "Service_A", System: "Core"}}
+	schema := domain.Schema{Services: []domain.Service{hyphen, underscore}}
+
+	relationships := target.prepareServiceRelationshipsDocsPayload(hyphen, schema.Services, nil)
+	require.Len(t, relationships.Services, 2)
+	assert.NotEqual(t, relationships.Services[0].ID, relationships.Services[1].ID)
+	require.Len(t, relationships.Edges, 1)
+	assert.NotEqual(t, relationships.Edges[0].From, relationships.Edges[0].To)
+
+	system := target.prepareSystemDocsPayload(schema, "Core", nil)
+	require.Len(t, system.SystemNodes, 2)
+	assert.Equal(t, "service_service-a", system.SystemNodes[0].ID)
+	assert.Regexp(t, `^service_service-a-[0-9a-f]{8}$`, system.SystemNodes[1].ID)
+	assert.Len(t, system.Edges, 1)
+}
@@ -0,0 +1,145 @@
+package d2
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+)
+
+// asyncEdgeKeySuffix ends the keys of edge sets for edges derived from AsyncAPI operations.
+const asyncEdgeKeySuffix = "|async"
+
+// Precedence of edges drawing the same flows between the same nodes, lower ranks win.
+const (
+	// rankDeclaredBySource is the rank of relationships declared by the sending or requesting service.
+	rankDeclaredBySource = iota
+	// rankDeclaredByTarget is the rank of relationships declared by the receiving service.
+	rankDeclaredByTarget
+	// rankDerived is the rank of edges derived from AsyncAPI operations.
+	rankDerived
+)
+
+// edgeFlow describes what an edge draws between two nodes: messages, requests or both.
+type edgeFlow struct {
+	from, to string
+	messages bool
+	requests bool
+	rank     int
+}
+
+func newEdgeFlow(from, to, label string, derived bool) edgeFlow {
+	flow := edgeFlow{from: from, to: to, rank: rankDeclaredBySource}
+
+	switch label {
+	case string(domain.RelationshipActionSends), asyncLabelPub, asyncOpSend:
+		flow.messages = true
+	case string(domain.RelationshipActionReceives):
+		flow.messages = true
+		flow.rank = rankDeclaredByTarget
+	case requestsLabel, asyncLabelReq, asyncOpReply:
+		flow.requests = true
+	case asyncLabelPubReq:
+		flow.messages, flow.requests = true, true
+	}
+
+	if derived {
+		flow.rank = rankDerived
+	}
+
+	return flow
+}
+
+// coveredBy reports whether the other edge takes precedence over the edge and draws all of its flows.
+func (f edgeFlow) coveredBy(other edgeFlow) bool {
+	if f.from != other.from || f.to != other.to || other.rank >= f.rank || (!f.messages && !f.requests) {
+		return false
+	}
+
+	return (!f.messages || other.messages) && (!f.requests || other.requests)
+}
+
+// dedupEdges removes the edges of an edge set drawing flows already drawn by an edge of higher precedence
+// between the same nodes, and merges them into that edge. Services declaring the same flow from both ends,
+// e.g. sends and receives, or flows also derived from AsyncAPI operations otherwise show up as several edges
+// with different labels. Relationships declared by the sending or requesting service win over ones declared
+// by the receiving service, which win over edges derived from AsyncAPI operations.
+func dedupEdges[E any](edgeSet map[string]E, endpoints func(E) (from, to, label string),
+	merge func(kept, duplicate E) E) {
+	flows := make(map[string]edgeFlow, len(edgeSet))
+	byNodes := make(map[[2]string][]string)
+
+	for _, key := range slices.Sorted(maps.Keys(edgeSet)) {
+		from, to, label := endpoints(edgeSet[key])
+		flows[key] = newEdgeFlow(from, to, label, strings.HasSuffix(key, asyncEdgeKeySuffix))
+		byNodes[[2]string{from, to}] = append(byNodes[[2]string{from, to}], key)
+	}
+
+	for _, keys := range byNodes {
+		// Duplicates merge into the edge of the highest precedence covering them, so chains such as a derived
+		// edge covered by a receives edge covered by a sends edge all end up in the sends edge.
+		slices.SortStableFunc(keys, func(a, b string) int { return flows[a].rank - flows[b].rank })
+
+		for i, key := range keys {
+			for _, other := range keys[:i] {
+				if _, kept := edgeSet[other]; kept && flows[key].coveredBy(flows[other]) {
+					edgeSet[other] = merge(edgeSet[other], edgeSet[key])
+					delete(edgeSet, key)
+
+					break
+				}
+			}
+		}
+	}
+}
+
+func overviewEdgeEndpoints(edge OverviewDocsEdge) (string, string, string) {
+	return edge.From, edge.To, edge.Label
+}
+
+func mergeOverviewEdges(kept, duplicate OverviewDocsEdge) OverviewDocsEdge {
+	kept.Technology = joinedValues(kept.Technology, duplicate.Technology)
+	kept.Proto = joinedValues(kept.Proto, duplicate.Proto)
+	kept.Criticality = domain.MaxCriticality(kept.Criticality, duplicate.Criticality)
+	kept.Planned = kept.Planned && duplicate.Planned
+	kept.Highlighted = kept.Highlighted || duplicate.Highlighted
+
+	return kept
+}
+
+func systemEdgeEndpoints(edge SystemDocsEdge) (string, string, string) {
+	return edge.From, edge.To, edge.Label
+}
+
+func mergeSystemEdges(kept, duplicate SystemDocsEdge) SystemDocsEdge {
+	kept.Technology = joinedValues(kept.Technology, duplicate.Technology)
+	kept.Proto = joinedValues(kept.Proto, duplicate.Proto)
+	kept.Criticality = domain.MaxCriticality(kept.Criticality, duplicate.Criticality)
+	kept.Planned = kept.Planned && duplicate.Planned
+	kept.Highlighted = kept.Highlighted || duplicate.Highlighted
+
+	return kept
+}
+
+func diagramEdgeEndpoints(edge diagramEdgeDocs) (string, string, string) {
+	return edge.From, edge.To, edge.Label
+}
+
+func mergeDiagramEdges(kept, duplicate diagramEdgeDocs) diagramEdgeDocs {
+	kept.Technology = joinedValues(kept.Technology, duplicate.Technology)
+	kept.Proto = joinedValues(kept.Proto, duplicate.Proto)
+	kept.Criticality = domain.MaxCriticality(kept.Criticality, duplicate.Criticality)
+	kept.Planned = kept.Planned && duplicate.Planned
+
+	return kept
+}
+
+// joinedValues adds the comma-separated values of a merged edge to the ones of an edge.
+func joinedValues(values, more string) string {
+	for _, value := range strings.Split(more, ", ") {
+		values = joinedValue(values, value)
+	}
+
+	return values
+}
@@ -0,0 +1,128 @@
+package d2
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+)
+
+// GenerateOverviewDiffScript generates the D2 script of an overview diagram of both schemas together. Nodes
+// and edges only in after get the added class, the ones only in before the removed class and nodes of
+// services that changed between the schemas the changed class.
+func (t *Target) GenerateOverviewDiffScript(before, after domain.Schema, globalName string) ([]byte, error) {
+	payload := t.prepareOverviewDiffPayload(before, after, globalName)
+
+	var buf bytes.Buffer
+	if err := t.overviewTemplate.Execute(&buf, payload); err != nil {
+		return nil, fmt.Errorf("execute overview diff template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (t *Target) prepareOverviewDiffPayload(before, after domain.Schema, globalName string) OverviewDocsPayload {
+	beforePayload := t.prepareOverviewDocsPayload(before, nil, globalName)
+	payload := t.prepareOverviewDocsPayload(after, nil, globalName)
+
+	beforeNodes := make(map[string]OverviewDocsNode, len(beforePayload.Nodes))
+	for _, node := range beforePayload.Nodes {
+		beforeNodes[node.ID] = node
+	}
+
+	changed := changedOverviewNodes(before, after)
+	afterNodes := make(map[string]struct{}, len(payload.Nodes))
+
+	for i, node := range payload.Nodes {
+		afterNodes[node.ID] = struct{}{}
+
+		previous, existed := beforeNodes[node.ID]
+
+		switch {
+		case !existed:
+			payload.Nodes[i].Diff = domain.ChangeTypeAdded
+		case changed[node.ID] || previous.Content != node.Content || previous.Planned != node.Planned:
+			payload.Nodes[i].Diff = domain.ChangeTypeChanged
+		}
+	}
+
+	for _, node := range beforePayload.Nodes {
+		if _, exists := afterNodes[node.ID]; !exists {
+			node.Diff = domain.ChangeTypeRemoved
+			payload.Nodes = append(payload.Nodes, node)
+			payload.HasInternalServices = payload.HasInternalServices || node.Internal
+		}
+	}
+
+	beforeEdges := make(map[string]OverviewDocsEdge, len(beforePayload.Edges))
+	for _, edge := range beforePayload.Edges {
+		beforeEdges[overviewEdgeKey(edge)] = edge
+	}
+
+	afterEdges := make(map[string]struct{}, len(payload.Edges))
+
+	for i, edge := range payload.Edges {
+		key := overviewEdgeKey(edge)
+		afterEdges[key] = struct{}{}
+
+		previous, existed := beforeEdges[key]
+
+		switch {
+		case !existed:
+			payload.Edges[i].Diff = domain.ChangeTypeAdded
+		case previous.Technology != edge.Technology || previous.Proto != edge.Proto ||
+			previous.Planned != edge.Planned:
+			payload.Edges[i].Diff = domain.ChangeTypeChanged
+		}
+	}
+
+	for _, edge := range beforePayload.Edges {
+		if _, exists := afterEdges[overviewEdgeKey(edge)]; !exists {
+			edge.Diff = domain.ChangeTypeRemoved
+			payload.Edges = append(payload.Edges, edge)
+		}
+	}
+
+	// Colors of criticality and datastore access would hide the ones of the changes.
+	for i := range payload.Edges {
+		payload.Edges[i].Criticality, payload.Edges[i].Access = "", ""
+	}
+
+	payload.HasPlanned = overviewHasPlanned(payload.Nodes, payload.Edges)
+	payload.HasDiff = true
+
+	return payload
+}
+
+func overviewEdgeKey(edge OverviewDocsEdge) string {
+	return edge.From + "\x00" + edge.To + "\x00" + edge.Label
+}
+
+// changedOverviewNodes returns the IDs of the overview nodes drawing services present in both schemas that
+// differ, the system node for services of a system.
+func changedOverviewNodes(before, after domain.Schema) map[string]bool {
+	previous := make(map[string]domain.Service, len(before.Services))
+	for _, service := range before.Services {
+		previous[service.Info.Name] = service
+	}
+
+	ids := newNodeIDs(after.Services)
+	changed := make(map[string]bool)
+
+	for _, service := range after.Services {
+		old, exists := previous[service.Info.Name]
+		if !exists || reflect.DeepEqual(old, service) {
+			continue
+		}
+
+		if system := strings.TrimSpace(service.Info.System); system != "" {
+			changed[ids.system(system)] = true
+		} else {
+			changed[ids.service(service.Info.Name)] = true
+		}
+	}
+
+	return changed
+}
@@ -0,0 +1,76 @@
+package d2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTarget_GenerateOverviewDiffScript(t *testing.T) {
+	t.Parallel()
+
+	target, err := NewTarget(config.D2Config{Pad: 64, Font: "SourceSansPro", Layout: "dagre"})
+	require.NoError(t, err)
+
+	before := domain.Schema{Services: []domain.Service{
+		{
+			Info: domain.ServiceInfo{Name: "Order Service", System: "Shop"},
+			Relationships: []domain.Relationship{
+				{Action: domain.RelationshipActionRequests, Participant: "Legacy Billing"},
+			},
+		},
+		{Info: domain.ServiceInfo{Name: "Report Service", Description: "Reports."}},
+		{Info: domain.ServiceInfo{Name: "Fax Service"}},
+	}}
+	after := domain.Schema{Services: []domain.Service{
+		{
+			Info: domain.ServiceInfo{Name: "Order Service", System: "Shop"},
+			Relationships: []domain.Relationship{
+				{Action: domain.RelationshipActionRequests, Participant: "Billing Service",
+					Criticality: domain.CriticalityCritical},
+			},
+		},
+		{Info: domain.ServiceInfo{Name: "Billing Service"}},
+		{Info: domain.ServiceInfo{Name: "Report Service", Description: "Reports and exports."}},
+		{Info: domain.ServiceInfo{Name: "Fax Service"}},
+	}}
+
+	payload := target.prepareOverviewDiffPayload(before, after, "Company")
+	assert.True(t, payload.HasDiff)
+
+	nodes := make(map[string]domain.ChangeType)
+	for _, node := range payload.Nodes {
+		nodes[node.Label] = node.Diff
+	}
+
+	assert.Equal(t, map[string]domain.ChangeType{
+		"Shop":            domain.ChangeTypeChanged,
+		"Billing Service": domain.ChangeTypeAdded,
+		"Report Service":  domain.ChangeTypeChanged,
+		"Fax Service":     "",
+		"Legacy Billing":  domain.ChangeTypeRemoved,
+	}, nodes)
+
+	edges := make(map[string]domain.ChangeType)
+	for _, edge := range payload.Edges {
+		edges[edge.From+" -> "+edge.To] = edge.Diff
+		assert.Empty(t, edge.Criticality)
+	}
+
+	assert.Equal(t, map[string]domain.ChangeType{
+		"internal.system_shop -> internal.service_billing-service": domain.ChangeTypeAdded,
+		"internal.system_shop -> external_legacy-billing":          domain.ChangeTypeRemoved,
+	}, edges)
+
+	script, err := target.GenerateOverviewDiffScript(before, after, "Company")
+	require.NoError(t, err)
+	assert.Contains(t, string(script), "removed: {")
+	assert.Contains(t, string(script), "external_legacy-billing.class: removed")
+
+	_, err = target.RenderSchema(context.Background(), domain.FormattedSchema{Type: targetType, Data: script})
+	require.NoError(t, err)
+}
@@ -0,0 +1,342 @@
+package d2
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"oss.terrastruct.com/d2/d2target"
+	"oss.terrastruct.com/d2/d2themes"
+	"oss.terrastruct.com/d2/d2themes/d2themescatalog"
+)
+
+// Identifiers of the root cells every draw.io diagram starts with.
+const (
+	drawioRootID  = "0"
+	drawioLayerID = "1"
+)
+
+type drawioFile struct {
+	XMLName xml.Name      `xml:"mxfile"`
+	Host    string        `xml:"host,attr"`
+	Diagram drawioDiagram `xml:"diagram"`
+}
+
+type drawioDiagram struct {
+	ID    string      `xml:"id,attr"`
+	Name  string      `xml:"name,attr"`
+	Model drawioModel `xml:"mxGraphModel"`
+}
+
+type drawioModel struct {
+	Grid  int          `xml:"grid,attr"`
+	Cells []drawioCell `xml:"root>mxCell"`
+}
+
+type drawioCell struct {
+	ID       string          `xml:"id,attr"`
+	Value    string          `xml:"value,attr,omitempty"`
+	Style    string          `xml:"style,attr,omitempty"`
+	Parent   string          `xml:"parent,attr,omitempty"`
+	Source   string          `xml:"source,attr,omitempty"`
+	Target   string          `xml:"target,attr,omitempty"`
+	Vertex   string          `xml:"vertex,attr,omitempty"`
+	Edge     string          `xml:"edge,attr,omitempty"`
+	Geometry *drawioGeometry `xml:"mxGeometry"`
+}
+
+type drawioGeometry struct {
+	X        int           `xml:"x,attr,omitempty"`
+	Y        int           `xml:"y,attr,omitempty"`
+	Width    int           `xml:"width,attr,omitempty"`
+	Height   int           `xml:"height,attr,omitempty"`
+	Relative string        `xml:"relative,attr,omitempty"`
+	As       string        `xml:"as,attr"`
+	Points   *drawioPoints `xml:"Array,omitempty"`
+}
+
+type drawioPoints struct {
+	As     string        `xml:"as,attr"`
+	Points []drawioPoint `xml:"mxPoint"`
+}
+
+type drawioPoint struct {
+	X float64 `xml:"x,attr"`
+	Y float64 `xml:"y,attr"`
+}
+
+// RenderDrawio lays out a D2 script like RenderSchema does and converts the result to an editable draw.io
+// diagram: containers, e.g. systems, become draw.io groups holding their services, and every node keeps
+// the shape and colors it has in the rendered SVG.
+func (t *Target) RenderDrawio(ctx context.Context, script []byte, name string) ([]byte, error) {
+	if ctx == nil {
+		return nil, ErrContextRequired
+	}
+
+	diagram, err := t.compile(ctx, script)
+	if err != nil {
+		return nil, err
+	}
+
+	theme := d2themescatalog.Find(t.config.Theme)
+	if theme.Name == "" {
+		theme = d2themescatalog.NeutralDefault
+	}
+
+	file := drawioFile{
+		Host: "holydocs",
+		Diagram: drawioDiagram{
+			ID:    name,
+			Name:  name,
+			Model: drawioModel{Grid: 1, Cells: drawioCells(diagram, theme)},
+		},
+	}
+
+	out, err := xml.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal draw.io diagram: %w", err)
+	}
+
+	return append([]byte(xml.Header), append(out, '\n')...), nil
+}
+
+// drawioCells converts the shapes and connections of a laid out diagram to draw.io cells. Parents come
+// before their children, so draw.io can nest them, and child geometry is relative to the parent.
+func drawioCells(diagram *d2target.Diagram, theme d2themes.Theme) []drawioCell {
+	cells := []drawioCell{{ID: drawioRootID}, {ID: drawioLayerID, Parent: drawioRootID}}
+
+	shapes := make(map[string]d2target.Shape, len(diagram.Shapes))
+	for _, shape := range diagram.Shapes {
+		shapes[shape.ID] = shape
+	}
+
+	parents := make(map[string]string, len(diagram.Shapes))
+	containers := make(map[string]bool)
+
+	for _, shape := range diagram.Shapes {
+		parent := shapeParent(shape.ID, shapes)
+		parents[shape.ID] = parent
+
+		if parent != "" {
+			containers[parent] = true
+		}
+	}
+
+	ordered := make([]d2target.Shape, len(diagram.Shapes))
+	copy(ordered, diagram.Shapes)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Level < ordered[j].Level
+	})
+
+	for _, shape := range ordered {
+		cell := drawioCell{
+			ID:     shape.ID,
+			Value:  shape.Label,
+			Style:  shapeStyle(shape, containers[shape.ID], theme),
+			Parent: drawioLayerID,
+			Vertex: "1",
+			Geometry: &drawioGeometry{
+				X:      shape.Pos.X,
+				Y:      shape.Pos.Y,
+				Width:  shape.Width,
+				Height: shape.Height,
+				As:     "geometry",
+			},
+		}
+
+		if parent := parents[shape.ID]; parent != "" {
+			cell.Parent = parent
+			cell.Geometry.X -= shapes[parent].Pos.X
+			cell.Geometry.Y -= shapes[parent].Pos.Y
+		}
+
+		cells = append(cells, cell)
+	}
+
+	for _, connection := range diagram.Connections {
+		cells = append(cells, connectionCell(connection, theme))
+	}
+
+	return cells
+}
+
+// shapeParent returns the closest shape containing the shape, D2 identifies nested shapes by the path
+// of their containers, e.g. "Shop.Order Service".
+func shapeParent(id string, shapes map[string]d2target.Shape) string {
+	for i := strings.LastIndex(id, "."); i > 0; i = strings.LastIndex(id[:i], ".") {
+		if _, ok := shapes[id[:i]]; ok {
+			return id[:i]
+		}
+	}
+
+	return ""
+}
+
+func connectionCell(connection d2target.Connection, theme d2themes.Theme) drawioCell {
+	geometry := &drawioGeometry{Relative: "1", As: "geometry"}
+
+	if len(connection.Route) > 2 {
+		points := &drawioPoints{As: "points"}
+		for _, point := range connection.Route[1 : len(connection.Route)-1] {
+			points.Points = append(points.Points, drawioPoint{X: point.X, Y: point.Y})
+		}
+		geometry.Points = points
+	}
+
+	style := styleBuilder{}
+	style.set("edgeStyle", "none")
+	style.set("html", "1")
+	style.set("rounded", "1")
+	style.set("endArrow", drawioArrow(connection.DstArrow))
+	style.set("startArrow", drawioArrow(connection.SrcArrow))
+	style.setColor("strokeColor", connection.Stroke, theme)
+	style.setColor("fontColor", connection.Color, theme)
+	style.setStroke(connection.StrokeWidth, connection.StrokeDash, connection.Opacity)
+
+	return drawioCell{
+		ID:       connection.ID,
+		Value:    connection.Label,
+		Style:    style.String(),
+		Parent:   drawioLayerID,
+		Source:   connection.Src,
+		Target:   connection.Dst,
+		Edge:     "1",
+		Geometry: geometry,
+	}
+}
+
+// shapeStyle maps the D2 shape types used by holydocs diagrams to their draw.io counterparts.
+func shapeStyle(shape d2target.Shape, container bool, theme d2themes.Theme) string {
+	style := styleBuilder{}
+
+	switch shape.Type {
+	case d2target.ShapeCylinder:
+		style.set("shape", "cylinder3")
+		style.set("boundedLbl", "1")
+	case d2target.ShapeQueue:
+		style.set("shape", "cylinder3")
+		style.set("direction", "south")
+		style.set("boundedLbl", "1")
+	case d2target.ShapePerson, d2target.ShapeC4Person:
+		style.set("shape", "umlActor")
+		style.set("verticalLabelPosition", "bottom")
+		style.set("verticalAlign", "top")
+	case d2target.ShapeCloud:
+		style.set("ellipse", "")
+		style.set("shape", "cloud")
+	case d2target.ShapeHexagon:
+		style.set("shape", "hexagon")
+		style.set("perimeter", "hexagonPerimeter2")
+	case d2target.ShapeOval, d2target.ShapeCircle:
+		style.set("ellipse", "")
+	case d2target.ShapeDiamond:
+		style.set("rhombus", "")
+	case d2target.ShapePage, d2target.ShapeDocument:
+		style.set("shape", "document")
+		style.set("boundedLbl", "1")
+	case d2target.ShapeText:
+		style.set("text", "")
+		style.set("strokeColor", "none")
+		style.set("fillColor", "none")
+	default:
+		style.set("rounded", boolFlag(shape.BorderRadius > 0))
+	}
+
+	style.set("whiteSpace", "wrap")
+	style.set("html", "1")
+
+	if container {
+		style.set("container", "1")
+		style.set("collapsible", "0")
+		style.set("verticalAlign", "top")
+		style.set("fontStyle", "1")
+	} else if shape.Bold {
+		style.set("fontStyle", "1")
+	}
+
+	if shape.Type != d2target.ShapeText {
+		style.setColor("fillColor", shape.Fill, theme)
+		style.setColor("strokeColor", shape.Stroke, theme)
+	}
+
+	style.setColor("fontColor", shape.Color, theme)
+	style.setStroke(shape.StrokeWidth, shape.StrokeDash, shape.Opacity)
+
+	if shape.FontSize > 0 {
+		style.set("fontSize", strconv.Itoa(shape.FontSize))
+	}
+
+	if shape.Link != "" {
+		style.set("link", shape.Link)
+	}
+
+	return style.String()
+}
+
+func drawioArrow(arrow d2target.Arrowhead) string {
+	switch arrow {
+	case d2target.NoArrowhead, "":
+		return "none"
+	case d2target.DiamondArrowhead, d2target.FilledDiamondArrowhead:
+		return "diamond"
+	case d2target.CircleArrowhead, d2target.FilledCircleArrowhead:
+		return "oval"
+	case d2target.BoxArrowhead, d2target.FilledBoxArrowhead:
+		return "box"
+	default:
+		return "block"
+	}
+}
+
+func boolFlag(value bool) string {
+	if value {
+		return "1"
+	}
+
+	return "0"
+}
+
+// styleBuilder builds draw.io styles, key=value pairs separated by semicolons in the order they're set.
+type styleBuilder struct {
+	parts []string
+}
+
+func (s *styleBuilder) set(key, value string) {
+	if value == "" {
+		s.parts = append(s.parts, key)
+
+		return
+	}
+
+	s.parts = append(s.parts, key+"="+value)
+}
+
+// setColor sets a color, resolving the theme color codes D2 uses, e.g. B1, to their values.
+func (s *styleBuilder) setColor(key, color string, theme d2themes.Theme) {
+	if color == "" || color == "transparent" {
+		return
+	}
+
+	s.set(key, d2themes.ResolveThemeColor(theme, color))
+}
+
+func (s *styleBuilder) setStroke(width int, dash, opacity float64) {
+	if width > 0 {
+		s.set("strokeWidth", strconv.Itoa(width))
+	}
+
+	if dash > 0 {
+		s.set("dashed", "1")
+	}
+
+	if opacity > 0 && opacity < 1 {
+		s.set("opacity", strconv.Itoa(int(opacity*100)))
+	}
+}
+
+func (s *styleBuilder) String() string {
+	return strings.Join(s.parts, ";") + ";"
+}
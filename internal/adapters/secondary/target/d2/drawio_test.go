@@ -0,0 +1,90 @@
+package d2
+
+import (
+	"context"
+	"encoding/xml"
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"oss.terrastruct.com/d2/d2target"
+)
+
+func TestRenderDrawio(t *testing.T) {
+	t.Parallel()
+
+	target, err := NewTarget(config.D2Config{Pad: 64, Font: "SourceSansPro", Layout: "dagre"})
+	require.NoError(t, err)
+
+	script := []byte(`Shop: {
+  Order Service
+  orders-db: {shape: cylinder}
+  Order Service -> orders-db: uses
+}
+Customer: {shape: person}
+Customer -> Shop.Order Service: places orders
+`)
+
+	out, err := target.RenderDrawio(context.Background(), script, "overview")
+	require.NoError(t, err)
+
+	var file drawioFile
+	require.NoError(t, xml.Unmarshal(out, &file))
+	assert.Equal(t, "overview", file.Diagram.Name)
+
+	cells := make(map[string]drawioCell)
+	for _, cell := range file.Diagram.Model.Cells {
+		cells[cell.ID] = cell
+	}
+
+	require.Contains(t, cells, "Shop")
+	assert.Equal(t, drawioLayerID, cells["Shop"].Parent)
+	assert.Contains(t, cells["Shop"].Style, "container=1")
+
+	require.Contains(t, cells, "Shop.Order Service")
+	assert.Equal(t, "Shop", cells["Shop.Order Service"].Parent)
+	assert.Contains(t, cells["Shop.Order Service"].Style, "fillColor=#")
+
+	require.Contains(t, cells, "Shop.orders-db")
+	assert.Contains(t, cells["Shop.orders-db"].Style, "shape=cylinder3")
+
+	require.Contains(t, cells, "Customer")
+	assert.Contains(t, cells["Customer"].Style, "shape=umlActor")
+
+	var edges []drawioCell
+	for _, cell := range file.Diagram.Model.Cells {
+		if cell.Edge == "1" {
+			edges = append(edges, cell)
+		}
+	}
+
+	require.Len(t, edges, 2)
+	assert.ElementsMatch(t, []string{"Customer", "Shop.Order Service"},
+		[]string{edges[0].Source, edges[1].Source})
+	assert.ElementsMatch(t, []string{"uses", "places orders"}, []string{edges[0].Value, edges[1].Value})
+}
+
+func TestShapeParent(t *testing.T) {
+	t.Parallel()
+
+	shapes := map[string]d2target.Shape{"Shop": {}, "Shop.Orders": {}}
+
+	tests := []struct {
+		id   string
+		want string
+	}{
+		{id: "Shop", want: ""},
+		{id: "Shop.Orders", want: "Shop"},
+		{id: "Shop.Orders.api", want: "Shop.Orders"},
+		{id: `Shop."v1.2"`, want: "Shop"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, shapeParent(tt.id, shapes))
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package d2
+
+import (
+	"strings"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+)
+
+// criticalColor is the stroke color of edges of critical relationships.
+const criticalColor = "#b91c1c"
+
+// accessColors are the stroke colors of edges to datastores by access mode.
+//
+//nolint:gochecknoglobals // Fixed palette of the diagrams.
+var accessColors = map[domain.Access]string{
+	domain.AccessRead:      "#2563eb",
+	domain.AccessWrite:     "#ea580c",
+	domain.AccessReadWrite: "#7c3aed",
+}
+
+// edgeStyle returns the D2 style map of an edge drawn for relationships with the criticality and datastore
+// access, empty when the edge has the default style. Edges get thicker with the criticality, D2 draws edges
+// 2 wide by default. Critical edges are red, other edges to datastores are colored by their access mode.
+func edgeStyle(criticality domain.Criticality, access domain.Access) string {
+	var fields []string
+
+	switch criticality {
+	case domain.CriticalityLow:
+		fields = append(fields, "stroke-width: 1")
+	case domain.CriticalityMedium:
+		fields = append(fields, "stroke-width: 2")
+	case domain.CriticalityHigh:
+		fields = append(fields, "stroke-width: 4")
+	case domain.CriticalityCritical:
+		fields = append(fields, "stroke-width: 6", `stroke: "`+criticalColor+`"`)
+	}
+
+	if color, ok := accessColors[access]; ok && criticality != domain.CriticalityCritical {
+		fields = append(fields, `stroke: "`+color+`"`)
+	}
+
+	if len(fields) == 0 {
+		return ""
+	}
+
+	return "{" + strings.Join(fields, "; ") + "}"
+}
+
+// edgeAttributes returns the inline D2 map of an edge with its classes and style, prefixed with a space.
+func edgeAttributes(classes, style string) string {
+	var fields []string
+
+	if classes != "" {
+		fields = append(fields, "class: "+classes)
+	}
+
+	if style != "" {
+		fields = append(fields, "style: "+style)
+	}
+
+	if len(fields) == 0 {
+		return ""
+	}
+
+	return " {" + strings.Join(fields, "; ") + "}"
+}
+
+// Style returns the D2 style map of the edge, empty when it has the default style.
+func (e OverviewDocsEdge) Style() string {
+	return edgeStyle(e.Criticality, e.Access)
+}
+
+// Attributes returns the inline D2 map of the edge, empty when it has the default style.
+func (e SystemDocsEdge) Attributes() string {
+	return edgeAttributes(e.Classes(), edgeStyle(e.Criticality, e.Access))
+}
+
+// Attributes returns the inline D2 map of the edge, empty when it has the default style.
+func (e ServiceRelationshipsDocsEdge) Attributes() string {
+	return edgeAttributes(classes(e.Planned, false), edgeStyle(e.Criticality, e.Access))
+}
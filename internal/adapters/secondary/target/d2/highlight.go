@@ -0,0 +1,160 @@
+package d2
+
+import (
+	"strings"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+)
+
+// Style classes of diagram nodes and edges.
+const (
+	classPlanned     = "planned"
+	classHighlighted = "highlighted"
+)
+
+// highlighter selects the nodes and edges of overview and system diagrams that get the highlighted class.
+type highlighter struct {
+	services     map[string]struct{}
+	technologies map[string]struct{}
+}
+
+func newHighlighter(cfg config.Highlight) highlighter {
+	return highlighter{
+		services:     foldedSet(cfg.Services),
+		technologies: foldedSet(cfg.Technologies),
+	}
+}
+
+func foldedSet(values []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		if value = strings.ToLower(strings.TrimSpace(value)); value != "" {
+			set[value] = struct{}{}
+		}
+	}
+
+	return set
+}
+
+// service reports whether the service, system or external participant with the name is highlighted.
+func (h highlighter) service(name string) bool {
+	_, ok := h.services[strings.ToLower(strings.TrimSpace(name))]
+
+	return ok
+}
+
+// relationship reports whether the technology of a relationship is highlighted.
+func (h highlighter) relationship(rel domain.Relationship) bool {
+	_, ok := h.technologies[strings.ToLower(strings.TrimSpace(rel.Technology))]
+
+	return ok
+}
+
+// overviewNodes highlights the nodes of highlighted services, systems and participants. A system node is
+// also highlighted when one of the services it groups is.
+func (h highlighter) overviewNodes(nodes, serviceToNode map[string]OverviewDocsNode) {
+	groups := make(map[string]struct{})
+	for name, node := range serviceToNode {
+		if h.service(name) {
+			groups[node.ID] = struct{}{}
+		}
+	}
+
+	for id, node := range nodes {
+		_, grouped := groups[id]
+		node.Highlighted = grouped || h.service(node.Label)
+		nodes[id] = node
+	}
+}
+
+// systemNodes highlights the nodes of highlighted services and participants.
+func (h highlighter) systemNodes(nodes map[string]SystemDocsNode) {
+	for id, node := range nodes {
+		node.Highlighted = h.service(node.Label)
+		nodes[id] = node
+	}
+}
+
+// classes returns the value of the class field of a node or edge with the planned and highlighted classes
+// and the given ones, empty when it has no class.
+func classes(planned, highlighted bool, others ...string) string {
+	var names []string
+
+	if planned {
+		names = append(names, classPlanned)
+	}
+
+	if highlighted {
+		names = append(names, classHighlighted)
+	}
+
+	for _, name := range others {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+
+	switch len(names) {
+	case 0:
+		return ""
+	case 1:
+		return names[0]
+	default:
+		return "[" + strings.Join(names, "; ") + "]"
+	}
+}
+
+// Classes returns the style classes of the node.
+func (n OverviewDocsNode) Classes() string {
+	return classes(n.Planned, n.Highlighted, string(n.Diff))
+}
+
+// Classes returns the style classes of the edge.
+func (e OverviewDocsEdge) Classes() string {
+	return classes(e.Planned, e.Highlighted, string(e.Diff))
+}
+
+// Classes returns the style classes of the node.
+func (n SystemDocsNode) Classes() string {
+	return classes(n.Planned, n.Highlighted)
+}
+
+// Classes returns the style classes of the edge.
+func (e SystemDocsEdge) Classes() string {
+	return classes(e.Planned, e.Highlighted)
+}
+
+func overviewHasHighlighted(nodes []OverviewDocsNode, edges []OverviewDocsEdge) bool {
+	for _, node := range nodes {
+		if node.Highlighted {
+			return true
+		}
+	}
+
+	for _, edge := range edges {
+		if edge.Highlighted {
+			return true
+		}
+	}
+
+	return false
+}
+
+func systemHasHighlighted(payload SystemDocsPayload) bool {
+	for _, nodes := range [][]SystemDocsNode{payload.SystemNodes, payload.ExternalNodes} {
+		for _, node := range nodes {
+			if node.Highlighted {
+				return true
+			}
+		}
+	}
+
+	for _, edge := range payload.Edges {
+		if edge.Highlighted {
+			return true
+		}
+	}
+
+	return false
+}
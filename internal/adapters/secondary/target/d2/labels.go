@@ -0,0 +1,124 @@
+package d2
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/holydocs/holydocs/internal/config"
+)
+
+// EdgeLabel is the data edge label templates are executed with.
+type EdgeLabel struct {
+	// Label is the action of the edge, e.g. requests or pub, after the configured overrides.
+	Label string
+	// Technology lists the technologies of the relationships drawn as the edge.
+	Technology string
+	// Proto lists the protocols of the relationships drawn as the edge.
+	Proto string
+}
+
+// edgeLabeler renders the labels of diagram edges. The zero value keeps the default labels.
+type edgeLabeler struct {
+	template *template.Template
+	actions  map[string]string
+}
+
+func newEdgeLabeler(cfg config.EdgeLabels) (edgeLabeler, error) {
+	labeler := edgeLabeler{actions: make(map[string]string, len(cfg.Actions))}
+
+	for action, label := range cfg.Actions {
+		if label = strings.TrimSpace(label); label != "" {
+			labeler.actions[strings.ToLower(strings.TrimSpace(action))] = label
+		}
+	}
+
+	if strings.TrimSpace(cfg.Template) == "" {
+		return labeler, nil
+	}
+
+	tmpl, err := template.New("edge_label").Parse(cfg.Template)
+	if err != nil {
+		return edgeLabeler{}, fmt.Errorf("%w %q: %w", ErrTemplateParsing, "edge label", err)
+	}
+
+	// Fields that don't exist are only reported when the template is executed.
+	if err := tmpl.Execute(io.Discard, EdgeLabel{}); err != nil {
+		return edgeLabeler{}, fmt.Errorf("%w %q: %w", ErrTemplateParsing, "edge label", err)
+	}
+
+	labeler.template = tmpl
+
+	return labeler, nil
+}
+
+// WithEdgeLabels returns a copy of the target that renders edge labels with the configured overrides and
+// template.
+func (t *Target) WithEdgeLabels(cfg config.EdgeLabels) (*Target, error) {
+	labeler, err := newEdgeLabeler(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	labeled := *t
+	labeled.labels = labeler
+
+	return &labeled, nil
+}
+
+// label returns the label of an edge drawn for relationships with the given default label, technologies and
+// protocols. The label is escaped for double-quoted D2 strings, a template that fails keeps the label.
+func (l edgeLabeler) label(label, technology, proto string) string {
+	if override, ok := l.actions[label]; ok {
+		label = override
+	}
+
+	if l.template != nil {
+		var rendered strings.Builder
+
+		err := l.template.Execute(&rendered, EdgeLabel{Label: label, Technology: technology, Proto: proto})
+		if err == nil {
+			label = strings.TrimSpace(rendered.String())
+		}
+	}
+
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", " ").Replace(label)
+}
+
+func (l edgeLabeler) overview(edges []OverviewDocsEdge) {
+	for i, edge := range edges {
+		edges[i].Label = l.label(edge.Label, edge.Technology, edge.Proto)
+	}
+}
+
+func (l edgeLabeler) system(edges []SystemDocsEdge) {
+	for i, edge := range edges {
+		edges[i].Label = l.label(edge.Label, edge.Technology, edge.Proto)
+	}
+}
+
+func (l edgeLabeler) serviceRelationships(edges []ServiceRelationshipsDocsEdge) {
+	for i, edge := range edges {
+		edges[i].Label = l.label(edge.Label, edge.Technology, edge.Proto)
+	}
+}
+
+// joinedValue adds a value to the comma-separated values of an edge drawn for several relationships.
+func joinedValue(values, value string) string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return values
+	}
+
+	if values == "" {
+		return value
+	}
+
+	if slices.Contains(strings.Split(values, ", "), value) {
+		return values
+	}
+
+	return values + ", " + value
+}
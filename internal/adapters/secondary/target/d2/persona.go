@@ -0,0 +1,212 @@
+package d2
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+)
+
+// PersonaJourney lists the services a persona interacts with across systems.
+type PersonaJourney struct {
+	Persona      string
+	Interactions []PersonaInteraction
+}
+
+// PersonaInteraction is a relationship between a persona and a service, declared by the service.
+type PersonaInteraction struct {
+	Service     string
+	System      string
+	Action      domain.RelationshipAction
+	Label       string
+	Technology  string
+	Description string
+	Planned     bool
+}
+
+// PersonaNode represents a service the persona reaches, inside its system when it has one.
+type PersonaNode struct {
+	ID      string
+	Label   string
+	Planned bool
+}
+
+// PersonaSystem groups the services of a system reached by the persona.
+type PersonaSystem struct {
+	ID       string
+	Label    string
+	Services []PersonaNode
+}
+
+// PersonaEdge represents an interaction, oriented like relationships in the other diagrams. Node IDs are
+// qualified with the ID of their system.
+type PersonaEdge struct {
+	From    string
+	To      string
+	Label   string
+	Planned bool
+}
+
+// PersonaPayload represents the data structure for the persona template.
+type PersonaPayload struct {
+	PersonID    string
+	PersonLabel string
+	Systems     []PersonaSystem
+	Services    []PersonaNode
+	Edges       []PersonaEdge
+	HasPlanned  bool
+}
+
+// BuildPersonaJourneys aggregates the relationships services declare with persons into one journey per
+// persona, sorted by persona. Interactions are sorted by system and service, services without a system last.
+func BuildPersonaJourneys(schema domain.Schema) []PersonaJourney {
+	plannedServices := plannedServiceNames(schema.Services)
+	byPersona := make(map[string]*PersonaJourney)
+
+	for _, service := range schema.Services {
+		for _, rel := range service.Relationships {
+			if !rel.Person || rel.Participant == "" {
+				continue
+			}
+
+			journey, ok := byPersona[rel.Participant]
+			if !ok {
+				journey = &PersonaJourney{Persona: rel.Participant}
+				byPersona[rel.Participant] = journey
+			}
+
+			journey.Interactions = append(journey.Interactions, PersonaInteraction{
+				Service:     service.Info.Name,
+				System:      service.Info.System,
+				Action:      rel.Action,
+				Label:       relationshipLabel(rel),
+				Technology:  rel.Technology,
+				Description: rel.Description,
+				Planned:     isPlannedRelationship(service, rel, plannedServices),
+			})
+		}
+	}
+
+	journeys := make([]PersonaJourney, 0, len(byPersona))
+	for _, journey := range byPersona {
+		sort.SliceStable(journey.Interactions, func(i, j int) bool {
+			a, b := journey.Interactions[i], journey.Interactions[j]
+			if (a.System == "") != (b.System == "") {
+				return b.System == ""
+			}
+
+			if a.System != b.System {
+				return a.System < b.System
+			}
+
+			return a.Service < b.Service
+		})
+
+		journeys = append(journeys, *journey)
+	}
+
+	sort.Slice(journeys, func(i, j int) bool {
+		return journeys[i].Persona < journeys[j].Persona
+	})
+
+	return journeys
+}
+
+// GeneratePersonaDiagramScript generates the D2 script of the services a persona reaches, grouped by system.
+func (t *Target) GeneratePersonaDiagramScript(schema domain.Schema, journey PersonaJourney) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := t.personaTemplate.Execute(&buf, buildPersonaPayload(schema, journey)); err != nil {
+		return nil, fmt.Errorf("execute persona template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// GeneratePersonaDiagram generates the diagram of the services a persona reaches.
+func (t *Target) GeneratePersonaDiagram(ctx context.Context, schema domain.Schema,
+	journey PersonaJourney) ([]byte, error) {
+	script, err := t.GeneratePersonaDiagramScript(schema, journey)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.RenderSchema(ctx, domain.FormattedSchema{Type: targetType, Data: script})
+}
+
+type personaEdgeKey struct {
+	from, to, label string
+}
+
+func buildPersonaPayload(schema domain.Schema, journey PersonaJourney) PersonaPayload {
+	ids := newNodeIDs(schema.Services)
+	payload := PersonaPayload{PersonID: externalNodeID(journey.Persona), PersonLabel: journey.Persona}
+
+	systems := make(map[string]*PersonaSystem)
+	var systemNames []string
+	nodes := make(map[string]*PersonaNode)
+	qualifiedIDs := make(map[string]string)
+	edges := make(map[personaEdgeKey]*PersonaEdge)
+	var keys []personaEdgeKey
+
+	for _, interaction := range journey.Interactions {
+		node, ok := nodes[interaction.Service]
+		if !ok {
+			node = &PersonaNode{ID: ids.service(interaction.Service), Label: interaction.Service, Planned: true}
+			nodes[interaction.Service] = node
+			qualifiedIDs[interaction.Service] = node.ID
+
+			if interaction.System != "" {
+				system, exists := systems[interaction.System]
+				if !exists {
+					system = &PersonaSystem{ID: ids.system(interaction.System), Label: interaction.System}
+					systems[interaction.System] = system
+					systemNames = append(systemNames, interaction.System)
+				}
+
+				qualifiedIDs[interaction.Service] = system.ID + "." + node.ID
+			}
+		}
+		node.Planned = node.Planned && interaction.Planned
+
+		from, to := orientedEdge(qualifiedIDs[interaction.Service], payload.PersonID, interaction.Action)
+		key := personaEdgeKey{from: from, to: to, label: interaction.Label}
+
+		edge, exists := edges[key]
+		if !exists {
+			edge = &PersonaEdge{From: from, To: to, Label: interaction.Label}
+			edges[key] = edge
+			keys = append(keys, key)
+		}
+		edge.Planned = plannedEdge(interaction.Planned, exists, edge.Planned)
+	}
+
+	// Interactions are sorted by system and service, so nodes keep that order.
+	for _, interaction := range journey.Interactions {
+		node, ok := nodes[interaction.Service]
+		if !ok {
+			continue
+		}
+		delete(nodes, interaction.Service)
+
+		payload.HasPlanned = payload.HasPlanned || node.Planned
+
+		if interaction.System == "" {
+			payload.Services = append(payload.Services, *node)
+		} else {
+			systems[interaction.System].Services = append(systems[interaction.System].Services, *node)
+		}
+	}
+
+	for _, name := range systemNames {
+		payload.Systems = append(payload.Systems, *systems[name])
+	}
+
+	for _, key := range keys {
+		payload.Edges = append(payload.Edges, *edges[key])
+		payload.HasPlanned = payload.HasPlanned || edges[key].Planned
+	}
+
+	return payload
+}
@@ -0,0 +1,78 @@
+package d2
+
+import (
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func personaSchema() domain.Schema {
+	return domain.Schema{Services: []domain.Service{
+		{
+			Info: domain.ServiceInfo{Name: "Reports Service", System: "Analytics"},
+			Relationships: []domain.Relationship{
+				{Action: domain.RelationshipActionReplies, Participant: "Data Analyst", Person: true,
+					Technology: "http"},
+				{Action: domain.RelationshipActionSends, Participant: "Data Analyst", Person: true,
+					Technology: "email", Planned: true},
+				{Action: domain.RelationshipActionUses, Participant: "postgres"},
+			},
+		},
+		{
+			Info: domain.ServiceInfo{Name: "Export Service"},
+			Relationships: []domain.Relationship{
+				{Action: domain.RelationshipActionReplies, Participant: "Data Analyst", Person: true},
+			},
+		},
+		{
+			Info: domain.ServiceInfo{Name: "Dashboard Service", System: "Analytics"},
+			Relationships: []domain.Relationship{
+				{Action: domain.RelationshipActionReplies, Participant: "Data Analyst", Person: true},
+				{Action: domain.RelationshipActionReplies, Participant: "Support Agent", Person: true},
+			},
+		},
+	}}
+}
+
+func TestBuildPersonaJourneys(t *testing.T) {
+	t.Parallel()
+
+	journeys := BuildPersonaJourneys(personaSchema())
+	require.Len(t, journeys, 2)
+	assert.Equal(t, "Data Analyst", journeys[0].Persona)
+	assert.Equal(t, "Support Agent", journeys[1].Persona)
+
+	services := make([]string, 0, len(journeys[0].Interactions))
+	for _, interaction := range journeys[0].Interactions {
+		services = append(services, interaction.Service)
+	}
+	assert.Equal(t, []string{"Dashboard Service", "Reports Service", "Reports Service", "Export Service"}, services)
+
+	assert.Equal(t, "requests", journeys[0].Interactions[1].Label)
+	assert.Equal(t, "sends", journeys[0].Interactions[2].Label)
+	assert.True(t, journeys[0].Interactions[2].Planned)
+}
+
+func TestGeneratePersonaDiagramScript(t *testing.T) {
+	t.Parallel()
+
+	target, err := NewTarget(config.D2Config{})
+	require.NoError(t, err)
+
+	schema := personaSchema()
+	script, err := target.GeneratePersonaDiagramScript(schema, BuildPersonaJourneys(schema)[0])
+	require.NoError(t, err)
+
+	content := string(script)
+	assert.Contains(t, content, `external_data-analyst: "🧑‍💻 Data Analyst"`)
+	assert.Contains(t, content, `system_analytics.service_reports-service: "Reports Service"`)
+	assert.Contains(t, content, `service_export-service: "Export Service"`)
+	assert.Contains(t, content,
+		`external_data-analyst -> system_analytics.service_reports-service: "requests"`)
+	assert.Contains(t, content,
+		`system_analytics.service_reports-service -> external_data-analyst: "sends" {class: planned}`)
+	assert.NotContains(t, content, "postgres")
+}
@@ -0,0 +1,94 @@
+package d2
+
+import "github.com/holydocs/holydocs/internal/core/domain"
+
+// plannedServiceNames returns the names of services marked as planned.
+func plannedServiceNames(services []domain.Service) map[string]struct{} {
+	planned := make(map[string]struct{})
+	for _, svc := range services {
+		if svc.Info.Planned {
+			planned[svc.Info.Name] = struct{}{}
+		}
+	}
+
+	return planned
+}
+
+// isPlannedRelationship reports whether a relationship does not exist yet, either because it is
+// marked as planned itself or because one of the services it connects is planned.
+func isPlannedRelationship(service domain.Service, rel domain.Relationship,
+	plannedServices map[string]struct{}) bool {
+	if rel.Planned || service.Info.Planned {
+		return true
+	}
+
+	_, targetPlanned := plannedServices[rel.Participant]
+
+	return targetPlanned
+}
+
+// plannedEdge resolves the planned flag of an edge that may already be present in an edge set,
+// an existing actual edge always wins over a planned one with the same key.
+func plannedEdge(planned, exists, existingPlanned bool) bool {
+	if !exists {
+		return planned
+	}
+
+	return planned && existingPlanned
+}
+
+func overviewHasPlanned(nodes []OverviewDocsNode, edges []OverviewDocsEdge) bool {
+	for _, node := range nodes {
+		if node.Planned {
+			return true
+		}
+	}
+
+	for _, edge := range edges {
+		if edge.Planned {
+			return true
+		}
+	}
+
+	return false
+}
+
+func serviceRelationshipsHavePlanned(payload ServiceRelationshipsDocsPayload) bool {
+	for _, node := range payload.Services {
+		if node.Planned {
+			return true
+		}
+	}
+
+	for _, node := range payload.ExternalNodes {
+		if node.Planned {
+			return true
+		}
+	}
+
+	for _, edge := range payload.Edges {
+		if edge.Planned {
+			return true
+		}
+	}
+
+	return false
+}
+
+func systemHasPlanned(payload SystemDocsPayload) bool {
+	for _, nodes := range [][]SystemDocsNode{payload.SystemNodes, payload.ExternalNodes} {
+		for _, node := range nodes {
+			if node.Planned {
+				return true
+			}
+		}
+	}
+
+	for _, edge := range payload.Edges {
+		if edge.Planned {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,155 @@
+package d2
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+)
+
+// RuntimeNode represents a service, or a participant of its relationships, on the runtime overlay.
+type RuntimeNode struct {
+	ID       string
+	Label    string
+	External bool
+}
+
+// RuntimeEdge represents a relationship annotated with its observed metrics. Edges breaching an objective
+// are drawn red, edges meeting their objectives green.
+type RuntimeEdge struct {
+	From      string
+	To        string
+	Label     string
+	Breached  bool
+	WithinSLO bool
+}
+
+// RuntimeOverlayPayload represents the data structure for the runtime overlay template.
+type RuntimeOverlayPayload struct {
+	Nodes        []RuntimeNode
+	Edges        []RuntimeEdge
+	HasBreached  bool
+	HasWithinSLO bool
+}
+
+// GenerateRuntimeOverlayScript generates the D2 script of the runtime overlay, the relationships with
+// observed metrics labeled with them, nil without metrics.
+func (t *Target) GenerateRuntimeOverlayScript(schema domain.Schema,
+	metrics []domain.RelationshipMetrics) ([]byte, error) {
+	payload := BuildRuntimeOverlay(schema, metrics)
+	if len(payload.Edges) == 0 {
+		return nil, nil
+	}
+
+	var buf bytes.Buffer
+	if err := t.runtimeTemplate.Execute(&buf, payload); err != nil {
+		return nil, fmt.Errorf("execute runtime overlay template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// GenerateRuntimeOverlayDiagram generates the runtime overlay diagram, nil without metrics.
+func (t *Target) GenerateRuntimeOverlayDiagram(ctx context.Context, schema domain.Schema,
+	metrics []domain.RelationshipMetrics) ([]byte, error) {
+	script, err := t.GenerateRuntimeOverlayScript(schema, metrics)
+	if err != nil || script == nil {
+		return nil, err
+	}
+
+	return t.RenderSchema(ctx, domain.FormattedSchema{Type: targetType, Data: script})
+}
+
+// BuildRuntimeOverlay draws the services and participants of the relationships with metrics, participants
+// that aren't documented services are drawn as external nodes.
+func BuildRuntimeOverlay(schema domain.Schema, metrics []domain.RelationshipMetrics) RuntimeOverlayPayload {
+	ids := newNodeIDs(schema.Services)
+
+	services := make(map[string]struct{}, len(schema.Services))
+	for _, service := range schema.Services {
+		services[service.Info.Name] = struct{}{}
+	}
+
+	nodes := make(map[string]RuntimeNode)
+	node := func(name string) string {
+		n := RuntimeNode{ID: ids.service(name), Label: name}
+		if _, ok := services[name]; !ok {
+			n = RuntimeNode{ID: externalNodeID(name), Label: name, External: true}
+		}
+		nodes[n.ID] = n
+
+		return n.ID
+	}
+
+	payload := RuntimeOverlayPayload{}
+
+	for _, m := range metrics {
+		edge := RuntimeEdge{
+			From:     node(m.Service),
+			To:       node(m.Participant),
+			Label:    RuntimeMetricsLabel(m),
+			Breached: m.Breached(),
+		}
+		edge.WithinSLO = !edge.Breached && (m.LatencySLO > 0 && m.P95Latency != nil ||
+			m.ErrorRateSLO > 0 && m.ErrorRate != nil)
+
+		payload.Edges = append(payload.Edges, edge)
+		payload.HasBreached = payload.HasBreached || edge.Breached
+		payload.HasWithinSLO = payload.HasWithinSLO || edge.WithinSLO
+	}
+
+	for _, n := range nodes {
+		payload.Nodes = append(payload.Nodes, n)
+	}
+
+	sort.Slice(payload.Nodes, func(i, j int) bool {
+		return payload.Nodes[i].ID < payload.Nodes[j].ID
+	})
+
+	sort.SliceStable(payload.Edges, func(i, j int) bool {
+		if payload.Edges[i].From != payload.Edges[j].From {
+			return payload.Edges[i].From < payload.Edges[j].From
+		}
+
+		return payload.Edges[i].To < payload.Edges[j].To
+	})
+
+	return payload
+}
+
+// RuntimeMetricsLabel returns the observed metrics of a relationship, e.g. "p95 420 ms · 0.2% errors".
+func RuntimeMetricsLabel(m domain.RelationshipMetrics) string {
+	var parts []string
+
+	if m.P95Latency != nil {
+		parts = append(parts, "p95 "+FormatLatency(*m.P95Latency))
+	}
+
+	if m.ErrorRate != nil {
+		parts = append(parts, FormatErrorRate(*m.ErrorRate)+" errors")
+	}
+
+	return strings.Join(parts, " · ")
+}
+
+// FormatLatency formats a latency in milliseconds below a second and in seconds above, e.g. 420 ms or 1.5 s.
+func FormatLatency(latency time.Duration) string {
+	if latency < time.Second {
+		return strconv.FormatInt(latency.Round(time.Millisecond).Milliseconds(), 10) + " ms"
+	}
+
+	return strconv.FormatFloat(latency.Round(100*time.Millisecond).Seconds(), 'f', -1, 64) + " s"
+}
+
+// FormatErrorRate formats a ratio of failed requests as a percentage, e.g. 0.25%.
+func FormatErrorRate(rate float64) string {
+	const hundredths = 100
+
+	return strconv.FormatFloat(math.Round(rate*hundredths*hundredths)/hundredths, 'f', -1, 64) + "%"
+}
@@ -0,0 +1,73 @@
+package d2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runtimeMetrics() []domain.RelationshipMetrics {
+	latency, slowLatency, errorRate := 420*time.Millisecond, 1540*time.Millisecond, 0.0025
+
+	return []domain.RelationshipMetrics{
+		{Service: "Order Service", Participant: "Stripe", P95Latency: &slowLatency, LatencySLO: time.Second},
+		{Service: "Order Service", Participant: "Payment Service", P95Latency: &latency, ErrorRate: &errorRate,
+			LatencySLO: 500 * time.Millisecond, ErrorRateSLO: 0.01},
+		{Service: "Payment Service", Participant: "Ledger Service", ErrorRate: &errorRate},
+	}
+}
+
+func TestBuildRuntimeOverlay(t *testing.T) {
+	t.Parallel()
+
+	schema := domain.Schema{Services: []domain.Service{
+		{Info: domain.ServiceInfo{Name: "Order Service"}},
+		{Info: domain.ServiceInfo{Name: "Payment Service"}},
+		{Info: domain.ServiceInfo{Name: "Ledger Service"}},
+	}}
+
+	payload := BuildRuntimeOverlay(schema, runtimeMetrics())
+	assert.Equal(t, []RuntimeNode{
+		{ID: "external_stripe", Label: "Stripe", External: true},
+		{ID: "service_ledger-service", Label: "Ledger Service"},
+		{ID: "service_order-service", Label: "Order Service"},
+		{ID: "service_payment-service", Label: "Payment Service"},
+	}, payload.Nodes)
+	assert.Equal(t, []RuntimeEdge{
+		{From: "service_order-service", To: "external_stripe", Label: "p95 1.5 s", Breached: true},
+		{From: "service_order-service", To: "service_payment-service", Label: "p95 420 ms · 0.25% errors",
+			WithinSLO: true},
+		{From: "service_payment-service", To: "service_ledger-service", Label: "0.25% errors"},
+	}, payload.Edges)
+	assert.True(t, payload.HasBreached)
+	assert.True(t, payload.HasWithinSLO)
+}
+
+func TestGenerateRuntimeOverlayDiagram(t *testing.T) {
+	t.Parallel()
+
+	target, err := NewTarget(config.D2Config{Pad: 64, Font: "SourceSansPro", Layout: "elk"})
+	require.NoError(t, err)
+
+	script, err := target.GenerateRuntimeOverlayScript(domain.Schema{}, nil)
+	require.NoError(t, err)
+	assert.Nil(t, script, "no overlay without metrics")
+
+	script, err = target.GenerateRuntimeOverlayScript(domain.Schema{}, runtimeMetrics())
+	require.NoError(t, err)
+
+	content := string(script)
+	assert.Contains(t, content, `external_order-service -> external_stripe: "p95 1.5 s" {
+  class: breached
+}`)
+	assert.Contains(t, content, `external_payment-service -> external_ledger-service: "0.25% errors"`+"\n")
+
+	diagram, err := target.GenerateRuntimeOverlayDiagram(context.Background(), domain.Schema{}, runtimeMetrics())
+	require.NoError(t, err)
+	assert.Contains(t, string(diagram), "<svg")
+}
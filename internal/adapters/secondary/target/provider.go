@@ -18,5 +18,10 @@ func NewTargetProvider(i do.Injector) (domain.Target, error) {
 		return nil, fmt.Errorf("creating D2 target: %w", err)
 	}
 
+	target, err = target.WithEdgeLabels(cfg.Diagram.EdgeLabels)
+	if err != nil {
+		return nil, fmt.Errorf("configuring D2 edge labels: %w", err)
+	}
+
 	return target, nil
 }
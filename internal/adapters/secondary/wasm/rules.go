@@ -0,0 +1,113 @@
+// Package wasm checks custom validation rules implemented by WebAssembly modules.
+//
+// A rule module is a WASI command, e.g. built with GOOS=wasip1 GOARCH=wasm. It reads a single JSON input
+// from its standard input and writes its findings as a single JSON document to its standard output. Modules
+// run sandboxed, without access to the filesystem, the network or the environment.
+package wasm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	do "github.com/samber/do/v2"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+)
+
+// ContractVersion is the version of the input contract sent to rule modules.
+const ContractVersion = 1
+
+// Limits of a single rule check.
+const (
+	checkTimeout = 30 * time.Second
+	// memoryLimitPages limits modules to 256 MiB, a page is 64 KiB.
+	memoryLimitPages = 4096
+)
+
+// input is the JSON document written to the standard input of a rule module.
+type input struct {
+	ContractVersion int               `json:"contract_version"`
+	Rule            string            `json:"rule"`
+	Config          map[string]string `json:"config,omitempty"`
+	Schema          domain.Schema     `json:"schema"`
+}
+
+// output is the JSON document a rule module writes to its standard output.
+type output struct {
+	Findings []struct {
+		Subject string `json:"subject"`
+		Message string `json:"message"`
+	} `json:"findings"`
+}
+
+// Rules runs rule modules with the wazero runtime, which needs no cgo.
+type Rules struct{}
+
+func NewRules(_ do.Injector) (*Rules, error) {
+	return &Rules{}, nil
+}
+
+// Check runs the module of the rule over the schema and returns its findings.
+func (r *Rules) Check(ctx context.Context, rule domain.CustomRule, schema domain.Schema) ([]domain.Finding, error) {
+	module, err := os.ReadFile(rule.Module)
+	if err != nil {
+		return nil, fmt.Errorf("reading module: %w", err)
+	}
+
+	in, err := json.Marshal(input{
+		ContractVersion: ContractVersion,
+		Rule:            string(rule.Name),
+		Config:          rule.Config,
+		Schema:          schema,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshaling rule input: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	runtime := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().
+		WithCloseOnContextDone(true).
+		WithMemoryLimitPages(memoryLimitPages))
+	defer runtime.Close(ctx)
+
+	wasi_snapshot_preview1.MustInstantiate(ctx, runtime)
+
+	compiled, err := runtime.CompileModule(ctx, module)
+	if err != nil {
+		return nil, fmt.Errorf("compiling module %s: %w", rule.Module, err)
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	config := wazero.NewModuleConfig().
+		WithName(string(rule.Name)).
+		WithArgs(string(rule.Name)).
+		WithStdin(bytes.NewReader(in)).
+		WithStdout(&stdout).
+		WithStderr(&stderr)
+
+	// Commands run when instantiated, exiting with status 0 isn't an error.
+	if _, err := runtime.InstantiateModule(ctx, compiled, config); err != nil {
+		return nil, fmt.Errorf("running module %s: %w: %s", rule.Module, err, strings.TrimSpace(stderr.String()))
+	}
+
+	var out output
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		return nil, fmt.Errorf("invalid output of module %s: %w", rule.Module, err)
+	}
+
+	findings := make([]domain.Finding, 0, len(out.Findings))
+	for _, finding := range out.Findings {
+		findings = append(findings, domain.Finding{Rule: rule.Name, Subject: finding.Subject, Message: finding.Message})
+	}
+
+	return findings, nil
+}
@@ -0,0 +1,67 @@
+package wasm
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// buildModule compiles the rule module in testdata to WebAssembly.
+func buildModule(t *testing.T) string {
+	t.Helper()
+
+	goBin := filepath.Join(runtime.GOROOT(), "bin", "go")
+	if _, err := os.Stat(goBin); err != nil {
+		t.Skip("go toolchain is not available")
+	}
+
+	module := filepath.Join(t.TempDir(), "description_rule.wasm")
+
+	cmd := exec.Command(goBin, "build", "-o", module, "./testdata/description_rule")
+	cmd.Env = append(os.Environ(), "GOOS=wasip1", "GOARCH=wasm")
+
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(output))
+
+	return module
+}
+
+func TestRules_Check(t *testing.T) {
+	t.Parallel()
+
+	module := buildModule(t)
+
+	schema := domain.Schema{Services: []domain.Service{
+		{Info: domain.ServiceInfo{Name: "Billing Service", Description: "Bills customers every month."}},
+		{Info: domain.ServiceInfo{Name: "Fax Service", Description: "Faxes."}},
+	}}
+
+	rule := domain.CustomRule{
+		Name:   "description-length",
+		Module: module,
+		Config: map[string]string{"min_words": "3"},
+	}
+
+	findings, err := (&Rules{}).Check(context.Background(), rule, schema)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Finding{{
+		Rule:    "description-length",
+		Subject: "Fax Service",
+		Message: "description has 1 words, at least 3 are required",
+	}}, findings)
+
+	rule.Config = nil
+	_, err = (&Rules{}).Check(context.Background(), rule, schema)
+	require.ErrorContains(t, err, "min_words is not a number")
+
+	rule.Module = filepath.Join(t.TempDir(), "missing.wasm")
+	_, err = (&Rules{}).Check(context.Background(), rule, schema)
+	require.Error(t, err)
+}
@@ -0,0 +1,55 @@
+// Command description_rule is a rule module reporting services with short descriptions, built by the tests
+// with GOOS=wasip1 GOARCH=wasm.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+type input struct {
+	Config map[string]string `json:"config"`
+	Schema struct {
+		Services []struct {
+			Info struct {
+				Name        string `json:"name"`
+				Description string `json:"description"`
+			} `json:"info"`
+		} `json:"services"`
+	} `json:"schema"`
+}
+
+type finding struct {
+	Subject string `json:"subject"`
+	Message string `json:"message"`
+}
+
+func main() {
+	var in input
+	if err := json.NewDecoder(os.Stdin).Decode(&in); err != nil {
+		fmt.Fprintln(os.Stderr, "decoding input:", err)
+		os.Exit(1)
+	}
+
+	minWords, err := strconv.Atoi(in.Config["min_words"])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "min_words is not a number")
+		os.Exit(2)
+	}
+
+	findings := []finding{}
+
+	for _, service := range in.Schema.Services {
+		if words := len(strings.Fields(service.Info.Description)); words < minWords {
+			findings = append(findings, finding{
+				Subject: service.Info.Name,
+				Message: fmt.Sprintf("description has %d words, at least %d are required", words, minWords),
+			})
+		}
+	}
+
+	_ = json.NewEncoder(os.Stdout).Encode(map[string]any{"findings": findings})
+}
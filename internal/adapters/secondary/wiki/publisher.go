@@ -0,0 +1,378 @@
+// Package wiki publishes generated documentation to wikis kept in git repositories, as GitLab and
+// Bitbucket wikis are.
+package wiki
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	do "github.com/samber/do/v2"
+)
+
+// manifestFileName lists the files published by the last run, so pages removed from the documentation
+// are removed from the wiki without touching pages maintained by hand.
+const manifestFileName = ".holydocs-wiki"
+
+// diagramsDirName is the directory of diagrams in the generated documentation.
+const diagramsDirName = "diagrams"
+
+// Permissions of published files.
+const (
+	dirPerm  = 0o755
+	filePerm = 0o644
+)
+
+//nolint:gochecknoglobals // Matches the destination of Markdown links and images.
+var linkPattern = regexp.MustCompile(`\]\(([^)\s]+)\)`)
+
+// provider holds the conventions of a wiki hosting.
+type provider struct {
+	// homePage is the page shown as the wiki home.
+	homePage string
+	// explicitRelative prefixes links to pages below the current directory with "./", GitLab resolves
+	// bare page names from the wiki root.
+	explicitRelative bool
+}
+
+//nolint:gochecknoglobals // Conventions of the supported wiki providers.
+var providers = map[domain.WikiProvider]provider{
+	domain.WikiProviderGitLab:    {homePage: "home", explicitRelative: true},
+	domain.WikiProviderBitbucket: {homePage: "Home"},
+}
+
+// Publisher pushes generated pages and diagrams to wiki repositories with the git command.
+type Publisher struct{}
+
+func NewPublisher(_ do.Injector) (*Publisher, error) {
+	return &Publisher{}, nil
+}
+
+// Publish clones the wiki repository, replaces the previously published files with the generated pages and
+// diagrams, then commits and pushes the changes. Links between pages are rewritten to wiki page names.
+func (p *Publisher) Publish(ctx context.Context, req domain.PublishWikiRequest) (domain.PublishWikiReply, error) {
+	prov, ok := providers[req.Provider]
+	if !ok {
+		return domain.PublishWikiReply{}, fmt.Errorf("%w: wiki provider %q, expected one of %v",
+			domain.ErrUnsupportedValue, req.Provider, domain.WikiProviders())
+	}
+
+	workDir, err := os.MkdirTemp("", "holydocs-wiki-")
+	if err != nil {
+		return domain.PublishWikiReply{}, fmt.Errorf("creating working directory: %w", err)
+	}
+	defer os.RemoveAll(workDir)
+
+	repo := gitRepo{dir: filepath.Join(workDir, "wiki"), url: req.URL}
+
+	cloneArgs := []string{"clone", "--depth", "1"}
+	if req.Branch != "" {
+		cloneArgs = append(cloneArgs, "--branch", req.Branch)
+	}
+
+	if _, err := repo.run(ctx, workDir, append(cloneArgs, req.URL, repo.dir)...); err != nil {
+		return domain.PublishWikiReply{}, err
+	}
+
+	files, err := collectFiles(req.SourceDir)
+	if err != nil {
+		return domain.PublishWikiReply{}, err
+	}
+
+	mapping := newPageMapping(req, prov, files)
+
+	if err := removeStaleFiles(repo.dir, req.Dir, mapping.targets()); err != nil {
+		return domain.PublishWikiReply{}, err
+	}
+
+	if err := mapping.write(req.SourceDir, repo.dir); err != nil {
+		return domain.PublishWikiReply{}, err
+	}
+
+	if err := writeManifest(repo.dir, req.Dir, mapping.targets()); err != nil {
+		return domain.PublishWikiReply{}, err
+	}
+
+	if _, err := repo.run(ctx, repo.dir, "add", "--all"); err != nil {
+		return domain.PublishWikiReply{}, err
+	}
+
+	status, err := repo.run(ctx, repo.dir, "status", "--porcelain")
+	if err != nil {
+		return domain.PublishWikiReply{}, err
+	}
+
+	reply := domain.PublishWikiReply{Changes: lines(status)}
+	if len(reply.Changes) == 0 || req.DryRun {
+		return reply, nil
+	}
+
+	commitArgs := []string{
+		"-c", "user.name=" + req.AuthorName,
+		"-c", "user.email=" + req.AuthorEmail,
+		"commit", "--quiet", "--message", req.Message,
+	}
+	if _, err := repo.run(ctx, repo.dir, commitArgs...); err != nil {
+		return domain.PublishWikiReply{}, err
+	}
+
+	if _, err := repo.run(ctx, repo.dir, "push", "--quiet", "origin", "HEAD"); err != nil {
+		return domain.PublishWikiReply{}, err
+	}
+
+	reply.Pushed = true
+
+	return reply, nil
+}
+
+// collectFiles returns the pages and diagrams of the generated documentation as slash-separated paths.
+func collectFiles(sourceDir string) ([]string, error) {
+	var files []string
+
+	err := filepath.WalkDir(sourceDir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(sourceDir, filePath)
+		if err != nil {
+			return fmt.Errorf("resolving %s: %w", filePath, err)
+		}
+
+		rel = filepath.ToSlash(rel)
+		if strings.HasSuffix(rel, ".md") || strings.HasPrefix(rel, diagramsDirName+"/") {
+			files = append(files, rel)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading documentation in %s: %w", sourceDir, err)
+	}
+
+	if !slices.ContainsFunc(files, isPage) {
+		return nil, fmt.Errorf("%w: no pages found in %s", domain.ErrPublishFailed, sourceDir)
+	}
+
+	return files, nil
+}
+
+func isPage(file string) bool {
+	return strings.HasSuffix(file, ".md")
+}
+
+// pageMapping maps generated files to files of the wiki repository.
+type pageMapping struct {
+	provider provider
+	// files maps generated paths to wiki repository paths.
+	files map[string]string
+}
+
+func newPageMapping(req domain.PublishWikiRequest, prov provider, files []string) pageMapping {
+	m := pageMapping{provider: prov, files: make(map[string]string, len(files))}
+
+	for _, file := range files {
+		target := file
+
+		switch name, ok := req.Pages[file]; {
+		case ok && name != "":
+			target = strings.TrimSuffix(name, ".md") + ".md"
+		case file == "README.md":
+			target = prov.homePage + ".md"
+		}
+
+		m.files[file] = path.Join(filepath.ToSlash(req.Dir), target)
+	}
+
+	return m
+}
+
+// targets returns the sorted wiki repository paths of all published files.
+func (m pageMapping) targets() []string {
+	targets := make([]string, 0, len(m.files))
+	for _, target := range m.files {
+		targets = append(targets, target)
+	}
+
+	slices.Sort(targets)
+
+	return targets
+}
+
+func (m pageMapping) write(sourceDir, repoDir string) error {
+	for file, target := range m.files {
+		content, err := os.ReadFile(filepath.Join(sourceDir, filepath.FromSlash(file)))
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", file, err)
+		}
+
+		if isPage(file) {
+			content = m.rewriteLinks(file, content)
+		}
+
+		targetPath := filepath.Join(repoDir, filepath.FromSlash(target))
+		if err := os.MkdirAll(filepath.Dir(targetPath), dirPerm); err != nil {
+			return fmt.Errorf("creating directory of %s: %w", target, err)
+		}
+
+		if err := os.WriteFile(targetPath, content, filePerm); err != nil {
+			return fmt.Errorf("writing %s: %w", target, err)
+		}
+	}
+
+	return nil
+}
+
+// rewriteLinks points relative links of a page to the published files. Links to pages lose their
+// extension, as wikis address pages by name.
+func (m pageMapping) rewriteLinks(page string, content []byte) []byte {
+	return linkPattern.ReplaceAllFunc(content, func(match []byte) []byte {
+		destination := string(match[2 : len(match)-1])
+
+		if rewritten, ok := m.rewriteLink(page, destination); ok {
+			return []byte("](" + rewritten + ")")
+		}
+
+		return match
+	})
+}
+
+func (m pageMapping) rewriteLink(page, destination string) (string, bool) {
+	if strings.Contains(destination, ":") || strings.HasPrefix(destination, "/") ||
+		strings.HasPrefix(destination, "#") {
+		return "", false
+	}
+
+	linked, fragment, _ := strings.Cut(destination, "#")
+
+	target, ok := m.files[path.Join(path.Dir(page), linked)]
+	if !ok {
+		return "", false
+	}
+
+	rel := relativePath(path.Dir(m.files[page]), target)
+
+	if isPage(target) {
+		rel = strings.TrimSuffix(rel, ".md")
+		if m.provider.explicitRelative && !strings.HasPrefix(rel, "../") {
+			rel = "./" + rel
+		}
+	}
+
+	if fragment != "" {
+		rel += "#" + fragment
+	}
+
+	return rel, true
+}
+
+// relativePath returns the slash-separated path of target relative to the directory dir.
+func relativePath(dir, target string) string {
+	rel, err := filepath.Rel(filepath.FromSlash(dir), filepath.FromSlash(target))
+	if err != nil {
+		return target
+	}
+
+	return filepath.ToSlash(rel)
+}
+
+// removeStaleFiles removes the files listed in the manifest of the last run that are not published again.
+func removeStaleFiles(repoDir, dir string, targets []string) error {
+	content, err := os.ReadFile(filepath.Join(repoDir, filepath.FromSlash(dir), manifestFileName))
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("reading manifest: %w", err)
+	}
+
+	for _, file := range lines(string(content)) {
+		if slices.Contains(targets, file) || !filepath.IsLocal(filepath.FromSlash(file)) {
+			continue
+		}
+
+		if err := os.Remove(filepath.Join(repoDir, filepath.FromSlash(file))); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("removing %s: %w", file, err)
+		}
+	}
+
+	return nil
+}
+
+func writeManifest(repoDir, dir string, targets []string) error {
+	manifestPath := filepath.Join(repoDir, filepath.FromSlash(dir), manifestFileName)
+
+	if err := os.MkdirAll(filepath.Dir(manifestPath), dirPerm); err != nil {
+		return fmt.Errorf("creating manifest directory: %w", err)
+	}
+
+	if err := os.WriteFile(manifestPath, []byte(strings.Join(targets, "\n")+"\n"), filePerm); err != nil {
+		return fmt.Errorf("writing manifest: %w", err)
+	}
+
+	return nil
+}
+
+// gitRepo runs git commands for a wiki repository.
+type gitRepo struct {
+	dir string
+	url string
+}
+
+// run runs git in the directory and returns its output. Credentials of the repository URL are removed
+// from errors.
+func (r gitRepo) run(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	// Missing credentials must fail instead of waiting for input.
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		command := r.redact(strings.Join(args, " "))
+		output := r.redact(strings.TrimSpace(stderr.String()))
+
+		return "", fmt.Errorf("%w: git %s: %w: %s", domain.ErrPublishFailed, command, err, output)
+	}
+
+	return stdout.String(), nil
+}
+
+func (r gitRepo) redact(text string) string {
+	parsed, err := url.Parse(r.url)
+	if err != nil || parsed.User == nil {
+		return text
+	}
+
+	parsed.User = nil
+
+	return strings.ReplaceAll(text, r.url, parsed.String())
+}
+
+func lines(text string) []string {
+	var result []string
+
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			result = append(result, line)
+		}
+	}
+
+	return result
+}
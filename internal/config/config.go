@@ -8,6 +8,16 @@ package config
 import (
 	"errors"
 	"fmt"
+	"io"
+	"maps"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
 
 	"github.com/cristalhq/aconfig"
 	"github.com/cristalhq/aconfig/aconfigyaml"
@@ -20,26 +30,383 @@ type Config struct {
 	Output        Output        `env:"OUTPUT" yaml:"output"`
 	Diagram       Diagram       `env:"DIAGRAM" yaml:"diagram"`
 	Documentation Documentation `env:"DOCUMENTATION" yaml:"documentation"`
+	Ingest        Ingest        `env:"INGEST" yaml:"ingest"`
+	Cache         Cache         `env:"CACHE" yaml:"cache"`
+	Publish       Publish       `env:"PUBLISH" yaml:"publish"`
+	Export        Export        `env:"EXPORT" yaml:"export"`
+	Validate      Validate      `env:"VALIDATE" yaml:"validate"`
+	Vocabulary    Vocabulary    `env:"VOCABULARY" yaml:"vocabulary" usage:"Replacements of generated terms such as Standalone Services or publishes to, by the generated term"`
+	Plugins       []Plugin      `env:"PLUGINS" yaml:"plugins" usage:"External programs providing sources, targets and publishers over the plugin protocol"`
+	Notifications Notifications `env:"NOTIFICATIONS" yaml:"notifications" usage:"Changelog entries posted to the webhooks of teams on every generation recording changes"`
+}
+
+// Plugin represents an external program speaking the plugin protocol, JSON over its standard input and output.
+type Plugin struct {
+	Name    string            `yaml:"name" usage:"Name of the plugin, sources it provides are stored below it"`
+	Kind    string            `yaml:"kind" usage:"What the plugin provides: source, target or publisher"`
+	Command string            `yaml:"command" usage:"Command starting the plugin, split into arguments at spaces and not run by a shell"`
+	Config  map[string]string `yaml:"config" usage:"Settings passed to the plugin with every request"`
+}
+
+// Vocabulary replaces terms generated by holydocs, keyed by the generated term.
+type Vocabulary map[string]string
+
+// Term returns the replacement of a generated term, or the term itself.
+func (v Vocabulary) Term(term string) string {
+	if replacement := strings.TrimSpace(v[term]); replacement != "" {
+		return replacement
+	}
+
+	return term
 }
 
 // Input represents input configuration for HolyDOCs.
 type Input struct {
-	Dir           string   `env:"DIR" yaml:"dir" default:"." usage:"Directory to scan for AsyncAPI and ServiceFile files"`
-	AsyncAPIFiles []string `env:"ASYNCAPI_FILES" yaml:"asyncapi_files" usage:"Comma-separated list of AsyncAPI specification files"`
-	ServiceFiles  []string `env:"SERVICE_FILES" yaml:"service_files" usage:"Comma-separated list of ServiceFile specification files"`
+	Dir           string              `env:"DIR" yaml:"dir" default:"." usage:"Directory to scan for AsyncAPI and ServiceFile files"`
+	AsyncAPIFiles []string            `env:"ASYNCAPI_FILES" yaml:"asyncapi_files" usage:"Comma-separated list of AsyncAPI specification files"`
+	ServiceFiles  []string            `env:"SERVICE_FILES" yaml:"service_files" usage:"Comma-separated list of ServiceFile specification files"`
+	OpenAPIFiles  []string            `env:"OPENAPI_FILES" yaml:"openapi_files" usage:"Comma-separated list of OpenAPI specification files documenting HTTP endpoints of services"`
+	Remote        []RemoteSource      `env:"REMOTE" yaml:"remote" usage:"Specifications fetched over HTTP before documentation is generated"`
+	Monorepo      Monorepo            `env:"MONOREPO" yaml:"monorepo" usage:"Mapping of services to the subdirectories of a monorepo"`
+	Review        Review              `env:"REVIEW" yaml:"review" usage:"Approval of services discovered in ingested and remote sources"`
+	Namespaces    []string            `env:"NAMESPACES" yaml:"namespaces" usage:"Namespaces of the services to document, services without a namespace are always documented (all services when empty)"`
+	Relationships []RelationshipRule  `env:"RELATIONSHIPS" yaml:"relationships" usage:"Relationships added to every service matching a rule, e.g. all services of a system using a logging platform"`
+	Technologies  map[string][]string `env:"TECHNOLOGIES" yaml:"technologies" usage:"Canonical names of technologies by the names and glob patterns collapsed into them, matched case-insensitively, e.g. PostgreSQL: [Postgres, PostgreSQL *]"`
+	Precedence    map[string][]string `env:"PRECEDENCE" yaml:"precedence" usage:"Source types whose values win for fields of services declared by several files, by field, e.g. description: [asyncapi, servicefile] (the longest description and the first value of other fields win by default)"`
+}
+
+// RelationshipRule declares a relationship of every service matched by its systems, services or tags, added
+// when specifications are merged. Systems and services are glob patterns, e.g. "*" for all services.
+type RelationshipRule struct {
+	Systems     []string `yaml:"systems" usage:"Patterns of the systems whose services get the relationship"`
+	Services    []string `yaml:"services" usage:"Patterns of the names of the services getting the relationship"`
+	Tags        []string `yaml:"tags" usage:"Services with any of the tags get the relationship"`
+	Action      string   `yaml:"action" usage:"Relationship action: uses, requests, replies, sends or receives"`
+	Participant string   `yaml:"participant" usage:"Participant of the relationship"`
+	Technology  string   `yaml:"technology" usage:"Technology of the relationship"`
+	Description string   `yaml:"description" usage:"Description of the relationship"`
+	External    bool     `yaml:"external" usage:"The participant is an external system"`
+}
+
+// Review represents configuration of the approval of services only defined by ingested and remote sources,
+// which are listed as pending review instead of being documented until they are approved.
+type Review struct {
+	Enabled  bool     `env:"ENABLED" yaml:"enabled" default:"false" usage:"List services only defined by ingested or remote sources as pending review until they are approved"`
+	Approved []string `env:"APPROVED" yaml:"approved" usage:"Names of discovered services approved for the documentation"`
+}
+
+// Monorepo represents configuration of the mapping of services to the packages of a monorepo.
+type Monorepo struct {
+	Root       string `env:"ROOT" yaml:"root" usage:"Checkout of the monorepo services are mapped to subdirectories of (monorepo mode is off when empty)"`
+	Repository string `env:"REPOSITORY" yaml:"repository" usage:"URL of the monorepo, the repository of mapped services without one"`
+	Mapping    string `env:"MAPPING" yaml:"mapping" usage:"YAML file mapping service names to subdirectories of the monorepo, taking precedence over the detection by go.mod and package.json files"`
+}
+
+// Defaults of remote sources.
+const (
+	defaultRemoteTimeout = 30 * time.Second
+	defaultRemoteBackoff = time.Second
+)
+
+// RemoteSource represents a specification fetched over HTTP and stored as an ingested source.
+type RemoteSource struct {
+	Name string `yaml:"name" usage:"Name the fetched specification is stored under"`
+	// Url is named after its key, aconfig maps keys of list items to field names with strings.Title.
+	Url      string `yaml:"url" usage:"HTTP(S) URL of the AsyncAPI or ServiceFile specification"` //nolint:revive,stylecheck
+	Timeout  string `yaml:"timeout" usage:"Timeout of a single fetch attempt (defaults to 30s)"`
+	Retries  int    `yaml:"retries" usage:"Number of retries after a failed fetch attempt"`
+	Backoff  string `yaml:"backoff" usage:"Delay before the first retry, doubled for every next retry (defaults to 1s)"`
+	Optional bool   `yaml:"optional" usage:"Continue with the previously fetched copy, if any, when fetching fails"`
+}
+
+// TimeoutDuration returns the parsed timeout of a fetch attempt.
+func (r RemoteSource) TimeoutDuration() (time.Duration, error) {
+	return parseRemoteDuration(r.Timeout, defaultRemoteTimeout, "timeout", r.Name)
+}
+
+// BackoffDuration returns the parsed delay before the first retry.
+func (r RemoteSource) BackoffDuration() (time.Duration, error) {
+	return parseRemoteDuration(r.Backoff, defaultRemoteBackoff, "backoff", r.Name)
+}
+
+func parseRemoteDuration(value string, fallback time.Duration, field, name string) (time.Duration, error) {
+	if strings.TrimSpace(value) == "" {
+		return fallback, nil
+	}
+
+	d, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid %s %q of remote source %s", field, value, name)
+	}
+
+	return d, nil
 }
 
 // Output represents output configuration for HolyDOCs.
 type Output struct {
-	Dir        string `env:"DIR" yaml:"dir" default:"docs" usage:"Directory where documentation will be generated"`
-	Title      string `env:"TITLE" yaml:"title" default:"HolyDOCs" usage:"Title for the generated documentation"`
-	GlobalName string `env:"GLOBAL_NAME" yaml:"global_name" default:"Internal Services" usage:"Name used for grouping internal services in diagrams"`
-	Format     string `env:"FORMAT" yaml:"format" default:"md_single_page" usage:"Documentation format: md_single_page or md_multi_page"`
+	Dir           string `env:"DIR" yaml:"dir" default:"docs" usage:"Directory where documentation will be generated"`
+	Title         string `env:"TITLE" yaml:"title" default:"HolyDOCs" usage:"Title for the generated documentation"`
+	GlobalName    string `env:"GLOBAL_NAME" yaml:"global_name" default:"Internal Services" usage:"Name used for grouping internal services in diagrams"`
+	Format        string `env:"FORMAT" yaml:"format" default:"md_single_page" usage:"Documentation format: md_single_page or md_multi_page"`
+	Flavor        string `env:"FLAVOR" yaml:"flavor" usage:"Static site generator the multi-page documentation is prepared for: mkdocs, docusaurus or hugo (plain Markdown when empty)"`
+	EmbedDiagrams bool   `env:"EMBED_DIAGRAMS" yaml:"embed_diagrams" default:"false" usage:"Inline diagrams into the pages as base64 data URIs instead of linking the diagram files"`
+	Assets        Assets `env:"ASSETS" yaml:"assets" usage:"Storage of the diagram files"`
+	// FrontMatter values are kept as decoded from YAML, strings may be nested in lists and maps.
+	FrontMatter map[string]any `env:"FRONT_MATTER" yaml:"front_matter" usage:"Fields added to the front matter of every page, string values are templates with the Title, Owner and System of the page"`
+	TOC         TOC            `env:"TOC" yaml:"toc" usage:"Table of contents generated at the top of the pages"`
+	Targets     []OutputTarget `env:"TARGETS" yaml:"targets" usage:"Additional outputs written by the same run from the diagrams rendered for the output directory"`
+	Redacted    Redacted       `env:"REDACTED" yaml:"redacted" usage:"Variant of the documentation with restricted services anonymized, e.g. for readers outside the company"`
+	Metadata    bool           `env:"METADATA" yaml:"metadata" default:"true" usage:"Record the schema and its changes in domain.json, disable for preview builds whose changes must not enter the changelog"`
+}
+
+// Redacted represents configuration of the redacted variant of the documentation. Services matching any of
+// the rules are restricted.
+type Redacted struct {
+	Dir             string   `env:"DIR" yaml:"dir" usage:"Directory the redacted documentation is written to (not written when empty)"`
+	Tags            []string `env:"TAGS" yaml:"tags" usage:"Tags of restricted services"`
+	Systems         []string `env:"SYSTEMS" yaml:"systems" usage:"Systems whose services are restricted"`
+	Classifications []string `env:"CLASSIFICATIONS" yaml:"classifications" usage:"Classifications of restricted services, e.g. internal or confidential"`
+	Placeholder     string   `env:"PLACEHOLDER" yaml:"placeholder" default:"Internal Service" usage:"Name of the anonymized nodes restricted services are replaced with, followed by a number"`
+}
+
+// Restricts reports whether a service with the system, tags and classification is restricted.
+func (r Redacted) Restricts(system string, tags []string, classification string) bool {
+	if system != "" && slices.Contains(r.Systems, system) {
+		return true
+	}
+
+	if classification != "" && slices.Contains(r.Classifications, classification) {
+		return true
+	}
+
+	return slices.ContainsFunc(tags, func(tag string) bool { return slices.Contains(r.Tags, tag) })
+}
+
+// OutputTarget represents an additional output of the documentation in another format or flavor.
+type OutputTarget struct {
+	Dir    string `yaml:"dir" usage:"Directory the target is written to"`
+	Format string `yaml:"format" usage:"Documentation format of the target: md_single_page or md_multi_page"`
+	Flavor string `yaml:"flavor" usage:"Static site generator the target is prepared for: mkdocs, docusaurus or hugo (plain Markdown when empty)"`
+}
+
+// Output returns the output configuration writing the target, the other settings are shared.
+func (t OutputTarget) Output(output Output) Output {
+	output.Dir, output.Format, output.Flavor = t.Dir, t.Format, t.Flavor
+	output.Targets = nil
+
+	return output
+}
+
+// TOC represents configuration of the generated tables of contents.
+type TOC struct {
+	Depth int `env:"DEPTH" yaml:"depth" default:"0" usage:"Heading levels below the page title listed in the generated table of contents of every page (0 keeps the tables of contents of the templates)"`
+}
+
+// Assets represents configuration of where diagram files are stored.
+type Assets struct {
+	Storage       string `env:"STORAGE" yaml:"storage" usage:"Storage of diagrams: lfs tracks them with Git LFS, bucket uploads them with upload_command (kept in the output directory when empty)"`
+	UploadCommand string `env:"UPLOAD_COMMAND" yaml:"upload_command" usage:"Command uploading a diagram to the bucket, {file} is replaced by the diagram file and {path} by its object path"`
+	BaseURL       string `env:"BASE_URL" yaml:"base_url" usage:"URL the bucket serves uploaded diagrams from, pages link the object paths below it"`
+}
+
+// maxTOCDepth lists headings down to level 6, the deepest Markdown heading below the level 1 page title.
+const maxTOCDepth = 5
+
+// Storages of diagram files.
+const (
+	AssetStorageLFS    = "lfs"
+	AssetStorageBucket = "bucket"
+)
+
+// Flavors of the multi-page documentation.
+const (
+	FlavorMkDocs     = "mkdocs"
+	FlavorDocusaurus = "docusaurus"
+	FlavorHugo       = "hugo"
+)
+
+// Ingest represents configuration of sources pushed to HolyDOCs with the ingest command.
+type Ingest struct {
+	Dir   string `env:"DIR" yaml:"dir" default:".holydocs/sources" usage:"Directory where ingested sources are persisted"`
+	TTL   string `env:"TTL" yaml:"ttl" default:"720h" usage:"How long an ingested source is kept after it was last received (0 to keep forever)"`
+	Token string `env:"TOKEN" yaml:"token" usage:"Bearer token required from ingest clients (no authentication when empty)"`
+}
+
+// Cache represents configuration of the cache of parsed specifications.
+type Cache struct {
+	Enabled bool   `env:"ENABLED" yaml:"enabled" default:"true" usage:"Reuse schemas parsed from unchanged AsyncAPI files in later runs"`
+	Dir     string `env:"DIR" yaml:"dir" default:".holydocs/cache" usage:"Directory where parsed schemas are cached"`
+}
+
+// Publish represents configuration of publishing generated documentation.
+type Publish struct {
+	Wiki    Wiki    `env:"WIKI" yaml:"wiki"`
+	Preview Preview `env:"PREVIEW" yaml:"preview"`
+}
+
+// Defaults of notifications.
+const defaultNotificationTimeout = 10 * time.Second
+
+// Notifications configures posting the changes recorded by a generation to the webhooks of subscribed teams.
+type Notifications struct {
+	Type          string            `env:"TYPE" yaml:"type" default:"slack" usage:"Message format of the webhooks: slack, teams or mattermost"`
+	Subscriptions map[string]string `env:"SUBSCRIPTIONS" yaml:"subscriptions" usage:"Webhook URLs by owner, each receiving the changes affecting the services of the owner and their dependencies"`
+	Timeout       string            `env:"TIMEOUT" yaml:"timeout" usage:"Timeout of posting a single notification (defaults to 10s)"`
+}
+
+// TimeoutDuration returns the parsed timeout of posting a single notification.
+func (n Notifications) TimeoutDuration() (time.Duration, error) {
+	if strings.TrimSpace(n.Timeout) == "" {
+		return defaultNotificationTimeout, nil
+	}
+
+	d, err := time.ParseDuration(strings.TrimSpace(n.Timeout))
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid timeout %q, expected a duration such as 10s", n.Timeout)
+	}
+
+	return d, nil
+}
+
+// Validate represents configuration of the validate command.
+type Validate struct {
+	Prose    Prose        `env:"PROSE" yaml:"prose" usage:"Linting of service and relationship descriptions enabled with validate --prose"`
+	Rules    []CustomRule `env:"RULES" yaml:"rules" usage:"Organization-specific rules checked by validate, implemented by WebAssembly modules"`
+	Approval Approval     `env:"APPROVAL" yaml:"approval"`
+	Baseline string       `env:"BASELINE" yaml:"baseline" usage:"JSON file of accepted findings validate doesn't fail on, written with validate --update-baseline"`
+}
+
+// Approval represents configuration of the approval of new relationships between systems.
+type Approval struct {
+	Required bool   `env:"REQUIRED" yaml:"required" default:"false" usage:"Fail validate on new relationships between systems without approval"`
+	File     string `env:"FILE" yaml:"file" usage:"YAML file listing approved relationships by service, participant and reference, e.g. an ADR"`
+}
+
+// CustomRule represents a validation rule implemented by a WebAssembly module, a WASI command reading the
+// schema as JSON from its standard input and writing its findings as JSON to its standard output.
+type CustomRule struct {
+	Name   string            `yaml:"name" usage:"Name of the rule its findings are reported under"`
+	Module string            `yaml:"module" usage:"Path of the WebAssembly module implementing the rule"`
+	Config map[string]string `yaml:"config" usage:"Settings passed to the module with the schema"`
+}
+
+// Prose represents configuration of the linting of descriptions.
+type Prose struct {
+	Dictionary        string   `env:"DICTIONARY" yaml:"dictionary" usage:"Word list of the spell checker with one word per line, e.g. /usr/share/dict/words or a Hunspell .dic file (spelling isn't checked when empty)"`
+	Words             []string `env:"WORDS" yaml:"words" usage:"Words accepted in addition to the dictionary, e.g. product names"`
+	MaxSentenceLength int      `env:"MAX_SENTENCE_LENGTH" yaml:"max_sentence_length" default:"30" usage:"Maximum number of words of a sentence (0 for no limit)"`
+	BannedWords       []string `env:"BANNED_WORDS" yaml:"banned_words" usage:"Words and phrases descriptions must not use, e.g. simply or obviously"`
+}
+
+// Export represents configuration of exports of the merged schema.
+type Export struct {
+	Radar Radar `env:"RADAR" yaml:"radar"`
+}
+
+// Radar represents configuration of the technology radar export.
+type Radar struct {
+	Rings           map[string]string `env:"RINGS" yaml:"rings" usage:"Ring of technologies by technology name: adopt, trial, assess or hold"`
+	Quadrants       map[string]string `env:"QUADRANTS" yaml:"quadrants" usage:"Quadrant of technologies by technology name"`
+	DefaultRing     string            `env:"DEFAULT_RING" yaml:"default_ring" default:"assess" usage:"Ring of technologies without a configured ring"`
+	DefaultQuadrant string            `env:"DEFAULT_QUADRANT" yaml:"default_quadrant" default:"platforms" usage:"Quadrant of technologies without a configured quadrant"`
+}
+
+// Rings of the technology radar.
+const (
+	RadarRingAdopt  = "adopt"
+	RadarRingTrial  = "trial"
+	RadarRingAssess = "assess"
+	RadarRingHold   = "hold"
+)
+
+// RadarRings returns the rings of the technology radar from the center.
+func RadarRings() []string {
+	return []string{RadarRingAdopt, RadarRingTrial, RadarRingAssess, RadarRingHold}
+}
+
+// Ring returns the ring of a technology, technology names are matched case-insensitively.
+func (r Radar) Ring(technology string) string {
+	return radarLookup(r.Rings, technology, r.DefaultRing)
+}
+
+// Quadrant returns the quadrant of a technology, technology names are matched case-insensitively.
+func (r Radar) Quadrant(technology string) string {
+	return radarLookup(r.Quadrants, technology, r.DefaultQuadrant)
+}
+
+func radarLookup(values map[string]string, technology, fallback string) string {
+	for name, value := range values {
+		if strings.EqualFold(name, technology) {
+			return value
+		}
+	}
+
+	return fallback
+}
+
+// Wiki represents configuration of publishing to a GitLab or Bitbucket wiki repository.
+type Wiki struct {
+	Provider    string            `env:"PROVIDER" yaml:"provider" default:"gitlab" usage:"Wiki provider: gitlab or bitbucket"`
+	URL         string            `env:"URL" yaml:"url" usage:"Git URL of the wiki repository, credentials may be part of it (prefer the environment variable then)"`
+	Branch      string            `env:"BRANCH" yaml:"branch" usage:"Branch pages are pushed to (the default branch of the wiki when empty)"`
+	Dir         string            `env:"DIR" yaml:"dir" usage:"Wiki directory pages are published under (the wiki root when empty)"`
+	Pages       map[string]string `env:"PAGES" yaml:"pages" usage:"Wiki page names by page path in the output directory, e.g. README.md: Architecture"`
+	Message     string            `env:"MESSAGE" yaml:"message" default:"Update architecture documentation" usage:"Commit message of published changes"`
+	AuthorName  string            `env:"AUTHOR_NAME" yaml:"author_name" default:"HolyDOCs" usage:"Author name of published commits"`
+	AuthorEmail string            `env:"AUTHOR_EMAIL" yaml:"author_email" default:"holydocs@localhost" usage:"Author email of published commits"`
+}
+
+// Preview represents configuration of documentation previews of pull requests built by the preview command.
+type Preview struct {
+	Dir           string `env:"DIR" yaml:"dir" default:".holydocs/previews" usage:"Directory previews are generated in, below their slugs"`
+	Uploader      string `env:"UPLOADER" yaml:"uploader" default:"command" usage:"Uploader of previews: command runs upload_command, dir copies them below target_dir"`
+	UploadCommand string `env:"UPLOAD_COMMAND" yaml:"upload_command" usage:"Command uploading a preview, {dir} is replaced by the generated directory and {slug} by the slug of the preview"`
+	TargetDir     string `env:"TARGET_DIR" yaml:"target_dir" usage:"Directory served by static hosting the dir uploader copies previews into, e.g. a checkout of a gh-pages branch"`
+	BaseURL       string `env:"BASE_URL" yaml:"base_url" usage:"URL previews are served from, a preview is served below its slug (the last line printed by upload_command when empty)"`
+}
+
+// TTLDuration returns the parsed TTL, zero means sources never expire.
+func (i Ingest) TTLDuration() (time.Duration, error) {
+	value := strings.TrimSpace(i.TTL)
+	if value == "" || value == "0" {
+		return 0, nil
+	}
+
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl < 0 {
+		return 0, fmt.Errorf("invalid ingest ttl %q", i.TTL)
+	}
+
+	return ttl, nil
 }
 
 // Diagram represents diagram generation configuration for HolyDOCs.
 type Diagram struct {
-	D2 D2Config `env:"D2" yaml:"d2"`
+	D2          D2Config   `env:"D2" yaml:"d2"`
+	OptimizeSVG bool       `env:"OPTIMIZE_SVG" yaml:"optimize_svg" default:"false" usage:"Minify rendered SVG diagrams, stripping metadata and unused styles"`
+	Drawio      bool       `env:"DRAWIO" yaml:"drawio" default:"false" usage:"Also export overview and system diagrams as editable draw.io files"`
+	Interactive bool       `env:"INTERACTIVE" yaml:"interactive" default:"false" usage:"Write an HTML viewer with pan, zoom and clickable nodes next to every diagram"`
+	Highlight   Highlight  `env:"HIGHLIGHT" yaml:"highlight"`
+	EdgeLabels  EdgeLabels `env:"EDGE_LABELS" yaml:"edge_labels"`
+	Strict      bool       `env:"STRICT" yaml:"strict" default:"false" usage:"Only draw relationships passing validation, listing the others in the Questionable Edges appendix"`
+
+	// Overview settings
+	Hide          []string `env:"HIDE" yaml:"hide" usage:"Services, participants or tags left out of the overview diagram"`
+	Collapse      []string `env:"COLLAPSE" yaml:"collapse" usage:"Services, participants or tags drawn as a single node in the overview diagram"`
+	CollapseLabel string   `env:"COLLAPSE_LABEL" yaml:"collapse_label" default:"Shared Dependencies" usage:"Label of the node collapsed services and participants are drawn as"`
+}
+
+// EdgeLabels customizes the labels of the edges of overview, system and service relationship diagrams.
+type EdgeLabels struct {
+	Template string            `env:"TEMPLATE" yaml:"template" usage:"Go template of edge labels with .Label, .Technology and .Proto"`
+	Actions  map[string]string `env:"ACTIONS" yaml:"actions" usage:"Labels replacing the default ones, e.g. requests, sends, receives, pub, req"`
+}
+
+// Highlight selects what overview and system diagrams emphasize with a distinct style, e.g. the services
+// and relationships affected by a migration.
+type Highlight struct {
+	Services     []string `env:"SERVICES" yaml:"services" usage:"Services, systems or external participants to highlight"`
+	Technologies []string `env:"TECHNOLOGIES" yaml:"technologies" usage:"Relationship technologies to highlight, e.g. kafka"`
 }
 
 // D2Config represents D2 diagram generation configuration.
@@ -62,11 +429,228 @@ type Markdown struct {
 
 // Documentation represents documentation configuration for extending generated docs with custom markdown.
 type Documentation struct {
-	Overview OverviewDocumentation           `env:"OVERVIEW" yaml:"overview" usage:"Markdown content to place after overview diagram"`
-	Services map[string]ServiceDocumentation `env:"SERVICES" yaml:"services" usage:"Markdown content for specific services to place after service relationship diagrams"`
-	Systems  map[string]SystemDocumentation  `env:"SYSTEMS" yaml:"systems" usage:"Markdown content for specific systems to place after system diagrams"`
+	Overview        OverviewDocumentation              `env:"OVERVIEW" yaml:"overview" usage:"Markdown content to place after overview diagram"`
+	Services        map[string]ServiceDocumentation    `env:"SERVICES" yaml:"services" usage:"Markdown content for specific services to place after service relationship diagrams"`
+	Systems         map[string]SystemDocumentation     `env:"SYSTEMS" yaml:"systems" usage:"Markdown content for specific systems to place after system diagrams"`
+	Changelog       ChangelogDocumentation             `env:"CHANGELOG" yaml:"changelog" usage:"Rendering of the changelog section"`
+	Examples        ExamplesDocumentation              `env:"EXAMPLES" yaml:"examples" usage:"Example payloads synthesized from message schemas"`
+	Datastores      map[string]DatastoreDocumentation  `env:"DATASTORES" yaml:"datastores" usage:"Table and collection inventories of datastores, by participant name"`
+	Channels        map[string]ChannelDocumentation    `env:"CHANNELS" yaml:"channels" usage:"Service level annotations of channels, by channel name, taking precedence over AsyncAPI extensions"`
+	ExternalAliases map[string][]string                `env:"EXTERNAL_ALIASES" yaml:"external_aliases" usage:"Names external participants are drawn and listed as, by the names merged into them, matched case-insensitively, e.g. Stripe: [Stripe API]"`
+	Dependencies    map[string]DependencyDocumentation `env:"DEPENDENCIES" yaml:"dependencies" usage:"Vendor, license, compliance status and status page of third-party dependencies, by participant name, listed with all external participants in the Third-Party Dependencies section"`
+	StatusPages     StatusPagesDocumentation           `env:"STATUS_PAGES" yaml:"status_pages" usage:"Current status of third-party dependencies fetched from their status pages"`
+	Staleness       StalenessDocumentation             `env:"STALENESS" yaml:"staleness" usage:"Detection of likely stale ServiceFiles listed as needing review"`
+	AtAGlance       AtAGlanceDocumentation             `env:"AT_A_GLANCE" yaml:"at_a_glance" usage:"Section with counts of services, systems, dependencies and channels and the most used technologies"`
+	OnCall          OnCallDocumentation                `env:"ON_CALL" yaml:"on_call" usage:"Escalation policies of services looked up at PagerDuty or Opsgenie on every generation and shown next to their owners"`
+	Badges          BadgesDocumentation                `env:"BADGES" yaml:"badges" usage:"Badges of services to embed into the READMEs of their repositories, linking back to the documentation"`
+	Runtime         RuntimeDocumentation               `env:"RUNTIME" yaml:"runtime" usage:"Runtime overlay of relationships annotated with their latency and error rate queried from Prometheus on every generation"`
+	// Repositories holds URL templates by SCM host, e.g. github.com.
+	Repositories map[string]RepositoryLinks `env:"REPOSITORIES" yaml:"repositories" usage:"URL templates of links into the repositories of services, by SCM host of the repository"`
+}
+
+// RepositoryLinks holds the URL templates of links into the repositories of services on an SCM host.
+// Fields are named after their keys, aconfig maps keys of map values to field names with strings.Title.
+type RepositoryLinks struct {
+	Readme      string `env:"README" yaml:"readme" usage:"URL template of the README of the repository"`
+	Servicefile string `env:"SERVICEFILE" yaml:"servicefile" usage:"URL template of the ServiceFile in the repository"`
+	Team        string `env:"TEAM" yaml:"team" usage:"URL template of the page of the owning team, linked when the service has an owner"`
+	Tree        string `env:"TREE" yaml:"tree" usage:"URL template of the directory of the service in a monorepo, linked as the repository of services with a subdirectory"`
+}
+
+// RepositoryLinkFields are the fields of repository URL templates.
+func RepositoryLinkFields() []string {
+	return []string{"Repository", "Host", "Path", "Org", "Name", "Subpath", "Dir", "Service", "System", "Owner"}
+}
+
+// AtAGlanceDocumentation configures the section summarizing the architecture in numbers.
+type AtAGlanceDocumentation struct {
+	Enabled         bool `env:"ENABLED" yaml:"enabled" default:"false" usage:"Add the At a Glance section after the overview"`
+	TopTechnologies int  `env:"TOP_TECHNOLOGIES" yaml:"top_technologies" default:"10" usage:"Number of most used technologies listed (0 lists all)"`
+}
+
+// BadgesDocumentation configures the badges of services written to the badges directory of the output.
+type BadgesDocumentation struct {
+	Enabled bool     `env:"ENABLED" yaml:"enabled" default:"false" usage:"Write the badges of every service to the badges directory of the output"`
+	BaseURL string   `env:"BASE_URL" yaml:"base_url" usage:"URL the documentation is published at, services get a snippet embedding their badges from below it"`
+	Tags    []string `env:"TAGS" yaml:"tags" usage:"Tags of services shown as badges, e.g. tier-1"`
+}
+
+// Defaults of on-call lookups.
+const defaultOnCallTimeout = 10 * time.Second
+
+// OnCallDocumentation configures the lookup of the escalation policies of services at an on-call provider.
+type OnCallDocumentation struct {
+	Provider string            `env:"PROVIDER" yaml:"provider" usage:"On-call provider: pagerduty or opsgenie (no lookup when empty)"`
+	Token    string            `env:"TOKEN" yaml:"token" usage:"API token of the provider (prefer the environment variable)"`
+	URL      string            `env:"URL" yaml:"url" usage:"Base URL of the provider API (https://api.pagerduty.com or https://api.opsgenie.com when empty)"`
+	Timeout  string            `env:"TIMEOUT" yaml:"timeout" usage:"Timeout of the lookup of a single service (defaults to 10s)"`
+	Services map[string]string `env:"SERVICES" yaml:"services" usage:"PagerDuty service ID or Opsgenie team name, by service name"`
+}
+
+// TimeoutDuration returns the parsed timeout of the lookup of a single service.
+func (o OnCallDocumentation) TimeoutDuration() (time.Duration, error) {
+	if strings.TrimSpace(o.Timeout) == "" {
+		return defaultOnCallTimeout, nil
+	}
+
+	d, err := time.ParseDuration(strings.TrimSpace(o.Timeout))
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("on_call: invalid timeout %q, expected a duration such as 10s", o.Timeout)
+	}
+
+	return d, nil
+}
+
+// Defaults of runtime metric queries.
+const defaultRuntimeTimeout = 10 * time.Second
+
+// Placeholders of runtime metric queries.
+const (
+	RuntimeServicePlaceholder     = "{service}"
+	RuntimeParticipantPlaceholder = "{participant}"
+)
+
+// RuntimeDocumentation configures the runtime overlay, a diagram of relationships annotated with the latency
+// and error rate observed in Prometheus.
+type RuntimeDocumentation struct {
+	Prometheus    string                `env:"PROMETHEUS" yaml:"prometheus" usage:"Base URL of the Prometheus HTTP API, e.g. https://prometheus.example.com (no overlay when empty)"`
+	Token         string                `env:"TOKEN" yaml:"token" usage:"Bearer token of the Prometheus API (prefer the environment variable)"`
+	Timeout       string                `env:"TIMEOUT" yaml:"timeout" usage:"Timeout of a single query (defaults to 10s)"`
+	Latency       string                `env:"LATENCY" yaml:"latency" usage:"PromQL query of the p95 latency of a relationship in seconds, {service} and {participant} are replaced by their names"`
+	ErrorRate     string                `env:"ERROR_RATE" yaml:"error_rate" usage:"PromQL query of the ratio of failed requests of a relationship from 0 to 1, {service} and {participant} are replaced by their names"`
+	LatencySLO    string                `env:"LATENCY_SLO" yaml:"latency_slo" usage:"p95 latency objective of relationships as a duration, e.g. 300ms"`
+	ErrorRateSLO  float64               `env:"ERROR_RATE_SLO" yaml:"error_rate_slo" usage:"Error rate objective of relationships from 0 to 1, e.g. 0.01"`
+	Relationships []RuntimeRelationship `yaml:"relationships" usage:"Queries and objectives of single relationships, replacing the default ones"`
+}
+
+// RuntimeRelationship configures the queries and objectives of the relationship of a service with a participant.
+type RuntimeRelationship struct {
+	Service      string  `yaml:"service" usage:"Name of the service declaring the relationship"`
+	Participant  string  `yaml:"participant" usage:"Participant of the relationship"`
+	Latency      string  `yaml:"latency" usage:"PromQL query of the p95 latency in seconds"`
+	ErrorRate    string  `yaml:"error_rate" usage:"PromQL query of the ratio of failed requests"`
+	LatencySLO   string  `yaml:"latency_slo" usage:"p95 latency objective as a duration"`
+	ErrorRateSLO float64 `yaml:"error_rate_slo" usage:"Error rate objective from 0 to 1"`
 }
 
+// TimeoutDuration returns the parsed timeout of a single query.
+func (r RuntimeDocumentation) TimeoutDuration() (time.Duration, error) {
+	if strings.TrimSpace(r.Timeout) == "" {
+		return defaultRuntimeTimeout, nil
+	}
+
+	d, err := time.ParseDuration(strings.TrimSpace(r.Timeout))
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("runtime: invalid timeout %q, expected a duration such as 10s", r.Timeout)
+	}
+
+	return d, nil
+}
+
+// ParseLatencySLO parses a p95 latency objective, zero when none is set.
+func ParseLatencySLO(slo string) (time.Duration, error) {
+	if strings.TrimSpace(slo) == "" {
+		return 0, nil
+	}
+
+	d, err := time.ParseDuration(strings.TrimSpace(slo))
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid latency_slo %q, expected a duration such as 300ms", slo)
+	}
+
+	return d, nil
+}
+
+// StalenessDocumentation configures the detection of ServiceFiles that likely need a review.
+type StalenessDocumentation struct {
+	AfterMonths int  `env:"AFTER_MONTHS" yaml:"after_months" default:"0" usage:"Months without changes after which a ServiceFile needs review when specifications of its dependencies changed since (0 disables the detection)"`
+	Badges      bool `env:"BADGES" yaml:"badges" default:"false" usage:"Show a last updated badge in the header of every service"`
+}
+
+// ChannelDocumentation annotates a channel with its service levels.
+type ChannelDocumentation struct {
+	Throughput string `env:"THROUGHPUT" yaml:"throughput" usage:"Expected throughput, e.g. 500 msg/s"`
+	MaxLatency string `env:"MAX_LATENCY" yaml:"maxLatency" usage:"Maximum delivery latency as a duration, e.g. 2s"`
+	// Dlq is named after its key, aconfig maps keys of map values to field names with strings.Title.
+	Dlq string `env:"DLQ" yaml:"dlq" usage:"Name of the dead letter queue channel"` //nolint:revive,stylecheck
+}
+
+// DependencyDocumentation records the vendor, licensing and status page of a third-party dependency.
+type DependencyDocumentation struct {
+	Vendor     string `env:"VENDOR" yaml:"vendor" usage:"Vendor of the dependency, e.g. MongoDB Inc."`
+	License    string `env:"LICENSE" yaml:"license" usage:"License the dependency is used under, e.g. SSPL"`
+	Compliance string `env:"COMPLIANCE" yaml:"compliance" usage:"Status of agreements and reviews, e.g. DPA signed"`
+	StatusPage string `env:"STATUS_PAGE" yaml:"statusPage" usage:"URL of the status page of the vendor, e.g. https://status.stripe.com"`
+}
+
+// Defaults of status page checks.
+const defaultStatusPageTimeout = 5 * time.Second
+
+// StatusPagesDocumentation configures checking the status pages of third-party dependencies on generation.
+type StatusPagesDocumentation struct {
+	Fetch   bool   `env:"FETCH" yaml:"fetch" default:"false" usage:"Fetch the current status of third-party dependencies from their Statuspage status pages on every generation"`
+	Timeout string `env:"TIMEOUT" yaml:"timeout" usage:"Timeout of fetching a single status page (defaults to 5s)"`
+}
+
+// TimeoutDuration returns the parsed timeout of fetching a single status page.
+func (s StatusPagesDocumentation) TimeoutDuration() (time.Duration, error) {
+	if strings.TrimSpace(s.Timeout) == "" {
+		return defaultStatusPageTimeout, nil
+	}
+
+	d, err := time.ParseDuration(strings.TrimSpace(s.Timeout))
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("status_pages: invalid timeout %q, expected a duration such as 5s", s.Timeout)
+	}
+
+	return d, nil
+}
+
+// DatastoreDocumentation attaches the schema of a datastore used by services.
+type DatastoreDocumentation struct {
+	Schema string `env:"SCHEMA" yaml:"schema" usage:"Path to a SQL DDL (.sql) or YAML table inventory (.yaml, .yml) of the datastore"`
+}
+
+// ExamplesDocumentation configures example payloads synthesized from message schemas.
+type ExamplesDocumentation struct {
+	Synthesize bool  `env:"SYNTHESIZE" yaml:"synthesize" default:"false" usage:"Synthesize example payloads from message schemas"`
+	Seed       int64 `env:"SEED" yaml:"seed" default:"1" usage:"Seed of the example generator, the same seed always produces the same examples"`
+}
+
+// ChangelogDocumentation configures how many changelog entries are shown expanded.
+type ChangelogDocumentation struct {
+	MaxEntries        int    `env:"MAX_ENTRIES" yaml:"max_entries" default:"0" usage:"Maximum number of changelog entries shown expanded, older ones are collapsed (0 for no limit)"`
+	CollapseOlderThan string `env:"COLLAPSE_OLDER_THAN" yaml:"collapse_older_than" usage:"Collapse changelog entries older than this age, in days (e.g. 90d) or as a duration (e.g. 720h)"`
+	Feed              bool   `env:"FEED" yaml:"feed" default:"false" usage:"Write the changelog as an Atom feed, changelog.atom, next to the pages"`
+	Calendar          bool   `env:"CALENDAR" yaml:"calendar" default:"false" usage:"Write the added and removed services and relationships as an iCalendar, changelog.ics, next to the pages"`
+}
+
+// CollapseAge returns the parsed CollapseOlderThan, zero when it is not set.
+func (c ChangelogDocumentation) CollapseAge() (time.Duration, error) {
+	value := strings.TrimSpace(c.CollapseOlderThan)
+	if value == "" {
+		return 0, nil
+	}
+
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid collapse_older_than %q", c.CollapseOlderThan)
+		}
+
+		return time.Duration(n) * hoursPerDay * time.Hour, nil
+	}
+
+	age, err := time.ParseDuration(value)
+	if err != nil || age < 0 {
+		return 0, fmt.Errorf("invalid collapse_older_than %q", c.CollapseOlderThan)
+	}
+
+	return age, nil
+}
+
+const hoursPerDay = 24
+
 type OverviewDocumentation struct {
 	Description Markdown `env:"DESCRIPTION" yaml:"description" usage:"Markdown content to place after overview diagram"`
 }
@@ -79,6 +663,7 @@ type ServiceDocumentation struct {
 type SystemDocumentation struct {
 	Summary     Markdown `env:"SUMMARY" yaml:"summary" usage:"Summary of the system"`
 	Description Markdown `env:"DESCRIPTION" yaml:"description" usage:"Markdown content for specific system to place after system diagrams"`
+	Output      string   `env:"OUTPUT" yaml:"output" usage:"Directory the documentation of the system is also written to, e.g. a checkout of the repository of the system team"`
 }
 
 // ConfigFilePath is a type used to provide config file path to DI container.
@@ -126,51 +711,196 @@ func LoadConfig(i do.Injector) (*Config, error) {
 	return cfg, nil
 }
 
-func validateConfig(cfg *Config) error {
-	if cfg.Output.Title == "" {
-		return errors.New("documentation title cannot be empty")
+// Default returns configuration populated only with default values,
+// without reading environment variables or configuration files.
+func Default() (*Config, error) {
+	cfg := &Config{}
+
+	loaderConfig := aconfig.Config{
+		SkipEnv:   true,
+		SkipFiles: true,
+		SkipFlags: true,
 	}
 
-	if cfg.Output.Dir == "" {
-		return errors.New("output directory cannot be empty")
+	if err := aconfig.LoaderFor(cfg, loaderConfig).Load(); err != nil {
+		return nil, fmt.Errorf("loading default configuration: %w", err)
 	}
 
-	if cfg.Output.Format != "md_single_page" && cfg.Output.Format != "md_multi_page" {
-		return fmt.Errorf("invalid output format: %s (must be md_single_page or md_multi_page)", cfg.Output.Format)
+	return cfg, nil
+}
+
+func validateOutputFormat(format string) error {
+	if format != "md_single_page" && format != "md_multi_page" {
+		return fmt.Errorf("invalid output format: %s (must be md_single_page or md_multi_page)", format)
 	}
 
-	if cfg.Input.Dir == "" &&
-		len(cfg.Input.AsyncAPIFiles) == 0 &&
-		len(cfg.Input.ServiceFiles) == 0 {
-		return errors.New("at least one input source must be provided (dir, asyncapi_files, or service_files)")
+	return nil
+}
+
+// validateOutputTargets checks the format and flavor of every target and that targets don't share their
+// directory with the output directory or with each other.
+func validateOutputTargets(output Output) error {
+	dirs := map[string]struct{}{filepath.Clean(output.Dir): {}}
+
+	for i, target := range output.Targets {
+		if target.Dir == "" {
+			return fmt.Errorf("output target %d has no dir", i+1)
+		}
+
+		if _, exists := dirs[filepath.Clean(target.Dir)]; exists {
+			return fmt.Errorf("output target %s: dir is already written by another output", target.Dir)
+		}
+		dirs[filepath.Clean(target.Dir)] = struct{}{}
+
+		if err := validateOutputFormat(target.Format); err != nil {
+			return fmt.Errorf("output target %s: %w", target.Dir, err)
+		}
+
+		if err := validateOutputFlavor(target.Output(output)); err != nil {
+			return fmt.Errorf("output target %s: %w", target.Dir, err)
+		}
 	}
 
-	if err := validateDocumentation(&cfg.Documentation); err != nil {
-		return fmt.Errorf("invalid documentation configuration: %w", err)
+	return nil
+}
+
+// validateRedacted checks that the redacted documentation has rules and its own directory.
+func validateRedacted(output Output) error {
+	redacted := output.Redacted
+	if redacted.Dir == "" {
+		return nil
+	}
+
+	if len(redacted.Tags) == 0 && len(redacted.Systems) == 0 && len(redacted.Classifications) == 0 {
+		return errors.New("redacted output needs tags, systems or classifications of restricted services")
+	}
+
+	if strings.TrimSpace(redacted.Placeholder) == "" {
+		return errors.New("redacted output placeholder cannot be empty")
+	}
+
+	dirs := []string{output.Dir}
+	for _, target := range output.Targets {
+		dirs = append(dirs, target.Dir)
+	}
+
+	for _, dir := range dirs {
+		if filepath.Clean(dir) == filepath.Clean(redacted.Dir) {
+			return fmt.Errorf("redacted output dir %s is already written by another output", redacted.Dir)
+		}
 	}
 
 	return nil
 }
 
-func validateDocumentation(doc *Documentation) error {
-	if err := validateMarkdown(&doc.Overview.Description, "overview description"); err != nil {
-		return err
+// validateSystemOutputs checks that the output directories of systems aren't written by another output.
+func validateSystemOutputs(output Output, systems map[string]SystemDocumentation) error {
+	dirs := map[string]struct{}{filepath.Clean(output.Dir): {}}
+	for _, target := range output.Targets {
+		dirs[filepath.Clean(target.Dir)] = struct{}{}
 	}
 
-	for serviceName, serviceDoc := range doc.Services {
-		if err := validateMarkdown(&serviceDoc.Summary, "service "+serviceName+" summary"); err != nil {
-			return err
+	if output.Redacted.Dir != "" {
+		dirs[filepath.Clean(output.Redacted.Dir)] = struct{}{}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(systems)) {
+		dir := systems[name].Output
+		if dir == "" {
+			continue
 		}
-		if err := validateMarkdown(&serviceDoc.Description, "service "+serviceName+" description"); err != nil {
-			return err
+
+		if _, exists := dirs[filepath.Clean(dir)]; exists {
+			return fmt.Errorf("output of system %s: dir %s is already written by another output", name, dir)
 		}
+		dirs[filepath.Clean(dir)] = struct{}{}
 	}
 
-	for systemName, systemDoc := range doc.Systems {
-		if err := validateMarkdown(&systemDoc.Summary, "system "+systemName+" summary"); err != nil {
-			return err
+	return nil
+}
+
+func validateOutputFlavor(output Output) error {
+	switch output.Flavor {
+	case "":
+		return nil
+	case FlavorMkDocs, FlavorDocusaurus, FlavorHugo:
+	default:
+		return fmt.Errorf("invalid output flavor: %s (must be mkdocs, docusaurus or hugo)", output.Flavor)
+	}
+
+	if output.Format != "md_multi_page" {
+		return fmt.Errorf("output flavor %s requires the md_multi_page format", output.Flavor)
+	}
+
+	return nil
+}
+
+// pluginNameRe matches plugin names, which prefix the names of the sources plugins provide, and the names of
+// custom rules.
+//
+//nolint:gochecknoglobals // Compiled once, used for validating plugin and rule names.
+var pluginNameRe = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// edgeLabelActions are the default edge labels that can be overridden.
+//
+//nolint:gochecknoglobals // Fixed vocabulary of the diagrams.
+var edgeLabelActions = []string{
+	"uses", "requests", "sends", "receives", "reads", "writes", "reads/writes", "pub", "req", "pub/req",
+}
+
+func validateDiagram(diagram Diagram) error {
+	if _, err := template.New("edge_label").Parse(diagram.EdgeLabels.Template); err != nil {
+		return fmt.Errorf("invalid edge label template: %w", err)
+	}
+
+	for action := range diagram.EdgeLabels.Actions {
+		if !slices.Contains(edgeLabelActions, strings.ToLower(strings.TrimSpace(action))) {
+			return fmt.Errorf("unknown edge label %q (must be one of %s)", action, strings.Join(edgeLabelActions, ", "))
 		}
-		if err := validateMarkdown(&systemDoc.Description, "system "+systemName+" description"); err != nil {
+	}
+
+	if len(diagram.Collapse) > 0 && strings.TrimSpace(diagram.CollapseLabel) == "" {
+		return errors.New("collapse_label cannot be empty when collapse is set")
+	}
+
+	for _, collapsed := range diagram.Collapse {
+		for _, hidden := range diagram.Hide {
+			if strings.EqualFold(strings.TrimSpace(collapsed), strings.TrimSpace(hidden)) {
+				return fmt.Errorf("%q is both hidden and collapsed", collapsed)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateFrontMatter(output Output) error {
+	var validate func(field string, value any) error
+	validate = func(field string, value any) error {
+		switch value := value.(type) {
+		case string:
+			if _, err := template.New(field).Parse(value); err != nil {
+				return fmt.Errorf("invalid front_matter field %s: %w", field, err)
+			}
+		case []any:
+			for _, item := range value {
+				if err := validate(field, item); err != nil {
+					return err
+				}
+			}
+		case map[string]any:
+			for key, item := range value {
+				if err := validate(field+"."+key, item); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	}
+
+	for field, value := range output.FrontMatter {
+		if err := validate(field, value); err != nil {
 			return err
 		}
 	}
@@ -178,6 +908,649 @@ func validateDocumentation(doc *Documentation) error {
 	return nil
 }
 
+func validateAssets(output Output) error {
+	switch output.Assets.Storage {
+	case "", AssetStorageLFS:
+		return nil
+	case AssetStorageBucket:
+	default:
+		return fmt.Errorf("invalid storage: %s (must be lfs or bucket)", output.Assets.Storage)
+	}
+
+	if !strings.Contains(output.Assets.UploadCommand, "{file}") {
+		return errors.New("upload_command must contain the {file} placeholder")
+	}
+
+	if !strings.HasPrefix(output.Assets.BaseURL, "http://") && !strings.HasPrefix(output.Assets.BaseURL, "https://") {
+		return fmt.Errorf("base_url %q must be an http or https URL", output.Assets.BaseURL)
+	}
+
+	if output.EmbedDiagrams {
+		return errors.New("diagrams uploaded to a bucket can't be embedded")
+	}
+
+	return nil
+}
+
+func validateOnCall(onCall OnCallDocumentation) error {
+	if onCall.Provider == "" {
+		return nil
+	}
+
+	if onCall.Provider != "pagerduty" && onCall.Provider != "opsgenie" {
+		return fmt.Errorf("on_call: invalid provider: %s (must be pagerduty or opsgenie)", onCall.Provider)
+	}
+
+	if onCall.URL != "" && !strings.HasPrefix(onCall.URL, "http://") && !strings.HasPrefix(onCall.URL, "https://") {
+		return fmt.Errorf("on_call: url %q must be an http or https URL", onCall.URL)
+	}
+
+	if _, err := onCall.TimeoutDuration(); err != nil {
+		return err
+	}
+
+	for service, id := range onCall.Services {
+		if strings.TrimSpace(id) == "" {
+			return fmt.Errorf("on_call: service %s is mapped to an empty ID", service)
+		}
+	}
+
+	return nil
+}
+
+func validateRuntime(runtime RuntimeDocumentation) error {
+	if runtime.Prometheus == "" {
+		return nil
+	}
+
+	if !strings.HasPrefix(runtime.Prometheus, "http://") && !strings.HasPrefix(runtime.Prometheus, "https://") {
+		return fmt.Errorf("runtime: prometheus %q must be an http or https URL", runtime.Prometheus)
+	}
+
+	if _, err := runtime.TimeoutDuration(); err != nil {
+		return err
+	}
+
+	if _, err := ParseLatencySLO(runtime.LatencySLO); err != nil {
+		return fmt.Errorf("runtime: %w", err)
+	}
+
+	if runtime.ErrorRateSLO < 0 || runtime.ErrorRateSLO > 1 {
+		return fmt.Errorf("runtime: error_rate_slo %v must be between 0 and 1", runtime.ErrorRateSLO)
+	}
+
+	type relationshipKey struct{ service, participant string }
+
+	relationships := make(map[relationshipKey]struct{}, len(runtime.Relationships))
+
+	for _, rel := range runtime.Relationships {
+		if rel.Service == "" || rel.Participant == "" {
+			return errors.New("runtime: relationships need a service and a participant")
+		}
+
+		key := relationshipKey{service: rel.Service, participant: rel.Participant}
+		if _, exists := relationships[key]; exists {
+			return fmt.Errorf("runtime: relationship of %s with %s is configured twice", rel.Service, rel.Participant)
+		}
+		relationships[key] = struct{}{}
+
+		if _, err := ParseLatencySLO(rel.LatencySLO); err != nil {
+			return fmt.Errorf("runtime: relationship of %s with %s: %w", rel.Service, rel.Participant, err)
+		}
+
+		if rel.ErrorRateSLO < 0 || rel.ErrorRateSLO > 1 {
+			return fmt.Errorf("runtime: relationship of %s with %s: error_rate_slo %v must be between 0 and 1",
+				rel.Service, rel.Participant, rel.ErrorRateSLO)
+		}
+	}
+
+	return nil
+}
+
+func validateWiki(wiki Wiki) error {
+	if wiki.Provider != "gitlab" && wiki.Provider != "bitbucket" {
+		return fmt.Errorf("invalid provider: %s (must be gitlab or bitbucket)", wiki.Provider)
+	}
+
+	if wiki.Dir != "" && !filepath.IsLocal(wiki.Dir) {
+		return fmt.Errorf("dir %s must be a relative path inside the wiki", wiki.Dir)
+	}
+
+	for page, name := range wiki.Pages {
+		if !filepath.IsLocal(name) {
+			return fmt.Errorf("name %s of page %s must be a relative path inside the wiki", name, page)
+		}
+	}
+
+	return nil
+}
+
+func validateCustomRules(rules []CustomRule) error {
+	names := make(map[string]bool, len(rules))
+
+	for _, rule := range rules {
+		if !pluginNameRe.MatchString(rule.Name) {
+			return fmt.Errorf("invalid rule name %q (only letters, digits, '.', '_' and '-')", rule.Name)
+		}
+
+		if names[rule.Name] {
+			return fmt.Errorf("duplicate rule %s", rule.Name)
+		}
+
+		names[rule.Name] = true
+
+		if strings.TrimSpace(rule.Module) == "" {
+			return fmt.Errorf("rule %s has no module", rule.Name)
+		}
+	}
+
+	return nil
+}
+
+func validatePlugins(plugins []Plugin) error {
+	names := make(map[string]bool, len(plugins))
+
+	for _, plugin := range plugins {
+		if !pluginNameRe.MatchString(plugin.Name) {
+			return fmt.Errorf("invalid plugin name %q (only letters, digits, '.', '_' and '-')", plugin.Name)
+		}
+
+		if names[plugin.Name] {
+			return fmt.Errorf("duplicate plugin %s", plugin.Name)
+		}
+
+		names[plugin.Name] = true
+
+		if plugin.Kind != "source" && plugin.Kind != "target" && plugin.Kind != "publisher" {
+			return fmt.Errorf("invalid kind of plugin %s: %s (must be source, target or publisher)",
+				plugin.Name, plugin.Kind)
+		}
+
+		if strings.TrimSpace(plugin.Command) == "" {
+			return fmt.Errorf("plugin %s has no command", plugin.Name)
+		}
+	}
+
+	return nil
+}
+
+func validateNotifications(notifications Notifications) error {
+	if !slices.Contains([]string{"slack", "teams", "mattermost"}, notifications.Type) {
+		return fmt.Errorf("invalid type: %s (must be slack, teams or mattermost)", notifications.Type)
+	}
+
+	for owner, webhook := range notifications.Subscriptions {
+		if strings.TrimSpace(owner) == "" {
+			return errors.New("subscription owner cannot be empty")
+		}
+
+		if !strings.HasPrefix(webhook, "http://") && !strings.HasPrefix(webhook, "https://") {
+			return fmt.Errorf("webhook of %s must be an http or https URL", owner)
+		}
+	}
+
+	if _, err := notifications.TimeoutDuration(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func validatePreview(preview Preview) error {
+	if preview.Uploader != "command" && preview.Uploader != "dir" {
+		return fmt.Errorf("invalid uploader: %s (must be command or dir)", preview.Uploader)
+	}
+
+	if preview.BaseURL != "" && !strings.HasPrefix(preview.BaseURL, "http://") &&
+		!strings.HasPrefix(preview.BaseURL, "https://") {
+		return fmt.Errorf("base_url %q must be an http or https URL", preview.BaseURL)
+	}
+
+	return nil
+}
+
+func validateRadar(radar Radar) error {
+	if !slices.Contains(RadarRings(), radar.DefaultRing) {
+		return fmt.Errorf("invalid default_ring: %s (must be one of %s)", radar.DefaultRing,
+			strings.Join(RadarRings(), ", "))
+	}
+
+	for technology, ring := range radar.Rings {
+		if !slices.Contains(RadarRings(), ring) {
+			return fmt.Errorf("invalid ring of %s: %s (must be one of %s)", technology, ring,
+				strings.Join(RadarRings(), ", "))
+		}
+	}
+
+	return nil
+}
+
+// validateRepositoryLinks parses the URL templates and executes them with empty fields, so misspelled fields
+// are reported before documentation is generated.
+func validateRepositoryLinks(hosts map[string]RepositoryLinks) error {
+	fields := make(map[string]string)
+	for _, field := range RepositoryLinkFields() {
+		fields[field] = ""
+	}
+
+	for host, links := range hosts {
+		for name, link := range map[string]string{"readme": links.Readme, "servicefile": links.Servicefile,
+			"team": links.Team, "tree": links.Tree} {
+			if link == "" {
+				continue
+			}
+
+			tmpl, err := template.New(name).Option("missingkey=error").Parse(link)
+			if err != nil {
+				return fmt.Errorf("invalid %s link template of repositories on %s: %w", name, host, err)
+			}
+
+			if err := tmpl.Execute(io.Discard, fields); err != nil {
+				return fmt.Errorf("invalid %s link template of repositories on %s (fields are %s): %w", name, host,
+					strings.Join(RepositoryLinkFields(), ", "), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateConfig(cfg *Config) error {
+	if err := validateOutput(cfg.Output, cfg.Documentation.Systems); err != nil {
+		return err
+	}
+
+	if err := validateDiagram(cfg.Diagram); err != nil {
+		return fmt.Errorf("invalid diagram configuration: %w", err)
+	}
+
+	if err := validateInput(cfg.Input); err != nil {
+		return err
+	}
+
+	if err := validateDocumentation(&cfg.Documentation); err != nil {
+		return fmt.Errorf("invalid documentation configuration: %w", err)
+	}
+
+	if _, err := cfg.Ingest.TTLDuration(); err != nil {
+		return err
+	}
+
+	return validateIntegrations(cfg)
+}
+
+// validateOutput checks the output directory and everything written to it or next to it.
+func validateOutput(output Output, systems map[string]SystemDocumentation) error {
+	if output.Title == "" {
+		return errors.New("documentation title cannot be empty")
+	}
+
+	if output.Dir == "" {
+		return errors.New("output directory cannot be empty")
+	}
+
+	if err := validateOutputFormat(output.Format); err != nil {
+		return err
+	}
+
+	if err := validateOutputFlavor(output); err != nil {
+		return err
+	}
+
+	if err := validateOutputTargets(output); err != nil {
+		return err
+	}
+
+	if err := validateRedacted(output); err != nil {
+		return err
+	}
+
+	if err := validateSystemOutputs(output, systems); err != nil {
+		return err
+	}
+
+	if err := validateFrontMatter(output); err != nil {
+		return err
+	}
+
+	if output.TOC.Depth < 0 || output.TOC.Depth > maxTOCDepth {
+		return fmt.Errorf("invalid toc depth: %d (must be between 0 and %d)", output.TOC.Depth, maxTOCDepth)
+	}
+
+	if err := validateAssets(output); err != nil {
+		return fmt.Errorf("invalid assets configuration: %w", err)
+	}
+
+	return nil
+}
+
+// validateInput checks that specifications are configured and how they are read and merged.
+func validateInput(input Input) error {
+	if input.Dir == "" &&
+		len(input.AsyncAPIFiles) == 0 &&
+		len(input.ServiceFiles) == 0 {
+		return errors.New("at least one input source must be provided (dir, asyncapi_files, or service_files)")
+	}
+
+	if err := validateRemoteSources(input.Remote); err != nil {
+		return fmt.Errorf("invalid remote sources: %w", err)
+	}
+
+	if err := validateRelationshipRules(input.Relationships); err != nil {
+		return fmt.Errorf("invalid relationship rules: %w", err)
+	}
+
+	if err := validateTechnologies(input.Technologies); err != nil {
+		return fmt.Errorf("invalid technologies: %w", err)
+	}
+
+	if err := validatePrecedence(input.Precedence); err != nil {
+		return fmt.Errorf("invalid precedence: %w", err)
+	}
+
+	return nil
+}
+
+// validateIntegrations checks publishing, validation rules, plugins, exports and notifications.
+func validateIntegrations(cfg *Config) error {
+	if err := validateWiki(cfg.Publish.Wiki); err != nil {
+		return fmt.Errorf("invalid wiki publishing configuration: %w", err)
+	}
+
+	if err := validatePreview(cfg.Publish.Preview); err != nil {
+		return fmt.Errorf("invalid preview configuration: %w", err)
+	}
+
+	if err := validateCustomRules(cfg.Validate.Rules); err != nil {
+		return fmt.Errorf("invalid validate rules: %w", err)
+	}
+
+	if err := validatePlugins(cfg.Plugins); err != nil {
+		return fmt.Errorf("invalid plugins: %w", err)
+	}
+
+	if cfg.Validate.Prose.MaxSentenceLength < 0 {
+		return errors.New("prose max_sentence_length cannot be negative")
+	}
+
+	if err := validateRadar(cfg.Export.Radar); err != nil {
+		return fmt.Errorf("invalid radar export configuration: %w", err)
+	}
+
+	if err := validateNotifications(cfg.Notifications); err != nil {
+		return fmt.Errorf("invalid notifications configuration: %w", err)
+	}
+
+	return nil
+}
+
+// precedenceFields are the fields of services whose precedence can be configured, declared by the source
+// types of precedenceSourceTypes.
+//
+//nolint:gochecknoglobals // Fixed vocabulary of the merge.
+var (
+	precedenceFields      = []string{"business_capability", "classification", "description", "owner", "repository", "subpath", "sunset_date", "system"}
+	precedenceSourceTypes = []string{"servicefile", "asyncapi"}
+)
+
+// validateTechnologies checks the patterns of the canonical technologies and that no name is collapsed into
+// two canonical names.
+func validateTechnologies(technologies map[string][]string) error {
+	canonical := make(map[string]string)
+
+	for _, name := range slices.Sorted(maps.Keys(technologies)) {
+		if strings.TrimSpace(name) == "" {
+			return errors.New("canonical technology name cannot be empty")
+		}
+
+		for _, alias := range append([]string{name}, technologies[name]...) {
+			if _, err := path.Match(alias, ""); err != nil {
+				return fmt.Errorf("technology %s: pattern %q: %w", name, alias, err)
+			}
+
+			key := strings.ToLower(strings.TrimSpace(alias))
+			if other, ok := canonical[key]; ok && other != name {
+				return fmt.Errorf("technology %q is collapsed into both %s and %s", alias, other, name)
+			}
+
+			canonical[key] = name
+		}
+	}
+
+	return nil
+}
+
+// validateExternalAliases checks that no alias names two external participants.
+func validateExternalAliases(aliases map[string][]string) error {
+	canonical := make(map[string]string)
+
+	for _, name := range slices.Sorted(maps.Keys(aliases)) {
+		if strings.TrimSpace(name) == "" {
+			return errors.New("external participant name cannot be empty")
+		}
+
+		for _, alias := range aliases[name] {
+			key := strings.ToLower(strings.TrimSpace(alias))
+			if other, ok := canonical[key]; ok && other != name {
+				return fmt.Errorf("alias %q names both %s and %s", alias, other, name)
+			}
+
+			canonical[key] = name
+		}
+	}
+
+	return nil
+}
+
+func validatePrecedence(precedence map[string][]string) error {
+	for field, types := range precedence {
+		if !slices.Contains(precedenceFields, field) {
+			return fmt.Errorf("unknown field %q (must be one of %s)", field, strings.Join(precedenceFields, ", "))
+		}
+
+		for _, sourceType := range types {
+			if !slices.Contains(precedenceSourceTypes, sourceType) {
+				return fmt.Errorf("field %s: unknown source type %q (must be one of %s)", field, sourceType,
+					strings.Join(precedenceSourceTypes, ", "))
+			}
+		}
+
+		if len(slices.Compact(slices.Sorted(slices.Values(types)))) != len(types) {
+			return fmt.Errorf("field %s lists a source type more than once", field)
+		}
+	}
+
+	return nil
+}
+
+func validateRelationshipRules(rules []RelationshipRule) error {
+	actions := []string{"uses", "requests", "replies", "sends", "receives"}
+
+	for i, rule := range rules {
+		if strings.TrimSpace(rule.Participant) == "" {
+			return fmt.Errorf("rule %d has no participant", i)
+		}
+
+		if len(rule.Systems) == 0 && len(rule.Services) == 0 && len(rule.Tags) == 0 {
+			return fmt.Errorf("rule %d for %s matches no services, set systems, services or tags", i, rule.Participant)
+		}
+
+		if !slices.Contains(actions, rule.Action) {
+			return fmt.Errorf("rule %d for %s: action %q must be one of %v", i, rule.Participant, rule.Action, actions)
+		}
+
+		for _, pattern := range slices.Concat(rule.Systems, rule.Services) {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("rule %d for %s: pattern %q: %w", i, rule.Participant, pattern, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateRemoteSources(remotes []RemoteSource) error {
+	names := make(map[string]struct{}, len(remotes))
+
+	for _, remote := range remotes {
+		if remote.Name == "" {
+			return fmt.Errorf("remote source %s has no name", remote.Url)
+		}
+
+		if _, exists := names[remote.Name]; exists {
+			return fmt.Errorf("remote source %s is defined more than once", remote.Name)
+		}
+		names[remote.Name] = struct{}{}
+
+		if !strings.HasPrefix(remote.Url, "http://") && !strings.HasPrefix(remote.Url, "https://") {
+			return fmt.Errorf("remote source %s: url %q must be an http or https URL", remote.Name, remote.Url)
+		}
+
+		if remote.Retries < 0 {
+			return fmt.Errorf("remote source %s: retries cannot be negative", remote.Name)
+		}
+
+		if _, err := remote.TimeoutDuration(); err != nil {
+			return err
+		}
+
+		if _, err := remote.BackoffDuration(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateDocumentation(doc *Documentation) error {
+	if err := validateDocumentationMarkdown(doc); err != nil {
+		return err
+	}
+
+	if doc.Changelog.MaxEntries < 0 {
+		return errors.New("changelog max_entries cannot be negative")
+	}
+
+	if _, err := doc.Changelog.CollapseAge(); err != nil {
+		return err
+	}
+
+	if doc.Staleness.AfterMonths < 0 {
+		return errors.New("staleness after_months cannot be negative")
+	}
+
+	if doc.AtAGlance.TopTechnologies < 0 {
+		return errors.New("at_a_glance top_technologies cannot be negative")
+	}
+
+	if err := validateRepositoryLinks(doc.Repositories); err != nil {
+		return err
+	}
+
+	if err := validateOnCall(doc.OnCall); err != nil {
+		return err
+	}
+
+	if err := validateRuntime(doc.Runtime); err != nil {
+		return err
+	}
+
+	if baseURL := doc.Badges.BaseURL; baseURL != "" && !strings.HasPrefix(baseURL, "http://") &&
+		!strings.HasPrefix(baseURL, "https://") {
+		return fmt.Errorf("badges: base_url %q must be an http or https URL", baseURL)
+	}
+
+	if err := validateChannelDocumentation(doc.Channels); err != nil {
+		return err
+	}
+
+	if err := validateExternalAliases(doc.ExternalAliases); err != nil {
+		return fmt.Errorf("invalid external_aliases: %w", err)
+	}
+
+	if err := validateDependencyDocumentation(doc.Dependencies); err != nil {
+		return err
+	}
+
+	if _, err := doc.StatusPages.TimeoutDuration(); err != nil {
+		return err
+	}
+
+	return validateDatastoreDocumentation(doc.Datastores)
+}
+
+// validateDocumentationMarkdown checks the markdown of the overview, the services and the systems.
+func validateDocumentationMarkdown(doc *Documentation) error {
+	if err := validateMarkdown(&doc.Overview.Description, "overview description"); err != nil {
+		return err
+	}
+
+	for serviceName, serviceDoc := range doc.Services {
+		if err := validateMarkdown(&serviceDoc.Summary, "service "+serviceName+" summary"); err != nil {
+			return err
+		}
+		if err := validateMarkdown(&serviceDoc.Description, "service "+serviceName+" description"); err != nil {
+			return err
+		}
+	}
+
+	for systemName, systemDoc := range doc.Systems {
+		if err := validateMarkdown(&systemDoc.Summary, "system "+systemName+" summary"); err != nil {
+			return err
+		}
+		if err := validateMarkdown(&systemDoc.Description, "system "+systemName+" description"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateChannelDocumentation(channels map[string]ChannelDocumentation) error {
+	for name, channelDoc := range channels {
+		if channelDoc.MaxLatency == "" {
+			continue
+		}
+
+		if _, err := time.ParseDuration(channelDoc.MaxLatency); err != nil {
+			return fmt.Errorf("channel %s: invalid maxLatency %q, expected a duration such as 250ms", name,
+				channelDoc.MaxLatency)
+		}
+	}
+
+	return nil
+}
+
+func validateDependencyDocumentation(dependencies map[string]DependencyDocumentation) error {
+	for name, dependencyDoc := range dependencies {
+		if dependencyDoc == (DependencyDocumentation{}) {
+			return fmt.Errorf("dependency %s: one of vendor, license, compliance or statusPage is required", name)
+		}
+
+		statusPage := dependencyDoc.StatusPage
+		if statusPage != "" && !strings.HasPrefix(statusPage, "http://") && !strings.HasPrefix(statusPage, "https://") {
+			return fmt.Errorf("dependency %s: statusPage %q must be an http or https URL", name, statusPage)
+		}
+	}
+
+	return nil
+}
+
+func validateDatastoreDocumentation(datastores map[string]DatastoreDocumentation) error {
+	for name, datastoreDoc := range datastores {
+		switch strings.ToLower(filepath.Ext(datastoreDoc.Schema)) {
+		case ".sql", ".yaml", ".yml":
+		case "":
+			return fmt.Errorf("datastore %s: schema is required", name)
+		default:
+			return fmt.Errorf("datastore %s: unsupported schema file %q, expected .sql, .yaml or .yml", name,
+				datastoreDoc.Schema)
+		}
+	}
+
+	return nil
+}
+
 func validateMarkdown(md *Markdown, context string) error {
 	hasContent := md.Content != ""
 	hasFilePath := md.FilePath != ""
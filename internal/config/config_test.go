@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	do "github.com/samber/do/v2"
 	"github.com/stretchr/testify/assert"
@@ -277,3 +278,625 @@ func TestValidateMarkdown_InvalidConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestChangelogDocumentation_CollapseAge(t *testing.T) {
+	tests := []struct {
+		value       string
+		expected    time.Duration
+		expectError bool
+	}{
+		{value: "", expected: 0},
+		{value: "90d", expected: 90 * 24 * time.Hour},
+		{value: "720h", expected: 720 * time.Hour},
+		{value: "abc", expectError: true},
+		{value: "-1d", expectError: true},
+		{value: "xd", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			age, err := ChangelogDocumentation{CollapseOlderThan: tt.value}.CollapseAge()
+			if tt.expectError {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, age)
+		})
+	}
+}
+
+func TestValidateDocumentation_Changelog(t *testing.T) {
+	require.NoError(t, validateDocumentation(&Documentation{
+		Changelog: ChangelogDocumentation{MaxEntries: 10, CollapseOlderThan: "30d"},
+	}))
+	require.Error(t, validateDocumentation(&Documentation{
+		Changelog: ChangelogDocumentation{MaxEntries: -1},
+	}))
+	require.Error(t, validateDocumentation(&Documentation{
+		Changelog: ChangelogDocumentation{CollapseOlderThan: "soon"},
+	}))
+}
+
+func TestValidateDocumentation_Staleness(t *testing.T) {
+	require.NoError(t, validateDocumentation(&Documentation{Staleness: StalenessDocumentation{AfterMonths: 6}}))
+	require.ErrorContains(t, validateDocumentation(&Documentation{
+		Staleness: StalenessDocumentation{AfterMonths: -1},
+	}), "after_months")
+}
+
+func TestValidateDocumentation_AtAGlance(t *testing.T) {
+	require.NoError(t, validateDocumentation(&Documentation{AtAGlance: AtAGlanceDocumentation{Enabled: true}}))
+	require.ErrorContains(t, validateDocumentation(&Documentation{
+		AtAGlance: AtAGlanceDocumentation{Enabled: true, TopTechnologies: -1},
+	}), "top_technologies")
+}
+
+func TestValidateDocumentation_Channels(t *testing.T) {
+	require.NoError(t, validateDocumentation(&Documentation{
+		Channels: map[string]ChannelDocumentation{"orders": {Throughput: "500 msg/s", MaxLatency: "250ms"}},
+	}))
+	require.ErrorContains(t, validateDocumentation(&Documentation{
+		Channels: map[string]ChannelDocumentation{"orders": {MaxLatency: "fast"}},
+	}), "maxLatency")
+}
+
+func TestValidateDocumentation_Datastores(t *testing.T) {
+	require.NoError(t, validateDocumentation(&Documentation{
+		Datastores: map[string]DatastoreDocumentation{"orders-db": {Schema: "schemas/orders.sql"}},
+	}))
+	require.ErrorContains(t, validateDocumentation(&Documentation{
+		Datastores: map[string]DatastoreDocumentation{"orders-db": {}},
+	}), "schema is required")
+	require.ErrorContains(t, validateDocumentation(&Documentation{
+		Datastores: map[string]DatastoreDocumentation{"orders-db": {Schema: "orders.dbml"}},
+	}), "unsupported schema file")
+}
+
+func TestValidateDocumentation_Dependencies(t *testing.T) {
+	require.NoError(t, validateDocumentation(&Documentation{
+		Dependencies: map[string]DependencyDocumentation{"Stripe": {Compliance: "DPA signed"}},
+	}))
+	require.ErrorContains(t, validateDocumentation(&Documentation{
+		Dependencies: map[string]DependencyDocumentation{"Stripe": {}},
+	}), "one of vendor, license, compliance or statusPage is required")
+	require.NoError(t, validateDocumentation(&Documentation{
+		Dependencies: map[string]DependencyDocumentation{"Stripe": {StatusPage: "https://status.stripe.com"}},
+		StatusPages:  StatusPagesDocumentation{Fetch: true, Timeout: "2s"},
+	}))
+	require.ErrorContains(t, validateDocumentation(&Documentation{
+		Dependencies: map[string]DependencyDocumentation{"Stripe": {StatusPage: "status.stripe.com"}},
+	}), "must be an http or https URL")
+	require.ErrorContains(t, validateDocumentation(&Documentation{
+		StatusPages: StatusPagesDocumentation{Timeout: "-1s"},
+	}), "invalid timeout")
+}
+
+func TestValidateDocumentation_OnCall(t *testing.T) {
+	require.NoError(t, validateDocumentation(&Documentation{OnCall: OnCallDocumentation{
+		Services: map[string]string{"Payments": ""},
+	}}), "no lookup without a provider")
+	require.NoError(t, validateDocumentation(&Documentation{OnCall: OnCallDocumentation{
+		Provider: "pagerduty", Timeout: "5s", Services: map[string]string{"Payments": "PX7Q2M1"},
+	}}))
+	require.ErrorContains(t, validateDocumentation(&Documentation{OnCall: OnCallDocumentation{
+		Provider: "victorops",
+	}}), "must be pagerduty or opsgenie")
+	require.ErrorContains(t, validateDocumentation(&Documentation{OnCall: OnCallDocumentation{
+		Provider: "opsgenie", URL: "api.eu.opsgenie.com",
+	}}), "must be an http or https URL")
+	require.ErrorContains(t, validateDocumentation(&Documentation{OnCall: OnCallDocumentation{
+		Provider: "opsgenie", Timeout: "soon",
+	}}), "invalid timeout")
+	require.ErrorContains(t, validateDocumentation(&Documentation{OnCall: OnCallDocumentation{
+		Provider: "opsgenie", Services: map[string]string{"Payments": " "},
+	}}), "mapped to an empty ID")
+}
+
+func TestValidateDocumentation_Badges(t *testing.T) {
+	require.NoError(t, validateDocumentation(&Documentation{Badges: BadgesDocumentation{
+		Enabled: true, BaseURL: "https://architecture.example.com",
+	}}))
+	require.ErrorContains(t, validateDocumentation(&Documentation{Badges: BadgesDocumentation{
+		Enabled: true, BaseURL: "architecture.example.com",
+	}}), "must be an http or https URL")
+}
+
+func TestValidateDocumentation_Runtime(t *testing.T) {
+	require.NoError(t, validateDocumentation(&Documentation{Runtime: RuntimeDocumentation{
+		Prometheus: "https://prometheus.example.com", LatencySLO: "300ms", ErrorRateSLO: 0.01,
+		Relationships: []RuntimeRelationship{{Service: "Orders", Participant: "Stripe", LatencySLO: "1s"}},
+	}}))
+	require.ErrorContains(t, validateDocumentation(&Documentation{Runtime: RuntimeDocumentation{
+		Prometheus: "prometheus:9090",
+	}}), "must be an http or https URL")
+	require.ErrorContains(t, validateDocumentation(&Documentation{Runtime: RuntimeDocumentation{
+		Prometheus: "http://prometheus:9090", LatencySLO: "fast",
+	}}), "invalid latency_slo")
+	require.ErrorContains(t, validateDocumentation(&Documentation{Runtime: RuntimeDocumentation{
+		Prometheus: "http://prometheus:9090", ErrorRateSLO: 5,
+	}}), "must be between 0 and 1")
+	require.ErrorContains(t, validateDocumentation(&Documentation{Runtime: RuntimeDocumentation{
+		Prometheus:    "http://prometheus:9090",
+		Relationships: []RuntimeRelationship{{Service: "Orders"}},
+	}}), "need a service and a participant")
+	require.ErrorContains(t, validateDocumentation(&Documentation{Runtime: RuntimeDocumentation{
+		Prometheus: "http://prometheus:9090",
+		Relationships: []RuntimeRelationship{
+			{Service: "Orders", Participant: "Stripe"},
+			{Service: "Orders", Participant: "Stripe", ErrorRateSLO: 0.05},
+		},
+	}}), "configured twice")
+}
+
+func TestIngest_TTLDuration(t *testing.T) {
+	ttl, err := Ingest{TTL: "24h"}.TTLDuration()
+	require.NoError(t, err)
+	assert.Equal(t, 24*time.Hour, ttl)
+
+	ttl, err = Ingest{TTL: "0"}.TTLDuration()
+	require.NoError(t, err)
+	assert.Zero(t, ttl)
+
+	_, err = Ingest{TTL: "week"}.TTLDuration()
+	require.Error(t, err)
+}
+
+func TestValidateOutputFlavor(t *testing.T) {
+	require.NoError(t, validateOutputFlavor(Output{Format: "md_single_page"}))
+	require.NoError(t, validateOutputFlavor(Output{Format: "md_multi_page", Flavor: FlavorMkDocs}))
+	require.NoError(t, validateOutputFlavor(Output{Format: "md_multi_page", Flavor: FlavorDocusaurus}))
+	require.NoError(t, validateOutputFlavor(Output{Format: "md_multi_page", Flavor: FlavorHugo}))
+	require.ErrorContains(t, validateOutputFlavor(Output{Format: "md_multi_page", Flavor: "jekyll"}),
+		"invalid output flavor")
+	require.ErrorContains(t, validateOutputFlavor(Output{Format: "md_single_page", Flavor: FlavorMkDocs}),
+		"requires the md_multi_page format")
+}
+
+func TestValidateFrontMatter(t *testing.T) {
+	require.NoError(t, validateFrontMatter(Output{FrontMatter: map[string]any{
+		"owners":      []any{"{{ .Owner }}"},
+		"review_date": "2025-12-01",
+		"weight":      3,
+	}}))
+	require.ErrorContains(t, validateFrontMatter(Output{FrontMatter: map[string]any{
+		"labels": map[string]any{"system": "{{ .System"},
+	}}), "labels.system")
+}
+
+func TestValidateAssets(t *testing.T) {
+	bucket := Assets{
+		Storage:       AssetStorageBucket,
+		UploadCommand: "aws s3 cp {file} s3://docs-assets/{path}",
+		BaseURL:       "https://docs-assets.s3.amazonaws.com",
+	}
+
+	require.NoError(t, validateAssets(Output{}))
+	require.NoError(t, validateAssets(Output{Assets: Assets{Storage: AssetStorageLFS}}))
+	require.NoError(t, validateAssets(Output{Assets: bucket}))
+	require.ErrorContains(t, validateAssets(Output{Assets: Assets{Storage: "s3"}}), "invalid storage")
+	require.ErrorContains(t, validateAssets(Output{Assets: Assets{Storage: AssetStorageBucket, BaseURL: bucket.BaseURL}}),
+		"{file}")
+	require.ErrorContains(t, validateAssets(Output{Assets: Assets{Storage: AssetStorageBucket,
+		UploadCommand: bucket.UploadCommand}}), "base_url")
+	require.ErrorContains(t, validateAssets(Output{Assets: bucket, EmbedDiagrams: true}), "can't be embedded")
+}
+
+func TestValidateRadar(t *testing.T) {
+	require.NoError(t, validateRadar(Radar{Rings: map[string]string{"Kafka": RadarRingAdopt},
+		DefaultRing: RadarRingAssess}))
+	require.ErrorContains(t, validateRadar(Radar{DefaultRing: "maybe"}), "default_ring")
+	require.ErrorContains(t, validateRadar(Radar{Rings: map[string]string{"Kafka": "forever"},
+		DefaultRing: RadarRingAssess}), "ring of Kafka")
+}
+
+func TestValidateDiagram(t *testing.T) {
+	require.NoError(t, validateDiagram(Diagram{Hide: []string{"metrics"}, Collapse: []string{"logging"},
+		CollapseLabel: "Shared Dependencies"}))
+	require.ErrorContains(t, validateDiagram(Diagram{Collapse: []string{"logging"}}), "collapse_label")
+	require.ErrorContains(t, validateDiagram(Diagram{Hide: []string{"Logging"}, Collapse: []string{"logging"},
+		CollapseLabel: "Shared Dependencies"}), "both hidden and collapsed")
+
+	require.NoError(t, validateDiagram(Diagram{EdgeLabels: EdgeLabels{
+		Template: "{{ .Label }}{{ with .Proto }} ({{ . }}){{ end }}",
+		Actions:  map[string]string{"pub": "publishes", "Requests": "calls"},
+	}}))
+	require.ErrorContains(t, validateDiagram(Diagram{EdgeLabels: EdgeLabels{Template: "{{ .Label"}}), "template")
+	require.ErrorContains(t, validateDiagram(Diagram{EdgeLabels: EdgeLabels{
+		Actions: map[string]string{"publishes": "emits"},
+	}}), "unknown edge label")
+}
+
+func TestValidatePreview(t *testing.T) {
+	require.NoError(t, validatePreview(Preview{Uploader: "command"}))
+	require.NoError(t, validatePreview(Preview{Uploader: "dir", BaseURL: "https://previews.example.com"}))
+
+	require.Error(t, validatePreview(Preview{Uploader: "ftp"}))
+	require.Error(t, validatePreview(Preview{Uploader: "dir", BaseURL: "previews.example.com"}))
+}
+
+func TestValidateWiki(t *testing.T) {
+	wiki := Wiki{Provider: "gitlab", Dir: "architecture", Pages: map[string]string{"README.md": "Architecture"}}
+	require.NoError(t, validateWiki(wiki))
+
+	require.Error(t, validateWiki(Wiki{Provider: "confluence"}))
+	require.Error(t, validateWiki(Wiki{Provider: "bitbucket", Dir: "../outside"}))
+	require.Error(t, validateWiki(Wiki{Provider: "bitbucket", Pages: map[string]string{"README.md": "/Home"}}))
+}
+
+func TestValidateRemoteSources(t *testing.T) {
+	remote := RemoteSource{Name: "billing", Url: "https://specs.example.com/billing.yaml"}
+
+	timeout, err := remote.TimeoutDuration()
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Second, timeout)
+
+	backoff, err := RemoteSource{Backoff: "250ms"}.BackoffDuration()
+	require.NoError(t, err)
+	assert.Equal(t, 250*time.Millisecond, backoff)
+
+	require.NoError(t, validateRemoteSources([]RemoteSource{remote}))
+	require.Error(t, validateRemoteSources([]RemoteSource{remote, remote}))
+	require.Error(t, validateRemoteSources([]RemoteSource{{Url: remote.Url}}))
+	require.Error(t, validateRemoteSources([]RemoteSource{{Name: "billing", Url: "ftp://specs.example.com"}}))
+	require.Error(t, validateRemoteSources([]RemoteSource{{Name: "billing", Url: remote.Url, Retries: -1}}))
+	require.Error(t, validateRemoteSources([]RemoteSource{{Name: "billing", Url: remote.Url, Timeout: "soon"}}))
+}
+
+func TestLoadConfig_RemoteSources(t *testing.T) {
+	yamlContent := `
+input:
+  remote:
+    - name: billing
+      url: https://specs.example.com/billing.yaml
+      timeout: 10s
+      retries: 3
+      backoff: 2s
+      optional: true
+    - name: users
+      url: http://specs.internal/users.yaml
+`
+
+	configFile := filepath.Join(t.TempDir(), "remote-config.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte(yamlContent), 0o644))
+
+	injector := do.New()
+	do.ProvideValue(injector, ConfigFilePath(configFile))
+	config, err := LoadConfig(injector)
+	require.NoError(t, err)
+
+	assert.Equal(t, []RemoteSource{
+		{
+			Name:     "billing",
+			Url:      "https://specs.example.com/billing.yaml",
+			Timeout:  "10s",
+			Retries:  3,
+			Backoff:  "2s",
+			Optional: true,
+		},
+		{Name: "users", Url: "http://specs.internal/users.yaml"},
+	}, config.Input.Remote)
+}
+
+func TestLoadConfig_Vocabulary(t *testing.T) {
+	yamlContent := `
+vocabulary:
+  Standalone Services: "Shared Services"
+  publishes to: "emits to"
+`
+
+	configFile := filepath.Join(t.TempDir(), "vocabulary-config.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte(yamlContent), 0o644))
+
+	injector := do.New()
+	do.ProvideValue(injector, ConfigFilePath(configFile))
+	config, err := LoadConfig(injector)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Shared Services", config.Vocabulary.Term("Standalone Services"))
+	assert.Equal(t, "emits to", config.Vocabulary.Term("publishes to"))
+	assert.Equal(t, "receives from", config.Vocabulary.Term("receives from"))
+}
+
+func TestLoadConfig_TOC(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "toc-config.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte("output:\n  toc:\n    depth: 2\n"), 0o644))
+
+	injector := do.New()
+	do.ProvideValue(injector, ConfigFilePath(configFile))
+	config, err := LoadConfig(injector)
+	require.NoError(t, err)
+	assert.Equal(t, 2, config.Output.TOC.Depth)
+
+	require.NoError(t, os.WriteFile(configFile, []byte("output:\n  toc:\n    depth: 6\n"), 0o644))
+
+	injector = do.New()
+	do.ProvideValue(injector, ConfigFilePath(configFile))
+	_, err = LoadConfig(injector)
+	require.ErrorContains(t, err, "invalid toc depth")
+}
+
+func TestLoadConfig_Repositories(t *testing.T) {
+	yamlContent := `
+documentation:
+  repositories:
+    github.com:
+      readme: "{{ .Repository }}/blob/main/README.md"
+      servicefile: "{{ .Repository }}/blob/main/servicefile.yaml"
+      team: "https://github.com/orgs/{{ .Org }}/teams/{{ .Owner }}"
+      tree: "{{ .Repository }}/tree/main/{{ .Subpath }}"
+`
+
+	configFile := filepath.Join(t.TempDir(), "repositories-config.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte(yamlContent), 0o644))
+
+	injector := do.New()
+	do.ProvideValue(injector, ConfigFilePath(configFile))
+	config, err := LoadConfig(injector)
+	require.NoError(t, err)
+
+	assert.Equal(t, RepositoryLinks{
+		Readme:      "{{ .Repository }}/blob/main/README.md",
+		Servicefile: "{{ .Repository }}/blob/main/servicefile.yaml",
+		Team:        "https://github.com/orgs/{{ .Org }}/teams/{{ .Owner }}",
+		Tree:        "{{ .Repository }}/tree/main/{{ .Subpath }}",
+	}, config.Documentation.Repositories["github.com"])
+
+	require.ErrorContains(t, validateRepositoryLinks(map[string]RepositoryLinks{
+		"gitlab.com": {Team: "https://gitlab.com/{{ .Group }}"},
+	}), "team link template of repositories on gitlab.com")
+}
+
+func TestValidateOutputTargets(t *testing.T) {
+	t.Parallel()
+
+	output := Output{Dir: "docs", Format: "md_single_page"}
+
+	output.Targets = []OutputTarget{
+		{Dir: "site", Format: "md_multi_page", Flavor: FlavorMkDocs},
+		{Dir: "wiki", Format: "md_multi_page"},
+	}
+	require.NoError(t, validateOutputTargets(output))
+
+	output.Targets = []OutputTarget{{Dir: "./docs", Format: "md_multi_page"}}
+	require.ErrorContains(t, validateOutputTargets(output), "already written by another output")
+
+	output.Targets = []OutputTarget{{Dir: "site", Format: "html"}}
+	require.ErrorContains(t, validateOutputTargets(output), "invalid output format: html")
+
+	output.Targets = []OutputTarget{{Dir: "site", Format: "md_single_page", Flavor: FlavorHugo}}
+	require.Error(t, validateOutputTargets(output))
+
+	output.Targets = []OutputTarget{{Format: "md_multi_page"}}
+	require.ErrorContains(t, validateOutputTargets(output), "has no dir")
+}
+
+func TestValidateSystemOutputs(t *testing.T) {
+	t.Parallel()
+
+	output := Output{Dir: "docs", Targets: []OutputTarget{{Dir: "site"}}}
+
+	require.NoError(t, validateSystemOutputs(output, map[string]SystemDocumentation{
+		"Orders":   {Output: "../orders/docs"},
+		"Payments": {},
+	}))
+
+	require.ErrorContains(t, validateSystemOutputs(output, map[string]SystemDocumentation{
+		"Orders": {Output: "site/"},
+	}), "output of system Orders")
+
+	require.ErrorContains(t, validateSystemOutputs(output, map[string]SystemDocumentation{
+		"Orders":   {Output: "shared"},
+		"Payments": {Output: "shared"},
+	}), "output of system Payments")
+}
+
+func TestValidateRedacted(t *testing.T) {
+	t.Parallel()
+
+	output := Output{Dir: "docs", Redacted: Redacted{
+		Dir: "public", Tags: []string{"internal"}, Placeholder: "Internal Service",
+	}}
+	require.NoError(t, validateRedacted(output))
+
+	assert.True(t, output.Redacted.Restricts("", []string{"payments", "internal"}, ""))
+	assert.False(t, output.Redacted.Restricts("Shop", []string{"payments"}, "public"))
+
+	output.Redacted.Dir = "docs/"
+	require.ErrorContains(t, validateRedacted(output), "already written by another output")
+
+	output.Redacted = Redacted{Dir: "public", Placeholder: "Internal Service"}
+	require.ErrorContains(t, validateRedacted(output), "needs tags, systems or classifications")
+}
+
+func TestLoadConfig_RelationshipRules(t *testing.T) {
+	yamlContent := `
+input:
+  relationships:
+    - systems: ["Shop"]
+      tags: ["edge"]
+      action: uses
+      participant: Central Logging
+      technology: OTLP
+      external: true
+`
+
+	configFile := filepath.Join(t.TempDir(), "rules-config.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte(yamlContent), 0o644))
+
+	injector := do.New()
+	do.ProvideValue(injector, ConfigFilePath(configFile))
+	config, err := LoadConfig(injector)
+	require.NoError(t, err)
+
+	assert.Equal(t, []RelationshipRule{{
+		Systems:     []string{"Shop"},
+		Tags:        []string{"edge"},
+		Action:      "uses",
+		Participant: "Central Logging",
+		Technology:  "OTLP",
+		External:    true,
+	}}, config.Input.Relationships)
+
+	require.ErrorContains(t, validateRelationshipRules([]RelationshipRule{{Action: "uses", Services: []string{"*"}}}),
+		"no participant")
+	require.ErrorContains(t, validateRelationshipRules([]RelationshipRule{{Action: "uses", Participant: "Logging"}}),
+		"matches no services")
+	require.ErrorContains(t, validateRelationshipRules([]RelationshipRule{{Action: "logs", Participant: "Logging",
+		Services: []string{"*"}}}), "action")
+	require.ErrorContains(t, validateRelationshipRules([]RelationshipRule{{Action: "uses", Participant: "Logging",
+		Systems: []string{"[Shop"}}}), "pattern")
+}
+
+func TestLoadConfig_Technologies(t *testing.T) {
+	yamlContent := `
+input:
+  technologies:
+    PostgreSQL: [Postgres, PostgreSQL *]
+    Kafka: [Apache Kafka]
+`
+
+	configFile := filepath.Join(t.TempDir(), "technologies-config.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte(yamlContent), 0o644))
+
+	injector := do.New()
+	do.ProvideValue(injector, ConfigFilePath(configFile))
+	config, err := LoadConfig(injector)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string][]string{
+		"PostgreSQL": {"Postgres", "PostgreSQL *"},
+		"Kafka":      {"Apache Kafka"},
+	}, config.Input.Technologies)
+
+	require.ErrorContains(t, validateTechnologies(map[string][]string{" ": {"pg"}}), "empty")
+	require.ErrorContains(t, validateTechnologies(map[string][]string{"PostgreSQL": {"pg["}}), "pattern")
+	require.ErrorContains(t, validateTechnologies(map[string][]string{"PostgreSQL": {"pg"}, "Pgpool": {"PG"}}),
+		"collapsed into both")
+}
+
+func TestValidateExternalAliases(t *testing.T) {
+	require.NoError(t, validateExternalAliases(map[string][]string{"Stripe": {"Stripe API"}, "Twilio": {"Twilio SMS"}}))
+	require.ErrorContains(t, validateExternalAliases(map[string][]string{"": {"Stripe API"}}), "empty")
+	require.ErrorContains(t, validateExternalAliases(map[string][]string{"Stripe": {"Payments"}, "Adyen": {"payments"}}),
+		"names both")
+}
+
+func TestValidateNotifications(t *testing.T) {
+	require.NoError(t, validateNotifications(Notifications{
+		Type:          "slack",
+		Subscriptions: map[string]string{"team-orders": "https://hooks.slack.com/services/T0/B0/X"},
+	}))
+	require.NoError(t, validateNotifications(Notifications{Type: "teams"}))
+	require.ErrorContains(t, validateNotifications(Notifications{Type: "discord"}), "invalid type: discord")
+	require.ErrorContains(t, validateNotifications(Notifications{
+		Type:          "slack",
+		Subscriptions: map[string]string{" ": "https://hooks.slack.com/services/T0/B0/X"},
+	}), "owner cannot be empty")
+	require.ErrorContains(t, validateNotifications(Notifications{
+		Type:          "mattermost",
+		Subscriptions: map[string]string{"team-orders": "hooks.slack.com/services/T0/B0/X"},
+	}), "webhook of team-orders must be an http or https URL")
+	require.ErrorContains(t, validateNotifications(Notifications{Type: "slack", Timeout: "soon"}), "invalid timeout")
+}
+
+func TestLoadConfig_Precedence(t *testing.T) {
+	yamlContent := `
+input:
+  precedence:
+    description: [asyncapi, servicefile]
+    owner: [servicefile]
+`
+
+	configFile := filepath.Join(t.TempDir(), "precedence-config.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte(yamlContent), 0o644))
+
+	injector := do.New()
+	do.ProvideValue(injector, ConfigFilePath(configFile))
+	config, err := LoadConfig(injector)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string][]string{
+		"description": {"asyncapi", "servicefile"},
+		"owner":       {"servicefile"},
+	}, config.Input.Precedence)
+
+	require.ErrorContains(t, validatePrecedence(map[string][]string{"tags": {"servicefile"}}), "unknown field")
+	require.ErrorContains(t, validatePrecedence(map[string][]string{"owner": {"registry"}}), "unknown source type")
+	require.ErrorContains(t, validatePrecedence(map[string][]string{"owner": {"asyncapi", "asyncapi"}}),
+		"more than once")
+}
+
+func TestLoadConfig_Plugins(t *testing.T) {
+	yamlContent := `
+plugins:
+  - name: catalog
+    kind: source
+    command: holydocs-catalog --insecure
+    config:
+      url: https://catalog.example.com
+  - name: confluence
+    kind: publisher
+    command: holydocs-confluence
+`
+
+	configFile := filepath.Join(t.TempDir(), "plugins-config.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte(yamlContent), 0o644))
+
+	injector := do.New()
+	do.ProvideValue(injector, ConfigFilePath(configFile))
+	config, err := LoadConfig(injector)
+	require.NoError(t, err)
+
+	assert.Equal(t, []Plugin{
+		{
+			Name:    "catalog",
+			Kind:    "source",
+			Command: "holydocs-catalog --insecure",
+			Config:  map[string]string{"url": "https://catalog.example.com"},
+		},
+		{Name: "confluence", Kind: "publisher", Command: "holydocs-confluence"},
+	}, config.Plugins)
+
+	require.ErrorContains(t, validatePlugins([]Plugin{{Name: "a/b", Kind: "source", Command: "x"}}), "name")
+	require.ErrorContains(t, validatePlugins([]Plugin{{Name: "a", Kind: "renderer", Command: "x"}}), "kind")
+	require.ErrorContains(t, validatePlugins([]Plugin{{Name: "a", Kind: "target"}}), "no command")
+	require.ErrorContains(t, validatePlugins([]Plugin{
+		{Name: "a", Kind: "target", Command: "x"},
+		{Name: "a", Kind: "source", Command: "y"},
+	}), "duplicate")
+}
+
+func TestLoadConfig_CustomRules(t *testing.T) {
+	yamlContent := `
+validate:
+  rules:
+    - name: owner-required
+      module: rules/owner.wasm
+      config:
+        prefix: "team:"
+`
+
+	configFile := filepath.Join(t.TempDir(), "rules-config.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte(yamlContent), 0o644))
+
+	injector := do.New()
+	do.ProvideValue(injector, ConfigFilePath(configFile))
+	config, err := LoadConfig(injector)
+	require.NoError(t, err)
+
+	assert.Equal(t, []CustomRule{{
+		Name:   "owner-required",
+		Module: "rules/owner.wasm",
+		Config: map[string]string{"prefix": "team:"},
+	}}, config.Validate.Rules)
+
+	require.ErrorContains(t, validateCustomRules([]CustomRule{{Name: "owner required", Module: "x.wasm"}}), "name")
+	require.ErrorContains(t, validateCustomRules([]CustomRule{{Name: "owner"}}), "no module")
+	require.ErrorContains(t, validateCustomRules([]CustomRule{
+		{Name: "owner", Module: "a.wasm"},
+		{Name: "owner", Module: "b.wasm"},
+	}), "duplicate")
+}
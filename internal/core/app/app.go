@@ -3,18 +3,30 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/holydocs/holydocs/internal/config"
 	"github.com/holydocs/holydocs/internal/core/domain"
 	"github.com/holydocs/messageflow/pkg/messageflow"
-	mfschema "github.com/holydocs/messageflow/pkg/schema"
 	mfd2 "github.com/holydocs/messageflow/pkg/schema/target/d2"
 )
 
 // SchemaLoader defines the interface for loading schemas from external sources.
 type SchemaLoader interface {
 	Load(ctx context.Context, serviceFilesPaths, asyncapiFilesPaths []string) (domain.Schema, error)
+	LoadMessageFlow(ctx context.Context, asyncapiFilesPaths []string) (messageflow.Schema, error)
+	LoadEndpoints(openAPIFilesPaths []string) (map[string][]domain.Endpoint, error)
+	LoadSourceFiles(ctx context.Context, serviceFilesPaths, asyncapiFilesPaths []string) ([]domain.SourceFile, error)
+	LoadDeclarations(ctx context.Context, serviceFilesPaths, asyncapiFilesPaths []string) ([]domain.Declaration, error)
+	LoadMonorepo(root, mappingPath string) (domain.Monorepo, error)
+}
+
+// SchemaCache defines the interface for the cache of parsed specifications.
+type SchemaCache interface {
+	Clear() (int, error)
 }
 
 // TargetRenderer defines the interface for rendering formatted schemas.
@@ -29,15 +41,126 @@ type DocumentationGenerator interface {
 		schema domain.Schema,
 		messageflowSchema messageflow.Schema,
 		messageflowTarget messageflow.Target,
-	) (*domain.Changelog, error)
+		opts domain.GenerateOptions,
+	) (domain.GenerateDocumentationReply, error)
+	GenerateServiceDiagram(
+		ctx context.Context,
+		schema domain.Schema,
+		messageflowSchema messageflow.Schema,
+		messageflowTarget messageflow.Target,
+		req domain.GenerateServiceDiagramRequest,
+	) ([]byte, error)
+	GenerateSnippet(
+		ctx context.Context,
+		schema domain.Schema,
+		messageflowSchema messageflow.Schema,
+		req domain.GenerateSnippetRequest,
+	) (domain.GenerateSnippetReply, error)
+	SquashChangelog(req domain.SquashChangelogRequest) (domain.SquashChangelogReply, error)
+	Release(req domain.ReleaseDocumentationRequest) (domain.ReleaseDocumentationReply, error)
+	DocumentedSchema(outputDir string) (domain.Schema, error)
+	SchemaSnapshot(path string) (domain.Schema, error)
+	GenerateDiffDiagram(ctx context.Context, before, after domain.Schema, format domain.DiagramFormat) ([]byte, error)
+	DiffDocs(before, after string, services []string) (domain.DocsDiff, error)
+	WriteRunReport(outputDir string, report domain.RunReport) error
+	WriteProbeReport(outputDir string, report domain.ProbeReport) error
+	CopyMetadata(fromDir, toDir string) error
+}
+
+// SourceStore defines the interface for persisting specifications pushed as sources.
+type SourceStore interface {
+	Save(ctx context.Context, source domain.Source, content []byte) (domain.Source, error)
+	List(ctx context.Context, now time.Time) ([]domain.Source, error)
+	Prune(ctx context.Context, now time.Time) (int, error)
+	Delete(ctx context.Context, name string) error
+}
+
+// RemoteFetcher defines the interface for fetching specifications of remote sources.
+type RemoteFetcher interface {
+	Fetch(ctx context.Context, source domain.RemoteSource) ([]byte, error)
+}
+
+// AsyncAPIExporter defines the interface for re-emitting the merged schema as AsyncAPI documents.
+type AsyncAPIExporter interface {
+	Export(schema domain.Schema, req domain.ExportAsyncAPIRequest) ([]domain.AsyncAPIDocument, error)
+}
+
+// DependencyExporter defines the interface for encoding the dependencies of all services.
+type DependencyExporter interface {
+	Export(dependencies []domain.Dependency, format domain.DependencyExportFormat) ([]byte, error)
+}
+
+// LineageExporter defines the interface for encoding the datasets services read and write.
+type LineageExporter interface {
+	Export(jobs []domain.LineageJob, req domain.ExportLineageRequest) ([]byte, error)
+}
+
+// WikiPublisher defines the interface for publishing generated documentation to wiki repositories.
+type WikiPublisher interface {
+	Publish(ctx context.Context, req domain.PublishWikiRequest) (domain.PublishWikiReply, error)
+}
+
+// PreviewUploader defines the interface for uploading documentation previews to static hosting.
+type PreviewUploader interface {
+	Upload(ctx context.Context, req domain.UploadPreviewRequest) (domain.UploadPreviewReply, error)
+}
+
+// PluginRunner defines the interface for running plugins speaking the plugin protocol.
+type PluginRunner interface {
+	Run(ctx context.Context, req domain.RunPluginRequest) (domain.RunPluginReply, error)
+}
+
+// RuleChecker defines the interface for checking custom validation rules implemented by WebAssembly modules.
+type RuleChecker interface {
+	Check(ctx context.Context, rule domain.CustomRule, schema domain.Schema) ([]domain.Finding, error)
+}
+
+// OnCallDirectory defines the interface for looking up the escalation policies of services at on-call providers.
+type OnCallDirectory interface {
+	Lookup(ctx context.Context, req domain.LookupOnCallRequest) (domain.OnCall, error)
+}
+
+// StatusPageChecker defines the interface for reading the current status of third-party dependencies.
+type StatusPageChecker interface {
+	Check(ctx context.Context, req domain.CheckStatusPageRequest) (domain.DependencyStatus, error)
+}
+
+// EndpointProber defines the interface for probing the health endpoints of deployments.
+type EndpointProber interface {
+	Probe(ctx context.Context, req domain.ProbeRequest) domain.ProbeResult
+}
+
+// MetricsSource defines the interface for querying the runtime metrics of relationships.
+type MetricsSource interface {
+	Query(ctx context.Context, req domain.QueryMetricRequest) (float64, error)
+}
+
+// Notifier defines the interface for posting changes to the webhooks of subscribers.
+type Notifier interface {
+	Notify(ctx context.Context, req domain.NotifyRequest) error
 }
 
 // App represents the core application with all business logic.
 type App struct {
-	schemaLoader  SchemaLoader
-	docsGenerator DocumentationGenerator
-	target        domain.Target
-	config        *config.Config
+	schemaLoader       SchemaLoader
+	docsGenerator      DocumentationGenerator
+	target             domain.Target
+	sourceStore        SourceStore
+	asyncAPIExporter   AsyncAPIExporter
+	dependencyExporter DependencyExporter
+	lineageExporter    LineageExporter
+	remoteFetcher      RemoteFetcher
+	schemaCache        SchemaCache
+	wikiPublisher      WikiPublisher
+	previewUploader    PreviewUploader
+	pluginRunner       PluginRunner
+	ruleChecker        RuleChecker
+	onCallDirectory    OnCallDirectory
+	statusChecker      StatusPageChecker
+	prober             EndpointProber
+	metrics            MetricsSource
+	notifier           Notifier
+	config             *config.Config
 }
 
 // NewApp creates a new application instance with provided dependencies.
@@ -45,13 +168,43 @@ func NewApp(
 	schemaLoader SchemaLoader,
 	docsGenerator DocumentationGenerator,
 	target domain.Target,
+	sourceStore SourceStore,
+	asyncAPIExporter AsyncAPIExporter,
+	dependencyExporter DependencyExporter,
+	lineageExporter LineageExporter,
+	remoteFetcher RemoteFetcher,
+	schemaCache SchemaCache,
+	wikiPublisher WikiPublisher,
+	previewUploader PreviewUploader,
+	pluginRunner PluginRunner,
+	ruleChecker RuleChecker,
+	onCallDirectory OnCallDirectory,
+	statusChecker StatusPageChecker,
+	prober EndpointProber,
+	metrics MetricsSource,
+	notifier Notifier,
 	config *config.Config,
 ) *App {
 	return &App{
-		schemaLoader:  schemaLoader,
-		docsGenerator: docsGenerator,
-		target:        target,
-		config:        config,
+		schemaLoader:       schemaLoader,
+		docsGenerator:      docsGenerator,
+		target:             target,
+		sourceStore:        sourceStore,
+		asyncAPIExporter:   asyncAPIExporter,
+		dependencyExporter: dependencyExporter,
+		lineageExporter:    lineageExporter,
+		remoteFetcher:      remoteFetcher,
+		schemaCache:        schemaCache,
+		wikiPublisher:      wikiPublisher,
+		previewUploader:    previewUploader,
+		pluginRunner:       pluginRunner,
+		ruleChecker:        ruleChecker,
+		onCallDirectory:    onCallDirectory,
+		statusChecker:      statusChecker,
+		prober:             prober,
+		metrics:            metrics,
+		notifier:           notifier,
+		config:             config,
 	}
 }
 
@@ -60,27 +213,627 @@ func (a *App) GenerateDocumentation(
 	ctx context.Context,
 	req domain.GenerateDocumentationRequest,
 ) (domain.GenerateDocumentationReply, error) {
-	schema, err := a.schemaLoader.Load(ctx, req.ServiceFilesPaths, req.AsyncAPIFilesPaths)
+	start := time.Now()
+	opts := domain.GenerateOptions{KeepGoing: req.KeepGoing}
+
+	if req.Preview {
+		opts.OutputDir = req.OutputDir
+	}
+
+	schema, warnings, err := a.documentedSchema(ctx, &req, &opts)
 	if err != nil {
-		return domain.GenerateDocumentationReply{}, fmt.Errorf("loading schema from files: %w", err)
+		return domain.GenerateDocumentationReply{}, err
 	}
 
-	mfSetup, err := createMessageFlowSetup(ctx, req.AsyncAPIFilesPaths)
+	mfSetup, err := a.createMessageFlowSetup(ctx, req.AsyncAPIFilesPaths)
 	if err != nil {
 		return domain.GenerateDocumentationReply{}, fmt.Errorf("setting up message flow target: %w", err)
 	}
 
-	changelog, err := a.docsGenerator.Generate(ctx, schema, mfSetup.Schema, mfSetup.Target)
+	sourcesDuration := time.Since(start)
+
+	opts.Previous, err = a.previousSchema(req.OutputDir)
+	if err != nil {
+		return domain.GenerateDocumentationReply{}, err
+	}
+
+	reply, err := a.docsGenerator.Generate(ctx, schema, mfSetup.Schema, mfSetup.Target, opts)
 	if err != nil {
 		return domain.GenerateDocumentationReply{}, fmt.Errorf("generating documentation: %w", err)
 	}
 
-	return domain.GenerateDocumentationReply{
-		Changelog: changelog,
+	reply.Warnings = append(reply.Warnings, warnings...)
+
+	if !req.Preview {
+		publishWarnings, err := a.publishDocumentation(ctx, schema, mfSetup, opts, req.OutputDir, reply.Changelog)
+		if err != nil {
+			return domain.GenerateDocumentationReply{}, err
+		}
+
+		reply.Warnings = append(reply.Warnings, publishWarnings...)
+	}
+
+	report := buildRunReport(req, schema, reply, start, sourcesDuration)
+	if err := a.docsGenerator.WriteRunReport(req.OutputDir, report); err != nil {
+		return domain.GenerateDocumentationReply{}, fmt.Errorf("writing run report: %w", err)
+	}
+
+	if len(reply.Errors) > 0 {
+		return reply, fmt.Errorf("%w, %d errors:\n- %s", domain.ErrPartialGeneration, len(reply.Errors),
+			strings.Join(reply.Errors, "\n- "))
+	}
+
+	if req.Strict && len(reply.Warnings) > 0 {
+		return reply, fmt.Errorf("%w:\n- %s", domain.ErrStrictWarnings, strings.Join(reply.Warnings, "\n- "))
+	}
+
+	return reply, nil
+}
+
+// documentedSchema loads the schema to document and fills the options looked up for it. With KeepGoing the
+// specification files failing to load are left out of req. Warnings of the lookups are returned.
+func (a *App) documentedSchema(
+	ctx context.Context,
+	req *domain.GenerateDocumentationRequest,
+	opts *domain.GenerateOptions,
+) (domain.Schema, []string, error) {
+	if req.KeepGoing {
+		req.ServiceFilesPaths, req.AsyncAPIFilesPaths, opts.SourceErrors = a.loadableSpecFiles(ctx,
+			req.ServiceFilesPaths, req.AsyncAPIFilesPaths)
+	}
+
+	schema, err := a.loadSchema(ctx, req.ServiceFilesPaths, req.AsyncAPIFilesPaths)
+	if err != nil {
+		return domain.Schema{}, nil, fmt.Errorf("loading schema from files: %w", err)
+	}
+
+	schema = annotateChannels(schema.FilterNamespaces(a.config.Input.Namespaces), a.config.Documentation.Channels)
+
+	endpoints, err := a.schemaLoader.LoadEndpoints(a.config.Input.OpenAPIFiles)
+	if err != nil {
+		return domain.Schema{}, nil, fmt.Errorf("loading endpoints from OpenAPI files: %w", err)
+	}
+
+	schema, warnings := attachEndpoints(schema, endpoints)
+
+	schema, err = a.applySourceFiles(ctx, *req, schema, opts)
+	if err != nil {
+		return domain.Schema{}, nil, err
+	}
+
+	lookupWarnings, err := a.lookupOptions(ctx, schema, opts)
+	if err != nil {
+		return domain.Schema{}, nil, err
+	}
+
+	return schema, append(warnings, lookupWarnings...), nil
+}
+
+// applySourceFiles reads the source files of the specifications when a feature depends on them: the review of
+// discovered services, monorepo mapping, staleness and attribution of changes.
+func (a *App) applySourceFiles(
+	ctx context.Context,
+	req domain.GenerateDocumentationRequest,
+	schema domain.Schema,
+	opts *domain.GenerateOptions,
+) (domain.Schema, error) {
+	staleness, monorepo, review := a.config.Documentation.Staleness, a.config.Input.Monorepo, a.config.Input.Review
+	attribution := req.Attribution == domain.ChangeAttributionGit
+
+	if staleness.AfterMonths <= 0 && !staleness.Badges && monorepo.Root == "" && !review.Enabled && !attribution {
+		return schema, nil
+	}
+
+	files, err := a.schemaLoader.LoadSourceFiles(ctx, req.ServiceFilesPaths, req.AsyncAPIFilesPaths)
+	if err != nil {
+		return domain.Schema{}, fmt.Errorf("reading source files: %w", err)
+	}
+
+	if review.Enabled {
+		sources, err := a.ListSources(ctx)
+		if err != nil {
+			return domain.Schema{}, err
+		}
+
+		schema, opts.PendingReview = pendingServices(schema, files, sources, review.Approved)
+	}
+
+	if monorepo.Root != "" {
+		packages, err := a.schemaLoader.LoadMonorepo(monorepo.Root, monorepo.Mapping)
+		if err != nil {
+			return domain.Schema{}, fmt.Errorf("reading monorepo: %w", err)
+		}
+
+		schema = applyMonorepo(schema, files, monorepo.Root, monorepo.Repository, packages)
+	}
+
+	if staleness.AfterMonths > 0 {
+		opts.NeedsReview = withoutPending(staleServices(schema, files, time.Now(), staleness.AfterMonths),
+			opts.PendingReview)
+	}
+
+	if staleness.Badges {
+		opts.LastUpdated = servicesModifiedAt(files)
+	}
+
+	if attribution {
+		opts.Attribution = serviceCommits(files)
+	}
+
+	return schema, nil
+}
+
+// lookupOptions looks up on-call policies, the status of third-party dependencies and runtime metrics for the
+// schema. Unreachable services are returned as warnings.
+func (a *App) lookupOptions(ctx context.Context, schema domain.Schema, opts *domain.GenerateOptions) ([]string, error) {
+	var (
+		warnings, lookupWarnings []string
+		err                      error
+	)
+
+	opts.OnCall, warnings, err = a.lookupOnCall(ctx, schema)
+	if err != nil {
+		return nil, err
+	}
+
+	opts.DependencyStatus, lookupWarnings, err = a.checkStatusPages(ctx, schema)
+	if err != nil {
+		return nil, err
+	}
+
+	warnings = append(warnings, lookupWarnings...)
+
+	opts.RuntimeMetrics, lookupWarnings, err = a.queryRuntimeMetrics(ctx, schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(warnings, lookupWarnings...), nil
+}
+
+// previousSchema returns the schema documented in outputDir before it is regenerated, which is nil on the
+// first run.
+func (a *App) previousSchema(outputDir string) (*domain.Schema, error) {
+	schema, err := a.recordedSchema(outputDir)
+	if errors.Is(err, domain.ErrMetadataNotFound) {
+		return nil, nil // First run
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("reading documented schema: %w", err)
+	}
+
+	return &schema, nil
+}
+
+// recordedSchema returns the schema recorded in domain.json of outputDir. Its technologies are canonicalized
+// like the ones of loaded schemas, so configuring input.technologies doesn't turn the renames into changes.
+func (a *App) recordedSchema(outputDir string) (domain.Schema, error) {
+	schema, err := a.docsGenerator.DocumentedSchema(outputDir)
+	if err != nil {
+		return domain.Schema{}, err
+	}
+
+	return schema.CanonicalizeTechnologies(a.config.Input.Technologies), nil
+}
+
+// publishDocumentation writes the redacted copy of the documentation, runs the target plugins on the output
+// directory and notifies subscribers of the changes, none of which pull request previews do.
+func (a *App) publishDocumentation(
+	ctx context.Context,
+	schema domain.Schema,
+	mfSetup domain.MessageFlowSetup,
+	opts domain.GenerateOptions,
+	outputDir string,
+	changelog *domain.Changelog,
+) ([]string, error) {
+	if redacted := a.config.Output.Redacted; redacted.Dir != "" {
+		if err := a.generateRedactedDocumentation(ctx, schema, mfSetup, opts, redacted); err != nil {
+			return nil, err
+		}
+	}
+
+	warnings, err := a.runTargetPlugins(ctx, schema, outputDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var previous domain.Schema
+	if opts.Previous != nil {
+		previous = *opts.Previous
+	}
+
+	notificationWarnings, err := a.notifySubscribers(ctx, previous, schema, changelog)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(warnings, notificationWarnings...), nil
+}
+
+func buildRunReport(
+	req domain.GenerateDocumentationRequest,
+	schema domain.Schema,
+	reply domain.GenerateDocumentationReply,
+	start time.Time,
+	sourcesDuration time.Duration,
+) domain.RunReport {
+	operations := 0
+	for _, service := range schema.Services {
+		operations += len(service.Operation)
+	}
+
+	warnings := reply.Warnings
+	if warnings == nil {
+		warnings = []string{}
+	}
+
+	errs := reply.Errors
+	if errs == nil {
+		errs = []string{}
+	}
+
+	return domain.RunReport{
+		Version:     domain.RunReportVersion,
+		GeneratedAt: start.UTC(),
+		DurationMS:  time.Since(start).Milliseconds(),
+		Sources: domain.SourcesReport{
+			ServiceFiles:  len(req.ServiceFilesPaths),
+			AsyncAPIFiles: len(req.AsyncAPIFilesPaths),
+			Services:      len(schema.Services),
+			Operations:    operations,
+			DurationMS:    sourcesDuration.Milliseconds(),
+		},
+		Diagrams:  reply.Diagrams,
+		Warnings:  warnings,
+		Errors:    errs,
+		Changelog: domain.NewChangelogSummary(reply.Changelog),
+	}
+}
+
+// loadableSpecFiles loads every specification file on its own and leaves out the ones that fail,
+// returning their errors, so one malformed file doesn't prevent documenting the others.
+func (a *App) loadableSpecFiles(
+	ctx context.Context,
+	serviceFilesPaths, asyncAPIFilesPaths []string,
+) ([]string, []string, []string) {
+	var (
+		serviceFiles, asyncAPIFiles []string
+		errs                        []string
+	)
+
+	for _, path := range serviceFilesPaths {
+		if _, err := a.schemaLoader.Load(ctx, []string{path}, nil); err != nil {
+			errs = append(errs, fmt.Sprintf("loading %s: %v", path, err))
+
+			continue
+		}
+		serviceFiles = append(serviceFiles, path)
+	}
+
+	for _, path := range asyncAPIFilesPaths {
+		if _, err := a.schemaLoader.Load(ctx, nil, []string{path}); err != nil {
+			errs = append(errs, fmt.Sprintf("loading %s: %v", path, err))
+
+			continue
+		}
+		asyncAPIFiles = append(asyncAPIFiles, path)
+	}
+
+	return serviceFiles, asyncAPIFiles, errs
+}
+
+// GenerateServiceDiagram renders a single diagram for one service without generating the whole documentation.
+func (a *App) GenerateServiceDiagram(ctx context.Context, req domain.GenerateServiceDiagramRequest) ([]byte, error) {
+	schema, err := a.loadSchema(ctx, req.ServiceFilesPaths, req.AsyncAPIFilesPaths)
+	if err != nil {
+		return nil, fmt.Errorf("loading schema from files: %w", err)
+	}
+
+	mfSetup, err := a.createMessageFlowSetup(ctx, req.AsyncAPIFilesPaths)
+	if err != nil {
+		return nil, fmt.Errorf("setting up message flow target: %w", err)
+	}
+
+	diagram, err := a.docsGenerator.GenerateServiceDiagram(ctx, schema, mfSetup.Schema, mfSetup.Target, req)
+	if err != nil {
+		return nil, fmt.Errorf("generating %s diagram for %s: %w", req.Type, req.Service, err)
+	}
+
+	return diagram, nil
+}
+
+// GenerateSnippet renders the README snippet of a service, to be committed into the repository of the service.
+func (a *App) GenerateSnippet(
+	ctx context.Context,
+	req domain.GenerateSnippetRequest,
+) (domain.GenerateSnippetReply, error) {
+	schema, err := a.loadSchema(ctx, req.ServiceFilesPaths, req.AsyncAPIFilesPaths)
+	if err != nil {
+		return domain.GenerateSnippetReply{}, fmt.Errorf("loading schema from files: %w", err)
+	}
+
+	mfSetup, err := a.createMessageFlowSetup(ctx, req.AsyncAPIFilesPaths)
+	if err != nil {
+		return domain.GenerateSnippetReply{}, fmt.Errorf("setting up message flow target: %w", err)
+	}
+
+	reply, err := a.docsGenerator.GenerateSnippet(ctx, schema, mfSetup.Schema, req)
+	if err != nil {
+		return domain.GenerateSnippetReply{}, fmt.Errorf("generating snippet for %s: %w", req.Service, err)
+	}
+
+	return reply, nil
+}
+
+// SquashChangelog collapses old changelog entries of previously generated documentation into a baseline entry.
+func (a *App) SquashChangelog(_ context.Context, req domain.SquashChangelogRequest) (domain.SquashChangelogReply, error) {
+	reply, err := a.docsGenerator.SquashChangelog(req)
+	if err != nil {
+		return domain.SquashChangelogReply{}, fmt.Errorf("squashing changelog: %w", err)
+	}
+
+	return reply, nil
+}
+
+// ReleaseDocumentation snapshots the generated documentation under its releases directory.
+func (a *App) ReleaseDocumentation(
+	_ context.Context,
+	req domain.ReleaseDocumentationRequest,
+) (domain.ReleaseDocumentationReply, error) {
+	reply, err := a.docsGenerator.Release(req)
+	if err != nil {
+		return domain.ReleaseDocumentationReply{}, fmt.Errorf("releasing documentation: %w", err)
+	}
+
+	return reply, nil
+}
+
+// DocumentedSchema returns the schema of the documentation last generated into outputDir.
+func (a *App) DocumentedSchema(_ context.Context, outputDir string) (domain.Schema, error) {
+	schema, err := a.recordedSchema(outputDir)
+	if err != nil {
+		return domain.Schema{}, fmt.Errorf("reading documented schema: %w", err)
+	}
+
+	return schema, nil
+}
+
+// IngestSource stores a pushed specification as a source that expires after the configured TTL
+// unless the request overrides it or the source is pushed again.
+func (a *App) IngestSource(ctx context.Context, req domain.IngestSourceRequest) (domain.Source, error) {
+	ttl := req.TTL
+	if ttl <= 0 {
+		configured, err := a.config.Ingest.TTLDuration()
+		if err != nil {
+			return domain.Source{}, fmt.Errorf("getting source ttl: %w", err)
+		}
+		ttl = configured
+	}
+
+	now := time.Now().UTC()
+	source := domain.Source{Name: req.Name, ReceivedAt: now}
+	if ttl > 0 {
+		source.ExpiresAt = now.Add(ttl)
+	}
+
+	source, err := a.sourceStore.Save(ctx, source, req.Content)
+	if err != nil {
+		return domain.Source{}, fmt.Errorf("saving source %s: %w", req.Name, err)
+	}
+
+	return source, nil
+}
+
+// ListSources returns sources that are not expired yet.
+func (a *App) ListSources(ctx context.Context) ([]domain.Source, error) {
+	sources, err := a.sourceStore.List(ctx, time.Now().UTC())
+	if err != nil {
+		return nil, fmt.Errorf("listing sources: %w", err)
+	}
+
+	return sources, nil
+}
+
+// PruneSources removes expired sources and returns how many were removed.
+func (a *App) PruneSources(ctx context.Context) (int, error) {
+	removed, err := a.sourceStore.Prune(ctx, time.Now().UTC())
+	if err != nil {
+		return removed, fmt.Errorf("pruning sources: %w", err)
+	}
+
+	return removed, nil
+}
+
+// FetchRemoteSources fetches the configured remote sources and stores them as ingested sources.
+// A failing optional source is returned as a warning and its previously fetched copy, if any, stays in use.
+func (a *App) FetchRemoteSources(ctx context.Context) ([]string, error) {
+	var warnings []string
+
+	for _, remote := range a.config.Input.Remote {
+		source, err := remoteSource(remote)
+		if err != nil {
+			return nil, err
+		}
+
+		content, err := a.remoteFetcher.Fetch(ctx, source)
+		if err == nil {
+			_, err = a.IngestSource(ctx, domain.IngestSourceRequest{Name: source.Name, Content: content})
+		}
+
+		if err != nil {
+			if !source.Optional {
+				return nil, fmt.Errorf("remote source %s: %w", source.Name, err)
+			}
+
+			warnings = append(warnings, fmt.Sprintf("optional remote source %s skipped: %v", source.Name, err))
+		}
+	}
+
+	return warnings, nil
+}
+
+func remoteSource(remote config.RemoteSource) (domain.RemoteSource, error) {
+	timeout, err := remote.TimeoutDuration()
+	if err != nil {
+		return domain.RemoteSource{}, fmt.Errorf("getting remote source timeout: %w", err)
+	}
+
+	backoff, err := remote.BackoffDuration()
+	if err != nil {
+		return domain.RemoteSource{}, fmt.Errorf("getting remote source backoff: %w", err)
+	}
+
+	return domain.RemoteSource{
+		Name:     remote.Name,
+		URL:      remote.Url,
+		Timeout:  timeout,
+		Retries:  remote.Retries,
+		Backoff:  backoff,
+		Optional: remote.Optional,
 	}, nil
 }
 
-func createMessageFlowSetup(
+// DeleteSource removes a source, e.g. when its service is decommissioned.
+func (a *App) DeleteSource(ctx context.Context, name string) error {
+	if err := a.sourceStore.Delete(ctx, name); err != nil {
+		return fmt.Errorf("deleting source %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// ExportAsyncAPI re-emits the async operations of the merged schema as AsyncAPI documents.
+func (a *App) ExportAsyncAPI(
+	ctx context.Context,
+	req domain.ExportAsyncAPIRequest,
+) ([]domain.AsyncAPIDocument, error) {
+	schema, err := a.loadSchema(ctx, req.ServiceFilesPaths, req.AsyncAPIFilesPaths)
+	if err != nil {
+		return nil, fmt.Errorf("loading schema from files: %w", err)
+	}
+
+	schema.Sort()
+
+	documents, err := a.asyncAPIExporter.Export(schema, req)
+	if err != nil {
+		return nil, fmt.Errorf("exporting AsyncAPI documents: %w", err)
+	}
+
+	return documents, nil
+}
+
+// ExportDependencies exports the relationships of all services, sorted by source service, in the requested
+// format.
+func (a *App) ExportDependencies(
+	ctx context.Context,
+	req domain.ExportDependenciesRequest,
+) (domain.DependencyExport, error) {
+	schema, err := a.loadSchema(ctx, req.ServiceFilesPaths, req.AsyncAPIFilesPaths)
+	if err != nil {
+		return domain.DependencyExport{}, fmt.Errorf("loading schema from files: %w", err)
+	}
+
+	schema.Sort()
+
+	var dependencies []domain.Dependency
+
+	for _, service := range schema.Services {
+		for _, rel := range service.Relationships {
+			dependencies = append(dependencies, domain.Dependency{
+				Source:     service.Info.Name,
+				Target:     rel.Participant,
+				Action:     rel.Action,
+				Technology: rel.Technology,
+				Proto:      rel.Proto,
+				System:     service.Info.System,
+				Owner:      service.Info.Owner,
+			})
+		}
+	}
+
+	content, err := a.dependencyExporter.Export(dependencies, req.Format)
+	if err != nil {
+		return domain.DependencyExport{}, fmt.Errorf("exporting dependencies: %w", err)
+	}
+
+	return domain.DependencyExport{Dependencies: len(dependencies), Content: content}, nil
+}
+
+// ExportRadar aggregates the technologies of the relationships of all services into technology radar
+// entries, placed in the rings and quadrants of the configuration and sorted by name.
+func (a *App) ExportRadar(ctx context.Context, req domain.ExportRadarRequest) ([]domain.RadarEntry, error) {
+	schema, err := a.loadSchema(ctx, req.ServiceFilesPaths, req.AsyncAPIFilesPaths)
+	if err != nil {
+		return nil, fmt.Errorf("loading schema from files: %w", err)
+	}
+
+	schema.Sort()
+
+	return radarEntries(schema, a.config.Export.Radar), nil
+}
+
+// ExportLineage exports the datastores and channels every service reads and writes, sorted by service, in the
+// requested format.
+func (a *App) ExportLineage(ctx context.Context, req domain.ExportLineageRequest) (domain.LineageExport, error) {
+	schema, err := a.loadSchema(ctx, req.ServiceFilesPaths, req.AsyncAPIFilesPaths)
+	if err != nil {
+		return domain.LineageExport{}, fmt.Errorf("loading schema from files: %w", err)
+	}
+
+	schema.Sort()
+
+	jobs := lineageJobs(schema)
+
+	content, err := a.lineageExporter.Export(jobs, req)
+	if err != nil {
+		return domain.LineageExport{}, fmt.Errorf("exporting lineage: %w", err)
+	}
+
+	return domain.LineageExport{Jobs: len(jobs), Content: content}, nil
+}
+
+// ClearCache removes cached schemas parsed from specifications and returns how many were removed.
+func (a *App) ClearCache(_ context.Context) (int, error) {
+	removed, err := a.schemaCache.Clear()
+	if err != nil {
+		return removed, fmt.Errorf("clearing schema cache: %w", err)
+	}
+
+	return removed, nil
+}
+
+// PublishWiki publishes generated documentation to a wiki repository.
+func (a *App) PublishWiki(ctx context.Context, req domain.PublishWikiRequest) (domain.PublishWikiReply, error) {
+	reply, err := a.wikiPublisher.Publish(ctx, req)
+	if err != nil {
+		return domain.PublishWikiReply{}, fmt.Errorf("publishing to %s wiki: %w", req.Provider, err)
+	}
+
+	return reply, nil
+}
+
+// PreparePreview seeds the directory of a preview with the metadata of the documentation in outputDir, so
+// the changelog of the preview lists the changes it previews.
+func (a *App) PreparePreview(_ context.Context, outputDir, previewDir string) error {
+	if err := a.docsGenerator.CopyMetadata(outputDir, previewDir); err != nil {
+		return fmt.Errorf("copying metadata to preview: %w", err)
+	}
+
+	return nil
+}
+
+// UploadPreview uploads the documentation generated for a preview to static hosting.
+func (a *App) UploadPreview(ctx context.Context, req domain.UploadPreviewRequest) (domain.UploadPreviewReply, error) {
+	reply, err := a.previewUploader.Upload(ctx, req)
+	if err != nil {
+		return domain.UploadPreviewReply{}, fmt.Errorf("uploading preview %s: %w", req.Slug, err)
+	}
+
+	return reply, nil
+}
+
+func (a *App) createMessageFlowSetup(
 	ctx context.Context,
 	asyncAPIFilesPaths []string,
 ) (domain.MessageFlowSetup, error) {
@@ -88,7 +841,7 @@ func createMessageFlowSetup(
 		return domain.MessageFlowSetup{}, nil
 	}
 
-	mfSchema, err := mfschema.Load(ctx, asyncAPIFilesPaths)
+	mfSchema, err := a.schemaLoader.LoadMessageFlow(ctx, asyncAPIFilesPaths)
 	if err != nil {
 		return domain.MessageFlowSetup{}, fmt.Errorf("loading messageflow schema: %w", err)
 	}
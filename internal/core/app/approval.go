@@ -0,0 +1,81 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+)
+
+// unapprovedRelationshipFindings reports the relationships between systems the changelog from the documented
+// schema to the schema adds, relationships of added services included, that neither carry an approval
+// annotation nor have an approval entry.
+func unapprovedRelationshipFindings(
+	documented, schema domain.Schema,
+	approvals []domain.RelationshipApproval,
+) []domain.Finding {
+	added := make(map[string]bool)
+
+	for _, change := range documented.Compare(schema).Changes {
+		if change.Type == domain.ChangeTypeAdded {
+			added[change.Category+":"+change.Name] = true
+		}
+	}
+
+	systems := make(map[string]string, len(schema.Services))
+	for _, service := range schema.Services {
+		systems[service.Info.Name] = strings.TrimSpace(service.Info.System)
+	}
+
+	var findings []domain.Finding
+
+	for _, service := range schema.Services {
+		newService := added["service:"+service.Info.Name]
+		system := systems[service.Info.Name]
+
+		for _, rel := range service.Relationships {
+			if !newService && !added["relationship:"+service.Info.Name+":"+domain.RelationshipKey(rel)] {
+				continue
+			}
+
+			participantSystem, documentedParticipant := systems[rel.Participant]
+			if documentedParticipant && participantSystem == system || !documentedParticipant && system == "" {
+				continue
+			}
+
+			if rel.Approval != "" || approved(approvals, service.Info.Name, rel.Participant) {
+				continue
+			}
+
+			findings = append(findings, domain.Finding{
+				Rule:    domain.FindingRuleUnapprovedRelationship,
+				Subject: service.Info.Name,
+				Message: fmt.Sprintf("new %s relationship to %s crosses from %s to %s without approval", rel.Action,
+					rel.Participant, systemLabel(system, true), systemLabel(participantSystem, documentedParticipant)),
+			})
+		}
+	}
+
+	return findings
+}
+
+func approved(approvals []domain.RelationshipApproval, service, participant string) bool {
+	for _, approval := range approvals {
+		if approval.Service == service && approval.Participant == participant {
+			return true
+		}
+	}
+
+	return false
+}
+
+func systemLabel(system string, documented bool) string {
+	switch {
+	case !documented:
+		return "outside the documented services"
+	case system == "":
+		return "no system"
+	default:
+		return "system " + system
+	}
+}
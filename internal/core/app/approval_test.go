@@ -0,0 +1,86 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnapprovedRelationshipFindings(t *testing.T) {
+	t.Parallel()
+
+	orders := domain.Relationship{Action: domain.RelationshipActionRequests, Participant: "Billing", Technology: "gRPC"}
+
+	documented := domain.Schema{Services: []domain.Service{
+		{Info: domain.ServiceInfo{Name: "Orders", System: "Commerce"}, Relationships: []domain.Relationship{orders}},
+		{Info: domain.ServiceInfo{Name: "Billing", System: "Finance"}},
+		{Info: domain.ServiceInfo{Name: "Cart", System: "Commerce"}},
+	}}
+
+	schema := domain.Schema{Services: []domain.Service{
+		{Info: domain.ServiceInfo{Name: "Orders", System: "Commerce"}, Relationships: []domain.Relationship{
+			orders,
+			{Action: domain.RelationshipActionRequests, Participant: "Cart", Technology: "HTTP"},
+			{Action: domain.RelationshipActionSends, Participant: "Billing", Technology: "Kafka"},
+			{Action: domain.RelationshipActionUses, Participant: "Stripe", Technology: "HTTPS"},
+			{Action: domain.RelationshipActionUses, Participant: "Ledger", Technology: "HTTP", Approval: "ADR-12"},
+		}},
+		{Info: domain.ServiceInfo{Name: "Billing", System: "Finance"}},
+		{Info: domain.ServiceInfo{Name: "Cart", System: "Commerce"}},
+		{Info: domain.ServiceInfo{Name: "Ledger", System: "Finance"}},
+		{Info: domain.ServiceInfo{Name: "Refunds", System: "Finance"}, Relationships: []domain.Relationship{
+			{Action: domain.RelationshipActionRequests, Participant: "Cart", Technology: "HTTP"},
+		}},
+	}}
+
+	findings := unapprovedRelationshipFindings(documented, schema, nil)
+	require.Len(t, findings, 3)
+	assert.Equal(t, domain.Finding{
+		Rule:    domain.FindingRuleUnapprovedRelationship,
+		Subject: "Orders",
+		Message: "new sends relationship to Billing crosses from system Commerce to system Finance without approval",
+	}, findings[0])
+	assert.Equal(t, "new uses relationship to Stripe crosses from system Commerce to outside the documented "+
+		"services without approval", findings[1].Message)
+	assert.Equal(t, "Refunds", findings[2].Subject)
+
+	findings = unapprovedRelationshipFindings(documented, schema, []domain.RelationshipApproval{
+		{Service: "Orders", Participant: "Billing", Reference: "ADR-7"},
+		{Service: "Orders", Participant: "Stripe", Reference: "ADR-9"},
+	})
+	require.Len(t, findings, 1)
+	assert.Equal(t, "Refunds", findings[0].Subject)
+}
+
+type fakeDocumentationGenerator struct {
+	DocumentationGenerator
+
+	documented domain.Schema
+}
+
+func (g *fakeDocumentationGenerator) DocumentedSchema(string) (domain.Schema, error) {
+	return g.documented, nil
+}
+
+func TestApp_DocumentedSchema_CanonicalTechnologies(t *testing.T) {
+	t.Parallel()
+
+	a := &App{
+		docsGenerator: &fakeDocumentationGenerator{documented: domain.Schema{Services: []domain.Service{{
+			Info: domain.ServiceInfo{Name: "Orders"},
+			Relationships: []domain.Relationship{
+				{Action: domain.RelationshipActionUses, Participant: "orders-db", Technology: "Postgres"},
+			},
+		}}}},
+		config: &config.Config{Input: config.Input{Technologies: map[string][]string{"PostgreSQL": {"Postgres"}}}},
+	}
+
+	documented, err := a.DocumentedSchema(context.Background(), "docs")
+	require.NoError(t, err)
+	assert.Equal(t, "PostgreSQL", documented.Services[0].Relationships[0].Technology,
+		"Compare callers don't see the renames of input.technologies as changes")
+}
@@ -0,0 +1,18 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+)
+
+// Coverage measures how completely the merged services document their owners and descriptions.
+func (a *App) Coverage(ctx context.Context, req domain.CoverageRequest) (domain.Coverage, error) {
+	schema, err := a.loadSchema(ctx, req.ServiceFilesPaths, req.AsyncAPIFilesPaths)
+	if err != nil {
+		return domain.Coverage{}, fmt.Errorf("loading schema from files: %w", err)
+	}
+
+	return schema.Coverage(), nil
+}
@@ -0,0 +1,67 @@
+package app
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+)
+
+// DiffSchemas compares two schema snapshots, summarizes the changes of their documentation and, for visual
+// diffs, renders the overview diagram of the changes.
+func (a *App) DiffSchemas(ctx context.Context, req domain.DiffSchemasRequest) (domain.DiffSchemasReply, error) {
+	before, err := a.docsGenerator.SchemaSnapshot(req.Before)
+	if err != nil {
+		return domain.DiffSchemasReply{}, fmt.Errorf("loading %s: %w", req.Before, err)
+	}
+
+	after, err := a.docsGenerator.SchemaSnapshot(req.After)
+	if err != nil {
+		return domain.DiffSchemasReply{}, fmt.Errorf("loading %s: %w", req.After, err)
+	}
+
+	// Snapshots may predate input.technologies, whose renames are not changes.
+	technologies := a.config.Input.Technologies
+	before, after = before.CanonicalizeTechnologies(technologies), after.CanonicalizeTechnologies(technologies)
+
+	reply := domain.DiffSchemasReply{Changelog: before.Compare(after)}
+	sortChanges(reply.Changelog.Changes)
+
+	if req.Attribution == domain.ChangeAttributionGit {
+		files, err := a.schemaLoader.LoadSourceFiles(ctx, req.ServiceFilesPaths, req.AsyncAPIFilesPaths)
+		if err != nil {
+			return domain.DiffSchemasReply{}, fmt.Errorf("reading source files: %w", err)
+		}
+
+		reply.Changelog = reply.Changelog.Attribute(serviceCommits(files))
+	}
+
+	services := make([]string, 0, len(before.Services)+len(after.Services))
+	for _, service := range slices.Concat(before.Services, after.Services) {
+		services = append(services, service.Info.Name)
+	}
+
+	reply.Docs, err = a.docsGenerator.DiffDocs(req.Before, req.After, services)
+	if err != nil {
+		return domain.DiffSchemasReply{}, fmt.Errorf("comparing documentation: %w", err)
+	}
+
+	if req.Visual {
+		reply.Diagram, err = a.docsGenerator.GenerateDiffDiagram(ctx, before, after, req.Format)
+		if err != nil {
+			return domain.DiffSchemasReply{}, fmt.Errorf("generating diff diagram: %w", err)
+		}
+	}
+
+	return reply, nil
+}
+
+// sortChanges orders changes by the service or relationship they are about, as comparing schemas lists them
+// in no particular order.
+func sortChanges(changes []domain.Change) {
+	slices.SortFunc(changes, func(a, b domain.Change) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.Category, b.Category), cmp.Compare(a.Type, b.Type))
+	})
+}
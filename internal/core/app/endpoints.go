@@ -0,0 +1,42 @@
+package app
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+)
+
+// attachEndpoints adds the HTTP endpoints loaded from OpenAPI specifications to their services.
+// Endpoints of services missing from the schema are left out and reported as warnings.
+func attachEndpoints(schema domain.Schema, endpoints map[string][]domain.Endpoint) (domain.Schema, []string) {
+	if len(endpoints) == 0 {
+		return schema, nil
+	}
+
+	services := make([]domain.Service, len(schema.Services))
+	attached := make(map[string]struct{}, len(endpoints))
+
+	for i, service := range schema.Services {
+		if serviceEndpoints, ok := endpoints[service.Info.Name]; ok {
+			service.Endpoints = slices.Concat(service.Endpoints, serviceEndpoints)
+			attached[service.Info.Name] = struct{}{}
+		}
+
+		services[i] = service
+	}
+
+	schema.Services = services
+	schema.Sort()
+
+	var warnings []string
+
+	for _, name := range slices.Sorted(maps.Keys(endpoints)) {
+		if _, ok := attached[name]; !ok {
+			warnings = append(warnings, fmt.Sprintf("OpenAPI endpoints of unknown service %s left out", name))
+		}
+	}
+
+	return schema, warnings
+}
@@ -0,0 +1,32 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttachEndpoints(t *testing.T) {
+	t.Parallel()
+
+	schema := domain.Schema{Services: []domain.Service{
+		{Info: domain.ServiceInfo{Name: "Campaign Service"}},
+		{Info: domain.ServiceInfo{Name: "User Service"}},
+	}}
+
+	schema, warnings := attachEndpoints(schema, map[string][]domain.Endpoint{
+		"Campaign Service": {
+			{Method: "POST", Path: "/campaigns"},
+			{Method: "GET", Path: "/campaigns"},
+		},
+		"Billing API": {{Method: "GET", Path: "/invoices"}},
+	})
+
+	assert.Equal(t, []domain.Endpoint{
+		{Method: "GET", Path: "/campaigns"},
+		{Method: "POST", Path: "/campaigns"},
+	}, schema.Services[0].Endpoints)
+	assert.Empty(t, schema.Services[1].Endpoints)
+	assert.Equal(t, []string{"OpenAPI endpoints of unknown service Billing API left out"}, warnings)
+}
@@ -0,0 +1,88 @@
+package app
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+)
+
+// lineageJobs lists the services reading or writing datasets: the participants of relationships with an access
+// mode, which are datastores, and the channels of operations. Services receive from the channels they receive
+// on and the reply channels of their send operations, and write to the other ones.
+func lineageJobs(schema domain.Schema) []domain.LineageJob {
+	var jobs []domain.LineageJob
+
+	for _, service := range schema.Services {
+		job := domain.LineageJob{
+			Service:     service.Info.Name,
+			Description: service.Info.Description,
+			System:      service.Info.System,
+			Owner:       service.Info.Owner,
+		}
+
+		for _, rel := range service.Relationships {
+			if rel.Access == "" {
+				continue
+			}
+
+			dataset := domain.LineageDataset{
+				Name:       rel.Participant,
+				Kind:       domain.LineageDatasetDatastore,
+				Technology: rel.Technology,
+			}
+
+			if rel.Access.Reads() {
+				job.Inputs = appendDataset(job.Inputs, dataset)
+			}
+
+			if rel.Access.Writes() {
+				job.Outputs = appendDataset(job.Outputs, dataset)
+			}
+		}
+
+		for _, op := range service.Operation {
+			channel := domain.LineageDataset{Name: op.Channel.Name, Kind: domain.LineageDatasetChannel}
+
+			var reply *domain.LineageDataset
+			if op.Reply != nil {
+				reply = &domain.LineageDataset{Name: op.Reply.Name, Kind: domain.LineageDatasetChannel}
+			}
+
+			switch op.Action {
+			case domain.ActionSend:
+				job.Outputs = appendDataset(job.Outputs, channel)
+				if reply != nil {
+					job.Inputs = appendDataset(job.Inputs, *reply)
+				}
+			case domain.ActionReceive:
+				job.Inputs = appendDataset(job.Inputs, channel)
+				if reply != nil {
+					job.Outputs = appendDataset(job.Outputs, *reply)
+				}
+			}
+		}
+
+		if len(job.Inputs) == 0 && len(job.Outputs) == 0 {
+			continue
+		}
+
+		slices.SortFunc(job.Inputs, compareDatasets)
+		slices.SortFunc(job.Outputs, compareDatasets)
+		jobs = append(jobs, job)
+	}
+
+	return jobs
+}
+
+func appendDataset(datasets []domain.LineageDataset, dataset domain.LineageDataset) []domain.LineageDataset {
+	if slices.Contains(datasets, dataset) {
+		return datasets
+	}
+
+	return append(datasets, dataset)
+}
+
+func compareDatasets(a, b domain.LineageDataset) int {
+	return cmp.Or(cmp.Compare(a.Kind, b.Kind), cmp.Compare(a.Name, b.Name), cmp.Compare(a.Technology, b.Technology))
+}
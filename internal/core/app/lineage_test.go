@@ -0,0 +1,52 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLineageJobs(t *testing.T) {
+	t.Parallel()
+
+	schema := domain.Schema{Services: []domain.Service{
+		{
+			Info: domain.ServiceInfo{Name: "Order Service", System: "Shop", Owner: "team-orders"},
+			Relationships: []domain.Relationship{
+				{Action: domain.RelationshipActionUses, Participant: "orders-db", Technology: "PostgreSQL",
+					Access: domain.AccessReadWrite},
+				{Action: domain.RelationshipActionUses, Participant: "catalog-cache", Technology: "Redis",
+					Access: domain.AccessRead},
+				{Action: domain.RelationshipActionRequests, Participant: "User Service"},
+			},
+			Operation: []domain.Operation{
+				{Action: domain.ActionSend, Channel: domain.Channel{Name: "orders.created"}},
+				{Action: domain.ActionSend, Channel: domain.Channel{Name: "payments.charge"},
+					Reply: &domain.Channel{Name: "payments.charged"}},
+			},
+		},
+		{
+			Info: domain.ServiceInfo{Name: "User Service"},
+			Relationships: []domain.Relationship{
+				{Action: domain.RelationshipActionRequests, Participant: "Auth Service"},
+			},
+		},
+	}}
+
+	assert.Equal(t, []domain.LineageJob{{
+		Service: "Order Service",
+		System:  "Shop",
+		Owner:   "team-orders",
+		Inputs: []domain.LineageDataset{
+			{Name: "payments.charged", Kind: domain.LineageDatasetChannel},
+			{Name: "catalog-cache", Kind: domain.LineageDatasetDatastore, Technology: "Redis"},
+			{Name: "orders-db", Kind: domain.LineageDatasetDatastore, Technology: "PostgreSQL"},
+		},
+		Outputs: []domain.LineageDataset{
+			{Name: "orders.created", Kind: domain.LineageDatasetChannel},
+			{Name: "payments.charge", Kind: domain.LineageDatasetChannel},
+			{Name: "orders-db", Kind: domain.LineageDatasetDatastore, Technology: "PostgreSQL"},
+		},
+	}}, lineageJobs(schema))
+}
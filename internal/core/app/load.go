@@ -0,0 +1,51 @@
+package app
+
+import (
+	"context"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+)
+
+// loadSchema loads and merges the specifications together with the relationships of the configured rules,
+// with the technologies replaced by their canonical names. With a configured precedence, the specifications
+// are loaded one by one to merge them by their source types.
+func (a *App) loadSchema(ctx context.Context, serviceFilesPaths, asyncAPIFilesPaths []string) (domain.Schema, error) {
+	var schema domain.Schema
+
+	if precedence := a.precedence(); len(precedence) == 0 {
+		loaded, err := a.schemaLoader.Load(ctx, serviceFilesPaths, asyncAPIFilesPaths)
+		if err != nil {
+			return domain.Schema{}, err
+		}
+
+		schema = loaded
+	} else {
+		declarations, err := a.schemaLoader.LoadDeclarations(ctx, serviceFilesPaths, asyncAPIFilesPaths)
+		if err != nil {
+			return domain.Schema{}, err
+		}
+
+		schema = domain.MergeDeclarations(declarations, precedence)
+		schema.ResolveNamespaces()
+	}
+
+	schema = applyRelationshipRules(schema, a.config.Input.Relationships)
+
+	return schema.CanonicalizeTechnologies(a.config.Input.Technologies), nil
+}
+
+// precedence returns the configured precedence of source types by field.
+func (a *App) precedence() domain.Precedence {
+	if len(a.config.Input.Precedence) == 0 {
+		return nil
+	}
+
+	precedence := make(domain.Precedence, len(a.config.Input.Precedence))
+	for field, types := range a.config.Input.Precedence {
+		for _, sourceType := range types {
+			precedence[field] = append(precedence[field], domain.SourceKind(sourceType))
+		}
+	}
+
+	return precedence
+}
@@ -0,0 +1,70 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+)
+
+// relationshipRulesSource names the relationship rules of the configuration as the source of declarations.
+const relationshipRulesSource = "input.relationships"
+
+// MergeSchemas merges the specifications the same way as for generating documentation, without writing anything.
+func (a *App) MergeSchemas(ctx context.Context, req domain.MergeRequest) (domain.Schema, error) {
+	schema, err := a.loadSchema(ctx, req.ServiceFilesPaths, req.AsyncAPIFilesPaths)
+	if err != nil {
+		return domain.Schema{}, fmt.Errorf("loading schema from files: %w", err)
+	}
+
+	if len(req.Services) == 0 {
+		return schema, nil
+	}
+
+	for _, name := range req.Services {
+		if !slices.ContainsFunc(schema.Services, func(s domain.Service) bool { return s.Info.Name == name }) {
+			return domain.Schema{}, fmt.Errorf("%w: %s", domain.ErrServiceNotFound, name)
+		}
+	}
+
+	schema.Services = slices.DeleteFunc(schema.Services, func(s domain.Service) bool {
+		return !slices.Contains(req.Services, s.Info.Name)
+	})
+
+	return schema, nil
+}
+
+// ExplainMerge tells which specification files, or relationship rules of the configuration, contributed the
+// fields of the merged services and which of their values were overridden.
+func (a *App) ExplainMerge(ctx context.Context, req domain.MergeRequest) ([]domain.ServiceProvenance, error) {
+	declarations, err := a.schemaLoader.LoadDeclarations(ctx, req.ServiceFilesPaths, req.AsyncAPIFilesPaths)
+	if err != nil {
+		return nil, fmt.Errorf("loading declarations from files: %w", err)
+	}
+
+	precedence := a.precedence()
+	rules := relationshipRuleDeclarations(domain.MergeDeclarations(declarations, precedence),
+		a.config.Input.Relationships)
+	if len(rules) > 0 {
+		declarations = append(declarations, domain.Declaration{
+			Source: relationshipRulesSource,
+			Schema: domain.Schema{Services: rules},
+		})
+	}
+
+	provenance := domain.ExplainMerge(declarations, precedence)
+	if len(req.Services) == 0 {
+		return provenance, nil
+	}
+
+	for _, name := range req.Services {
+		if !slices.ContainsFunc(provenance, func(p domain.ServiceProvenance) bool { return p.Service == name }) {
+			return nil, fmt.Errorf("%w: %s", domain.ErrServiceNotFound, name)
+		}
+	}
+
+	return slices.DeleteFunc(provenance, func(p domain.ServiceProvenance) bool {
+		return !slices.Contains(req.Services, p.Service)
+	}), nil
+}
@@ -0,0 +1,132 @@
+package app
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/holydocs/holydocs/internal/slug"
+)
+
+// applyMonorepo sets the subdirectories of services in the monorepo checked out at root, and the repository
+// of the monorepo on services without one. Services with a repository of their own aren't part of it.
+func applyMonorepo(schema domain.Schema, files []domain.SourceFile, root, repository string,
+	monorepo domain.Monorepo,
+) domain.Schema {
+	subpaths := monorepoSubpaths(schema, files, root, monorepo)
+
+	services := make([]domain.Service, len(schema.Services))
+	for i, service := range schema.Services {
+		services[i] = service
+
+		subpath, ok := subpaths[service.Info.Name]
+		if !ok || (service.Info.Repository != "" && !sameRepository(service.Info.Repository, repository)) {
+			continue
+		}
+
+		services[i].Info.Subpath = subpath
+		if services[i].Info.Repository == "" {
+			services[i].Info.Repository = repository
+		}
+	}
+
+	schema.Services = services
+
+	return schema
+}
+
+// monorepoSubpaths returns the subdirectories of services, taken from the mapping file, else from the package
+// containing the ServiceFile of the service, else from the package named after the service, with or without
+// its "service" suffix.
+func monorepoSubpaths(schema domain.Schema, files []domain.SourceFile, root string,
+	monorepo domain.Monorepo,
+) map[string]string {
+	byName := make(map[string]string)
+	for _, pkg := range monorepo.Packages {
+		if _, ok := byName[slug.Make(pkg.Name)]; !ok {
+			byName[slug.Make(pkg.Name)] = pkg.Dir
+		}
+	}
+
+	serviceFiles := make(map[string][]string)
+
+	for _, file := range files {
+		if file.Kind != domain.SourceKindServiceFile {
+			continue
+		}
+
+		for _, name := range file.Services {
+			serviceFiles[name] = append(serviceFiles[name], file.Path)
+		}
+	}
+
+	subpaths := make(map[string]string)
+
+	for _, service := range schema.Services {
+		name := service.Info.Name
+
+		if dir, ok := monorepo.Mapping[name]; ok {
+			subpaths[name] = dir
+
+			continue
+		}
+
+		if dir, ok := containingPackage(serviceFiles[name], root, monorepo.Packages); ok {
+			subpaths[name] = dir
+
+			continue
+		}
+
+		serviceSlug := slug.Make(name)
+		for _, candidate := range []string{serviceSlug, strings.TrimSuffix(serviceSlug, "-service")} {
+			if dir, ok := byName[candidate]; ok && candidate != "" {
+				subpaths[name] = dir
+
+				break
+			}
+		}
+	}
+
+	return subpaths
+}
+
+// containingPackage returns the directory of the innermost package containing one of the paths.
+func containingPackage(paths []string, root string, packages []domain.MonorepoPackage) (string, bool) {
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return "", false
+	}
+
+	found := ""
+
+	for _, path := range paths {
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			continue
+		}
+
+		rel, err := filepath.Rel(absRoot, absPath)
+		if err != nil || !filepath.IsLocal(rel) {
+			continue
+		}
+
+		rel = filepath.ToSlash(rel)
+		for _, pkg := range packages {
+			if strings.HasPrefix(rel, pkg.Dir+"/") && len(pkg.Dir) > len(found) {
+				found = pkg.Dir
+			}
+		}
+	}
+
+	return found, found != ""
+}
+
+// sameRepository reports whether two repository URLs name the same repository, ignoring case, trailing
+// slashes and the .git suffix.
+func sameRepository(a, b string) bool {
+	normalize := func(repository string) string {
+		return strings.TrimSuffix(strings.TrimRight(strings.ToLower(strings.TrimSpace(repository)), "/"), ".git")
+	}
+
+	return normalize(a) == normalize(b)
+}
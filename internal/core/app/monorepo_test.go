@@ -0,0 +1,54 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyMonorepo(t *testing.T) {
+	t.Parallel()
+
+	const repository = "https://github.com/acme/platform"
+
+	schema := domain.Schema{Services: []domain.Service{
+		{Info: domain.ServiceInfo{Name: "Campaign Service"}},
+		{Info: domain.ServiceInfo{Name: "Billing Service"}},
+		{Info: domain.ServiceInfo{Name: "Storefront Service", Repository: repository + ".git"}},
+		{Info: domain.ServiceInfo{Name: "Mailer", Repository: "https://github.com/acme/mailer"}},
+		{Info: domain.ServiceInfo{Name: "Audit Service"}},
+	}}
+
+	files := []domain.SourceFile{
+		{Path: "/src/platform/services/billing/api/servicefile.yaml", Kind: domain.SourceKindServiceFile,
+			Services: []string{"Billing Service"}},
+		{Path: "/src/platform/web/storefront/asyncapi.yaml", Kind: domain.SourceKindAsyncAPI,
+			Services: []string{"Audit Service"}},
+	}
+
+	monorepo := domain.Monorepo{
+		Mapping: map[string]string{"Campaign Service": "services/campaigns"},
+		Packages: []domain.MonorepoPackage{
+			{Dir: "services", Name: "services"},
+			{Dir: "services/billing", Name: "payments"},
+			{Dir: "web/storefront", Name: "storefront"},
+			{Dir: "tools/mailer", Name: "mailer"},
+		},
+	}
+
+	schema = applyMonorepo(schema, files, "/src/platform", repository, monorepo)
+
+	infos := make(map[string]domain.ServiceInfo)
+	for _, service := range schema.Services {
+		infos[service.Info.Name] = service.Info
+	}
+
+	assert.Equal(t, domain.ServiceInfo{Name: "Campaign Service", Repository: repository,
+		Subpath: "services/campaigns"}, infos["Campaign Service"])
+	assert.Equal(t, "services/billing", infos["Billing Service"].Subpath)
+	assert.Equal(t, "web/storefront", infos["Storefront Service"].Subpath)
+	assert.Equal(t, repository+".git", infos["Storefront Service"].Repository)
+	assert.Empty(t, infos["Mailer"].Subpath)
+	assert.Equal(t, domain.ServiceInfo{Name: "Audit Service"}, infos["Audit Service"])
+}
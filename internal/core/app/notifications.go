@@ -0,0 +1,93 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+)
+
+// notifySubscribers posts the changes recorded by a generation to the webhooks of the subscribed owners. An
+// owner only receives the changes affecting the services it owns or owned in the previous schema, or the
+// dependencies of these services, and nothing when no change affects them. A webhook failing to answer is
+// reported as a warning.
+func (a *App) notifySubscribers(ctx context.Context, previous, schema domain.Schema,
+	changelog *domain.Changelog) ([]string, error) {
+	subscriptions := a.config.Notifications.Subscriptions
+	if changelog == nil || len(changelog.Changes) == 0 || len(subscriptions) == 0 {
+		return nil, nil
+	}
+
+	timeout, err := a.config.Notifications.TimeoutDuration()
+	if err != nil {
+		return nil, err
+	}
+
+	dependencies, previousDependencies := serviceDependencies(schema), serviceDependencies(previous)
+
+	var warnings []string
+
+	for _, owner := range slices.Sorted(maps.Keys(subscriptions)) {
+		// Removed services and services moved to another owner are only owned in the previous schema.
+		services := subscribedServices(schema, dependencies, owner)
+		maps.Copy(services, subscribedServices(previous, previousDependencies, owner))
+
+		changes := subscribedChanges(changelog.Changes, services)
+		if len(changes) == 0 {
+			continue
+		}
+
+		err := a.notifier.Notify(ctx, domain.NotifyRequest{
+			Type:       domain.NotificationType(a.config.Notifications.Type),
+			Webhook:    subscriptions[owner],
+			Subscriber: owner,
+			Date:       changelog.Date,
+			Changes:    changes,
+			Timeout:    timeout,
+		})
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("notification of %s not sent: %v", owner, err))
+		}
+	}
+
+	return warnings, nil
+}
+
+// subscribedServices returns the services of an owner, matched case-insensitively, along with their
+// dependencies.
+func subscribedServices(schema domain.Schema, dependencies map[string][]string, owner string) map[string]struct{} {
+	services := make(map[string]struct{})
+
+	for _, service := range schema.Services {
+		if !strings.EqualFold(strings.TrimSpace(service.Info.Owner), strings.TrimSpace(owner)) {
+			continue
+		}
+
+		services[service.Info.Name] = struct{}{}
+		for _, dependency := range dependencies[service.Info.Name] {
+			services[dependency] = struct{}{}
+		}
+	}
+
+	return services
+}
+
+// subscribedChanges returns the changes affecting any of the services.
+func subscribedChanges(changes []domain.Change, services map[string]struct{}) []domain.Change {
+	var subscribed []domain.Change
+
+	for _, change := range changes {
+		for _, name := range change.Services() {
+			if _, ok := services[name]; ok {
+				subscribed = append(subscribed, change)
+
+				break
+			}
+		}
+	}
+
+	return subscribed
+}
@@ -0,0 +1,122 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeNotifier struct {
+	requests []domain.NotifyRequest
+}
+
+func (n *fakeNotifier) Notify(_ context.Context, req domain.NotifyRequest) error {
+	n.requests = append(n.requests, req)
+
+	if req.Webhook == "https://hooks.example.com/broken" {
+		return errors.New("unexpected status 404 Not Found")
+	}
+
+	return nil
+}
+
+func TestApp_NotifySubscribers(t *testing.T) {
+	t.Parallel()
+
+	schema := domain.Schema{Services: []domain.Service{
+		{
+			Info: domain.ServiceInfo{Name: "Orders", Owner: "team-orders"},
+			Relationships: []domain.Relationship{
+				{Action: domain.RelationshipActionRequests, Participant: "Payments", Technology: "HTTP"},
+			},
+		},
+		{Info: domain.ServiceInfo{Name: "Payments", Owner: "team-payments"}},
+		{Info: domain.ServiceInfo{Name: "Search", Owner: "team-search"}},
+		{Info: domain.ServiceInfo{Name: "Audit", Owner: "team-audit"}},
+	}}
+	date := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	paymentsChanged := domain.Change{Type: domain.ChangeTypeChanged, Category: "operation",
+		Name: "Payments:send:payments.captured"}
+	ordersRequests := domain.Change{Type: domain.ChangeTypeAdded, Category: "relationship",
+		Name: "Orders:requests|Payments|HTTP|"}
+	searchAdded := domain.Change{Type: domain.ChangeTypeAdded, Category: "service", Name: "Search"}
+	changelog := &domain.Changelog{Date: date, Changes: []domain.Change{paymentsChanged, ordersRequests, searchAdded}}
+
+	notifier := &fakeNotifier{}
+	a := &App{notifier: notifier, config: &config.Config{Notifications: config.Notifications{
+		Type: "teams",
+		Subscriptions: map[string]string{
+			"Team-Orders":   "https://hooks.example.com/orders",
+			"team-payments": "https://hooks.example.com/broken",
+			"team-audit":    "https://hooks.example.com/audit",
+		},
+	}}}
+
+	warnings, err := a.notifySubscribers(context.Background(), domain.Schema{}, schema, nil)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Empty(t, notifier.requests, "nothing is posted without changes")
+
+	warnings, err = a.notifySubscribers(context.Background(), domain.Schema{}, schema, changelog)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"notification of team-payments not sent: unexpected status 404 Not Found"}, warnings)
+	assert.Equal(t, []domain.NotifyRequest{
+		{
+			Type:       domain.NotificationTypeTeams,
+			Webhook:    "https://hooks.example.com/orders",
+			Subscriber: "Team-Orders",
+			Date:       date,
+			Changes:    []domain.Change{paymentsChanged, ordersRequests},
+			Timeout:    10 * time.Second,
+		},
+		{
+			Type:       domain.NotificationTypeTeams,
+			Webhook:    "https://hooks.example.com/broken",
+			Subscriber: "team-payments",
+			Date:       date,
+			Changes:    []domain.Change{paymentsChanged, ordersRequests},
+			Timeout:    10 * time.Second,
+		},
+	}, notifier.requests, "owners without affected services aren't notified")
+}
+
+func TestApp_NotifySubscribers_PreviousOwners(t *testing.T) {
+	t.Parallel()
+
+	previous := domain.Schema{Services: []domain.Service{
+		{Info: domain.ServiceInfo{Name: "Legacy", Owner: "team-legacy"}},
+		{Info: domain.ServiceInfo{Name: "Search", Owner: "team-legacy"}},
+	}}
+	schema := domain.Schema{Services: []domain.Service{
+		{Info: domain.ServiceInfo{Name: "Search", Owner: "team-search"}},
+	}}
+	date := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	legacyRemoved := domain.Change{Type: domain.ChangeTypeRemoved, Category: "service", Name: "Legacy"}
+	searchChanged := domain.Change{Type: domain.ChangeTypeChanged, Category: "service", Name: "Search"}
+	changelog := &domain.Changelog{Date: date, Changes: []domain.Change{legacyRemoved, searchChanged}}
+
+	notifier := &fakeNotifier{}
+	a := &App{notifier: notifier, config: &config.Config{Notifications: config.Notifications{
+		Type: "teams",
+		Subscriptions: map[string]string{
+			"team-legacy": "https://hooks.example.com/legacy",
+			"team-search": "https://hooks.example.com/search",
+		},
+	}}}
+
+	warnings, err := a.notifySubscribers(context.Background(), previous, schema, changelog)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	require.Len(t, notifier.requests, 2)
+	assert.Equal(t, "team-legacy", notifier.requests[0].Subscriber)
+	assert.Equal(t, []domain.Change{legacyRemoved, searchChanged}, notifier.requests[0].Changes,
+		"the previous owner hears of the removal and of the ownership move")
+	assert.Equal(t, "team-search", notifier.requests[1].Subscriber)
+	assert.Equal(t, []domain.Change{searchChanged}, notifier.requests[1].Changes)
+}
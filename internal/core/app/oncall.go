@@ -0,0 +1,58 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+)
+
+// lookupOnCall looks up the escalation policies of the services mapped under documentation.on_call. They are
+// looked up on every generation to stay current, a failed lookup leaves the service out with a warning.
+func (a *App) lookupOnCall(ctx context.Context, schema domain.Schema) (map[string]domain.OnCall, []string, error) {
+	onCall := a.config.Documentation.OnCall
+	if onCall.Provider == "" || len(onCall.Services) == 0 {
+		return nil, nil, nil
+	}
+
+	timeout, err := onCall.TimeoutDuration()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	services := make(map[string]struct{}, len(schema.Services))
+	for _, service := range schema.Services {
+		services[service.Info.Name] = struct{}{}
+	}
+
+	policies := make(map[string]domain.OnCall)
+
+	var warnings []string
+
+	for _, name := range slices.Sorted(maps.Keys(onCall.Services)) {
+		if _, ok := services[name]; !ok {
+			warnings = append(warnings, fmt.Sprintf("on-call mapping of %s matches no service", name))
+
+			continue
+		}
+
+		policy, err := a.onCallDirectory.Lookup(ctx, domain.LookupOnCallRequest{
+			Provider: domain.OnCallProvider(onCall.Provider),
+			BaseURL:  onCall.URL,
+			Token:    onCall.Token,
+			ID:       onCall.Services[name],
+			Timeout:  timeout,
+		})
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("on-call of %s not looked up: %v", name, err))
+
+			continue
+		}
+
+		policies[name] = policy
+	}
+
+	return policies, warnings, nil
+}
@@ -0,0 +1,70 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeOnCallDirectory struct {
+	requests []domain.LookupOnCallRequest
+	policies map[string]domain.OnCall
+}
+
+func (d *fakeOnCallDirectory) Lookup(_ context.Context, req domain.LookupOnCallRequest) (domain.OnCall, error) {
+	d.requests = append(d.requests, req)
+
+	policy, ok := d.policies[req.ID]
+	if !ok {
+		return domain.OnCall{}, errors.New("unexpected status 404 Not Found")
+	}
+
+	return policy, nil
+}
+
+func TestApp_LookupOnCall(t *testing.T) {
+	t.Parallel()
+
+	directory := &fakeOnCallDirectory{policies: map[string]domain.OnCall{
+		"PX7Q2M1": {Provider: domain.OnCallProviderPagerDuty, EscalationPolicy: "Payments Primary"},
+	}}
+	schema := domain.Schema{Services: []domain.Service{
+		{Info: domain.ServiceInfo{Name: "Payments"}},
+		{Info: domain.ServiceInfo{Name: "Orders"}},
+	}}
+
+	a := &App{onCallDirectory: directory, config: &config.Config{}}
+
+	policies, warnings, err := a.lookupOnCall(context.Background(), schema)
+	require.NoError(t, err)
+	assert.Nil(t, policies, "no lookup without a provider")
+	assert.Empty(t, warnings)
+
+	a.config.Documentation.OnCall = config.OnCallDocumentation{
+		Provider: "pagerduty",
+		Token:    "secret",
+		Services: map[string]string{"Payments": "PX7Q2M1", "Orders": "PORDERS", "Search": "PSEARCH"},
+	}
+
+	policies, warnings, err = a.lookupOnCall(context.Background(), schema)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]domain.OnCall{
+		"Payments": {Provider: domain.OnCallProviderPagerDuty, EscalationPolicy: "Payments Primary"},
+	}, policies)
+	assert.Equal(t, []string{
+		"on-call of Orders not looked up: unexpected status 404 Not Found",
+		"on-call mapping of Search matches no service",
+	}, warnings)
+	assert.Equal(t, domain.LookupOnCallRequest{
+		Provider: domain.OnCallProviderPagerDuty,
+		Token:    "secret",
+		ID:       "PORDERS",
+		Timeout:  10 * time.Second,
+	}, directory.requests[0])
+}
@@ -0,0 +1,110 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+)
+
+// FetchPluginSources runs the source plugins and stores the specifications they provide as sources named
+// <plugin>.<name>, documented like the sources of the ingest command. Warnings of the plugins are returned.
+func (a *App) FetchPluginSources(ctx context.Context) ([]string, error) {
+	var warnings []string
+
+	for _, plugin := range a.plugins(domain.PluginKindSource) {
+		reply, err := a.pluginRunner.Run(ctx, domain.RunPluginRequest{Plugin: plugin})
+		if err != nil {
+			return nil, fmt.Errorf("source plugin %s: %w", plugin.Name, err)
+		}
+
+		warnings = append(warnings, pluginWarnings(plugin, reply.Warnings)...)
+
+		for _, source := range reply.Sources {
+			name := plugin.Name + "." + source.Name
+
+			if _, err := a.IngestSource(ctx, domain.IngestSourceRequest{Name: name, Content: source.Content}); err != nil {
+				return nil, fmt.Errorf("source %s of plugin %s: %w", source.Name, plugin.Name, err)
+			}
+		}
+	}
+
+	return warnings, nil
+}
+
+// runTargetPlugins runs the target plugins on the schema of the documentation generated into outputDir.
+func (a *App) runTargetPlugins(ctx context.Context, schema domain.Schema, outputDir string) ([]string, error) {
+	var warnings []string
+
+	for _, plugin := range a.plugins(domain.PluginKindTarget) {
+		reply, err := a.pluginRunner.Run(ctx, domain.RunPluginRequest{
+			Plugin:    plugin,
+			Schema:    &schema,
+			OutputDir: outputDir,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("target plugin %s: %w", plugin.Name, err)
+		}
+
+		warnings = append(warnings, pluginWarnings(plugin, reply.Warnings)...)
+	}
+
+	return warnings, nil
+}
+
+// PublishPlugin publishes the documentation generated into outputDir with the publisher plugin of the name.
+func (a *App) PublishPlugin(ctx context.Context, name, outputDir string) (domain.RunPluginReply, error) {
+	for _, plugin := range a.plugins(domain.PluginKindPublisher) {
+		if plugin.Name != name {
+			continue
+		}
+
+		reply, err := a.pluginRunner.Run(ctx, domain.RunPluginRequest{Plugin: plugin, OutputDir: outputDir})
+		if err != nil {
+			return domain.RunPluginReply{}, fmt.Errorf("publisher plugin %s: %w", name, err)
+		}
+
+		return reply, nil
+	}
+
+	return domain.RunPluginReply{}, fmt.Errorf("%w: no publisher plugin %s", domain.ErrPluginNotFound, name)
+}
+
+// PublisherPlugins returns the names of the configured publisher plugins.
+func (a *App) PublisherPlugins() []string {
+	var names []string
+	for _, plugin := range a.plugins(domain.PluginKindPublisher) {
+		names = append(names, plugin.Name)
+	}
+
+	return names
+}
+
+// plugins returns the configured plugins of a kind.
+func (a *App) plugins(kind domain.PluginKind) []domain.Plugin {
+	var plugins []domain.Plugin
+
+	for _, plugin := range a.config.Plugins {
+		if domain.PluginKind(plugin.Kind) != kind {
+			continue
+		}
+
+		plugins = append(plugins, domain.Plugin{
+			Name:    plugin.Name,
+			Kind:    kind,
+			Command: plugin.Command,
+			Config:  plugin.Config,
+		})
+	}
+
+	return plugins
+}
+
+func pluginWarnings(plugin domain.Plugin, warnings []string) []string {
+	prefixed := make([]string, 0, len(warnings))
+	for _, warning := range warnings {
+		prefixed = append(prefixed, fmt.Sprintf("%s plugin %s: %s", plugin.Kind, plugin.Name, warning))
+	}
+
+	return prefixed
+}
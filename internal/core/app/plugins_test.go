@@ -0,0 +1,80 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePluginRunner struct {
+	requests []domain.RunPluginRequest
+	replies  map[string]domain.RunPluginReply
+}
+
+func (r *fakePluginRunner) Run(_ context.Context, req domain.RunPluginRequest) (domain.RunPluginReply, error) {
+	r.requests = append(r.requests, req)
+
+	return r.replies[req.Plugin.Name], nil
+}
+
+type fakeSourceStore struct {
+	saved map[string][]byte
+}
+
+func (s *fakeSourceStore) Save(_ context.Context, source domain.Source, content []byte) (domain.Source, error) {
+	s.saved[source.Name] = content
+
+	return source, nil
+}
+
+func (s *fakeSourceStore) List(context.Context, time.Time) ([]domain.Source, error) { return nil, nil }
+
+func (s *fakeSourceStore) Prune(context.Context, time.Time) (int, error) { return 0, nil }
+
+func (s *fakeSourceStore) Delete(context.Context, string) error { return nil }
+
+func TestApp_Plugins(t *testing.T) {
+	t.Parallel()
+
+	runner := &fakePluginRunner{replies: map[string]domain.RunPluginReply{
+		"catalog": {
+			Sources:  []domain.PluginSource{{Name: "billing", Content: []byte("servicefile: 0.1.0")}},
+			Warnings: []string{"entry without owner"},
+		},
+		"structurizr": {Files: []string{"workspace.dsl"}},
+		"confluence":  {URL: "https://wiki.example.com/architecture"},
+	}}
+	store := &fakeSourceStore{saved: make(map[string][]byte)}
+
+	a := &App{sourceStore: store, pluginRunner: runner, config: &config.Config{Plugins: []config.Plugin{
+		{Name: "catalog", Kind: "source", Command: "holydocs-catalog"},
+		{Name: "structurizr", Kind: "target", Command: "holydocs-structurizr"},
+		{Name: "confluence", Kind: "publisher", Command: "holydocs-confluence"},
+	}}}
+
+	warnings, err := a.FetchPluginSources(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"source plugin catalog: entry without owner"}, warnings)
+	assert.Equal(t, map[string][]byte{"catalog.billing": []byte("servicefile: 0.1.0")}, store.saved)
+
+	schema := domain.Schema{Services: []domain.Service{{Info: domain.ServiceInfo{Name: "Billing"}}}}
+	warnings, err = a.runTargetPlugins(context.Background(), schema, "docs")
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Equal(t, "docs", runner.requests[1].OutputDir)
+	assert.Equal(t, &schema, runner.requests[1].Schema)
+
+	assert.Equal(t, []string{"confluence"}, a.PublisherPlugins())
+
+	reply, err := a.PublishPlugin(context.Background(), "confluence", "docs")
+	require.NoError(t, err)
+	assert.Equal(t, "https://wiki.example.com/architecture", reply.URL)
+
+	_, err = a.PublishPlugin(context.Background(), "catalog", "docs")
+	require.ErrorIs(t, err, domain.ErrPluginNotFound)
+}
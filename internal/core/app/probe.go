@@ -0,0 +1,68 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+)
+
+// ProbeDeployments probes the health and readiness endpoints of the deployments declared by services and
+// records the results next to the documentation, where the next gen-docs run shows them.
+func (a *App) ProbeDeployments(
+	ctx context.Context,
+	req domain.ProbeDeploymentsRequest,
+) (domain.ProbeDeploymentsReply, error) {
+	schema, err := a.loadSchema(ctx, req.ServiceFilesPaths, req.AsyncAPIFilesPaths)
+	if err != nil {
+		return domain.ProbeDeploymentsReply{}, fmt.Errorf("loading schema from files: %w", err)
+	}
+
+	report := a.probeDeployments(ctx, schema.FilterNamespaces(a.config.Input.Namespaces), req.Environments,
+		req.Timeout, time.Now())
+
+	if err := a.docsGenerator.WriteProbeReport(req.OutputDir, report); err != nil {
+		return domain.ProbeDeploymentsReply{}, fmt.Errorf("writing probe report: %w", err)
+	}
+
+	return domain.ProbeDeploymentsReply{Report: report}, nil
+}
+
+// probeDeployments probes the endpoints of the deployments to the environments, of all deployments when no
+// environment is given, one after the other.
+func (a *App) probeDeployments(ctx context.Context, schema domain.Schema, environments []string,
+	timeout time.Duration, now time.Time) domain.ProbeReport {
+	report := domain.ProbeReport{ProbedAt: now.UTC().Truncate(time.Second), Results: []domain.ProbeResult{}}
+
+	for _, service := range schema.Services {
+		for _, deployment := range service.Info.Deployments {
+			if len(environments) > 0 && !slices.Contains(environments, deployment.Environment) {
+				continue
+			}
+
+			for _, endpoint := range []struct {
+				kind domain.ProbeKind
+				url  string
+			}{
+				{domain.ProbeKindHealth, deployment.Health},
+				{domain.ProbeKindReadiness, deployment.Readiness},
+			} {
+				if endpoint.url == "" {
+					continue
+				}
+
+				result := a.prober.Probe(ctx, domain.ProbeRequest{URL: endpoint.url, Timeout: timeout})
+				result.Service = service.Info.Name
+				result.Environment = deployment.Environment
+				result.Kind = endpoint.kind
+				result.URL = endpoint.url
+
+				report.Results = append(report.Results, result)
+			}
+		}
+	}
+
+	return report
+}
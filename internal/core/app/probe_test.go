@@ -0,0 +1,53 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeProber struct {
+	requests []domain.ProbeRequest
+}
+
+func (p *fakeProber) Probe(_ context.Context, req domain.ProbeRequest) domain.ProbeResult {
+	p.requests = append(p.requests, req)
+
+	return domain.ProbeResult{URL: "ignored", Reachable: true, StatusCode: http.StatusOK, LatencyMS: 12}
+}
+
+func TestApp_ProbeDeployments(t *testing.T) {
+	t.Parallel()
+
+	schema := domain.Schema{Services: []domain.Service{
+		{Info: domain.ServiceInfo{Name: "Orders", Deployments: []domain.Deployment{
+			{Environment: "staging", Health: "https://orders.staging.example.com/healthz"},
+			{Environment: "production", Health: "https://orders.example.com/healthz",
+				Readiness: "https://orders.example.com/readyz"},
+		}}},
+		{Info: domain.ServiceInfo{Name: "Payments"}},
+	}}
+
+	prober := &fakeProber{}
+	a := &App{prober: prober}
+	now := time.Date(2024, 5, 2, 10, 30, 15, 500, time.UTC)
+
+	report := a.probeDeployments(context.Background(), schema, []string{"production"}, time.Second, now)
+	assert.Equal(t, domain.ProbeReport{
+		ProbedAt: time.Date(2024, 5, 2, 10, 30, 15, 0, time.UTC),
+		Results: []domain.ProbeResult{
+			{Service: "Orders", Environment: "production", Kind: domain.ProbeKindHealth,
+				URL: "https://orders.example.com/healthz", Reachable: true, StatusCode: http.StatusOK, LatencyMS: 12},
+			{Service: "Orders", Environment: "production", Kind: domain.ProbeKindReadiness,
+				URL: "https://orders.example.com/readyz", Reachable: true, StatusCode: http.StatusOK, LatencyMS: 12},
+		},
+	}, report)
+	assert.Equal(t, time.Second, prober.requests[0].Timeout)
+
+	report = a.probeDeployments(context.Background(), schema, nil, time.Second, now)
+	assert.Len(t, report.Results, 3, "all environments are probed by default")
+}
@@ -0,0 +1,227 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+)
+
+var (
+	// Code spans and URLs are not prose, they are removed before linting.
+	codeSpanPattern = regexp.MustCompile("`[^`]*`")
+	urlPattern      = regexp.MustCompile(`https?://\S+`)
+	sentenceEnd     = regexp.MustCompile(`[.!?]+(\s|$)`)
+)
+
+// inflectionSuffixes are removed from words missing from the dictionary, word lists such as Hunspell
+// dictionaries only hold their stems.
+//
+//nolint:gochecknoglobals // Read-only lookup table
+var inflectionSuffixes = []struct {
+	suffix string
+	stems  []string
+}{
+	{suffix: "'s", stems: []string{""}},
+	{suffix: "ies", stems: []string{"y"}},
+	{suffix: "es", stems: []string{""}},
+	{suffix: "s", stems: []string{""}},
+	{suffix: "ed", stems: []string{"", "e"}},
+	{suffix: "ing", stems: []string{"", "e"}},
+	{suffix: "ly", stems: []string{""}},
+}
+
+// proseText is a description linted by the prose rules.
+type proseText struct {
+	subject string
+	// what tells which description of the subject the text is.
+	what string
+	text string
+}
+
+// proseFindings lints the descriptions of services and their relationships. Words of the names in the
+// schema, such as services, participants and technologies, are always spelled correctly.
+func proseFindings(schema domain.Schema, rules domain.ProseRules) []domain.Finding {
+	names := schemaWords(schema)
+
+	var findings []domain.Finding
+
+	for _, service := range schema.Services {
+		texts := []proseText{{subject: service.Info.Name, what: "description", text: service.Info.Description}}
+		for _, rel := range service.Relationships {
+			texts = append(texts, proseText{
+				subject: service.Info.Name,
+				what:    "description of relationship with " + rel.Participant,
+				text:    rel.Description,
+			})
+		}
+
+		for _, text := range texts {
+			findings = append(findings, lintProse(text, rules, names)...)
+		}
+	}
+
+	return findings
+}
+
+func lintProse(text proseText, rules domain.ProseRules, names map[string]struct{}) []domain.Finding {
+	prose := urlPattern.ReplaceAllString(codeSpanPattern.ReplaceAllString(text.text, " "), " ")
+	if strings.TrimSpace(prose) == "" {
+		return nil
+	}
+
+	var findings []domain.Finding
+
+	finding := func(rule domain.FindingRule, format string, args ...any) {
+		findings = append(findings, domain.Finding{
+			Rule:    rule,
+			Subject: text.subject,
+			Message: text.what + ": " + fmt.Sprintf(format, args...),
+		})
+	}
+
+	if len(rules.Dictionary) > 0 {
+		if unknown := unknownWords(prose, rules.Dictionary, names); len(unknown) > 0 {
+			finding(domain.FindingRuleSpelling, "unknown words %s", quoteWords(unknown))
+		}
+	}
+
+	if rules.MaxSentenceLength > 0 {
+		if longest := longestSentence(prose); longest > rules.MaxSentenceLength {
+			finding(domain.FindingRuleSentenceLength, "sentence of %d words, the limit is %d",
+				longest, rules.MaxSentenceLength)
+		}
+	}
+
+	if banned := bannedWords(prose, rules.BannedWords); len(banned) > 0 {
+		finding(domain.FindingRuleBannedWord, "uses banned words %s", quoteWords(banned))
+	}
+
+	return findings
+}
+
+// proseWords splits text into words, keeping apostrophes within words.
+func proseWords(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\'' && r != '’'
+	})
+}
+
+// unknownWords returns the words missing from the dictionary and the names, in the order they appear.
+// Words with digits or capitals after the first letter, such as acronyms and product names, are skipped.
+func unknownWords(text string, dictionary, names map[string]struct{}) []string {
+	var unknown []string
+
+	for _, word := range proseWords(text) {
+		word = strings.Trim(strings.ReplaceAll(word, "’", "'"), "'")
+		if word == "" || strings.ContainsFunc(word, unicode.IsDigit) {
+			continue
+		}
+
+		if _, size := utf8.DecodeRuneInString(word); strings.ContainsFunc(word[size:], unicode.IsUpper) {
+			continue
+		}
+
+		lower := strings.ToLower(word)
+		if knownWord(lower, dictionary) || knownWord(lower, names) || slices.Contains(unknown, lower) {
+			continue
+		}
+
+		unknown = append(unknown, lower)
+	}
+
+	return unknown
+}
+
+func knownWord(word string, words map[string]struct{}) bool {
+	if _, ok := words[word]; ok {
+		return true
+	}
+
+	for _, inflection := range inflectionSuffixes {
+		stem, ok := strings.CutSuffix(word, inflection.suffix)
+		if !ok || stem == "" {
+			continue
+		}
+
+		for _, ending := range inflection.stems {
+			if _, ok := words[stem+ending]; ok {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// longestSentence returns the number of words of the longest sentence.
+func longestSentence(text string) int {
+	longest := 0
+	for _, sentence := range sentenceEnd.Split(text, -1) {
+		longest = max(longest, len(proseWords(sentence)))
+	}
+
+	return longest
+}
+
+// bannedWords returns the banned words and phrases the text uses, matched case-insensitively as whole words.
+func bannedWords(text string, banned []string) []string {
+	var used []string
+
+	for _, phrase := range banned {
+		phrase = strings.TrimSpace(phrase)
+		if phrase == "" {
+			continue
+		}
+
+		pattern := regexp.MustCompile(`(?i)(^|\W)` + regexp.QuoteMeta(phrase) + `(\W|$)`)
+		if pattern.MatchString(text) {
+			used = append(used, phrase)
+		}
+	}
+
+	return used
+}
+
+// schemaWords returns the lowercased words of the names of services, systems, owners, participants,
+// technologies, tags and channels.
+func schemaWords(schema domain.Schema) map[string]struct{} {
+	words := make(map[string]struct{})
+
+	add := func(names ...string) {
+		for _, name := range names {
+			for _, word := range proseWords(name) {
+				words[strings.ToLower(strings.Trim(word, "'’"))] = struct{}{}
+			}
+		}
+	}
+
+	for _, service := range schema.Services {
+		add(service.Info.Name, service.Info.System, service.Info.Owner)
+		add(service.Info.Tags...)
+
+		for _, rel := range service.Relationships {
+			add(rel.Participant, rel.Technology, rel.Proto)
+			add(rel.Tags...)
+		}
+
+		for _, op := range service.Operation {
+			add(op.Channel.Name, op.Channel.Message.Name)
+		}
+	}
+
+	return words
+}
+
+func quoteWords(words []string) string {
+	quoted := make([]string, 0, len(words))
+	for _, word := range words {
+		quoted = append(quoted, fmt.Sprintf("%q", word))
+	}
+
+	return strings.Join(quoted, ", ")
+}
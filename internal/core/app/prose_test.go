@@ -0,0 +1,57 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProseFindings(t *testing.T) {
+	t.Parallel()
+
+	schema := domain.Schema{Services: []domain.Service{{
+		Info: domain.ServiceInfo{
+			Name:        "Order Service",
+			Description: "Stores orders in `orders_v2` and simply forwards them, see https://example.com/orders. Recieves refunds.",
+		},
+		Relationships: []domain.Relationship{{
+			Action:      domain.RelationshipActionRequests,
+			Participant: "Stripe",
+			Technology:  "HTTP",
+			Description: "Charges the customers of stores through the Stripe API, which is used for every payment.",
+		}},
+	}}}
+
+	rules := domain.ProseRules{
+		Dictionary: map[string]struct{}{
+			"store": {}, "in": {}, "and": {}, "simply": {}, "forward": {}, "them": {}, "see": {}, "refund": {},
+			"charge": {}, "the": {}, "customer": {}, "of": {}, "through": {}, "which": {}, "is": {}, "use": {},
+			"for": {}, "every": {}, "payment": {},
+		},
+		MaxSentenceLength: 12,
+		BannedWords:       []string{"Simply", "obviously"},
+	}
+
+	assert.Equal(t, []domain.Finding{
+		{
+			Rule:    domain.FindingRuleSpelling,
+			Subject: "Order Service",
+			Message: `description: unknown words "recieves"`,
+		},
+		{
+			Rule:    domain.FindingRuleBannedWord,
+			Subject: "Order Service",
+			Message: `description: uses banned words "Simply"`,
+		},
+		{
+			Rule:    domain.FindingRuleSentenceLength,
+			Subject: "Order Service",
+			Message: "description of relationship with Stripe: sentence of 15 words, the limit is 12",
+		},
+	}, proseFindings(schema, rules))
+
+	rules.Dictionary = nil
+	rules.BannedWords = nil
+	assert.Len(t, proseFindings(schema, rules), 1)
+}
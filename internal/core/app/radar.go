@@ -0,0 +1,50 @@
+package app
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+)
+
+// radarEntries lists every technology of the relationships of the services once, with the services using
+// it. Technologies differing only in case are the same, the first spelling is kept.
+func radarEntries(schema domain.Schema, radar config.Radar) []domain.RadarEntry {
+	entries := make(map[string]*domain.RadarEntry)
+
+	for _, service := range schema.Services {
+		for _, rel := range service.Relationships {
+			technology := strings.TrimSpace(rel.Technology)
+			if technology == "" {
+				continue
+			}
+
+			key := strings.ToLower(technology)
+
+			entry, ok := entries[key]
+			if !ok {
+				entry = &domain.RadarEntry{
+					Name:     technology,
+					Ring:     radar.Ring(technology),
+					Quadrant: radar.Quadrant(technology),
+				}
+				entries[key] = entry
+			}
+
+			if !slices.Contains(entry.Services, service.Info.Name) {
+				entry.Services = append(entry.Services, service.Info.Name)
+			}
+		}
+	}
+
+	radarEntries := make([]domain.RadarEntry, 0, len(entries))
+	for _, key := range slices.Sorted(maps.Keys(entries)) {
+		entry := entries[key]
+		slices.Sort(entry.Services)
+		radarEntries = append(radarEntries, *entry)
+	}
+
+	return radarEntries
+}
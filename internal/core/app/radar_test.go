@@ -0,0 +1,43 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRadarEntries(t *testing.T) {
+	t.Parallel()
+
+	schema := domain.Schema{Services: []domain.Service{
+		{
+			Info: domain.ServiceInfo{Name: "Billing Service"},
+			Relationships: []domain.Relationship{
+				{Action: domain.RelationshipActionUses, Participant: "billing-db", Technology: "PostgreSQL"},
+				{Action: domain.RelationshipActionUses, Participant: "ledger-db", Technology: "PostgreSQL"},
+			},
+		},
+		{
+			Info: domain.ServiceInfo{Name: "Order Service"},
+			Relationships: []domain.Relationship{
+				{Action: domain.RelationshipActionUses, Participant: "orders-db", Technology: "postgresql"},
+				{Action: domain.RelationshipActionUses, Participant: "cache", Technology: "Redis"},
+				{Action: domain.RelationshipActionRequests, Participant: "User Service"},
+			},
+		},
+	}}
+
+	radar := config.Radar{
+		Rings:           map[string]string{"postgresql": config.RadarRingAdopt},
+		Quadrants:       map[string]string{"Redis": "databases"},
+		DefaultRing:     config.RadarRingAssess,
+		DefaultQuadrant: "platforms",
+	}
+
+	assert.Equal(t, []domain.RadarEntry{
+		{Name: "PostgreSQL", Ring: "adopt", Quadrant: "platforms", Services: []string{"Billing Service", "Order Service"}},
+		{Name: "Redis", Ring: "assess", Quadrant: "databases", Services: []string{"Order Service"}},
+	}, radarEntries(schema, radar))
+}
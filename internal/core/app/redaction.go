@@ -0,0 +1,171 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/holydocs/messageflow/pkg/messageflow"
+)
+
+// generateRedactedDocumentation writes the variant of the documentation with the services restricted by the
+// rules anonymized to the directory of the rules.
+func (a *App) generateRedactedDocumentation(
+	ctx context.Context,
+	schema domain.Schema,
+	mfSetup domain.MessageFlowSetup,
+	opts domain.GenerateOptions,
+	rules config.Redacted,
+) error {
+	anonymized := restrictedServices(schema, rules)
+
+	previous, err := a.previousSchema(rules.Dir)
+	if err != nil {
+		return err
+	}
+
+	opts = redactOptions(opts, anonymized)
+	opts.OutputDir, opts.Previous = rules.Dir, previous
+
+	_, err = a.docsGenerator.Generate(ctx, redactSchema(schema, anonymized),
+		redactMessageFlow(mfSetup.Schema, anonymized), mfSetup.Target, opts)
+	if err != nil {
+		return fmt.Errorf("generating redacted documentation: %w", err)
+	}
+
+	return nil
+}
+
+// restrictedServices returns the anonymized names of the services restricted by the rules, by service name.
+// Services are numbered in the order of their names, so the names only change when restricted services do.
+func restrictedServices(schema domain.Schema, rules config.Redacted) map[string]string {
+	var names []string
+
+	for _, service := range schema.Services {
+		if rules.Restricts(service.Info.System, service.Info.Tags, service.Info.Classification) {
+			names = append(names, service.Info.Name)
+		}
+	}
+
+	slices.Sort(names)
+	names = slices.Compact(names)
+
+	anonymized := make(map[string]string, len(names))
+	for i, name := range names {
+		anonymized[name] = fmt.Sprintf("%s %d", rules.Placeholder, i+1)
+	}
+
+	return anonymized
+}
+
+// redactSchema replaces restricted services with anonymized nodes. They keep their relationships and operations
+// but lose everything else describing them, relationships with them lose their descriptions and notes.
+func redactSchema(schema domain.Schema, anonymized map[string]string) domain.Schema {
+	redacted := domain.Schema{Services: make([]domain.Service, 0, len(schema.Services))}
+
+	for _, service := range schema.Services {
+		name, restricted := anonymized[service.Info.Name]
+		if restricted {
+			service.Info = domain.ServiceInfo{Name: name, Planned: service.Info.Planned}
+			service.Endpoints = nil
+		}
+
+		relationships := make([]domain.Relationship, 0, len(service.Relationships))
+		for _, rel := range service.Relationships {
+			participant, participantRestricted := anonymized[rel.Participant]
+			if participantRestricted {
+				rel.Participant = participant
+			}
+
+			if restricted || participantRestricted {
+				rel.Description, rel.Notes = "", ""
+			}
+
+			relationships = append(relationships, rel)
+		}
+		service.Relationships = relationships
+
+		redacted.Services = append(redacted.Services, service)
+	}
+
+	return redacted
+}
+
+// redactMessageFlow replaces restricted services of the message flow with their anonymized nodes.
+func redactMessageFlow(schema messageflow.Schema, anonymized map[string]string) messageflow.Schema {
+	redacted := messageflow.Schema{Services: make([]messageflow.Service, 0, len(schema.Services))}
+
+	for _, service := range schema.Services {
+		if name, restricted := anonymized[service.Name]; restricted {
+			service.Name, service.Description = name, ""
+		}
+
+		redacted.Services = append(redacted.Services, service)
+	}
+
+	return redacted
+}
+
+// redactOptions keeps the generation options of services that aren't restricted.
+func redactOptions(opts domain.GenerateOptions, anonymized map[string]string) domain.GenerateOptions {
+	var needsReview []domain.StaleService
+
+	for _, service := range opts.NeedsReview {
+		if _, restricted := anonymized[service.Name]; restricted {
+			continue
+		}
+
+		service.ChangedDependencies = slices.Clone(service.ChangedDependencies)
+		for i, dependency := range service.ChangedDependencies {
+			if name, restricted := anonymized[dependency]; restricted {
+				service.ChangedDependencies[i] = name
+			}
+		}
+
+		needsReview = append(needsReview, service)
+	}
+	opts.NeedsReview = needsReview
+
+	// Services pending review aren't meant for any audience yet.
+	opts.PendingReview = nil
+
+	if opts.LastUpdated != nil {
+		lastUpdated := make(map[string]time.Time, len(opts.LastUpdated))
+		for name, modifiedAt := range opts.LastUpdated {
+			if anonymizedName, restricted := anonymized[name]; restricted {
+				name = anonymizedName
+			}
+			lastUpdated[name] = modifiedAt
+		}
+		opts.LastUpdated = lastUpdated
+	}
+
+	// Escalation policies would tell whose restricted services are anonymized.
+	if opts.OnCall != nil {
+		onCall := make(map[string]domain.OnCall, len(opts.OnCall))
+		for name, policy := range opts.OnCall {
+			if _, restricted := anonymized[name]; !restricted {
+				onCall[name] = policy
+			}
+		}
+		opts.OnCall = onCall
+	}
+
+	// Metrics of relationships of restricted services would still show their traffic.
+	var runtimeMetrics []domain.RelationshipMetrics
+
+	for _, metrics := range opts.RuntimeMetrics {
+		_, restrictedService := anonymized[metrics.Service]
+		_, restrictedParticipant := anonymized[metrics.Participant]
+
+		if !restrictedService && !restrictedParticipant {
+			runtimeMetrics = append(runtimeMetrics, metrics)
+		}
+	}
+	opts.RuntimeMetrics = runtimeMetrics
+
+	return opts
+}
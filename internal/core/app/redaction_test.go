@@ -0,0 +1,103 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/holydocs/messageflow/pkg/messageflow"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactSchema(t *testing.T) {
+	t.Parallel()
+
+	schema := domain.Schema{Services: []domain.Service{
+		{
+			Info: domain.ServiceInfo{Name: "Order Service", Description: "Takes orders.", System: "Shop"},
+			Relationships: []domain.Relationship{
+				{Action: domain.RelationshipActionRequests, Participant: "Fraud Service", Description: "Scores orders",
+					Notes: "Chargebacks", Technology: "gRPC"},
+				{Action: domain.RelationshipActionUses, Participant: "orders-db", Description: "Stores orders",
+					Technology: "PostgreSQL"},
+			},
+		},
+		{
+			Info: domain.ServiceInfo{Name: "Fraud Service", Description: "Scores risk.", System: "Risk",
+				Owner: "risk-team", Tags: []string{"ml"}},
+			Relationships: []domain.Relationship{
+				{Action: domain.RelationshipActionUses, Participant: "models", Description: "Loads models",
+					Technology: "S3"},
+			},
+			Endpoints: []domain.Endpoint{{Method: "POST", Path: "/score"}},
+		},
+		{Info: domain.ServiceInfo{Name: "Audit Service", Classification: "confidential"}},
+	}}
+
+	rules := config.Redacted{Systems: []string{"Risk"}, Classifications: []string{"confidential"},
+		Placeholder: "Internal Service"}
+
+	anonymized := restrictedServices(schema, rules)
+	assert.Equal(t, map[string]string{
+		"Audit Service": "Internal Service 1",
+		"Fraud Service": "Internal Service 2",
+	}, anonymized)
+
+	redacted := redactSchema(schema, anonymized)
+
+	assert.Equal(t, domain.Schema{Services: []domain.Service{
+		{
+			Info: domain.ServiceInfo{Name: "Order Service", Description: "Takes orders.", System: "Shop"},
+			Relationships: []domain.Relationship{
+				{Action: domain.RelationshipActionRequests, Participant: "Internal Service 2", Technology: "gRPC"},
+				{Action: domain.RelationshipActionUses, Participant: "orders-db", Description: "Stores orders",
+					Technology: "PostgreSQL"},
+			},
+		},
+		{
+			Info: domain.ServiceInfo{Name: "Internal Service 2"},
+			Relationships: []domain.Relationship{
+				{Action: domain.RelationshipActionUses, Participant: "models", Technology: "S3"},
+			},
+		},
+		{Info: domain.ServiceInfo{Name: "Internal Service 1"}, Relationships: []domain.Relationship{}},
+	}}, redacted)
+
+	assert.Equal(t, "Fraud Service", schema.Services[1].Info.Name, "the schema must not be modified")
+	assert.Equal(t, "Fraud Service", schema.Services[0].Relationships[0].Participant)
+
+	mfSchema := redactMessageFlow(messageflow.Schema{Services: []messageflow.Service{
+		{Name: "Fraud Service", Description: "Scores risk."},
+		{Name: "Order Service", Description: "Takes orders."},
+	}}, anonymized)
+	assert.Equal(t, []messageflow.Service{
+		{Name: "Internal Service 2"},
+		{Name: "Order Service", Description: "Takes orders."},
+	}, mfSchema.Services)
+}
+
+func TestRedactOptions(t *testing.T) {
+	t.Parallel()
+
+	opts := redactOptions(domain.GenerateOptions{
+		NeedsReview: []domain.StaleService{
+			{Name: "Fraud Service"},
+			{Name: "Order Service", ChangedDependencies: []string{"Fraud Service", "User Service"}},
+		},
+		OnCall: map[string]domain.OnCall{
+			"Fraud Service": {EscalationPolicy: "Fraud Primary"},
+			"Order Service": {EscalationPolicy: "Orders Primary"},
+		},
+		RuntimeMetrics: []domain.RelationshipMetrics{
+			{Service: "Order Service", Participant: "Fraud Service"},
+			{Service: "Order Service", Participant: "Stripe"},
+		},
+	}, map[string]string{"Fraud Service": "Internal Service 1"})
+
+	assert.Equal(t, []domain.StaleService{
+		{Name: "Order Service", ChangedDependencies: []string{"Internal Service 1", "User Service"}},
+	}, opts.NeedsReview)
+	assert.Equal(t, map[string]domain.OnCall{"Order Service": {EscalationPolicy: "Orders Primary"}}, opts.OnCall)
+	assert.Equal(t, []domain.RelationshipMetrics{{Service: "Order Service", Participant: "Stripe"}},
+		opts.RuntimeMetrics)
+}
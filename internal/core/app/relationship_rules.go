@@ -0,0 +1,79 @@
+package app
+
+import (
+	"path"
+	"slices"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+)
+
+// applyRelationshipRules adds the relationships of the rules to the services they match. They are merged like
+// declarations of the services themselves, so a service declaring the relationship too has it once.
+func applyRelationshipRules(schema domain.Schema, rules []config.RelationshipRule) domain.Schema {
+	declarations := relationshipRuleDeclarations(schema, rules)
+	if len(declarations) == 0 {
+		return schema
+	}
+
+	merged := schema.Merge(domain.Schema{Services: declarations})
+	merged.ResolveNamespaces()
+
+	return merged
+}
+
+// relationshipRuleDeclarations returns the relationships the rules add to the services of the schema, as
+// declarations of the services.
+func relationshipRuleDeclarations(schema domain.Schema, rules []config.RelationshipRule) []domain.Service {
+	var declarations []domain.Service
+
+	for _, service := range schema.Services {
+		var relationships []domain.Relationship
+
+		for _, rule := range rules {
+			if !ruleMatches(rule, service.Info) ||
+				rule.Participant == service.Info.Name || rule.Participant == service.Info.LocalName() {
+				continue
+			}
+
+			relationships = append(relationships, domain.Relationship{
+				Action:      domain.RelationshipAction(rule.Action),
+				Participant: rule.Participant,
+				Technology:  rule.Technology,
+				Description: rule.Description,
+				External:    rule.External,
+			})
+		}
+
+		if len(relationships) > 0 {
+			declarations = append(declarations, domain.Service{
+				Info:          domain.ServiceInfo{Name: service.Info.Name},
+				Relationships: relationships,
+			})
+		}
+	}
+
+	return declarations
+}
+
+// ruleMatches reports whether a service is matched by the systems, services or tags of a rule. Patterns of
+// services match qualified names as well as names without the namespace.
+func ruleMatches(rule config.RelationshipRule, info domain.ServiceInfo) bool {
+	for _, pattern := range rule.Systems {
+		if matched, _ := path.Match(pattern, info.System); matched && info.System != "" {
+			return true
+		}
+	}
+
+	for _, pattern := range rule.Services {
+		if matched, _ := path.Match(pattern, info.Name); matched {
+			return true
+		}
+
+		if matched, _ := path.Match(pattern, info.LocalName()); matched {
+			return true
+		}
+	}
+
+	return slices.ContainsFunc(rule.Tags, func(tag string) bool { return slices.Contains(info.Tags, tag) })
+}
@@ -0,0 +1,53 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyRelationshipRules(t *testing.T) {
+	t.Parallel()
+
+	schema := domain.Schema{Services: []domain.Service{
+		{
+			Info: domain.ServiceInfo{Name: "Order Service", System: "Shop"},
+			Relationships: []domain.Relationship{
+				{Action: domain.RelationshipActionUses, Participant: "Central Logging", Description: "Audit trail."},
+			},
+		},
+		{Info: domain.ServiceInfo{Name: "Cart Service", System: "Shop"}},
+		{Info: domain.ServiceInfo{Name: "Payments/API Gateway", Namespace: "Payments", Tags: []string{"edge"}}},
+		{Info: domain.ServiceInfo{Name: "Central Logging", System: "Shop"}},
+		{Info: domain.ServiceInfo{Name: "Reports"}},
+	}}
+
+	rules := []config.RelationshipRule{
+		{Systems: []string{"Shop"}, Action: "uses", Participant: "Central Logging"},
+		{Services: []string{"API *"}, Action: "requests", Participant: "WAF", Technology: "HTTP", External: true},
+		{Tags: []string{"edge"}, Action: "requests", Participant: "WAF", Technology: "HTTP", External: true},
+	}
+
+	applied := applyRelationshipRules(schema, rules)
+
+	relationships := make(map[string][]domain.Relationship)
+	for _, service := range applied.Services {
+		relationships[service.Info.Name] = service.Relationships
+	}
+
+	assert.Equal(t, map[string][]domain.Relationship{
+		"Cart Service":    {{Action: domain.RelationshipActionUses, Participant: "Central Logging"}},
+		"Central Logging": nil,
+		"Order Service": {
+			{Action: domain.RelationshipActionUses, Participant: "Central Logging", Description: "Audit trail."},
+		},
+		"Payments/API Gateway": {
+			{Action: domain.RelationshipActionRequests, Participant: "WAF", Technology: "HTTP", External: true},
+		},
+		"Reports": nil,
+	}, relationships)
+
+	assert.Equal(t, schema, applyRelationshipRules(schema, nil))
+}
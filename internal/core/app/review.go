@@ -0,0 +1,68 @@
+package app
+
+import (
+	"path/filepath"
+	"slices"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+)
+
+// pendingServices takes the services only defined by ingested or remote sources and not approved out of the
+// schema. Services defined by any configured specification are documented as usual.
+func pendingServices(
+	schema domain.Schema,
+	files []domain.SourceFile,
+	sources []domain.Source,
+	approved []string,
+) (domain.Schema, []domain.PendingService) {
+	sourceNames := make(map[string]string, len(sources))
+	for _, source := range sources {
+		sourceNames[filepath.Clean(source.Path)] = source.Name
+	}
+
+	discovered := make(map[string][]string)
+	configured := make(map[string]bool)
+
+	for _, file := range files {
+		source, ingested := sourceNames[filepath.Clean(file.Path)]
+
+		for _, name := range file.Services {
+			if !ingested {
+				configured[name] = true
+			} else if !slices.Contains(discovered[name], source) {
+				discovered[name] = append(discovered[name], source)
+			}
+		}
+	}
+
+	var pending []domain.PendingService
+
+	services := make([]domain.Service, 0, len(schema.Services))
+
+	for _, service := range schema.Services {
+		sources, found := discovered[service.Info.Name]
+		if !found || configured[service.Info.Name] || slices.Contains(approved, service.Info.Name) {
+			services = append(services, service)
+
+			continue
+		}
+
+		slices.Sort(sources)
+		pending = append(pending, domain.PendingService{
+			Name:        service.Info.Name,
+			Description: service.Info.Description,
+			Sources:     sources,
+		})
+	}
+
+	schema.Services = services
+
+	return schema, pending
+}
+
+// withoutPending leaves the services pending review out of the stale ones, they aren't documented yet.
+func withoutPending(stale []domain.StaleService, pending []domain.PendingService) []domain.StaleService {
+	return slices.DeleteFunc(stale, func(service domain.StaleService) bool {
+		return slices.ContainsFunc(pending, func(p domain.PendingService) bool { return p.Name == service.Name })
+	})
+}
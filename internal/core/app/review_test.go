@@ -0,0 +1,48 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPendingServices(t *testing.T) {
+	t.Parallel()
+
+	schema := domain.Schema{Services: []domain.Service{
+		{Info: domain.ServiceInfo{Name: "Order Service"}},
+		{Info: domain.ServiceInfo{Name: "Load Test Service", Description: "Generates load."}},
+		{Info: domain.ServiceInfo{Name: "Billing Service"}},
+		{Info: domain.ServiceInfo{Name: "Shipping Service"}},
+	}}
+	files := []domain.SourceFile{
+		{Path: "specs/order.servicefile.yaml", Services: []string{"Order Service"}},
+		{Path: ".holydocs/sources/order.yaml", Services: []string{"Order Service"}},
+		{Path: ".holydocs/sources/load-test.yaml", Services: []string{"Load Test Service"}},
+		{Path: ".holydocs/sources/billing.yaml", Services: []string{"Billing Service"}},
+		{Path: ".holydocs/sources/shipping.yaml", Services: []string{"Shipping Service", "Load Test Service"}},
+	}
+	sources := []domain.Source{
+		{Name: "order", Path: ".holydocs/sources/order.yaml"},
+		{Name: "load-test", Path: ".holydocs/sources/load-test.yaml"},
+		{Name: "billing", Path: ".holydocs/sources/billing.yaml"},
+		{Name: "shipping", Path: "./.holydocs/sources/shipping.yaml"},
+	}
+
+	documented, pending := pendingServices(schema, files, sources, []string{"Billing Service"})
+
+	names := make([]string, 0, len(documented.Services))
+	for _, service := range documented.Services {
+		names = append(names, service.Info.Name)
+	}
+
+	assert.Equal(t, []string{"Order Service", "Billing Service"}, names)
+	assert.Equal(t, []domain.PendingService{
+		{Name: "Load Test Service", Description: "Generates load.", Sources: []string{"load-test", "shipping"}},
+		{Name: "Shipping Service", Sources: []string{"shipping"}},
+	}, pending)
+
+	stale := withoutPending([]domain.StaleService{{Name: "Order Service"}, {Name: "Shipping Service"}}, pending)
+	assert.Equal(t, []domain.StaleService{{Name: "Order Service"}}, stale)
+}
@@ -0,0 +1,167 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+)
+
+type relationshipKey struct {
+	service     string
+	participant string
+}
+
+// queryRuntimeMetrics queries the p95 latency and error rate of relationships for the runtime overlay under
+// documentation.runtime. Relationships without data are left out, failing queries are reported as warnings.
+func (a *App) queryRuntimeMetrics(ctx context.Context,
+	schema domain.Schema) ([]domain.RelationshipMetrics, []string, error) {
+	runtime := a.config.Documentation.Runtime
+	if runtime.Prometheus == "" {
+		return nil, nil, nil
+	}
+
+	timeout, err := runtime.TimeoutDuration()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	overrides := make(map[relationshipKey]config.RuntimeRelationship, len(runtime.Relationships))
+	for _, rel := range runtime.Relationships {
+		overrides[relationshipKey{service: rel.Service, participant: rel.Participant}] = rel
+	}
+
+	var (
+		metrics  []domain.RelationshipMetrics
+		warnings []string
+	)
+
+	seen := make(map[relationshipKey]struct{})
+
+	for _, service := range schema.Services {
+		for _, rel := range service.Relationships {
+			key := relationshipKey{service: service.Info.Name, participant: rel.Participant}
+			if _, ok := seen[key]; ok || rel.Person || rel.Participant == "" {
+				continue
+			}
+			seen[key] = struct{}{}
+
+			queries := runtimeQueries(runtime, overrides[key])
+			if queries.Latency == "" && queries.ErrorRate == "" {
+				continue
+			}
+
+			relationshipMetrics, err := a.queryRelationshipMetrics(ctx, runtime, key, queries, timeout)
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("runtime metrics of %s → %s not queried: %v",
+					key.service, key.participant, err))
+
+				continue
+			}
+
+			if relationshipMetrics.P95Latency != nil || relationshipMetrics.ErrorRate != nil {
+				metrics = append(metrics, relationshipMetrics)
+			}
+		}
+	}
+
+	for _, rel := range runtime.Relationships {
+		if _, ok := seen[relationshipKey{service: rel.Service, participant: rel.Participant}]; !ok {
+			warnings = append(warnings, fmt.Sprintf("runtime relationship of %s with %s matches no relationship",
+				rel.Service, rel.Participant))
+		}
+	}
+
+	return metrics, warnings, nil
+}
+
+// runtimeQueries returns the queries and objectives of a relationship, the configured ones of the
+// relationship taking precedence over the defaults.
+func runtimeQueries(runtime config.RuntimeDocumentation,
+	override config.RuntimeRelationship) config.RuntimeRelationship {
+	queries := config.RuntimeRelationship{
+		Latency:      runtime.Latency,
+		ErrorRate:    runtime.ErrorRate,
+		LatencySLO:   runtime.LatencySLO,
+		ErrorRateSLO: runtime.ErrorRateSLO,
+	}
+
+	if override.Latency != "" {
+		queries.Latency = override.Latency
+	}
+
+	if override.ErrorRate != "" {
+		queries.ErrorRate = override.ErrorRate
+	}
+
+	if override.LatencySLO != "" {
+		queries.LatencySLO = override.LatencySLO
+	}
+
+	if override.ErrorRateSLO > 0 {
+		queries.ErrorRateSLO = override.ErrorRateSLO
+	}
+
+	return queries
+}
+
+func (a *App) queryRelationshipMetrics(ctx context.Context, runtime config.RuntimeDocumentation,
+	key relationshipKey, queries config.RuntimeRelationship, timeout time.Duration) (domain.RelationshipMetrics, error) {
+	latencySLO, err := config.ParseLatencySLO(queries.LatencySLO)
+	if err != nil {
+		return domain.RelationshipMetrics{}, err
+	}
+
+	metrics := domain.RelationshipMetrics{
+		Service:      key.service,
+		Participant:  key.participant,
+		LatencySLO:   latencySLO,
+		ErrorRateSLO: queries.ErrorRateSLO,
+	}
+
+	replacer := strings.NewReplacer(config.RuntimeServicePlaceholder, key.service,
+		config.RuntimeParticipantPlaceholder, key.participant)
+
+	query := func(promQL string) (*float64, error) {
+		if promQL == "" {
+			return nil, nil
+		}
+
+		value, err := a.metrics.Query(ctx, domain.QueryMetricRequest{
+			URL:     runtime.Prometheus,
+			Token:   runtime.Token,
+			Query:   replacer.Replace(promQL),
+			Timeout: timeout,
+		})
+		if errors.Is(err, domain.ErrNoMetricData) {
+			return nil, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &value, nil
+	}
+
+	latency, err := query(queries.Latency)
+	if err != nil {
+		return domain.RelationshipMetrics{}, fmt.Errorf("latency: %w", err)
+	}
+
+	if latency != nil {
+		p95 := time.Duration(*latency * float64(time.Second))
+		metrics.P95Latency = &p95
+	}
+
+	metrics.ErrorRate, err = query(queries.ErrorRate)
+	if err != nil {
+		return domain.RelationshipMetrics{}, fmt.Errorf("error rate: %w", err)
+	}
+
+	return metrics, nil
+}
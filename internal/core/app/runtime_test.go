@@ -0,0 +1,94 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeMetricsSource struct {
+	queries []string
+	values  map[string]float64
+}
+
+func (s *fakeMetricsSource) Query(_ context.Context, req domain.QueryMetricRequest) (float64, error) {
+	s.queries = append(s.queries, req.Query)
+
+	if req.Query == "broken" {
+		return 0, errors.New("query failed: bad_data: parse error")
+	}
+
+	value, ok := s.values[req.Query]
+	if !ok {
+		return 0, domain.ErrNoMetricData
+	}
+
+	return value, nil
+}
+
+func TestApp_QueryRuntimeMetrics(t *testing.T) {
+	t.Parallel()
+
+	source := &fakeMetricsSource{values: map[string]float64{
+		`p95{from="Orders",to="Payments"}`:    0.42,
+		`errors{from="Orders",to="Payments"}`: 0.002,
+		`stripe_p95`:                          1.5,
+	}}
+	schema := domain.Schema{Services: []domain.Service{
+		{Info: domain.ServiceInfo{Name: "Orders"}, Relationships: []domain.Relationship{
+			{Action: domain.RelationshipActionRequests, Participant: "Payments"},
+			{Action: domain.RelationshipActionSends, Participant: "Payments"},
+			{Action: domain.RelationshipActionRequests, Participant: "Stripe", External: true},
+			{Action: domain.RelationshipActionUses, Participant: "Customer", Person: true},
+			{Action: domain.RelationshipActionUses, Participant: "orders-db"},
+		}},
+		{Info: domain.ServiceInfo{Name: "Payments"}, Relationships: []domain.Relationship{
+			{Action: domain.RelationshipActionRequests, Participant: "Ledger"},
+		}},
+	}}
+
+	a := &App{metrics: source, config: &config.Config{}}
+
+	metrics, warnings, err := a.queryRuntimeMetrics(context.Background(), schema)
+	require.NoError(t, err)
+	assert.Nil(t, metrics, "no queries without prometheus")
+	assert.Empty(t, warnings)
+
+	a.config.Documentation.Runtime = config.RuntimeDocumentation{
+		Prometheus:   "https://prometheus.example.com",
+		Latency:      `p95{from="{service}",to="{participant}"}`,
+		ErrorRate:    `errors{from="{service}",to="{participant}"}`,
+		LatencySLO:   "300ms",
+		ErrorRateSLO: 0.01,
+		Relationships: []config.RuntimeRelationship{
+			{Service: "Orders", Participant: "Stripe", Latency: "stripe_p95", LatencySLO: "2s"},
+			{Service: "Payments", Participant: "Ledger", ErrorRate: "broken"},
+			{Service: "Search", Participant: "Payments"},
+		},
+	}
+
+	metrics, warnings, err = a.queryRuntimeMetrics(context.Background(), schema)
+	require.NoError(t, err)
+
+	latency, stripeLatency, errorRate := 420*time.Millisecond, 1500*time.Millisecond, 0.002
+	assert.Equal(t, []domain.RelationshipMetrics{
+		{Service: "Orders", Participant: "Payments", P95Latency: &latency, ErrorRate: &errorRate,
+			LatencySLO: 300 * time.Millisecond, ErrorRateSLO: 0.01},
+		{Service: "Orders", Participant: "Stripe", P95Latency: &stripeLatency,
+			LatencySLO: 2 * time.Second, ErrorRateSLO: 0.01},
+	}, metrics, "relationships without data are left out")
+	assert.True(t, metrics[0].Breached())
+	assert.False(t, metrics[1].Breached())
+	assert.Equal(t, []string{
+		"runtime metrics of Payments → Ledger not queried: error rate: query failed: bad_data: parse error",
+		"runtime relationship of Search with Payments matches no relationship",
+	}, warnings)
+	assert.NotContains(t, source.queries, `p95{from="Orders",to="Customer"}`, "people are not queried")
+	assert.Len(t, source.queries, 8, "relationships with the same participant are queried once")
+}
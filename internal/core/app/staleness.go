@@ -0,0 +1,149 @@
+package app
+
+import (
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+)
+
+// staleServices lists services whose ServiceFiles haven't changed for the given number of months while
+// specifications of their dependencies changed since, those ServiceFiles likely miss the changes.
+func staleServices(schema domain.Schema, files []domain.SourceFile, now time.Time, months int) []domain.StaleService {
+	cutoff := now.AddDate(0, -months, 0)
+
+	serviceFiles := make(map[string][]string)
+	definedAt := make(map[string]time.Time)
+	modifiedAt := servicesModifiedAt(files)
+
+	for _, file := range files {
+		for _, name := range file.Services {
+			if file.Kind != domain.SourceKindServiceFile {
+				continue
+			}
+
+			serviceFiles[name] = append(serviceFiles[name], file.Path)
+			if file.ModifiedAt.After(definedAt[name]) {
+				definedAt[name] = file.ModifiedAt
+			}
+		}
+	}
+
+	dependencies := serviceDependencies(schema)
+
+	var stale []domain.StaleService
+
+	for _, name := range slices.Sorted(maps.Keys(definedAt)) {
+		defined := definedAt[name]
+		if !defined.Before(cutoff) {
+			continue
+		}
+
+		service := domain.StaleService{Name: name, Paths: serviceFiles[name], ModifiedAt: defined}
+
+		for _, dependency := range dependencies[name] {
+			changed := modifiedAt[dependency]
+			if !changed.After(defined) {
+				continue
+			}
+
+			service.ChangedDependencies = append(service.ChangedDependencies, dependency)
+			if changed.After(service.DependenciesModifiedAt) {
+				service.DependenciesModifiedAt = changed
+			}
+		}
+
+		if len(service.ChangedDependencies) > 0 {
+			stale = append(stale, service)
+		}
+	}
+
+	return stale
+}
+
+// servicesModifiedAt returns the last modification of the specifications of every service, by service name.
+func servicesModifiedAt(files []domain.SourceFile) map[string]time.Time {
+	modifiedAt := make(map[string]time.Time)
+
+	for _, file := range files {
+		for _, name := range file.Services {
+			if file.ModifiedAt.After(modifiedAt[name]) {
+				modifiedAt[name] = file.ModifiedAt
+			}
+		}
+	}
+
+	return modifiedAt
+}
+
+// serviceCommits returns the last commit changing the specifications of every service, by service name.
+func serviceCommits(files []domain.SourceFile) map[string]domain.Commit {
+	commits := make(map[string]domain.Commit)
+	committedAt := make(map[string]time.Time)
+
+	for _, file := range files {
+		if file.LastCommit == (domain.Commit{}) {
+			continue
+		}
+
+		for _, name := range file.Services {
+			if _, ok := commits[name]; !ok || file.ModifiedAt.After(committedAt[name]) {
+				commits[name], committedAt[name] = file.LastCommit, file.ModifiedAt
+			}
+		}
+	}
+
+	return commits
+}
+
+// serviceDependencies returns the sorted dependencies of every service: the participants of its relationships
+// and the services declaring relationships with it, and the services operating on the same channels.
+func serviceDependencies(schema domain.Schema) map[string][]string {
+	related := make(map[string]map[string]struct{})
+	link := func(a, b string) {
+		if a == b {
+			return
+		}
+
+		for _, pair := range [][2]string{{a, b}, {b, a}} {
+			if related[pair[0]] == nil {
+				related[pair[0]] = make(map[string]struct{})
+			}
+
+			related[pair[0]][pair[1]] = struct{}{}
+		}
+	}
+
+	channels := make(map[string][]string)
+
+	for _, service := range schema.Services {
+		for _, rel := range service.Relationships {
+			if rel.Participant != "" {
+				link(service.Info.Name, rel.Participant)
+			}
+		}
+
+		for _, op := range service.Operation {
+			channels[op.Channel.Name] = append(channels[op.Channel.Name], service.Info.Name)
+			if op.Reply != nil {
+				channels[op.Reply.Name] = append(channels[op.Reply.Name], service.Info.Name)
+			}
+		}
+	}
+
+	for _, services := range channels {
+		for i, a := range services {
+			for _, b := range services[i+1:] {
+				link(a, b)
+			}
+		}
+	}
+
+	dependencies := make(map[string][]string, len(related))
+	for name, names := range related {
+		dependencies[name] = slices.Sorted(maps.Keys(names))
+	}
+
+	return dependencies
+}
@@ -0,0 +1,84 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStaleServices(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	longAgo := now.AddDate(-1, 0, 0)
+	recently := now.AddDate(0, 0, -7)
+
+	schema := domain.Schema{Services: []domain.Service{
+		{
+			Info:          domain.ServiceInfo{Name: "Order Service"},
+			Relationships: []domain.Relationship{{Action: domain.RelationshipActionRequests, Participant: "User Service"}},
+		},
+		{Info: domain.ServiceInfo{Name: "User Service"}},
+		{
+			Info:      domain.ServiceInfo{Name: "Billing Service"},
+			Operation: []domain.Operation{{Action: domain.ActionReceive, Channel: domain.Channel{Name: "orders"}}},
+		},
+		{
+			Info:      domain.ServiceInfo{Name: "Shipping Service"},
+			Operation: []domain.Operation{{Action: domain.ActionSend, Channel: domain.Channel{Name: "orders"}}},
+		},
+		{Info: domain.ServiceInfo{Name: "Audit Service"}},
+	}}
+
+	files := []domain.SourceFile{
+		{Path: "order.servicefile.yaml", Kind: domain.SourceKindServiceFile, Services: []string{"Order Service"},
+			ModifiedAt: longAgo},
+		{Path: "user.servicefile.yaml", Kind: domain.SourceKindServiceFile, Services: []string{"User Service"},
+			ModifiedAt: recently},
+		{Path: "billing.servicefile.yaml", Kind: domain.SourceKindServiceFile, Services: []string{"Billing Service"},
+			ModifiedAt: longAgo},
+		{Path: "shipping.asyncapi.yaml", Kind: domain.SourceKindAsyncAPI, Services: []string{"Shipping Service"},
+			ModifiedAt: recently},
+		{Path: "audit.servicefile.yaml", Kind: domain.SourceKindServiceFile, Services: []string{"Audit Service"},
+			ModifiedAt: longAgo},
+	}
+
+	assert.Equal(t, []domain.StaleService{
+		{
+			Name:                   "Billing Service",
+			Paths:                  []string{"billing.servicefile.yaml"},
+			ModifiedAt:             longAgo,
+			ChangedDependencies:    []string{"Shipping Service"},
+			DependenciesModifiedAt: recently,
+		},
+		{
+			Name:                   "Order Service",
+			Paths:                  []string{"order.servicefile.yaml"},
+			ModifiedAt:             longAgo,
+			ChangedDependencies:    []string{"User Service"},
+			DependenciesModifiedAt: recently,
+		},
+	}, staleServices(schema, files, now, 6))
+
+	assert.Empty(t, staleServices(schema, files, now, 24))
+}
+
+func TestServiceCommits(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	files := []domain.SourceFile{
+		{Path: "orders.servicefile.yaml", Services: []string{"Orders"}, ModifiedAt: now.AddDate(0, -1, 0),
+			LastCommit: domain.Commit{Author: "Jane Doe", Subject: "Document orders"}},
+		{Path: "orders.asyncapi.yaml", Services: []string{"Orders", "Billing"}, ModifiedAt: now,
+			LastCommit: domain.Commit{Author: "John Roe", Subject: "Publish invoices"}},
+		{Path: "search.servicefile.yaml", Services: []string{"Search"}, ModifiedAt: now},
+	}
+
+	assert.Equal(t, map[string]domain.Commit{
+		"Orders":  {Author: "John Roe", Subject: "Publish invoices"},
+		"Billing": {Author: "John Roe", Subject: "Publish invoices"},
+	}, serviceCommits(files), "files outside git repositories have no commit")
+}
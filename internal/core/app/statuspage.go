@@ -0,0 +1,55 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/holydocs/holydocs/internal/core/domain"
+)
+
+// checkStatusPages fetches the current status of the third-party dependencies with a status page under
+// documentation.dependencies that services have relationships with. A status page failing to answer leaves
+// its dependency without a status and is reported as a warning.
+func (a *App) checkStatusPages(ctx context.Context,
+	schema domain.Schema) (map[string]domain.DependencyStatus, []string, error) {
+	if !a.config.Documentation.StatusPages.Fetch {
+		return nil, nil, nil
+	}
+
+	timeout, err := a.config.Documentation.StatusPages.TimeoutDuration()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	participants := make(map[string]struct{})
+	for _, service := range schema.Services {
+		for _, rel := range service.Relationships {
+			participants[rel.Participant] = struct{}{}
+		}
+	}
+
+	dependencies := a.config.Documentation.Dependencies
+	statuses := make(map[string]domain.DependencyStatus)
+
+	var warnings []string
+
+	for _, name := range slices.Sorted(maps.Keys(dependencies)) {
+		statusPage := dependencies[name].StatusPage
+		if _, used := participants[name]; !used || statusPage == "" {
+			continue
+		}
+
+		status, err := a.statusChecker.Check(ctx, domain.CheckStatusPageRequest{URL: statusPage, Timeout: timeout})
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("status of %s not fetched: %v", name, err))
+
+			continue
+		}
+
+		statuses[name] = status
+	}
+
+	return statuses, warnings, nil
+}
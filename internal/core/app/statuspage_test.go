@@ -0,0 +1,73 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeStatusPageChecker struct {
+	requests []domain.CheckStatusPageRequest
+	statuses map[string]domain.DependencyStatus
+}
+
+func (c *fakeStatusPageChecker) Check(_ context.Context,
+	req domain.CheckStatusPageRequest) (domain.DependencyStatus, error) {
+	c.requests = append(c.requests, req)
+
+	status, ok := c.statuses[req.URL]
+	if !ok {
+		return domain.DependencyStatus{}, errors.New("unexpected status 503 Service Unavailable")
+	}
+
+	return status, nil
+}
+
+func TestApp_CheckStatusPages(t *testing.T) {
+	t.Parallel()
+
+	checker := &fakeStatusPageChecker{statuses: map[string]domain.DependencyStatus{
+		"https://status.stripe.com": {Indicator: "none", Description: "All Systems Operational"},
+	}}
+	schema := domain.Schema{Services: []domain.Service{{
+		Info: domain.ServiceInfo{Name: "Payments"},
+		Relationships: []domain.Relationship{
+			{Participant: "Stripe", External: true},
+			{Participant: "Twilio", External: true},
+		},
+	}}}
+
+	a := &App{statusChecker: checker, config: &config.Config{Documentation: config.Documentation{
+		Dependencies: map[string]config.DependencyDocumentation{
+			"Stripe":  {StatusPage: "https://status.stripe.com"},
+			"Twilio":  {StatusPage: "https://status.twilio.com"},
+			"Segment": {StatusPage: "https://status.segment.com"},
+			"Mongo":   {Vendor: "MongoDB Inc."},
+		},
+	}}}
+
+	statuses, warnings, err := a.checkStatusPages(context.Background(), schema)
+	require.NoError(t, err)
+	assert.Nil(t, statuses, "status pages are only fetched when enabled")
+	assert.Empty(t, warnings)
+	assert.Empty(t, checker.requests)
+
+	a.config.Documentation.StatusPages.Fetch = true
+
+	statuses, warnings, err = a.checkStatusPages(context.Background(), schema)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]domain.DependencyStatus{
+		"Stripe": {Indicator: "none", Description: "All Systems Operational"},
+	}, statuses)
+	assert.Equal(t, []string{"status of Twilio not fetched: unexpected status 503 Service Unavailable"}, warnings)
+	assert.Equal(t, []domain.CheckStatusPageRequest{
+		{URL: "https://status.stripe.com", Timeout: 5 * time.Second},
+		{URL: "https://status.twilio.com", Timeout: 5 * time.Second},
+	}, checker.requests, "status pages of dependencies no service uses aren't fetched")
+}
@@ -0,0 +1,296 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+)
+
+// Validate loads the specifications and reports inconsistencies between them.
+func (a *App) Validate(ctx context.Context, req domain.ValidateRequest) (domain.ValidateReply, error) {
+	schema, err := a.loadSchema(ctx, req.ServiceFilesPaths, req.AsyncAPIFilesPaths)
+	if err != nil {
+		return domain.ValidateReply{}, fmt.Errorf("loading schema from files: %w", err)
+	}
+
+	schema = annotateChannels(schema, a.config.Documentation.Channels)
+
+	findings := missingDLQFindings(schema)
+	findings = append(findings, duplicateDescriptionFindings(schema)...)
+	findings = append(findings, contradictoryLimitFindings(schema)...)
+
+	if req.Prose != nil {
+		findings = append(findings, proseFindings(schema, *req.Prose)...)
+	}
+
+	if req.Approval != nil {
+		documented, err := a.recordedSchema(req.Approval.OutputDir)
+		if err != nil {
+			return domain.ValidateReply{}, fmt.Errorf("reading documented schema, run gen-docs first: %w", err)
+		}
+
+		findings = append(findings, unapprovedRelationshipFindings(documented, schema, req.Approval.Approvals)...)
+	}
+
+	for _, rule := range req.Rules {
+		ruleFindings, err := a.ruleChecker.Check(ctx, rule, schema)
+		if err != nil {
+			return domain.ValidateReply{}, fmt.Errorf("checking rule %s: %w", rule.Name, err)
+		}
+
+		findings = append(findings, ruleFindings...)
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Rule != findings[j].Rule {
+			return findings[i].Rule < findings[j].Rule
+		}
+
+		return findings[i].Subject < findings[j].Subject
+	})
+
+	return applyBaseline(findings, req.Baseline), nil
+}
+
+// applyBaseline leaves out the findings of the baseline, so only new findings are reported. A finding of the
+// baseline accepts one identical finding, duplicates are accepted as often as they are listed.
+func applyBaseline(findings, baseline []domain.Finding) domain.ValidateReply {
+	accepted := make(map[domain.Finding]int, len(baseline))
+	for _, finding := range baseline {
+		accepted[finding]++
+	}
+
+	var reply domain.ValidateReply
+
+	for _, finding := range findings {
+		if accepted[finding] > 0 {
+			accepted[finding]--
+			reply.Accepted++
+
+			continue
+		}
+
+		reply.Findings = append(reply.Findings, finding)
+	}
+
+	for _, finding := range baseline {
+		if accepted[finding] > 0 {
+			accepted[finding]--
+			reply.Fixed = append(reply.Fixed, finding)
+		}
+	}
+
+	return reply
+}
+
+// missingDLQFindings reports channels declaring a dead letter queue that isn't a channel of any operation,
+// which usually means the queue was renamed or nobody consumes it.
+func missingDLQFindings(schema domain.Schema) []domain.Finding {
+	channels := schema.ChannelNames()
+	slas := schema.ChannelSLAs()
+
+	var findings []domain.Finding
+
+	for _, name := range slices.Sorted(maps.Keys(slas)) {
+		dlq := slas[name].DLQ
+		if dlq == "" {
+			continue
+		}
+
+		if _, ok := channels[dlq]; !ok {
+			findings = append(findings, domain.Finding{
+				Rule:    domain.FindingRuleMissingDLQ,
+				Subject: name,
+				Message: fmt.Sprintf("dead letter queue %s is not a channel of any operation", dlq),
+			})
+		}
+	}
+
+	return findings
+}
+
+// contradictoryLimitFindings reports relationships declaring limits the participant can't sustain: a rate
+// limit above the requests per second the participant handles, or a connection pool larger than the
+// connections it accepts.
+func contradictoryLimitFindings(schema domain.Schema) []domain.Finding {
+	limits := make(map[string]domain.ServiceLimits)
+	for _, service := range schema.Services {
+		if service.Info.Limits != nil {
+			limits[service.Info.Name] = *service.Info.Limits
+		}
+	}
+
+	var findings []domain.Finding
+
+	for _, service := range schema.Services {
+		for _, rel := range service.Relationships {
+			participant, ok := limits[rel.Participant]
+			if rel.Limits == nil || !ok {
+				continue
+			}
+
+			if participant.MaxRPS > 0 && rel.Limits.RateLimit > participant.MaxRPS {
+				findings = append(findings, domain.Finding{
+					Rule:    domain.FindingRuleContradictoryLimits,
+					Subject: service.Info.Name,
+					Message: fmt.Sprintf("rate limit of %d requests per second toward %s exceeds the %d it handles",
+						rel.Limits.RateLimit, rel.Participant, participant.MaxRPS),
+				})
+			}
+
+			if participant.MaxConnections > 0 && rel.Limits.ConnectionPool > participant.MaxConnections {
+				findings = append(findings, domain.Finding{
+					Rule:    domain.FindingRuleContradictoryLimits,
+					Subject: service.Info.Name,
+					Message: fmt.Sprintf("connection pool of %d toward %s exceeds the %d connections it accepts",
+						rel.Limits.ConnectionPool, rel.Participant, participant.MaxConnections),
+				})
+			}
+		}
+	}
+
+	return findings
+}
+
+// Descriptions sharing this share of their words are nearly identical, provided they are long enough for
+// the share to mean something.
+const (
+	similarDescriptionRatio    = 0.8
+	similarDescriptionMinWords = 4
+)
+
+// duplicateDescriptionFindings reports services with the description of another service, up to the names
+// of the services and a few words. Copied descriptions usually mean the specification was scaffolded from a
+// template and never filled in.
+func duplicateDescriptionFindings(schema domain.Schema) []domain.Finding {
+	words := make([][]string, len(schema.Services))
+	for i, service := range schema.Services {
+		words[i] = descriptionWords(service.Info.Description, service.Info.Name)
+	}
+
+	var findings []domain.Finding
+
+	for i, service := range schema.Services {
+		if len(words[i]) == 0 {
+			continue
+		}
+
+		var duplicates []string
+
+		for j, other := range schema.Services {
+			if i != j && similarDescriptions(words[i], words[j]) {
+				duplicates = append(duplicates, other.Info.Name)
+			}
+		}
+
+		if len(duplicates) == 0 {
+			continue
+		}
+
+		slices.Sort(duplicates)
+		findings = append(findings, domain.Finding{
+			Rule:    domain.FindingRuleDuplicateDescription,
+			Subject: service.Info.Name,
+			Message: "description is identical or nearly identical to the one of " + strings.Join(duplicates, ", "),
+		})
+	}
+
+	return findings
+}
+
+// descriptionWords returns the lowercased words of a description without the words of the service name,
+// which templates fill in.
+func descriptionWords(description, name string) []string {
+	isSeparator := func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }
+	nameWords := strings.FieldsFunc(strings.ToLower(name), isSeparator)
+
+	return slices.DeleteFunc(strings.FieldsFunc(strings.ToLower(description), isSeparator), func(word string) bool {
+		return slices.Contains(nameWords, word)
+	})
+}
+
+// similarDescriptions reports whether descriptions have the same words, or share most of their distinct
+// words when both are long enough.
+func similarDescriptions(a, b []string) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return false
+	}
+
+	if slices.Equal(a, b) {
+		return true
+	}
+
+	if len(a) < similarDescriptionMinWords || len(b) < similarDescriptionMinWords {
+		return false
+	}
+
+	setA, setB := wordSet(a), wordSet(b)
+
+	shared := 0
+	for word := range setA {
+		if _, ok := setB[word]; ok {
+			shared++
+		}
+	}
+
+	return float64(shared)/float64(len(setA)+len(setB)-shared) >= similarDescriptionRatio
+}
+
+func wordSet(words []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(words))
+	for _, word := range words {
+		set[word] = struct{}{}
+	}
+
+	return set
+}
+
+// annotateChannels applies the service level annotations of channels configured in the documentation
+// on top of the ones declared in AsyncAPI specifications.
+func annotateChannels(schema domain.Schema, channels map[string]config.ChannelDocumentation) domain.Schema {
+	if len(channels) == 0 {
+		return schema
+	}
+
+	annotate := func(channel *domain.Channel) {
+		doc, ok := channels[channel.Name]
+		if !ok {
+			return
+		}
+
+		sla := domain.ChannelSLA{Throughput: doc.Throughput, MaxLatency: doc.MaxLatency, DLQ: doc.Dlq}
+		if channel.SLA != nil {
+			sla = sla.Merge(*channel.SLA)
+		}
+
+		channel.SLA = &sla
+	}
+
+	services := make([]domain.Service, len(schema.Services))
+	for i, service := range schema.Services {
+		service.Operation = slices.Clone(service.Operation)
+
+		for j := range service.Operation {
+			annotate(&service.Operation[j].Channel)
+
+			if reply := service.Operation[j].Reply; reply != nil {
+				replyCopy := *reply
+				annotate(&replyCopy)
+				service.Operation[j].Reply = &replyCopy
+			}
+		}
+
+		services[i] = service
+	}
+
+	schema.Services = services
+
+	return schema
+}
@@ -0,0 +1,151 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMissingDLQFindings(t *testing.T) {
+	t.Parallel()
+
+	schema := domain.Schema{Services: []domain.Service{
+		{
+			Info: domain.ServiceInfo{Name: "Order Service"},
+			Operation: []domain.Operation{
+				{Action: domain.ActionSend, Channel: domain.Channel{Name: "orders",
+					SLA: &domain.ChannelSLA{DLQ: "orders.dlq"}}},
+				{Action: domain.ActionSend, Channel: domain.Channel{Name: "payments"}},
+				{Action: domain.ActionSend, Channel: domain.Channel{Name: "refunds"}},
+			},
+		},
+		{
+			Info: domain.ServiceInfo{Name: "Audit Service"},
+			Operation: []domain.Operation{
+				{Action: domain.ActionReceive, Channel: domain.Channel{Name: "orders.dlq"}},
+			},
+		},
+	}}
+
+	assert.Empty(t, missingDLQFindings(schema))
+
+	schema = annotateChannels(schema, map[string]config.ChannelDocumentation{
+		"payments": {Throughput: "50 msg/s", Dlq: "payments.dlq"},
+		"orders":   {MaxLatency: "2s", Dlq: "orders.dead"},
+	})
+
+	assert.Equal(t, &domain.ChannelSLA{MaxLatency: "2s", DLQ: "orders.dead"},
+		schema.Services[0].Operation[0].Channel.SLA)
+	assert.Nil(t, schema.Services[0].Operation[2].Channel.SLA)
+
+	findings := missingDLQFindings(schema)
+	require.Len(t, findings, 2)
+	assert.Equal(t, domain.Finding{
+		Rule:    domain.FindingRuleMissingDLQ,
+		Subject: "orders",
+		Message: "dead letter queue orders.dead is not a channel of any operation",
+	}, findings[0])
+	assert.Equal(t, "payments", findings[1].Subject)
+}
+
+func TestDuplicateDescriptionFindings(t *testing.T) {
+	t.Parallel()
+
+	schema := domain.Schema{Services: []domain.Service{
+		{Info: domain.ServiceInfo{Name: "Order Service", Description: "Order Service: TODO describe the service."}},
+		{Info: domain.ServiceInfo{Name: "Billing Service", Description: "Billing service - todo, describe the service"}},
+		{Info: domain.ServiceInfo{Name: "Shipping Service",
+			Description: "Ships parcels to customers and tracks their delivery status with carriers."}},
+		{Info: domain.ServiceInfo{Name: "Returns Service",
+			Description: "Ships parcels back to customers and tracks their delivery status with carriers."}},
+		{Info: domain.ServiceInfo{Name: "Audit Service", Description: "Records changes for compliance."}},
+		{Info: domain.ServiceInfo{Name: "Search Service"}},
+		{Info: domain.ServiceInfo{Name: "Report Service"}},
+	}}
+
+	assert.Equal(t, []domain.Finding{
+		{
+			Rule:    domain.FindingRuleDuplicateDescription,
+			Subject: "Order Service",
+			Message: "description is identical or nearly identical to the one of Billing Service",
+		},
+		{
+			Rule:    domain.FindingRuleDuplicateDescription,
+			Subject: "Billing Service",
+			Message: "description is identical or nearly identical to the one of Order Service",
+		},
+		{
+			Rule:    domain.FindingRuleDuplicateDescription,
+			Subject: "Shipping Service",
+			Message: "description is identical or nearly identical to the one of Returns Service",
+		},
+		{
+			Rule:    domain.FindingRuleDuplicateDescription,
+			Subject: "Returns Service",
+			Message: "description is identical or nearly identical to the one of Shipping Service",
+		},
+	}, duplicateDescriptionFindings(schema))
+}
+
+func TestContradictoryLimitFindings(t *testing.T) {
+	t.Parallel()
+
+	schema := domain.Schema{Services: []domain.Service{
+		{
+			Info: domain.ServiceInfo{Name: "Order Service"},
+			Relationships: []domain.Relationship{
+				{Action: domain.RelationshipActionRequests, Participant: "Billing Service",
+					Limits: &domain.RelationshipLimits{RateLimit: 800, ConnectionPool: 20}},
+				{Action: domain.RelationshipActionRequests, Participant: "Stripe",
+					Limits: &domain.RelationshipLimits{RateLimit: 100}},
+			},
+		},
+		{
+			Info: domain.ServiceInfo{Name: "Report Service"},
+			Relationships: []domain.Relationship{
+				{Action: domain.RelationshipActionRequests, Participant: "Billing Service",
+					Limits: &domain.RelationshipLimits{RateLimit: 50, ConnectionPool: 80}},
+				{Action: domain.RelationshipActionRequests, Participant: "Billing Service"},
+			},
+		},
+		{
+			Info: domain.ServiceInfo{Name: "Billing Service",
+				Limits: &domain.ServiceLimits{MaxRPS: 500, MaxConnections: 50}},
+		},
+	}}
+
+	assert.Equal(t, []domain.Finding{
+		{
+			Rule:    domain.FindingRuleContradictoryLimits,
+			Subject: "Order Service",
+			Message: "rate limit of 800 requests per second toward Billing Service exceeds the 500 it handles",
+		},
+		{
+			Rule:    domain.FindingRuleContradictoryLimits,
+			Subject: "Report Service",
+			Message: "connection pool of 80 toward Billing Service exceeds the 50 connections it accepts",
+		},
+	}, contradictoryLimitFindings(schema))
+}
+
+func TestApplyBaseline(t *testing.T) {
+	t.Parallel()
+
+	dlq := domain.Finding{Rule: domain.FindingRuleMissingDLQ, Subject: "orders", Message: "dead letter queue"}
+	spelling := domain.Finding{Rule: domain.FindingRuleSpelling, Subject: "Billing", Message: "unknown word recieve"}
+	banned := domain.Finding{Rule: domain.FindingRuleBannedWord, Subject: "Billing", Message: "banned word simply"}
+	fixed := domain.Finding{Rule: domain.FindingRuleSpelling, Subject: "Orders", Message: "unknown word adress"}
+
+	reply := applyBaseline([]domain.Finding{dlq, spelling, spelling, banned}, []domain.Finding{spelling, dlq, fixed})
+	assert.Equal(t, []domain.Finding{spelling, banned}, reply.Findings)
+	assert.Equal(t, 2, reply.Accepted)
+	assert.Equal(t, []domain.Finding{fixed}, reply.Fixed)
+
+	reply = applyBaseline([]domain.Finding{dlq}, nil)
+	assert.Equal(t, []domain.Finding{dlq}, reply.Findings)
+	assert.Zero(t, reply.Accepted)
+	assert.Empty(t, reply.Fixed)
+}
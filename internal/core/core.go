@@ -1,8 +1,23 @@
 package core
 
 import (
+	"github.com/holydocs/holydocs/internal/adapters/secondary/asyncapi"
+	"github.com/holydocs/holydocs/internal/adapters/secondary/cache"
+	"github.com/holydocs/holydocs/internal/adapters/secondary/dependencies"
 	docsgen "github.com/holydocs/holydocs/internal/adapters/secondary/docs"
+	"github.com/holydocs/holydocs/internal/adapters/secondary/lineage"
+	"github.com/holydocs/holydocs/internal/adapters/secondary/notify"
+	"github.com/holydocs/holydocs/internal/adapters/secondary/oncall"
+	"github.com/holydocs/holydocs/internal/adapters/secondary/plugin"
+	"github.com/holydocs/holydocs/internal/adapters/secondary/preview"
+	"github.com/holydocs/holydocs/internal/adapters/secondary/probe"
+	"github.com/holydocs/holydocs/internal/adapters/secondary/prometheus"
+	"github.com/holydocs/holydocs/internal/adapters/secondary/remote"
 	"github.com/holydocs/holydocs/internal/adapters/secondary/schema"
+	"github.com/holydocs/holydocs/internal/adapters/secondary/sources"
+	"github.com/holydocs/holydocs/internal/adapters/secondary/statuspage"
+	"github.com/holydocs/holydocs/internal/adapters/secondary/wasm"
+	"github.com/holydocs/holydocs/internal/adapters/secondary/wiki"
 	"github.com/holydocs/holydocs/internal/config"
 	"github.com/holydocs/holydocs/internal/core/app"
 	"github.com/holydocs/holydocs/internal/core/domain"
@@ -19,6 +34,21 @@ func NewApp(i do.Injector) (*app.App, error) {
 		do.MustInvoke[*schema.Loader](i),
 		do.MustInvoke[*docsgen.Generator](i),
 		do.MustInvoke[domain.Target](i),
+		do.MustInvoke[*sources.Store](i),
+		do.MustInvoke[*asyncapi.Exporter](i),
+		do.MustInvoke[*dependencies.Exporter](i),
+		do.MustInvoke[*lineage.Exporter](i),
+		do.MustInvoke[*remote.Fetcher](i),
+		do.MustInvoke[*cache.Store](i),
+		do.MustInvoke[*wiki.Publisher](i),
+		do.MustInvoke[*preview.Uploader](i),
+		do.MustInvoke[*plugin.Runner](i),
+		do.MustInvoke[*wasm.Rules](i),
+		do.MustInvoke[*oncall.Directory](i),
+		do.MustInvoke[*statuspage.Checker](i),
+		do.MustInvoke[*probe.Prober](i),
+		do.MustInvoke[*prometheus.Client](i),
+		do.MustInvoke[*notify.Notifier](i),
 		do.MustInvoke[*config.Config](i),
 	), nil
 }
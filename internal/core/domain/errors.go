@@ -2,9 +2,31 @@
 package domain
 
 import (
+	"errors"
 	"fmt"
 )
 
+// Errors.
+var (
+	ErrServiceNotFound   = errors.New("service not found")
+	ErrNoDiagramData     = errors.New("no diagram data")
+	ErrUnsupportedValue  = errors.New("unsupported value")
+	ErrInvalidSource     = errors.New("invalid source")
+	ErrSourceNotFound    = errors.New("source not found")
+	ErrNoAsyncOperations = errors.New("no async operations")
+	ErrStrictWarnings    = errors.New("warnings reported in strict mode")
+	ErrPartialGeneration = errors.New("documentation generated partially")
+	ErrFetchFailed       = errors.New("fetching remote source failed")
+	ErrPublishFailed     = errors.New("publishing to wiki failed")
+	ErrValidationFailed  = errors.New("validation failed")
+	ErrPluginFailed      = errors.New("plugin failed")
+	ErrPluginNotFound    = errors.New("plugin not found")
+	ErrReleaseExists     = errors.New("release already exists")
+	ErrNoMetricData      = errors.New("no metric data")
+	ErrCoverageTooLow    = errors.New("coverage below threshold")
+	ErrMetadataNotFound  = errors.New("no domain.json found")
+)
+
 // UnsupportedFormatModeError represents an error when an unsupported format mode is used.
 type UnsupportedFormatModeError struct {
 	Mode     FormatMode
@@ -4,6 +4,10 @@ package domain
 import (
 	"context"
 	"fmt"
+	"hash/fnv"
+	"maps"
+	"path"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -43,16 +47,91 @@ type Service struct {
 	Info          ServiceInfo    `json:"info"`
 	Relationships []Relationship `json:"relationships"`
 	Operation     []Operation    `json:"operations"`
+	// Endpoints are the HTTP endpoints of the service, documented by OpenAPI specifications.
+	Endpoints []Endpoint `json:"endpoints,omitempty"`
+}
+
+// Endpoint represents an HTTP endpoint of a service.
+type Endpoint struct {
+	Method  string `json:"method"`
+	Path    string `json:"path"`
+	Summary string `json:"summary,omitempty"`
+	// Auth lists the security schemes protecting the endpoint, it is empty for public endpoints.
+	Auth []string `json:"auth,omitempty"`
 }
 
 // ServiceInfo represents info about service.
 type ServiceInfo struct {
-	Name        string   `json:"name"`
-	Description string   `json:"description"`
-	System      string   `json:"system,omitempty"`
-	Owner       string   `json:"owner,omitempty"`
-	Repository  string   `json:"repository,omitempty"`
-	Tags        []string `json:"tags,omitempty"`
+	// Name is qualified with the namespace of namespaced services, see QualifiedName.
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Namespace tells apart services of the same name owned by different organizations or business units.
+	Namespace string `json:"namespace,omitempty"`
+	System    string `json:"system,omitempty"`
+	// BusinessCapability is the business capability the service supports, e.g. Payments, independently of
+	// the system it is built in.
+	BusinessCapability string   `json:"business_capability,omitempty"`
+	Owner              string   `json:"owner,omitempty"`
+	Repository         string   `json:"repository,omitempty"`
+	Subpath            string   `json:"subpath,omitempty"`
+	Tags               []string `json:"tags,omitempty"`
+	Planned            bool     `json:"planned,omitempty"`
+	Deprecated         bool     `json:"deprecated,omitempty"`
+	SunsetDate         string   `json:"sunset_date,omitempty"`
+	// BoundedContext marks the system of the service as a DDD bounded context.
+	BoundedContext bool `json:"bounded_context,omitempty"`
+	// Classification is the access classification of the service, e.g. internal or public.
+	Classification string         `json:"classification,omitempty"`
+	Risks          []Risk         `json:"risks,omitempty"`
+	Limits         *ServiceLimits `json:"limits,omitempty"`
+	Deployments    []Deployment   `json:"deployments,omitempty"`
+}
+
+// Deployment is an environment a service is deployed to, with the endpoints reporting its health there.
+type Deployment struct {
+	Environment string `json:"environment"`
+	Health      string `json:"health,omitempty"`
+	Readiness   string `json:"readiness,omitempty"`
+}
+
+// mergeDeployments adds the deployments to environments the service isn't known to be deployed to yet.
+func mergeDeployments(existing, incoming []Deployment) []Deployment {
+	for _, deployment := range incoming {
+		if !slices.ContainsFunc(existing, func(d Deployment) bool { return d.Environment == deployment.Environment }) {
+			existing = append(existing, deployment)
+		}
+	}
+
+	return existing
+}
+
+// ServiceLimits are known capacity limits of a service.
+type ServiceLimits struct {
+	// MaxRPS is the number of requests per second the service handles at most.
+	MaxRPS int `json:"max_rps,omitempty"`
+	// MaxConnections is the number of concurrent connections the service accepts at most.
+	MaxConnections int `json:"max_connections,omitempty"`
+}
+
+// NamespaceSeparator separates the namespace from the name in qualified names of services.
+const NamespaceSeparator = "/"
+
+// QualifiedName returns the name of a service qualified with its namespace, e.g. "Payments/API Gateway".
+func QualifiedName(namespace, name string) string {
+	if namespace = strings.TrimSpace(namespace); namespace == "" {
+		return name
+	}
+
+	return namespace + NamespaceSeparator + name
+}
+
+// LocalName returns the name of a service without its namespace.
+func (i ServiceInfo) LocalName() string {
+	if i.Namespace == "" {
+		return i.Name
+	}
+
+	return strings.TrimPrefix(i.Name, i.Namespace+NamespaceSeparator)
 }
 
 // RelationshipAction represents the type of relationship that can exist between services.
@@ -72,11 +151,234 @@ type Relationship struct {
 	Action      RelationshipAction `json:"action"`
 	Participant string             `json:"participant,omitempty"`
 	Description string             `json:"description,omitempty"`
+	Notes       string             `json:"notes,omitempty"`
 	Technology  string             `json:"technology"`
 	Proto       string             `json:"proto,omitempty"`
 	Tags        []string           `json:"tags,omitempty"`
 	External    bool               `json:"external,omitempty"`
 	Person      bool               `json:"person,omitempty"`
+	Planned     bool               `json:"planned,omitempty"`
+	Criticality Criticality        `json:"criticality,omitempty"`
+	Access      Access             `json:"access,omitempty"`
+	DDDPatterns []DDDPattern       `json:"ddd_patterns,omitempty"`
+	// Approval references the approval of the relationship, e.g. an architecture decision record.
+	Approval string              `json:"approval,omitempty"`
+	Risks    []Risk              `json:"risks,omitempty"`
+	Limits   *RelationshipLimits `json:"limits,omitempty"`
+}
+
+// RelationshipLimits are known limits of a relationship toward its participant.
+type RelationshipLimits struct {
+	// RateLimit is the number of requests per second the service sends to the participant at most, e.g. the
+	// rate limit of an external API.
+	RateLimit int `json:"rate_limit,omitempty"`
+	// ConnectionPool is the size of the pool of connections the service keeps to the participant.
+	ConnectionPool int `json:"connection_pool,omitempty"`
+}
+
+// Criticality tells how much a service depends on a relationship.
+type Criticality string
+
+// Criticalities.
+const (
+	CriticalityLow      Criticality = "low"
+	CriticalityMedium   Criticality = "medium"
+	CriticalityHigh     Criticality = "high"
+	CriticalityCritical Criticality = "critical"
+)
+
+// Criticalities returns all supported criticalities, from the lowest to the highest.
+func Criticalities() []Criticality {
+	return []Criticality{CriticalityLow, CriticalityMedium, CriticalityHigh, CriticalityCritical}
+}
+
+// Valid reports whether the criticality is supported.
+func (c Criticality) Valid() bool {
+	return c.Weight() > 0
+}
+
+// Weight orders criticalities from 1 for low to 4 for critical, it is 0 when no criticality is set.
+func (c Criticality) Weight() int {
+	switch c {
+	case CriticalityLow:
+		return 1
+	case CriticalityMedium:
+		return 2
+	case CriticalityHigh:
+		return 3
+	case CriticalityCritical:
+		return 4
+	default:
+		return 0
+	}
+}
+
+// Access is how a service accesses a datastore it has a relationship with.
+type Access string
+
+// Access modes.
+const (
+	AccessRead      Access = "read"
+	AccessWrite     Access = "write"
+	AccessReadWrite Access = "read-write"
+)
+
+// AccessModes returns all supported access modes.
+func AccessModes() []Access {
+	return []Access{AccessRead, AccessWrite, AccessReadWrite}
+}
+
+// Valid reports whether the access mode is supported.
+func (a Access) Valid() bool {
+	return a == AccessRead || a == AccessWrite || a == AccessReadWrite
+}
+
+// Reads reports whether the service reads from the datastore.
+func (a Access) Reads() bool {
+	return a == AccessRead || a == AccessReadWrite
+}
+
+// Writes reports whether the service writes to the datastore.
+func (a Access) Writes() bool {
+	return a == AccessWrite || a == AccessReadWrite
+}
+
+// CombineAccess returns the access mode covering both access modes.
+func CombineAccess(a, b Access) Access {
+	reads, writes := a.Reads() || b.Reads(), a.Writes() || b.Writes()
+
+	switch {
+	case reads && writes:
+		return AccessReadWrite
+	case writes:
+		return AccessWrite
+	case reads:
+		return AccessRead
+	default:
+		return ""
+	}
+}
+
+// MaxCriticality returns the higher of two criticalities.
+func MaxCriticality(a, b Criticality) Criticality {
+	if b.Weight() > a.Weight() {
+		return b
+	}
+
+	return a
+}
+
+// DDDPattern is a domain-driven design integration pattern between bounded contexts.
+type DDDPattern string
+
+// DDD patterns.
+const (
+	DDDPatternPartnership         DDDPattern = "partnership"
+	DDDPatternSharedKernel        DDDPattern = "shared_kernel"
+	DDDPatternCustomerSupplier    DDDPattern = "customer_supplier"
+	DDDPatternConformist          DDDPattern = "conformist"
+	DDDPatternAnticorruptionLayer DDDPattern = "anticorruption_layer"
+	DDDPatternOpenHostService     DDDPattern = "open_host_service"
+	DDDPatternPublishedLanguage   DDDPattern = "published_language"
+)
+
+// DDDPatterns returns all supported DDD patterns.
+func DDDPatterns() []DDDPattern {
+	return []DDDPattern{
+		DDDPatternPartnership,
+		DDDPatternSharedKernel,
+		DDDPatternCustomerSupplier,
+		DDDPatternConformist,
+		DDDPatternAnticorruptionLayer,
+		DDDPatternOpenHostService,
+		DDDPatternPublishedLanguage,
+	}
+}
+
+// Valid reports whether the pattern is supported.
+func (p DDDPattern) Valid() bool {
+	return p.Abbreviation() != ""
+}
+
+// Abbreviation returns the short name used on context maps, e.g. ACL for anticorruption_layer.
+func (p DDDPattern) Abbreviation() string {
+	switch p {
+	case DDDPatternPartnership:
+		return "P"
+	case DDDPatternSharedKernel:
+		return "SK"
+	case DDDPatternCustomerSupplier:
+		return "C/S"
+	case DDDPatternConformist:
+		return "CF"
+	case DDDPatternAnticorruptionLayer:
+		return "ACL"
+	case DDDPatternOpenHostService:
+		return "OHS"
+	case DDDPatternPublishedLanguage:
+		return "PL"
+	default:
+		return ""
+	}
+}
+
+// Symmetric reports whether the pattern binds both contexts equally, so it has no upstream and downstream.
+func (p DDDPattern) Symmetric() bool {
+	return p == DDDPatternPartnership || p == DDDPatternSharedKernel
+}
+
+// RiskType is the kind of a known risk of a service or relationship.
+type RiskType string
+
+// Risk types.
+const (
+	RiskTypeSinglePointOfFailure RiskType = "single_point_of_failure"
+	RiskTypeVendorLockIn         RiskType = "vendor_lock_in"
+	RiskTypeEOLRuntime           RiskType = "eol_runtime"
+	RiskTypeOther                RiskType = "other"
+)
+
+// RiskTypes returns all supported risk types.
+func RiskTypes() []RiskType {
+	return []RiskType{RiskTypeSinglePointOfFailure, RiskTypeVendorLockIn, RiskTypeEOLRuntime, RiskTypeOther}
+}
+
+// Valid reports whether the risk type is supported.
+func (t RiskType) Valid() bool {
+	return t.Title() != ""
+}
+
+// Title returns the name of the risk type shown in the documentation.
+func (t RiskType) Title() string {
+	switch t {
+	case RiskTypeSinglePointOfFailure:
+		return "Single point of failure"
+	case RiskTypeVendorLockIn:
+		return "Vendor lock-in"
+	case RiskTypeEOLRuntime:
+		return "End-of-life runtime"
+	case RiskTypeOther:
+		return "Other"
+	default:
+		return ""
+	}
+}
+
+// Risk is a known risk of a service or relationship. Severity uses the scale of criticalities.
+type Risk struct {
+	Type        RiskType    `json:"type"`
+	Severity    Criticality `json:"severity"`
+	Description string      `json:"description,omitempty"`
+}
+
+func mergeRisks(existing, incoming []Risk) []Risk {
+	for _, risk := range incoming {
+		if !slices.Contains(existing, risk) {
+			existing = append(existing, risk)
+		}
+	}
+
+	return existing
 }
 
 // OperationAction represents the type of operation that can be performed on a channel.
@@ -92,12 +394,78 @@ const (
 type Message struct {
 	Name    string `json:"name"`
 	Payload string `json:"payload"`
+	// Examples are example payloads declared in the specification, each as indented JSON.
+	Examples []string `json:"examples,omitempty"`
 }
 
 // Channel represents a communication channel with a name and message.
 type Channel struct {
-	Name    string  `json:"name"`
-	Message Message `json:"message"`
+	Name    string      `json:"name"`
+	Message Message     `json:"message"`
+	SLA     *ChannelSLA `json:"sla,omitempty"`
+}
+
+// ChannelSLA holds the service level annotations of a channel.
+type ChannelSLA struct {
+	// Throughput is the expected throughput, e.g. "500 msg/s".
+	Throughput string `json:"throughput,omitempty"`
+	// MaxLatency is the maximum delivery latency, e.g. "2s".
+	MaxLatency string `json:"max_latency,omitempty"`
+	// DLQ is the name of the dead letter queue channel.
+	DLQ string `json:"dlq,omitempty"`
+}
+
+// Merge fills the annotations missing in s from other.
+func (s ChannelSLA) Merge(other ChannelSLA) ChannelSLA {
+	if s.Throughput == "" {
+		s.Throughput = other.Throughput
+	}
+
+	if s.MaxLatency == "" {
+		s.MaxLatency = other.MaxLatency
+	}
+
+	if s.DLQ == "" {
+		s.DLQ = other.DLQ
+	}
+
+	return s
+}
+
+// ChannelSLAs returns the annotations of the channels of all operations, including reply channels, by name.
+func (s Schema) ChannelSLAs() map[string]ChannelSLA {
+	slas := make(map[string]ChannelSLA)
+
+	add := func(channel *Channel) {
+		if channel != nil && channel.SLA != nil {
+			slas[channel.Name] = slas[channel.Name].Merge(*channel.SLA)
+		}
+	}
+
+	for _, service := range s.Services {
+		for i := range service.Operation {
+			add(&service.Operation[i].Channel)
+			add(service.Operation[i].Reply)
+		}
+	}
+
+	return slas
+}
+
+// ChannelNames returns the names of the channels of all operations, including reply channels.
+func (s Schema) ChannelNames() map[string]struct{} {
+	names := make(map[string]struct{})
+
+	for _, service := range s.Services {
+		for _, op := range service.Operation {
+			names[op.Channel.Name] = struct{}{}
+			if op.Reply != nil {
+				names[op.Reply.Name] = struct{}{}
+			}
+		}
+	}
+
+	return names
 }
 
 // Operation defines an action to be performed on a channel, optionally with a reply channel.
@@ -135,6 +503,8 @@ const (
 	ChangeTypeAdded   ChangeType = "added"
 	ChangeTypeRemoved ChangeType = "removed"
 	ChangeTypeChanged ChangeType = "changed"
+	// ChangeTypeBaseline marks a summary of squashed changelog entries.
+	ChangeTypeBaseline ChangeType = "baseline"
 )
 
 // Change represents a single change in the schema.
@@ -147,10 +517,61 @@ type Change struct {
 	Timestamp time.Time  `json:"timestamp"`
 }
 
+// Services returns the services a change affects: the service it was recorded for, named before the key of
+// the relationship or operation, and the participant of relationships.
+func (c Change) Services() []string {
+	service, key, found := strings.Cut(c.Name, ":")
+	if c.Category == "service" || !found {
+		return []string{c.Name}
+	}
+
+	// Relationship keys are the action, participant, technology and protocol separated by |.
+	if parts := strings.Split(key, "|"); c.Category == "relationship" && len(parts) > 1 {
+		return []string{service, parts[1]}
+	}
+
+	return []string{service}
+}
+
 // Changelog represents a collection of changes with a version and date.
 type Changelog struct {
 	Date    time.Time `json:"date"`
 	Changes []Change  `json:"changes"`
+	// Baseline summarizes the entries a squashed baseline entry replaces, nil for other entries.
+	Baseline *ChangelogBaseline `json:"baseline,omitempty"`
+}
+
+// ChangelogBaseline counts the changelog entries and changes squashed into a baseline entry, so squashing
+// again adds them up.
+type ChangelogBaseline struct {
+	Entries int `json:"entries"`
+	Changes int `json:"changes"`
+	// Since is the date of the oldest squashed entry, the baseline entry is dated as the newest.
+	Since time.Time `json:"since"`
+}
+
+// Attribute adds the last commit of the specifications of the service every change was recorded for to the
+// details of the change, e.g. (last commit by Jane Doe: "Publish invoices"). Changes of services without a
+// commit, such as removed services, are left as they are.
+func (c Changelog) Attribute(commits map[string]Commit) Changelog {
+	if len(commits) == 0 {
+		return c
+	}
+
+	changes := make([]Change, 0, len(c.Changes))
+
+	for _, change := range c.Changes {
+		if commit, ok := commits[change.Services()[0]]; ok && change.Type != ChangeTypeBaseline {
+			change.Details = strings.TrimSpace(fmt.Sprintf("%s (last commit by %s: %q)", change.Details,
+				commit.Author, commit.Subject))
+		}
+
+		changes = append(changes, change)
+	}
+
+	c.Changes = changes
+
+	return c
 }
 
 // Target interface defines the contract for schema formatting and rendering.
@@ -175,52 +596,1284 @@ type GenerateDocumentationRequest struct {
 	ServiceFilesPaths  []string
 	AsyncAPIFilesPaths []string
 	OutputDir          string
+	// Strict fails the run when warnings are reported, after the documentation and the run report are written.
+	Strict bool
+	// KeepGoing leaves out specifications that fail to load and diagrams that fail to render instead of
+	// aborting, documents everything else and fails the run with all collected errors at the end.
+	KeepGoing bool
+	// Attribution adds context to the details of the recorded changes, none when empty.
+	Attribution ChangeAttribution
+	// Preview generates a pull request preview into OutputDir only: output targets, outputs of systems, the
+	// redacted copy and target plugins are left out and subscribers aren't notified of its changes.
+	Preview bool
+}
+
+// GenerateOptions tune documentation generation.
+type GenerateOptions struct {
+	// KeepGoing replaces diagrams that fail to render with a placeholder and collects their errors.
+	KeepGoing bool
+	// SourceErrors lists specifications left out because they failed to load. The schema is incomplete then,
+	// so it is not recorded in domain.json and the changelog.
+	SourceErrors []string
+	// NeedsReview lists services whose specifications are likely stale.
+	NeedsReview []StaleService
+	// PendingReview lists services discovered in ingested or remote sources, left out until they are approved.
+	PendingReview []PendingService
+	// LastUpdated is the last modification of the specifications of every service, by service name.
+	LastUpdated map[string]time.Time
+	// OnCall is the escalation policy of services looked up at the on-call provider, by service name.
+	OnCall map[string]OnCall
+	// DependencyStatus is the current status of third-party dependencies, by participant name.
+	DependencyStatus map[string]DependencyStatus
+	// RuntimeMetrics are the metrics of relationships queried for the runtime overlay.
+	RuntimeMetrics []RelationshipMetrics
+	// OutputDir replaces the configured output directory, output targets and outputs of systems are left out then.
+	OutputDir string
+	// Attribution is the last commit of the specifications of every service, by service name, added to the
+	// details of the recorded changes.
+	Attribution map[string]Commit
+	// Previous is the schema documented before, which changes are recorded against instead of the one read
+	// from domain.json. It is nil on the first run.
+	Previous *Schema
+}
+
+// Monorepo describes the subdirectories of a monorepo services are mapped to.
+type Monorepo struct {
+	// Mapping holds the subdirectories of services by service name, taking precedence over packages.
+	Mapping map[string]string
+	// Packages are the packages below the root of the monorepo.
+	Packages []MonorepoPackage
+}
+
+// MonorepoPackage is a package of a monorepo, detected by its go.mod or package.json file.
+type MonorepoPackage struct {
+	// Dir is the directory of the package relative to the monorepo root, with forward slashes.
+	Dir string
+	// Name is the last segment of the Go module path or the package.json name without its scope.
+	Name string
+}
+
+// SourceFile is a specification file with the services it defines, as seen on disk.
+type SourceFile struct {
+	Path       string
+	Kind       SourceKind
+	Services   []string
+	ModifiedAt time.Time
+	// LastCommit is the last commit changing the file, empty outside git repositories.
+	LastCommit Commit
+}
+
+// Commit is a commit of a git repository.
+type Commit struct {
+	Author  string
+	Subject string
+}
+
+// ChangeAttribution is where the context added to the details of changes comes from.
+type ChangeAttribution string
+
+// Change attributions.
+const (
+	// ChangeAttributionGit adds the last commit of the specifications of a service to its changes.
+	ChangeAttributionGit ChangeAttribution = "git"
+)
+
+// ChangeAttributions returns all supported change attributions.
+func ChangeAttributions() []ChangeAttribution {
+	return []ChangeAttribution{ChangeAttributionGit}
+}
+
+// Declaration is the schema declared by one source, a specification file or the relationship rules of the
+// configuration.
+type Declaration struct {
+	Source string
+	// Kind is the type of the source, empty for sources without precedence like the relationship rules.
+	Kind   SourceKind
+	Schema Schema
+}
+
+// Precedence lists, by field of services, the source types whose values win when several sources declare the
+// field, the first type first. Values of unlisted types come after the listed ones, and values of the same type
+// are merged by the default rules: the longest description and the first value of other fields win.
+type Precedence map[string][]SourceKind
+
+// PrecedenceFields returns the fields of services whose precedence can be configured.
+func PrecedenceFields() []string {
+	return slices.Sorted(maps.Keys(precedenceFields))
+}
+
+//nolint:gochecknoglobals // Fields are looked up the same way for every merge.
+var precedenceFields = map[string]func(*ServiceInfo) *string{
+	"description":         func(i *ServiceInfo) *string { return &i.Description },
+	"system":              func(i *ServiceInfo) *string { return &i.System },
+	"business_capability": func(i *ServiceInfo) *string { return &i.BusinessCapability },
+	"owner":               func(i *ServiceInfo) *string { return &i.Owner },
+	"repository":          func(i *ServiceInfo) *string { return &i.Repository },
+	"subpath":             func(i *ServiceInfo) *string { return &i.Subpath },
+	"classification":      func(i *ServiceInfo) *string { return &i.Classification },
+	"sunset_date":         func(i *ServiceInfo) *string { return &i.SunsetDate },
+}
+
+// rank returns the position of the source type in the precedence of the field, unlisted types rank last.
+func (p Precedence) rank(field string, kind SourceKind) int {
+	kinds := p[field]
+	if i := slices.Index(kinds, kind); i >= 0 && kind != "" {
+		return i
+	}
+
+	return len(kinds)
+}
+
+// ServiceProvenance tells which sources declared a merged service and contributed its fields.
+type ServiceProvenance struct {
+	Service       string            `json:"service"`
+	Sources       []string          `json:"sources"`
+	Fields        []FieldProvenance `json:"fields,omitempty"`
+	Tags          []ItemProvenance  `json:"tags,omitempty"`
+	Relationships []ItemProvenance  `json:"relationships,omitempty"`
+	Operations    []ItemProvenance  `json:"operations,omitempty"`
+}
+
+// FieldProvenance is the merged value of a field with the source it was taken from and the different values
+// of other sources it overrode.
+type FieldProvenance struct {
+	Field      string         `json:"field"`
+	Value      string         `json:"value"`
+	Source     string         `json:"source"`
+	Overridden []SourcedValue `json:"overridden,omitempty"`
+}
+
+// SourcedValue is the value of a field declared by a source.
+type SourcedValue struct {
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
+
+// ItemProvenance lists the sources declaring an item of a service, like a tag or a relationship, with the
+// provenance of the fields of the item.
+type ItemProvenance struct {
+	Item    string            `json:"item"`
+	Sources []string          `json:"sources"`
+	Fields  []FieldProvenance `json:"fields,omitempty"`
+}
+
+// PendingService is a service only defined by ingested or remote sources that isn't approved yet.
+type PendingService struct {
+	Name        string
+	Description string
+	// Sources are the names of the sources defining the service.
+	Sources []string
+}
+
+// StaleService is a service whose ServiceFile hasn't changed for a long time while the specifications of
+// its dependencies changed since.
+type StaleService struct {
+	Name string
+	// Paths are the ServiceFiles defining the service.
+	Paths      []string
+	ModifiedAt time.Time
+	// ChangedDependencies are the services declared or observed as dependencies, whose specifications
+	// changed after the ServiceFiles of the service.
+	ChangedDependencies []string
+	// DependenciesModifiedAt is the last change of the specifications of the dependencies.
+	DependenciesModifiedAt time.Time
 }
 
 // GenerateDocumentationReply represents the reply from generating documentation.
 type GenerateDocumentationReply struct {
 	Changelog *Changelog
+	Warnings  []string
+	// Errors are collected with keep-going, for the parts left out of the documentation.
+	Errors   []string
+	Diagrams DiagramStats
+}
+
+// RunReportVersion is the format version of run-report.json.
+const RunReportVersion = 1
+
+// ProbeKind is what a probed endpoint of a deployment reports.
+type ProbeKind string
+
+// Probe kinds.
+const (
+	ProbeKindHealth    ProbeKind = "health"
+	ProbeKindReadiness ProbeKind = "readiness"
+)
+
+// ProbeRequest represents a request to probe an endpoint of a deployment.
+type ProbeRequest struct {
+	URL     string
+	Timeout time.Duration
+}
+
+// ProbeResult is the reachability of an endpoint of a deployment at the time it was probed.
+type ProbeResult struct {
+	Service     string    `json:"service"`
+	Environment string    `json:"environment"`
+	Kind        ProbeKind `json:"kind"`
+	URL         string    `json:"url"`
+	// Reachable is set when the endpoint answered, StatusCode tells whether it reported itself healthy.
+	Reachable  bool   `json:"reachable"`
+	StatusCode int    `json:"status_code,omitempty"`
+	LatencyMS  int64  `json:"latency_ms"`
+	Error      string `json:"error,omitempty"`
+}
+
+// OK reports whether the endpoint answered with a 2xx status.
+func (r ProbeResult) OK() bool {
+	return r.Reachable && r.StatusCode >= 200 && r.StatusCode < 300
+}
+
+// ProbeReport holds the results of probing the deployments of all services, written as probes.json next to
+// the documentation. The results are a point-in-time check, not monitoring.
+type ProbeReport struct {
+	ProbedAt time.Time     `json:"probed_at"`
+	Results  []ProbeResult `json:"results"`
+}
+
+// ProbeDeploymentsRequest represents a request to probe the deployments declared by services.
+type ProbeDeploymentsRequest struct {
+	ServiceFilesPaths  []string
+	AsyncAPIFilesPaths []string
+	OutputDir          string
+	// Environments limits probing to deployments to the environments, all are probed when empty.
+	Environments []string
+	Timeout      time.Duration
+}
+
+// ProbeDeploymentsReply represents the result of probing deployments.
+type ProbeDeploymentsReply struct {
+	Report ProbeReport
+}
+
+// RunReport summarizes a documentation run, written as run-report.json next to the documentation
+// so CI dashboards can track the health of the documentation pipeline.
+type RunReport struct {
+	Version     int              `json:"version"`
+	GeneratedAt time.Time        `json:"generated_at"`
+	DurationMS  int64            `json:"duration_ms"`
+	Sources     SourcesReport    `json:"sources"`
+	Diagrams    DiagramStats     `json:"diagrams"`
+	Warnings    []string         `json:"warnings"`
+	Errors      []string         `json:"errors"`
+	Changelog   ChangelogSummary `json:"changelog"`
+}
+
+// SourcesReport describes the specifications loaded in a documentation run.
+type SourcesReport struct {
+	ServiceFiles  int   `json:"service_files"`
+	AsyncAPIFiles int   `json:"asyncapi_files"`
+	Services      int   `json:"services"`
+	Operations    int   `json:"operations"`
+	DurationMS    int64 `json:"duration_ms"`
+}
+
+// DiagramStats counts the diagrams of a documentation run.
+type DiagramStats struct {
+	Rendered   int              `json:"rendered"`
+	Skipped    []SkippedDiagram `json:"skipped"`
+	DurationMS int64            `json:"duration_ms"`
+}
+
+// SkippedDiagram is a diagram that was not rendered and is left out of the documentation.
+type SkippedDiagram struct {
+	Diagram string `json:"diagram"`
+	Reason  string `json:"reason"`
+}
+
+// ChangelogSummary counts the changes detected in a documentation run by type.
+type ChangelogSummary struct {
+	Changes int                `json:"changes"`
+	ByType  map[ChangeType]int `json:"by_type"`
+}
+
+// NewChangelogSummary summarizes the changelog entry recorded in a run, nil when nothing changed.
+func NewChangelogSummary(changelog *Changelog) ChangelogSummary {
+	summary := ChangelogSummary{ByType: make(map[ChangeType]int)}
+	if changelog == nil {
+		return summary
+	}
+
+	for _, change := range changelog.Changes {
+		summary.Changes++
+		summary.ByType[change.Type]++
+	}
+
+	return summary
+}
+
+// SquashChangelogRequest represents a request to collapse old changelog entries into a baseline entry.
+type SquashChangelogRequest struct {
+	OutputDir string
+	// Before squashes entries older than this date, zero value squashes all entries.
+	Before time.Time
+}
+
+// SquashChangelogReply represents the reply from squashing changelog entries.
+type SquashChangelogReply struct {
+	Squashed  int
+	Remaining int
+}
+
+// ReleaseDocumentationRequest represents a request to snapshot the generated documentation for a product release.
+type ReleaseDocumentationRequest struct {
+	OutputDir string
+	Version   string
+	// Date is when the version was released.
+	Date time.Time
+	// Force replaces an existing snapshot of the version.
+	Force bool
+}
+
+// ReleaseDocumentationReply represents the reply from snapshotting the documentation of a release.
+type ReleaseDocumentationReply struct {
+	// Dir is the directory holding the snapshot.
+	Dir string
+	// Releases lists all snapshots in the output directory, newest first.
+	Releases []Release
+}
+
+// Release describes a snapshot of the documentation taken for a product release.
+type Release struct {
+	Version    string    `json:"version"`
+	ReleasedAt time.Time `json:"released_at"`
+	Services   int       `json:"services"`
+}
+
+// ServiceDiagramType is the kind of diagram that can be rendered for a single service.
+type ServiceDiagramType string
+
+// Service diagram types.
+const (
+	ServiceDiagramRelationships ServiceDiagramType = "relationships"
+	ServiceDiagramFlow          ServiceDiagramType = "flow"
+	// ServiceDiagramFocus is the overview diagram limited to the neighborhood of a service or system.
+	ServiceDiagramFocus ServiceDiagramType = "focus"
+)
+
+// DiagramFormat is the output format of a rendered diagram.
+type DiagramFormat string
+
+// Diagram formats.
+const (
+	DiagramFormatSVG DiagramFormat = "svg"
+	DiagramFormatD2  DiagramFormat = "d2"
+)
+
+// GenerateServiceDiagramRequest represents a request to render a single diagram for one service.
+type GenerateServiceDiagramRequest struct {
+	ServiceFilesPaths  []string
+	AsyncAPIFilesPaths []string
+	Service            string
+	Type               ServiceDiagramType
+	Format             DiagramFormat
+	// Depth is the number of hops around the service, or the services of the system named Service, shown
+	// in focus diagrams.
+	Depth int
+}
+
+// GenerateSnippetRequest represents a request to render the README snippet of a service, a Markdown fragment
+// committed into the repository of the service.
+type GenerateSnippetRequest struct {
+	ServiceFilesPaths  []string
+	AsyncAPIFilesPaths []string
+	Service            string
+	// DocsURL is the URL the full documentation is published at, the snippet links it when set.
+	DocsURL string
+	// DiagramPath is the path the snippet references the relationships diagram by, no diagram is rendered
+	// when empty.
+	DiagramPath string
+	// Existing is the current content of the file receiving the snippet. A snippet found in it is replaced,
+	// otherwise the snippet is appended.
+	Existing []byte
+}
+
+// GenerateSnippetReply represents the reply from rendering the README snippet of a service.
+type GenerateSnippetReply struct {
+	// Content is Existing with the snippet replaced or appended.
+	Content []byte
+	// Diagram is the relationships diagram as SVG, only rendered with a DiagramPath.
+	Diagram []byte
+}
+
+// MergeRequest represents a request to merge the specifications without generating documentation.
+type MergeRequest struct {
+	ServiceFilesPaths  []string
+	AsyncAPIFilesPaths []string
+	// Services limits the reply to the named services, all services when empty.
+	Services []string
+}
+
+// DiffSchemasRequest represents a request to compare two schema snapshots, domain.json files or directories
+// of generated documentation holding one.
+type DiffSchemasRequest struct {
+	Before string
+	After  string
+	// Visual renders the overview diagram of both snapshots with the changes colored.
+	Visual bool
+	Format DiagramFormat
+	// Attribution adds context to the details of the changes from the specification files, none when empty.
+	Attribution        ChangeAttribution
+	ServiceFilesPaths  []string
+	AsyncAPIFilesPaths []string
+}
+
+// DiffSchemasReply represents the reply from comparing two schema snapshots.
+type DiffSchemasReply struct {
+	Changelog Changelog
+	// Diagram is only rendered for visual diffs.
+	Diagram []byte
+	// Docs is only summarized when both snapshots are directories of generated documentation.
+	Docs DocsDiff
+}
+
+// DocsDiff summarizes the changes of the Markdown pages between two directories of generated documentation.
+type DocsDiff struct {
+	Pages []PageDiff
+	// Services lists the services whose documentation changed.
+	Services []string
+}
+
+// PageDiff is a Markdown page added, removed or changed between two directories of generated documentation.
+// Sections are identified by the path of their headings, joined with " / ".
+type PageDiff struct {
+	Path            string
+	Type            ChangeType
+	SectionsAdded   []string
+	SectionsRemoved []string
+	SectionsChanged []string
+}
+
+// SourceKind is the kind of specification received as an ingested source.
+type SourceKind string
+
+// Source kinds.
+const (
+	SourceKindServiceFile SourceKind = "servicefile"
+	SourceKindAsyncAPI    SourceKind = "asyncapi"
+)
+
+// Source is a specification pushed to holydocs, e.g. by a deploy pipeline, instead of being read from static files.
+type Source struct {
+	Name       string     `json:"name"`
+	Kind       SourceKind `json:"kind"`
+	Path       string     `json:"path"`
+	ReceivedAt time.Time  `json:"received_at"`
+	// ExpiresAt is the moment the source is dropped unless it is sent again, zero value never expires.
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Expired reports whether the source is expired at the given time.
+func (s Source) Expired(now time.Time) bool {
+	return !s.ExpiresAt.IsZero() && !now.Before(s.ExpiresAt)
+}
+
+// RemoteSource is a specification fetched over HTTP before documentation is generated.
+type RemoteSource struct {
+	Name string
+	URL  string
+	// Timeout limits every single fetch attempt.
+	Timeout time.Duration
+	// Retries is the number of attempts made after the first one failed.
+	Retries int
+	// Backoff is the delay before the first retry, it doubles with every next retry.
+	Backoff time.Duration
+	// Optional sources don't fail the run when fetching fails.
+	Optional bool
+}
+
+// AsyncAPIExportScope defines how exported AsyncAPI documents are split.
+type AsyncAPIExportScope string
+
+// AsyncAPI export scopes.
+const (
+	AsyncAPIExportScopeGlobal AsyncAPIExportScope = "global"
+	AsyncAPIExportScopeSystem AsyncAPIExportScope = "system"
+)
+
+// AsyncAPIExportScopes returns all supported AsyncAPI export scopes.
+func AsyncAPIExportScopes() []AsyncAPIExportScope {
+	return []AsyncAPIExportScope{AsyncAPIExportScopeGlobal, AsyncAPIExportScopeSystem}
+}
+
+// ExportAsyncAPIRequest represents a request to re-emit the merged async operations as AsyncAPI documents.
+type ExportAsyncAPIRequest struct {
+	ServiceFilesPaths  []string
+	AsyncAPIFilesPaths []string
+	Scope              AsyncAPIExportScope
+	// Title is the title of the global document.
+	Title string
+	// GlobalName names the document of services without a system when exporting per system.
+	GlobalName string
+	// Version is the info.version of the exported documents.
+	Version string
+}
+
+// DependencyExportFormat defines the file format of exported dependencies.
+type DependencyExportFormat string
+
+// Dependency export formats.
+const (
+	DependencyExportFormatCSV  DependencyExportFormat = "csv"
+	DependencyExportFormatXLSX DependencyExportFormat = "xlsx"
+	DependencyExportFormatDOT  DependencyExportFormat = "dot"
+)
+
+// DependencyExportFormats returns all supported dependency export formats.
+func DependencyExportFormats() []DependencyExportFormat {
+	return []DependencyExportFormat{DependencyExportFormatCSV, DependencyExportFormatXLSX, DependencyExportFormatDOT}
+}
+
+// ExportDependenciesRequest represents a request to export the relationships of all services.
+type ExportDependenciesRequest struct {
+	ServiceFilesPaths  []string
+	AsyncAPIFilesPaths []string
+	Format             DependencyExportFormat
+}
+
+// DependencyExport is the dependency table of all services encoded in the requested format.
+type DependencyExport struct {
+	// Dependencies is the number of exported dependencies.
+	Dependencies int
+	Content      []byte
+}
+
+// Dependency is a relationship of a service flattened into a table row, System and Owner are the ones
+// of the source service.
+type Dependency struct {
+	Source     string
+	Target     string
+	Action     RelationshipAction
+	Technology string
+	Proto      string
+	System     string
+	Owner      string
+}
+
+// RadarExportFormat defines the file format of an exported technology radar.
+type RadarExportFormat string
+
+// Radar export formats.
+const (
+	RadarExportFormatJSON RadarExportFormat = "json"
+	RadarExportFormatCSV  RadarExportFormat = "csv"
+)
+
+// RadarExportFormats returns all supported radar export formats.
+func RadarExportFormats() []RadarExportFormat {
+	return []RadarExportFormat{RadarExportFormatJSON, RadarExportFormatCSV}
+}
+
+// ExportRadarRequest represents a request to aggregate the technologies of all services into a radar.
+type ExportRadarRequest struct {
+	ServiceFilesPaths  []string
+	AsyncAPIFilesPaths []string
+}
+
+// RadarEntry is a technology placed on the technology radar with the services using it.
+type RadarEntry struct {
+	Name     string
+	Ring     string
+	Quadrant string
+	Services []string
+}
+
+// LineageExportFormat defines the format of exported lineage.
+type LineageExportFormat string
+
+// Lineage export formats.
+const (
+	LineageExportFormatOpenLineage LineageExportFormat = "openlineage"
+	LineageExportFormatDataHub     LineageExportFormat = "datahub"
+)
+
+// LineageExportFormats returns all supported lineage export formats.
+func LineageExportFormats() []LineageExportFormat {
+	return []LineageExportFormat{LineageExportFormatOpenLineage, LineageExportFormatDataHub}
+}
+
+// ExportLineageRequest represents a request to export the datasets services read and write.
+type ExportLineageRequest struct {
+	ServiceFilesPaths  []string
+	AsyncAPIFilesPaths []string
+	Format             LineageExportFormat
+	// Namespace is the namespace of the jobs and of the datasets without a technology.
+	Namespace string
+	// GlobalName names the DataHub data flow of services without a system.
+	GlobalName string
+}
+
+// LineageExport is the lineage of all services encoded in the requested format.
+type LineageExport struct {
+	// Jobs is the number of exported services.
+	Jobs    int
+	Content []byte
+}
+
+// LineageDatasetKind defines the kind of dataset in lineage.
+type LineageDatasetKind string
+
+// Lineage dataset kinds.
+const (
+	LineageDatasetDatastore LineageDatasetKind = "datastore"
+	LineageDatasetChannel   LineageDatasetKind = "channel"
+)
+
+// LineageDataset is a datastore or channel read or written by a service.
+type LineageDataset struct {
+	Name string
+	Kind LineageDatasetKind
+	// Technology is the technology of the relationship with a datastore, channels have none.
+	Technology string
+}
+
+// LineageJob is a service with the datasets it reads (Inputs) and writes (Outputs).
+type LineageJob struct {
+	Service     string
+	Description string
+	System      string
+	Owner       string
+	Inputs      []LineageDataset
+	Outputs     []LineageDataset
+}
+
+// ValidateRequest represents a request to check the specifications for inconsistencies.
+type ValidateRequest struct {
+	ServiceFilesPaths  []string
+	AsyncAPIFilesPaths []string
+	// Prose enables the linting of descriptions when set.
+	Prose *ProseRules
+	// Rules are checked in addition to the built-in rules.
+	Rules []CustomRule
+	// Approval requires approvals of new relationships between systems when set.
+	Approval *ApprovalPolicy
+	// Baseline holds accepted findings, which aren't reported again.
+	Baseline []Finding
+}
+
+// CoverageRequest represents a request to measure how completely the services are documented.
+type CoverageRequest struct {
+	ServiceFilesPaths  []string
+	AsyncAPIFilesPaths []string
+}
+
+// Coverage tells how many services document their owner and description, along with the services that don't.
+type Coverage struct {
+	Services            int
+	MissingOwners       []string
+	MissingDescriptions []string
+}
+
+// OwnerCoverage returns the percentage of services with an owner, 100 without services.
+func (c Coverage) OwnerCoverage() float64 {
+	return coveragePercent(c.Services, len(c.MissingOwners))
+}
+
+// DescriptionCoverage returns the percentage of services with a description, 100 without services.
+func (c Coverage) DescriptionCoverage() float64 {
+	return coveragePercent(c.Services, len(c.MissingDescriptions))
+}
+
+// fullCoverage is the coverage percentage of complete documentation.
+const fullCoverage = 100
+
+func coveragePercent(total, missing int) float64 {
+	if total == 0 {
+		return fullCoverage
+	}
+
+	return float64(total-missing) * fullCoverage / float64(total)
+}
+
+// ApprovalPolicy requires approvals of relationships between systems added since the documented schema.
+type ApprovalPolicy struct {
+	// OutputDir holds the documented schema new relationships are detected against.
+	OutputDir string
+	Approvals []RelationshipApproval
+}
+
+// RelationshipApproval approves the relationships of a service to a participant.
+type RelationshipApproval struct {
+	Service     string
+	Participant string
+	// Reference tells where the relationship was approved, e.g. an architecture decision record.
+	Reference string
+}
+
+// CustomRule is an organization-specific validation rule implemented by a WebAssembly module.
+type CustomRule struct {
+	// Name is the rule findings of the module are reported under.
+	Name FindingRule
+	// Module is the path of the WebAssembly module, a WASI command.
+	Module string
+	// Config holds the settings passed to the module with the schema.
+	Config map[string]string
+}
+
+// ProseRules configures the linting of service and relationship descriptions.
+type ProseRules struct {
+	// Dictionary holds the accepted words in lower case, spelling isn't checked when it is empty.
+	Dictionary map[string]struct{}
+	// MaxSentenceLength is the maximum number of words of a sentence, 0 for no limit.
+	MaxSentenceLength int
+	// BannedWords are words and phrases descriptions must not use.
+	BannedWords []string
+}
+
+// ValidateReply lists the inconsistencies found in the specifications.
+type ValidateReply struct {
+	// Findings are the findings missing from the baseline.
+	Findings []Finding
+	// Accepted is the number of findings of the baseline that were found again.
+	Accepted int
+	// Fixed are the findings of the baseline that weren't found again.
+	Fixed []Finding
+}
+
+// FindingRule identifies the check reporting a finding.
+type FindingRule string
+
+// Finding rules.
+const (
+	// FindingRuleMissingDLQ reports channels declaring a dead letter queue no operation uses as a channel.
+	FindingRuleMissingDLQ FindingRule = "missing-dlq"
+	// FindingRuleDuplicateDescription reports services sharing identical or nearly identical descriptions.
+	FindingRuleDuplicateDescription FindingRule = "duplicate-description"
+	// FindingRuleSpelling reports descriptions with words missing from the dictionary.
+	FindingRuleSpelling FindingRule = "spelling"
+	// FindingRuleSentenceLength reports descriptions with sentences longer than allowed.
+	FindingRuleSentenceLength FindingRule = "sentence-length"
+	// FindingRuleBannedWord reports descriptions using banned words.
+	FindingRuleBannedWord FindingRule = "banned-word"
+	// FindingRuleContradictoryLimits reports relationships whose limits exceed the ones of the participant.
+	FindingRuleContradictoryLimits FindingRule = "contradictory-limits"
+	// FindingRuleUnapprovedRelationship reports new relationships between systems without approval.
+	FindingRuleUnapprovedRelationship FindingRule = "unapproved-relationship"
+)
+
+// Finding is an inconsistency of the specifications.
+type Finding struct {
+	Rule FindingRule `json:"rule"`
+	// Subject is the service or channel the finding is about.
+	Subject string `json:"subject"`
+	Message string `json:"message"`
+}
+
+// String returns the finding as a single line.
+func (f Finding) String() string {
+	return fmt.Sprintf("%s: %s (%s)", f.Subject, f.Message, f.Rule)
+}
+
+// AsyncAPIDocument is an exported AsyncAPI document.
+type AsyncAPIDocument struct {
+	// Name is the title of the document, the system name when exporting per system.
+	Name    string
+	Content []byte
+}
+
+// WikiProvider is a wiki hosting keeping the pages of a wiki in a git repository.
+type WikiProvider string
+
+// Wiki providers.
+const (
+	WikiProviderGitLab    WikiProvider = "gitlab"
+	WikiProviderBitbucket WikiProvider = "bitbucket"
+)
+
+// WikiProviders returns all supported wiki providers.
+func WikiProviders() []WikiProvider {
+	return []WikiProvider{WikiProviderGitLab, WikiProviderBitbucket}
+}
+
+// PublishWikiRequest represents a request to publish generated documentation to a wiki repository.
+type PublishWikiRequest struct {
+	Provider WikiProvider
+	// URL is the git URL of the wiki repository, it may hold credentials.
+	URL string
+	// Branch is the branch pages are pushed to, the default branch of the repository when empty.
+	Branch string
+	// SourceDir is the directory of the generated documentation.
+	SourceDir string
+	// Dir is the wiki directory pages are published under, the wiki root when empty.
+	Dir string
+	// Pages maps page paths relative to SourceDir to wiki page names.
+	Pages       map[string]string
+	Message     string
+	AuthorName  string
+	AuthorEmail string
+	// DryRun reports the changes without committing and pushing them.
+	DryRun bool
+}
+
+// PublishWikiReply represents the result of publishing documentation to a wiki repository.
+type PublishWikiReply struct {
+	// Changes lists the changed files of the wiki repository in git status short format.
+	Changes []string
+	// Pushed is set when the changes were committed and pushed.
+	Pushed bool
+}
+
+// PreviewUploader is a way of uploading documentation previews to static hosting.
+type PreviewUploader string
+
+// Preview uploaders.
+const (
+	// PreviewUploaderCommand runs a command uploading the preview, e.g. to a bucket or a hosting CLI.
+	PreviewUploaderCommand PreviewUploader = "command"
+	// PreviewUploaderDir copies the preview into a directory served by static hosting.
+	PreviewUploaderDir PreviewUploader = "dir"
+)
+
+// PreviewUploaders returns all supported preview uploaders.
+func PreviewUploaders() []PreviewUploader {
+	return []PreviewUploader{PreviewUploaderCommand, PreviewUploaderDir}
+}
+
+// UploadPreviewRequest represents a request to upload the documentation generated for a preview.
+type UploadPreviewRequest struct {
+	Uploader PreviewUploader
+	// Dir is the directory of the generated documentation.
+	Dir string
+	// Slug identifies the preview, e.g. pr-42, previews are served below their slugs.
+	Slug string
+	// Command is the command of the command uploader, {dir} and {slug} are replaced in its arguments.
+	Command string
+	// TargetDir is the directory the dir uploader copies previews into.
+	TargetDir string
+	// BaseURL is the URL previews are served from.
+	BaseURL string
+}
+
+// UploadPreviewReply represents the result of uploading a preview.
+type UploadPreviewReply struct {
+	// URL is where the preview is served, empty when it isn't known.
+	URL string
+}
+
+// OnCallProvider is an incident response service paging the teams owning services.
+type OnCallProvider string
+
+// On-call providers.
+const (
+	OnCallProviderPagerDuty OnCallProvider = "pagerduty"
+	OnCallProviderOpsgenie  OnCallProvider = "opsgenie"
+)
+
+// OnCallProviders returns all supported on-call providers.
+func OnCallProviders() []OnCallProvider {
+	return []OnCallProvider{OnCallProviderPagerDuty, OnCallProviderOpsgenie}
+}
+
+// Title returns the product name of the provider, e.g. PagerDuty.
+func (p OnCallProvider) Title() string {
+	switch p {
+	case OnCallProviderPagerDuty:
+		return "PagerDuty"
+	case OnCallProviderOpsgenie:
+		return "Opsgenie"
+	default:
+		return string(p)
+	}
+}
+
+// LookupOnCallRequest represents a request to look up the escalation policy of a service.
+type LookupOnCallRequest struct {
+	Provider OnCallProvider
+	// BaseURL is the URL of the provider API, the public API of the provider when empty.
+	BaseURL string
+	Token   string
+	// ID is the PagerDuty service ID or the Opsgenie team name the service is mapped to.
+	ID      string
+	Timeout time.Duration
+}
+
+// OnCall is the escalation policy paging the owners of a service, as currently set up at the provider.
+type OnCall struct {
+	Provider            OnCallProvider
+	EscalationPolicy    string
+	EscalationPolicyURL string
+	// URL links the service or the team at the provider.
+	URL string
+}
+
+// NotificationType is the message format of the webhooks notifications are posted to.
+type NotificationType string
+
+// Notification types.
+const (
+	NotificationTypeSlack      NotificationType = "slack"
+	NotificationTypeTeams      NotificationType = "teams"
+	NotificationTypeMattermost NotificationType = "mattermost"
+)
+
+// NotificationTypes returns all supported notification types.
+func NotificationTypes() []NotificationType {
+	return []NotificationType{NotificationTypeSlack, NotificationTypeTeams, NotificationTypeMattermost}
+}
+
+// NotifyRequest represents a request to post the changes affecting a subscriber to its webhook.
+type NotifyRequest struct {
+	Type    NotificationType
+	Webhook string
+	// Subscriber is the owner the changes were selected for.
+	Subscriber string
+	Date       time.Time
+	Changes    []Change
+	Timeout    time.Duration
+}
+
+// CheckStatusPageRequest represents a request to read the current status of a third-party dependency.
+type CheckStatusPageRequest struct {
+	// URL is the status page, or its status JSON endpoint.
+	URL     string
+	Timeout time.Duration
+}
+
+// DependencyStatus is the current status of a third-party dependency as reported by its status page.
+type DependencyStatus struct {
+	// Indicator is the severity of the status: none, minor, major, critical or maintenance.
+	Indicator string
+	// Description summarizes the status, e.g. All Systems Operational.
+	Description string
+}
+
+// QueryMetricRequest represents a request to evaluate a PromQL query resulting in a single value.
+type QueryMetricRequest struct {
+	// URL is the base URL of the Prometheus HTTP API.
+	URL     string
+	Token   string
+	Query   string
+	Timeout time.Duration
+}
+
+// RelationshipMetrics are the metrics observed for the relationship of a service with a participant,
+// along with the objectives they are held against.
+type RelationshipMetrics struct {
+	Service     string
+	Participant string
+	// P95Latency is the observed 95th percentile latency, nil without data.
+	P95Latency *time.Duration
+	// ErrorRate is the observed ratio of failed requests from 0 to 1, nil without data.
+	ErrorRate *float64
+	// LatencySLO is the p95 latency objective, zero without one.
+	LatencySLO time.Duration
+	// ErrorRateSLO is the error rate objective, zero without one.
+	ErrorRateSLO float64
+}
+
+// Breached reports whether an observed metric exceeds its objective.
+func (m RelationshipMetrics) Breached() bool {
+	if m.P95Latency != nil && m.LatencySLO > 0 && *m.P95Latency > m.LatencySLO {
+		return true
+	}
+
+	return m.ErrorRate != nil && m.ErrorRateSLO > 0 && *m.ErrorRate > m.ErrorRateSLO
+}
+
+// PluginKind is what a plugin extends holydocs with.
+type PluginKind string
+
+// Plugin kinds.
+const (
+	// PluginKindSource provides specifications, stored as sources like the ones of the ingest command.
+	PluginKindSource PluginKind = "source"
+	// PluginKindTarget writes further outputs of the merged schema into the output directory.
+	PluginKindTarget PluginKind = "target"
+	// PluginKindPublisher publishes the generated documentation.
+	PluginKindPublisher PluginKind = "publisher"
+)
+
+// PluginKinds returns all supported plugin kinds.
+func PluginKinds() []PluginKind {
+	return []PluginKind{PluginKindSource, PluginKindTarget, PluginKindPublisher}
+}
+
+// Plugin is an external program extending holydocs, it speaks the plugin protocol over its standard input
+// and output.
+type Plugin struct {
+	Name string
+	Kind PluginKind
+	// Command starts the plugin, it is split into arguments at spaces.
+	Command string
+	// Config holds the settings passed to the plugin with every request.
+	Config map[string]string
+}
+
+// RunPluginRequest represents a request to run a plugin.
+type RunPluginRequest struct {
+	Plugin Plugin
+	// Schema is the merged schema, sent to target plugins.
+	Schema *Schema
+	// OutputDir is the directory of the generated documentation, sent to target and publisher plugins.
+	OutputDir string
+}
+
+// RunPluginReply represents the answer of a plugin.
+type RunPluginReply struct {
+	// Sources are the specifications provided by a source plugin.
+	Sources []PluginSource
+	// Files are the files a target plugin wrote, relative to the output directory.
+	Files []string
+	// Changes describe what a publisher plugin published.
+	Changes []string
+	// URL is where a publisher plugin published the documentation, when it is known.
+	URL      string
+	Warnings []string
+}
+
+// PluginSource is a specification provided by a source plugin.
+type PluginSource struct {
+	Name    string
+	Content []byte
+}
+
+// IngestSourceRequest represents a request to store a pushed specification as a source.
+type IngestSourceRequest struct {
+	Name    string
+	Content []byte
+	// TTL overrides the configured time to live of the source when positive.
+	TTL time.Duration
+}
+
+// MessageFlowSetup holds the message flow schema and target.
+type MessageFlowSetup struct {
+	Schema messageflow.Schema
+	Target messageflow.Target
+}
+
+// Sort orders services, relationships, and operations for deterministic output.
+func (s *Schema) Sort() {
+	// Elements are compared through pointers, copying large structs dominates sorting big schemas otherwise.
+	for i := range s.Services {
+		sort.Slice(s.Services[i].Relationships, func(j, k int) bool {
+			rel1 := &s.Services[i].Relationships[j]
+			rel2 := &s.Services[i].Relationships[k]
+
+			if rel1.Action != rel2.Action {
+				return rel1.Action < rel2.Action
+			}
+
+			if rel1.Participant != rel2.Participant {
+				return rel1.Participant < rel2.Participant
+			}
+
+			return rel1.Technology < rel2.Technology
+		})
+
+		sort.Slice(s.Services[i].Operation, func(j, k int) bool {
+			op1 := &s.Services[i].Operation[j]
+			op2 := &s.Services[i].Operation[k]
+
+			if op1.Action != op2.Action {
+				return op1.Action < op2.Action
+			}
+
+			return op1.Channel.Name < op2.Channel.Name
+		})
+
+		sort.Slice(s.Services[i].Endpoints, func(j, k int) bool {
+			e1 := &s.Services[i].Endpoints[j]
+			e2 := &s.Services[i].Endpoints[k]
+
+			if e1.Path != e2.Path {
+				return e1.Path < e2.Path
+			}
+
+			return e1.Method < e2.Method
+		})
+	}
+
+	sort.Slice(s.Services, func(i, j int) bool {
+		return s.Services[i].Info.Name < s.Services[j].Info.Name
+	})
+}
+
+// Coverage measures how many services document their owner and description. Blank values count as missing.
+func (s Schema) Coverage() Coverage {
+	coverage := Coverage{Services: len(s.Services)}
+
+	for _, service := range s.Services {
+		if strings.TrimSpace(service.Info.Owner) == "" {
+			coverage.MissingOwners = append(coverage.MissingOwners, service.Info.Name)
+		}
+
+		if strings.TrimSpace(service.Info.Description) == "" {
+			coverage.MissingDescriptions = append(coverage.MissingDescriptions, service.Info.Name)
+		}
+	}
+
+	sort.Strings(coverage.MissingOwners)
+	sort.Strings(coverage.MissingDescriptions)
+
+	return coverage
+}
+
+// CanonicalizeTechnologies replaces the technologies of relationships by their canonical names, given with the
+// names and glob patterns collapsed into them, and merges the relationships of a service that become the same.
+// Names and patterns are matched case-insensitively, technologies matching none are kept as they are.
+func (s Schema) CanonicalizeTechnologies(technologies map[string][]string) Schema {
+	if len(technologies) == 0 {
+		return s
+	}
+
+	names := slices.Sorted(maps.Keys(technologies))
+
+	return s.mapRelationships(func(rel Relationship) Relationship {
+		rel.Technology = canonicalTechnology(rel.Technology, names, technologies)
+
+		return rel
+	})
+}
+
+// AliasExternalParticipants replaces the participants of external relationships by their canonical names,
+// given with the aliases merged into them, and merges the relationships of a service that become the same.
+// Aliases are matched case-insensitively, relationships toward services are never renamed.
+func (s Schema) AliasExternalParticipants(aliases map[string][]string) Schema {
+	if len(aliases) == 0 {
+		return s
+	}
+
+	canonical := make(map[string]string)
+	for name, names := range aliases {
+		for _, alias := range names {
+			canonical[strings.ToLower(strings.TrimSpace(alias))] = name
+		}
+	}
+
+	return s.mapRelationships(func(rel Relationship) Relationship {
+		if name, ok := canonical[strings.ToLower(strings.TrimSpace(rel.Participant))]; ok && rel.External {
+			rel.Participant = name
+		}
+
+		return rel
+	})
+}
+
+// mapRelationships returns a copy of the schema with the relationships replaced by fn, merging the relationships
+// of a service that become the same.
+func (s Schema) mapRelationships(fn func(Relationship) Relationship) Schema {
+	services := make([]Service, 0, len(s.Services))
+
+	for _, service := range s.Services {
+		relationships := make([]Relationship, 0, len(service.Relationships))
+		for _, rel := range service.Relationships {
+			relationships = append(relationships, fn(rel))
+		}
+
+		merger := &serviceMerger{service: service}
+		merger.service.Relationships = nil
+		merger.mergeRelationships(relationships)
+		services = append(services, normalizeService(merger.service))
+	}
+
+	schema := Schema{Services: services}
+	schema.Sort()
+
+	return schema
+}
+
+func canonicalTechnology(technology string, names []string, technologies map[string][]string) string {
+	key := strings.ToLower(strings.TrimSpace(technology))
+	if key == "" {
+		return technology
+	}
+
+	for _, name := range names {
+		for _, pattern := range append([]string{name}, technologies[name]...) {
+			if matched, _ := path.Match(strings.ToLower(strings.TrimSpace(pattern)), key); matched {
+				return name
+			}
+		}
+	}
+
+	return technology
+}
+
+// ResolveNamespaces qualifies the participants of relationships naming namespaced services by their local
+// names. Services of the same namespace take precedence, any other service only when no other namespace has
+// a service of that name.
+func (s *Schema) ResolveNamespaces() {
+	qualified := make(map[string][]string)
+	known := make(map[string]bool, len(s.Services))
+
+	for _, service := range s.Services {
+		known[service.Info.Name] = true
+		if service.Info.Namespace != "" {
+			local := service.Info.LocalName()
+			qualified[local] = append(qualified[local], service.Info.Name)
+		}
+	}
+
+	if len(qualified) == 0 {
+		return
+	}
+
+	for i := range s.Services {
+		namespace := s.Services[i].Info.Namespace
+
+		for j := range s.Services[i].Relationships {
+			rel := &s.Services[i].Relationships[j]
+
+			switch name := QualifiedName(namespace, rel.Participant); {
+			case namespace != "" && known[name]:
+				rel.Participant = name
+			case known[rel.Participant]:
+				// Names a service without a namespace or is qualified already.
+			case len(qualified[rel.Participant]) == 1:
+				rel.Participant = qualified[rel.Participant][0]
+			}
+		}
+	}
 }
 
-// MessageFlowSetup holds the message flow schema and target.
-type MessageFlowSetup struct {
-	Schema messageflow.Schema
-	Target messageflow.Target
-}
+// FilterNamespaces keeps the services of the given namespaces and the ones without a namespace, which are
+// shared by all of them. Nothing is filtered when no namespace is given.
+func (s Schema) FilterNamespaces(namespaces []string) Schema {
+	if len(namespaces) == 0 {
+		return s
+	}
 
-// Sort orders services, relationships, and operations for deterministic output.
-func (s *Schema) Sort() {
-	for i := range s.Services {
-		sort.Slice(s.Services[i].Relationships, func(j, k int) bool {
-			rel1 := s.Services[i].Relationships[j]
-			rel2 := s.Services[i].Relationships[k]
+	services := make([]Service, 0, len(s.Services))
 
-			if rel1.Action != rel2.Action {
-				return rel1.Action < rel2.Action
-			}
+	for _, service := range s.Services {
+		if service.Info.Namespace == "" || slices.Contains(namespaces, service.Info.Namespace) {
+			services = append(services, service)
+		}
+	}
 
-			if rel1.Participant != rel2.Participant {
-				return rel1.Participant < rel2.Participant
-			}
+	s.Services = services
 
-			return rel1.Technology < rel2.Technology
-		})
+	return s
+}
 
-		sort.Slice(s.Services[i].Operation, func(j, k int) bool {
-			op1 := s.Services[i].Operation[j]
-			op2 := s.Services[i].Operation[k]
+// BusinessCapabilities returns the business capabilities of the services, sorted.
+func (s Schema) BusinessCapabilities() []string {
+	capabilities := make(map[string]struct{})
 
-			if op1.Action != op2.Action {
-				return op1.Action < op2.Action
-			}
+	for _, service := range s.Services {
+		if capability := strings.TrimSpace(service.Info.BusinessCapability); capability != "" {
+			capabilities[capability] = struct{}{}
+		}
+	}
 
-			return op1.Channel.Name < op2.Channel.Name
-		})
+	return slices.Sorted(maps.Keys(capabilities))
+}
+
+// ByBusinessCapability returns a copy of the schema with the services grouped by business capability instead
+// of system, so system diagrams draw capabilities. Services without a business capability have no system
+// then, and capabilities aren't bounded contexts.
+func (s Schema) ByBusinessCapability() Schema {
+	services := make([]Service, len(s.Services))
+
+	for i, service := range s.Services {
+		service.Info.System = strings.TrimSpace(service.Info.BusinessCapability)
+		service.Info.BoundedContext = false
+		services[i] = service
 	}
 
-	sort.Slice(s.Services, func(i, j int) bool {
-		return s.Services[i].Info.Name < s.Services[j].Info.Name
-	})
+	s.Services = services
+
+	return s
 }
 
 // Merge merges the schema with additional schemas and returns a new schema.
@@ -240,8 +1893,8 @@ func (s Schema) Compare(other Schema) Changelog {
 	changes := []Change{}
 	now := time.Now()
 
-	oldServices := make(map[string]Service)
-	newServices := make(map[string]Service)
+	oldServices := make(map[string]Service, len(s.Services))
+	newServices := make(map[string]Service, len(other.Services))
 
 	for _, service := range s.Services {
 		oldServices[service.Info.Name] = service
@@ -288,32 +1941,43 @@ func (s Schema) Compare(other Schema) Changelog {
 }
 
 func mergeSchemas(schemas ...Schema) Schema {
-	if len(schemas) == 0 {
+	declarations := make([]Declaration, 0, len(schemas))
+	for _, schema := range schemas {
+		declarations = append(declarations, Declaration{Schema: schema})
+	}
+
+	return MergeDeclarations(declarations, nil)
+}
+
+// MergeDeclarations combines the schemas of the declarations into a single schema, choosing between values
+// of fields declared by several sources by their precedence.
+func MergeDeclarations(declarations []Declaration, precedence Precedence) Schema {
+	if len(declarations) == 0 {
 		return Schema{Services: []Service{}}
 	}
 
-	serviceMap := make(map[string]Service)
+	mergers := make(map[string]*serviceMerger)
 
-	for _, schema := range schemas {
-		for _, service := range schema.Services {
+	for _, declaration := range declarations {
+		for _, service := range declaration.Schema.Services {
 			name := strings.TrimSpace(service.Info.Name)
 			if name == "" {
 				continue
 			}
 
-			if existingService, exists := serviceMap[name]; exists {
-				serviceMap[name] = mergeServices(existingService, service)
+			if merger, exists := mergers[name]; exists {
+				merger.merge(service, declaration.Kind)
 
 				continue
 			}
 
-			serviceMap[name] = service
+			mergers[name] = newServiceMerger(service, declaration.Kind, precedence)
 		}
 	}
 
-	mergedServices := make([]Service, 0, len(serviceMap))
-	for _, service := range serviceMap {
-		mergedServices = append(mergedServices, normalizeService(service))
+	mergedServices := make([]Service, 0, len(mergers))
+	for _, merger := range mergers {
+		mergedServices = append(mergedServices, normalizeService(merger.service))
 	}
 
 	result := Schema{Services: mergedServices}
@@ -322,6 +1986,347 @@ func mergeSchemas(schemas ...Schema) Schema {
 	return result
 }
 
+// serviceMerger accumulates all declarations of a service. Relationships and operations are indexed by their
+// signature when the service is declared again, and the index is kept for further declarations, so merging
+// a declaration costs its own size instead of re-indexing everything merged before.
+type serviceMerger struct {
+	service       Service
+	relationships map[string]int
+	operations    map[string]int
+	precedence    Precedence
+	// kinds are the source types of the current values of the fields with a precedence.
+	kinds map[string]SourceKind
+}
+
+func newServiceMerger(service Service, kind SourceKind, precedence Precedence) *serviceMerger {
+	m := &serviceMerger{service: service, precedence: precedence}
+
+	if len(precedence) > 0 {
+		m.kinds = make(map[string]SourceKind, len(precedence))
+
+		for field := range precedence {
+			if value, ok := precedenceFields[field]; ok && strings.TrimSpace(*value(&service.Info)) != "" {
+				m.kinds[field] = kind
+			}
+		}
+	}
+
+	return m
+}
+
+func (m *serviceMerger) merge(incoming Service, kind SourceKind) {
+	current := m.service.Info
+	m.service.Info = mergeServiceInfo(current, incoming.Info)
+	m.applyPrecedence(current, incoming.Info, kind)
+	m.mergeRelationships(incoming.Relationships)
+	m.mergeOperations(incoming.Operation)
+	m.service.Endpoints = slices.Concat(m.service.Endpoints, incoming.Endpoints)
+}
+
+// applyPrecedence corrects the merged values of the fields with a precedence: the value of the source type
+// ranking first wins, the default rules only decide between values of source types of the same rank.
+func (m *serviceMerger) applyPrecedence(current, incoming ServiceInfo, kind SourceKind) {
+	for field := range m.precedence {
+		value, ok := precedenceFields[field]
+		if !ok {
+			continue
+		}
+
+		currentValue, incomingValue := *value(&current), *value(&incoming)
+
+		switch {
+		case strings.TrimSpace(incomingValue) == "":
+		case strings.TrimSpace(currentValue) == "":
+			m.kinds[field] = kind
+		case m.precedence.rank(field, kind) < m.precedence.rank(field, m.kinds[field]):
+			*value(&m.service.Info) = incomingValue
+			m.kinds[field] = kind
+		case m.precedence.rank(field, kind) > m.precedence.rank(field, m.kinds[field]):
+			*value(&m.service.Info) = currentValue
+		case *value(&m.service.Info) == incomingValue:
+			m.kinds[field] = kind
+		}
+	}
+}
+
+func (m *serviceMerger) mergeRelationships(incoming []Relationship) {
+	if len(incoming) == 0 {
+		return
+	}
+
+	if m.relationships == nil {
+		m.service.Relationships, m.relationships = indexBySignature(m.service.Relationships, relationshipSignature)
+	}
+
+	for _, rel := range incoming {
+		key := relationshipSignature(rel)
+		if i, ok := m.relationships[key]; ok {
+			m.service.Relationships[i] = mergeRelationship(m.service.Relationships[i], rel)
+
+			continue
+		}
+
+		m.relationships[key] = len(m.service.Relationships)
+		m.service.Relationships = append(m.service.Relationships, rel)
+	}
+}
+
+func (m *serviceMerger) mergeOperations(incoming []Operation) {
+	if len(incoming) == 0 {
+		return
+	}
+
+	if m.operations == nil {
+		m.service.Operation, m.operations = indexBySignature(m.service.Operation, operationSignature)
+	}
+
+	for _, op := range incoming {
+		key := operationSignature(op)
+		if i, ok := m.operations[key]; ok {
+			m.service.Operation[i] = mergeOperation(m.service.Operation[i], op)
+
+			continue
+		}
+
+		m.operations[key] = len(m.service.Operation)
+		m.service.Operation = append(m.service.Operation, op)
+	}
+}
+
+// indexBySignature returns a copy of items without duplicates, the last of duplicates wins, together with
+// the positions of the items by signature. The copy keeps the declarations passed to merging untouched.
+func indexBySignature[T any](items []T, signature func(T) string) ([]T, map[string]int) {
+	unique := make([]T, 0, len(items))
+	index := make(map[string]int, len(items))
+
+	for _, item := range items {
+		key := signature(item)
+		if i, ok := index[key]; ok {
+			unique[i] = item
+
+			continue
+		}
+
+		index[key] = len(unique)
+		unique = append(unique, item)
+	}
+
+	return unique, index
+}
+
+// ExplainMerge replays the merge of the declarations in their order and tells, for every merged service,
+// which source each field was taken from and which values of other sources it overrode.
+func ExplainMerge(declarations []Declaration, precedence Precedence) []ServiceProvenance {
+	var names []string
+
+	declared := make(map[string][]declaredService)
+
+	for _, declaration := range declarations {
+		for _, service := range declaration.Schema.Services {
+			name := strings.TrimSpace(service.Info.Name)
+			if name == "" {
+				continue
+			}
+
+			if _, ok := declared[name]; !ok {
+				names = append(names, name)
+			}
+
+			declared[name] = append(declared[name],
+				declaredService{source: declaration.Source, kind: declaration.Kind, service: service})
+		}
+	}
+
+	sort.Strings(names)
+
+	provenance := make([]ServiceProvenance, 0, len(names))
+	for _, name := range names {
+		provenance = append(provenance, explainService(name, declared[name], precedence))
+	}
+
+	return provenance
+}
+
+type declaredService struct {
+	source  string
+	kind    SourceKind
+	service Service
+}
+
+type declaredValue[T any] struct {
+	source string
+	value  T
+}
+
+// provenanceField reads a field of a declaration as text, empty when the declaration leaves it out.
+type provenanceField[T any] struct {
+	name  string
+	value func(T) string
+}
+
+func boolField(set bool) string {
+	if set {
+		return "true"
+	}
+
+	return ""
+}
+
+//nolint:gochecknoglobals // Fields are read the same way for every explanation.
+var serviceInfoFields = []provenanceField[ServiceInfo]{
+	{"description", func(i ServiceInfo) string { return i.Description }},
+	{"system", func(i ServiceInfo) string { return i.System }},
+	{"business_capability", func(i ServiceInfo) string { return i.BusinessCapability }},
+	{"owner", func(i ServiceInfo) string { return i.Owner }},
+	{"repository", func(i ServiceInfo) string { return i.Repository }},
+	{"subpath", func(i ServiceInfo) string { return i.Subpath }},
+	{"namespace", func(i ServiceInfo) string { return i.Namespace }},
+	{"classification", func(i ServiceInfo) string { return i.Classification }},
+	{"planned", func(i ServiceInfo) string { return boolField(i.Planned) }},
+	{"deprecated", func(i ServiceInfo) string { return boolField(i.Deprecated) }},
+	{"sunset_date", func(i ServiceInfo) string { return i.SunsetDate }},
+	{"bounded_context", func(i ServiceInfo) string { return boolField(i.BoundedContext) }},
+}
+
+//nolint:gochecknoglobals // Fields are read the same way for every explanation.
+var relationshipFields = []provenanceField[Relationship]{
+	{"description", func(r Relationship) string { return r.Description }},
+	{"notes", func(r Relationship) string { return r.Notes }},
+	{"external", func(r Relationship) string { return boolField(r.External) }},
+	{"planned", func(r Relationship) string { return boolField(r.Planned) }},
+	{"criticality", func(r Relationship) string { return string(r.Criticality) }},
+	{"access", func(r Relationship) string { return string(r.Access) }},
+	{"approval", func(r Relationship) string { return r.Approval }},
+}
+
+func explainService(name string, declarations []declaredService, precedence Precedence) ServiceProvenance {
+	merger := newServiceMerger(declarations[0].service, declarations[0].kind, precedence)
+	for _, declaration := range declarations[1:] {
+		merger.merge(declaration.service, declaration.kind)
+	}
+
+	schema := Schema{Services: []Service{normalizeService(merger.service)}}
+	schema.Sort()
+	merged := schema.Services[0]
+
+	infos := make([]declaredValue[ServiceInfo], 0, len(declarations))
+	tags := make(map[string][]string)
+	relationships := make(map[string][]declaredValue[Relationship])
+	operations := make(map[string][]string)
+
+	var sources []string
+
+	for _, declaration := range declarations {
+		sources = appendUnique(sources, declaration.source)
+		infos = append(infos, declaredValue[ServiceInfo]{source: declaration.source, value: declaration.service.Info})
+
+		for _, tag := range declaration.service.Info.Tags {
+			tag = strings.TrimSpace(tag)
+			tags[tag] = appendUnique(tags[tag], declaration.source)
+		}
+
+		for _, rel := range declaration.service.Relationships {
+			key := relationshipSignature(rel)
+			relationships[key] = append(relationships[key],
+				declaredValue[Relationship]{source: declaration.source, value: rel})
+		}
+
+		for _, op := range declaration.service.Operation {
+			key := operationSignature(op)
+			operations[key] = appendUnique(operations[key], declaration.source)
+		}
+	}
+
+	provenance := ServiceProvenance{
+		Service: name,
+		Sources: sources,
+		Fields:  explainFields(serviceInfoFields, merged.Info, infos),
+	}
+
+	for _, tag := range merged.Info.Tags {
+		provenance.Tags = append(provenance.Tags, ItemProvenance{Item: tag, Sources: tags[tag]})
+	}
+
+	for _, rel := range merged.Relationships {
+		declared := relationships[relationshipSignature(rel)]
+
+		var relSources []string
+		for _, declaration := range declared {
+			relSources = appendUnique(relSources, declaration.source)
+		}
+
+		provenance.Relationships = append(provenance.Relationships, ItemProvenance{
+			Item:    relationshipLabel(rel),
+			Sources: relSources,
+			Fields:  explainFields(relationshipFields, rel, declared),
+		})
+	}
+
+	for _, op := range merged.Operation {
+		provenance.Operations = append(provenance.Operations, ItemProvenance{
+			Item:    string(op.Action) + " " + op.Channel.Name,
+			Sources: operations[operationSignature(op)],
+		})
+	}
+
+	return provenance
+}
+
+// explainFields tells for every field set in merged which declaration set the merged value first and which
+// different values other declarations set.
+func explainFields[T any](fields []provenanceField[T], merged T, declared []declaredValue[T]) []FieldProvenance {
+	var provenance []FieldProvenance
+
+	for _, field := range fields {
+		value := strings.TrimSpace(field.value(merged))
+		if value == "" {
+			continue
+		}
+
+		explained := FieldProvenance{Field: field.name, Value: value}
+
+		for _, declaration := range declared {
+			candidate := strings.TrimSpace(field.value(declaration.value))
+
+			switch {
+			case candidate == "":
+			case candidate == value:
+				if explained.Source == "" {
+					explained.Source = declaration.source
+				}
+			case !slices.Contains(explained.Overridden, SourcedValue{Value: candidate, Source: declaration.source}):
+				explained.Overridden = append(explained.Overridden,
+					SourcedValue{Value: candidate, Source: declaration.source})
+			}
+		}
+
+		provenance = append(provenance, explained)
+	}
+
+	return provenance
+}
+
+func relationshipLabel(rel Relationship) string {
+	label := string(rel.Action) + " " + rel.Participant
+	if rel.Technology != "" {
+		label += " via " + rel.Technology
+	}
+
+	if rel.Proto != "" {
+		label += " (" + rel.Proto + ")"
+	}
+
+	return label
+}
+
+func appendUnique(values []string, value string) []string {
+	if slices.Contains(values, value) {
+		return values
+	}
+
+	return append(values, value)
+}
+
 func normalizeService(s Service) Service {
 	if len(s.Info.Tags) > 0 {
 		s.Info.Tags = uniqueStrings(s.Info.Tags)
@@ -336,17 +2341,12 @@ func normalizeService(s Service) Service {
 	return s
 }
 
-func mergeServices(base, incoming Service) Service {
-	merged := base
-
-	merged.Info = mergeServiceInfo(base.Info, incoming.Info)
-	merged.Relationships = mergeRelationships(base.Relationships, incoming.Relationships)
-	merged.Operation = mergeOperations(base.Operation, incoming.Operation)
-
-	return merged
-}
-
 func mergeServiceInfo(base, incoming ServiceInfo) ServiceInfo {
+	// Actual specifications win over planned ones, the service is only planned when all declarations are.
+	if base.Planned && !incoming.Planned {
+		base, incoming = incoming, base
+	}
+
 	merged := base
 
 	if merged.Name == "" {
@@ -359,6 +2359,10 @@ func mergeServiceInfo(base, incoming ServiceInfo) ServiceInfo {
 		merged.System = incoming.System
 	}
 
+	if merged.BusinessCapability == "" {
+		merged.BusinessCapability = incoming.BusinessCapability
+	}
+
 	if merged.Owner == "" {
 		merged.Owner = incoming.Owner
 	}
@@ -367,92 +2371,113 @@ func mergeServiceInfo(base, incoming ServiceInfo) ServiceInfo {
 		merged.Repository = incoming.Repository
 	}
 
+	if merged.Subpath == "" {
+		merged.Subpath = incoming.Subpath
+	}
+
 	if len(incoming.Tags) > 0 {
-		merged.Tags = append(merged.Tags, incoming.Tags...)
+		merged.Tags = append(slices.Clip(merged.Tags), incoming.Tags...)
 	}
 
-	return merged
-}
+	if incoming.Deprecated {
+		merged.Deprecated = true
+	}
 
-func mergeRelationships(existing, incoming []Relationship) []Relationship {
-	if len(incoming) == 0 {
-		return existing
+	if merged.SunsetDate == "" {
+		merged.SunsetDate = incoming.SunsetDate
 	}
 
-	relMap := make(map[string]Relationship, len(existing)+len(incoming))
+	if incoming.BoundedContext {
+		merged.BoundedContext = true
+	}
 
-	for _, rel := range existing {
-		key := relationshipSignature(rel)
-		relMap[key] = rel
+	if merged.Classification == "" {
+		merged.Classification = incoming.Classification
 	}
 
-	for _, rel := range incoming {
-		key := relationshipSignature(rel)
-		if current, ok := relMap[key]; ok {
-			updated := current
-			updated.Description = chooseMoreInformative(rel.Description, current.Description)
-			if rel.Technology != "" {
-				updated.Technology = rel.Technology
-			}
-			if rel.Proto != "" {
-				updated.Proto = rel.Proto
-			}
-			if rel.External {
-				updated.External = true
-			}
-			if len(rel.Tags) > 0 {
-				updated.Tags = append(updated.Tags, rel.Tags...)
-			}
-			relMap[key] = updated
+	if merged.Namespace == "" {
+		merged.Namespace = incoming.Namespace
+	}
 
-			continue
-		}
+	merged.Risks = mergeRisks(slices.Clip(merged.Risks), incoming.Risks)
 
-		relMap[key] = rel
+	if merged.Limits == nil {
+		merged.Limits = incoming.Limits
 	}
 
-	merged := make([]Relationship, 0, len(relMap))
-	for _, rel := range relMap {
-		merged = append(merged, rel)
-	}
+	merged.Deployments = mergeDeployments(slices.Clip(merged.Deployments), incoming.Deployments)
 
 	return merged
 }
 
-func mergeOperations(existing, incoming []Operation) []Operation {
-	if len(incoming) == 0 {
-		return existing
+// mergeRelationship merges a declaration into a relationship with the same signature.
+// Slices are clipped before appending, so the declarations passed to merging are never modified.
+func mergeRelationship(current, rel Relationship) Relationship {
+	// Actual specifications win over planned ones, the relationship is only planned when all declarations are.
+	if current.Planned && !rel.Planned {
+		current, rel = rel, current
 	}
 
-	opMap := make(map[string]Operation, len(existing)+len(incoming))
-
-	for _, op := range existing {
-		key := operationSignature(op)
-		opMap[key] = op
+	updated := current
+	updated.Planned = current.Planned && rel.Planned
+	updated.Description = chooseMoreInformative(rel.Description, current.Description)
+	updated.Notes = chooseMoreInformative(rel.Notes, current.Notes)
+	if rel.Technology != "" {
+		updated.Technology = rel.Technology
+	}
+	if rel.Proto != "" {
+		updated.Proto = rel.Proto
+	}
+	if rel.External {
+		updated.External = true
+	}
+	if updated.Approval == "" {
+		updated.Approval = rel.Approval
+	}
+	updated.Criticality = MaxCriticality(updated.Criticality, rel.Criticality)
+	updated.Access = CombineAccess(updated.Access, rel.Access)
+	if len(rel.Tags) > 0 {
+		updated.Tags = append(slices.Clip(updated.Tags), rel.Tags...)
+	}
+	updated.DDDPatterns = mergeDDDPatterns(slices.Clip(updated.DDDPatterns), rel.DDDPatterns)
+	updated.Risks = mergeRisks(slices.Clip(updated.Risks), rel.Risks)
+	if updated.Limits == nil {
+		updated.Limits = rel.Limits
 	}
 
-	for _, op := range incoming {
-		key := operationSignature(op)
-		if current, ok := opMap[key]; ok {
-			updated := current
-			if updated.Reply == nil && op.Reply != nil {
-				reply := *op.Reply
-				updated.Reply = &reply
-			}
-			opMap[key] = updated
+	return updated
+}
 
-			continue
+func mergeDDDPatterns(existing, incoming []DDDPattern) []DDDPattern {
+	for _, pattern := range incoming {
+		if !slices.Contains(existing, pattern) {
+			existing = append(existing, pattern)
 		}
+	}
 
-		opMap[key] = op
+	return existing
+}
+
+// mergeOperation merges a declaration into an operation with the same signature.
+func mergeOperation(current, op Operation) Operation {
+	updated := current
+	if updated.Reply == nil && op.Reply != nil {
+		reply := *op.Reply
+		updated.Reply = &reply
 	}
 
-	merged := make([]Operation, 0, len(opMap))
-	for _, op := range opMap {
-		merged = append(merged, op)
+	if updated.Channel.SLA == nil && op.Channel.SLA != nil {
+		sla := *op.Channel.SLA
+		updated.Channel.SLA = &sla
 	}
 
-	return merged
+	if updated.Reply != nil && updated.Reply.SLA == nil && op.Reply != nil && op.Reply.SLA != nil {
+		reply, sla := *updated.Reply, *op.Reply.SLA
+		reply.SLA = &sla
+		updated.Reply = &reply
+	}
+
+	return updated
 }
 
 func chooseMoreInformative(candidate, current string) string {
@@ -498,7 +2523,7 @@ func uniqueStrings(values []string) []string {
 }
 
 func relationshipSignature(rel Relationship) string {
-	return fmt.Sprintf("%s|%s|%s|%s", rel.Action, rel.Participant, rel.Technology, rel.Proto)
+	return relationshipKey(rel)
 }
 
 func operationSignature(op Operation) string {
@@ -507,14 +2532,13 @@ func operationSignature(op Operation) string {
 		replyName = op.Reply.Name
 	}
 
-	return fmt.Sprintf(
-		"%s|%s|%s|%s|%s",
-		op.Action,
+	return strings.Join([]string{
+		string(op.Action),
 		op.Channel.Name,
 		op.Channel.Message.Name,
 		op.Channel.Message.Payload,
 		replyName,
-	)
+	}, "|")
 }
 
 func compareServiceRelationships(oldService, newService Service, timestamp time.Time) []Change {
@@ -530,7 +2554,7 @@ func compareServiceRelationships(oldService, newService Service, timestamp time.
 }
 
 func buildRelationshipMap(relationships []Relationship) map[string]Relationship {
-	relMap := make(map[string]Relationship)
+	relMap := make(map[string]Relationship, len(relationships))
 	for _, rel := range relationships {
 		key := relationshipKey(rel)
 		relMap[key] = rel
@@ -608,7 +2632,7 @@ func findChangedRelationship(
 }
 
 func relationshipKey(rel Relationship) string {
-	return fmt.Sprintf("%s|%s|%s|%s", rel.Action, rel.Participant, rel.Technology, rel.Proto)
+	return string(rel.Action) + "|" + rel.Participant + "|" + rel.Technology + "|" + rel.Proto
 }
 
 // RelationshipKey returns the deterministic key for a relationship.
@@ -629,7 +2653,7 @@ func compareServiceOperations(oldService, newService Service, timestamp time.Tim
 }
 
 func buildOperationMap(operations []Operation) map[string]Operation {
-	opMap := make(map[string]Operation)
+	opMap := make(map[string]Operation, len(operations))
 	for _, op := range operations {
 		key := operationKey(op)
 		opMap[key] = op
@@ -696,7 +2720,7 @@ func findRemovedAndChangedOperations(
 }
 
 func operationKey(op Operation) string {
-	key := fmt.Sprintf("%s:%s", op.Action, op.Channel.Name)
+	key := string(op.Action) + ":" + op.Channel.Name
 	if op.Reply != nil {
 		key += ":" + op.Reply.Name
 	}
@@ -708,3 +2732,49 @@ func operationKey(op Operation) string {
 func OperationKey(op Operation) string {
 	return operationKey(op)
 }
+
+// UniqueKeys derives a key, such as a file name or a diagram node ID, from every name and keeps the keys of
+// different names apart. When names share a key, e.g. "Service_A" and "Service-A" for a key ignoring case and
+// separators, the first of them in sorted order keeps it and the others get a suffix hashed from the name,
+// so the keys don't depend on the order of the names.
+func UniqueKeys(names []string, key func(string) string) map[string]string {
+	sorted := slices.Compact(slices.Sorted(slices.Values(names)))
+
+	keys := make(map[string]string, len(sorted))
+	taken := make(map[string]struct{}, len(sorted))
+
+	var clashing []string
+
+	for _, name := range sorted {
+		k := key(name)
+		if _, ok := taken[k]; ok {
+			clashing = append(clashing, name)
+
+			continue
+		}
+
+		taken[k] = struct{}{}
+		keys[name] = k
+	}
+
+	// Suffixed keys are assigned after all plain keys, so a suffixed key never takes the plain key of a name.
+	for _, name := range clashing {
+		hash := fnv.New32a()
+		hash.Write([]byte(name))
+
+		base := fmt.Sprintf("%s-%08x", key(name), hash.Sum32())
+
+		k := base
+		for i := 2; ; i++ {
+			if _, ok := taken[k]; !ok {
+				break
+			}
+			k = fmt.Sprintf("%s-%d", base, i)
+		}
+
+		taken[k] = struct{}{}
+		keys[name] = k
+	}
+
+	return keys
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"fmt"
+	"testing"
+)
+
+// benchmarkSchemas builds declarations of services split across schemas, the way specifications of a large
+// organization arrive: every service is declared by its ServiceFile and again by its AsyncAPI document.
+func benchmarkSchemas(services, relationships int) []Schema {
+	serviceFiles := Schema{Services: make([]Service, 0, services)}
+	asyncAPIs := Schema{Services: make([]Service, 0, services)}
+
+	for i := range services {
+		name := fmt.Sprintf("Service %05d", i)
+
+		rels := make([]Relationship, 0, relationships)
+		for j := range relationships {
+			rels = append(rels, Relationship{
+				Action:      RelationshipActionRequests,
+				Participant: fmt.Sprintf("Service %05d", (i+j+1)%services),
+				Technology:  "grpc",
+				Description: "Requests data",
+				Tags:        []string{"sync"},
+			})
+		}
+
+		serviceFiles.Services = append(serviceFiles.Services, Service{
+			Info:          ServiceInfo{Name: name, System: fmt.Sprintf("System %02d", i%20), Tags: []string{"team"}},
+			Relationships: rels,
+		})
+
+		asyncAPIs.Services = append(asyncAPIs.Services, Service{
+			Info: ServiceInfo{Name: name, Description: "Service emitting events"},
+			Operation: []Operation{
+				{Action: ActionSend, Channel: Channel{Name: name + ".events", Message: Message{Name: "Event"}}},
+				{Action: ActionReceive, Channel: Channel{Name: fmt.Sprintf("Service %05d.events", (i+1)%services)}},
+			},
+		})
+	}
+
+	return []Schema{serviceFiles, asyncAPIs}
+}
+
+func BenchmarkMergeSchemas(b *testing.B) {
+	for _, size := range []struct{ services, relationships int }{{100, 10}, {1000, 10}, {2000, 20}} {
+		schemas := benchmarkSchemas(size.services, size.relationships)
+
+		b.Run(fmt.Sprintf("services=%d/relationships=%d", size.services, size.relationships), func(b *testing.B) {
+			b.ReportAllocs()
+
+			for range b.N {
+				MergeSchemas(schemas...)
+			}
+		})
+	}
+}
+
+func BenchmarkSchemaCompare(b *testing.B) {
+	for _, size := range []struct{ services, relationships int }{{100, 10}, {1000, 10}, {2000, 20}} {
+		oldSchema := MergeSchemas(benchmarkSchemas(size.services, size.relationships)...)
+		newSchema := MergeSchemas(benchmarkSchemas(size.services+size.services/10, size.relationships)...)
+
+		b.Run(fmt.Sprintf("services=%d/relationships=%d", size.services, size.relationships), func(b *testing.B) {
+			b.ReportAllocs()
+
+			for range b.N {
+				oldSchema.Compare(newSchema)
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -1636,6 +1638,7 @@ func TestApp_MergeSchemas_DuplicateRelationships(t *testing.T) {
 						Participant: "Database",
 						Technology:  "PostgreSQL",
 						Description: "Updated description (longer)",
+						Notes:       "Shared cluster managed by the platform team",
 					},
 				},
 			},
@@ -1646,6 +1649,210 @@ func TestApp_MergeSchemas_DuplicateRelationships(t *testing.T) {
 	assert.Len(t, result.Services, 1)
 	assert.Len(t, result.Services[0].Relationships, 1)
 	assert.Equal(t, "Updated description (longer)", result.Services[0].Relationships[0].Description)
+	assert.Equal(t, "Shared cluster managed by the platform team", result.Services[0].Relationships[0].Notes)
+}
+
+func TestApp_MergeSchemas_Planned(t *testing.T) {
+	t.Parallel()
+	schema1 := Schema{
+		Services: []Service{
+			{
+				Info: ServiceInfo{Name: "Service A", System: "Ordering"},
+				Relationships: []Relationship{
+					{Action: RelationshipActionUses, Participant: "Database"},
+				},
+			},
+		},
+	}
+	schema2 := Schema{
+		Services: []Service{
+			{
+				Info: ServiceInfo{Name: "Service A", System: "Checkout", Planned: true},
+				Relationships: []Relationship{
+					{Action: RelationshipActionUses, Participant: "Database", Planned: true},
+				},
+			},
+		},
+	}
+
+	result := MergeSchemas(schema1, schema2)
+	require.Len(t, result.Services, 1)
+	require.Len(t, result.Services[0].Relationships, 1)
+	assert.False(t, result.Services[0].Info.Planned, "actual specifications win")
+	assert.False(t, result.Services[0].Relationships[0].Planned, "actual specifications win")
+	assert.Equal(t, "Ordering", result.Services[0].Info.System)
+
+	result = MergeSchemas(schema2, schema1)
+	assert.False(t, result.Services[0].Info.Planned, "actual specifications win")
+	assert.False(t, result.Services[0].Relationships[0].Planned, "actual specifications win")
+	assert.Equal(t, "Ordering", result.Services[0].Info.System)
+
+	result = MergeSchemas(schema2, schema2)
+	assert.True(t, result.Services[0].Info.Planned)
+	assert.True(t, result.Services[0].Relationships[0].Planned)
+}
+
+func TestApp_MergeSchemas_DDD(t *testing.T) {
+	t.Parallel()
+	schema1 := Schema{
+		Services: []Service{
+			{
+				Info: ServiceInfo{Name: "Service A", System: "Ordering"},
+				Relationships: []Relationship{
+					{Action: RelationshipActionRequests, Participant: "Service B",
+						DDDPatterns: []DDDPattern{DDDPatternAnticorruptionLayer}},
+				},
+			},
+		},
+	}
+	schema2 := Schema{
+		Services: []Service{
+			{
+				Info: ServiceInfo{Name: "Service A", BoundedContext: true},
+				Relationships: []Relationship{
+					{Action: RelationshipActionRequests, Participant: "Service B",
+						DDDPatterns: []DDDPattern{DDDPatternAnticorruptionLayer, DDDPatternOpenHostService}},
+				},
+			},
+		},
+	}
+
+	result := MergeSchemas(schema1, schema2)
+	require.Len(t, result.Services, 1)
+	require.Len(t, result.Services[0].Relationships, 1)
+	assert.True(t, result.Services[0].Info.BoundedContext)
+	assert.Equal(t, []DDDPattern{DDDPatternAnticorruptionLayer, DDDPatternOpenHostService},
+		result.Services[0].Relationships[0].DDDPatterns)
+}
+
+func TestDDDPattern(t *testing.T) {
+	t.Parallel()
+
+	for _, pattern := range DDDPatterns() {
+		assert.True(t, pattern.Valid(), pattern)
+	}
+	assert.False(t, DDDPattern("big_ball_of_mud").Valid())
+	assert.Equal(t, "ACL", DDDPatternAnticorruptionLayer.Abbreviation())
+	assert.True(t, DDDPatternSharedKernel.Symmetric())
+	assert.False(t, DDDPatternConformist.Symmetric())
+}
+
+func TestApp_MergeSchemas_Risks(t *testing.T) {
+	t.Parallel()
+
+	spof := Risk{Type: RiskTypeSinglePointOfFailure, Severity: CriticalityHigh}
+	lockIn := Risk{Type: RiskTypeVendorLockIn, Severity: CriticalityMedium, Description: "Stripe only"}
+
+	result := MergeSchemas(
+		Schema{Services: []Service{{
+			Info:          ServiceInfo{Name: "Service A", Risks: []Risk{spof}},
+			Relationships: []Relationship{{Action: RelationshipActionRequests, Participant: "Stripe", Risks: []Risk{lockIn}}},
+		}}},
+		Schema{Services: []Service{{
+			Info:          ServiceInfo{Name: "Service A", Risks: []Risk{spof}},
+			Relationships: []Relationship{{Action: RelationshipActionRequests, Participant: "Stripe", Risks: []Risk{spof}}},
+		}}},
+	)
+	require.Len(t, result.Services, 1)
+	assert.Equal(t, []Risk{spof}, result.Services[0].Info.Risks)
+	require.Len(t, result.Services[0].Relationships, 1)
+	assert.Equal(t, []Risk{lockIn, spof}, result.Services[0].Relationships[0].Risks)
+
+	for _, riskType := range RiskTypes() {
+		assert.True(t, riskType.Valid(), riskType)
+	}
+	assert.False(t, RiskType("data_loss").Valid())
+}
+
+func TestApp_MergeSchemas_Deployments(t *testing.T) {
+	t.Parallel()
+
+	production := Deployment{Environment: "production", Health: "https://a.example.com/healthz"}
+	staging := Deployment{Environment: "staging", Health: "https://a.staging.example.com/healthz"}
+
+	result := MergeSchemas(
+		Schema{Services: []Service{{Info: ServiceInfo{Name: "Service A", Deployments: []Deployment{production}}}}},
+		Schema{Services: []Service{{Info: ServiceInfo{Name: "Service A", Deployments: []Deployment{
+			{Environment: "production", Health: "https://other.example.com/healthz"},
+			staging,
+		}}}}},
+	)
+	require.Len(t, result.Services, 1)
+	assert.Equal(t, []Deployment{production, staging}, result.Services[0].Info.Deployments)
+}
+
+func TestCriticality(t *testing.T) {
+	t.Parallel()
+
+	for i, criticality := range Criticalities() {
+		assert.True(t, criticality.Valid(), criticality)
+		assert.Equal(t, i+1, criticality.Weight(), criticality)
+	}
+	assert.False(t, Criticality("urgent").Valid())
+	assert.Equal(t, CriticalityHigh, MaxCriticality(CriticalityHigh, CriticalityLow))
+	assert.Equal(t, CriticalityLow, MaxCriticality("", CriticalityLow))
+
+	rel := Relationship{Action: RelationshipActionRequests, Participant: "Service B"}
+	critical := rel
+	critical.Criticality = CriticalityCritical
+
+	result := MergeSchemas(
+		Schema{Services: []Service{{Info: ServiceInfo{Name: "Service A"}, Relationships: []Relationship{critical}}}},
+		Schema{Services: []Service{{Info: ServiceInfo{Name: "Service A"}, Relationships: []Relationship{rel}}}},
+	)
+	require.Len(t, result.Services[0].Relationships, 1)
+	assert.Equal(t, CriticalityCritical, result.Services[0].Relationships[0].Criticality)
+}
+
+func TestAccess(t *testing.T) {
+	t.Parallel()
+
+	for _, access := range AccessModes() {
+		assert.True(t, access.Valid(), access)
+	}
+	assert.False(t, Access("append").Valid())
+	assert.True(t, AccessRead.Reads())
+	assert.False(t, AccessRead.Writes())
+	assert.True(t, AccessReadWrite.Writes())
+	assert.Equal(t, AccessReadWrite, CombineAccess(AccessRead, AccessWrite))
+	assert.Equal(t, AccessWrite, CombineAccess("", AccessWrite))
+	assert.Equal(t, AccessRead, CombineAccess(AccessRead, AccessRead))
+
+	rel := Relationship{Action: RelationshipActionUses, Participant: "orders-db"}
+	read, write := rel, rel
+	read.Access = AccessRead
+	write.Access = AccessWrite
+
+	result := MergeSchemas(
+		Schema{Services: []Service{{Info: ServiceInfo{Name: "Service A"}, Relationships: []Relationship{read}}}},
+		Schema{Services: []Service{{Info: ServiceInfo{Name: "Service A"}, Relationships: []Relationship{write}}}},
+	)
+	require.Len(t, result.Services[0].Relationships, 1)
+	assert.Equal(t, AccessReadWrite, result.Services[0].Relationships[0].Access)
+}
+
+func TestChannelSLAs(t *testing.T) {
+	t.Parallel()
+
+	op := Operation{Action: ActionSend, Channel: Channel{Name: "orders", Message: Message{Name: "OrderCreated"}},
+		Reply: &Channel{Name: "orders.reply"}}
+	annotated := op
+	annotated.Channel.SLA = &ChannelSLA{Throughput: "500 msg/s", DLQ: "orders.dlq"}
+	annotated.Reply = &Channel{Name: "orders.reply", SLA: &ChannelSLA{MaxLatency: "1s"}}
+
+	result := MergeSchemas(
+		Schema{Services: []Service{{Info: ServiceInfo{Name: "Service A"}, Operation: []Operation{op}}}},
+		Schema{Services: []Service{{Info: ServiceInfo{Name: "Service A"}, Operation: []Operation{annotated}}}},
+	)
+	require.Len(t, result.Services[0].Operation, 1)
+
+	assert.Equal(t, map[string]ChannelSLA{
+		"orders":       {Throughput: "500 msg/s", DLQ: "orders.dlq"},
+		"orders.reply": {MaxLatency: "1s"},
+	}, result.ChannelSLAs())
+	assert.Equal(t, map[string]struct{}{"orders": {}, "orders.reply": {}}, result.ChannelNames())
+	assert.Equal(t, ChannelSLA{Throughput: "1 msg/s", MaxLatency: "1s"},
+		ChannelSLA{Throughput: "1 msg/s"}.Merge(ChannelSLA{Throughput: "5 msg/s", MaxLatency: "1s"}))
 }
 
 func TestApp_MergeSchemas_DuplicateOperations(t *testing.T) {
@@ -1902,3 +2109,423 @@ func TestApp_SortSchema_ServiceWithNoRelationshipsOrOperations(t *testing.T) {
 	assert.Empty(t, schema.Services[0].Relationships)
 	assert.Empty(t, schema.Services[0].Operation)
 }
+
+func TestNewChangelogSummary(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, ChangelogSummary{ByType: map[ChangeType]int{}}, NewChangelogSummary(nil))
+
+	summary := NewChangelogSummary(&Changelog{Changes: []Change{
+		{Type: ChangeTypeAdded, Category: "service"},
+		{Type: ChangeTypeAdded, Category: "relationship"},
+		{Type: ChangeTypeRemoved, Category: "service"},
+	}})
+	assert.Equal(t, 3, summary.Changes)
+	assert.Equal(t, map[ChangeType]int{ChangeTypeAdded: 2, ChangeTypeRemoved: 1}, summary.ByType)
+}
+
+func TestApp_MergeSchemas_InputsNotModified(t *testing.T) {
+	t.Parallel()
+	base := Schema{
+		Services: []Service{
+			{
+				Info: ServiceInfo{Name: "Service A", Tags: make([]string, 1, 4)},
+				Relationships: []Relationship{
+					{Action: RelationshipActionUses, Participant: "Database", Tags: make([]string, 1, 4)},
+				},
+			},
+		},
+	}
+	base.Services[0].Info.Tags[0] = "core"
+	base.Services[0].Relationships[0].Tags[0] = "sql"
+
+	incoming := Schema{
+		Services: []Service{
+			{
+				Info: ServiceInfo{Name: "Service A", Tags: []string{"billing"}},
+				Relationships: []Relationship{
+					{Action: RelationshipActionUses, Participant: "Database", Tags: []string{"primary"}},
+					{Action: RelationshipActionUses, Participant: "Cache"},
+				},
+			},
+		},
+	}
+
+	result := MergeSchemas(base, incoming)
+	require.Len(t, result.Services, 1)
+	assert.Equal(t, []string{"core", "billing"}, result.Services[0].Info.Tags)
+	assert.Len(t, result.Services[0].Relationships, 2)
+
+	assert.Equal(t, []string{"core"}, base.Services[0].Info.Tags)
+	assert.Equal(t, []string{"sql"}, base.Services[0].Relationships[0].Tags)
+	assert.Len(t, base.Services[0].Relationships, 1)
+	assert.Equal(t, []string{"core", ""}, base.Services[0].Info.Tags[:2], "Spare capacity is not written to")
+}
+
+func TestApp_MergeSchemas_ManyDeclarations(t *testing.T) {
+	t.Parallel()
+
+	const declarations = 2000
+
+	schemas := make([]Schema, 0, declarations)
+	for i := range declarations {
+		schemas = append(schemas, Schema{
+			Services: []Service{
+				{
+					Info: ServiceInfo{Name: "Gateway"},
+					Relationships: []Relationship{
+						{Action: RelationshipActionRequests, Participant: fmt.Sprintf("Service %04d", i)},
+						{Action: RelationshipActionUses, Participant: "Redis"},
+					},
+					Operation: []Operation{
+						{Action: ActionSend, Channel: Channel{Name: fmt.Sprintf("events.%04d", i%10)}},
+					},
+				},
+				{Info: ServiceInfo{Name: fmt.Sprintf("Service %04d", i)}},
+			},
+		})
+	}
+
+	result := MergeSchemas(schemas...)
+	require.Len(t, result.Services, declarations+1)
+
+	gateway := result.Services[0]
+	assert.Equal(t, "Gateway", gateway.Info.Name)
+	assert.Len(t, gateway.Relationships, declarations+1)
+	assert.Len(t, gateway.Operation, 10)
+	assert.Equal(t, "Service 0000", gateway.Relationships[0].Participant)
+	assert.Equal(t, "Redis", gateway.Relationships[declarations].Participant)
+}
+
+func TestUniqueKeys(t *testing.T) {
+	t.Parallel()
+
+	key := func(name string) string {
+		return strings.ToLower(strings.NewReplacer(" ", "-", "_", "-").Replace(name))
+	}
+
+	keys := UniqueKeys([]string{"Service-A", "Service_A", "service a", "Orders", "Service-A"}, key)
+	require.Len(t, keys, 4)
+
+	assert.Equal(t, "orders", keys["Orders"])
+	assert.Equal(t, "service-a", keys["Service-A"], "the first name in sorted order keeps the key")
+	assert.Regexp(t, `^service-a-[0-9a-f]{8}$`, keys["Service_A"])
+	assert.Regexp(t, `^service-a-[0-9a-f]{8}$`, keys["service a"])
+	assert.NotEqual(t, keys["Service_A"], keys["service a"])
+
+	reordered := UniqueKeys([]string{"service a", "Orders", "Service_A", "Service-A"}, key)
+	assert.Equal(t, keys, reordered, "keys don't depend on the order of the names")
+}
+
+func TestUniqueKeys_SuffixedKeyTaken(t *testing.T) {
+	t.Parallel()
+
+	lower := UniqueKeys([]string{"A", "a"}, strings.ToLower)
+	suffixed := lower["a"]
+
+	// A name whose plain key equals the suffixed key of another name keeps it.
+	keys := UniqueKeys([]string{"A", "a", suffixed}, strings.ToLower)
+	assert.Equal(t, "a", keys["A"])
+	assert.Equal(t, suffixed, keys[suffixed])
+	assert.Equal(t, suffixed+"-2", keys["a"])
+}
+
+func TestSchema_ResolveNamespaces(t *testing.T) {
+	t.Parallel()
+
+	rel := func(participant string) []Relationship {
+		return []Relationship{{Action: RelationshipActionRequests, Participant: participant}}
+	}
+
+	schema := Schema{Services: []Service{
+		{Info: ServiceInfo{Name: "Payments/API Gateway", Namespace: "Payments"}, Relationships: rel("Ledger")},
+		{Info: ServiceInfo{Name: "Payments/Ledger", Namespace: "Payments"}, Relationships: rel("Auth")},
+		{Info: ServiceInfo{Name: "Retail/API Gateway", Namespace: "Retail"}, Relationships: rel("Auth")},
+		{Info: ServiceInfo{Name: "Retail/Auth", Namespace: "Retail"}},
+		{Info: ServiceInfo{Name: "Auth"}},
+		{Info: ServiceInfo{Name: "Storefront"}, Relationships: rel("API Gateway")},
+		{Info: ServiceInfo{Name: "Reports"}, Relationships: rel("Ledger")},
+	}}
+
+	schema.ResolveNamespaces()
+
+	participants := make(map[string]string)
+	for _, service := range schema.Services {
+		if len(service.Relationships) > 0 {
+			participants[service.Info.Name] = service.Relationships[0].Participant
+		}
+	}
+
+	assert.Equal(t, map[string]string{
+		"Payments/API Gateway": "Payments/Ledger",
+		"Payments/Ledger":      "Auth",
+		"Retail/API Gateway":   "Retail/Auth",
+		"Storefront":           "API Gateway",
+		"Reports":              "Payments/Ledger",
+	}, participants)
+}
+
+func TestSchema_CanonicalizeTechnologies(t *testing.T) {
+	t.Parallel()
+
+	schema := Schema{Services: []Service{{
+		Info: ServiceInfo{Name: "Orders"},
+		Relationships: []Relationship{
+			{Action: RelationshipActionUses, Participant: "orders-db", Technology: "postgres"},
+			{Action: RelationshipActionUses, Participant: "orders-db", Technology: "PostgreSQL 14", Description: "Orders"},
+			{Action: RelationshipActionRequests, Participant: "Payments", Technology: "HTTP"},
+		},
+	}}}
+
+	assert.Equal(t, schema, schema.CanonicalizeTechnologies(nil))
+
+	canonical := schema.CanonicalizeTechnologies(map[string][]string{
+		"PostgreSQL": {"Postgres", "postgresql *"},
+	})
+
+	require.Len(t, canonical.Services, 1)
+	assert.Equal(t, []Relationship{
+		{Action: RelationshipActionRequests, Participant: "Payments", Technology: "HTTP"},
+		{Action: RelationshipActionUses, Participant: "orders-db", Technology: "PostgreSQL", Description: "Orders"},
+	}, canonical.Services[0].Relationships)
+	assert.Equal(t, "postgres", schema.Services[0].Relationships[0].Technology, "input is left unchanged")
+}
+
+func TestSchema_Coverage(t *testing.T) {
+	t.Parallel()
+
+	coverage := Schema{Services: []Service{
+		{Info: ServiceInfo{Name: "Orders", Owner: "team-orders", Description: "Handles orders"}},
+		{Info: ServiceInfo{Name: "Payments", Owner: " ", Description: "Takes payments"}},
+		{Info: ServiceInfo{Name: "Billing"}},
+		{Info: ServiceInfo{Name: "Audit", Owner: "team-audit"}},
+	}}.Coverage()
+
+	assert.Equal(t, Coverage{
+		Services:            4,
+		MissingOwners:       []string{"Billing", "Payments"},
+		MissingDescriptions: []string{"Audit", "Billing"},
+	}, coverage)
+	assert.InDelta(t, 50, coverage.OwnerCoverage(), 0.001)
+	assert.InDelta(t, 50, coverage.DescriptionCoverage(), 0.001)
+	assert.InDelta(t, 100, Schema{}.Coverage().OwnerCoverage(), 0.001, "no services are fully covered")
+}
+
+func TestChange_Services(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"Orders"}, Change{Category: "service", Name: "Orders"}.Services())
+	assert.Equal(t, []string{"Orders", "Payments"},
+		Change{Category: "relationship", Name: "Orders:requests|Payments|HTTP|"}.Services())
+	assert.Equal(t, []string{"Orders"}, Change{Category: "operation", Name: "Orders:send:orders.created"}.Services())
+}
+
+func TestChangelog_Attribute(t *testing.T) {
+	t.Parallel()
+
+	changelog := Changelog{Changes: []Change{
+		{Type: ChangeTypeAdded, Category: "relationship", Name: "Orders:requests|Payments|HTTP|",
+			Details: "'requests' relationship to 'Payments' using 'HTTP' was added to service 'Orders'"},
+		{Type: ChangeTypeRemoved, Category: "service", Name: "Legacy", Details: "'Legacy' was removed"},
+		{Type: ChangeTypeBaseline, Category: "changelog", Name: "Orders", Details: "12 changes squashed"},
+	}}
+	commits := map[string]Commit{"Orders": {Author: "Jane Doe", Subject: "Charge orders on checkout"}}
+
+	assert.Equal(t, changelog, changelog.Attribute(nil))
+
+	attributed := changelog.Attribute(commits)
+	assert.Equal(t, "'requests' relationship to 'Payments' using 'HTTP' was added to service 'Orders' "+
+		`(last commit by Jane Doe: "Charge orders on checkout")`, attributed.Changes[0].Details)
+	assert.Equal(t, "'Legacy' was removed", attributed.Changes[1].Details, "services without commits are left")
+	assert.Equal(t, "12 changes squashed", attributed.Changes[2].Details, "baselines are left")
+	assert.Equal(t, "'Legacy' was removed", changelog.Changes[1].Details)
+	assert.NotEqual(t, attributed.Changes[0].Details, changelog.Changes[0].Details, "the changelog is copied")
+}
+
+func TestSchema_AliasExternalParticipants(t *testing.T) {
+	t.Parallel()
+
+	schema := Schema{Services: []Service{
+		{
+			Info: ServiceInfo{Name: "Checkout"},
+			Relationships: []Relationship{
+				{Action: RelationshipActionRequests, Participant: "Stripe API", Technology: "HTTP", External: true},
+				{Action: RelationshipActionRequests, Participant: "stripe", Technology: "HTTP", External: true},
+			},
+		},
+		{
+			Info: ServiceInfo{Name: "Billing"},
+			Relationships: []Relationship{
+				{Action: RelationshipActionRequests, Participant: "Stripe API", Technology: "HTTP"},
+			},
+		},
+	}}
+
+	assert.Equal(t, schema, schema.AliasExternalParticipants(nil))
+
+	aliased := schema.AliasExternalParticipants(map[string][]string{"Stripe": {"Stripe API", "stripe"}})
+
+	require.Len(t, aliased.Services, 2)
+	assert.Equal(t, []Relationship{
+		{Action: RelationshipActionRequests, Participant: "Stripe API", Technology: "HTTP"},
+	}, aliased.Services[0].Relationships, "relationships toward services keep their participant")
+	assert.Equal(t, []Relationship{
+		{Action: RelationshipActionRequests, Participant: "Stripe", Technology: "HTTP", External: true},
+	}, aliased.Services[1].Relationships)
+}
+
+func TestSchema_FilterNamespaces(t *testing.T) {
+	t.Parallel()
+
+	schema := Schema{Services: []Service{
+		{Info: ServiceInfo{Name: "Payments/API Gateway", Namespace: "Payments"}},
+		{Info: ServiceInfo{Name: "Retail/API Gateway", Namespace: "Retail"}},
+		{Info: ServiceInfo{Name: "Auth"}},
+	}}
+
+	assert.Equal(t, schema, schema.FilterNamespaces(nil))
+
+	filtered := schema.FilterNamespaces([]string{"Retail"})
+	require.Len(t, filtered.Services, 2)
+	assert.Equal(t, "Retail/API Gateway", filtered.Services[0].Info.Name)
+	assert.Equal(t, "Auth", filtered.Services[1].Info.Name)
+	assert.Len(t, schema.Services, 3)
+}
+
+func TestSchema_ByBusinessCapability(t *testing.T) {
+	t.Parallel()
+
+	schema := Schema{Services: []Service{
+		{Info: ServiceInfo{Name: "Checkout", System: "Storefront", BusinessCapability: "Payments", BoundedContext: true}},
+		{Info: ServiceInfo{Name: "Ledger", System: "Finance", BusinessCapability: " Payments "}},
+		{Info: ServiceInfo{Name: "Login", System: "Storefront", BusinessCapability: "Identity"}},
+		{Info: ServiceInfo{Name: "Mailer", System: "Messaging"}},
+	}}
+
+	assert.Equal(t, []string{"Identity", "Payments"}, schema.BusinessCapabilities())
+
+	grouped := schema.ByBusinessCapability()
+	require.Len(t, grouped.Services, 4)
+	assert.Equal(t, "Payments", grouped.Services[0].Info.System)
+	assert.False(t, grouped.Services[0].Info.BoundedContext)
+	assert.Equal(t, "Payments", grouped.Services[1].Info.System)
+	assert.Equal(t, "Identity", grouped.Services[2].Info.System)
+	assert.Empty(t, grouped.Services[3].Info.System)
+	assert.Equal(t, "Storefront", schema.Services[0].Info.System)
+}
+
+func TestSchema_Merge_BusinessCapability(t *testing.T) {
+	t.Parallel()
+
+	merged := Schema{Services: []Service{{Info: ServiceInfo{Name: "Checkout"}}}}.Merge(
+		Schema{Services: []Service{{Info: ServiceInfo{Name: "Checkout", BusinessCapability: "Payments"}}}},
+		Schema{Services: []Service{{Info: ServiceInfo{Name: "Checkout", BusinessCapability: "Billing"}}}},
+	)
+
+	require.Len(t, merged.Services, 1)
+	assert.Equal(t, "Payments", merged.Services[0].Info.BusinessCapability)
+}
+
+func TestExplainMerge(t *testing.T) {
+	t.Parallel()
+
+	declarations := []Declaration{
+		{Source: "orders.servicefile.yaml", Schema: Schema{Services: []Service{{
+			Info: ServiceInfo{Name: "Orders", Description: "Handles orders", Owner: "team-orders", Tags: []string{"core"}},
+			Relationships: []Relationship{
+				{Action: RelationshipActionUses, Participant: "PostgreSQL", Technology: "SQL", Description: "Stores"},
+			},
+		}}}},
+		{Source: "orders.asyncapi.yaml", Schema: Schema{Services: []Service{{
+			Info:      ServiceInfo{Name: "Orders", Description: "Orders", Tags: []string{"core", "events"}},
+			Operation: []Operation{{Action: ActionSend, Channel: Channel{Name: "orders.created"}}},
+		}}}},
+		{Source: "input.relationships", Schema: Schema{Services: []Service{{
+			Info: ServiceInfo{Name: "Orders"},
+			Relationships: []Relationship{
+				{Action: RelationshipActionUses, Participant: "PostgreSQL", Technology: "SQL", Description: "Database"},
+			},
+		}}}},
+		{Source: "payments.servicefile.yaml", Schema: Schema{Services: []Service{{Info: ServiceInfo{Name: "Payments"}}}}},
+	}
+
+	provenance := ExplainMerge(declarations, nil)
+	require.Len(t, provenance, 2)
+
+	assert.Equal(t, ServiceProvenance{
+		Service: "Orders",
+		Sources: []string{"orders.servicefile.yaml", "orders.asyncapi.yaml", "input.relationships"},
+		Fields: []FieldProvenance{
+			{
+				Field:      "description",
+				Value:      "Handles orders",
+				Source:     "orders.servicefile.yaml",
+				Overridden: []SourcedValue{{Value: "Orders", Source: "orders.asyncapi.yaml"}},
+			},
+			{Field: "owner", Value: "team-orders", Source: "orders.servicefile.yaml"},
+		},
+		Tags: []ItemProvenance{
+			{Item: "core", Sources: []string{"orders.servicefile.yaml", "orders.asyncapi.yaml"}},
+			{Item: "events", Sources: []string{"orders.asyncapi.yaml"}},
+		},
+		Relationships: []ItemProvenance{{
+			Item:    "uses PostgreSQL via SQL",
+			Sources: []string{"orders.servicefile.yaml", "input.relationships"},
+			Fields: []FieldProvenance{{
+				Field:      "description",
+				Value:      "Database",
+				Source:     "input.relationships",
+				Overridden: []SourcedValue{{Value: "Stores", Source: "orders.servicefile.yaml"}},
+			}},
+		}},
+		Operations: []ItemProvenance{{Item: "send orders.created", Sources: []string{"orders.asyncapi.yaml"}}},
+	}, provenance[0])
+
+	assert.Equal(t, ServiceProvenance{Service: "Payments", Sources: []string{"payments.servicefile.yaml"}},
+		provenance[1])
+}
+
+func TestMergeDeclarations_Precedence(t *testing.T) {
+	t.Parallel()
+
+	declarations := []Declaration{
+		{Source: "orders.asyncapi.yaml", Kind: SourceKindAsyncAPI, Schema: Schema{Services: []Service{{
+			Info: ServiceInfo{Name: "Orders", Description: "Orders", Owner: "async-team"},
+		}}}},
+		{Source: "orders.servicefile.yaml", Kind: SourceKindServiceFile, Schema: Schema{Services: []Service{{
+			Info: ServiceInfo{Name: "Orders", Description: "Handles the lifecycle of orders", Owner: "team-orders"},
+		}}}},
+		{Source: "orders-v2.asyncapi.yaml", Kind: SourceKindAsyncAPI, Schema: Schema{Services: []Service{{
+			Info: ServiceInfo{Name: "Orders", Description: "Order events", System: "Shop"},
+		}}}},
+	}
+
+	merged := MergeDeclarations(declarations, nil)
+	assert.Equal(t, "Handles the lifecycle of orders", merged.Services[0].Info.Description, "longest wins by default")
+	assert.Equal(t, "async-team", merged.Services[0].Info.Owner, "first wins by default")
+
+	precedence := Precedence{
+		"description": {SourceKindAsyncAPI, SourceKindServiceFile},
+		"owner":       {SourceKindServiceFile},
+		"system":      {SourceKindServiceFile},
+	}
+
+	merged = MergeDeclarations(declarations, precedence)
+	assert.Equal(t, "Order events", merged.Services[0].Info.Description,
+		"AsyncAPI beats ServiceFile, the longest AsyncAPI description wins")
+	assert.Equal(t, "team-orders", merged.Services[0].Info.Owner)
+	assert.Equal(t, "Shop", merged.Services[0].Info.System, "values of unlisted types are taken when nothing else is set")
+
+	provenance := ExplainMerge(declarations, precedence)
+	assert.Equal(t, FieldProvenance{
+		Field:  "owner",
+		Value:  "team-orders",
+		Source: "orders.servicefile.yaml",
+		Overridden: []SourcedValue{
+			{Value: "async-team", Source: "orders.asyncapi.yaml"},
+		},
+	}, provenance[0].Fields[2])
+
+	assert.Equal(t, []string{"business_capability", "classification", "description", "owner", "repository",
+		"subpath", "sunset_date", "system"}, PrecedenceFields())
+}
@@ -0,0 +1,282 @@
+// Package jsonschema generates JSON Schema documents from Go types using reflection.
+// It supports the subset of Go types used by holydocs models: structs, slices, maps,
+// pointers, strings, booleans, numbers and time.Time.
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Draft is the JSON Schema dialect of generated schemas.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema document or subschema.
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	ID                   string             `json:"$id,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Default              any                `json:"default,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+}
+
+// Options configure how Go types are reflected into a schema.
+type Options struct {
+	// TagName is the struct tag used for property names, e.g. "json" or "yaml".
+	TagName string
+	// RequireNonOmitEmpty marks fields without omitempty as required.
+	RequireNonOmitEmpty bool
+	// Enums lists allowed values for named types.
+	Enums map[reflect.Type][]any
+}
+
+type reflector struct {
+	opts Options
+	defs map[string]*Schema
+	// names are the $defs names of the named struct types reflected so far.
+	names map[reflect.Type]string
+}
+
+// Generate reflects the type of v into a standalone JSON Schema document.
+// Named struct types are placed into $defs under their package and type name, e.g. "domain.Service", and
+// referenced from their usages. Anonymous struct types are inlined.
+func Generate(v any, id, title string, opts Options) *Schema {
+	if opts.TagName == "" {
+		opts.TagName = "json"
+	}
+
+	r := &reflector{opts: opts, defs: make(map[string]*Schema), names: make(map[reflect.Type]string)}
+	t := indirect(reflect.TypeOf(v))
+
+	root := r.structSchema(t)
+	root.Schema = Draft
+	root.ID = id
+	root.Title = title
+
+	if len(r.defs) > 0 {
+		root.Defs = r.defs
+	}
+
+	return root
+}
+
+// Marshal returns the indented JSON encoding of the schema followed by a newline.
+func (s *Schema) Marshal() ([]byte, error) {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshaling schema: %w", err)
+	}
+
+	return append(data, '\n'), nil
+}
+
+//nolint:gochecknoglobals // Type constant used for reflection.
+var timeType = reflect.TypeOf(time.Time{})
+
+func (r *reflector) typeSchema(t reflect.Type) *Schema {
+	t = indirect(t)
+
+	if values, ok := r.opts.Enums[t]; ok {
+		return &Schema{Type: jsonType(t.Kind()), Enum: values}
+	}
+
+	if t == timeType {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		return r.structRef(t)
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+
+		return &Schema{Type: "array", Items: r.typeSchema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: r.typeSchema(t.Elem())}
+	case reflect.Interface:
+		return &Schema{}
+	default:
+		return &Schema{Type: jsonType(t.Kind())}
+	}
+}
+
+func (r *reflector) structRef(t reflect.Type) *Schema {
+	if t.Name() == "" {
+		return r.structSchema(t)
+	}
+
+	name, exists := r.names[t]
+	if !exists {
+		name = r.defName(t)
+		r.names[t] = name
+		// Reserve the name first so recursive types terminate.
+		r.defs[name] = &Schema{}
+		*r.defs[name] = *r.structSchema(t)
+	}
+
+	return &Schema{Ref: "#/$defs/" + name}
+}
+
+// defName names the definition of a type after the last element of its package path and its name. Types of
+// packages sharing that element get a number appended, e.g. "docs.Metadata_2".
+func (r *reflector) defName(t reflect.Type) string {
+	pkg := t.PkgPath()[strings.LastIndex(t.PkgPath(), "/")+1:]
+
+	// Names of generic types hold the package paths of their type arguments, e.g. "Page[example.com/a.Item]".
+	base := strings.Map(func(c rune) rune {
+		if c == '.' || c == '_' || c == '-' || unicode.IsLetter(c) || unicode.IsDigit(c) {
+			return c
+		}
+
+		return '_'
+	}, pkg+"."+t.Name())
+
+	name := base
+	for i := 2; ; i++ {
+		if _, taken := r.defs[name]; !taken {
+			return name
+		}
+
+		name = base + "_" + strconv.Itoa(i)
+	}
+}
+
+func (r *reflector) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	r.addFields(schema, t)
+
+	return schema
+}
+
+func (r *reflector) addFields(schema *Schema, t reflect.Type) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, omitEmpty, skip := r.fieldName(field)
+		if skip {
+			continue
+		}
+
+		if field.Anonymous && name == "" && indirect(field.Type).Kind() == reflect.Struct {
+			r.addFields(schema, indirect(field.Type))
+
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		property := r.typeSchema(field.Type)
+		if usage := field.Tag.Get("usage"); usage != "" {
+			property = withDescription(property, usage)
+		}
+		if def, ok := field.Tag.Lookup("default"); ok {
+			property.Default = parseDefault(def, indirect(field.Type).Kind())
+		}
+
+		schema.Properties[name] = property
+
+		if r.opts.RequireNonOmitEmpty && !omitEmpty {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+func (r *reflector) fieldName(field reflect.StructField) (string, bool, bool) {
+	tag, ok := field.Tag.Lookup(r.opts.TagName)
+	if !ok {
+		return "", false, false
+	}
+
+	parts := strings.Split(tag, ",")
+	if parts[0] == "-" {
+		return "", false, true
+	}
+
+	omitEmpty := false
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" {
+			omitEmpty = true
+		}
+	}
+
+	return parts[0], omitEmpty, false
+}
+
+// withDescription attaches a description, wrapping references since $ref siblings are
+// only honored by newer drafts and some editors ignore them.
+func withDescription(schema *Schema, description string) *Schema {
+	if schema.Ref != "" {
+		return &Schema{Ref: schema.Ref, Description: description}
+	}
+
+	schema.Description = description
+
+	return schema
+}
+
+func parseDefault(value string, kind reflect.Kind) any {
+	switch kind {
+	case reflect.Bool:
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return n
+		}
+	case reflect.Float32, reflect.Float64:
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	case reflect.String:
+		return value
+	default:
+	}
+
+	return nil
+}
+
+func jsonType(kind reflect.Kind) string {
+	switch kind {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		return "string"
+	default:
+		return ""
+	}
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t
+}
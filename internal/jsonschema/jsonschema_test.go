@@ -0,0 +1,95 @@
+package jsonschema
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testKind string
+
+type testChild struct {
+	Name string `json:"name"`
+}
+
+type testRoot struct {
+	ID       string               `json:"id"`
+	Kind     testKind             `json:"kind,omitempty"`
+	Created  time.Time            `json:"created"`
+	Children []testChild          `json:"children,omitempty"`
+	Labels   map[string]string    `json:"labels,omitempty"`
+	Parent   *testChild           `json:"parent,omitempty"`
+	ByName   map[string]testChild `json:"by_name,omitempty"`
+	Port     int                  `json:"port,omitempty" default:"8080" usage:"Port to listen on"`
+	Ignored  string               `json:"-"`
+}
+
+// URL shares its name with url.URL.
+type URL struct {
+	Link string `json:"link"`
+}
+
+type testPage[T any] struct {
+	Items []T `json:"items"`
+}
+
+type testLinks struct {
+	Docs     URL                 `json:"docs"`
+	Upstream url.URL             `json:"upstream"`
+	Pages    testPage[testChild] `json:"pages"`
+	Contact  struct {
+		Email string `json:"email"`
+	} `json:"contact"`
+}
+
+func TestGenerate_DefNames(t *testing.T) {
+	t.Parallel()
+
+	schema := Generate(testLinks{}, "https://example.com/links.json", "Links", Options{})
+
+	assert.Equal(t, "#/$defs/jsonschema.URL", schema.Properties["docs"].Ref)
+	assert.Equal(t, "#/$defs/url.URL", schema.Properties["upstream"].Ref)
+	assert.Equal(t, "string", schema.Defs["jsonschema.URL"].Properties["link"].Type)
+	assert.Contains(t, schema.Defs["url.URL"].Properties, "Host")
+
+	page := strings.TrimPrefix(schema.Properties["pages"].Ref, "#/$defs/")
+	assert.Regexp(t, `^jsonschema\.testPage_[A-Za-z0-9._-]+_$`, page)
+	assert.Equal(t, "#/$defs/jsonschema.testChild", schema.Defs[page].Properties["items"].Items.Ref)
+
+	assert.Empty(t, schema.Properties["contact"].Ref)
+	assert.Equal(t, "string", schema.Properties["contact"].Properties["email"].Type)
+}
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	schema := Generate(testRoot{}, "https://example.com/root.json", "Root", Options{
+		RequireNonOmitEmpty: true,
+		Enums:               map[reflect.Type][]any{reflect.TypeOf(testKind("")): {"a", "b"}},
+	})
+
+	assert.Equal(t, Draft, schema.Schema)
+	assert.Equal(t, "https://example.com/root.json", schema.ID)
+	assert.Equal(t, []string{"id", "created"}, schema.Required)
+	assert.NotContains(t, schema.Properties, "Ignored")
+
+	assert.Equal(t, []any{"a", "b"}, schema.Properties["kind"].Enum)
+	assert.Equal(t, "date-time", schema.Properties["created"].Format)
+	assert.Equal(t, "#/$defs/jsonschema.testChild", schema.Properties["children"].Items.Ref)
+	assert.Equal(t, "#/$defs/jsonschema.testChild", schema.Properties["parent"].Ref)
+	assert.Equal(t, "string", schema.Properties["labels"].AdditionalProperties.Type)
+	assert.Equal(t, int64(8080), schema.Properties["port"].Default)
+	assert.Equal(t, "Port to listen on", schema.Properties["port"].Description)
+
+	require.Contains(t, schema.Defs, "jsonschema.testChild")
+	assert.Equal(t, []string{"name"}, schema.Defs["jsonschema.testChild"].Required)
+
+	data, err := schema.Marshal()
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"$defs"`)
+}
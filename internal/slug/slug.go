@@ -0,0 +1,119 @@
+// Package slug turns names of systems, services and channels into the ASCII slugs used for anchors,
+// diagram node IDs and file names.
+package slug
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// maxFilenameLength keeps output paths below the 260 characters Windows allows by default, leaving room for
+// the output directory and the suffixes of diagram file names.
+const maxFilenameLength = 64
+
+//nolint:gochecknoglobals // Device names Windows reserves as file names, regardless of the extension.
+var windowsReservedNames = map[string]struct{}{
+	"con": {}, "prn": {}, "aux": {}, "nul": {},
+	"com1": {}, "com2": {}, "com3": {}, "com4": {}, "com5": {}, "com6": {}, "com7": {}, "com8": {}, "com9": {},
+	"lpt1": {}, "lpt2": {}, "lpt3": {}, "lpt4": {}, "lpt5": {}, "lpt6": {}, "lpt7": {}, "lpt8": {}, "lpt9": {},
+}
+
+//nolint:gochecknoglobals // Transliterations of letters that don't decompose into ASCII letters and accents.
+var transliterations = map[rune]string{
+	// Latin
+	'ß': "ss", 'æ': "ae", 'œ': "oe", 'ø': "o", 'ł': "l", 'đ': "d", 'ð': "d", 'þ': "th", 'ı': "i",
+	// Cyrillic
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'ґ': "g", 'д': "d", 'е': "e", 'ё': "e", 'є': "ye", 'ж': "zh",
+	'з': "z", 'и': "i", 'і': "i", 'ї': "yi", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ў': "u", 'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch",
+	'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+	// Greek
+	'α': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'ζ': "z", 'η': "i", 'θ': "th", 'ι': "i", 'κ': "k",
+	'λ': "l", 'μ': "m", 'ν': "n", 'ξ': "x", 'ο': "o", 'π': "p", 'ρ': "r", 'σ': "s", 'ς': "s", 'τ': "t",
+	'υ': "y", 'φ': "f", 'χ': "ch", 'ψ': "ps", 'ω': "o",
+}
+
+// Make returns the slug of a name: lowercase ASCII letters and digits, with spaces and underscores turned into
+// hyphens and other punctuation dropped, so "Order Service (v2)" becomes "order-service-v2". Accents are
+// removed and Cyrillic and Greek letters are transliterated. Letters of other scripts can't be spelled in
+// ASCII, so names containing them, like names without any letter or digit, get a hash of the name appended
+// instead, keeping different names apart. It returns an empty string for a blank name.
+func Make(name string) string {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ""
+	}
+
+	var b strings.Builder
+
+	lossy := false
+
+	for _, r := range norm.NFC.String(strings.ToLower(name)) {
+		// Precomposed letters are looked up first, "й" is spelled "y" rather than as "и" without the breve.
+		if transliteration, ok := transliterations[r]; ok {
+			b.WriteString(transliteration)
+
+			continue
+		}
+
+		for _, d := range norm.NFKD.String(string(r)) {
+			transliteration, ok := transliterations[d]
+
+			switch {
+			case ok:
+				b.WriteString(transliteration)
+			case d < utf8.RuneSelf && (unicode.IsLetter(d) || unicode.IsDigit(d) || d == '-'):
+				b.WriteRune(unicode.ToLower(d))
+			case unicode.IsSpace(d) || d == '_':
+				b.WriteByte('-')
+			case unicode.Is(unicode.Mn, d):
+				// Accents of decomposed letters are dropped, "é" becomes "e".
+			case unicode.IsLetter(d) || unicode.IsNumber(d):
+				lossy = true
+			}
+		}
+	}
+
+	slug := strings.Trim(strings.ReplaceAll(b.String(), "--", "-"), "-")
+
+	switch {
+	case slug == "":
+		return Hash(name)
+	case lossy:
+		return slug + "-" + Hash(name)
+	default:
+		return slug
+	}
+}
+
+// Filename returns the slug of a name for use in file names that check out on every platform. Slugs that are
+// device names reserved on Windows, such as "con", get a hash of the name appended, and slugs longer than
+// 64 characters are shortened and end with the hash. Names differing only in case share the slug and are
+// expected to be told apart by the caller, as on case-insensitive file systems they would share the file.
+func Filename(name string) string {
+	filename := Make(name)
+
+	if len(filename) > maxFilenameLength {
+		hash := Hash(name)
+		filename = strings.TrimRight(filename[:maxFilenameLength-len(hash)-1], "-") + "-" + hash
+	}
+
+	if _, reserved := windowsReservedNames[filename]; reserved {
+		filename += "-" + Hash(name)
+	}
+
+	return filename
+}
+
+// Hash returns a short hash of a name, used where a name can't be spelled in a slug.
+func Hash(name string) string {
+	hash := fnv.New32a()
+	hash.Write([]byte(strings.TrimSpace(name)))
+
+	return fmt.Sprintf("%08x", hash.Sum32())
+}
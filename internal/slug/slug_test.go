@@ -0,0 +1,66 @@
+package slug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMake(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Order Service", "order-service"},
+		{"  Order_Service ", "order-service"},
+		{"Order Service (v2)", "order-service-v2"},
+		{"user.created", "usercreated"},
+		{"Café Crème", "cafe-creme"},
+		{"Straße", "strasse"},
+		{"Ｆｕｌｌ Ｗｉｄｔｈ", "full-width"},
+		{"Сервис заказов", "servis-zakazov"},
+		{"Υπηρεσία", "ypiresia"},
+		{"", ""},
+		{"   ", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, Make(tt.name))
+		})
+	}
+}
+
+func TestMake_HashFallback(t *testing.T) {
+	t.Parallel()
+
+	assert.Regexp(t, `^[0-9a-f]{8}$`, Make("注文サービス"))
+	assert.Regexp(t, `^[0-9a-f]{8}$`, Make("🚀"))
+	assert.Regexp(t, `^order-[0-9a-f]{8}$`, Make("Order 注文"))
+
+	assert.NotEqual(t, Make("注文サービス"), Make("在庫サービス"), "names of other scripts are kept apart")
+	assert.NotEqual(t, Make("Order"), Make("Order 注文"))
+	assert.Equal(t, Make("注文サービス"), Make(" 注文サービス "), "the hash ignores surrounding spaces")
+}
+
+func TestFilename(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "order-service", Filename("Order Service"))
+	assert.Equal(t, "console", Filename("Console"))
+	assert.Empty(t, Filename(""))
+
+	for _, reserved := range []string{"CON", "nul", "Com1", "LPT9"} {
+		assert.Regexp(t, `^[a-z]{3}[0-9]?-[0-9a-f]{8}$`, Filename(reserved), reserved)
+	}
+
+	long := "Customer Relationship Management Integration Gateway For Enterprise Resource Planning Systems"
+	filename := Filename(long)
+	assert.LessOrEqual(t, len(filename), maxFilenameLength)
+	assert.Regexp(t, `^customer-relationship-management-[a-z-]+[a-z]-[0-9a-f]{8}$`, filename)
+	assert.NotEqual(t, filename, Filename(long+" v2"), "shortened names are kept apart")
+}
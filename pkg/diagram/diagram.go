@@ -0,0 +1,98 @@
+// Package diagram provides programmatic generation of single HolyDOCs diagrams,
+// so they can be embedded into other documentation without generating the whole docs.
+package diagram
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/holydocs/holydocs/internal/adapters"
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core"
+	"github.com/holydocs/holydocs/internal/core/app"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	do "github.com/samber/do/v2"
+)
+
+// Type is the kind of diagram to render.
+type Type string
+
+// Diagram types.
+const (
+	Relationships Type = Type(domain.ServiceDiagramRelationships)
+	Flow          Type = Type(domain.ServiceDiagramFlow)
+	// Focus is the overview diagram limited to the neighborhood of a service or system.
+	Focus Type = Type(domain.ServiceDiagramFocus)
+)
+
+// Format is the output format of the diagram.
+type Format string
+
+// Diagram formats.
+const (
+	SVG Format = Format(domain.DiagramFormatSVG)
+	D2  Format = Format(domain.DiagramFormatD2)
+)
+
+// Errors.
+var (
+	ErrServiceNotFound  = domain.ErrServiceNotFound
+	ErrNoDiagramData    = domain.ErrNoDiagramData
+	ErrUnsupportedValue = domain.ErrUnsupportedValue
+)
+
+// Options configures diagram generation.
+type Options struct {
+	// ServiceFiles are paths to ServiceFile specifications.
+	ServiceFiles []string
+	// AsyncAPIFiles are paths to AsyncAPI specifications, required for Flow diagrams.
+	AsyncAPIFiles []string
+	// Type of the diagram, Relationships when empty.
+	Type Type
+	// Format of the output, SVG when empty.
+	Format Format
+	// Depth is the number of hops around the service or system shown in Focus diagrams.
+	Depth int
+}
+
+// GenerateServiceDiagram renders one diagram for the named service using default diagram settings.
+// Focus diagrams also accept the name of a system.
+func GenerateServiceDiagram(ctx context.Context, serviceName string, opts Options) ([]byte, error) {
+	cfg, err := config.Default()
+	if err != nil {
+		return nil, fmt.Errorf("creating configuration: %w", err)
+	}
+
+	// Parsed schemas aren't cached, a library call leaves no files in the working directory.
+	cfg.Cache.Enabled = false
+
+	injector := do.New(core.Package, adapters.SecondaryPackage)
+	do.ProvideValue(injector, cfg)
+
+	application, err := do.Invoke[*app.App](injector)
+	if err != nil {
+		return nil, fmt.Errorf("creating application: %w", err)
+	}
+
+	if opts.Type == "" {
+		opts.Type = Relationships
+	}
+
+	if opts.Format == "" {
+		opts.Format = SVG
+	}
+
+	diagram, err := application.GenerateServiceDiagram(ctx, domain.GenerateServiceDiagramRequest{
+		ServiceFilesPaths:  opts.ServiceFiles,
+		AsyncAPIFilesPaths: opts.AsyncAPIFiles,
+		Service:            serviceName,
+		Type:               domain.ServiceDiagramType(opts.Type),
+		Format:             domain.DiagramFormat(opts.Format),
+		Depth:              opts.Depth,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("generating diagram: %w", err)
+	}
+
+	return diagram, nil
+}
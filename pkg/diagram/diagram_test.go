@@ -0,0 +1,62 @@
+package diagram
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testdataDir = "../../internal/adapters/secondary/docs/testdata/"
+
+func TestGenerateServiceDiagram(t *testing.T) {
+	t.Parallel()
+
+	opts := Options{
+		ServiceFiles:  []string{testdataDir + "user.servicefile.yaml"},
+		AsyncAPIFiles: []string{testdataDir + "user.asyncapi.yaml", testdataDir + "campaign.asyncapi.yaml"},
+	}
+
+	svg, err := GenerateServiceDiagram(context.Background(), "User Service", opts)
+	require.NoError(t, err)
+	assert.Contains(t, string(svg), "<svg")
+
+	opts.Format = D2
+	script, err := GenerateServiceDiagram(context.Background(), "User Service", opts)
+	require.NoError(t, err)
+	assert.Contains(t, string(script), "service_user-service")
+
+	opts.Type = Flow
+	flow, err := GenerateServiceDiagram(context.Background(), "User Service", opts)
+	require.NoError(t, err)
+	assert.NotEmpty(t, flow)
+
+	opts.Type = Focus
+	opts.Depth = 1
+	focus, err := GenerateServiceDiagram(context.Background(), "User Service", opts)
+	require.NoError(t, err)
+	assert.Contains(t, string(focus), "user-service")
+}
+
+func TestGenerateServiceDiagram_Errors(t *testing.T) {
+	t.Parallel()
+
+	opts := Options{ServiceFiles: []string{testdataDir + "user.servicefile.yaml"}}
+
+	_, err := GenerateServiceDiagram(context.Background(), "Unknown Service", opts)
+	require.ErrorIs(t, err, ErrServiceNotFound)
+
+	opts.Type = Flow
+	_, err = GenerateServiceDiagram(context.Background(), "User Service", opts)
+	require.ErrorIs(t, err, ErrNoDiagramData)
+
+	opts.Type = Focus
+	opts.Depth = -1
+	_, err = GenerateServiceDiagram(context.Background(), "User Service", opts)
+	require.ErrorIs(t, err, ErrUnsupportedValue)
+
+	opts.Type = "sequence"
+	_, err = GenerateServiceDiagram(context.Background(), "User Service", opts)
+	require.ErrorIs(t, err, ErrUnsupportedValue)
+}
@@ -0,0 +1,78 @@
+// Package docs provides programmatic generation of HolyDOCs documentation into any filesystem, e.g. into
+// memory with outputfs.NewMemory, without touching the local filesystem.
+package docs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/holydocs/holydocs/internal/adapters"
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core"
+	"github.com/holydocs/holydocs/internal/core/app"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/holydocs/holydocs/pkg/outputfs"
+	do "github.com/samber/do/v2"
+)
+
+// Errors.
+var (
+	ErrPartialGeneration = domain.ErrPartialGeneration
+)
+
+// Options configures documentation generation.
+type Options struct {
+	// ServiceFiles are paths to ServiceFile specifications, read from the local filesystem.
+	ServiceFiles []string
+	// AsyncAPIFiles are paths to AsyncAPI specifications, read from the local filesystem.
+	AsyncAPIFiles []string
+	// OutputDir is the directory of the documentation within Output, "docs" when empty.
+	OutputDir string
+	// Output is the filesystem the documentation is written to, the local filesystem when nil.
+	Output outputfs.FS
+}
+
+// Result describes generated documentation.
+type Result struct {
+	// Warnings are problems of the specifications that didn't stop generation.
+	Warnings []string
+}
+
+// Generate generates the documentation of the specifications using default settings. The documentation
+// of a previous run in the output directory is read for the changelog, as with the gen-docs command.
+func Generate(ctx context.Context, opts Options) (Result, error) {
+	cfg, err := config.Default()
+	if err != nil {
+		return Result{}, fmt.Errorf("creating configuration: %w", err)
+	}
+
+	// Parsed schemas aren't cached, a library call leaves no files in the working directory.
+	cfg.Cache.Enabled = false
+
+	if opts.OutputDir != "" {
+		cfg.Output.Dir = opts.OutputDir
+	}
+
+	injector := do.New(core.Package, adapters.SecondaryPackage)
+	do.ProvideValue(injector, cfg)
+
+	if opts.Output != nil {
+		do.OverrideValue(injector, opts.Output)
+	}
+
+	application, err := do.Invoke[*app.App](injector)
+	if err != nil {
+		return Result{}, fmt.Errorf("creating application: %w", err)
+	}
+
+	reply, err := application.GenerateDocumentation(ctx, domain.GenerateDocumentationRequest{
+		ServiceFilesPaths:  opts.ServiceFiles,
+		AsyncAPIFilesPaths: opts.AsyncAPIFiles,
+		OutputDir:          cfg.Output.Dir,
+	})
+	if err != nil {
+		return Result{}, fmt.Errorf("generating documentation: %w", err)
+	}
+
+	return Result{Warnings: reply.Warnings}, nil
+}
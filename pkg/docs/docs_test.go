@@ -0,0 +1,37 @@
+package docs
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/holydocs/holydocs/pkg/outputfs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testdataDir = "../../internal/adapters/secondary/docs/testdata/"
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	fsys := outputfs.NewMemory()
+
+	_, err := Generate(context.Background(), Options{
+		ServiceFiles:  []string{testdataDir + "user.servicefile.yaml"},
+		AsyncAPIFiles: []string{testdataDir + "user.asyncapi.yaml", testdataDir + "campaign.asyncapi.yaml"},
+		OutputDir:     "handbook",
+		Output:        fsys,
+	})
+	require.NoError(t, err)
+
+	files := fsys.Files()
+	assert.Contains(t, string(files["handbook/README.md"]), "User Service")
+	assert.Contains(t, files, "handbook/domain.json")
+	assert.Contains(t, files, "handbook/run-report.json")
+	assert.True(t, strings.HasPrefix(string(files["handbook/diagrams/overview.svg"]), "<?xml"))
+
+	_, err = os.Stat("handbook")
+	assert.ErrorIs(t, err, os.ErrNotExist, "nothing is written to the local filesystem")
+}
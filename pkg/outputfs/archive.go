@@ -0,0 +1,172 @@
+package outputfs
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"strings"
+)
+
+// ArchiveFormat is the format of an archive of documentation.
+type ArchiveFormat string
+
+// Archive formats.
+const (
+	ArchiveZip   ArchiveFormat = "zip"
+	ArchiveTarGz ArchiveFormat = "tar.gz"
+)
+
+// ErrUnsupportedArchive is returned for archive files of unknown formats.
+var ErrUnsupportedArchive = errors.New("unsupported archive format")
+
+// ArchiveFormatOf returns the format of an archive file by its extension: .zip, .tar.gz or .tgz.
+func ArchiveFormatOf(name string) (ArchiveFormat, error) {
+	lower := strings.ToLower(name)
+
+	switch {
+	case strings.HasSuffix(lower, ".zip"):
+		return ArchiveZip, nil
+	case strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		return ArchiveTarGz, nil
+	default:
+		return "", fmt.Errorf("%w: %s, expected .zip, .tar.gz or .tgz", ErrUnsupportedArchive, name)
+	}
+}
+
+// WriteArchive packs the files below dir into an archive written to w. Paths in the archive are relative to
+// dir and use forward slashes.
+func WriteArchive(w io.Writer, fsys FS, dir string, format ArchiveFormat) error {
+	switch format {
+	case ArchiveZip:
+		return writeZip(w, fsys, dir)
+	case ArchiveTarGz:
+		return writeTarGz(w, fsys, dir)
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedArchive, format)
+	}
+}
+
+// archiveEntry is a file or directory of an archive.
+type archiveEntry struct {
+	name string
+	info fs.FileInfo
+	file string
+}
+
+// walkArchive calls fn for every file and directory below dir, leaving out dir itself.
+func walkArchive(fsys FS, dir string, fn func(archiveEntry) error) error {
+	return WalkDir(fsys, dir, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, file)
+		if err != nil || rel == "." {
+			return err
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		name := filepath.ToSlash(rel)
+		if entry.IsDir() {
+			name += "/"
+		}
+
+		return fn(archiveEntry{name: name, info: info, file: file})
+	})
+}
+
+func writeZip(w io.Writer, fsys FS, dir string) error {
+	archive := zip.NewWriter(w)
+
+	err := walkArchive(fsys, dir, func(entry archiveEntry) error {
+		header := &zip.FileHeader{Name: entry.name, Method: zip.Deflate, Modified: entry.info.ModTime()}
+		header.SetMode(entry.info.Mode())
+
+		if entry.info.IsDir() {
+			header.Method = zip.Store
+			_, err := archive.CreateHeader(header)
+
+			return err
+		}
+
+		content, err := fsys.ReadFile(entry.file)
+		if err != nil {
+			return err
+		}
+
+		writer, err := archive.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+
+		_, err = writer.Write(content)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("writing zip archive: %w", err)
+	}
+
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("writing zip archive: %w", err)
+	}
+
+	return nil
+}
+
+func writeTarGz(w io.Writer, fsys FS, dir string) error {
+	compressed := gzip.NewWriter(w)
+	archive := tar.NewWriter(compressed)
+
+	err := walkArchive(fsys, dir, func(entry archiveEntry) error {
+		header := &tar.Header{
+			Name:    entry.name,
+			Mode:    int64(entry.info.Mode().Perm()),
+			ModTime: entry.info.ModTime(),
+		}
+
+		if entry.info.IsDir() {
+			header.Typeflag = tar.TypeDir
+
+			return archive.WriteHeader(header)
+		}
+
+		content, err := fsys.ReadFile(entry.file)
+		if err != nil {
+			return err
+		}
+
+		header.Typeflag = tar.TypeReg
+		header.Size = int64(len(content))
+
+		if err := archive.WriteHeader(header); err != nil {
+			return err
+		}
+
+		_, err = archive.Write(content)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("writing tar archive: %w", err)
+	}
+
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("writing tar archive: %w", err)
+	}
+
+	if err := compressed.Close(); err != nil {
+		return fmt.Errorf("writing tar archive: %w", err)
+	}
+
+	return nil
+}
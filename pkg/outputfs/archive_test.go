@@ -0,0 +1,105 @@
+package outputfs
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testDocs(t *testing.T) *Memory {
+	t.Helper()
+
+	fsys := NewMemory()
+	require.NoError(t, fsys.MkdirAll("docs/diagrams", 0o755))
+	require.NoError(t, fsys.WriteFile("docs/README.md", []byte("# Docs"), 0o644))
+	require.NoError(t, fsys.WriteFile("docs/domain.json", []byte("{}"), 0o644))
+	require.NoError(t, fsys.WriteFile("docs/diagrams/overview.svg", []byte("<svg/>"), 0o644))
+
+	return fsys
+}
+
+func TestArchiveFormatOf(t *testing.T) {
+	t.Parallel()
+
+	for name, expected := range map[string]ArchiveFormat{
+		"docs.zip": ArchiveZip, "docs.tar.gz": ArchiveTarGz, "DOCS.TGZ": ArchiveTarGz,
+	} {
+		format, err := ArchiveFormatOf(name)
+		require.NoError(t, err)
+		assert.Equal(t, expected, format, name)
+	}
+
+	_, err := ArchiveFormatOf("docs.rar")
+	require.ErrorIs(t, err, ErrUnsupportedArchive)
+}
+
+func TestWriteArchive_Zip(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteArchive(&buf, testDocs(t), "docs", ArchiveZip))
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	files := make(map[string]string)
+	for _, file := range archive.File {
+		if file.FileInfo().IsDir() {
+			files[file.Name] = ""
+
+			continue
+		}
+
+		reader, err := file.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		files[file.Name] = string(content)
+	}
+
+	assert.Equal(t, map[string]string{
+		"README.md":             "# Docs",
+		"diagrams/":             "",
+		"diagrams/overview.svg": "<svg/>",
+		"domain.json":           "{}",
+	}, files)
+}
+
+func TestWriteArchive_TarGz(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteArchive(&buf, testDocs(t), "docs", ArchiveTarGz))
+
+	compressed, err := gzip.NewReader(&buf)
+	require.NoError(t, err)
+
+	archive := tar.NewReader(compressed)
+	files := make(map[string]string)
+
+	for {
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+
+		content, err := io.ReadAll(archive)
+		require.NoError(t, err)
+		files[header.Name] = string(content)
+	}
+
+	assert.Equal(t, map[string]string{
+		"README.md":             "# Docs",
+		"diagrams/":             "",
+		"diagrams/overview.svg": "<svg/>",
+		"domain.json":           "{}",
+	}, files)
+}
@@ -0,0 +1,274 @@
+package outputfs
+
+import (
+	"errors"
+	"io/fs"
+	"maps"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	errIsDir       = errors.New("is a directory")
+	errNotDir      = errors.New("not a directory")
+	errDirNotEmpty = errors.New("directory not empty")
+)
+
+// Memory is a filesystem held in memory. Paths are cleaned and use forward slashes, the working directory
+// "." and the root "/" always exist.
+type Memory struct {
+	mu    sync.RWMutex
+	files map[string]memoryFile
+	dirs  map[string]memoryDir
+}
+
+type memoryFile struct {
+	data    []byte
+	perm    fs.FileMode
+	modTime time.Time
+}
+
+type memoryDir struct {
+	perm    fs.FileMode
+	modTime time.Time
+}
+
+// NewMemory returns an empty filesystem held in memory.
+func NewMemory() *Memory {
+	return &Memory{
+		files: make(map[string]memoryFile),
+		dirs:  map[string]memoryDir{".": {perm: fs.ModePerm}, "/": {perm: fs.ModePerm}},
+	}
+}
+
+var _ FS = (*Memory)(nil)
+
+// Files returns the content of all files by their path.
+func (m *Memory) Files() map[string][]byte {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	files := make(map[string][]byte, len(m.files))
+	for name, file := range m.files {
+		files[name] = slices.Clone(file.data)
+	}
+
+	return files
+}
+
+// MkdirAll creates the directory and its missing parents.
+func (m *Memory) MkdirAll(name string, perm fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	name = clean(name)
+
+	var missing []string
+
+	for dir := name; ; dir = path.Dir(dir) {
+		if _, ok := m.files[dir]; ok {
+			return &fs.PathError{Op: "mkdir", Path: dir, Err: errNotDir}
+		}
+
+		if _, ok := m.dirs[dir]; ok {
+			break
+		}
+
+		missing = append(missing, dir)
+	}
+
+	for _, dir := range missing {
+		m.dirs[dir] = memoryDir{perm: perm.Perm(), modTime: time.Now()}
+	}
+
+	return nil
+}
+
+// WriteFile writes the file, its directory has to exist.
+func (m *Memory) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	name = clean(name)
+
+	if _, ok := m.dirs[name]; ok {
+		return &fs.PathError{Op: "open", Path: name, Err: errIsDir}
+	}
+
+	if _, ok := m.dirs[path.Dir(name)]; !ok {
+		return &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+
+	m.files[name] = memoryFile{data: slices.Clone(data), perm: perm.Perm(), modTime: time.Now()}
+
+	return nil
+}
+
+// ReadFile returns the content of the file.
+func (m *Memory) ReadFile(name string) ([]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	name = clean(name)
+
+	file, ok := m.files[name]
+	if !ok {
+		if _, ok := m.dirs[name]; ok {
+			return nil, &fs.PathError{Op: "read", Path: name, Err: errIsDir}
+		}
+
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+
+	return slices.Clone(file.data), nil
+}
+
+// Stat describes the file or directory.
+func (m *Memory) Stat(name string) (fs.FileInfo, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	info, ok := m.stat(clean(name))
+	if !ok {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+	}
+
+	return info, nil
+}
+
+// ReadDir returns the entries of the directory sorted by name.
+func (m *Memory) ReadDir(name string) ([]fs.DirEntry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	name = clean(name)
+
+	if _, ok := m.dirs[name]; !ok {
+		if _, ok := m.files[name]; ok {
+			return nil, &fs.PathError{Op: "readdir", Path: name, Err: errNotDir}
+		}
+
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+
+	var entries []fs.DirEntry
+
+	for _, child := range m.children(name) {
+		info, _ := m.stat(child)
+		entries = append(entries, fs.FileInfoToDirEntry(info))
+	}
+
+	return entries, nil
+}
+
+// Remove removes the file or the empty directory.
+func (m *Memory) Remove(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	name = clean(name)
+
+	if _, ok := m.files[name]; ok {
+		delete(m.files, name)
+
+		return nil
+	}
+
+	if _, ok := m.dirs[name]; !ok {
+		return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrNotExist}
+	}
+
+	if len(m.children(name)) > 0 {
+		return &fs.PathError{Op: "remove", Path: name, Err: errDirNotEmpty}
+	}
+
+	delete(m.dirs, name)
+
+	return nil
+}
+
+// RemoveAll removes the path and everything it contains.
+func (m *Memory) RemoveAll(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	name = clean(name)
+
+	for file := range m.files {
+		if within(file, name) {
+			delete(m.files, file)
+		}
+	}
+
+	for dir := range m.dirs {
+		if within(dir, name) && dir != "." && dir != "/" {
+			delete(m.dirs, dir)
+		}
+	}
+
+	return nil
+}
+
+func (m *Memory) stat(name string) (fs.FileInfo, bool) {
+	if file, ok := m.files[name]; ok {
+		return memoryInfo{name: path.Base(name), size: int64(len(file.data)), mode: file.perm, modTime: file.modTime}, true
+	}
+
+	if dir, ok := m.dirs[name]; ok {
+		return memoryInfo{name: path.Base(name), mode: fs.ModeDir | dir.perm, modTime: dir.modTime}, true
+	}
+
+	return nil, false
+}
+
+// children returns the sorted paths of the files and directories directly in the directory.
+func (m *Memory) children(dir string) []string {
+	var children []string
+
+	for name := range maps.Keys(m.files) {
+		if path.Dir(name) == dir {
+			children = append(children, name)
+		}
+	}
+
+	for name := range maps.Keys(m.dirs) {
+		if path.Dir(name) == dir && name != dir {
+			children = append(children, name)
+		}
+	}
+
+	slices.Sort(children)
+
+	return children
+}
+
+// within tells whether name is the directory dir or below it.
+func within(name, dir string) bool {
+	if dir == "." {
+		return !path.IsAbs(name)
+	}
+
+	return name == dir || strings.HasPrefix(name, strings.TrimSuffix(dir, "/")+"/")
+}
+
+func clean(name string) string {
+	return path.Clean(filepath.ToSlash(name))
+}
+
+type memoryInfo struct {
+	name    string
+	size    int64
+	mode    fs.FileMode
+	modTime time.Time
+}
+
+func (i memoryInfo) Name() string       { return i.name }
+func (i memoryInfo) Size() int64        { return i.size }
+func (i memoryInfo) Mode() fs.FileMode  { return i.mode }
+func (i memoryInfo) ModTime() time.Time { return i.modTime }
+func (i memoryInfo) IsDir() bool        { return i.mode.IsDir() }
+func (i memoryInfo) Sys() any           { return nil }
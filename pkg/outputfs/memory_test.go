@@ -0,0 +1,82 @@
+package outputfs
+
+import (
+	"io/fs"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemory(t *testing.T) {
+	t.Parallel()
+
+	fsys := NewMemory()
+
+	require.ErrorIs(t, fsys.WriteFile(filepath.Join("docs", "README.md"), []byte("# Docs"), 0o644), fs.ErrNotExist)
+
+	require.NoError(t, fsys.MkdirAll(filepath.Join("docs", "diagrams", "services"), 0o755))
+	require.NoError(t, fsys.WriteFile(filepath.Join("docs", "README.md"), []byte("# Docs"), 0o644))
+	require.NoError(t, fsys.WriteFile(filepath.Join("docs", "diagrams", "overview.svg"), []byte("<svg/>"), 0o644))
+
+	content, err := fsys.ReadFile("docs/README.md")
+	require.NoError(t, err)
+	assert.Equal(t, "# Docs", string(content))
+
+	info, err := fsys.Stat("docs/diagrams")
+	require.NoError(t, err)
+	assert.True(t, info.IsDir())
+
+	_, err = fsys.Stat("docs/missing.md")
+	require.ErrorIs(t, err, fs.ErrNotExist)
+
+	entries, err := fsys.ReadDir("docs")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "README.md", entries[0].Name())
+	assert.True(t, entries[1].IsDir())
+
+	require.Error(t, fsys.Remove("docs/diagrams"))
+	require.NoError(t, fsys.RemoveAll("docs/diagrams"))
+	require.NoError(t, fsys.RemoveAll("docs/missing"))
+
+	assert.Equal(t, map[string][]byte{"docs/README.md": []byte("# Docs")}, fsys.Files())
+
+	require.NoError(t, fsys.Remove("docs/README.md"))
+	assert.Empty(t, fsys.Files())
+}
+
+func TestWalkDir(t *testing.T) {
+	t.Parallel()
+
+	fsys := NewMemory()
+	require.NoError(t, fsys.MkdirAll("docs/diagrams/badges", 0o755))
+	require.NoError(t, fsys.MkdirAll("docs/diagrams/services", 0o755))
+
+	for _, file := range []string{"docs/README.md", "docs/diagrams/badges/a.svg", "docs/diagrams/services/b.svg"} {
+		require.NoError(t, fsys.WriteFile(file, nil, 0o644))
+	}
+
+	var visited []string
+
+	err := WalkDir(fsys, "docs", func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() && entry.Name() == "badges" {
+			return filepath.SkipDir
+		}
+
+		visited = append(visited, filepath.ToSlash(file))
+
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs", "docs/README.md", "docs/diagrams", "docs/diagrams/services",
+		"docs/diagrams/services/b.svg"}, visited)
+
+	err = WalkDir(fsys, "missing", func(_ string, _ fs.DirEntry, err error) error { return err })
+	require.ErrorIs(t, err, fs.ErrNotExist)
+}
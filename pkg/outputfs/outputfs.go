@@ -0,0 +1,101 @@
+// Package outputfs abstracts the filesystem HolyDOCs writes documentation to, so it can be generated into
+// memory, an archive or an object store instead of the local filesystem.
+//
+// Paths are the paths the generator builds from the output directory, with the separator of the operating
+// system. Implementations decide how they map them, the local filesystem uses them as they are.
+package outputfs
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// FS is the filesystem documentation is written to. Besides writing, the generator reads what it wrote and
+// what the previous run left, such as the recorded schema of the changelog.
+type FS interface {
+	MkdirAll(path string, perm fs.FileMode) error
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+	ReadFile(name string) ([]byte, error)
+	Stat(name string) (fs.FileInfo, error)
+	// ReadDir returns the entries of the directory sorted by name.
+	ReadDir(name string) ([]fs.DirEntry, error)
+	Remove(name string) error
+	// RemoveAll removes the path and everything it contains, it returns nil when the path doesn't exist.
+	RemoveAll(path string) error
+}
+
+// OS returns the local filesystem.
+func OS() FS {
+	return osFS{}
+}
+
+type osFS struct{}
+
+func (osFS) MkdirAll(path string, perm fs.FileMode) error { return os.MkdirAll(path, perm) }
+
+func (osFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	return os.WriteFile(name, data, perm)
+}
+
+func (osFS) ReadFile(name string) ([]byte, error) { return os.ReadFile(name) }
+
+func (osFS) Stat(name string) (fs.FileInfo, error) { return os.Stat(name) }
+
+func (osFS) ReadDir(name string) ([]fs.DirEntry, error) { return os.ReadDir(name) }
+
+func (osFS) Remove(name string) error { return os.Remove(name) }
+
+func (osFS) RemoveAll(path string) error { return os.RemoveAll(path) }
+
+// WalkDir walks the tree at root like filepath.WalkDir, calling fn for every file and directory in lexical
+// order.
+func WalkDir(fsys FS, root string, fn fs.WalkDirFunc) error {
+	info, err := fsys.Stat(root)
+	if err != nil {
+		err = fn(root, nil, err)
+	} else {
+		err = walkDir(fsys, root, fs.FileInfoToDirEntry(info), fn)
+	}
+
+	if errors.Is(err, filepath.SkipDir) || errors.Is(err, filepath.SkipAll) {
+		return nil
+	}
+
+	return err
+}
+
+func walkDir(fsys FS, name string, entry fs.DirEntry, fn fs.WalkDirFunc) error {
+	if err := fn(name, entry, nil); err != nil || !entry.IsDir() {
+		if errors.Is(err, filepath.SkipDir) && entry.IsDir() {
+			err = nil
+		}
+
+		return err
+	}
+
+	entries, err := fsys.ReadDir(name)
+	if err != nil {
+		// The directory is reported a second time with the error of reading it.
+		if err := fn(name, entry, err); err != nil {
+			if errors.Is(err, filepath.SkipDir) {
+				err = nil
+			}
+
+			return err
+		}
+	}
+
+	for _, child := range entries {
+		if err := walkDir(fsys, filepath.Join(name, child.Name()), child, fn); err != nil {
+			if errors.Is(err, filepath.SkipDir) {
+				break
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,153 @@
+// Package schemas publishes JSON Schemas for holydocs inputs and outputs, so editors can
+// provide completion and validation and other tools can produce compatible files.
+// The schema files are generated from Go types and embedded into the binary.
+package schemas
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	docsgen "github.com/holydocs/holydocs/internal/adapters/secondary/docs"
+	"github.com/holydocs/holydocs/internal/config"
+	"github.com/holydocs/holydocs/internal/core/domain"
+	"github.com/holydocs/holydocs/internal/jsonschema"
+)
+
+// Schema names.
+const (
+	Domain   = "domain"
+	Metadata = "metadata"
+	Config   = "config"
+	// RunReport is the run-report.json written after every documentation run.
+	RunReport = "run-report"
+)
+
+const baseURL = "https://raw.githubusercontent.com/holydocs/holydocs/main/schemas/"
+
+// Errors.
+var (
+	ErrUnknownSchema = errors.New("unknown schema")
+)
+
+//go:embed *.schema.json
+var files embed.FS
+
+// Names returns the names of all published schemas.
+func Names() []string {
+	names := []string{Domain, Metadata, Config, RunReport}
+	sort.Strings(names)
+
+	return names
+}
+
+// FileName returns the name of the embedded file holding the schema.
+func FileName(name string) string {
+	return name + ".schema.json"
+}
+
+// Get returns the embedded JSON Schema by name.
+func Get(name string) ([]byte, error) {
+	if !known(name) {
+		return nil, fmt.Errorf("%w: %s (expected one of %v)", ErrUnknownSchema, name, Names())
+	}
+
+	data, err := files.ReadFile(FileName(name))
+	if err != nil {
+		return nil, fmt.Errorf("reading schema %s: %w", name, err)
+	}
+
+	return data, nil
+}
+
+// Generate reflects the JSON Schema by name from the current Go types.
+func Generate(name string) ([]byte, error) {
+	var schema *jsonschema.Schema
+
+	id := baseURL + FileName(name)
+
+	switch name {
+	case Domain:
+		schema = jsonschema.Generate(domain.Schema{}, id, "HolyDOCs domain schema", domainOptions())
+	case Metadata:
+		schema = jsonschema.Generate(docsgen.Metadata{}, id, "HolyDOCs domain.json metadata", domainOptions())
+	case RunReport:
+		schema = jsonschema.Generate(domain.RunReport{}, id, "HolyDOCs run-report.json", domainOptions())
+	case Config:
+		schema = jsonschema.Generate(config.Config{}, id, "HolyDOCs configuration (holydocs.yaml)",
+			jsonschema.Options{TagName: "yaml"})
+	default:
+		return nil, fmt.Errorf("%w: %s (expected one of %v)", ErrUnknownSchema, name, Names())
+	}
+
+	data, err := schema.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("generating schema %s: %w", name, err)
+	}
+
+	return data, nil
+}
+
+func domainOptions() jsonschema.Options {
+	return jsonschema.Options{
+		TagName:             "json",
+		RequireNonOmitEmpty: true,
+		Enums: map[reflect.Type][]any{
+			reflect.TypeOf(domain.RelationshipAction("")): {
+				domain.RelationshipActionUses,
+				domain.RelationshipActionRequests,
+				domain.RelationshipActionReplies,
+				domain.RelationshipActionSends,
+				domain.RelationshipActionReceives,
+			},
+			reflect.TypeOf(domain.OperationAction("")): {
+				domain.ActionSend,
+				domain.ActionReceive,
+			},
+			reflect.TypeOf(domain.ChangeType("")): {
+				domain.ChangeTypeAdded,
+				domain.ChangeTypeRemoved,
+				domain.ChangeTypeChanged,
+				domain.ChangeTypeBaseline,
+			},
+			reflect.TypeOf(domain.DDDPattern("")): {
+				domain.DDDPatternPartnership,
+				domain.DDDPatternSharedKernel,
+				domain.DDDPatternCustomerSupplier,
+				domain.DDDPatternConformist,
+				domain.DDDPatternAnticorruptionLayer,
+				domain.DDDPatternOpenHostService,
+				domain.DDDPatternPublishedLanguage,
+			},
+			reflect.TypeOf(domain.RiskType("")): {
+				domain.RiskTypeSinglePointOfFailure,
+				domain.RiskTypeVendorLockIn,
+				domain.RiskTypeEOLRuntime,
+				domain.RiskTypeOther,
+			},
+			reflect.TypeOf(domain.Criticality("")): {
+				domain.CriticalityLow,
+				domain.CriticalityMedium,
+				domain.CriticalityHigh,
+				domain.CriticalityCritical,
+			},
+			reflect.TypeOf(domain.Access("")): {
+				domain.AccessRead,
+				domain.AccessWrite,
+				domain.AccessReadWrite,
+			},
+		},
+	}
+}
+
+func known(name string) bool {
+	for _, n := range Names() {
+		if n == name {
+			return true
+		}
+	}
+
+	return false
+}
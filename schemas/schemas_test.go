@@ -0,0 +1,45 @@
+package schemas
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEmbeddedSchemasUpToDate ensures the published schema files match the Go types.
+// Run with OVERWRITE_TESTDATA=true to regenerate them.
+func TestEmbeddedSchemasUpToDate(t *testing.T) {
+	overwrite := os.Getenv("OVERWRITE_TESTDATA") == "true"
+
+	for _, name := range Names() {
+		t.Run(name, func(t *testing.T) {
+			generated, err := Generate(name)
+			require.NoError(t, err)
+			require.True(t, json.Valid(generated))
+
+			if overwrite {
+				require.NoError(t, os.WriteFile(FileName(name), generated, 0o600))
+
+				return
+			}
+
+			embedded, err := Get(name)
+			require.NoError(t, err)
+			assert.Equal(t, string(generated), string(embedded),
+				"schema %s is outdated, regenerate with OVERWRITE_TESTDATA=true", name)
+		})
+	}
+}
+
+func TestGet_UnknownSchema(t *testing.T) {
+	t.Parallel()
+
+	_, err := Get("servicefile")
+	require.ErrorIs(t, err, ErrUnknownSchema)
+
+	_, err = Generate("servicefile")
+	require.ErrorIs(t, err, ErrUnknownSchema)
+}